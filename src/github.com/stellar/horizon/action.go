@@ -30,6 +30,10 @@ type Action struct {
 
 	hq *history.Q
 	cq *core.Q
+
+	// nowCursor caches the cursor that "now" is resolved to, so that it is
+	// resolved once for the life of the action.
+	nowCursor string
 }
 
 // CoreQ provides access to queries that access the stellar core database.
@@ -42,7 +46,7 @@ func (action *Action) CoreQ() *core.Q {
 }
 
 // GetPagingParams modifies the base GetPagingParams method to replace
-// cursors that are "now" with the last seen ledger's cursor.
+// cursors that are "now" with the cursor returned by NowCursor.
 func (action *Action) GetPagingParams() (cursor string, order string, limit uint64) {
 	if action.Err != nil {
 		return
@@ -51,17 +55,46 @@ func (action *Action) GetPagingParams() (cursor string, order string, limit uint
 	cursor, order, limit = action.Base.GetPagingParams()
 
 	if cursor == "now" {
-		tid := toid.ID{
-			LedgerSequence:   ledger.CurrentState().HistoryLatest,
-			TransactionOrder: toid.TransactionMask,
-			OperationOrder:   toid.OperationMask,
-		}
-		cursor = tid.String()
+		cursor = action.NowCursor()
 	}
 
 	return
 }
 
+// NowCursor returns the cursor that the special cursor value "now" stands for:
+// the greatest paging token of the latest ledger in the history database, such
+// that an ascending page starting from it is empty, and a stream starting from
+// it sends only the records ingested after it connected.  Each paging token of
+// the records of a ledger is within its range of total order ids, and so
+// precedes the token returned, whichever the endpoint.
+//
+// The cursor is resolved once per action, when first asked for, against the
+// database rather than the cached ledger state, which may trail the records
+// already ingested.
+func (action *Action) NowCursor() string {
+	if action.Err != nil {
+		return ""
+	}
+
+	if action.nowCursor != "" {
+		return action.nowCursor
+	}
+
+	var latest int32
+	action.Err = action.HistoryQ().LatestLedger(&latest)
+	if action.Err != nil {
+		return ""
+	}
+
+	tid := toid.ID{
+		LedgerSequence:   latest,
+		TransactionOrder: toid.TransactionMask,
+		OperationOrder:   toid.OperationMask,
+	}
+	action.nowCursor = tid.String()
+	return action.nowCursor
+}
+
 // GetPageQuery is a helper that returns a new db.PageQuery struct initialized
 // using the results from a call to GetPagingParams()
 func (action *Action) GetPageQuery() db2.PageQuery {
@@ -178,6 +211,19 @@ func (action *Action) EnsureHistoryFreshness() {
 	}
 }
 
+// EnsureEffectsEnabled halts processing with a feature_disabled problem when
+// effect ingestion, and therefore the effects and trades recorded by it, has
+// been disabled.
+func (action *Action) EnsureEffectsEnabled() {
+	if action.Err != nil {
+		return
+	}
+
+	if action.App.config.DisableEffectIngestion {
+		action.Err = &problem.FeatureDisabled
+	}
+}
+
 // BaseURL returns the base url for this requestion, defined as a url containing
 // the Host and Scheme portions of the request uri.
 func (action *Action) BaseURL() *url.URL {
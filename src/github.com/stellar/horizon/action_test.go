@@ -0,0 +1,72 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/toid"
+)
+
+func TestAction_CursorNow(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	const account = "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+
+	latest := toid.ID{
+		LedgerSequence:   6,
+		TransactionOrder: toid.TransactionMask,
+		OperationOrder:   toid.OperationMask,
+	}
+	now := latest.String()
+
+	// "now" is resolved against the history database, rather than the cached
+	// ledger state, which we set to trail it.
+	ls := ledger.CurrentState()
+	ht.Require.Equal(int32(6), ls.HistoryLatest)
+	ls.HistoryLatest = 5
+	ledger.SetState(ls)
+
+	endpoints := []string{
+		"/ledgers",
+		"/transactions",
+		"/accounts/" + account + "/transactions",
+		"/operations",
+		"/accounts/" + account + "/operations",
+		"/payments",
+		"/accounts/" + account + "/payments",
+		"/effects",
+		"/accounts/" + account + "/effects",
+		"/accounts/" + account + "/trades",
+	}
+
+	for _, path := range endpoints {
+		// an ascending page from now is empty, its links carrying the cursor
+		// resolved to.
+		w := ht.Get(path + "?cursor=now")
+		if !ht.Assert.Equal(200, w.Code, path) {
+			continue
+		}
+		ht.Assert.PageOf(0, w.Body)
+
+		var page struct {
+			Links struct {
+				Self struct {
+					Href string `json:"href"`
+				} `json:"self"`
+			} `json:"_links"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &page))
+		self, err := url.Parse(page.Links.Self.Href)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(now, self.Query().Get("cursor"), path)
+
+		// while a descending page from now starts with the latest record
+		w = ht.Get(path + "?cursor=now&order=desc&limit=1")
+		if ht.Assert.Equal(200, w.Code, path) {
+			ht.Assert.PageOf(1, w.Body)
+		}
+	}
+}
@@ -2,10 +2,12 @@ package actions
 
 import (
 	"net/http"
+	"time"
 
 	gctx "github.com/goji/context"
 
 	"github.com/stellar/horizon/render"
+	"github.com/stellar/horizon/render/ndjson"
 	"github.com/stellar/horizon/render/problem"
 	"github.com/stellar/horizon/render/sse"
 	"github.com/zenazn/goji/web"
@@ -23,6 +25,11 @@ type Base struct {
 	R       *http.Request
 	Err     error
 
+	// Recheck, when set, causes an event stream to also rerun its action when
+	// it receives, rather than only when the next ledger is ingested, so that
+	// an action awaiting data that may never arrive can give up in time.
+	Recheck <-chan time.Time
+
 	isSetup bool
 }
 
@@ -39,7 +46,14 @@ func (base *Base) Prepare(c web.C, w http.ResponseWriter, r *http.Request) {
 // Execute trigger content negottion and the actual execution of one of the
 // action's handlers.
 func (base *Base) Execute(action interface{}) {
-	contentType := render.Negotiate(base.Ctx, base.R)
+	// plain text is only offered by the actions that render it, such as that
+	// of the metrics endpoint, lest other actions take requests for it
+	var extra []string
+	if _, ok := action.(Text); ok {
+		extra = append(extra, render.MimeText)
+	}
+
+	contentType := render.Negotiate(base.Ctx, base.R, extra...)
 
 	switch contentType {
 	case render.MimeHal, render.MimeJSON:
@@ -63,6 +77,7 @@ func (base *Base) Execute(action interface{}) {
 		}
 
 		stream := sse.NewStream(base.Ctx, base.W, base.R)
+		defer stream.Close()
 
 		for {
 			action.SSE(stream)
@@ -76,7 +91,10 @@ func (base *Base) Execute(action interface{}) {
 					return
 				}
 
-				stream.Err(base.Err)
+				// the error event is sent to the client, so it carries the
+				// problem rather than the error itself, which may be internal
+				p := problem.FromError(base.Ctx, base.Err)
+				stream.Err(&p)
 			}
 
 			if stream.IsDone() {
@@ -88,6 +106,8 @@ func (base *Base) Execute(action interface{}) {
 				return
 			case <-sse.Pumped():
 				//no-op, continue onto the next iteration
+			case <-base.Recheck:
+				//no-op, continue onto the next iteration
 			}
 		}
 	case render.MimeRaw:
@@ -99,6 +119,40 @@ func (base *Base) Execute(action interface{}) {
 
 		action.Raw()
 
+		if base.Err != nil {
+			problem.Render(base.Ctx, base.W, base.Err)
+			return
+		}
+	case render.MimeNDJSON:
+		action, ok := action.(NDJSON)
+
+		if !ok {
+			goto NotAcceptable
+		}
+
+		nw := ndjson.NewWriter(base.W)
+		action.NDJSON(nw)
+
+		if base.Err != nil {
+			// once a line has been written the response can no longer be a
+			// problem, which is instead sent as its last line.
+			if nw.SentCount() == 0 {
+				problem.Render(base.Ctx, base.W, base.Err)
+				return
+			}
+
+			p := problem.FromError(base.Ctx, base.Err)
+			nw.Err(&p)
+		}
+	case render.MimeText:
+		action, ok := action.(Text)
+
+		if !ok {
+			goto NotAcceptable
+		}
+
+		action.Text()
+
 		if base.Err != nil {
 			problem.Render(base.Ctx, base.W, base.Err)
 			return
@@ -1,8 +1,12 @@
 package actions
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
 	"mime"
 	"strconv"
+	"time"
 
 	"github.com/stellar/go/amount"
 	"github.com/stellar/go/strkey"
@@ -19,6 +23,10 @@ const (
 	ParamOrder = "order"
 	// ParamLimit is a query string param name
 	ParamLimit = "limit"
+
+	// defaultMaxMemory is the memory used when parsing multipart forms, the
+	// same default used by net/http.
+	defaultMaxMemory = 32 << 20
 )
 
 // GetString retrieves a string from either the URLParams, form or query string.
@@ -43,6 +51,22 @@ func (base *Base) GetString(name string) string {
 	return base.R.URL.Query().Get(name)
 }
 
+// GetStrings retrieves every value given for the form or query string
+// parameter `name`, allowing a client to provide a list by repeating the
+// parameter.  Form values precede query string values.
+func (base *Base) GetStrings(name string) []string {
+	if base.Err != nil {
+		return nil
+	}
+
+	if base.R.Form == nil {
+		// errors are ignored in the same manner as http.Request.FormValue
+		base.R.ParseMultipartForm(defaultMaxMemory)
+	}
+
+	return base.R.Form[name]
+}
+
 // GetInt64 retrieves an int64 from the action parameter of the given name.
 // Populates err if the value is not a valid int64
 func (base *Base) GetInt64(name string) int64 {
@@ -89,6 +113,54 @@ func (base *Base) GetInt32(name string) int32 {
 	return int32(asI64)
 }
 
+// GetBool retrieves a bool from the action parameter of the given name.
+// Populates err if the value is not a valid bool, and returns false if the
+// value is blank.
+func (base *Base) GetBool(name string) bool {
+	if base.Err != nil {
+		return false
+	}
+
+	asStr := base.GetString(name)
+
+	if asStr == "" {
+		return false
+	}
+
+	asBool, err := strconv.ParseBool(asStr)
+
+	if err != nil {
+		base.SetInvalidField(name, err)
+		return false
+	}
+
+	return asBool
+}
+
+// GetTime retrieves a time from the action parameter of the given name,
+// expressed in RFC 3339 format.  Populates err if the value is not a valid
+// time, and returns the zero time if the value is blank.
+func (base *Base) GetTime(name string) time.Time {
+	if base.Err != nil {
+		return time.Time{}
+	}
+
+	asStr := base.GetString(name)
+
+	if asStr == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, asStr)
+
+	if err != nil {
+		base.SetInvalidField(name, err)
+		return time.Time{}
+	}
+
+	return t
+}
+
 // GetPagingParams returns the cursor/order/limit triplet that is the
 // standard way of communicating paging data to a horizon endpoint.
 func (base *Base) GetPagingParams() (cursor string, order string, limit uint64) {
@@ -247,6 +319,29 @@ func (base *Base) GetAsset(prefix string) (result xdr.Asset) {
 	return
 }
 
+// GetCanonicalAsset decodes an asset from the request field `name`, expressed
+// in its canonical form: either "native" or "CODE:ISSUER".  `found` is false
+// when the field is blank.
+func (base *Base) GetCanonicalAsset(name string) (result xdr.Asset, found bool) {
+	if base.Err != nil {
+		return
+	}
+
+	s := base.GetString(name)
+	if s == "" {
+		return
+	}
+
+	result, err := assets.ParseCanonical(s)
+	if err != nil {
+		base.SetInvalidField(name, err)
+		return
+	}
+
+	found = true
+	return
+}
+
 // SetInvalidField establishes an error response triggered by an invalid
 // input field from the user.
 func (base *Base) SetInvalidField(name string, reason error) {
@@ -265,6 +360,44 @@ func (base *Base) Path() string {
 	return base.R.URL.Path
 }
 
+// LimitBodySize sets a request_too_large error on the action if the request's
+// body is larger than `max` bytes, reading no more than `max` bytes of it to
+// find out.  The body read is retained, so that the request's form may be
+// parsed afterwards.  A `max` of zero means there is no limit.
+func (base *Base) LimitBodySize(max int64) {
+	if base.Err != nil || max <= 0 {
+		return
+	}
+
+	tooLarge := func() {
+		p := problem.RequestTooLarge
+		p.Extras = map[string]interface{}{"max": max}
+		base.Err = &p
+	}
+
+	if base.R.ContentLength > max {
+		tooLarge()
+		return
+	}
+
+	if base.R.Body == nil {
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(base.R.Body, max+1))
+	if err != nil {
+		base.Err = err
+		return
+	}
+
+	if int64(len(body)) > max {
+		tooLarge()
+		return
+	}
+
+	base.R.Body = ioutil.NopCloser(bytes.NewReader(body))
+}
+
 // ValidateBodyType sets an error on the action if the requests Content-Type
 //  is not `application/x-www-form-urlencoded`
 func (base *Base) ValidateBodyType() {
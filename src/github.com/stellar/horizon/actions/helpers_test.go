@@ -5,7 +5,9 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/render/problem"
@@ -145,6 +147,49 @@ func TestGetInt64(t *testing.T) {
 	tt.Assert.Equal(int64(math.MinInt64), result)
 }
 
+func TestGetBool(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	action := makeTestAction()
+
+	result := action.GetBool("blank")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.False(result)
+
+	result = action.GetBool("zero")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.False(result)
+
+	result = action.GetBool("true")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.True(result)
+
+	_ = action.GetBool("time")
+	if tt.Assert.IsType(&problem.P{}, action.Err) {
+		p := action.Err.(*problem.P)
+		tt.Assert.Equal("bad_request", p.Type)
+		tt.Assert.Equal("time", p.Extras["invalid_field"])
+	}
+}
+
+func TestGetTime(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	action := makeTestAction()
+
+	result := action.GetTime("blank")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.True(result.IsZero())
+
+	result = action.GetTime("time")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.Equal(time.Date(2016, 9, 1, 12, 30, 0, 0, time.UTC), result.UTC())
+
+	result = action.GetTime("two")
+	tt.Assert.Error(action.Err)
+	tt.Assert.True(result.IsZero())
+}
+
 func TestGetPagingParams(t *testing.T) {
 	tt := test.Start(t)
 	defer tt.Finish()
@@ -192,6 +237,75 @@ func TestPath(t *testing.T) {
 	tt.Assert.Equal("/foo-bar/blah", action.Path())
 }
 
+func TestGetStrings(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	r, _ := http.NewRequest(
+		"POST",
+		"/foo?hash=c",
+		strings.NewReader("hash=a&hash=b&other=d"),
+	)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	action := &Base{Ctx: test.Context(), R: r}
+
+	tt.Assert.Equal([]string{"a", "b", "c"}, action.GetStrings("hash"))
+	tt.Assert.Empty(action.GetStrings("missing"))
+	tt.Assert.NoError(action.Err)
+}
+
+func TestLimitBodySize(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	post := func(body string, chunked bool) *Base {
+		r, _ := http.NewRequest("POST", "/foo", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if chunked {
+			// the length of a chunked body is unknown until it is read
+			r.ContentLength = -1
+		}
+		return &Base{Ctx: test.Context(), R: r}
+	}
+
+	for _, chunked := range []bool{false, true} {
+		// within the limit, the form remains readable
+		action := post("tx=abcd", chunked)
+		action.LimitBodySize(7)
+		tt.Assert.NoError(action.Err)
+		tt.Assert.Equal("abcd", action.GetString("tx"))
+
+		// beyond the limit
+		action = post("tx=abcde", chunked)
+		action.LimitBodySize(7)
+		if p, ok := action.Err.(*problem.P); tt.Assert.True(ok) {
+			tt.Assert.Equal("request_too_large", p.Type)
+			tt.Assert.Equal(http.StatusRequestEntityTooLarge, p.Status)
+			tt.Assert.Equal(int64(7), p.Extras["max"])
+		}
+
+		// no limit
+		action = post("tx=abcde", chunked)
+		action.LimitBodySize(0)
+		tt.Assert.NoError(action.Err)
+		tt.Assert.Equal("abcde", action.GetString("tx"))
+	}
+
+	// a body that exceeds the limit is not read
+	r, _ := http.NewRequest("POST", "/foo", &unreadableBody{})
+	r.ContentLength = 1 << 20
+	action := &Base{Ctx: test.Context(), R: r}
+	action.LimitBodySize(1024)
+	tt.Assert.IsType(&problem.P{}, action.Err)
+}
+
+// unreadableBody fails any test that reads it.
+type unreadableBody struct{}
+
+func (b *unreadableBody) Read(p []byte) (int, error) {
+	panic("body read")
+}
+
 func makeTestAction() *Base {
 	r, _ := http.NewRequest("GET", "/foo-bar/blah?limit=2&cursor=hello", nil)
 	action := &Base{
@@ -205,6 +319,8 @@ func makeTestAction() *Base {
 				"32max":             fmt.Sprint(math.MaxInt32),
 				"64min":             fmt.Sprint(math.MinInt64),
 				"64max":             fmt.Sprint(math.MaxInt64),
+				"time":              "2016-09-01T14:30:00+02:00",
+				"true":              "true",
 				"native_asset_type": "native",
 				"4_asset_type":      "credit_alphanum4",
 				"4_asset_code":      "USD",
@@ -1,6 +1,9 @@
 package actions
 
-import "github.com/stellar/horizon/render/sse"
+import (
+	"github.com/stellar/horizon/render/ndjson"
+	"github.com/stellar/horizon/render/sse"
+)
 
 // JSON implementors can respond to a request whose response type was negotiated
 // to be MimeHal or MimeJSON.
@@ -14,6 +17,18 @@ type Raw interface {
 	Raw()
 }
 
+// Text implementors can respond to a request whose response type was
+// negotiated to be MimeText.
+type Text interface {
+	Text()
+}
+
+// NDJSON implementors can respond to a request whose response type was
+// negotiated to be MimeNDJSON.
+type NDJSON interface {
+	NDJSON(*ndjson.Writer)
+}
+
 // SSE implementors can respond to a request whose response type was negotiated
 // to be MimeEventStream.
 type SSE interface {
@@ -1,9 +1,16 @@
 package horizon
 
 import (
+	"net/http"
+
+	"github.com/guregu/null"
+	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/ingest"
+	"github.com/stellar/horizon/ledger"
 	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/problem"
 	"github.com/stellar/horizon/render/sse"
 	"github.com/stellar/horizon/resource"
 )
@@ -11,12 +18,14 @@ import (
 // This file contains the actions:
 //
 // AccountShowAction: details for single account (including stellar-core state)
+// AccountSpendableAssetsAction: the amount of each asset an account may spend
 
 // AccountShowAction renders a account summary found by its address.
 type AccountShowAction struct {
 	Action
 	Address        string
 	HistoryRecord  history.Account
+	HistoryFound   bool
 	CoreData       []core.AccountData
 	CoreRecord     core.Account
 	CoreSigners    []core.Signer
@@ -31,7 +40,7 @@ func (action *AccountShowAction) JSON() {
 		action.loadRecord,
 		action.loadResource,
 		func() {
-			hal.Render(action.W, action.Resource)
+			hal.Render(action.Ctx, action.W, action.Resource)
 		},
 	)
 }
@@ -54,32 +63,32 @@ func (action *AccountShowAction) loadParams() {
 }
 
 func (action *AccountShowAction) loadRecord() {
-	action.Err = action.CoreQ().
-		AccountByAddress(&action.CoreRecord, action.Address)
-	if action.Err != nil {
-		return
-	}
+	// load the core state for the account from a single snapshot, so that a
+	// ledger closing mid-request cannot produce a mismatched response.
+	action.Err = action.CoreQ().Isolated(func(q *core.Q) error {
+		err := loadAccountData(q, action.Address, &action.CoreRecord, &action.CoreData)
+		if err != nil {
+			return err
+		}
 
-	action.Err = action.CoreQ().
-		AllDataByAddress(&action.CoreData, action.Address)
-	if action.Err != nil {
-		return
-	}
+		err = q.SignersByAddress(&action.CoreSigners, action.Address)
+		if err != nil {
+			return err
+		}
 
-	action.Err = action.CoreQ().
-		SignersByAddress(&action.CoreSigners, action.Address)
-	if action.Err != nil {
+		return q.TrustlinesByAddress(&action.CoreTrustlines, action.Address)
+	})
+	if action.CoreQ().NoRows(action.Err) {
+		action.explainMissingAccount(action.Address)
 		return
 	}
-
-	action.Err = action.CoreQ().
-		TrustlinesByAddress(&action.CoreTrustlines, action.Address)
 	if action.Err != nil {
 		return
 	}
 
 	action.Err = action.HistoryQ().
 		AccountByAddress(&action.HistoryRecord, action.Address)
+	action.HistoryFound = action.Err == nil
 
 	// Do not fail when we cannot find the history record... it probably just
 	// means that the account was created outside of our known history range.
@@ -92,6 +101,62 @@ func (action *AccountShowAction) loadRecord() {
 	}
 }
 
+// loadAccountData loads, with `q`, the account at `address` into `account`
+// and its data entries into `data`.  The account and data endpoints both load
+// data entries with it, so that an entry renders the same through either.
+func loadAccountData(
+	q *core.Q,
+	address string,
+	account *core.Account,
+	data *[]core.AccountData,
+) error {
+	err := q.AccountByAddress(account, address)
+	if err != nil {
+		return err
+	}
+
+	return q.AccountDataByAddress(data, address)
+}
+
+// explainMissingAccount explains why the account at `address`, missing from
+// stellar-core, cannot be found:  if it was merged away into another account,
+// the request fails with an account_merged problem describing the merge.
+// Otherwise the original not found error stands.
+func (action *Action) explainMissingAccount(address string) {
+	var merge history.AccountMerge
+	err := action.HistoryQ().AccountMergeByAddress(&merge, address)
+	if action.HistoryQ().NoRows(err) {
+		return
+	}
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	into, err := merge.Into()
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	action.Err = &problem.P{
+		Type:   "account_merged",
+		Title:  "Account Merged",
+		Status: http.StatusGone,
+		Detail: "The account requested no longer exists because it was merged " +
+			"into another account.  The `extras.merged_into` field of this " +
+			"response is the address of that account, and the `extras.ledger` " +
+			"and `extras.transaction_hash` fields identify the merge.",
+		Extras: map[string]interface{}{
+			"merged_into":      into,
+			"ledger":           merge.LedgerSequence,
+			"closed_at":        merge.LedgerClosedAt,
+			"transaction_hash": merge.TransactionHash,
+			"operation_id":     merge.PagingToken(),
+		},
+	}
+}
+
 func (action *AccountShowAction) loadResource() {
 	action.Err = action.Resource.Populate(
 		action.Ctx,
@@ -101,4 +166,119 @@ func (action *AccountShowAction) loadResource() {
 		action.CoreTrustlines,
 		action.HistoryRecord,
 	)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.loadCreatedBeforeHistory()
+}
+
+// loadCreatedBeforeHistory flags the account as created before the ingested
+// history when its creation is known not to lie within it:  the account
+// appears in the history, yet no ledger ingested since horizon began
+// recording the creation of accounts created it.  An account missing from the
+// history may instead have been created in a ledger not yet ingested, and one
+// whose creation may lie within ledgers ingested by an older version of
+// horizon is unknown until they are reingested, so either is left null.
+func (action *AccountShowAction) loadCreatedBeforeHistory() error {
+	if !action.HistoryFound || action.HistoryRecord.CreatedLedger.Valid {
+		return nil
+	}
+
+	var outdated bool
+	err := action.HistoryQ().
+		HasOutdatedLedgers(&outdated, ingest.AccountCreationVersion)
+	if err != nil {
+		return err
+	}
+
+	if !outdated {
+		action.Resource.CreatedBeforeHistory = null.BoolFrom(true)
+	}
+	return nil
+}
+
+// AccountSpendableAssetsAction renders, for each asset held by an account found
+// by its address, the amount the account may spend:  its balance, less the
+// amount offered for sale by its open offers and, for lumens, its reserve.
+type AccountSpendableAssetsAction struct {
+	Action
+	Address         string
+	CoreRecord      core.Account
+	CoreTrustlines  []core.Trustline
+	CoreLiabilities []core.SellingLiability
+	LedgerParams    ledger.State
+	Page            hal.BasePage
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountSpendableAssetsAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.Ctx, action.W, action.Page)
+		},
+	)
+}
+
+func (action *AccountSpendableAssetsAction) loadParams() {
+	action.Address = action.GetString("account_id")
+}
+
+func (action *AccountSpendableAssetsAction) loadRecords() {
+	// the balances, offers and base reserve must agree with one another, so
+	// they are loaded from a single snapshot.
+	action.Err = action.CoreQ().Isolated(func(q *core.Q) error {
+		err := q.AccountByAddress(&action.CoreRecord, action.Address)
+		if err != nil {
+			return err
+		}
+
+		err = q.TrustlinesByAddress(&action.CoreTrustlines, action.Address)
+		if err != nil {
+			return err
+		}
+
+		err = q.SellingLiabilitiesByAddress(&action.CoreLiabilities, action.Address)
+		if err != nil {
+			return err
+		}
+
+		return q.LedgerParams(&action.LedgerParams)
+	})
+}
+
+func (action *AccountSpendableAssetsAction) loadPage() {
+	var native xdr.Int64
+	selling := map[string]xdr.Int64{}
+	for _, l := range action.CoreLiabilities {
+		if l.AssetType == xdr.AssetTypeAssetTypeNative {
+			native = l.Amount
+			continue
+		}
+		selling[l.AssetCode.String+":"+l.Issuer.String] = l.Amount
+	}
+
+	action.Page.Init()
+	for _, tl := range action.CoreTrustlines {
+		var res resource.SpendableAsset
+		action.Err = res.Populate(tl, selling[tl.Assetcode+":"+tl.Issuer])
+		if action.Err != nil {
+			return
+		}
+		action.Page.Add(res)
+	}
+
+	var res resource.SpendableAsset
+	action.Err = res.PopulateNative(
+		action.CoreRecord,
+		native,
+		action.LedgerParams.BaseReserve,
+	)
+	if action.Err != nil {
+		return
+	}
+	action.Page.Add(res)
 }
@@ -2,8 +2,16 @@ package horizon
 
 import (
 	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
 	"testing"
 
+	"github.com/guregu/null"
+	"github.com/stellar/horizon/ingest"
+	"github.com/stellar/horizon/render"
+	"github.com/stellar/horizon/render/problem"
 	"github.com/stellar/horizon/resource"
 )
 
@@ -21,11 +29,161 @@ func TestAccountActions_Show(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &result)
 		ht.Require.NoError(err)
 		ht.Assert.Equal("3", result.Sequence)
+
+		// the root account predates any create_account operation
+		ht.Assert.False(result.CreatedLedger.Valid)
+		ht.Assert.Nil(result.CreatedAt)
+		ht.Assert.Equal(null.BoolFrom(true), result.CreatedBeforeHistory)
+	}
+
+	// account created within the ingested history
+	w = ht.Get(
+		"/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU",
+	)
+	if ht.Assert.Equal(200, w.Code) {
+		var result map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(float64(2), result["created_ledger"])
+		ht.Assert.Equal("2016-06-29T16:33:54Z", result["created_at"])
+		ht.Assert.Equal(false, result["created_before_history"])
+	}
+
+	// the creation of an account is unknown while ledgers ingested before
+	// horizon recorded it remain
+	_, err := ht.App.historyQ.ExecRaw(
+		`UPDATE history_ledgers SET importer_version = ? WHERE sequence = 2`,
+		ingest.AccountCreationVersion-1,
+	)
+	ht.Require.NoError(err)
+	w = ht.Get(
+		"/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+	)
+	if ht.Assert.Equal(200, w.Code) {
+		var result map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Nil(result["created_ledger"])
+		ht.Assert.Contains(result, "created_before_history")
+		ht.Assert.Nil(result["created_before_history"])
 	}
 
 	// missing account
 	w = ht.Get("/accounts/100")
 	ht.Assert.Equal(404, w.Code)
+
+	// oversized response
+	render.SetMaxBodySize(100)
+	defer render.SetMaxBodySize(0)
+	w = ht.Get(
+		"/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+	)
+	if ht.Assert.Equal(500, w.Code) {
+		ht.Assert.ProblemType(w.Body, "response_too_large")
+
+		// the problem identifies the request
+		var p problem.P
+		err := json.Unmarshal(w.Body.Bytes(), &p)
+		ht.Require.NoError(err)
+		ht.Assert.NotEmpty(p.Instance)
+	}
+}
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// TestAccountActions_ShowGolden compares the rendering of an account holding
+// an authorized and an unauthorized trustline and data entries against the
+// golden file in testdata.  Run the test with -update to regenerate it.
+func TestAccountActions_ShowGolden(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	const account = "GCVW5LCRZFP7PENXTAGOVIQXADDNUXXZJCNKF4VQB2IK7W2LPJWF73UG"
+	_, err := ht.App.coreQ.ExecRaw(`
+		INSERT INTO accountdata VALUES
+			($1, 'name', 'c3RlbGxhcg=='),
+			($1, 'config', 'AQID')
+	`, account)
+	ht.Require.NoError(err)
+
+	w := ht.Get("/accounts/"+account, func(r *http.Request) {
+		r.Host = "horizon.example.com"
+	})
+	ht.Require.Equal(200, w.Code)
+
+	path := filepath.Join("testdata", "account_show.golden")
+	if *update {
+		ht.Require.NoError(ioutil.WriteFile(path, w.Body.Bytes(), 0644))
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	ht.Require.NoError(err)
+	ht.Assert.Equal(string(want), w.Body.String())
+
+	// the data endpoint renders each entry as the account does
+	w = ht.Get("/accounts/" + account + "/data/config")
+	if ht.Assert.Equal(200, w.Code) {
+		var result map[string]string
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+		ht.Assert.Equal("AQID", result["value"])
+	}
+}
+
+func TestAccountActions_ShowBalanceOrder(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	// balances are ordered lumens first, then by asset code and issuer,
+	// regardless of the order stellar-core returns trustlines in
+	w := ht.Get("/accounts/GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.Account
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+
+		var order []string
+		for _, b := range result.Balances {
+			order = append(order, b.Type+":"+b.Code)
+		}
+		ht.Assert.Equal([]string{
+			"native:",
+			"credit_alphanum4:EUR",
+			"credit_alphanum4:USD",
+		}, order)
+	}
+}
+
+func TestAccountActions_ShowMerged(t *testing.T) {
+	ht := StartHTTPTest(t, "account_merge")
+	defer ht.Finish()
+
+	w := ht.Get(
+		"/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU",
+	)
+	if ht.Assert.Equal(410, w.Code) {
+		var result problem.P
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("account_merged", result.Type)
+		ht.Assert.Equal(
+			"GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2",
+			result.Extras["merged_into"],
+		)
+		ht.Assert.Equal(float64(3), result.Extras["ledger"])
+		ht.Assert.Equal("12884905985", result.Extras["operation_id"])
+	}
+
+	// the account it was merged into is still present
+	w = ht.Get(
+		"/accounts/GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2",
+	)
+	ht.Assert.Equal(200, w.Code)
+
+	// an account that never existed is still not found
+	w = ht.Get(
+		"/accounts/GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON",
+	)
+	ht.Assert.Equal(404, w.Code)
 }
 
 func TestAccountActions_ShowRegressions(t *testing.T) {
@@ -42,3 +200,77 @@ func TestAccountActions_ShowRegressions(t *testing.T) {
 	ht.Assert.Equal(200, w.Code)
 
 }
+
+func TestAccountActions_SpendableAssets(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	const (
+		bartek = "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+		usd    = "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+	)
+
+	load := func() map[string]resource.SpendableAsset {
+		w := ht.Get("/accounts/" + bartek + "/spendable_assets")
+		if !ht.Assert.Equal(200, w.Code) {
+			return nil
+		}
+
+		var page struct {
+			Embedded struct {
+				Records []resource.SpendableAsset `json:"records"`
+			} `json:"_embedded"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &page))
+
+		byCode := map[string]resource.SpendableAsset{}
+		for _, r := range page.Embedded.Records {
+			byCode[r.Code] = r
+		}
+		return byCode
+	}
+
+	assets := load()
+	if ht.Assert.Len(assets, 3) {
+		// offers of 90, 80 and 50 EUR are set aside
+		eur := assets["EUR"]
+		ht.Assert.Equal("450.0000000", eur.Balance)
+		ht.Assert.Equal("220.0000000", eur.SellingLiabilities)
+		ht.Assert.Equal("230.0000000", eur.Spendable)
+		ht.Assert.Equal("", eur.Reserve)
+
+		ht.Assert.Equal("50.0000000", assets["USD"].Spendable)
+
+		// as is a reserve of (2 + 5 subentries) * 10 lumens
+		native := assets[""]
+		ht.Assert.Equal("native", native.Type)
+		ht.Assert.Equal("99.9999500", native.Balance)
+		ht.Assert.Equal("0.0000000", native.SellingLiabilities)
+		ht.Assert.Equal("70.0000000", native.Reserve)
+		ht.Assert.Equal("29.9999500", native.Spendable)
+	}
+
+	// nothing may be spent from an unauthorized trustline, nor beyond the
+	// balance
+	_, err := ht.App.coreQ.ExecRaw(`
+		UPDATE trustlines SET flags = 0
+		WHERE accountid = $1 AND assetcode = 'USD'
+	`, bartek)
+	ht.Require.NoError(err)
+	_, err = ht.App.coreQ.ExecRaw(`
+		UPDATE offers SET amount = 9000000000
+		WHERE sellerid = $1 AND offerid = 1
+	`, bartek)
+	ht.Require.NoError(err)
+
+	assets = load()
+	if ht.Assert.Len(assets, 3) {
+		ht.Assert.Equal("0.0000000", assets["USD"].Spendable)
+		ht.Assert.Equal("0.0000000", assets["EUR"].Spendable)
+		ht.Assert.Equal(usd, assets["USD"].Issuer)
+	}
+
+	// missing account
+	w := ht.Get("/accounts/100/spendable_assets")
+	ht.Assert.Equal(404, w.Code)
+}
@@ -0,0 +1,133 @@
+package horizon
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/problem"
+	"github.com/stellar/horizon/resource"
+)
+
+// This file contains the actions:
+//
+// AssetsIndexAction: pages of non-native assets, with their stats
+
+// assetStatsOrderings are the values accepted by the `order_by` parameter of
+// AssetsIndexAction.
+var assetStatsOrderings = []string{
+	history.AssetStatsOrderByAmount,
+	history.AssetStatsOrderByAsset,
+	history.AssetStatsOrderByHolders,
+}
+
+// AssetsIndexAction renders a page of the non-native assets held by accounts,
+// optionally filtered by code and issuer, with the stats maintained for them
+// during ingestion.
+type AssetsIndexAction struct {
+	Action
+	CodeFilter   string
+	IssuerFilter string
+	OrderBy      string
+	PagingParams db2.PageQuery
+	Records      []history.AssetStat
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *AssetsIndexAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.Ctx, action.W, action.Page)
+		},
+	)
+}
+
+func (action *AssetsIndexAction) loadParams() {
+	action.CodeFilter = action.GetString("asset_code")
+	if action.GetString("asset_issuer") != "" {
+		action.IssuerFilter = action.GetAddress("asset_issuer")
+	}
+	action.OrderBy = action.getOrderBy()
+	action.PagingParams = action.GetPageQuery()
+}
+
+// getOrderBy retrieves the ordering of the page from the `order_by`
+// parameter, defaulting to ordering by asset.
+func (action *AssetsIndexAction) getOrderBy() string {
+	orderBy := action.GetString("order_by")
+	if action.Err != nil {
+		return ""
+	}
+
+	if orderBy == "" {
+		return history.AssetStatsOrderByAsset
+	}
+
+	for _, valid := range assetStatsOrderings {
+		if orderBy == valid {
+			return orderBy
+		}
+	}
+
+	action.SetInvalidField("order_by", fmt.Errorf(
+		"unknown ordering %q, expected one of: %s",
+		orderBy,
+		strings.Join(assetStatsOrderings, ", "),
+	))
+	action.Err.(*problem.P).Extras["valid_values"] = assetStatsOrderings
+	return ""
+}
+
+func (action *AssetsIndexAction) loadRecords() {
+	q := action.HistoryQ().AssetStats()
+
+	if action.CodeFilter != "" {
+		q = q.ForCode(action.CodeFilter)
+	}
+
+	if action.IssuerFilter != "" {
+		q = q.ForIssuer(action.IssuerFilter)
+	}
+
+	action.Err = q.Page(action.PagingParams, action.OrderBy).Select(&action.Records)
+}
+
+func (action *AssetsIndexAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.AssetStat
+		res.Populate(action.Ctx, record, action.OrderBy)
+		action.Page.Add(res)
+	}
+
+	action.Page.BaseURL = action.BaseURL()
+	action.Page.BasePath = action.Path()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.Filters = action.filters()
+	action.Page.PopulateLinks()
+}
+
+// filters returns the filters that were applied to the request, for
+// preservation in the page links.
+func (action *AssetsIndexAction) filters() url.Values {
+	f := url.Values{}
+	if action.CodeFilter != "" {
+		f.Set("asset_code", action.CodeFilter)
+	}
+	if action.IssuerFilter != "" {
+		f.Set("asset_issuer", action.IssuerFilter)
+	}
+	if action.OrderBy != history.AssetStatsOrderByAsset {
+		f.Set("order_by", action.OrderBy)
+	}
+	return f
+}
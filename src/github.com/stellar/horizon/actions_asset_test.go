@@ -0,0 +1,111 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stellar/horizon/resource"
+)
+
+func TestAssetsActions_Index(t *testing.T) {
+	ht := StartHTTPTest(t, "paths")
+	defer ht.Finish()
+
+	const issuer = "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN"
+
+	// default params
+	w := ht.Get("/assets")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(8, w.Body)
+	}
+
+	// filtered by code and issuer
+	w = ht.Get("/assets?asset_code=USD&asset_issuer=" + issuer)
+	if ht.Assert.Equal(200, w.Code) {
+		var page struct {
+			Embedded struct {
+				Records []resource.AssetStat `json:"records"`
+			} `json:"_embedded"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &page))
+		if ht.Assert.Len(page.Embedded.Records, 1) {
+			stat := page.Embedded.Records[0]
+			ht.Assert.Equal("credit_alphanum4", stat.Type)
+			ht.Assert.Equal("USD", stat.Code)
+			ht.Assert.Equal(issuer, stat.Issuer)
+			ht.Assert.Equal("5000.0000000", stat.Amount)
+			ht.Assert.Equal(int32(2), stat.NumAccounts)
+			ht.Assert.Equal("USD_"+issuer, stat.PT)
+		}
+	}
+
+	w = ht.Get("/assets?asset_code=NOPE")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	w = ht.Get("/assets?asset_issuer=foo")
+	ht.Assert.Equal(400, w.Code)
+
+	// ordered by holders, the ordering being preserved when paging
+	w = ht.Get("/assets?order_by=holders&order=desc&limit=2")
+	if ht.Assert.Equal(200, w.Code) {
+		var page struct {
+			Embedded struct {
+				Records []resource.AssetStat `json:"records"`
+			} `json:"_embedded"`
+			Links struct {
+				Next struct {
+					Href string `json:"href"`
+				} `json:"next"`
+			} `json:"_links"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &page))
+		if ht.Assert.Len(page.Embedded.Records, 2) {
+			ht.Assert.Equal("USD", page.Embedded.Records[0].Code)
+			ht.Assert.Equal("EUR", page.Embedded.Records[1].Code)
+			ht.Assert.Equal("2_EUR_"+issuer, page.Embedded.Records[1].PT)
+		}
+		ht.Assert.Contains(page.Links.Next.Href, "order_by=holders")
+
+		next, err := url.Parse(page.Links.Next.Href)
+		ht.Require.NoError(err)
+		w = ht.Get(next.RequestURI())
+		if ht.Assert.Equal(200, w.Code) {
+			ht.Assert.PageOf(2, w.Body)
+		}
+	}
+
+	// invalid ordering
+	w = ht.Get("/assets?order_by=popularity")
+	if ht.Assert.Equal(400, w.Code) {
+		var p struct {
+			Extras struct {
+				InvalidField string   `json:"invalid_field"`
+				ValidValues  []string `json:"valid_values"`
+			} `json:"extras"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &p))
+		ht.Assert.Equal("order_by", p.Extras.InvalidField)
+		ht.Assert.Equal([]string{"amount", "asset", "holders"}, p.Extras.ValidValues)
+	}
+
+	// cursors from another ordering are rejected
+	w = ht.Get("/assets?order_by=holders&cursor=USD_" + issuer)
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "bad_request")
+	}
+}
+
+func TestAssetsActions_IndexNative(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// the native asset is held in account balances rather than trustlines,
+	// and is never listed.
+	w := ht.Get("/assets")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+}
@@ -1,6 +1,8 @@
 package horizon
 
 import (
+	"database/sql"
+
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/render/hal"
 	"github.com/stellar/horizon/render/sse"
@@ -21,7 +23,7 @@ func (action *DataShowAction) JSON() {
 		action.loadRecord,
 		func() {
 
-			hal.Render(action.W, map[string]string{
+			hal.Render(action.Ctx, action.W, map[string]string{
 				"value": action.Data.Value,
 			})
 		},
@@ -61,10 +63,31 @@ func (action *DataShowAction) loadParams() {
 	action.Key = action.GetString("key")
 }
 
+// loadRecord loads the data entry of the requested key, as the account
+// endpoint loads all of them (see loadAccountData).  An entry of a missing
+// account is explained as the account itself would be.
 func (action *DataShowAction) loadRecord() {
-	action.Err = action.CoreQ().
-		AccountDataByKey(&action.Data, action.Address, action.Key)
+	var (
+		account core.Account
+		data    []core.AccountData
+	)
+	action.Err = action.CoreQ().Isolated(func(q *core.Q) error {
+		return loadAccountData(q, action.Address, &account, &data)
+	})
+	if action.CoreQ().NoRows(action.Err) {
+		action.explainMissingAccount(action.Address)
+		return
+	}
 	if action.Err != nil {
 		return
 	}
+
+	for _, d := range data {
+		if d.Key == action.Key {
+			action.Data = d
+			return
+		}
+	}
+
+	action.Err = sql.ErrNoRows
 }
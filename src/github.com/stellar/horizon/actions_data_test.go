@@ -36,3 +36,18 @@ func TestDataActions_Show(t *testing.T) {
 	w = ht.Get(prefix+"/data/missing", test.RequestHelperRaw)
 	ht.Assert.Equal(404, w.Code)
 }
+
+func TestDataActions_ShowMerged(t *testing.T) {
+	ht := StartHTTPTest(t, "account_merge")
+	defer ht.Finish()
+
+	// the data of a merged account is gone with the account
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/data/name1")
+	if ht.Assert.Equal(410, w.Code) {
+		ht.Assert.ProblemType(w.Body, "account_merged")
+	}
+
+	// while that of an existing account without the key is not found
+	w = ht.Get("/accounts/GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2/data/name1")
+	ht.Assert.Equal(404, w.Code)
+}
@@ -2,13 +2,20 @@ package horizon
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/problem"
 	"github.com/stellar/horizon/render/sse"
 	"github.com/stellar/horizon/resource"
+	"github.com/stellar/horizon/resource/effects"
 )
 
 // This file contains the actions:
@@ -17,13 +24,17 @@ import (
 
 // EffectIndexAction renders a page of effect resources, identified by
 // a normal page query and optionally filtered by an account, ledger,
-// transaction, or operation.
+// transaction, or operation.  The effects may further be filtered by their
+// types and by the asset they concern.
 type EffectIndexAction struct {
 	Action
 	AccountFilter     string
 	LedgerFilter      int32
 	TransactionFilter string
 	OperationFilter   int64
+	TypeFilter        []history.EffectType
+	AssetFilter       xdr.Asset
+	HasAssetFilter    bool
 
 	PagingParams db2.PageQuery
 	Records      []history.Effect
@@ -33,6 +44,7 @@ type EffectIndexAction struct {
 // JSON is a method for actions.JSON
 func (action *EffectIndexAction) JSON() {
 	action.Do(
+		action.EnsureEffectsEnabled,
 		action.EnsureHistoryFreshness,
 		action.loadParams,
 		action.ValidateCursorWithinHistory,
@@ -41,13 +53,14 @@ func (action *EffectIndexAction) JSON() {
 	)
 
 	action.Do(func() {
-		hal.Render(action.W, action.Page)
+		hal.Render(action.Ctx, action.W, action.Page)
 	})
 }
 
 // SSE is a method for actions.SSE
 func (action *EffectIndexAction) SSE(stream sse.Stream) {
 	action.Setup(
+		action.EnsureEffectsEnabled,
 		action.EnsureHistoryFreshness,
 		action.loadParams,
 		action.ValidateCursorWithinHistory,
@@ -83,6 +96,8 @@ func (action *EffectIndexAction) loadParams() {
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
 	action.OperationFilter = action.GetInt64("op_id")
+	action.TypeFilter = action.getEffectTypes("type")
+	action.AssetFilter, action.HasAssetFilter = action.GetCanonicalAsset("asset")
 }
 
 // loadRecords populates action.Records
@@ -100,7 +115,18 @@ func (action *EffectIndexAction) loadRecords() {
 		effects.ForTransaction(action.TransactionFilter)
 	}
 
+	if len(action.TypeFilter) > 0 {
+		effects.ForTypes(action.TypeFilter)
+	}
+
+	if action.HasAssetFilter {
+		effects.ForAsset(action.AssetFilter)
+	}
+
 	action.Err = effects.Page(action.PagingParams).Select(&action.Records)
+	if action.LedgerFilter > 0 {
+		action.HealLedger(action.LedgerFilter)
+	}
 }
 
 // loadPage populates action.Page
@@ -119,9 +145,23 @@ func (action *EffectIndexAction) loadPage() {
 	action.Page.Limit = action.PagingParams.Limit
 	action.Page.Cursor = action.PagingParams.Cursor
 	action.Page.Order = action.PagingParams.Order
+	action.Page.Filters = action.filters()
 	action.Page.PopulateLinks()
 }
 
+// filters returns the type and asset filters that were applied to the
+// request, for preservation in the page links.
+func (action *EffectIndexAction) filters() url.Values {
+	f := url.Values{}
+	if len(action.TypeFilter) > 0 {
+		f.Set("type", effectTypeList(action.TypeFilter))
+	}
+	if action.HasAssetFilter {
+		f.Set("asset", action.GetString("asset"))
+	}
+	return f
+}
+
 // ValidateCursor ensures that the provided cursor parameter is of the form
 // OPERATIONID-INDEX (such as 1234-56) or is the special value "now" that
 // represents the the cursor directly after the last closed ledger
@@ -145,3 +185,55 @@ func (action *EffectIndexAction) ValidateCursor() {
 
 	return
 }
+
+// getEffectTypes retrieves the effect types named by the comma separated list
+// `name` from the request, using the names of horizon's effect resources (see
+// effects.TypeNames).  An unknown type fails the action with a bad request,
+// whose `valid_values` extra lists the names of the valid types.  An absent
+// list yields nil.
+func (action *Action) getEffectTypes(name string) []history.EffectType {
+	list := action.GetString(name)
+	if action.Err != nil || list == "" {
+		return nil
+	}
+
+	byName := map[string]history.EffectType{}
+	validNames := make([]string, 0, len(effects.TypeNames))
+	for typ, typName := range effects.TypeNames {
+		byName[typName] = typ
+		validNames = append(validNames, typName)
+	}
+	sort.Strings(validNames)
+
+	var types []history.EffectType
+	seen := map[history.EffectType]bool{}
+	for _, typName := range strings.Split(list, ",") {
+		typName = strings.TrimSpace(typName)
+		typ, ok := byName[typName]
+		if !ok {
+			action.SetInvalidField(name, fmt.Errorf(
+				"unknown effect type %q, expected a comma separated list of: %s",
+				typName,
+				strings.Join(validNames, ", "),
+			))
+			action.Err.(*problem.P).Extras["valid_values"] = validNames
+			return nil
+		}
+
+		if !seen[typ] {
+			seen[typ] = true
+			types = append(types, typ)
+		}
+	}
+
+	return types
+}
+
+// effectTypeList returns the comma separated list of the names of `types`.
+func effectTypeList(types []history.EffectType) string {
+	names := make([]string, len(types))
+	for i, typ := range types {
+		names[i] = effects.TypeNames[typ]
+	}
+	return strings.Join(names, ",")
+}
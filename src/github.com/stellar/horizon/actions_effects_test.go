@@ -1,9 +1,13 @@
 package horizon
 
 import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stellar/horizon/test"
+	"github.com/stellar/horizon/toid"
 )
 
 func TestEffectActions_Index(t *testing.T) {
@@ -69,3 +73,159 @@ func TestEffectActions_Index(t *testing.T) {
 	ht.Assert.Equal(410, w.Code)
 	ht.Logger.Error(w.Body.String())
 }
+
+func TestEffectActions_Filters(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	account := "/accounts/GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU/effects"
+
+	// filtered by type
+	w := ht.Get(account + "?type=trade")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/effects?type=trade,trustline_authorized,trustline_deauthorized")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(11, w.Body)
+	}
+
+	w = ht.Get("/effects?type=trade,bogus")
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "bad_request")
+		ht.Assert.Contains(w.Body.String(), "valid_values")
+		ht.Assert.Contains(w.Body.String(), "trustline_authorized")
+	}
+
+	// filtered by asset
+	w = ht.Get(account + "?asset=USD:GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+	}
+
+	w = ht.Get("/effects?asset=EUR:GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/effects?asset=USD")
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "bad_request")
+	}
+
+	// filtered by both, with paging preserving the filters
+	w = ht.Get("/effects?type=trade&asset=native&limit=5")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(5, w.Body)
+		ht.Assert.Contains(w.Body.String(), "asset=native")
+		ht.Assert.Contains(w.Body.String(), "type=trade")
+	}
+
+	w = ht.Get("/effects?type=trade&asset=native&limit=5&cursor=" + lastEffectCursor(ht, w))
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/effects?type=account_created&asset=native")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// streamed
+	w = ht.Get(account+"?type=trade&limit=3", test.RequestHelperStreaming)
+	if ht.Assert.Equal(200, w.Code) {
+		body := w.Body.String()
+		ht.Assert.Equal(3, strings.Count(body, "id: "))
+		ht.Assert.Equal(3, strings.Count(body, `"type":"trade"`))
+	}
+}
+
+// lastEffectCursor returns the paging token of the last effect of the page
+// recorded by `w`.
+func lastEffectCursor(ht *HTTPT, w *httptest.ResponseRecorder) string {
+	var page struct {
+		Embedded struct {
+			Records []struct {
+				PT string `json:"paging_token"`
+			} `json:"records"`
+		} `json:"_embedded"`
+	}
+	ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &page))
+	ht.Require.NotEmpty(page.Embedded.Records)
+	return page.Embedded.Records[len(page.Embedded.Records)-1].PT
+}
+
+func TestEffectActions_Disabled(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	ht.App.config.DisableEffectIngestion = true
+
+	w := ht.Get("/effects")
+	if ht.Assert.Equal(501, w.Code) {
+		ht.Assert.ProblemType(w.Body, "feature_disabled")
+	}
+
+	w = ht.Get("/ledgers/2/effects", test.RequestHelperStreaming)
+	ht.Assert.Equal(501, w.Code)
+
+	w = ht.Get("/order_book/trades?selling_asset_type=native&buying_asset_type=native")
+	if ht.Assert.Equal(501, w.Code) {
+		ht.Assert.ProblemType(w.Body, "feature_disabled")
+	}
+
+	// as are the ledger export, which includes effects, and the network
+	// statistics
+	w = ht.Get("/ledgers/2/export")
+	if ht.Assert.Equal(501, w.Code) {
+		ht.Assert.ProblemType(w.Body, "feature_disabled")
+	}
+
+	w = ht.Get("/stats")
+	if ht.Assert.Equal(501, w.Code) {
+		ht.Assert.ProblemType(w.Body, "feature_disabled")
+	}
+
+	// operations don't depend upon effects
+	w = ht.Get("/operations")
+	ht.Assert.Equal(200, w.Code)
+}
+
+func TestEffectActions_FailedTransactionFee(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// the fee of a failed transaction, the fifth of ledger 3, recorded against
+	// the transaction
+	_, err := ht.App.historyQ.ExecRaw(`
+		INSERT INTO history_effects VALUES (2, $1, 1, 3, $2)
+	`, toid.New(3, 5, 0).ToInt64(), `{
+		"asset_type": "native",
+		"amount": "0.0000100",
+		"fee": true,
+		"transaction_hash": "f5a1ef0d1b1f26ec8e6d1a0e5d16ef38f77a6a2bd6d6c7c7c6d62ec1d4f2c7b2"
+	}`)
+	ht.Require.NoError(err)
+
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/effects?order=desc&limit=2")
+	if !ht.Assert.Equal(200, w.Code) {
+		return
+	}
+
+	var page struct {
+		Embedded struct {
+			Records []map[string]interface{} `json:"records"`
+		} `json:"_embedded"`
+	}
+	ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &page))
+	ht.Require.Len(page.Embedded.Records, 2)
+
+	// the fee has no operation to link to...
+	fee := page.Embedded.Records[0]
+	ht.Assert.Equal(true, fee["fee"])
+	ht.Assert.NotContains(fee["_links"], "operation")
+	ht.Assert.Contains(fee["_links"], "succeeds")
+
+	// ...unlike the effects of operations
+	ht.Assert.Contains(page.Embedded.Records[1]["_links"], "operation")
+}
@@ -24,7 +24,7 @@ func (action *FriendbotAction) JSON() {
 		action.loadResource,
 
 		func() {
-			hal.Render(action.W, action.Resource)
+			hal.Render(action.Ctx, action.W, action.Resource)
 		})
 }
 
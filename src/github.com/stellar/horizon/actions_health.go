@@ -0,0 +1,98 @@
+package horizon
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/resource"
+)
+
+// defaultHealthCheckTimeout is how long HealthAction waits for each database
+// to answer when Config.HealthCheckTimeout is unset.
+const defaultHealthCheckTimeout = time.Second
+
+// defaultHealthMaxStateAge is how long ago the ledger state may have been
+// refreshed before HealthAction reports horizon unhealthy, when
+// Config.HealthMaxStateAge is unset.
+const defaultHealthMaxStateAge = 30 * time.Second
+
+// HealthAction reports whether horizon is fit to serve requests (see
+// resource.Health), for the benefit of load balancers.  It responds with 503
+// Service Unavailable when horizon is unhealthy.
+//
+// The databases are pinged on each request, but an unresponsive database is
+// not waited upon beyond Config.HealthCheckTimeout (see
+// db2.Health.CheckWithin), and the ledgers are taken from the cached ledger
+// state, so that the action answers promptly even while a database hangs.
+// Horizon is reported unhealthy should that cached state grow older than
+// Config.HealthMaxStateAge, or ingestion be judged stalled (see
+// App.IngestionStall), so that a refresh or ingestion that has silently
+// stopped is not mistaken for health.
+type HealthAction struct {
+	Action
+	CoreErr    error
+	HorizonErr error
+}
+
+// JSON is a method for actions.JSON
+func (action *HealthAction) JSON() {
+	action.checkDatabases()
+
+	maxAge := action.App.config.HealthMaxStateAge
+	if maxAge == 0 {
+		maxAge = defaultHealthMaxStateAge
+	}
+
+	ls, age := ledger.CurrentStateWithAge()
+
+	var res resource.Health
+	res.Populate(
+		action.Ctx,
+		ls,
+		age,
+		action.App.IngestionStall(),
+		action.CoreErr,
+		action.HorizonErr,
+		resource.HealthLimits{
+			DegradedLag:  int32(action.App.config.HealthDegradedLag),
+			UnhealthyLag: int32(action.App.config.HealthUnhealthyLag),
+			MaxStateAge:  maxAge,
+		},
+	)
+
+	status := http.StatusOK
+	if res.Status == resource.HealthUnhealthy {
+		status = http.StatusServiceUnavailable
+	}
+	hal.RenderStatus(action.Ctx, action.W, status, res)
+}
+
+// checkDatabases pings the stellar-core and horizon databases in parallel.
+func (action *HealthAction) checkDatabases() {
+	timeout := action.App.config.HealthCheckTimeout
+	if timeout == 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		action.CoreErr = action.App.coreHealth.CheckWithin(action.App.CoreRepo(nil), timeout)
+	}()
+	go func() {
+		defer wg.Done()
+		action.HorizonErr = action.App.horizonHealth.CheckWithin(action.App.HorizonRepo(nil), timeout)
+	}()
+	wg.Wait()
+
+	if action.CoreErr != nil {
+		action.Log.WithField("err", action.CoreErr.Error()).Warn("stellar-core db failed health check")
+	}
+	if action.HorizonErr != nil {
+		action.Log.WithField("err", action.HorizonErr.Error()).Warn("horizon db failed health check")
+	}
+}
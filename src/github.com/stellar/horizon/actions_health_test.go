@@ -0,0 +1,183 @@
+package horizon
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/resource"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestHealthAction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	ht.App.config.HealthDegradedLag = 10
+	ht.App.config.HealthUnhealthyLag = 100
+
+	get := func() (int, resource.Health) {
+		var res resource.Health
+		w := ht.Get("/health")
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+		return w.Code, res
+	}
+
+	code, res := get()
+	ht.Assert.Equal(200, code)
+	ht.Assert.Equal(resource.HealthHealthy, res.Status)
+	ht.Assert.True(res.CoreDB.Reachable)
+	ht.Assert.True(res.HorizonDB.Reachable)
+	ht.Assert.Equal(int32(3), res.CoreLatest)
+	ht.Assert.Equal(int32(3), res.HistoryLatest)
+	ht.Assert.Equal(int32(0), res.IngestionLag)
+	ht.Assert.True(ht.App.HorizonHealth().Healthy())
+
+	// ingestion trailing stellar-core
+	ls := ledger.CurrentState()
+	ls.CoreLatest = 50
+	ledger.SetState(ls)
+	code, res = get()
+	ht.Assert.Equal(200, code)
+	ht.Assert.Equal(resource.HealthDegraded, res.Status)
+	ht.Assert.Equal(int32(47), res.IngestionLag)
+
+	ls.CoreLatest = 200
+	ledger.SetState(ls)
+	code, res = get()
+	ht.Assert.Equal(503, code)
+	ht.Assert.Equal(resource.HealthUnhealthy, res.Status)
+	ht.Assert.Equal(int32(197), res.IngestionLag)
+
+	ls.CoreLatest = 3
+	ledger.SetState(ls)
+
+	// ledger state gone stale
+	ht.App.config.HealthMaxStateAge = time.Minute
+	stale := ls
+	stale.UpdatedAt = time.Now().Add(-time.Hour)
+	ledger.SetState(stale)
+	code, res = get()
+	ht.Assert.Equal(503, code)
+	ht.Assert.Equal(resource.HealthUnhealthy, res.Status)
+	ht.Assert.Equal(int32(0), res.IngestionLag)
+	ht.Assert.True(res.LedgerStateAge >= int64(time.Hour/time.Millisecond))
+
+	ls.UpdatedAt = time.Now()
+	ledger.SetState(ls)
+	code, res = get()
+	ht.Assert.Equal(200, code)
+	ht.Assert.Equal(resource.HealthHealthy, res.Status)
+
+	// ingestion stalled while within the lag thresholds
+	stalled := ls
+	stalled.CoreLatest = 5
+	ledger.SetState(stalled)
+	ht.App.ingestStall = &ledger.StallDetector{}
+	ht.App.ingestStall.Observe(stalled)
+	code, res = get()
+	ht.Assert.Equal(503, code)
+	ht.Assert.Equal(resource.HealthUnhealthy, res.Status)
+	ht.Assert.True(res.IngestionStalled)
+	ht.Assert.Equal(int32(2), res.IngestionLag)
+
+	ledger.SetState(ls)
+	ht.App.ingestStall.Observe(ls)
+	code, res = get()
+	ht.Assert.Equal(200, code)
+	ht.Assert.False(res.IngestionStalled)
+
+	// unreachable databases
+	unreachable, err := sqlx.Open("postgres", "postgres://127.0.0.1:1/horizon_test?sslmode=disable")
+	ht.Require.NoError(err)
+	defer unreachable.Close()
+
+	coreDB := ht.App.coreQ.Repo.DB
+	ht.App.coreQ.Repo.DB = unreachable
+	code, res = get()
+	ht.Assert.Equal(503, code)
+	ht.Assert.Equal(resource.HealthUnhealthy, res.Status)
+	ht.Assert.False(res.CoreDB.Reachable)
+	ht.Assert.True(res.HorizonDB.Reachable)
+
+	horizonDB := ht.App.historyQ.Repo.DB
+	ht.App.historyQ.Repo.DB = unreachable
+	code, res = get()
+	ht.Assert.Equal(503, code)
+	ht.Assert.False(res.CoreDB.Reachable)
+	ht.Assert.False(res.HorizonDB.Reachable)
+
+	ht.App.coreQ.Repo.DB = coreDB
+	code, res = get()
+	ht.Assert.Equal(503, code)
+	ht.Assert.True(res.CoreDB.Reachable)
+	ht.Assert.False(res.HorizonDB.Reachable)
+
+	ht.App.historyQ.Repo.DB = horizonDB
+	code, res = get()
+	ht.Assert.Equal(200, code)
+	ht.Assert.Equal(resource.HealthHealthy, res.Status)
+}
+
+func TestHealthStatus(t *testing.T) {
+	down := errors.New("connection refused")
+	stalled := ledger.Stall{Stalled: true, Behind: 5}
+	limits := resource.HealthLimits{
+		DegradedLag:  10,
+		UnhealthyLag: 100,
+		MaxStateAge:  time.Minute,
+	}
+
+	cases := []struct {
+		name       string
+		coreErr    error
+		horizonErr error
+		coreLatest int32
+		age        time.Duration
+		stall      ledger.Stall
+		limits     resource.HealthLimits
+		expected   string
+	}{
+		{"healthy", nil, nil, 105, 0, ledger.Stall{}, limits, resource.HealthHealthy},
+		{"at the degraded threshold", nil, nil, 110, 0, ledger.Stall{}, limits, resource.HealthHealthy},
+		{"beyond the degraded threshold", nil, nil, 111, 0, ledger.Stall{}, limits, resource.HealthDegraded},
+		{"at the unhealthy threshold", nil, nil, 200, 0, ledger.Stall{}, limits, resource.HealthDegraded},
+		{"beyond the unhealthy threshold", nil, nil, 201, 0, ledger.Stall{}, limits, resource.HealthUnhealthy},
+		{"core db down", down, nil, 100, 0, ledger.Stall{}, limits, resource.HealthUnhealthy},
+		{"horizon db down", nil, down, 100, 0, ledger.Stall{}, limits, resource.HealthUnhealthy},
+		{"both dbs down", down, down, 100, 0, ledger.Stall{}, limits, resource.HealthUnhealthy},
+		{"core db down while degraded", down, nil, 150, 0, ledger.Stall{}, limits, resource.HealthUnhealthy},
+		{"horizon db down while lagging", nil, down, 500, 0, ledger.Stall{}, limits, resource.HealthUnhealthy},
+		{"thresholds disabled", nil, nil, 10000, time.Hour, ledger.Stall{}, resource.HealthLimits{}, resource.HealthHealthy},
+		{"history ahead of core", nil, nil, 90, 0, ledger.Stall{}, limits, resource.HealthHealthy},
+		{"state at the maximum age", nil, nil, 100, time.Minute, ledger.Stall{}, limits, resource.HealthHealthy},
+		{"state beyond the maximum age", nil, nil, 100, time.Minute + 1, ledger.Stall{}, limits, resource.HealthUnhealthy},
+		{"stalled within the thresholds", nil, nil, 105, 0, stalled, limits, resource.HealthUnhealthy},
+		{"stalled with thresholds disabled", nil, nil, 105, 0, stalled, resource.HealthLimits{}, resource.HealthUnhealthy},
+	}
+
+	for _, kase := range cases {
+		ls := ledger.State{CoreLatest: kase.coreLatest, HistoryLatest: 100}
+
+		var res resource.Health
+		res.Populate(
+			context.Background(),
+			ls,
+			kase.age,
+			kase.stall,
+			kase.coreErr,
+			kase.horizonErr,
+			kase.limits,
+		)
+
+		assert.Equal(t, kase.expected, res.Status, kase.name)
+		assert.Equal(t, kase.coreErr == nil, res.CoreDB.Reachable, kase.name)
+		assert.Equal(t, kase.horizonErr == nil, res.HorizonDB.Reachable, kase.name)
+		assert.Equal(t, kase.stall.Stalled, res.IngestionStalled, kase.name)
+		assert.True(t, res.IngestionLag >= 0, kase.name)
+	}
+}
@@ -1,6 +1,10 @@
 package horizon
 
 import (
+	"errors"
+	"net/url"
+	"time"
+
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/ledger"
@@ -14,11 +18,14 @@ import (
 //
 // LedgerIndexAction: pages of ledgers
 // LedgerShowAction: single ledger by sequence
+// LedgerByTimeAction: single ledger by close time
+// LedgerUpgradeIndexAction: network upgrades applied by a single ledger
 
 // LedgerIndexAction renders a page of ledger resources, identified by
 // a normal page query.
 type LedgerIndexAction struct {
 	Action
+	Extended     bool
 	PagingParams db2.PageQuery
 	Records      []history.Ledger
 	Page         hal.Page
@@ -32,7 +39,7 @@ func (action *LedgerIndexAction) JSON() {
 		action.ValidateCursorWithinHistory,
 		action.loadRecords,
 		action.loadPage,
-		func() { hal.Render(action.W, action.Page) },
+		func() { hal.Render(action.Ctx, action.W, action.Page) },
 	)
 }
 
@@ -52,6 +59,9 @@ func (action *LedgerIndexAction) SSE(stream sse.Stream) {
 			for _, record := range records {
 				var res resource.Ledger
 				res.Populate(action.Ctx, record)
+				if action.Extended {
+					res.IncludeExtended(record)
+				}
 				stream.Send(sse.Event{ID: res.PagingToken(), Data: res})
 			}
 		},
@@ -60,6 +70,7 @@ func (action *LedgerIndexAction) SSE(stream sse.Stream) {
 
 func (action *LedgerIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
+	action.Extended = action.GetBool("extended")
 	action.PagingParams = action.GetPageQuery()
 }
 
@@ -73,6 +84,9 @@ func (action *LedgerIndexAction) loadPage() {
 	for _, record := range action.Records {
 		var res resource.Ledger
 		res.Populate(action.Ctx, record)
+		if action.Extended {
+			res.IncludeExtended(record)
+		}
 		action.Page.Add(res)
 	}
 
@@ -81,6 +95,10 @@ func (action *LedgerIndexAction) loadPage() {
 	action.Page.Limit = action.PagingParams.Limit
 	action.Page.Cursor = action.PagingParams.Cursor
 	action.Page.Order = action.PagingParams.Order
+	action.Page.Filters = url.Values{}
+	if action.Extended {
+		action.Page.Filters.Set("extended", "true")
+	}
 	action.Page.PopulateLinks()
 }
 
@@ -88,6 +106,7 @@ func (action *LedgerIndexAction) loadPage() {
 type LedgerShowAction struct {
 	Action
 	Sequence int32
+	Extended bool
 	Record   history.Ledger
 }
 
@@ -101,18 +120,23 @@ func (action *LedgerShowAction) JSON() {
 		func() {
 			var res resource.Ledger
 			res.Populate(action.Ctx, action.Record)
-			hal.Render(action.W, res)
+			if action.Extended {
+				res.IncludeExtended(action.Record)
+			}
+			hal.Render(action.Ctx, action.W, res)
 		},
 	)
 }
 
 func (action *LedgerShowAction) loadParams() {
 	action.Sequence = action.GetInt32("id")
+	action.Extended = action.GetBool("extended")
 }
 
 func (action *LedgerShowAction) loadRecord() {
 	action.Err = action.HistoryQ().
 		LedgerBySequence(&action.Record, action.Sequence)
+	action.HealLedger(action.Sequence)
 }
 
 func (action *LedgerShowAction) verifyWithinHistory() {
@@ -120,3 +144,106 @@ func (action *LedgerShowAction) verifyWithinHistory() {
 		action.Err = &problem.BeforeHistory
 	}
 }
+
+// LedgerByTimeAction renders the latest ledger that closed at or before the
+// time given by the `at` parameter, allowing a point in time to be mapped to
+// a ledger sequence.
+type LedgerByTimeAction struct {
+	Action
+	At     time.Time
+	Record history.Ledger
+}
+
+// JSON is a method for actions.JSON
+func (action *LedgerByTimeAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecord,
+		func() {
+			var res resource.Ledger
+			res.Populate(action.Ctx, action.Record)
+			hal.Render(action.Ctx, action.W, res)
+		},
+	)
+}
+
+func (action *LedgerByTimeAction) loadParams() {
+	action.At = action.GetTime("at")
+
+	if action.Err == nil && action.At.IsZero() {
+		action.SetInvalidField("at", errMissingTime)
+	}
+}
+
+// loadRecord loads the ledger, responding with a 410 Gone when the requested
+// time precedes the recorded history, since an earlier ledger may exist that
+// this horizon has not ingested.
+func (action *LedgerByTimeAction) loadRecord() {
+	q := action.HistoryQ()
+	err := q.LedgerByCloseTime(&action.Record, action.At)
+
+	if q.NoRows(err) && ledger.CurrentState().HistoryElder > 1 {
+		action.Err = &problem.BeforeHistory
+		return
+	}
+
+	action.Err = err
+}
+
+var errMissingTime = errors.New("must be a time in RFC 3339 format, such as 2016-09-01T12:00:00Z")
+
+// LedgerUpgradeIndexAction renders the network upgrades that were applied
+// when the ledger identified by its sequence number closed.
+type LedgerUpgradeIndexAction struct {
+	Action
+	Sequence int32
+	Ledger   history.Ledger
+	Records  []history.LedgerUpgrade
+	Page     hal.BasePage
+}
+
+// JSON is a method for actions.JSON
+func (action *LedgerUpgradeIndexAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.verifyWithinHistory,
+		action.loadLedger,
+		action.loadRecords,
+		action.loadPage,
+		func() { hal.Render(action.Ctx, action.W, action.Page) },
+	)
+}
+
+func (action *LedgerUpgradeIndexAction) loadParams() {
+	action.Sequence = action.GetInt32("ledger_id")
+}
+
+func (action *LedgerUpgradeIndexAction) verifyWithinHistory() {
+	if action.Sequence < ledger.CurrentState().HistoryElder {
+		action.Err = &problem.BeforeHistory
+	}
+}
+
+// loadLedger ensures the requested ledger has been ingested, so that an
+// unknown ledger renders as a 404 rather than an empty page.
+func (action *LedgerUpgradeIndexAction) loadLedger() {
+	action.Err = action.HistoryQ().
+		LedgerBySequence(&action.Ledger, action.Sequence)
+	action.HealLedger(action.Sequence)
+}
+
+func (action *LedgerUpgradeIndexAction) loadRecords() {
+	action.Err = action.HistoryQ().
+		LedgerUpgradesBySequence(&action.Records, action.Sequence)
+}
+
+func (action *LedgerUpgradeIndexAction) loadPage() {
+	action.Page.Init()
+	for _, record := range action.Records {
+		var res resource.LedgerUpgrade
+		res.Populate(action.Ctx, record)
+		action.Page.Add(res)
+	}
+}
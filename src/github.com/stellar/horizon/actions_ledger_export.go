@@ -0,0 +1,255 @@
+package horizon
+
+import (
+	"net/http"
+
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/ndjson"
+	"github.com/stellar/horizon/render/problem"
+	"github.com/stellar/horizon/resource"
+)
+
+// This file contains the actions:
+//
+// LedgerExportAction: the full contents of a single ledger by sequence
+
+// ledgerExportMaxRecords is the most transactions, operations and effects, in
+// all, that a ledger exported as a single document may hold.  Larger ledgers
+// must be exported as newline delimited JSON, which is not bounded.
+const ledgerExportMaxRecords = 10000
+
+// ledgerTooLargeToExport is the problem rendered when a ledger holds more than
+// ledgerExportMaxRecords records to export as a single document.
+var ledgerTooLargeToExport = problem.P{
+	Type:   "response_too_large",
+	Title:  "Response Too Large",
+	Status: http.StatusInternalServerError,
+	Detail: "The ledger requested holds too many transactions, operations and " +
+		"effects to export as a single document.  Please request its export " +
+		"as newline delimited JSON by setting the Accept header to " +
+		"`application/x-ndjson`.",
+}
+
+// LedgerExportAction renders the full contents of a ledger found by its
+// sequence number:  its header, and every transaction it applied, failed ones
+// included, with their operations and effects.  They are either nested within
+// a single document, or, when newline delimited JSON is requested, streamed a
+// record per line in the order of the ledger, its transactions, its operations
+// and then its effects.
+type LedgerExportAction struct {
+	Action
+	Sequence   int32
+	IncludeRaw []string
+	Ledger     history.Ledger
+	Resource   resource.LedgerExport
+}
+
+// JSON is a method for actions.JSON
+func (action *LedgerExportAction) JSON() {
+	action.Do(
+		action.EnsureEffectsEnabled,
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.verifyWithinHistory,
+		action.loadLedger,
+		action.loadResource,
+		func() { hal.Render(action.Ctx, action.W, action.Resource) },
+	)
+}
+
+// NDJSON is a method for actions.NDJSON
+func (action *LedgerExportAction) NDJSON(w *ndjson.Writer) {
+	action.Do(
+		action.EnsureEffectsEnabled,
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.verifyWithinHistory,
+		action.loadLedger,
+		func() { action.writeLines(w) },
+	)
+}
+
+func (action *LedgerExportAction) loadParams() {
+	action.Sequence = action.GetInt32("id")
+	action.IncludeRaw = action.getIncludeRaw()
+}
+
+func (action *LedgerExportAction) verifyWithinHistory() {
+	if action.Sequence < ledger.CurrentState().HistoryElder {
+		action.Err = &problem.BeforeHistory
+	}
+}
+
+func (action *LedgerExportAction) loadLedger() {
+	action.Err = action.HistoryQ().
+		LedgerBySequence(&action.Ledger, action.Sequence)
+	action.HealLedger(action.Sequence)
+}
+
+// loadResource loads the ledger's records into action.Resource, failing with
+// ledgerTooLargeToExport once there are more than ledgerExportMaxRecords.
+func (action *LedgerExportAction) loadResource() {
+	var (
+		txs     []history.Transaction
+		ops     []history.Operation
+		effects []history.Effect
+	)
+
+	count := 0
+	tooLarge := func(n int) bool {
+		count += n
+		if count > ledgerExportMaxRecords {
+			action.Err = &ledgerTooLargeToExport
+			return true
+		}
+		return false
+	}
+
+	action.eachTransactions(func(records []history.Transaction) {
+		if !tooLarge(len(records)) {
+			txs = append(txs, records...)
+		}
+	})
+	action.eachOperations(func(records []history.Operation) {
+		if !tooLarge(len(records)) {
+			ops = append(ops, records...)
+		}
+	})
+	action.eachEffects(func(records []history.Effect) {
+		if !tooLarge(len(records)) {
+			effects = append(effects, records...)
+		}
+	})
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.Resource.Populate(
+		action.Ctx, action.Ledger, txs, ops, effects, action.IncludeRaw,
+	)
+}
+
+// writeLines writes the ledger's records to `w`, each as an object whose sole
+// attribute, named for the kind of record, is its resource.
+func (action *LedgerExportAction) writeLines(w *ndjson.Writer) {
+	write := func(kind string, res interface{}) {
+		if action.Err == nil {
+			action.Err = w.Write(map[string]interface{}{kind: res})
+		}
+	}
+
+	var header resource.Ledger
+	header.Populate(action.Ctx, action.Ledger)
+	write("ledger", header)
+
+	action.eachTransactions(func(records []history.Transaction) {
+		for _, record := range records {
+			var res resource.Transaction
+			res.Populate(action.Ctx, record)
+			res.IncludeRaw(record, action.IncludeRaw)
+			write("transaction", res)
+		}
+	})
+	action.eachOperations(func(records []history.Operation) {
+		for _, record := range records {
+			res, err := resource.NewOperation(action.Ctx, record)
+			if err != nil {
+				action.Err = err
+				return
+			}
+			write("operation", res)
+		}
+	})
+	action.eachEffects(func(records []history.Effect) {
+		for _, record := range records {
+			res, err := resource.NewEffect(action.Ctx, record)
+			if err != nil {
+				action.Err = err
+				return
+			}
+			write("effect", res)
+		}
+	})
+}
+
+// ledgerExportPage returns the query for the first page of the records of a
+// ledger.
+func ledgerExportPage() db2.PageQuery {
+	return db2.PageQuery{Order: db2.OrderAscending, Limit: db2.MaxPageSize}
+}
+
+// eachTransactions calls `fn` with each page of the ledger's transactions,
+// failed ones included, in the order they were applied, until every page is
+// loaded or the action fails.
+func (action *LedgerExportAction) eachTransactions(fn func([]history.Transaction)) {
+	page := ledgerExportPage()
+	for action.Err == nil {
+		var records []history.Transaction
+		action.Err = action.HistoryQ().Transactions().
+			ForLedger(action.Sequence).
+			IncludeFailed().
+			Page(page).
+			Select(&records)
+		if action.Err != nil || len(records) == 0 {
+			return
+		}
+
+		action.loadRawFromCore(records, action.IncludeRaw)
+		if action.Err != nil {
+			return
+		}
+
+		fn(records)
+		if uint64(len(records)) < page.Limit {
+			return
+		}
+		page.Cursor = records[len(records)-1].PagingToken()
+	}
+}
+
+// eachOperations calls `fn` with each page of the ledger's operations, in the
+// order they were applied, until every page is loaded or the action fails.
+func (action *LedgerExportAction) eachOperations(fn func([]history.Operation)) {
+	page := ledgerExportPage()
+	for action.Err == nil {
+		var records []history.Operation
+		action.Err = action.HistoryQ().Operations().
+			ForLedger(action.Sequence).
+			Page(page).
+			Select(&records)
+		if action.Err != nil || len(records) == 0 {
+			return
+		}
+
+		fn(records)
+		if uint64(len(records)) < page.Limit {
+			return
+		}
+		page.Cursor = records[len(records)-1].PagingToken()
+	}
+}
+
+// eachEffects calls `fn` with each page of the ledger's effects, in the order
+// they were applied, until every page is loaded or the action fails.
+func (action *LedgerExportAction) eachEffects(fn func([]history.Effect)) {
+	page := ledgerExportPage()
+	for action.Err == nil {
+		var records []history.Effect
+		action.Err = action.HistoryQ().Effects().
+			ForLedger(action.Sequence).
+			Page(page).
+			Select(&records)
+		if action.Err != nil || len(records) == 0 {
+			return
+		}
+
+		fn(records)
+		if uint64(len(records)) < page.Limit {
+			return
+		}
+		page.Cursor = records[len(records)-1].PagingToken()
+	}
+}
@@ -2,9 +2,11 @@ package horizon
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stellar/horizon/resource"
+	"github.com/stellar/horizon/test"
 )
 
 func TestLedgerActions_Index(t *testing.T) {
@@ -48,3 +50,199 @@ func TestLedgerActions_Show(t *testing.T) {
 	w = ht.Get("/ledgers/1")
 	ht.Assert.Equal(410, w.Code)
 }
+
+func TestLedgerActions_Extended(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// not included unless requested
+	w := ht.Get("/ledgers/2")
+	if ht.Assert.Equal(200, w.Code) {
+		var result map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		if ht.Assert.NoError(err) {
+			ht.Assert.NotContains(result, "protocol_version")
+			ht.Assert.NotContains(result, "ingest_duration_ms")
+		}
+	}
+
+	// ledgers ingested with their stats
+	w = ht.Get("/ledgers/2?extended=true")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.Ledger
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		if ht.Assert.NoError(err) && ht.Assert.NotNil(result.LedgerExtended) {
+			ht.Assert.Equal(int64(2), result.ProtocolVersion.Int64)
+			ht.Assert.Equal(int32(3), result.SuccessfulTransactionCount)
+			ht.Assert.True(result.FailedTransactionCount.Valid)
+			ht.Assert.Equal(int64(0), result.FailedTransactionCount.Int64)
+			ht.Assert.Equal(int64(17), result.IngestDurationMs.Int64)
+			ht.Assert.False(result.IngestedAt.IsZero())
+		}
+	}
+
+	w = ht.Get("/ledgers?extended=true&limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		var page struct {
+			Embedded struct {
+				Records []resource.Ledger `json:"records"`
+			} `json:"_embedded"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &page)
+		if ht.Assert.NoError(err) && ht.Assert.Len(page.Embedded.Records, 1) {
+			record := page.Embedded.Records[0]
+			if ht.Assert.NotNil(record.LedgerExtended) {
+				ht.Assert.True(record.ProtocolVersion.Valid)
+			}
+		}
+		ht.Assert.Contains(w.Body.String(), "extended=true")
+	}
+
+	// ledgers ingested before their stats were recorded
+	ht.Scenario("kahuna")
+	ht.App.UpdateLedgerState()
+	w = ht.Get("/ledgers/2?extended=true")
+	if ht.Assert.Equal(200, w.Code) {
+		var result map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		if ht.Assert.NoError(err) {
+			ht.Assert.Contains(result, "protocol_version")
+			ht.Assert.Nil(result["protocol_version"])
+			ht.Assert.Nil(result["failed_transaction_count"])
+			ht.Assert.Nil(result["ingest_duration_ms"])
+			ht.Assert.NotNil(result["successful_transaction_count"])
+		}
+	}
+}
+
+func TestLedgerActions_ByTime(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	cases := []struct {
+		At       string
+		Sequence int32
+	}{
+		{"2016-06-29T16:33:54Z", 2},
+		{"2016-06-29T16:33:54.5Z", 2},
+		{"2016-06-29T18:33:55%2B02:00", 3},
+		{"2020-01-01T00:00:00Z", 3},
+		{"1970-01-01T00:00:00Z", 1},
+	}
+
+	for _, c := range cases {
+		w := ht.Get("/ledgers/by_time?at=" + c.At)
+		if ht.Assert.Equal(200, w.Code, c.At) {
+			var result resource.Ledger
+			err := json.Unmarshal(w.Body.Bytes(), &result)
+			if ht.Assert.NoError(err) {
+				ht.Assert.Equal(c.Sequence, result.Sequence, c.At)
+			}
+		}
+	}
+
+	// before the first ledger
+	w := ht.Get("/ledgers/by_time?at=1960-01-01T00:00:00Z")
+	ht.Assert.Equal(404, w.Code)
+
+	// missing or malformed time
+	w = ht.Get("/ledgers/by_time")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/ledgers/by_time?at=yesterday")
+	ht.Assert.Equal(400, w.Code)
+
+	// time of a ledger that was reaped
+	ht.ReapHistory(1)
+
+	w = ht.Get("/ledgers/by_time?at=2016-06-29T16:33:54Z")
+	ht.Assert.Equal(410, w.Code)
+}
+
+func TestLedgerActions_Upgrades(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/ledgers/2/upgrades")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+	}
+
+	w = ht.Get("/ledgers/3/upgrades")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// ledger higher than history
+	w = ht.Get("/ledgers/100/upgrades")
+	ht.Assert.Equal(404, w.Code)
+}
+
+func TestLedgerActions_Export(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	var result struct {
+		Ledger       resource.Ledger `json:"ledger"`
+		Transactions []struct {
+			Transaction resource.Transaction `json:"transaction"`
+			Operations  []struct {
+				Operation map[string]interface{}   `json:"operation"`
+				Effects   []map[string]interface{} `json:"effects"`
+			} `json:"operations"`
+		} `json:"transactions"`
+	}
+
+	w := ht.Get("/ledgers/2/export")
+	if !ht.Assert.Equal(200, w.Code) {
+		return
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	if !ht.Assert.NoError(err) {
+		return
+	}
+
+	ht.Assert.Equal(int32(2), result.Ledger.Sequence)
+	ht.Assert.Len(result.Transactions, int(result.Ledger.TransactionCount))
+
+	ops, effects := 0, 0
+	for _, tx := range result.Transactions {
+		ht.Assert.Equal(int32(2), tx.Transaction.Ledger)
+		for _, op := range tx.Operations {
+			links := op.Operation["_links"].(map[string]interface{})
+			href := links["transaction"].(map[string]interface{})["href"].(string)
+			ht.Assert.True(strings.HasSuffix(href, "/transactions/"+tx.Transaction.Hash))
+			ops++
+			effects += len(op.Effects)
+		}
+	}
+	ht.Assert.Equal(3, ops)
+	ht.Assert.Equal(9, effects)
+
+	// as newline delimited JSON
+	w = ht.Get("/ledgers/2/export", test.RequestHelperHeader("Accept", "application/x-ndjson"))
+	if ht.Assert.Equal(200, w.Code) {
+		lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+		kinds := map[string]int{}
+		for _, line := range lines {
+			var record map[string]json.RawMessage
+			if ht.Assert.NoError(json.Unmarshal([]byte(line), &record)) && ht.Assert.Len(record, 1) {
+				for kind := range record {
+					kinds[kind]++
+				}
+			}
+		}
+
+		ht.Assert.Equal(map[string]int{
+			"ledger":      1,
+			"transaction": len(result.Transactions),
+			"operation":   3,
+			"effect":      9,
+		}, kinds)
+	}
+
+	// ledger higher than history
+	w = ht.Get("/ledgers/100/export")
+	ht.Assert.Equal(404, w.Code)
+	w = ht.Get("/ledgers/100/export", test.RequestHelperHeader("Accept", "application/x-ndjson"))
+	ht.Assert.Equal(404, w.Code)
+}
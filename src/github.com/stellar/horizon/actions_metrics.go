@@ -1,10 +1,158 @@
 package horizon
 
 import (
+	"strings"
+
 	"github.com/rcrowley/go-metrics"
 	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/prometheus"
 )
 
+// prometheusPrefix prefixes the names of the metrics horizon exposes to
+// prometheus.
+const prometheusPrefix = "horizon_"
+
+// prometheusDescs describes how the metrics of the app's registry are exposed
+// to prometheus, by their name in the registry.  Metrics missing from it are
+// exposed under a name derived from their name in the registry.
+var prometheusDescs = map[string]prometheus.Desc{
+	"history.latest_ledger": {
+		Name: "horizon_history_latest_ledger",
+		Help: "The sequence of the latest ledger in the history database.",
+	},
+	"history.elder_ledger": {
+		Name: "horizon_history_elder_ledger",
+		Help: "The sequence of the oldest ledger in the history database.",
+	},
+	"stellar_core.latest_ledger": {
+		Name: "horizon_stellar_core_latest_ledger",
+		Help: "The sequence of the latest ledger in the stellar-core database.",
+	},
+	"stellar_core.elder_ledger": {
+		Name: "horizon_stellar_core_elder_ledger",
+		Help: "The sequence of the oldest ledger in the stellar-core database.",
+	},
+	"history.open_connections": {
+		Name: "horizon_history_db_open_connections",
+		Help: "The number of open connections to the horizon database.",
+	},
+	"stellar_core.open_connections": {
+		Name: "horizon_stellar_core_db_open_connections",
+		Help: "The number of open connections to the stellar-core database.",
+	},
+	"stellar_core.healthy": {
+		Name: "horizon_stellar_core_db_healthy",
+		Help: "1 if the latest health check of the stellar-core database succeeded, otherwise 0.",
+	},
+	"stellar_core.selected_db": {
+		Name: "horizon_stellar_core_db_selected",
+		Help: "The position of the stellar-core database in use among those configured, 0 being the primary.",
+	},
+	"history.ingestion_stalled": {
+		Name: "horizon_ingestion_stalled",
+		Help: "1 if ingestion has stalled behind stellar-core, otherwise 0.",
+	},
+	"goroutines": {
+		Name: "horizon_goroutines",
+		Help: "The number of goroutines.",
+	},
+	"db.queries": {
+		Name: "horizon_db_queries_total",
+		Help: "The number of statements run against either database.",
+	},
+	"db.slow_queries": {
+		Name: "horizon_db_slow_queries_total",
+		Help: "The number of statements that ran longer than the slow query threshold.",
+	},
+	"ingester.ingest_ledger": {
+		Name: "horizon_ingest_ledger_duration_seconds",
+		Help: "The time taken to ingest a ledger.",
+	},
+	"ingester.clear_ledger": {
+		Name: "horizon_ingest_clear_ledger_duration_seconds",
+		Help: "The time taken to clear a ledger's history before reingesting it.",
+	},
+	"ingester.load_ledger": {
+		Name: "horizon_ingest_load_ledger_duration_seconds",
+		Help: "The time taken to load a ledger from the stellar-core database for ingestion.",
+	},
+	"txsub.buffered": {
+		Name: "horizon_txsub_buffered_submissions",
+		Help: "The number of submissions waiting on the sequence number of their source account.",
+	},
+	"txsub.open": {
+		Name: "horizon_txsub_open_submissions",
+		Help: "The number of submissions awaiting their result.",
+	},
+	"txsub.succeeded": {
+		Name: "horizon_txsub_succeeded_total",
+		Help: "The number of submissions stellar-core accepted.",
+	},
+	"txsub.failed": {
+		Name: "horizon_txsub_failed_total",
+		Help: "The number of submissions stellar-core rejected.",
+	},
+	"txsub.total": {
+		Name: "horizon_txsub_submission_duration_seconds",
+		Help: "The time taken to submit a transaction to stellar-core.",
+	},
+	"requests.total": {
+		Name: "horizon_http_requests_duration_seconds",
+		Help: "The time taken to serve a request, for all requests.",
+	},
+	"requests.succeeded": {
+		Name: "horizon_http_requests_succeeded_total",
+		Help: "The number of requests served with a 2xx or 3xx status.",
+	},
+	"requests.failed": {
+		Name: "horizon_http_requests_failed_total",
+		Help: "The number of requests served with a 4xx or 5xx status.",
+	},
+	"requests.over_capacity": {
+		Name: "horizon_http_requests_over_capacity_total",
+		Help: "The number of requests rejected for exceeding the concurrent request limit.",
+	},
+	"sse.open_streams": {
+		Name: "horizon_sse_open_streams",
+		Help: "The number of open event streams.",
+		Type: prometheus.Gauge,
+	},
+	"sse.streams": {
+		Name: "horizon_sse_streams_total",
+		Help: "The number of event streams opened.",
+	},
+	"sse.over_capacity": {
+		Name: "horizon_sse_streams_over_capacity_total",
+		Help: "The number of stream requests rejected for exceeding the concurrent stream limit.",
+	},
+}
+
+// routeDurationDesc describes the per route timers of the web server.
+var routeDurationDesc = prometheus.Desc{
+	Name: "horizon_http_route_duration_seconds",
+	Help: "The time taken to serve a request, by the route that served it.",
+}
+
+// prometheusDesc returns the description of the registry's metric `name`.
+func prometheusDesc(name string) prometheus.Desc {
+	if d, ok := prometheusDescs[name]; ok {
+		return d
+	}
+
+	if strings.HasPrefix(name, "logging.") {
+		return prometheus.Desc{
+			Name:   "horizon_log_messages_total",
+			Help:   "The number of messages logged, by level.",
+			Labels: prometheus.Labels{"level": strings.TrimPrefix(name, "logging.")},
+		}
+	}
+
+	return prometheus.Desc{
+		Name: prometheus.Name(prometheusPrefix + name),
+		Help: "The " + name + " metric.",
+	}
+}
+
 // MetricsAction collects and renders a snapshot from the metrics system that
 // will inlude any previously registered metrics.
 type MetricsAction struct {
@@ -19,7 +167,26 @@ func (action *MetricsAction) JSON() {
 		"self": hal.NewLink("/metrics"),
 	}
 
-	hal.Render(action.W, action.Snapshot)
+	hal.Render(action.Ctx, action.W, action.Snapshot)
+}
+
+// Text renders the metrics in the prometheus text exposition format, which
+// prometheus requests when scraping.
+func (action *MetricsAction) Text() {
+	var c prometheus.Collector
+
+	action.App.metrics.Each(func(name string, i interface{}) {
+		c.Add(prometheusDesc(name), i)
+	})
+
+	action.App.web.routeTimers.Each(func(key string, i interface{}) {
+		d := routeDurationDesc
+		parts := strings.SplitN(key, " ", 2)
+		d.Labels = prometheus.Labels{"method": parts[0], "route": parts[1]}
+		c.Add(d, i)
+	})
+
+	prometheus.Render(action.W, c.Families())
 }
 
 // LoadSnapshot populates action.Snapshot
@@ -0,0 +1,75 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stellar/horizon/render/prometheus"
+)
+
+func TestMetricsAction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// the json snapshot remains the default
+	w := ht.Get("/metrics")
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.Equal("application/hal+json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	var snapshot map[string]interface{}
+	ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &snapshot))
+	ht.Assert.Contains(snapshot, "requests.total")
+}
+
+func TestMetricsAction_Prometheus(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	ht.Assert.Equal(200, ht.Get("/ledgers").Code)
+	ht.Assert.Equal(404, ht.Get("/not_a_route").Code)
+
+	// the accept header prometheus sends when scraping
+	w := ht.Get("/metrics", func(r *http.Request) {
+		r.Header.Set("Accept", "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,text/plain;version=0.0.4;q=0.3,*/*;q=0.1")
+	})
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.Equal(prometheus.ContentType, w.Header().Get("Content-Type"))
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(w.Body)
+	ht.Require.NoError(err)
+
+	for name, f := range families {
+		ht.Assert.True(strings.HasPrefix(name, prometheusPrefix), name)
+		ht.Assert.NotEmpty(f.GetHelp(), name)
+	}
+
+	// every metric horizon registers is described
+	ht.App.metrics.Each(func(name string, i interface{}) {
+		if strings.HasPrefix(name, "logging.") {
+			return
+		}
+		ht.Assert.Contains(prometheusDescs, name)
+	})
+
+	ht.Assert.Equal(dto.MetricType_SUMMARY, families["horizon_http_requests_duration_seconds"].GetType())
+	ht.Assert.Equal(dto.MetricType_GAUGE, families["horizon_sse_open_streams"].GetType())
+	ht.Assert.Equal(dto.MetricType_COUNTER, families["horizon_log_messages_total"].GetType())
+
+	// requests are timed by the route that served them, unmatched requests
+	// being left out.
+	routes := map[string]uint64{}
+	for _, m := range families["horizon_http_route_duration_seconds"].GetMetric() {
+		labels := map[string]string{}
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		routes[labels["method"]+" "+labels["route"]] = m.GetSummary().GetSampleCount()
+	}
+	ht.Assert.Equal(uint64(1), routes["GET /ledgers"])
+	ht.Assert.NotContains(routes, "GET /not_a_route")
+}
@@ -28,7 +28,7 @@ func (action *OffersByAccountAction) JSON() {
 		action.loadRecords,
 		action.loadPage,
 		func() {
-			hal.Render(action.W, action.Page)
+			hal.Render(action.Ctx, action.W, action.Page)
 		},
 	)
 }
@@ -1,6 +1,14 @@
 package horizon
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/ledger"
@@ -8,6 +16,7 @@ import (
 	"github.com/stellar/horizon/render/problem"
 	"github.com/stellar/horizon/render/sse"
 	"github.com/stellar/horizon/resource"
+	"github.com/stellar/horizon/resource/operations"
 	"github.com/stellar/horizon/toid"
 )
 
@@ -15,18 +24,24 @@ import (
 //
 // OperationIndexAction: pages of operations
 // OperationShowAction: single operation by id
+// OperationLocatorAction: the position named by an operation id
 
 // OperationIndexAction renders a page of operations resources, identified by
 // a normal page query and optionally filtered by an account, ledger, or
-// transaction.
+// transaction, and by the asset involved.
 type OperationIndexAction struct {
 	Action
 	LedgerFilter      int32
 	AccountFilter     string
 	TransactionFilter string
+	AssetFilter       xdr.Asset
+	HasAssetFilter    bool
+	TypeFilter        []xdr.OperationType
 	PagingParams      db2.PageQuery
 	Records           []history.Operation
 	Page              hal.Page
+
+	txDeadline time.Time
 }
 
 // JSON is a method for actions.JSON
@@ -38,19 +53,26 @@ func (action *OperationIndexAction) JSON() {
 		action.loadRecords,
 		action.loadPage)
 	action.Do(func() {
-		hal.Render(action.W, action.Page)
+		hal.Render(action.Ctx, action.W, action.Page)
 	})
 }
 
-// SSE is a method for actions.SSE
+// SSE is a method for actions.SSE.  A stream of the operations of a
+// transaction that has yet to be ingested waits for it, for up to the
+// configured TransactionStreamTimeout, so that a client may follow a
+// transaction it has just submitted.
 func (action *OperationIndexAction) SSE(stream sse.Stream) {
 	action.Setup(
 		action.EnsureHistoryFreshness,
 		action.loadParams,
 		action.ValidateCursorWithinHistory,
 	)
+	action.Do(action.loadRecords)
+	if action.awaitingTransaction() {
+		return
+	}
+
 	action.Do(
-		action.loadRecords,
 		func() {
 			stream.SetLimit(int(action.PagingParams.Limit))
 			records := action.Records[stream.SentCount():]
@@ -72,11 +94,48 @@ func (action *OperationIndexAction) SSE(stream sse.Stream) {
 
 }
 
+// awaitingTransaction returns true if the action streams the operations of a
+// transaction that has yet to be ingested, clearing the resulting not found
+// error so that the stream remains open.  Once TransactionStreamTimeout has
+// elapsed since the request began, the action gives up, responding with a
+// not_found problem.
+func (action *OperationIndexAction) awaitingTransaction() bool {
+	if action.TransactionFilter == "" || !action.HistoryQ().NoRows(action.Err) {
+		return false
+	}
+
+	timeout := action.App.config.TransactionStreamTimeout
+	if timeout <= 0 {
+		return false
+	}
+
+	if action.txDeadline.IsZero() {
+		action.txDeadline = time.Now().Add(timeout)
+		action.Recheck = time.After(timeout)
+	}
+
+	if !time.Now().Before(action.txDeadline) {
+		p := problem.NotFound
+		p.Detail = fmt.Sprintf(
+			"The transaction %s was not ingested within %s.",
+			action.TransactionFilter,
+			timeout,
+		)
+		action.Err = &p
+		return false
+	}
+
+	action.Err = nil
+	return true
+}
+
 func (action *OperationIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
+	action.AssetFilter, action.HasAssetFilter = action.GetCanonicalAsset("asset")
+	action.TypeFilter = action.getOperationTypes("type", operationTypes)
 	action.PagingParams = action.GetPageQuery()
 }
 
@@ -93,7 +152,18 @@ func (action *OperationIndexAction) loadRecords() {
 		ops.ForTransaction(action.TransactionFilter)
 	}
 
+	if action.HasAssetFilter {
+		ops.ForAsset(action.AssetFilter)
+	}
+
+	if len(action.TypeFilter) > 0 {
+		ops.ForTypes(action.TypeFilter)
+	}
+
 	action.Err = ops.Page(action.PagingParams).Select(&action.Records)
+	if action.LedgerFilter > 0 {
+		action.HealLedger(action.LedgerFilter)
+	}
 }
 
 func (action *OperationIndexAction) loadPage() {
@@ -111,9 +181,105 @@ func (action *OperationIndexAction) loadPage() {
 	action.Page.Limit = action.PagingParams.Limit
 	action.Page.Cursor = action.PagingParams.Cursor
 	action.Page.Order = action.PagingParams.Order
+	action.Page.Filters = action.filters()
 	action.Page.PopulateLinks()
 }
 
+// filters returns the filters that were applied to the request, for
+// preservation in the page links.
+func (action *OperationIndexAction) filters() url.Values {
+	f := url.Values{}
+	if v := action.GetString("asset"); v != "" {
+		f.Set("asset", v)
+	}
+	if len(action.TypeFilter) > 0 {
+		f.Set("type", operationTypeList(action.TypeFilter))
+	}
+	return f
+}
+
+// operationTypes are the types of every operation horizon knows of.
+var operationTypes = func() []xdr.OperationType {
+	types := make([]xdr.OperationType, 0, len(operations.TypeNames))
+	for typ := range operations.TypeNames {
+		types = append(types, typ)
+	}
+	return types
+}()
+
+// getOperationTypes retrieves the operation types named by the comma separated
+// list `name` from the request, using the names of horizon's operation
+// resources (see operations.TypeNames).  A type that is not among `valid`,
+// whether unknown or not accepted by the endpoint, such as a non-payment type
+// given to the payments endpoints, fails the action with a bad request, whose
+// `valid_values` extra lists the names of the valid types.  An absent list
+// yields nil.
+func (action *Action) getOperationTypes(
+	name string,
+	valid []xdr.OperationType,
+) []xdr.OperationType {
+	list := action.GetString(name)
+	if action.Err != nil || list == "" {
+		return nil
+	}
+
+	byName := map[string]xdr.OperationType{}
+	validNames := make([]string, 0, len(valid))
+	for _, typ := range valid {
+		byName[operations.TypeNames[typ]] = typ
+		validNames = append(validNames, operations.TypeNames[typ])
+	}
+	sort.Strings(validNames)
+
+	var types []xdr.OperationType
+	seen := map[xdr.OperationType]bool{}
+	for _, typName := range strings.Split(list, ",") {
+		typName = strings.TrimSpace(typName)
+		typ, ok := byName[typName]
+		if !ok {
+			reason := "unknown operation type"
+			if isOperationTypeName(typName) {
+				reason = "operation type not accepted by this endpoint"
+			}
+			action.SetInvalidField(name, fmt.Errorf(
+				"%s %q, expected a comma separated list of: %s",
+				reason,
+				typName,
+				strings.Join(validNames, ", "),
+			))
+			action.Err.(*problem.P).Extras["valid_values"] = validNames
+			return nil
+		}
+
+		if !seen[typ] {
+			seen[typ] = true
+			types = append(types, typ)
+		}
+	}
+
+	return types
+}
+
+// isOperationTypeName returns true if `name` names a type of operation horizon
+// knows of.
+func isOperationTypeName(name string) bool {
+	for _, typ := range operationTypes {
+		if operations.TypeNames[typ] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// operationTypeList returns the comma separated list of the names of `types`.
+func operationTypeList(types []xdr.OperationType) string {
+	names := make([]string, len(types))
+	for i, typ := range types {
+		names[i] = operations.TypeNames[typ]
+	}
+	return strings.Join(names, ",")
+}
+
 // OperationShowAction renders a ledger found by its sequence number.
 type OperationShowAction struct {
 	Action
@@ -144,7 +310,7 @@ func (action *OperationShowAction) JSON() {
 		action.loadResource,
 	)
 	action.Do(func() {
-		hal.Render(action.W, action.Resource)
+		hal.Render(action.Ctx, action.W, action.Resource)
 	})
 }
 
@@ -154,3 +320,56 @@ func (action *OperationShowAction) verifyWithinHistory() {
 		action.Err = &problem.BeforeHistory
 	}
 }
+
+// OperationLocatorAction renders an operation id, such as one found in a
+// paging token, decoded into the ledger, transaction and operation it names.
+// The id is decoded whether or not the operation exists, but the transaction
+// is linked only when it is found in history.
+type OperationLocatorAction struct {
+	Action
+	ID          int64
+	Transaction *history.Transaction
+	Resource    resource.OperationLocator
+}
+
+// JSON is a method for actions.JSON
+func (action *OperationLocatorAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadTransaction,
+		func() {
+			action.Resource.Populate(action.Ctx, action.ID, action.Transaction)
+			hal.Render(action.Ctx, action.W, action.Resource)
+		},
+	)
+}
+
+func (action *OperationLocatorAction) loadParams() {
+	action.ID = action.GetInt64("id")
+	if action.Err == nil && action.ID <= 0 {
+		action.SetInvalidField("id", errors.New("must be a positive operation id"))
+	}
+}
+
+// loadTransaction loads the transaction the id names, if any, leaving
+// action.Transaction nil should it not be found.
+func (action *OperationLocatorAction) loadTransaction() {
+	parsed := toid.Parse(action.ID)
+	if parsed.TransactionOrder == 0 {
+		return
+	}
+
+	id := toid.New(parsed.LedgerSequence, parsed.TransactionOrder, 0).ToInt64()
+
+	var tx history.Transaction
+	err := action.HistoryQ().TransactionByID(&tx, id)
+	switch {
+	case action.HistoryQ().NoRows(err):
+		return
+	case err != nil:
+		action.Err = err
+		return
+	}
+
+	action.Transaction = &tx
+}
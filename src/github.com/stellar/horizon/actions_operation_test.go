@@ -2,8 +2,14 @@ package horizon
 
 import (
 	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/stellar/horizon/render/sse"
+	"github.com/stellar/horizon/resource"
 	"github.com/stellar/horizon/resource/operations"
 	"github.com/stellar/horizon/test"
 )
@@ -70,6 +76,83 @@ func TestOperationActions_Index(t *testing.T) {
 	// missing ledger
 	w = ht.Get("/ledgers/100/operations")
 	ht.Assert.Equal(404, w.Code)
+
+	// filtered by asset
+	w = ht.Get("/operations?asset=native")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/operations?asset=USD:GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	w = ht.Get("/ledgers/2/operations?asset=native")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/operations?asset=USD")
+	ht.Assert.Equal(400, w.Code)
+
+	// filtered by type
+	w = ht.Get("/operations?type=payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?type=create_account,%20payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H/operations?type=create_account")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/operations?type=manage_offer")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	w = ht.Get("/operations?type=payment,bogus")
+	if ht.Assert.Equal(400, w.Code) {
+		var p struct {
+			Extras struct {
+				InvalidField string   `json:"invalid_field"`
+				ValidValues  []string `json:"valid_values"`
+			} `json:"extras"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &p))
+		ht.Assert.Equal("type", p.Extras.InvalidField)
+		ht.Assert.Len(p.Extras.ValidValues, len(operations.TypeNames))
+		ht.Assert.Contains(p.Extras.ValidValues, "manage_offer")
+	}
+
+	// the type filter is preserved when paging
+	w = ht.Get("/operations?type=create_account&limit=2")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+
+		var page struct {
+			Links struct {
+				Next struct {
+					Href string `json:"href"`
+				} `json:"next"`
+			} `json:"_links"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &page))
+		ht.Assert.Contains(page.Links.Next.Href, "type=create_account")
+
+		next, err := url.Parse(page.Links.Next.Href)
+		ht.Require.NoError(err)
+		w = ht.Get(next.RequestURI())
+		if ht.Assert.Equal(200, w.Code) {
+			ht.Assert.PageOf(1, w.Body)
+		}
+	}
 }
 
 func TestOperationActions_Show(t *testing.T) {
@@ -83,6 +166,8 @@ func TestOperationActions_Show(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &result)
 		ht.Require.NoError(err, "failed to parse body")
 		ht.Assert.Equal("8589938689", result.PT)
+		ht.Assert.Equal(int32(1), result.ApplicationOrder)
+		ht.Assert.Equal("op_success", result.ResultCode)
 	}
 
 	// doesn't exist
@@ -95,6 +180,62 @@ func TestOperationActions_Show(t *testing.T) {
 	ht.Assert.Equal(410, w.Code)
 }
 
+func TestOperationActions_Locator(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	locate := func(id string) (resource.OperationLocator, bool) {
+		var result resource.OperationLocator
+		w := ht.Get("/operations/" + id + "/locator")
+		if !ht.Assert.Equal(200, w.Code, id) {
+			return result, false
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+		return result, true
+	}
+
+	// an operation in history
+	if result, ok := locate("12884905985"); ok {
+		ht.Assert.Equal(int32(3), result.LedgerSequence)
+		ht.Assert.Equal(int32(1), result.TransactionOrder)
+		ht.Assert.Equal(int32(1), result.OperationIndex)
+		ht.Assert.Equal("cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a", result.TransactionHash)
+		ht.Assert.True(strings.HasSuffix(result.Links.Ledger.Href, "/ledgers/3"))
+		ht.Assert.True(strings.HasSuffix(result.Links.Operation.Href, "/operations/12884905985"))
+		if ht.Assert.NotNil(result.Links.Transaction) {
+			ht.Assert.True(strings.HasSuffix(
+				result.Links.Transaction.Href,
+				"/transactions/cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a",
+			))
+		}
+	}
+
+	// the id of a ledger, as found in a ledger's paging token
+	if result, ok := locate("8589934592"); ok {
+		ht.Assert.Equal(int32(2), result.LedgerSequence)
+		ht.Assert.Equal(int32(0), result.TransactionOrder)
+		ht.Assert.Equal(int32(0), result.OperationIndex)
+		ht.Assert.Nil(result.Links.Transaction)
+	}
+
+	// an id beyond history is decoded all the same
+	if result, ok := locate("429496733698"); ok {
+		ht.Assert.Equal(int32(100), result.LedgerSequence)
+		ht.Assert.Equal(int32(1), result.TransactionOrder)
+		ht.Assert.Equal(int32(2), result.OperationIndex)
+		ht.Assert.Equal("", result.TransactionHash)
+		ht.Assert.Nil(result.Links.Transaction)
+	}
+
+	// invalid ids
+	for _, id := range []string{"abc", "0", "-4096"} {
+		w := ht.Get("/operations/" + id + "/locator")
+		if ht.Assert.Equal(400, w.Code, id) {
+			ht.Assert.ProblemType(w.Body, "bad_request")
+		}
+	}
+}
+
 func TestOperationActions_Regressions(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -105,4 +246,76 @@ func TestOperationActions_Regressions(t *testing.T) {
 	if ht.Assert.Equal(404, w.Code) {
 		ht.Assert.ProblemType(w.Body, "not_found")
 	}
+	// invalid paging parameters are the client's fault, not the server's
+	w = ht.Get("/operations?cursor=hello")
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "bad_request")
+		ht.Assert.Contains(w.Body.String(), `"invalid_field": "cursor"`)
+	}
+}
+
+func TestOperationActions_StreamTransaction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	path := "/transactions/" + hash + "/operations?limit=1"
+	missing := "/transactions/" + strings.Repeat("0", 64) + "/operations?limit=1"
+
+	// without a timeout, a missing transaction is not waited for
+	w := ht.Get(missing, test.RequestHelperStreaming)
+	ht.Assert.Equal(404, w.Code)
+
+	ht.App.config.TransactionStreamTimeout = 100 * time.Millisecond
+
+	// ingested
+	w = ht.Get(path, test.RequestHelperStreaming)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Contains(w.Body.String(), "id: 8589938689\n")
+	}
+
+	// never ingested
+	start := time.Now()
+	w = ht.Get(missing, test.RequestHelperStreaming)
+	if ht.Assert.Equal(404, w.Code) {
+		ht.Assert.ProblemType(w.Body, "not_found")
+		ht.Assert.Contains(w.Body.String(), "was not ingested within 100ms")
+	}
+	ht.Assert.True(time.Since(start) >= 100*time.Millisecond)
+
+	// ingested while the stream waits, which we simulate by hiding the
+	// transaction and restoring it.
+	ht.App.config.TransactionStreamTimeout = 5 * time.Second
+	rename := func(from, to string) {
+		_, err := ht.App.historyQ.ExecRaw(
+			"UPDATE history_transactions SET transaction_hash = $1 WHERE transaction_hash = $2",
+			to, from,
+		)
+		ht.Require.NoError(err)
+	}
+	rename(hash, "hidden")
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- ht.Get(path, test.RequestHelperStreaming)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("stream did not wait for the transaction")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	rename("hidden", hash)
+	for w = nil; w == nil; {
+		select {
+		case w = <-done:
+		case <-time.After(10 * time.Millisecond):
+			sse.Tick()
+		}
+	}
+
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Contains(w.Body.String(), "id: 8589938689\n")
+	}
 }
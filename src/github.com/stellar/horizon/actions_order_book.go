@@ -1,6 +1,7 @@
 package horizon
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/stellar/go/xdr"
@@ -14,10 +15,12 @@ import (
 // OrderBookShowAction renders a account summary found by its address.
 type OrderBookShowAction struct {
 	Action
-	Selling  xdr.Asset
-	Buying   xdr.Asset
-	Record   core.OrderBookSummary
-	Resource resource.OrderBookSummary
+	Selling    xdr.Asset
+	Buying     xdr.Asset
+	Depth      int
+	Record     core.OrderBookSummary
+	Remainders []core.OrderBookRemainder
+	Resource   resource.OrderBookSummary
 }
 
 // LoadQuery sets action.Query from the request params
@@ -36,16 +39,46 @@ func (action *OrderBookShowAction) LoadQuery() {
 				"have specified selling_asset_code and selling_issuer if selling_asset_type is not 'native', as well " +
 				"as buying_asset_code and buying_issuer if buying_asset_type is not 'native'",
 		}
+		return
+	}
+
+	action.Depth = int(action.GetInt32("limit"))
+	if action.Err != nil {
+		return
+	}
+
+	switch {
+	case action.Depth == 0:
+		action.Depth = core.DefaultOrderBookDepth
+	case action.Depth < 0 || action.Depth > core.MaxOrderBookDepth:
+		action.SetInvalidField("limit", fmt.Errorf(
+			"limit must be between 1 and %d", core.MaxOrderBookDepth,
+		))
 	}
 }
 
-// LoadRecord populates action.Record
+// LoadRecord populates action.Record, and action.Remainders with the price
+// levels beyond action.Depth.  Both are loaded within a single transaction, so
+// that they describe the same state of the order book.
 func (action *OrderBookShowAction) LoadRecord() {
-	action.Err = action.CoreQ().GetOrderBookSummary(
-		&action.Record,
-		action.Selling,
-		action.Buying,
-	)
+	action.Err = action.CoreQ().Isolated(func(q *core.Q) error {
+		err := q.OrderBookSummary(
+			&action.Record,
+			action.Selling,
+			action.Buying,
+			action.Depth,
+		)
+		if err != nil {
+			return err
+		}
+
+		return q.OrderBookRemainders(
+			&action.Remainders,
+			action.Selling,
+			action.Buying,
+			action.Depth,
+		)
+	})
 }
 
 // LoadResource populates action.Record
@@ -55,6 +88,7 @@ func (action *OrderBookShowAction) LoadResource() {
 		action.Selling,
 		action.Buying,
 		action.Record,
+		action.Remainders,
 	)
 }
 
@@ -63,7 +97,7 @@ func (action *OrderBookShowAction) JSON() {
 	action.Do(action.LoadQuery, action.LoadRecord, action.LoadResource)
 
 	action.Do(func() {
-		hal.Render(action.W, action.Resource)
+		hal.Render(action.Ctx, action.W, action.Resource)
 	})
 }
 
@@ -2,11 +2,18 @@ package horizon
 
 import (
 	"encoding/json"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/stellar/horizon/render/sse"
 	"github.com/stellar/horizon/resource"
+	"github.com/stellar/horizon/test"
 )
 
+const usdOrderBook = "/order_book?selling_asset_type=native&buying_asset_type=credit_alphanum4&buying_asset_code=USD&buying_asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+
 func TestOrderBookActions_Show(t *testing.T) {
 	ht := StartHTTPTest(t, "order_books")
 	defer ht.Finish()
@@ -75,7 +82,7 @@ func TestOrderBookActions_Show(t *testing.T) {
 	}
 
 	// happy path
-	w = ht.Get("/order_book?selling_asset_type=native&buying_asset_type=credit_alphanum4&buying_asset_code=USD&buying_asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	w = ht.Get(usdOrderBook)
 	if ht.Assert.Equal(200, w.Code) {
 		err := json.Unmarshal(w.Body.Bytes(), &result)
 		ht.Require.NoError(err)
@@ -96,5 +103,87 @@ func TestOrderBookActions_Show(t *testing.T) {
 		ht.Assert.Equal("10.0000000", result.Bids[0].Amount)
 		ht.Assert.Equal("100.0000000", result.Bids[1].Amount)
 		ht.Assert.Equal("1000.0000000", result.Bids[2].Amount)
+
+		// prices are rendered from their exact ratios
+		ht.Assert.Equal(resource.Price{N: 1, D: 10}, result.Asks[0].PriceR)
+		ht.Assert.Equal("0.1000000", result.Asks[0].Price)
+		ht.Assert.Equal(resource.Price{N: 1, D: 15}, result.Bids[0].PriceR)
+		ht.Assert.Equal("0.0666667", result.Bids[0].Price)
+
+		// every price level was returned
+		ht.Assert.Equal(resource.OrderBookRemainder{Amount: "0.0000000"}, result.AsksRemainder)
+		ht.Assert.Equal(resource.OrderBookRemainder{Amount: "0.0000000"}, result.BidsRemainder)
+	}
+
+	// limited depth
+	w = ht.Get(usdOrderBook + "&limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+
+		ht.Require.Len(result.Asks, 1)
+		ht.Require.Len(result.Bids, 1)
+		ht.Assert.Equal("100.0000000", result.Asks[0].Amount)
+
+		ht.Assert.Equal(int32(2), result.AsksRemainder.PriceLevels)
+		ht.Assert.Equal("5900.0000000", result.AsksRemainder.Amount)
+		ht.Assert.Equal(int32(2), result.BidsRemainder.PriceLevels)
+		ht.Assert.Equal("1100.0000000", result.BidsRemainder.Amount)
+	}
+
+	// depth beyond the available offers
+	w = ht.Get(usdOrderBook + "&limit=200")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+
+		ht.Assert.Len(result.Asks, 3)
+		ht.Assert.Len(result.Bids, 3)
+		ht.Assert.Equal(int32(0), result.AsksRemainder.PriceLevels)
+		ht.Assert.Equal(int32(0), result.BidsRemainder.PriceLevels)
+	}
+
+	// invalid depth
+	for _, limit := range []string{"-1", "201", "ten"} {
+		w = ht.Get("/order_book?selling_asset_type=native&buying_asset_type=native&limit=" + limit)
+		if ht.Assert.Equal(400, w.Code, "limit: %s", limit) {
+			ht.Assert.ProblemType(w.Body, "bad_request")
+		}
+	}
+}
+
+func TestOrderBookActions_Stream(t *testing.T) {
+	ht := StartHTTPTest(t, "order_books")
+	defer ht.Finish()
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- ht.Get(usdOrderBook+"&limit=1", test.RequestHelperStreaming)
+	}()
+
+	var w *httptest.ResponseRecorder
+	for w == nil {
+		select {
+		case w = <-done:
+		case <-time.After(10 * time.Millisecond):
+			sse.Tick()
+		}
+	}
+
+	// every event streamed is limited to the depth requested
+	ht.Require.Equal(200, w.Code)
+	var events int
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		if !strings.HasPrefix(line, "data: {") {
+			continue
+		}
+		events++
+
+		var result resource.OrderBookSummary
+		ht.Require.NoError(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &result))
+		ht.Assert.Len(result.Asks, 1)
+		ht.Assert.Len(result.Bids, 1)
+		ht.Assert.Equal("5900.0000000", result.AsksRemainder.Amount)
 	}
+	ht.Assert.Equal(10, events)
 }
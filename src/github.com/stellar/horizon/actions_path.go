@@ -3,15 +3,27 @@ package horizon
 import (
 	"github.com/stellar/horizon/paths"
 	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/problem"
 	"github.com/stellar/horizon/resource"
 )
 
 // PathIndexAction provides path finding
 type PathIndexAction struct {
 	Action
-	Query   paths.Query
-	Records []paths.Path
-	Page    hal.BasePage
+	Query       paths.Query
+	Explain     bool
+	Records     []paths.Path
+	Explanation *paths.Explanation
+	Page        hal.BasePage
+	Explained   ExplainedPathPage
+}
+
+// ExplainedPathPage is the response rendered for a path search made with
+// `explain=true`: the usual page of paths along with a breakdown of the
+// search that produced them.
+type ExplainedPathPage struct {
+	hal.BasePage
+	Explanation resource.PathExplanation `json:"explanation"`
 }
 
 // JSON implements actions.JSON
@@ -22,7 +34,11 @@ func (action *PathIndexAction) JSON() {
 		action.loadRecords,
 		action.loadPage,
 		func() {
-			hal.Render(action.W, action.Page)
+			if action.Explain {
+				hal.Render(action.Ctx, action.W, action.Explained)
+				return
+			}
+			hal.Render(action.Ctx, action.W, action.Page)
 		},
 	)
 }
@@ -31,7 +47,7 @@ func (action *PathIndexAction) loadQuery() {
 	action.Query.DestinationAmount = action.GetAmount("destination_amount")
 	action.Query.DestinationAddress = action.GetAddress("destination_account")
 	action.Query.DestinationAsset = action.GetAsset("destination_")
-
+	action.Explain = action.GetBool("explain")
 }
 
 func (action *PathIndexAction) loadSourceAssets() {
@@ -42,7 +58,19 @@ func (action *PathIndexAction) loadSourceAssets() {
 }
 
 func (action *PathIndexAction) loadRecords() {
-	action.Records, action.Err = action.App.paths.Find(action.Query)
+	if !action.Explain {
+		action.Records, action.Err = action.App.paths.Find(action.Query)
+		return
+	}
+
+	explainer, ok := action.App.paths.(paths.Explainer)
+	if !ok {
+		action.Err = &problem.NotImplemented
+		return
+	}
+
+	action.Records, action.Explanation, action.Err =
+		explainer.Explain(action.Query)
 }
 
 func (action *PathIndexAction) loadPage() {
@@ -55,4 +83,14 @@ func (action *PathIndexAction) loadPage() {
 		}
 		action.Page.Add(res)
 	}
+
+	if action.Explanation == nil {
+		return
+	}
+
+	action.Explained.BasePage = action.Page
+	action.Err = action.Explained.Explanation.Populate(
+		action.Ctx,
+		action.Explanation,
+	)
 }
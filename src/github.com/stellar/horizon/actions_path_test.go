@@ -1,8 +1,11 @@
 package horizon
 
 import (
+	"encoding/json"
 	"net/url"
 	"testing"
+
+	"github.com/stellar/horizon/resource"
 )
 
 func TestPathActions_Index(t *testing.T) {
@@ -36,4 +39,32 @@ func TestPathActions_Index(t *testing.T) {
 	ht.Assert.Equal(200, w.Code)
 	ht.Assert.PageOf(3, w.Body)
 
+	// explained search
+	q.Set("explain", "true")
+	w = ht.Get("/paths?" + q.Encode())
+	if ht.Assert.Equal(200, w.Code) {
+		var page struct {
+			Embedded struct {
+				Records []resource.Path `json:"records"`
+			} `json:"_embedded"`
+			Explanation resource.PathExplanation `json:"explanation"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &page)
+		if ht.Assert.NoError(err) {
+			ht.Assert.Len(page.Embedded.Records, 3)
+			ht.Assert.True(page.Explanation.DestinationTrusted)
+			ht.Assert.Equal("exhausted", page.Explanation.StoppedBy)
+			ht.Assert.NotEmpty(page.Explanation.Considered)
+		}
+	}
+
+	// a search that finds nothing still explains itself
+	q.Set("destination_amount", "50.0000001")
+	w = ht.Get("/paths?" + q.Encode())
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.Contains(w.Body.String(), `"insufficient_liquidity"`)
+
+	q.Set("explain", "maybe")
+	w = ht.Get("/paths?" + q.Encode())
+	ht.Assert.Equal(400, w.Code)
 }
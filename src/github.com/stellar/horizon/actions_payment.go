@@ -1,6 +1,9 @@
 package horizon
 
 import (
+	"net/url"
+
+	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/render/hal"
@@ -9,12 +12,21 @@ import (
 )
 
 // PaymentsIndexAction returns a paged slice of payments based upon the provided
-// filters
+// filters.  In addition to the collection being indexed, payments may be
+// narrowed to those delivering a specific `asset` (either "native" or
+// "CODE:ISSUER"), sent `to` or sent `from` a specific account.  These filters
+// apply to streamed responses as well, such that a client watching for
+// deposits only receives the events it cares about.
 type PaymentsIndexAction struct {
 	Action
 	LedgerFilter      int32
 	AccountFilter     string
 	TransactionFilter string
+	AssetFilter       xdr.Asset
+	HasAssetFilter    bool
+	ToFilter          string
+	FromFilter        string
+	TypeFilter        []xdr.OperationType
 	PagingParams      db2.PageQuery
 	Records           []history.Operation
 	Page              hal.Page
@@ -30,7 +42,7 @@ func (action *PaymentsIndexAction) JSON() {
 		action.loadPage,
 	)
 	action.Do(func() {
-		hal.Render(action.W, action.Page)
+		hal.Render(action.Ctx, action.W, action.Page)
 	})
 }
 
@@ -68,12 +80,30 @@ func (action *PaymentsIndexAction) loadParams() {
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
+	action.AssetFilter, action.HasAssetFilter = action.GetCanonicalAsset("asset")
+
+	if action.GetString("to") != "" {
+		action.ToFilter = action.GetAddress("to")
+	}
+
+	if action.GetString("from") != "" {
+		action.FromFilter = action.GetAddress("from")
+	}
+
+	action.TypeFilter = action.getOperationTypes("type", history.PaymentTypes)
+
 	action.PagingParams = action.GetPageQuery()
 }
 
 func (action *PaymentsIndexAction) loadRecords() {
 	q := action.HistoryQ()
-	ops := q.Operations().OnlyPayments()
+	ops := q.Operations()
+
+	if len(action.TypeFilter) > 0 {
+		ops.ForTypes(action.TypeFilter)
+	} else {
+		ops.OnlyPayments()
+	}
 
 	switch {
 	case action.AccountFilter != "":
@@ -84,7 +114,22 @@ func (action *PaymentsIndexAction) loadRecords() {
 		ops.ForTransaction(action.TransactionFilter)
 	}
 
+	if action.HasAssetFilter {
+		ops.ForPaymentAsset(action.AssetFilter)
+	}
+
+	if action.ToFilter != "" {
+		ops.ForPaymentRecipient(action.ToFilter)
+	}
+
+	if action.FromFilter != "" {
+		ops.ForPaymentSender(action.FromFilter)
+	}
+
 	action.Err = ops.Page(action.PagingParams).Select(&action.Records)
+	if action.LedgerFilter > 0 {
+		action.HealLedger(action.LedgerFilter)
+	}
 }
 
 func (action *PaymentsIndexAction) loadPage() {
@@ -102,5 +147,21 @@ func (action *PaymentsIndexAction) loadPage() {
 	action.Page.Limit = action.PagingParams.Limit
 	action.Page.Cursor = action.PagingParams.Cursor
 	action.Page.Order = action.PagingParams.Order
+	action.Page.Filters = action.filters()
 	action.Page.PopulateLinks()
 }
+
+// filters returns the payment-specific filters that were applied to the
+// request, for preservation in the page links.
+func (action *PaymentsIndexAction) filters() url.Values {
+	f := url.Values{}
+	for _, name := range []string{"asset", "to", "from"} {
+		if v := action.GetString(name); v != "" {
+			f.Set(name, v)
+		}
+	}
+	if len(action.TypeFilter) > 0 {
+		f.Set("type", operationTypeList(action.TypeFilter))
+	}
+	return f
+}
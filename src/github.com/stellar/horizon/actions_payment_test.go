@@ -30,6 +30,35 @@ func TestPaymentActions(t *testing.T) {
 		ht.Assert.PageOf(1, w.Body)
 	}
 
+	// filtered by type, which may only name the types of payments
+	w = ht.Get("/payments?type=payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/payments?type=create_account,payment,path_payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/payments?type=create_account&limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+		ht.Assert.Contains(w.Body.String(), "type=create_account")
+	}
+
+	// other operation types are left to the operations endpoints
+	w = ht.Get("/payments?type=manage_offer")
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "bad_request")
+		ht.Assert.Contains(w.Body.String(), "not accepted by this endpoint")
+		ht.Assert.Contains(w.Body.String(), `"valid_values": [
+      "create_account",
+      "path_payment",
+      "payment"
+    ]`)
+	}
+
 	// switch scenarios
 	ht.T.Scenario("pathed_payment")
 
@@ -43,4 +72,32 @@ func TestPaymentActions(t *testing.T) {
 	if ht.Assert.Equal(200, w.Code) {
 		ht.Assert.PageOf(1, w.Body)
 	}
+
+	// filtered by asset
+	w = ht.Get("/payments?asset=native")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(5, w.Body)
+	}
+
+	w = ht.Get("/payments?asset=EUR:GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/payments?asset=EUR")
+	ht.Assert.Equal(400, w.Code)
+
+	// filtered by direction
+	w = ht.Get("/accounts/GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON/payments?to=GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON&asset=USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/payments?from=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+	}
+
+	w = ht.Get("/payments?to=not_an_address")
+	ht.Assert.Equal(400, w.Code)
 }
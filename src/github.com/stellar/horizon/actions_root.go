@@ -16,14 +16,18 @@ type RootAction struct {
 func (action *RootAction) JSON() {
 	action.App.UpdateStellarCoreInfo()
 
+	info := action.App.currentCoreInfo()
+
 	var res resource.Root
 	res.Populate(
 		action.Ctx,
 		ledger.CurrentState(),
 		action.App.horizonVersion,
-		action.App.coreVersion,
-		action.App.networkPassphrase,
+		info.Version,
+		info.NetworkPassphrase,
+		action.App.capabilities.Enabled(action.App),
 	)
+	res.CoreInfoStale = info.Stale
 
-	hal.Render(action.W, res)
+	hal.Render(action.Ctx, action.W, res)
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/stellar/horizon/friendbot"
 	"github.com/stellar/horizon/resource"
 	"github.com/stellar/horizon/test"
 )
@@ -22,6 +23,7 @@ func TestRootAction(t *testing.T) {
 
 	ht.App.horizonVersion = "test-horizon"
 	ht.App.config.StellarCoreURL = server.URL
+	ht.App.UpdateLedgerState()
 
 	w := ht.Get("/")
 	if ht.Assert.Equal(200, w.Code) {
@@ -30,5 +32,77 @@ func TestRootAction(t *testing.T) {
 		ht.Require.NoError(err)
 		ht.Assert.Equal("test-horizon", actual.HorizonVersion)
 		ht.Assert.Equal("test-core", actual.StellarCoreVersion)
+		ht.Assert.Equal("test", actual.NetworkPassphrase)
+		ht.Assert.Equal(int32(2), actual.ProtocolVersion)
+		ht.Assert.Equal(int32(100), actual.BaseFee)
+		ht.Assert.Equal(int32(100000000), actual.BaseReserve)
+		ht.Assert.Equal(int32(10000), actual.MaxTxSetSize)
+
+		// both databases hold the same latest ledger
+		ht.Assert.NotEmpty(actual.CoreLatestHash)
+		ht.Assert.Equal(actual.CoreLatestHash, actual.HistoryLatestHash)
+		ht.Assert.False(actual.CoreLatestClosedAt.IsZero())
+		ht.Assert.True(actual.CoreLatestClosedAt.Equal(actual.HistoryLatestClosedAt))
 	}
+
+	// submissions are checked against the network reported by stellar-core
+	passphrase, validate := ht.App.submitter.Network()
+	ht.Assert.Equal("test", passphrase)
+	ht.Assert.Equal(true, validate)
+}
+
+func TestRootAction_Capabilities(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	ht.App.config.StellarCoreURL = ""
+
+	load := func() []string {
+		w := ht.Get("/")
+		ht.Require.Equal(200, w.Code)
+
+		var actual resource.Root
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		return actual.Capabilities
+	}
+
+	// features every instance supports are advertised by default
+	caps := load()
+	ht.Assert.Contains(caps, "assets")
+	ht.Assert.Contains(caps, "operation_filters")
+	ht.Assert.Contains(caps, "effect_filters")
+	ht.Assert.Contains(caps, "trades_streaming")
+	ht.Assert.Contains(caps, "spendable_assets")
+	ht.Assert.Contains(caps, "include_raw")
+	ht.Assert.Contains(caps, "ledger_export_ndjson")
+	ht.Assert.NotContains(caps, "api_keys")
+	ht.Assert.NotContains(caps, "friendbot")
+	ht.Assert.NotContains(caps, "failed_transactions")
+	ht.Assert.NotContains(caps, "self_heal_reingest")
+
+	// features configured on are advertised
+	ht.App.config.IngestFailedTransactions = true
+	ht.App.config.IngestFailedTransactionFees = true
+	ht.App.friendbot = &friendbot.Bot{}
+	ht.App.selfHealer = &selfHealer{}
+	ht.App.web.apiKeys = &APIKeys{}
+	caps = load()
+	ht.Assert.Contains(caps, "api_keys")
+	ht.Assert.Contains(caps, "failed_transactions")
+	ht.Assert.Contains(caps, "failed_transaction_fees")
+	ht.Assert.Contains(caps, "friendbot")
+	ht.Assert.Contains(caps, "self_heal_reingest")
+
+	// features relying upon effects are withdrawn when effects are disabled
+	ht.App.config.DisableEffectIngestion = true
+	caps = load()
+	ht.Assert.Contains(caps, "assets")
+	ht.Assert.NotContains(caps, "effects")
+	ht.Assert.NotContains(caps, "effect_filters")
+	ht.Assert.NotContains(caps, "trades")
+	ht.Assert.NotContains(caps, "trades_streaming")
+	ht.Assert.NotContains(caps, "failed_transaction_fees")
+	ht.Assert.NotContains(caps, "ledger_export")
+	ht.Assert.NotContains(caps, "ledger_export_ndjson")
+	ht.Assert.Contains(caps, "failed_transactions")
 }
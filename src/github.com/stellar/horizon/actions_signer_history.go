@@ -0,0 +1,69 @@
+package horizon
+
+import (
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/resource"
+)
+
+// This file contains the actions:
+//
+// SignerHistoryAction: pages of changes to an account's signers
+
+// SignerHistoryAction renders a page of the changes made to an account's
+// signers, each identified by the transaction and ledger that made it.
+type SignerHistoryAction struct {
+	Action
+	AccountFilter string
+	PagingParams  db2.PageQuery
+	Records       []history.SignerChange
+	Page          hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *SignerHistoryAction) JSON() {
+	action.Do(
+		action.EnsureEffectsEnabled,
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.Ctx, action.W, action.Page)
+		},
+	)
+}
+
+func (action *SignerHistoryAction) loadParams() {
+	action.AccountFilter = action.GetString("account_id")
+	action.PagingParams = action.GetPageQuery()
+}
+
+// loadRecords populates action.Records
+func (action *SignerHistoryAction) loadRecords() {
+	action.Err = action.HistoryQ().SignerChanges().
+		ForAccount(action.AccountFilter).
+		Page(action.PagingParams).
+		Select(&action.Records)
+}
+
+// loadPage populates action.Page
+func (action *SignerHistoryAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.SignerChange
+		action.Err = res.Populate(action.Ctx, record)
+		if action.Err != nil {
+			return
+		}
+		action.Page.Add(res)
+	}
+
+	action.Page.BaseURL = action.BaseURL()
+	action.Page.BasePath = action.Path()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
@@ -0,0 +1,41 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/horizon/resource"
+)
+
+func TestSignerHistoryAction(t *testing.T) {
+	ht := StartHTTPTest(t, "set_options")
+	defer ht.Finish()
+
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/signer_history?limit=20")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(14, w.Body)
+	}
+
+	// the most recent change is the removal of the added signer
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/signer_history?order=desc&limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		var result struct {
+			Embedded struct {
+				Records []resource.SignerChange `json:"records"`
+			} `json:"_embedded"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		if ht.Assert.NoError(err) && ht.Assert.Len(result.Embedded.Records, 1) {
+			change := result.Embedded.Records[0]
+			ht.Assert.Equal("signer_removed", change.Type)
+			ht.Assert.Equal("GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4", change.PublicKey)
+			ht.Assert.Equal(int32(11), change.Ledger)
+			ht.Assert.NotEmpty(change.TransactionHash)
+			ht.Assert.False(change.LedgerCloseTime.IsZero())
+		}
+	}
+
+	// an account with no history
+	w = ht.Get("/accounts/GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON/signer_history")
+	ht.Assert.Equal(404, w.Code)
+}
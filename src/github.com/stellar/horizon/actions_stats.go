@@ -0,0 +1,76 @@
+package horizon
+
+import (
+	"time"
+
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/problem"
+	"github.com/stellar/horizon/resource"
+)
+
+// statsWindow is the number of recent ledgers over which StatsAction averages
+// the operations per ledger.
+const statsWindow = 100
+
+// StatsAction renders a summary of the network's activity (see
+// resource.Stats).  The figures are read from the running totals recorded with
+// each ledger during ingestion, rather than counted on each request.  Like the
+// effects endpoints, it responds with a feature_disabled problem while effect
+// ingestion is disabled.
+type StatsAction struct {
+	Action
+	Latest      history.Ledger
+	DayStart    history.Ledger
+	WindowStart history.Ledger
+}
+
+// JSON is a method for actions.JSON
+func (action *StatsAction) JSON() {
+	action.Do(
+		action.EnsureEffectsEnabled,
+		action.EnsureHistoryFreshness,
+		action.loadRecords,
+		func() {
+			var res resource.Stats
+			res.Populate(action.Ctx, action.Latest, action.DayStart, action.WindowStart)
+			hal.Render(action.Ctx, action.W, res)
+		},
+	)
+}
+
+// loadRecords loads the latest ledger and the ledgers at the start of the
+// periods the stats cover.  Without a ledger at the start of the averaging
+// window, for example because it has just been reaped, the average is
+// omitted.
+func (action *StatsAction) loadRecords() {
+	ls := ledger.CurrentState()
+	if ls.HistoryLatest == 0 {
+		action.Err = &problem.NotFound
+		return
+	}
+
+	q := action.HistoryQ()
+	action.Err = q.LedgerBySequence(&action.Latest, ls.HistoryLatest)
+	if action.Err != nil {
+		return
+	}
+
+	dayAgo := action.Latest.ClosedAt.Add(-24 * time.Hour)
+	action.Err = q.LedgerAfterCloseTime(&action.DayStart, dayAgo)
+	if action.Err != nil {
+		return
+	}
+
+	start := action.Latest.Sequence - statsWindow + 1
+	if start < ls.HistoryElder {
+		start = ls.HistoryElder
+	}
+
+	err := q.LedgerBySequence(&action.WindowStart, start)
+	if q.NoRows(err) {
+		return
+	}
+	action.Err = err
+}
@@ -0,0 +1,47 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/horizon/resource"
+)
+
+func TestStatsAction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// ledger 1 closed long before the others, so that the last 24 hours hold
+	// ledgers 2 and 3 alone
+	w := ht.Get("/stats")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual resource.Stats
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &actual))
+		ht.Assert.Equal(int32(3), actual.LatestLedger)
+		ht.Assert.Equal(int64(4), actual.TotalAccounts.Int64)
+		ht.Assert.True(actual.TotalTrustlines.Valid)
+		ht.Assert.Equal(int64(0), actual.TotalTrustlines.Int64)
+		ht.Assert.Equal(int64(4), actual.TotalOperations.Int64)
+		ht.Assert.Equal(int64(4), actual.OperationsLast24h.Int64)
+		ht.Assert.Equal(int32(3), actual.AverageWindow)
+		ht.Assert.InDelta(4.0/3.0, actual.AverageOperationsPerLedger.Float64, 0.0001)
+	}
+
+	// totals unknown for the latest ledger are null
+	_, err := ht.HorizonRepo().ExecRaw(`
+		UPDATE history_ledgers
+		SET total_accounts = NULL, total_operations = NULL
+		WHERE sequence = 3`)
+	ht.Require.NoError(err)
+
+	w = ht.Get("/stats")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual map[string]interface{}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &actual))
+		ht.Assert.Nil(actual["total_accounts"])
+		ht.Assert.Nil(actual["total_operations"])
+		ht.Assert.Nil(actual["operations_last_24h"])
+		ht.Assert.Nil(actual["average_operations_per_ledger"])
+		ht.Assert.Equal(float64(0), actual["total_trustlines"])
+	}
+}
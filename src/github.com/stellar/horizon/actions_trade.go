@@ -5,6 +5,7 @@ import (
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/sse"
 	"github.com/stellar/horizon/resource"
 )
 
@@ -24,12 +25,45 @@ type TradeIndexAction struct {
 // JSON is a method for actions.JSON
 func (action *TradeIndexAction) JSON() {
 	action.Do(
+		action.EnsureEffectsEnabled,
 		action.EnsureHistoryFreshness,
 		action.loadParams,
 		action.loadRecords,
 		action.loadPage,
 		func() {
-			hal.Render(action.W, action.Page)
+			hal.Render(action.Ctx, action.W, action.Page)
+		},
+	)
+}
+
+// SSE is a method for actions.SSE
+func (action *TradeIndexAction) SSE(stream sse.Stream) {
+	action.Setup(
+		action.EnsureEffectsEnabled,
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+	)
+
+	action.Do(
+		action.loadRecords,
+		func() {
+			stream.SetLimit(int(action.PagingParams.Limit))
+			records := action.Records[stream.SentCount():]
+
+			for _, record := range records {
+				var res resource.Trade
+				err := res.Populate(action.Ctx, record)
+
+				if err != nil {
+					stream.Err(err)
+					return
+				}
+
+				stream.Send(sse.Event{
+					ID:   res.PagingToken(),
+					Data: res,
+				})
+			}
 		},
 	)
 }
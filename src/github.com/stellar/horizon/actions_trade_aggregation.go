@@ -0,0 +1,233 @@
+package horizon
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/render/problem"
+	"github.com/stellar/horizon/resource"
+)
+
+// This file contains the actions:
+//
+// TradeAggregationIndexAction: pages of the trades of an asset pair,
+// aggregated into buckets of time
+
+// tradeAggregationResolutions are the bucket widths accepted by the
+// `resolution` parameter of TradeAggregationIndexAction, by name.
+var tradeAggregationResolutions = []struct {
+	Name     string
+	Duration time.Duration
+}{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"1d", 24 * time.Hour},
+	{"1w", 7 * 24 * time.Hour},
+}
+
+// tradeAggregationMaxBuckets is the greatest number of buckets the time range
+// of a request may span, once clamped to the ledgers in the history database,
+// which bounds the trades aggregated to answer it.
+const tradeAggregationMaxBuckets = 1000
+
+// TradeAggregationIndexAction renders a page of the trades of an asset pair
+// made between two times, aggregated into buckets of the requested resolution.
+type TradeAggregationIndexAction struct {
+	Action
+	Base         xdr.Asset
+	Counter      xdr.Asset
+	StartTime    time.Time
+	EndTime      time.Time
+	Resolution   string
+	PagingParams db2.PageQuery
+	Records      []history.TradeAggregation
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *TradeAggregationIndexAction) JSON() {
+	action.Do(
+		action.EnsureEffectsEnabled,
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.clampTimeRange,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.Ctx, action.W, action.Page)
+		},
+	)
+}
+
+func (action *TradeAggregationIndexAction) loadParams() {
+	action.Base = action.GetAsset("base_")
+	action.Counter = action.GetAsset("counter_")
+	action.StartTime = action.getRequiredTime("start_time")
+	action.EndTime = action.getRequiredTime("end_time")
+	action.Resolution = action.getResolution()
+	action.PagingParams = action.GetPageQuery()
+}
+
+// getRequiredTime retrieves the time from the parameter `name`, which is
+// required:  the buckets of a request are aggregated from the trades made
+// within its time range, so the range must be bounded.
+func (action *TradeAggregationIndexAction) getRequiredTime(name string) time.Time {
+	if action.Err != nil {
+		return time.Time{}
+	}
+
+	if action.GetString(name) == "" {
+		action.SetInvalidField(name, errors.New("required"))
+		return time.Time{}
+	}
+
+	return action.GetTime(name)
+}
+
+// getResolution retrieves the name of the bucket width from the `resolution`
+// parameter, which is required.
+func (action *TradeAggregationIndexAction) getResolution() string {
+	res := action.GetString("resolution")
+	if action.Err != nil {
+		return ""
+	}
+
+	var names []string
+	for _, valid := range tradeAggregationResolutions {
+		if res == valid.Name {
+			return res
+		}
+		names = append(names, valid.Name)
+	}
+
+	action.SetInvalidField("resolution", fmt.Errorf(
+		"unsupported resolution %q, expected one of: %s",
+		res,
+		strings.Join(names, ", "),
+	))
+	action.Err.(*problem.P).Extras["valid_values"] = names
+	return ""
+}
+
+// resolution returns the width of the buckets requested.
+func (action *TradeAggregationIndexAction) resolution() time.Duration {
+	for _, valid := range tradeAggregationResolutions {
+		if action.Resolution == valid.Name {
+			return valid.Duration
+		}
+	}
+	return 0
+}
+
+// clampTimeRange aligns the requested time range to whole buckets, and
+// narrows it to the buckets of the ledgers in the history database:  a bucket
+// is included only when it lies wholly within the requested range.  A range
+// that still spans more than tradeAggregationMaxBuckets buckets is rejected.
+func (action *TradeAggregationIndexAction) clampTimeRange() {
+	res := action.resolution()
+	start := alignTime(action.StartTime.Add(res-time.Nanosecond), res)
+	end := alignTime(action.EndTime, res)
+
+	ls := ledger.CurrentState()
+	if ls.HistoryLatest == 0 {
+		action.StartTime, action.EndTime = start, start
+		return
+	}
+
+	var elder, latest history.Ledger
+	action.Err = action.HistoryQ().LedgerBySequence(&elder, ls.HistoryElder)
+	if action.Err != nil {
+		return
+	}
+	action.Err = action.HistoryQ().LedgerBySequence(&latest, ls.HistoryLatest)
+	if action.Err != nil {
+		return
+	}
+
+	if first := alignTime(elder.ClosedAt, res); start.Before(first) {
+		start = first
+	}
+
+	if last := alignTime(latest.ClosedAt, res).Add(res); end.After(last) {
+		end = last
+	}
+
+	if end.Sub(start) > tradeAggregationMaxBuckets*res {
+		action.SetInvalidField("end_time", fmt.Errorf(
+			"time range spans more than %d buckets of the resolution",
+			tradeAggregationMaxBuckets,
+		))
+		return
+	}
+
+	action.StartTime = start
+	action.EndTime = end
+}
+
+func (action *TradeAggregationIndexAction) loadRecords() {
+	// an empty range, such as that left without history, has no buckets
+	if !action.StartTime.Before(action.EndTime) {
+		return
+	}
+
+	action.Err = action.HistoryQ().
+		TradeAggregations(action.Base, action.Counter, action.resolution()).
+		ForTimeRange(action.StartTime, action.EndTime).
+		Page(action.PagingParams).
+		Select(&action.Records)
+}
+
+func (action *TradeAggregationIndexAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.TradeAggregation
+		action.Err = res.Populate(action.Ctx, record)
+		if action.Err != nil {
+			return
+		}
+		action.Page.Add(res)
+	}
+
+	action.Page.BaseURL = action.BaseURL()
+	action.Page.BasePath = action.Path()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.Filters = action.filters()
+	action.Page.PopulateLinks()
+}
+
+// filters returns the parameters of the request, the time range being that
+// clamped to, for preservation in the page links.
+func (action *TradeAggregationIndexAction) filters() url.Values {
+	f := url.Values{}
+	for _, prefix := range []string{"base_", "counter_"} {
+		for _, field := range []string{"asset_type", "asset_code", "asset_issuer"} {
+			if v := action.GetString(prefix + field); v != "" {
+				f.Set(prefix+field, v)
+			}
+		}
+	}
+
+	f.Set("resolution", action.Resolution)
+	f.Set("start_time", action.StartTime.UTC().Format(time.RFC3339))
+	f.Set("end_time", action.EndTime.UTC().Format(time.RFC3339))
+	return f
+}
+
+// alignTime returns the start of the bucket `res` wide, aligned to the unix
+// epoch, that contains `t`.
+func alignTime(t time.Time, res time.Duration) time.Time {
+	secs := int64(res / time.Second)
+	unix := t.Unix()
+	return time.Unix(unix-unix%secs, 0).UTC()
+}
@@ -0,0 +1,253 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/resource"
+	"github.com/stellar/horizon/toid"
+)
+
+func TestTradeAggregationActions_Index(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	const (
+		usd = "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+		eur = "GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG"
+	)
+
+	// the scenario's single trade of 50 USD for 50 EUR, in ledger 6, is joined
+	// by two more in the same ledger, and by one in ledger 5, which we move to
+	// an hour earlier.
+	trade := func(id int64, sold, bought string) {
+		_, err := ht.App.historyQ.ExecRaw(`
+			INSERT INTO history_effects VALUES (2, $1, 1, 33, $2)
+		`, id, `{
+			"seller": "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2",
+			"offer_id": 1,
+			"sold_amount": "`+sold+`",
+			"bought_amount": "`+bought+`",
+			"sold_asset_code": "USD",
+			"sold_asset_type": "credit_alphanum4",
+			"sold_asset_issuer": "`+usd+`",
+			"bought_asset_code": "EUR",
+			"bought_asset_type": "credit_alphanum4",
+			"bought_asset_issuer": "`+eur+`"
+		}`)
+		ht.Require.NoError(err)
+	}
+	trade(toid.New(6, 1, 2).ToInt64(), "10.0000000", "12.0000000")
+	trade(toid.New(6, 1, 3).ToInt64(), "30.0000000", "20.0000000")
+	trade(toid.New(5, 1, 1).ToInt64(), "3.0000000", "1.0000000")
+	_, err := ht.App.historyQ.ExecRaw(`
+		UPDATE history_ledgers
+		SET closed_at = '2016-06-29 15:34:51'
+		WHERE sequence = 5
+	`)
+	ht.Require.NoError(err)
+
+	q := make(url.Values)
+	q.Set("base_asset_type", "credit_alphanum4")
+	q.Set("base_asset_code", "USD")
+	q.Set("base_asset_issuer", usd)
+	q.Set("counter_asset_type", "credit_alphanum4")
+	q.Set("counter_asset_code", "EUR")
+	q.Set("counter_asset_issuer", eur)
+	q.Set("start_time", "2016-06-29T00:00:00Z")
+	q.Set("end_time", "2016-06-30T00:00:00Z")
+
+	type page struct {
+		Embedded struct {
+			Records []resource.TradeAggregation `json:"records"`
+		} `json:"_embedded"`
+		Links struct {
+			Next struct {
+				Href string `json:"href"`
+			} `json:"next"`
+		} `json:"_links"`
+	}
+
+	get := func(path string, params map[string]string) (p page) {
+		v := url.Values{}
+		for k := range q {
+			v.Set(k, q.Get(k))
+		}
+		for k, val := range params {
+			v.Set(k, val)
+		}
+
+		w := ht.Get(path + "?" + v.Encode())
+		if ht.Assert.Equal(200, w.Code) {
+			ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &p))
+		}
+		return
+	}
+
+	// by the minute
+	p := get("/trade_aggregations", map[string]string{"resolution": "1m"})
+	if ht.Assert.Len(p.Embedded.Records, 2) {
+		first := p.Embedded.Records[0]
+		ht.Assert.Equal(time.Date(2016, 6, 29, 15, 34, 0, 0, time.UTC), first.Timestamp)
+		ht.Assert.Equal(int32(1), first.TradeCount)
+		ht.Assert.Equal("3.0000000", first.BaseVolume)
+		ht.Assert.Equal("1.0000000", first.CounterVolume)
+		ht.Assert.Equal("0.3333333", first.Open)
+		ht.Assert.Equal("0.3333333", first.Close)
+
+		second := p.Embedded.Records[1]
+		ht.Assert.Equal(time.Date(2016, 6, 29, 16, 34, 0, 0, time.UTC), second.Timestamp)
+		ht.Assert.Equal(int32(3), second.TradeCount)
+		ht.Assert.Equal("90.0000000", second.BaseVolume)
+		ht.Assert.Equal("82.0000000", second.CounterVolume)
+		ht.Assert.Equal("1.0000000", second.Open)
+		ht.Assert.Equal("1.2000000", second.High)
+		ht.Assert.Equal("0.6666667", second.Low)
+		ht.Assert.Equal("0.6666667", second.Close)
+		ht.Assert.Equal("1467218040", second.PT)
+	}
+
+	// by the day
+	p = get("/trade_aggregations", map[string]string{"resolution": "1d"})
+	if ht.Assert.Len(p.Embedded.Records, 1) {
+		day := p.Embedded.Records[0]
+		ht.Assert.Equal(time.Date(2016, 6, 29, 0, 0, 0, 0, time.UTC), day.Timestamp)
+		ht.Assert.Equal(int32(4), day.TradeCount)
+		ht.Assert.Equal("93.0000000", day.BaseVolume)
+		ht.Assert.Equal("83.0000000", day.CounterVolume)
+		ht.Assert.Equal("0.3333333", day.Open)
+		ht.Assert.Equal("1.2000000", day.High)
+		ht.Assert.Equal("0.3333333", day.Low)
+		ht.Assert.Equal("0.6666667", day.Close)
+	}
+
+	// the other side of the pair
+	w := ht.Get("/trade_aggregations?resolution=1d" +
+		"&base_asset_type=credit_alphanum4&base_asset_code=EUR&base_asset_issuer=" + eur +
+		"&counter_asset_type=credit_alphanum4&counter_asset_code=USD&counter_asset_issuer=" + usd +
+		"&start_time=2016-06-29T00:00:00Z&end_time=2016-06-30T00:00:00Z")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// paged by bucket, the time range being clamped to the history available
+	p = get("/trade_aggregations", map[string]string{"resolution": "1m", "limit": "1"})
+	if ht.Assert.Len(p.Embedded.Records, 1) {
+		ht.Assert.Equal(int32(1), p.Embedded.Records[0].TradeCount)
+		ht.Assert.Contains(p.Links.Next.Href, "resolution=1m")
+		ht.Assert.Contains(p.Links.Next.Href, "start_time=2016-06-29T00%3A00%3A00Z")
+		ht.Assert.Contains(p.Links.Next.Href, "end_time=2016-06-29T16%3A35%3A00Z")
+
+		next, err := url.Parse(p.Links.Next.Href)
+		ht.Require.NoError(err)
+		w = ht.Get(next.RequestURI())
+		if ht.Assert.Equal(200, w.Code) {
+			var np page
+			ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &np))
+			if ht.Assert.Len(np.Embedded.Records, 1) {
+				ht.Assert.Equal(int32(3), np.Embedded.Records[0].TradeCount)
+			}
+		}
+	}
+
+	p = get("/trade_aggregations", map[string]string{"resolution": "1m", "order": "desc"})
+	if ht.Assert.Len(p.Embedded.Records, 2) {
+		ht.Assert.Equal(int32(3), p.Embedded.Records[0].TradeCount)
+	}
+
+	p = get("/trade_aggregations", map[string]string{
+		"resolution": "1m",
+		"order":      "desc",
+		"cursor":     "1467218040",
+	})
+	if ht.Assert.Len(p.Embedded.Records, 1) {
+		ht.Assert.Equal(int32(1), p.Embedded.Records[0].TradeCount)
+	}
+
+	// only whole buckets within the requested time range are included
+	p = get("/trade_aggregations", map[string]string{
+		"resolution": "1m",
+		"start_time": "2016-06-29T15:34:30Z",
+	})
+	if ht.Assert.Len(p.Embedded.Records, 1) {
+		ht.Assert.Equal(int32(3), p.Embedded.Records[0].TradeCount)
+	}
+
+	p = get("/trade_aggregations", map[string]string{
+		"resolution": "1m",
+		"end_time":   "2016-06-29T16:34:30Z",
+	})
+	if ht.Assert.Len(p.Embedded.Records, 1) {
+		ht.Assert.Equal(int32(1), p.Embedded.Records[0].TradeCount)
+	}
+
+	p = get("/trade_aggregations", map[string]string{
+		"resolution": "1h",
+		"start_time": "2016-06-29T17:00:00Z",
+		"end_time":   "2016-06-29T16:00:00Z",
+	})
+	ht.Assert.Len(p.Embedded.Records, 0)
+
+	// unsupported resolutions are rejected, listing those supported
+	w = ht.Get("/trade_aggregations?resolution=2m&" + q.Encode())
+	if ht.Assert.Equal(400, w.Code) {
+		var problem struct {
+			Extras struct {
+				InvalidField string   `json:"invalid_field"`
+				ValidValues  []string `json:"valid_values"`
+			} `json:"extras"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+		ht.Assert.Equal("resolution", problem.Extras.InvalidField)
+		ht.Assert.Equal([]string{"1m", "5m", "15m", "1h", "1d", "1w"}, problem.Extras.ValidValues)
+	}
+
+	w = ht.Get("/trade_aggregations?" + q.Encode())
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get("/trade_aggregations?resolution=1m")
+	ht.Assert.Equal(400, w.Code)
+
+	// the time range is required, and may span a limited number of buckets
+	invalidField := func(params map[string]string) string {
+		v := url.Values{}
+		for k := range q {
+			v.Set(k, q.Get(k))
+		}
+		for k, val := range params {
+			if val == "" {
+				v.Del(k)
+				continue
+			}
+			v.Set(k, val)
+		}
+
+		w := ht.Get("/trade_aggregations?" + v.Encode())
+		if !ht.Assert.Equal(400, w.Code) {
+			return ""
+		}
+
+		var problem struct {
+			Extras struct {
+				InvalidField string `json:"invalid_field"`
+			} `json:"extras"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+		return problem.Extras.InvalidField
+	}
+
+	ht.Assert.Equal("start_time", invalidField(map[string]string{
+		"resolution": "1h",
+		"start_time": "",
+	}))
+	ht.Assert.Equal("end_time", invalidField(map[string]string{
+		"resolution": "1h",
+		"end_time":   "",
+	}))
+	ht.Assert.Equal("end_time", invalidField(map[string]string{
+		"resolution": "1m",
+		"start_time": "2016-06-28T00:00:00Z",
+	}))
+}
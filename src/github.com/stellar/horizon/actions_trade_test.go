@@ -1,8 +1,16 @@
 package horizon
 
 import (
+	"fmt"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
+
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/render/sse"
+	"github.com/stellar/horizon/test"
+	"github.com/stellar/horizon/toid"
 )
 
 func TestTradeActions_Index(t *testing.T) {
@@ -29,3 +37,51 @@ func TestTradeActions_Index(t *testing.T) {
 		ht.Assert.PageOf(1, w.Body)
 	}
 }
+
+func TestTradeActions_Stream(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	path := "/accounts/GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2/trades?limit=1"
+
+	// existing trades
+	w := ht.Get(path, test.RequestHelperStreaming)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Contains(w.Body.String(), "id: 25769807873-2\n")
+	}
+
+	// trades since the request, which we simulate by recording a trade in a
+	// ledger after the latest one.
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- ht.Get(path+"&cursor=now", test.RequestHelperStreaming)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("stream sent an existing trade")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	id := toid.New(ledger.CurrentState().HistoryLatest+1, 1, 1).ToInt64()
+	_, err := ht.App.historyQ.ExecRaw(`
+		INSERT INTO history_effects
+		SELECT history_account_id, $1, "order", type, details
+		FROM history_effects
+		WHERE history_operation_id = 25769807873 AND "order" = 2
+	`, id)
+	ht.Require.NoError(err)
+
+	for w = nil; w == nil; {
+		select {
+		case w = <-done:
+		case <-time.After(10 * time.Millisecond):
+			sse.Tick()
+		}
+	}
+
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Contains(w.Body.String(), fmt.Sprintf("id: %d-2\n", id))
+		ht.Assert.NotContains(w.Body.String(), "id: 25769807873-2\n")
+	}
+}
@@ -1,14 +1,21 @@
 package horizon
 
 import (
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/render/hal"
 	"github.com/stellar/horizon/render/problem"
 	"github.com/stellar/horizon/render/sse"
 	"github.com/stellar/horizon/resource"
+	"github.com/stellar/horizon/resource/operations"
 	"github.com/stellar/horizon/txsub"
 )
 
@@ -16,6 +23,8 @@ import (
 //
 // TransactionIndexAction: pages of transactions
 // TransactionShowAction: single transaction by sequence, by hash or id
+// TransactionMetaAction: the result and meta xdr of a single transaction
+// TransactionStatusAction: the statuses of many transactions, by hash
 
 // TransactionIndexAction renders a page of ledger resources, identified by
 // a normal page query.
@@ -23,6 +32,8 @@ type TransactionIndexAction struct {
 	Action
 	LedgerFilter  int32
 	AccountFilter string
+	IncludeFailed bool
+	IncludeRaw    []string
 	PagingParams  db2.PageQuery
 	Records       []history.Transaction
 	Page          hal.Page
@@ -37,7 +48,7 @@ func (action *TransactionIndexAction) JSON() {
 		action.loadRecords,
 		action.loadPage,
 		func() {
-			hal.Render(action.W, action.Page)
+			hal.Render(action.Ctx, action.W, action.Page)
 		},
 	)
 }
@@ -58,6 +69,7 @@ func (action *TransactionIndexAction) SSE(stream sse.Stream) {
 			for _, record := range records {
 				var res resource.Transaction
 				res.Populate(action.Ctx, record)
+				res.IncludeRaw(record, action.IncludeRaw)
 				stream.Send(sse.Event{ID: res.PagingToken(), Data: res})
 			}
 		},
@@ -68,6 +80,8 @@ func (action *TransactionIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
+	action.IncludeFailed = action.GetBool("include_failed")
+	action.IncludeRaw = action.getIncludeRaw()
 	action.PagingParams = action.GetPageQuery()
 }
 
@@ -82,13 +96,26 @@ func (action *TransactionIndexAction) loadRecords() {
 		txs.ForLedger(action.LedgerFilter)
 	}
 
+	if action.IncludeFailed {
+		txs.IncludeFailed()
+	}
+
 	action.Err = txs.Page(action.PagingParams).Select(&action.Records)
+	if action.LedgerFilter > 0 {
+		action.HealLedger(action.LedgerFilter)
+	}
+	if action.Err != nil {
+		return
+	}
+
+	action.loadRawFromCore(action.Records, action.IncludeRaw)
 }
 
 func (action *TransactionIndexAction) loadPage() {
 	for _, record := range action.Records {
 		var res resource.Transaction
 		res.Populate(action.Ctx, record)
+		res.IncludeRaw(record, action.IncludeRaw)
 		action.Page.Add(res)
 	}
 
@@ -97,27 +124,184 @@ func (action *TransactionIndexAction) loadPage() {
 	action.Page.Limit = action.PagingParams.Limit
 	action.Page.Cursor = action.PagingParams.Cursor
 	action.Page.Order = action.PagingParams.Order
+	action.Page.Filters = url.Values{}
+	if action.IncludeFailed {
+		action.Page.Filters.Set("include_failed", "true")
+	}
+	if len(action.IncludeRaw) > 0 {
+		action.Page.Filters.Set("include_raw", strings.Join(action.IncludeRaw, ","))
+	}
 	action.Page.PopulateLinks()
 }
 
 // TransactionShowAction renders a ledger found by its sequence number.
 type TransactionShowAction struct {
 	Action
-	Hash     string
-	Record   history.Transaction
-	Resource resource.Transaction
+	Hash       string
+	IncludeRaw []string
+	Record     history.Transaction
+	Resource   resource.Transaction
 }
 
 func (action *TransactionShowAction) loadParams() {
-	action.Hash = action.GetString("id")
+	action.Hash = action.getTransactionHash("id")
+	action.IncludeRaw = action.getIncludeRaw()
 }
 
+// loadRecord loads the transaction.  Because the hash of a transaction is
+// known before it is submitted, a well-formed hash that is not found is
+// reported with a distinct problem, allowing clients to tell an unconfirmed
+// transaction apart from a bad request.
 func (action *TransactionShowAction) loadRecord() {
 	action.Err = action.HistoryQ().TransactionByHash(&action.Record, action.Hash)
+	if action.HistoryQ().NoRows(action.Err) {
+		action.Err = &problem.NotFoundMaybePending
+		return
+	}
+	if action.Err != nil {
+		return
+	}
+
+	records := []history.Transaction{action.Record}
+	action.loadRawFromCore(records, action.IncludeRaw)
+	action.Record = records[0]
+}
+
+var errInvalidTransactionHash = errors.New("must be a 64 character hex-encoded transaction hash")
+
+// getTransactionHash retrieves the transaction hash named `name` from the
+// request, failing the action with a bad request if it is malformed.
+func (action *Action) getTransactionHash(name string) string {
+	hash := action.GetString(name)
+	if action.Err != nil {
+		return ""
+	}
+
+	if !isTransactionHash(hash) {
+		action.SetInvalidField(name, errInvalidTransactionHash)
+	}
+
+	return hash
+}
+
+// isTransactionHash returns true if `hash` is a hex-encoded 32 byte hash.
+func isTransactionHash(hash string) bool {
+	raw, err := hex.DecodeString(hash)
+	return err == nil && len(raw) == 32
 }
 
 func (action *TransactionShowAction) loadResource() {
 	action.Resource.Populate(action.Ctx, action.Record)
+	action.Resource.IncludeRaw(action.Record, action.IncludeRaw)
+}
+
+// getIncludeRaw retrieves the raw xdr to load from stellar-core, should horizon
+// not have stored it, from the comma-separated `include_raw` parameter, each a
+// resource.TransactionRaw constant.  None is loaded by default.
+func (action *Action) getIncludeRaw() []string {
+	param := action.GetString("include_raw")
+	if action.Err != nil || param == "" {
+		return nil
+	}
+
+	var raws []string
+	seen := map[string]bool{}
+	for _, raw := range strings.Split(param, ",") {
+		raw = strings.TrimSpace(raw)
+		if !isTransactionRaw(raw) {
+			action.SetInvalidField("include_raw", fmt.Errorf(
+				"unknown raw xdr %q, expected a comma-separated list of: %s",
+				raw,
+				strings.Join(resource.TransactionRaws, ", "),
+			))
+			action.Err.(*problem.P).Extras["valid_values"] = resource.TransactionRaws
+			return nil
+		}
+
+		if seen[raw] {
+			continue
+		}
+
+		seen[raw] = true
+		raws = append(raws, raw)
+	}
+
+	return raws
+}
+
+// isTransactionRaw returns true if `raw` names raw xdr that may be included
+// in a transaction resource.
+func isTransactionRaw(raw string) bool {
+	for _, valid := range resource.TransactionRaws {
+		if raw == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// loadRawFromCore fills in, from stellar-core, any raw xdr named by `raws` that
+// is missing from `records`.  Transactions in ledgers stellar-core has since
+// trimmed are left as they are, and are rendered with a warning.
+func (action *Action) loadRawFromCore(records []history.Transaction, raws []string) {
+	if len(raws) == 0 {
+		return
+	}
+
+	var missing []string
+	for _, r := range records {
+		if r.TxEnvelope == "" || r.TxResult == "" || r.TxMeta == "" || r.TxFeeMeta == "" {
+			missing = append(missing, r.TransactionHash)
+		}
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	var (
+		txs  []core.Transaction
+		fees []core.TransactionFee
+	)
+
+	action.Err = action.CoreQ().TransactionsByHash(&txs, missing)
+	if action.Err != nil {
+		return
+	}
+	action.Err = action.CoreQ().TransactionFeesByHash(&fees, missing)
+	if action.Err != nil {
+		return
+	}
+
+	ctxs := map[string]*core.Transaction{}
+	for i := range txs {
+		ctxs[txs[i].TransactionHash] = &txs[i]
+	}
+
+	cfees := map[string]*core.TransactionFee{}
+	for i := range fees {
+		cfees[fees[i].TransactionHash] = &fees[i]
+	}
+
+	for i := range records {
+		r := &records[i]
+
+		if tx, ok := ctxs[r.TransactionHash]; ok {
+			if r.TxEnvelope == "" {
+				r.TxEnvelope = tx.EnvelopeXDR()
+			}
+			if r.TxResult == "" {
+				r.TxResult = tx.ResultXDR()
+			}
+			if r.TxMeta == "" {
+				r.TxMeta = tx.ResultMetaXDR()
+			}
+		}
+
+		if fee, ok := cfees[r.TransactionHash]; ok && r.TxFeeMeta == "" {
+			r.TxFeeMeta = fee.ChangesXDR()
+		}
+	}
 }
 
 // JSON is a method for actions.JSON
@@ -127,10 +311,158 @@ func (action *TransactionShowAction) JSON() {
 		action.loadParams,
 		action.loadRecord,
 		action.loadResource,
-		func() { hal.Render(action.W, action.Resource) },
+		func() { hal.Render(action.Ctx, action.W, action.Resource) },
+	)
+}
+
+// TransactionMetaAction renders the result and meta xdr of a single
+// transaction, found by its hash.  It is a lighter alternative to
+// TransactionShowAction for clients that only process the meta.
+type TransactionMetaAction struct {
+	Action
+	Hash     string
+	Record   history.TransactionMeta
+	Resource resource.TransactionMeta
+}
+
+// JSON is a method for actions.JSON
+func (action *TransactionMetaAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecord,
+		func() {
+			action.Resource.Populate(action.Ctx, action.Record)
+			hal.Render(action.Ctx, action.W, action.Resource)
+		},
 	)
 }
 
+func (action *TransactionMetaAction) loadParams() {
+	action.Hash = action.getTransactionHash("tx_id")
+}
+
+func (action *TransactionMetaAction) loadRecord() {
+	action.Err = action.HistoryQ().TransactionMetaByHash(&action.Record, action.Hash)
+	if action.HistoryQ().NoRows(action.Err) {
+		action.Err = &problem.NotFoundMaybePending
+	}
+}
+
+// MaxTransactionStatusHashes is the largest number of distinct transaction
+// hashes that may be given in a single request to TransactionStatusAction.
+const MaxTransactionStatusHashes = 100
+
+// TransactionStatusAction renders the status of each of a list of
+// transactions, found by their hashes, allowing a client that submitted many
+// transactions to reconcile them in a single request.  Hashes are given by
+// repeating the `hash` parameter; duplicates are ignored.
+type TransactionStatusAction struct {
+	Action
+	Hashes         []string
+	HistoryRecords []history.TransactionLedger
+	CoreRecords    []core.Transaction
+	Page           hal.BasePage
+}
+
+// JSON is a method for actions.JSON
+func (action *TransactionStatusAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.Ctx, action.W, action.Page)
+		},
+	)
+}
+
+func (action *TransactionStatusAction) loadParams() {
+	action.ValidateBodyType()
+	hashes := action.GetStrings("hash")
+	if action.Err != nil {
+		return
+	}
+
+	if len(hashes) == 0 {
+		action.SetInvalidField("hash", errors.New("at least one transaction hash is required"))
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, hash := range hashes {
+		if !isTransactionHash(hash) {
+			action.SetInvalidField("hash", errInvalidTransactionHash)
+			return
+		}
+
+		if seen[hash] {
+			continue
+		}
+
+		seen[hash] = true
+		action.Hashes = append(action.Hashes, hash)
+	}
+
+	if len(action.Hashes) > MaxTransactionStatusHashes {
+		action.SetInvalidField("hash", fmt.Errorf(
+			"at most %d distinct transaction hashes may be given",
+			MaxTransactionStatusHashes,
+		))
+	}
+}
+
+// loadRecords loads the transactions found in history and, for those that are
+// not, any stellar-core has a record of, so that failed transactions can be
+// told apart from pending ones.
+func (action *TransactionStatusAction) loadRecords() {
+	action.Err = action.HistoryQ().TransactionLedgersByHash(
+		&action.HistoryRecords,
+		action.Hashes,
+	)
+	if action.Err != nil {
+		return
+	}
+
+	found := map[string]bool{}
+	for _, r := range action.HistoryRecords {
+		found[r.TransactionHash] = true
+	}
+
+	var missing []string
+	for _, hash := range action.Hashes {
+		if !found[hash] {
+			missing = append(missing, hash)
+		}
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	action.Err = action.CoreQ().TransactionsByHash(&action.CoreRecords, missing)
+}
+
+func (action *TransactionStatusAction) loadPage() {
+	hrows := map[string]*history.TransactionLedger{}
+	for i := range action.HistoryRecords {
+		hrows[action.HistoryRecords[i].TransactionHash] = &action.HistoryRecords[i]
+	}
+
+	crows := map[string]*core.Transaction{}
+	for i := range action.CoreRecords {
+		crows[action.CoreRecords[i].TransactionHash] = &action.CoreRecords[i]
+	}
+
+	action.Page.Init()
+	for _, hash := range action.Hashes {
+		var res resource.TransactionStatus
+		res.Populate(hash, hrows[hash], crows[hash])
+		action.Page.Add(res)
+	}
+}
+
 // TransactionCreateAction submits a transaction to the stellar-core network
 // on behalf of the requesting client.
 type TransactionCreateAction struct {
@@ -148,11 +480,12 @@ func (action *TransactionCreateAction) JSON() {
 		action.loadResource,
 
 		func() {
-			hal.Render(action.W, action.Resource)
+			hal.Render(action.Ctx, action.W, action.Resource)
 		})
 }
 
 func (action *TransactionCreateAction) loadTX() {
+	action.LimitBodySize(int64(action.App.config.MaxSubmissionBodySize))
 	action.ValidateBodyType()
 	action.TX = action.GetString("tx")
 }
@@ -203,6 +536,57 @@ func (action *TransactionCreateAction) loadResource() {
 				"result_codes": rcr,
 			},
 		}
+	case *txsub.WrongNetworkError:
+		action.Err = &problem.P{
+			Type:   "wrong_network",
+			Title:  "Wrong Network",
+			Status: http.StatusBadRequest,
+			Detail: "The transaction was signed for a different stellar network " +
+				"than the one this horizon server submits to, and was not " +
+				"submitted.  Sign the transaction using the network passphrase " +
+				"in the `extras.network_passphrase` field of this response.  The " +
+				"passphrase the transaction was signed with is echoed in the " +
+				"`extras.signed_network_passphrase` field.",
+			Extras: map[string]interface{}{
+				"envelope_xdr":              action.Result.EnvelopeXDR,
+				"network_passphrase":        err.Passphrase,
+				"signed_network_passphrase": err.SignedPassphrase,
+			},
+		}
+	case *txsub.LimitExceededError:
+		action.Err = &problem.P{
+			Type:   "transaction_too_large",
+			Title:  "Transaction Too Large",
+			Status: http.StatusBadRequest,
+			Detail: "The transaction exceeds one of the limits this horizon server " +
+				"places on submissions, and was not submitted.  The " +
+				"`extras.limit` field of this response names the limit exceeded, " +
+				"and the `extras.max` and `extras.actual` fields give its value " +
+				"and that of the transaction.",
+			Extras: map[string]interface{}{
+				"limit":  err.Limit,
+				"max":    err.Max,
+				"actual": err.Actual,
+			},
+		}
+	case *txsub.OperationNotPermittedError:
+		action.Err = &problem.P{
+			Type:   "operation_not_permitted",
+			Title:  "Operation Not Permitted",
+			Status: http.StatusBadRequest,
+			Detail: "The transaction contains an operation of a type this horizon " +
+				"server does not permit in submitted transactions, and was not " +
+				"submitted.  The `extras.operation_index` and " +
+				"`extras.operation_type` fields of this response identify the " +
+				"operation, and the `extras.permitted_operations` field lists the " +
+				"types that are permitted.",
+			Extras: map[string]interface{}{
+				"envelope_xdr":         action.Result.EnvelopeXDR,
+				"operation_index":      err.Index,
+				"operation_type":       operations.TypeNames[err.Type],
+				"permitted_operations": operationTypeNames(action.App.submitter.SubmittableOperations),
+			},
+		}
 	case *txsub.MalformedTransactionError:
 		action.Err = &problem.P{
 			Type:   "transaction_malformed",
@@ -2,9 +2,13 @@ package horizon
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/url"
 	"testing"
 
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/resource"
 	"github.com/stellar/horizon/txsub"
 	"github.com/stellar/horizon/txsub/sequence"
@@ -25,11 +29,71 @@ func TestTransactionActions_Show(t *testing.T) {
 			"2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d",
 			actual.Hash,
 		)
+		ht.Assert.Equal(int32(1), actual.ApplicationOrder)
 	}
 
-	// missing tx
+	// the application order is that within the transaction's ledger
+	w = ht.Get("/transactions/164a5064eba64f2cdbadb856bf3448485fc626247ada3ed39cddf0f6902133b6")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual resource.Transaction
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(int32(2), actual.ApplicationOrder)
+	}
+
+	// malformed hash
 	w = ht.Get("/transactions/not_real")
-	ht.Assert.Equal(404, w.Code)
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "bad_request")
+	}
+
+	w = ht.Get("/transactions/2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d")
+	ht.Assert.Equal(400, w.Code)
+
+	// missing tx
+	w = ht.Get("/transactions/0000000000000000000000000000000000000000000000000000000000000000")
+	if ht.Assert.Equal(404, w.Code) {
+		ht.Assert.ProblemType(w.Body, "not_found_maybe_pending")
+	}
+}
+
+func TestTransactionActions_Meta(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const hash = "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+
+	w := ht.Get("/transactions/" + hash + "/meta")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual resource.TransactionMeta
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+
+		var tx resource.Transaction
+		w = ht.Get("/transactions/" + hash)
+		err = json.Unmarshal(w.Body.Bytes(), &tx)
+		ht.Require.NoError(err)
+
+		ht.Assert.Equal(hash, actual.Hash)
+		ht.Assert.Equal(tx.Ledger, actual.Ledger)
+		ht.Assert.Equal(tx.ResultXdr, actual.ResultXdr)
+		ht.Assert.Equal(tx.ResultMetaXdr, actual.ResultMetaXdr)
+		ht.Assert.Equal(tx.FeeMetaXdr, actual.FeeMetaXdr)
+		ht.Assert.NotEqual("", actual.ResultMetaXdr)
+		ht.Assert.NotEqual("", actual.FeeMetaXdr)
+	}
+
+	// malformed hash
+	w = ht.Get("/transactions/not_real/meta")
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "bad_request")
+	}
+
+	// missing tx
+	w = ht.Get("/transactions/0000000000000000000000000000000000000000000000000000000000000000/meta")
+	if ht.Assert.Equal(404, w.Code) {
+		ht.Assert.ProblemType(w.Body, "not_found_maybe_pending")
+	}
 }
 
 func TestTransactionActions_Index(t *testing.T) {
@@ -78,6 +142,168 @@ func TestTransactionActions_Index(t *testing.T) {
 	}
 }
 
+func TestTransactionActions_IncludeFailed(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const account = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+	// the first transaction of the scenario is stored as having failed
+	var hash string
+	err := ht.App.historyQ.GetRaw(&hash, `
+		SELECT transaction_hash FROM history_transactions ORDER BY id LIMIT 1
+	`)
+	ht.Require.NoError(err)
+	_, err = ht.App.historyQ.ExecRaw(`
+		UPDATE history_transactions SET successful = false
+		WHERE transaction_hash = $1
+	`, hash)
+	ht.Require.NoError(err)
+
+	type page struct {
+		Embedded struct {
+			Records []resource.Transaction `json:"records"`
+		} `json:"_embedded"`
+		Links struct {
+			Next struct {
+				Href string `json:"href"`
+			} `json:"next"`
+		} `json:"_links"`
+	}
+
+	load := func(path string) (p page) {
+		w := ht.Get(path)
+		if ht.Assert.Equal(200, w.Code, path) {
+			ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &p))
+		}
+		return
+	}
+
+	// failed transactions are excluded by default...
+	p := load("/accounts/" + account + "/transactions")
+	if ht.Assert.Len(p.Embedded.Records, 2) {
+		for _, tx := range p.Embedded.Records {
+			ht.Assert.NotEqual(hash, tx.Hash)
+			ht.Assert.True(tx.Successful)
+		}
+	}
+
+	p = load("/transactions")
+	ht.Assert.Len(p.Embedded.Records, 3)
+
+	// ...and included, in the order they were applied, when requested
+	p = load("/accounts/" + account + "/transactions?include_failed=true")
+	if ht.Assert.Len(p.Embedded.Records, 3) {
+		ht.Assert.Equal(hash, p.Embedded.Records[0].Hash)
+		ht.Assert.False(p.Embedded.Records[0].Successful)
+		ht.Assert.True(p.Embedded.Records[1].Successful)
+		ht.Assert.Contains(p.Links.Next.Href, "include_failed=true")
+	}
+
+	p = load("/accounts/" + account + "/transactions?include_failed=true&limit=1")
+	if ht.Assert.Len(p.Embedded.Records, 1) {
+		ht.Assert.Equal(hash, p.Embedded.Records[0].Hash)
+
+		next, err := url.Parse(p.Links.Next.Href)
+		ht.Require.NoError(err)
+		p = load(next.RequestURI())
+		if ht.Assert.Len(p.Embedded.Records, 1) {
+			ht.Assert.NotEqual(hash, p.Embedded.Records[0].Hash)
+		}
+	}
+
+	// a failed transaction can always be found by its hash
+	w := ht.Get("/transactions/" + hash)
+	if ht.Assert.Equal(200, w.Code) {
+		var tx resource.Transaction
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &tx))
+		ht.Assert.False(tx.Successful)
+	}
+
+	w = ht.Get("/accounts/" + account + "/transactions?include_failed=maybe")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestTransactionActions_IncludeRaw(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const hash = "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+
+	show := func(query string) (tx resource.Transaction) {
+		w := ht.Get("/transactions/" + hash + query)
+		if ht.Assert.Equal(200, w.Code) {
+			ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &tx))
+		}
+		return
+	}
+
+	// all raw xdr is included by default
+	full := show("")
+	ht.Assert.NotEqual("", full.EnvelopeXdr)
+	ht.Assert.NotEqual("", full.ResultXdr)
+	ht.Assert.NotEqual("", full.ResultMetaXdr)
+	ht.Assert.NotEqual("", full.FeeMetaXdr)
+	ht.Assert.Empty(full.Warnings)
+
+	// the page links carry the parameter
+	w := ht.Get("/transactions?include_raw=meta")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+		ht.Assert.Contains(w.Body.String(), "include_raw=meta")
+	}
+
+	_, err := ht.App.historyQ.ExecRaw(`
+		UPDATE history_transactions SET tx_meta = '', tx_fee_meta = ''
+		WHERE transaction_hash = $1
+	`, hash)
+	ht.Require.NoError(err)
+
+	// meta missing from the history database is blank unless requested...
+	tx := show("")
+	ht.Assert.Equal("", tx.ResultMetaXdr)
+	ht.Assert.Equal("", tx.FeeMetaXdr)
+	ht.Assert.Empty(tx.Warnings)
+
+	// ...in which case it is loaded from stellar-core
+	tx = show("?include_raw=meta")
+	ht.Assert.Equal(full.ResultMetaXdr, tx.ResultMetaXdr)
+	ht.Assert.Equal(full.FeeMetaXdr, tx.FeeMetaXdr)
+	ht.Assert.Empty(tx.Warnings)
+
+	// and is blank, with a warning, once stellar-core has trimmed the ledger
+	_, err = ht.App.coreQ.ExecRaw(`DELETE FROM txhistory WHERE txid = $1`, hash)
+	ht.Require.NoError(err)
+	_, err = ht.App.coreQ.ExecRaw(`DELETE FROM txfeehistory WHERE txid = $1`, hash)
+	ht.Require.NoError(err)
+
+	tx = show("?include_raw=meta")
+	ht.Assert.Equal("", tx.ResultMetaXdr)
+	ht.Assert.Equal("", tx.FeeMetaXdr)
+	ht.Assert.Len(tx.Warnings, 2)
+
+	// the xdr horizon stored is unaffected
+	ht.Assert.Equal(full.EnvelopeXdr, tx.EnvelopeXdr)
+	ht.Assert.Equal(full.ResultXdr, tx.ResultXdr)
+
+	// unknown raw xdr is rejected
+	w = ht.Get("/transactions/" + hash + "?include_raw=envelope,signatures")
+	if ht.Assert.Equal(400, w.Code) {
+		var problem struct {
+			Extras struct {
+				InvalidField string   `json:"invalid_field"`
+				ValidValues  []string `json:"valid_values"`
+			} `json:"extras"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+		ht.Assert.Equal("include_raw", problem.Extras.InvalidField)
+		ht.Assert.Equal([]string{"envelope", "result", "meta"}, problem.Extras.ValidValues)
+	}
+
+	w = ht.Get("/transactions?include_raw=all")
+	ht.Assert.Equal(400, w.Code)
+}
+
 func TestTransactionActions_Post(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -88,6 +314,18 @@ func TestTransactionActions_Post(t *testing.T) {
 	w := ht.Post("/transactions", form)
 	ht.Assert.Equal(200, w.Code)
 
+	// a request body larger than the configured maximum is rejected, and one
+	// at the maximum is read in full.
+	ht.App.config.MaxSubmissionBodySize = uint(len(form.Encode()) - 1)
+	w = ht.Post("/transactions", form)
+	if ht.Assert.Equal(413, w.Code) {
+		ht.Assert.ProblemType(w.Body, "request_too_large")
+	}
+
+	ht.App.config.MaxSubmissionBodySize = uint(len(form.Encode()))
+	w = ht.Post("/transactions", form)
+	ht.Assert.Equal(200, w.Code)
+
 	// sequence buffer full
 	ht.App.submitter.Results = &txsub.MockResultProvider{
 		Results: []txsub.Result{
@@ -96,4 +334,137 @@ func TestTransactionActions_Post(t *testing.T) {
 	}
 	w = ht.Post("/transactions", form)
 	ht.Assert.Equal(503, w.Code)
+
+	// signed for the wrong network
+	ht.App.submitter.Results = &txsub.MockResultProvider{}
+	ht.App.submitter.SetNetwork(build.PublicNetwork.Passphrase, true)
+	w = ht.Post("/transactions", form)
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "wrong_network")
+	}
+
+	// exceeding a submission limit
+	ht.App.submitter.SetNetwork(build.PublicNetwork.Passphrase, false)
+	ht.App.submitter.Limits = txsub.Limits{MaxEnvelopeSize: 16}
+	w = ht.Post("/transactions", form)
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "transaction_too_large")
+	}
+
+	// containing an operation that is not permitted
+	ht.App.submitter.Limits = txsub.Limits{}
+	ht.App.submitter.SubmittableOperations = txsub.OperationTypes{
+		xdr.OperationTypePayment:      true,
+		xdr.OperationTypeAccountMerge: true,
+	}
+	w = ht.Post("/transactions", form)
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "operation_not_permitted")
+
+		var p struct {
+			Extras struct {
+				OperationIndex      int      `json:"operation_index"`
+				OperationType       string   `json:"operation_type"`
+				PermittedOperations []string `json:"permitted_operations"`
+			} `json:"extras"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &p))
+		ht.Assert.Equal(0, p.Extras.OperationIndex)
+		ht.Assert.Equal("create_account", p.Extras.OperationType)
+		ht.Assert.Equal([]string{"account_merge", "payment"}, p.Extras.PermittedOperations)
+	}
+}
+
+func TestTransactionActions_Status(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const (
+		applied = "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+		unknown = "0000000000000000000000000000000000000000000000000000000000000000"
+	)
+
+	form := url.Values{"hash": []string{unknown, applied, unknown}}
+	w := ht.Post("/transactions/status", form)
+	if ht.Assert.Equal(200, w.Code) {
+		var page struct {
+			Embedded struct {
+				Records []resource.TransactionStatus `json:"records"`
+			} `json:"_embedded"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &page)
+		ht.Require.NoError(err)
+
+		// duplicates are removed, and the order of the request kept
+		records := page.Embedded.Records
+		if ht.Assert.Equal(2, len(records)) {
+			ht.Assert.Equal(resource.TransactionStatus{
+				Hash:   unknown,
+				Status: "pending",
+			}, records[0])
+			ht.Assert.Equal(resource.TransactionStatus{
+				Hash:       applied,
+				Status:     "success",
+				Found:      true,
+				Successful: true,
+				Ledger:     2,
+			}, records[1])
+		}
+	}
+
+	// failed transactions are only known to stellar-core
+	var failed core.Transaction
+	failed.LedgerSequence = 3
+	failed.Result.Result.Result.Code = xdr.TransactionResultCodeTxFailed
+	var res resource.TransactionStatus
+	res.Populate(unknown, nil, &failed)
+	ht.Assert.Equal(resource.TransactionStatus{
+		Hash:   unknown,
+		Status: "failed",
+		Found:  true,
+		Ledger: 3,
+	}, res)
+
+	// unless failed transactions are stored in history
+	_, err := ht.App.historyQ.ExecRaw(`
+		UPDATE history_transactions SET successful = false
+		WHERE transaction_hash = $1
+	`, applied)
+	ht.Require.NoError(err)
+
+	w = ht.Post("/transactions/status", url.Values{"hash": []string{applied}})
+	if ht.Assert.Equal(200, w.Code) {
+		var page struct {
+			Embedded struct {
+				Records []resource.TransactionStatus `json:"records"`
+			} `json:"_embedded"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &page))
+		if ht.Assert.Len(page.Embedded.Records, 1) {
+			ht.Assert.Equal(resource.TransactionStatus{
+				Hash:   applied,
+				Status: "failed",
+				Found:  true,
+				Ledger: 2,
+			}, page.Embedded.Records[0])
+		}
+	}
+
+	// malformed hash
+	w = ht.Post("/transactions/status", url.Values{"hash": []string{applied, "not_real"}})
+	ht.Assert.Equal(400, w.Code)
+
+	// no hashes
+	w = ht.Post("/transactions/status", url.Values{})
+	ht.Assert.Equal(400, w.Code)
+
+	// too many hashes
+	var many []string
+	for i := 0; i <= MaxTransactionStatusHashes; i++ {
+		many = append(many, fmt.Sprintf("%064x", i))
+	}
+	w = ht.Post("/transactions/status", url.Values{"hash": many})
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.Contains(w.Body.String(), "at most")
+	}
 }
@@ -0,0 +1,98 @@
+package horizon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/horizon/log"
+)
+
+// serveAdmin serves the admin port (see Config.AdminPort), which exposes
+// operations that must not be reachable by the public, on the loopback
+// interface only.
+func (a *App) serveAdmin() {
+	addr := fmt.Sprintf("127.0.0.1:%d", a.config.AdminPort)
+	log.Infof("Starting admin server on %s", addr)
+
+	// a nil *ingest.System must not become a non-nil sessionReporter
+	var sessions sessionReporter
+	if a.ingester != nil {
+		sessions = a.ingester
+	}
+
+	err := http.ListenAndServe(addr, adminHandler(a.reingestJobs, sessions))
+	if err != nil {
+		log.WithField("err", err).Error("admin server failed")
+	}
+}
+
+// adminHandler returns the handler of the admin port.  Reingestion is started
+// and monitored with `jobs`, and the session in progress reported by
+// `sessions`, both of which are nil when ingestion is disabled.
+func adminHandler(jobs *reingestJobs, sessions sessionReporter) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/log_levels", logLevelsHandler)
+	mux.HandleFunc("/cursors", cursorsHandler(sessions))
+	mux.HandleFunc("/ingest/reingest", jobs.reingestHandler)
+	mux.HandleFunc("/ingest/jobs/", jobs.jobHandler)
+	return mux
+}
+
+// adminActor identifies the client making `r` in audit events.
+func adminActor(r *http.Request) string {
+	return "admin:" + r.RemoteAddr
+}
+
+// logLevelsHandler reports the current log levels on GET.  On POST or PUT it
+// first sets the level of the subsystem named by the `subsystem` parameter
+// (see log.SetLevelFor) to the `level` parameter, or, when `level` is
+// "default", causes the subsystem to follow the default level again.  Changes
+// are recorded by log.DefaultAuditor.
+func logLevelsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST", "PUT":
+		subsystem, level := r.FormValue("subsystem"), r.FormValue("level")
+		event := log.Audit(adminActor(r), "set_log_level", map[string]interface{}{
+			"subsystem": subsystem,
+			"level":     level,
+		})
+
+		err := setLogLevel(subsystem, level)
+		event.End(err)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	levels := map[string]string{
+		"default": log.DefaultLogger.Logger.Level.String(),
+	}
+	for _, name := range log.Subsystems() {
+		level, _, _ := log.LevelFor(name)
+		levels[name] = level.String()
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(levels)
+}
+
+// setLogLevel parses `level` and applies it to the named subsystem.
+func setLogLevel(subsystem, level string) error {
+	if level == "default" {
+		return log.ResetLevelFor(subsystem)
+	}
+
+	ll, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	return log.SetLevelFor(subsystem, ll)
+}
@@ -0,0 +1,117 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stellar/horizon/ingest"
+	"github.com/stellar/horizon/ledger"
+)
+
+// sessionReporter is the part of *ingest.System that the cursors endpoint
+// uses.
+type sessionReporter interface {
+	CurrentSession() (ingest.SessionStatus, bool)
+}
+
+// cursorsReport is the body of the admin port's /cursors endpoint.
+type cursorsReport struct {
+	// Ingesting is false when this horizon does not run ingestion, in which
+	// case no session is ever in progress.
+	Ingesting  bool               `json:"ingesting"`
+	InProgress bool               `json:"in_progress"`
+	Session    *cursorsSession    `json:"session"`
+	State      cursorsLedgerState `json:"ledger_state"`
+	Readings   []cursorsReading   `json:"readings"`
+}
+
+type cursorsSession struct {
+	ID          string    `json:"id"`
+	FirstLedger int32     `json:"first_ledger"`
+	LastLedger  int32     `json:"last_ledger"`
+	Backfill    bool      `json:"backfill"`
+	Reingest    bool      `json:"reingest"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type cursorsLedgerState struct {
+	CoreLatest    int32     `json:"core_latest"`
+	CoreElder     int32     `json:"core_elder"`
+	HistoryLatest int32     `json:"history_latest"`
+	HistoryElder  int32     `json:"history_elder"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Age           string    `json:"age"`
+}
+
+type cursorsReading struct {
+	Source    string    `json:"source"`
+	Latest    int32     `json:"latest"`
+	Elder     int32     `json:"elder"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// cursorsHandler reports, for troubleshooting ingestion, the ledger range of
+// the ingestion session in progress, if any, be it started by a tick, by
+// backfilling or by a reingestion such as a reingest job's, along with the
+// cached ledger state from which ingestion ticks derive their sessions and the
+// reading of each database it aggregates.  `sessions` is nil when ingestion is
+// disabled.
+func cursorsHandler(sessions sessionReporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var report cursorsReport
+
+		if sessions != nil {
+			report.Ingesting = true
+
+			if s, ok := sessions.CurrentSession(); ok {
+				report.InProgress = true
+				report.Session = &cursorsSession{
+					ID:          s.ID,
+					FirstLedger: s.FirstLedger,
+					LastLedger:  s.LastLedger,
+					Backfill:    s.Backfill,
+					Reingest:    s.Reingest,
+					CreatedAt:   s.CreatedAt,
+				}
+			}
+		}
+
+		ls, age := ledger.CurrentStateWithAge()
+		report.State = cursorsLedgerState{
+			CoreLatest:    ls.CoreLatest,
+			CoreElder:     ls.CoreElder,
+			HistoryLatest: ls.HistoryLatest,
+			HistoryElder:  ls.HistoryElder,
+			UpdatedAt:     ls.UpdatedAt,
+			Age:           age.String(),
+		}
+
+		report.Readings = []cursorsReading{}
+		for _, src := range []ledger.Source{
+			ledger.CorePrimary,
+			ledger.CoreSecondary,
+			ledger.HistoryPrimary,
+		} {
+			reading, ok := ledger.CurrentReading(src)
+			if !ok {
+				continue
+			}
+
+			report.Readings = append(report.Readings, cursorsReading{
+				Source:    src.String(),
+				Latest:    reading.Latest,
+				Elder:     reading.Elder,
+				UpdatedAt: reading.UpdatedAt,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(report)
+	}
+}
@@ -0,0 +1,94 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/ingest"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessions reports `status` as the session in progress, when it is not
+// nil.
+type fakeSessions struct {
+	status *ingest.SessionStatus
+}
+
+func (f *fakeSessions) CurrentSession() (ingest.SessionStatus, bool) {
+	if f.status == nil {
+		return ingest.SessionStatus{}, false
+	}
+	return *f.status, true
+}
+
+func TestAdminCursors(t *testing.T) {
+	ledger.Reset()
+	defer ledger.Reset()
+	ledger.SetState(ledger.State{
+		CoreLatest:    10,
+		CoreElder:     1,
+		HistoryLatest: 8,
+		HistoryElder:  1,
+	})
+
+	report := func(sessions sessionReporter) cursorsReport {
+		w := adminRequest(t, adminHandler(nil, sessions), "GET", "/cursors", "")
+		require.Equal(t, 200, w.Code)
+
+		var ret cursorsReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ret))
+		return ret
+	}
+
+	// ingestion disabled
+	r := report(nil)
+	assert.False(t, r.Ingesting)
+	assert.False(t, r.InProgress)
+	assert.Nil(t, r.Session)
+	assert.Equal(t, int32(10), r.State.CoreLatest)
+	assert.Equal(t, int32(8), r.State.HistoryLatest)
+	if assert.Len(t, r.Readings, 2) {
+		assert.Equal(t, "core_primary", r.Readings[0].Source)
+		assert.Equal(t, int32(10), r.Readings[0].Latest)
+		assert.Equal(t, "history_primary", r.Readings[1].Source)
+	}
+
+	// idle
+	sessions := &fakeSessions{}
+	r = report(sessions)
+	assert.True(t, r.Ingesting)
+	assert.False(t, r.InProgress)
+	assert.Nil(t, r.Session)
+
+	// ingesting
+	created := time.Date(2016, 10, 14, 18, 42, 21, 0, time.UTC)
+	sessions.status = &ingest.SessionStatus{
+		ID:          "abc",
+		FirstLedger: 9,
+		LastLedger:  10,
+		CreatedAt:   created,
+	}
+	r = report(sessions)
+	assert.True(t, r.InProgress)
+	if assert.NotNil(t, r.Session) {
+		assert.Equal(t, "abc", r.Session.ID)
+		assert.Equal(t, int32(9), r.Session.FirstLedger)
+		assert.Equal(t, int32(10), r.Session.LastLedger)
+		assert.False(t, r.Session.Backfill)
+		assert.False(t, r.Session.Reingest)
+		assert.True(t, created.Equal(r.Session.CreatedAt))
+	}
+
+	// reingesting
+	sessions.status.Reingest = true
+	r = report(sessions)
+	if assert.NotNil(t, r.Session) {
+		assert.True(t, r.Session.Reingest)
+	}
+
+	w := adminRequest(t, adminHandler(nil, sessions), "POST", "/cursors", "")
+	assert.Equal(t, 405, w.Code)
+}
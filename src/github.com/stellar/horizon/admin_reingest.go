@@ -0,0 +1,398 @@
+package horizon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stellar/horizon/errors"
+	"github.com/stellar/horizon/ingest"
+	"github.com/stellar/horizon/log"
+	"golang.org/x/net/context"
+)
+
+const (
+	// reingestChunkSize is the number of ledgers a reingest job reingests in
+	// each ingestion session.  The job's progress is updated, and a request to
+	// cancel it takes effect, between sessions.
+	reingestChunkSize = 100
+
+	// maxFinishedReingestJobs is the number of finished reingest jobs whose
+	// outcome is retained.  Older ones are forgotten.
+	maxFinishedReingestJobs = 32
+
+	// reingestSessionPollInterval is how often a reingest job checks whether
+	// the ingestion session that holds up its next ledgers has finished.
+	reingestSessionPollInterval = 100 * time.Millisecond
+)
+
+// The states of a reingestJob.
+const (
+	jobRunning   = "running"
+	jobSucceeded = "succeeded"
+	jobFailed    = "failed"
+	jobCancelled = "cancelled"
+)
+
+// errReingestPanicked is the error of a reingest job whose reingestion
+// panicked.  The panic itself is logged and reported by errors.Recover.
+var errReingestPanicked = fmt.Errorf("reingestion panicked")
+
+// reingester is the part of *ingest.System that reingest jobs use.
+type reingester interface {
+	sessionReporter
+	ReingestBounds() (start, end int32)
+	ReingestRange(start, end int32) (int, error)
+}
+
+// reingestJob describes a reingestion started through the admin port.
+type reingestJob struct {
+	ID        string     `json:"id"`
+	Start     int32      `json:"start"`
+	End       int32      `json:"end"`
+	State     string     `json:"state"`
+	Ingested  int        `json:"ingested"`
+	Total     int        `json:"total"`
+	Error     string     `json:"error,omitempty"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+
+	cancel context.CancelFunc
+}
+
+// reingestJobs runs the reingest jobs of the admin port in the background and
+// tracks their progress.  At most one running job may include a given ledger,
+// and a job never reingests ledgers that the ingester's session in progress
+// includes.
+type reingestJobs struct {
+	ctx          context.Context
+	sys          reingester
+	chunkSize    int32
+	pollInterval time.Duration
+
+	lock     sync.Mutex
+	lastID   int
+	jobs     map[string]*reingestJob
+	finished []string
+	wg       sync.WaitGroup
+}
+
+// overlapError is returned when a reingest job would include ledgers that a
+// running job, or the ingester's session in progress, includes.
+type overlapError struct {
+	ID string
+
+	// Session is set when ID identifies an ingestion session rather than a
+	// job.
+	Session bool
+}
+
+func (e *overlapError) Error() string {
+	if e.Session {
+		return fmt.Sprintf("overlaps ingestion session %s", e.ID)
+	}
+	return fmt.Sprintf("overlaps running job %s", e.ID)
+}
+
+// sessionOverlaps returns the ingester's session in progress should it include
+// any of the ledgers from `start` to `end`.
+func (j *reingestJobs) sessionOverlaps(start, end int32) (ingest.SessionStatus, bool) {
+	s, ok := j.sys.CurrentSession()
+	if !ok || end < s.FirstLedger || s.LastLedger < start {
+		return ingest.SessionStatus{}, false
+	}
+	return s, true
+}
+
+// newReingestJobs returns a reingestJobs running jobs with `sys`.  Jobs are
+// cancelled when `ctx` is done.
+func newReingestJobs(ctx context.Context, sys reingester) *reingestJobs {
+	return &reingestJobs{
+		ctx:          ctx,
+		sys:          sys,
+		chunkSize:    reingestChunkSize,
+		pollInterval: reingestSessionPollInterval,
+		jobs:         map[string]*reingestJob{},
+	}
+}
+
+// Start starts a job reingesting the ledgers from `start` to `end`, inclusive,
+// and returns it.  It returns an *overlapError when a running job, or the
+// ingester's session in progress, includes any of the ledgers.  The job is
+// recorded by log.DefaultAuditor as an action of `actor`.
+func (j *reingestJobs) Start(start, end int32, actor string) (reingestJob, error) {
+	if start <= 0 || end < start {
+		return reingestJob{}, fmt.Errorf("invalid range: %d-%d", start, end)
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	for _, other := range j.jobs {
+		if other.State == jobRunning && start <= other.End && other.Start <= end {
+			return reingestJob{}, &overlapError{ID: other.ID}
+		}
+	}
+
+	if s, ok := j.sessionOverlaps(start, end); ok {
+		return reingestJob{}, &overlapError{ID: s.ID, Session: true}
+	}
+
+	j.lastID++
+	ctx, cancel := context.WithCancel(j.ctx)
+	job := &reingestJob{
+		ID:        strconv.Itoa(j.lastID),
+		Start:     start,
+		End:       end,
+		State:     jobRunning,
+		Total:     int(end-start) + 1,
+		StartedAt: time.Now().UTC(),
+		cancel:    cancel,
+	}
+	j.jobs[job.ID] = job
+
+	event := log.Audit(actor, "reingest", map[string]interface{}{
+		"ledgers": fmt.Sprintf("%d-%d", start, end),
+		"job":     job.ID,
+	})
+
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		defer errors.Recover("reingest job", log.F{"job": job.ID})
+		j.run(ctx, job, event)
+	}()
+
+	return *job, nil
+}
+
+// Get returns the job identified by `id`, if it is known.
+func (j *reingestJobs) Get(id string) (reingestJob, bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	job, ok := j.jobs[id]
+	if !ok {
+		return reingestJob{}, false
+	}
+	return *job, true
+}
+
+// Cancel requests that the job identified by `id` stop, and returns it.  A
+// running job stops once the ledgers it is reingesting when cancelled are
+// done.  Cancelling a job that has finished has no effect.
+func (j *reingestJobs) Cancel(id string) (reingestJob, bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	job, ok := j.jobs[id]
+	if !ok {
+		return reingestJob{}, false
+	}
+
+	job.cancel()
+	return *job, true
+}
+
+// Wait waits for the running jobs to finish.
+func (j *reingestJobs) Wait() {
+	j.wg.Wait()
+}
+
+// run runs `job` and records its outcome.
+func (j *reingestJobs) run(ctx context.Context, job *reingestJob, event *log.AuditEvent) {
+	err := errReingestPanicked
+	defer func() {
+		event.End(err)
+		j.finish(job, err)
+	}()
+
+	err = j.reingest(ctx, job)
+}
+
+// reingest reingests the ledgers of `job` in chunks of j.chunkSize ledgers,
+// until they are done, a chunk fails or `ctx` is done.  Each chunk waits for
+// an ingestion session that includes any of its ledgers, such as one started
+// by a tick since the job started, to finish.
+func (j *reingestJobs) reingest(ctx context.Context, job *reingestJob) error {
+	for start := int64(job.Start); start <= int64(job.End); start += int64(j.chunkSize) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := start + int64(j.chunkSize) - 1
+		if end > int64(job.End) {
+			end = int64(job.End)
+		}
+
+		if err := j.waitForSession(ctx, int32(start), int32(end)); err != nil {
+			return err
+		}
+
+		n, err := j.sys.ReingestRange(int32(start), int32(end))
+
+		j.lock.Lock()
+		job.Ingested += n
+		j.lock.Unlock()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitForSession waits until the ingester's session in progress, if any, no
+// longer includes any of the ledgers from `start` to `end`, or `ctx` is done.
+func (j *reingestJobs) waitForSession(ctx context.Context, start, end int32) error {
+	for {
+		if _, ok := j.sessionOverlaps(start, end); !ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(j.pollInterval):
+		}
+	}
+}
+
+// finish records the outcome of `job`, and forgets the oldest finished jobs
+// beyond maxFinishedReingestJobs.
+func (j *reingestJobs) finish(job *reingestJob, err error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	now := time.Now().UTC()
+	job.EndedAt = &now
+	job.cancel()
+
+	switch {
+	case err == nil:
+		job.State = jobSucceeded
+	case err == context.Canceled:
+		job.State = jobCancelled
+	default:
+		job.State = jobFailed
+		job.Error = err.Error()
+	}
+
+	j.finished = append(j.finished, job.ID)
+	for len(j.finished) > maxFinishedReingestJobs {
+		delete(j.jobs, j.finished[0])
+		j.finished = j.finished[1:]
+	}
+}
+
+// reingestRequest is the body of a request to start a reingest job.  It names
+// either a range of ledgers, a single ledger or all ledgers (see
+// ingest.System.ReingestBounds).
+type reingestRequest struct {
+	Start  int32 `json:"start"`
+	End    int32 `json:"end"`
+	Ledger int32 `json:"ledger"`
+	All    bool  `json:"all"`
+}
+
+// bounds returns the range of ledgers requested by `r`.
+func (r reingestRequest) bounds(sys reingester) (start, end int32, err error) {
+	rangeGiven := r.Start != 0 || r.End != 0
+
+	switch {
+	case r.All && r.Ledger == 0 && !rangeGiven:
+		start, end = sys.ReingestBounds()
+	case r.Ledger != 0 && !r.All && !rangeGiven:
+		start, end = r.Ledger, r.Ledger
+	case rangeGiven && !r.All && r.Ledger == 0:
+		start, end = r.Start, r.End
+	default:
+		err = fmt.Errorf("specify one of start and end, ledger or all")
+	}
+	return
+}
+
+// reingestHandler starts a reingest job on POST, for the ledgers named by the
+// JSON body (see reingestRequest), and responds with the job.  It responds
+// with 409 Conflict should the ledgers overlap those of a running job or of the
+// ingester's session in progress.
+func (j *reingestJobs) reingestHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if j == nil {
+		http.Error(w, "reingestion requires ingestion to be enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req reingestRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	start, end, err := req.bounds(j.sys)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job, err := j.Start(start, end, adminActor(r))
+	if _, ok := err.(*overlapError); ok {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeAdminJSON(w, http.StatusAccepted, job)
+}
+
+// jobHandler reports the reingest job identified by the last element of the
+// path on GET, and cancels it on DELETE (see Cancel).
+func (j *reingestJobs) jobHandler(w http.ResponseWriter, r *http.Request) {
+	if j == nil {
+		http.Error(w, "reingestion requires ingestion to be enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	var (
+		job reingestJob
+		ok  bool
+	)
+	switch r.Method {
+	case "GET":
+		job, ok = j.Get(id)
+	case "DELETE":
+		job, ok = j.Cancel(id)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !ok {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, job)
+}
+
+// writeAdminJSON writes `v` as the JSON body of a response with `status`.
+func writeAdminJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
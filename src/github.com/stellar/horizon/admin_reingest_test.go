@@ -0,0 +1,310 @@
+package horizon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/ingest"
+	"github.com/stellar/horizon/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// fakeReingester records the ranges it is asked to reingest.  When entered is
+// not nil, each call sends to it on entry, and when gate is not nil, each call
+// waits to receive from it before returning.  Ranges including failAt fail.
+// The ingestion session it reports in progress is set with SetSession.
+type fakeReingester struct {
+	entered chan struct{}
+	gate    chan struct{}
+	failAt  int32
+	start   int32
+	end     int32
+
+	lock    sync.Mutex
+	calls   []string
+	session *ingest.SessionStatus
+}
+
+func (f *fakeReingester) CurrentSession() (ingest.SessionStatus, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.session == nil {
+		return ingest.SessionStatus{}, false
+	}
+	return *f.session, true
+}
+
+func (f *fakeReingester) SetSession(s *ingest.SessionStatus) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.session = s
+}
+
+func (f *fakeReingester) ReingestBounds() (int32, int32) {
+	return f.start, f.end
+}
+
+func (f *fakeReingester) ReingestRange(start, end int32) (int, error) {
+	f.lock.Lock()
+	f.calls = append(f.calls, fmt.Sprintf("%d-%d", start, end))
+	f.lock.Unlock()
+
+	if f.entered != nil {
+		f.entered <- struct{}{}
+	}
+	if f.gate != nil {
+		<-f.gate
+	}
+
+	if start <= f.failAt && f.failAt <= end {
+		return 0, fmt.Errorf("ledger %d: load failed", f.failAt)
+	}
+	return int(end-start) + 1, nil
+}
+
+func (f *fakeReingester) Calls() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// testReingestJobs returns a handler of the admin port whose reingest jobs are
+// run with `sys`, five ledgers at a time.
+func testReingestJobs(sys reingester) (*reingestJobs, http.Handler) {
+	jobs := newReingestJobs(context.Background(), sys)
+	jobs.chunkSize = 5
+	return jobs, adminHandler(jobs, nil)
+}
+
+// adminRequest makes a request of `h` and returns the response.
+func adminRequest(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	r, err := http.NewRequest(method, path, strings.NewReader(body))
+	require.NoError(t, err)
+	r.RemoteAddr = "127.0.0.1:5000"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+// decodeJob decodes the job in the body of `w`.
+func decodeJob(t *testing.T, w *httptest.ResponseRecorder) reingestJob {
+	var job reingestJob
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+	return job
+}
+
+func TestAdminReingest(t *testing.T) {
+	sink := new(bytes.Buffer)
+	defer func(s log.AuditSink) { log.DefaultAuditor.Sink = s }(log.DefaultAuditor.Sink)
+	log.DefaultAuditor.Sink = &log.AuditWriter{W: sink}
+
+	sys := &fakeReingester{}
+	jobs, h := testReingestJobs(sys)
+
+	w := adminRequest(t, h, "POST", "/ingest/reingest", `{"start": 3, "end": 14}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	job := decodeJob(t, w)
+	assert.Equal(t, "1", job.ID)
+	assert.Equal(t, int32(3), job.Start)
+	assert.Equal(t, int32(14), job.End)
+	assert.Equal(t, 12, job.Total)
+	jobs.Wait()
+
+	w = adminRequest(t, h, "GET", "/ingest/jobs/1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	job = decodeJob(t, w)
+	assert.Equal(t, jobSucceeded, job.State)
+	assert.Equal(t, 12, job.Ingested)
+	assert.Empty(t, job.Error)
+	assert.NotNil(t, job.EndedAt)
+	assert.Equal(t, []string{"3-7", "8-12", "13-14"}, sys.Calls())
+
+	var event log.AuditEvent
+	require.NoError(t, json.Unmarshal(sink.Bytes(), &event))
+	assert.Equal(t, "admin:127.0.0.1:5000", event.Actor)
+	assert.Equal(t, "reingest", event.Action)
+	assert.Equal(t, "3-14", event.Parameters["ledgers"])
+	assert.Equal(t, log.AuditSuccess, event.Result)
+
+	// a single ledger
+	w = adminRequest(t, h, "POST", "/ingest/reingest", `{"ledger": 20}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	jobs.Wait()
+	assert.Equal(t, "20-20", sys.Calls()[3])
+
+	// all ledgers
+	sys.start, sys.end = 30, 31
+	w = adminRequest(t, h, "POST", "/ingest/reingest", `{"all": true}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	job = decodeJob(t, w)
+	assert.Equal(t, int32(30), job.Start)
+	assert.Equal(t, int32(31), job.End)
+	jobs.Wait()
+}
+
+func TestAdminReingest_Invalid(t *testing.T) {
+	_, h := testReingestJobs(&fakeReingester{})
+
+	for _, body := range []string{
+		`{}`,
+		`{"ledger": 5, "all": true}`,
+		`{"start": 5, "end": 10, "ledger": 5}`,
+		`{"start": 10, "end": 5}`,
+		`{"start": 0, "end": 5}`,
+		`not json`,
+	} {
+		w := adminRequest(t, h, "POST", "/ingest/reingest", body)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	w := adminRequest(t, h, "GET", "/ingest/reingest", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = adminRequest(t, h, "GET", "/ingest/jobs/12", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// without ingestion, there is nothing to reingest with
+	w = adminRequest(t, adminHandler(nil, nil), "POST", "/ingest/reingest", `{"ledger": 5}`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	w = adminRequest(t, adminHandler(nil, nil), "GET", "/ingest/jobs/1", "")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestAdminReingest_Overlap(t *testing.T) {
+	sys := &fakeReingester{gate: make(chan struct{})}
+	jobs, h := testReingestJobs(sys)
+
+	w := adminRequest(t, h, "POST", "/ingest/reingest", `{"start": 10, "end": 20}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	for _, body := range []string{
+		`{"ledger": 10}`,
+		`{"ledger": 20}`,
+		`{"start": 1, "end": 10}`,
+		`{"start": 12, "end": 14}`,
+		`{"start": 5, "end": 25}`,
+	} {
+		w = adminRequest(t, h, "POST", "/ingest/reingest", body)
+		assert.Equal(t, http.StatusConflict, w.Code, body)
+		assert.Contains(t, w.Body.String(), "overlaps running job 1")
+	}
+
+	w = adminRequest(t, h, "POST", "/ingest/reingest", `{"start": 21, "end": 22}`)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	close(sys.gate)
+	jobs.Wait()
+
+	// once the job is done, its ledgers may be reingested again
+	w = adminRequest(t, h, "POST", "/ingest/reingest", `{"ledger": 10}`)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	jobs.Wait()
+}
+
+func TestAdminReingest_OverlapSession(t *testing.T) {
+	sys := &fakeReingester{}
+	sys.SetSession(&ingest.SessionStatus{ID: "abc", FirstLedger: 10, LastLedger: 20})
+	jobs, h := testReingestJobs(sys)
+	jobs.pollInterval = time.Millisecond
+
+	for _, body := range []string{
+		`{"ledger": 10}`,
+		`{"ledger": 20}`,
+		`{"start": 1, "end": 10}`,
+		`{"start": 5, "end": 25}`,
+	} {
+		w := adminRequest(t, h, "POST", "/ingest/reingest", body)
+		assert.Equal(t, http.StatusConflict, w.Code, body)
+		assert.Contains(t, w.Body.String(), "overlaps ingestion session abc")
+	}
+	assert.Empty(t, sys.Calls())
+
+	// a session started while a job runs holds up the job's ledgers it
+	// includes, until it finishes
+	sys.entered = make(chan struct{}, 1)
+	sys.gate = make(chan struct{})
+	w := adminRequest(t, h, "POST", "/ingest/reingest", `{"start": 21, "end": 30}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	<-sys.entered
+
+	sys.SetSession(&ingest.SessionStatus{ID: "def", FirstLedger: 28, LastLedger: 28})
+	sys.gate <- struct{}{}
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []string{"21-25"}, sys.Calls())
+
+	sys.SetSession(nil)
+	<-sys.entered
+	sys.gate <- struct{}{}
+	jobs.Wait()
+	assert.Equal(t, []string{"21-25", "26-30"}, sys.Calls())
+}
+
+func TestAdminReingest_Cancel(t *testing.T) {
+	sys := &fakeReingester{
+		entered: make(chan struct{}, 1),
+		gate:    make(chan struct{}),
+	}
+	jobs, h := testReingestJobs(sys)
+
+	w := adminRequest(t, h, "POST", "/ingest/reingest", `{"start": 1, "end": 20}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	<-sys.entered
+
+	// the job stops once the ledgers it is reingesting are done
+	w = adminRequest(t, h, "DELETE", "/ingest/jobs/1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	sys.gate <- struct{}{}
+	jobs.Wait()
+
+	w = adminRequest(t, h, "GET", "/ingest/jobs/1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	job := decodeJob(t, w)
+	assert.Equal(t, jobCancelled, job.State)
+	assert.Equal(t, 5, job.Ingested)
+	assert.Equal(t, []string{"1-5"}, sys.Calls())
+
+	w = adminRequest(t, h, "DELETE", "/ingest/jobs/12", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdminReingest_Failure(t *testing.T) {
+	sys := &fakeReingester{failAt: 7}
+	jobs, h := testReingestJobs(sys)
+
+	w := adminRequest(t, h, "POST", "/ingest/reingest", `{"start": 1, "end": 20}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	jobs.Wait()
+
+	w = adminRequest(t, h, "GET", "/ingest/jobs/1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	job := decodeJob(t, w)
+	assert.Equal(t, jobFailed, job.State)
+	assert.Equal(t, "ledger 7: load failed", job.Error)
+	assert.Equal(t, 5, job.Ingested)
+	assert.Equal(t, []string{"1-5", "6-10"}, sys.Calls())
+}
+
+func TestReingestJobsRetention(t *testing.T) {
+	jobs := newReingestJobs(context.Background(), &fakeReingester{})
+
+	for i := 0; i < maxFinishedReingestJobs+2; i++ {
+		_, err := jobs.Start(1, 1, "test")
+		require.NoError(t, err)
+		jobs.Wait()
+	}
+
+	_, ok := jobs.Get("1")
+	assert.False(t, ok)
+	_, ok = jobs.Get("3")
+	assert.True(t, ok)
+	assert.Len(t, jobs.jobs, maxFinishedReingestJobs)
+}
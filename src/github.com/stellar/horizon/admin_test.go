@@ -0,0 +1,90 @@
+package horizon
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/horizon/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminLogLevels(t *testing.T) {
+	defer log.ResetLevelFor(log.IngestSubsystem)
+	h := adminHandler(nil, nil)
+
+	levels := func(w *httptest.ResponseRecorder) map[string]string {
+		var ret map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ret))
+		return ret
+	}
+
+	set := func(form url.Values) *httptest.ResponseRecorder {
+		r, err := http.NewRequest("PUT", "/log_levels", strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	r, err := http.NewRequest("GET", "/log_levels", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, levels(w)["default"], levels(w)["ingest"])
+
+	w = set(url.Values{"subsystem": {"ingest"}, "level": {"debug"}})
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "debug", levels(w)["ingest"])
+	level, explicit, err := log.LevelFor(log.IngestSubsystem)
+	require.NoError(t, err)
+	assert.True(t, explicit)
+	assert.Equal(t, logrus.DebugLevel, level)
+
+	w = set(url.Values{"subsystem": {"ingest"}, "level": {"default"}})
+	assert.Equal(t, 200, w.Code)
+	_, explicit, _ = log.LevelFor(log.IngestSubsystem)
+	assert.False(t, explicit)
+
+	w = set(url.Values{"subsystem": {"nope"}, "level": {"debug"}})
+	assert.Equal(t, 400, w.Code)
+	w = set(url.Values{"subsystem": {"ingest"}, "level": {"loud"}})
+	assert.Equal(t, 400, w.Code)
+}
+
+func TestAdminLogLevels_Audited(t *testing.T) {
+	defer log.ResetLevelFor(log.IngestSubsystem)
+	sink := new(bytes.Buffer)
+	defer func(s log.AuditSink) { log.DefaultAuditor.Sink = s }(log.DefaultAuditor.Sink)
+	log.DefaultAuditor.Sink = &log.AuditWriter{W: sink}
+
+	form := url.Values{"subsystem": {"ingest"}, "level": {"debug"}}
+	r, err := http.NewRequest("POST", "/log_levels", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.RemoteAddr = "127.0.0.1:5000"
+	adminHandler(nil, nil).ServeHTTP(httptest.NewRecorder(), r)
+
+	var event log.AuditEvent
+	require.NoError(t, json.Unmarshal(sink.Bytes(), &event))
+	assert.Equal(t, "admin:127.0.0.1:5000", event.Actor)
+	assert.Equal(t, "set_log_level", event.Action)
+	assert.Equal(t, "ingest", event.Parameters["subsystem"])
+	assert.Equal(t, "debug", event.Parameters["level"])
+	assert.Equal(t, log.AuditSuccess, event.Result)
+
+	// reading the levels is not audited
+	sink.Reset()
+	r, err = http.NewRequest("GET", "/log_levels", nil)
+	require.NoError(t, err)
+	adminHandler(nil, nil).ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, 0, sink.Len())
+}
@@ -0,0 +1,110 @@
+package horizon
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/stellar/horizon/log"
+)
+
+// APIKeyQuota is the quota granted to clients identifying themselves with an
+// API key:  the number of requests they may make per hour, and the number of
+// event streams they may hold open at once, zero meaning no streams limit.
+type APIKeyQuota struct {
+	PerHour    int  `json:"per_hour"`
+	MaxStreams uint `json:"max_streams"`
+}
+
+// APIKeys is the set of valid API keys, and their quotas, loaded from a JSON
+// file mapping each key to its quota, for example:
+//
+//	{"4d0f13e1": {"per_hour": 36000, "max_streams": 20}}
+//
+// The file is reloaded by Reload whenever it has been modified, so that keys
+// may be issued or revoked without restarting horizon.
+type APIKeys struct {
+	path string
+
+	lock    sync.RWMutex
+	modTime time.Time
+	quotas  map[string]APIKeyQuota
+}
+
+// LoadAPIKeys loads the API keys in the file at `path`.
+func LoadAPIKeys(path string) (*APIKeys, error) {
+	keys := &APIKeys{path: path}
+	_, err := keys.load()
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Quota returns the quota of `key`, and whether it is a valid key.
+func (keys *APIKeys) Quota(key string) (APIKeyQuota, bool) {
+	keys.lock.RLock()
+	defer keys.lock.RUnlock()
+	quota, ok := keys.quotas[key]
+	return quota, ok
+}
+
+// Reload loads the file of API keys again if it has been modified since it was
+// last loaded.  Should the file no longer be readable or valid, the keys
+// already loaded are kept and the error logged.
+func (keys *APIKeys) Reload() {
+	reloaded, err := keys.load()
+	if err != nil {
+		log.WithField("path", keys.path).WithError(err).Error("failed to reload api keys")
+		return
+	}
+
+	if reloaded {
+		keys.lock.RLock()
+		count := len(keys.quotas)
+		keys.lock.RUnlock()
+		log.WithField("path", keys.path).WithField("keys", count).Info("reloaded api keys")
+	}
+}
+
+// load reads the file of API keys unless it is unmodified since last read,
+// reporting whether it did.
+func (keys *APIKeys) load() (bool, error) {
+	info, err := os.Stat(keys.path)
+	if err != nil {
+		return false, errors.Wrap(err, 1)
+	}
+
+	keys.lock.RLock()
+	unmodified := info.ModTime().Equal(keys.modTime)
+	keys.lock.RUnlock()
+	if unmodified {
+		return false, nil
+	}
+
+	f, err := os.Open(keys.path)
+	if err != nil {
+		return false, errors.Wrap(err, 1)
+	}
+	defer f.Close()
+
+	var quotas map[string]APIKeyQuota
+	err = json.NewDecoder(f).Decode(&quotas)
+	if err != nil {
+		return false, errors.Wrap(err, 1)
+	}
+
+	for key, quota := range quotas {
+		if key == "" || quota.PerHour <= 0 {
+			return false, errors.Errorf("api key %q: per_hour must be positive", key)
+		}
+	}
+
+	keys.lock.Lock()
+	keys.quotas = quotas
+	keys.modTime = info.ModTime()
+	keys.lock.Unlock()
+	return true, nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/garyburd/redigo/redis"
@@ -15,6 +16,7 @@ import (
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/errors"
 	"github.com/stellar/horizon/friendbot"
 	"github.com/stellar/horizon/ingest"
 	"github.com/stellar/horizon/ledger"
@@ -33,22 +35,46 @@ var version = ""
 
 // App represents the root of the state of a horizon instance.
 type App struct {
-	config            Config
-	web               *Web
-	historyQ          *history.Q
-	coreQ             *core.Q
-	ctx               context.Context
-	cancel            func()
-	redis             *redis.Pool
-	coreVersion       string
-	horizonVersion    string
-	networkPassphrase string
-	submitter         *txsub.System
-	paths             paths.Finder
-	friendbot         *friendbot.Bot
-	ingester          *ingest.System
-	reaper            *reap.System
-	ticks             *time.Ticker
+	config         Config
+	web            *Web
+	historyQ       *history.Q
+	coreQ          *core.Q
+	coreFailover   *db2.Failover
+	coreHealth     *db2.Health
+	horizonHealth  *db2.Health
+	ingestStall    *ledger.StallDetector
+	ctx            context.Context
+	cancel         func()
+	redis          *redis.Pool
+	horizonVersion string
+	submitter      *txsub.System
+	paths          paths.Finder
+	friendbot      *friendbot.Bot
+	ingester       *ingest.System
+	reingestJobs   *reingestJobs
+	selfHealer     *selfHealer
+	reaper         *reap.System
+	ticks          *time.Ticker
+
+	// capabilities are the features advertised by the root resource,
+	// registered by the initializers that set them up.
+	capabilities capabilitySet
+
+	// coreInfo is what horizon last learned of stellar-core from its info
+	// endpoint.  It is updated in the background (see UpdateStellarCoreInfo)
+	// while requests read it, so is guarded by coreInfoLock; use
+	// currentCoreInfo to read it.
+	coreInfoLock sync.RWMutex
+	coreInfo     coreInfo
+
+	// coreInfoUpdating is non-zero while an update of coreInfo is in flight.
+	coreInfoUpdating int32
+
+	// streamPolls ticks every SSEPollInterval, when set, at which open event
+	// streams are pumped should streamsStale, set when the ledger state
+	// changes, be non-zero.
+	streamPolls  *time.Ticker
+	streamsStale int32
 
 	// metrics
 	metrics                  metrics.Registry
@@ -58,6 +84,9 @@ type App struct {
 	coreLatestLedgerGauge    metrics.Gauge
 	coreElderLedgerGauge     metrics.Gauge
 	coreConnGauge            metrics.Gauge
+	coreHealthyGauge         metrics.Gauge
+	coreSelectedDBGauge      metrics.Gauge
+	ingestStalledGauge       metrics.Gauge
 	goroutineGauge           metrics.Gauge
 }
 
@@ -72,8 +101,12 @@ func NewApp(config Config) (*App, error) {
 
 	result := &App{config: config}
 	result.horizonVersion = version
-	result.networkPassphrase = build.DefaultNetwork.Passphrase
+	result.coreInfo.NetworkPassphrase = build.DefaultNetwork.Passphrase
+	result.coreInfo.Stale = true
 	result.ticks = time.NewTicker(1 * time.Second)
+	if config.SSEPollInterval > 0 {
+		result.streamPolls = time.NewTicker(config.SSEPollInterval)
+	}
 	result.init()
 	return result, nil
 }
@@ -107,6 +140,10 @@ func (a *App) Serve() {
 
 	go a.run()
 
+	if a.config.AdminPort != 0 {
+		go a.serveAdmin()
+	}
+
 	var err error
 	if a.config.TLSCert != "" {
 		err = srv.ListenAndServeTLS(a.config.TLSCert, a.config.TLSKey)
@@ -125,9 +162,16 @@ func (a *App) Serve() {
 func (a *App) Close() {
 	a.cancel()
 	a.ticks.Stop()
+	if a.streamPolls != nil {
+		a.streamPolls.Stop()
+	}
 
 	a.historyQ.Repo.DB.Close()
-	a.coreQ.Repo.DB.Close()
+	if a.coreFailover != nil {
+		a.coreFailover.Close()
+	} else {
+		a.coreQ.Repo.DB.Close()
+	}
 }
 
 // HistoryQ returns a helper object for performing sql queries against the
@@ -145,7 +189,25 @@ func (a *App) HorizonRepo(ctx context.Context) *db2.Repo {
 // CoreRepo returns a new repo that loads data from the stellar core
 // database. The returned repo is bound to `ctx`.
 func (a *App) CoreRepo(ctx context.Context) *db2.Repo {
-	return &db2.Repo{DB: a.coreQ.Repo.DB, Ctx: ctx}
+	return &db2.Repo{
+		DB:       a.coreQ.Repo.DB,
+		Ctx:      ctx,
+		Retry:    a.coreQ.Repo.Retry,
+		ReadOnly: a.coreQ.Repo.ReadOnly,
+		Failover: a.coreQ.Repo.Failover,
+	}
+}
+
+// CoreHealth returns the result of the most recent health check of the stellar
+// core database.
+func (a *App) CoreHealth() *db2.Health {
+	return a.coreHealth
+}
+
+// HorizonHealth returns the result of the most recent health check of the
+// horizon database.
+func (a *App) HorizonHealth() *db2.Health {
+	return a.horizonHealth
 }
 
 // CoreQ returns a helper object for performing sql queries aginst the
@@ -165,84 +227,254 @@ func (a *App) IsHistoryStale() bool {
 	return (ls.CoreLatest - ls.HistoryLatest) > int32(a.config.StaleThreshold)
 }
 
-// UpdateLedgerState triggers a refresh of several metrics gauges, such as open
-// db connections and ledger state
-func (a *App) UpdateLedgerState() {
-	var err error
-	var next ledger.State
+// IngestionStall returns whether ingestion has stalled, as of the most recent
+// refresh of the ledger state (see ledger.StallDetector).
+func (a *App) IngestionStall() ledger.Stall {
+	return a.ingestStall.Current()
+}
 
-	err = a.CoreQ().LatestLedger(&next.CoreLatest)
+// UpdateLedgerState refreshes the cached ledger state (see ledger.Refresh),
+// logging any failure to do so, and judges from it whether ingestion has
+// stalled.
+func (a *App) UpdateLedgerState() {
+	ls, err := ledger.Refresh(a.ctx)
 	if err != nil {
-		goto Failed
+		log.WithStack(err).
+			WithField("err", err.Error()).
+			Error("failed to load ledger state")
+		return
 	}
 
-	err = a.CoreQ().ElderLedger(&next.CoreElder)
-	if err != nil {
-		goto Failed
+	was := a.ingestStall.Current()
+	stall := a.ingestStall.Observe(ls)
+	switch {
+	case stall.Stalled && !was.Stalled:
+		log.WithField("behind", stall.Behind).
+			WithField("since", stall.Since).
+			Error("ingestion stalled: history has not advanced while behind stellar-core")
+	case was.Stalled && !stall.Stalled:
+		log.WithField("behind", stall.Behind).Info("ingestion resumed")
 	}
+}
 
-	err = a.HistoryQ().LatestLedger(&next.HistoryLatest)
-	if err != nil {
-		goto Failed
-	}
+// LoadLedgerState loads a new snapshot of the ledger state from the horizon
+// and stellar-core databases, using a single query against each.  It is the
+// ledger.Loader used by the app.
+func (a *App) LoadLedgerState(ctx context.Context) (next ledger.State, err error) {
+	cq := &core.Q{a.CoreRepo(ctx)}
+	hq := &history.Q{a.HorizonRepo(ctx)}
 
-	err = a.HistoryQ().ElderLedger(&next.HistoryElder)
+	err = cq.LedgerState(&next)
 	if err != nil {
-		goto Failed
+		return
 	}
 
-	ledger.SetState(next)
+	err = hq.LedgerState(&next)
 	return
+}
+
+// SeedLedgerState seeds the cached ledger state (see ledger.Seed) with the
+// latest and elder ledgers of the history database, which persist across
+// restarts.  This lets callers consulting the ledger state before the first
+// refresh completes see where history stood, rather than an empty database.
+func (a *App) SeedLedgerState() error {
+	hq := &history.Q{a.HorizonRepo(a.ctx)}
 
-Failed:
-	log.WithStack(err).
-		WithField("err", err.Error()).
-		Error("failed to load ledger state")
+	var ls ledger.State
+	err := hq.LedgerState(&ls)
+	if err != nil {
+		return err
+	}
 
+	ledger.Seed(ledger.Reading{
+		Source:         ledger.HistoryPrimary,
+		Latest:         ls.HistoryLatest,
+		Elder:          ls.HistoryElder,
+		LatestHash:     ls.HistoryLatestHash,
+		LatestClosedAt: ls.HistoryLatestClosedAt,
+	})
+	return nil
 }
 
-// UpdateStellarCoreInfo updates the value of coreVersion and networkPassphrase
-// from the Stellar core API.
+// UpdateStellarCoreInfo updates the app's coreInfo from the Stellar core API.
+// Each request to stellar-core waits no longer than StellarCoreInfoTimeout,
+// and a failed one is retried up to StellarCoreInfoRetries times, backing off
+// between attempts.  Should every attempt fail, the last known info is kept
+// and marked stale.  Only one update runs at a time:  a call made while
+// another is in flight returns at once.
 func (a *App) UpdateStellarCoreInfo() {
 	if a.config.StellarCoreURL == "" {
 		return
 	}
 
-	fail := func(err error) {
-		log.Warnf("could not load stellar-core info: %s", err)
+	if !atomic.CompareAndSwapInt32(&a.coreInfoUpdating, 0, 1) {
+		return
 	}
+	defer atomic.StoreInt32(&a.coreInfoUpdating, 0)
 
-	resp, err := http.Get(fmt.Sprint(a.config.StellarCoreURL, "/info"))
-
+	info, err := a.loadStellarCoreInfo()
 	if err != nil {
-		fail(err)
+		a.coreInfoLock.Lock()
+		a.coreInfo.Stale = true
+		updatedAt := a.coreInfo.UpdatedAt
+		a.coreInfoLock.Unlock()
+
+		log.WithField("last_updated_at", updatedAt).
+			Warnf("could not load stellar-core info, keeping the last known: %s", err)
 		return
 	}
 
+	a.coreInfoLock.Lock()
+	a.coreInfo = coreInfo{
+		Version:           info.Build,
+		NetworkPassphrase: info.Network,
+		UpdatedAt:         time.Now(),
+	}
+	a.coreInfoLock.Unlock()
+
+	// now that the network is known, submissions can be checked against it
+	if a.submitter != nil {
+		a.submitter.SetNetwork(info.Network, !a.config.SkipTransactionNetworkCheck)
+	}
+}
+
+// coreInfo is horizon's view of stellar-core, as reported by its info
+// endpoint.
+type coreInfo struct {
+	Version           string
+	NetworkPassphrase string
+
+	// UpdatedAt is when the info was last loaded.  Stale is set until it is
+	// first loaded, and whenever the latest attempt to update it failed.
+	UpdatedAt time.Time
+	Stale     bool
+}
+
+// currentCoreInfo returns what horizon last learned of stellar-core.
+func (a *App) currentCoreInfo() coreInfo {
+	a.coreInfoLock.RLock()
+	defer a.coreInfoLock.RUnlock()
+	return a.coreInfo
+}
+
+// stellarCoreInfo is the portion of stellar-core's info response used by
+// horizon.
+type stellarCoreInfo struct {
+	Build   string `json:"build"`
+	Network string `json:"network"`
+}
+
+// loadStellarCoreInfo requests stellar-core's info, retrying a failed request
+// as configured.  The delay between attempts starts at
+// StellarCoreInfoRetryBackoff and doubles after each.
+func (a *App) loadStellarCoreInfo() (stellarCoreInfo, error) {
+	client := &http.Client{Timeout: a.config.StellarCoreInfoTimeout}
+	url := fmt.Sprint(a.config.StellarCoreURL, "/info")
+	backoff := a.config.StellarCoreInfoRetryBackoff
+
+	for attempt := uint(0); ; attempt++ {
+		info, err := fetchStellarCoreInfo(client, url)
+		if err == nil || attempt >= a.config.StellarCoreInfoRetries {
+			return info, err
+		}
+
+		log.WithField("attempt", attempt+1).
+			Debugf("retrying stellar-core info request: %s", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-a.ctx.Done():
+			return info, a.ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// fetchStellarCoreInfo makes a single request for stellar-core's info to
+// `url`.
+func fetchStellarCoreInfo(client *http.Client, url string) (stellarCoreInfo, error) {
+	var response struct {
+		Info *stellarCoreInfo `json:"info"`
+	}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return stellarCoreInfo{}, err
+	}
 	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return stellarCoreInfo{}, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
 	contents, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		fail(err)
-		return
+		return stellarCoreInfo{}, err
 	}
 
-	var responseJSON map[string]*json.RawMessage
-	err = json.Unmarshal(contents, &responseJSON)
+	err = json.Unmarshal(contents, &response)
 	if err != nil {
-		fail(err)
-		return
+		return stellarCoreInfo{}, err
+	}
+
+	if response.Info == nil {
+		return stellarCoreInfo{}, fmt.Errorf("response has no info")
 	}
 
-	var serverInfo map[string]interface{}
-	err = json.Unmarshal(*responseJSON["info"], &serverInfo)
+	return *response.Info, nil
+}
+
+// UpdateCoreHealth pings the stellar core database, recording the result in
+// the app's core health state.  When failover between stellar-core databases
+// is configured, each is checked first, so that the database pinged is the
+// most preferred of those available.
+func (a *App) UpdateCoreHealth() {
+	if a.coreFailover != nil {
+		err := a.coreFailover.Check()
+		if err != nil {
+			log.Warnf("stellar-core db failover check failed: %s", err)
+		}
+		a.updateCoreSecondaryReading()
+	}
+
+	err := a.coreHealth.Check(a.CoreRepo(nil))
 	if err != nil {
-		fail(err)
+		log.Warnf("stellar-core db health check failed: %s", err)
+	}
+}
+
+// updateCoreSecondaryReading records the ledgers of the most preferred of the
+// failover's stellar-core databases other than the primary that its latest
+// check found available, as the reading of ledger.CoreSecondary.
+func (a *App) updateCoreSecondaryReading() {
+	for _, status := range a.coreFailover.Statuses()[1:] {
+		if !status.Available {
+			continue
+		}
+
+		r := ledger.Reading{Source: ledger.CoreSecondary}
+		q := &core.Q{a.coreFailover.Repo(status.Index)}
+		err := q.LatestLedger(&r.Latest)
+		if err == nil {
+			err = q.ElderLedger(&r.Elder)
+		}
+		if err != nil {
+			log.Warnf("failed to load secondary stellar-core db ledgers: %s", err)
+			return
+		}
+
+		ledger.SetReading(r)
 		return
 	}
+}
 
-	// TODO: make resilient to changes in stellar-core's info output
-	a.coreVersion = serverInfo["build"].(string)
-	a.networkPassphrase = serverInfo["network"].(string)
+// UpdateHorizonHealth pings the horizon database, recording the result in the
+// app's horizon health state.
+func (a *App) UpdateHorizonHealth() {
+	err := a.horizonHealth.Check(a.HorizonRepo(nil))
+	if err != nil {
+		log.Warnf("horizon db health check failed: %s", err)
+	}
 }
 
 // UpdateMetrics triggers a refresh of several metrics gauges, such as open
@@ -256,7 +488,24 @@ func (a *App) UpdateMetrics() {
 	a.coreElderLedgerGauge.Update(int64(ls.CoreElder))
 
 	a.horizonConnGauge.Update(int64(a.historyQ.Repo.DB.Stats().OpenConnections))
-	a.coreConnGauge.Update(int64(a.coreQ.Repo.DB.Stats().OpenConnections))
+	if a.coreFailover != nil {
+		a.coreConnGauge.Update(int64(a.coreFailover.DB().Stats().OpenConnections))
+		a.coreSelectedDBGauge.Update(int64(a.coreFailover.Current()))
+	} else {
+		a.coreConnGauge.Update(int64(a.coreQ.Repo.DB.Stats().OpenConnections))
+	}
+
+	if a.coreHealth.Healthy() {
+		a.coreHealthyGauge.Update(1)
+	} else {
+		a.coreHealthyGauge.Update(0)
+	}
+
+	if a.IngestionStall().Stalled {
+		a.ingestStalledGauge.Update(1)
+	} else {
+		a.ingestStalledGauge.Update(0)
+	}
 }
 
 // DeleteUnretainedHistory forwards to the app's reaper.  See
@@ -266,30 +515,71 @@ func (a *App) DeleteUnretainedHistory() error {
 }
 
 // Tick triggers horizon to update all of it's background processes such as
-// transaction submission, metrics, ingestion and reaping.
+// transaction submission, metrics, reaping and the reloading of API keys.
+// Ingestion and SSE streams are instead triggered by changes to the ledger
+// state (see LedgerStateChanged).
 func (a *App) Tick() {
 	var wg sync.WaitGroup
 	log.Debug("ticking app")
-	// update ledger state and stellar-core info in parallel
-	wg.Add(2)
-	go func() { a.UpdateLedgerState(); wg.Done() }()
-	go func() { a.UpdateStellarCoreInfo(); wg.Done() }()
+	// update ledger state and db health in parallel.  The update of
+	// stellar-core info is not waited upon, so that a slow stellar-core cannot
+	// hold up the next tick's refresh of the ledger state.
+	go func() {
+		defer errors.Recover("stellar-core info", nil)
+		a.UpdateStellarCoreInfo()
+	}()
+	background(&wg, "ledger state", a.UpdateLedgerState)
+	background(&wg, "core health", a.UpdateCoreHealth)
+	background(&wg, "horizon health", a.UpdateHorizonHealth)
 	wg.Wait()
 
+	background(&wg, "reaper", a.reaper.Tick)
+	background(&wg, "txsub", func() { a.submitter.Tick(a.ctx) })
+	if a.web.apiKeys != nil {
+		background(&wg, "api keys", a.web.apiKeys.Reload)
+	}
+	wg.Wait()
+
+	// finally, update metrics
+	a.UpdateMetrics()
+	log.Debug("finished ticking app")
+}
+
+// background runs `fn` in a goroutine of its own, as part of `wg`.  A panic in
+// `fn` is recovered (see errors.Recover), rather than bringing down horizon.
+func background(wg *sync.WaitGroup, component string, fn func()) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer errors.Recover(component, nil)
+		fn()
+	}()
+}
+
+// LedgerStateChanged triggers the processes that depend upon new ledgers:  it
+// starts ingestion of any new ledgers, and causes open SSE streams to check
+// for new data, at once or, should SSEPollInterval be set, at the next poll
+// (see PollStreams).
+func (a *App) LedgerStateChanged() {
 	if a.ingester != nil {
 		go a.ingester.Tick()
 	}
 
-	wg.Add(2)
-	go func() { a.reaper.Tick(); wg.Done() }()
-	go func() { a.submitter.Tick(a.ctx); wg.Done() }()
-	wg.Wait()
+	if a.streamPolls != nil {
+		atomic.StoreInt32(&a.streamsStale, 1)
+		return
+	}
 
 	sse.Tick()
+}
 
-	// finally, update metrics
-	a.UpdateMetrics()
-	log.Debug("finished ticking app")
+// PollStreams causes open SSE streams to check for new data if the ledger
+// state has changed since they last did, so that streams query the database no
+// more than once per SSEPollInterval however often ledgers close.
+func (a *App) PollStreams() {
+	if atomic.CompareAndSwapInt32(&a.streamsStale, 1, 0) {
+		sse.Tick()
+	}
 }
 
 // Init initializes app, using the config to populate db connections and
@@ -299,12 +589,25 @@ func (a *App) init() {
 }
 
 // run is the function that runs in the background that triggers Tick each
-// second
+// second, LedgerStateChanged whenever the ledger state changes, and
+// PollStreams every SSEPollInterval.
 func (a *App) run() {
+	states := ledger.Subscribe()
+	defer ledger.Unsubscribe(states)
+
+	var polls <-chan time.Time
+	if a.streamPolls != nil {
+		polls = a.streamPolls.C
+	}
+
 	for {
 		select {
 		case <-a.ticks.C:
 			a.Tick()
+		case <-states:
+			a.LedgerStateChanged()
+		case <-polls:
+			a.PollStreams()
 		case <-a.ctx.Done():
 			log.Info("finished background ticker")
 			return
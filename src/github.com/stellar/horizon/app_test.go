@@ -1,9 +1,14 @@
 package horizon
 
 import (
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stellar/horizon/ledger"
 	"github.com/stellar/horizon/render/sse"
 	"github.com/stellar/horizon/test"
 )
@@ -104,3 +109,163 @@ func TestTick(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestLedgerStateChanged(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// SSE streams are pumped when the ledger state changes
+	ch := sse.Pumped()
+	ht.App.LedgerStateChanged()
+
+	select {
+	case <-ch:
+		// no-op.  Success!
+	default:
+		t.Error("sse.Pumped() did not trigger after ledger state changed")
+		t.FailNow()
+	}
+}
+
+func TestPollStreams(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	c := NewTestConfig()
+	c.SSEPollInterval = time.Hour
+	app, err := NewApp(c)
+	tt.Require.NoError(err)
+	defer app.Close()
+
+	pumped := func(ch <-chan struct{}) bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// with a poll interval, changes to the ledger state are left for the next
+	// poll to pump streams for
+	ch := sse.Pumped()
+	app.LedgerStateChanged()
+	app.LedgerStateChanged()
+	tt.Assert.False(pumped(ch))
+
+	app.PollStreams()
+	tt.Assert.True(pumped(ch))
+
+	// and streams are left alone when nothing has changed since
+	ch = sse.Pumped()
+	app.PollStreams()
+	tt.Assert.False(pumped(ch))
+}
+
+func TestSeedLedgerState(t *testing.T) {
+	// warm boot
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	app := NewTestApp()
+	defer app.Close()
+
+	ls := ledger.CurrentState()
+	tt.Assert.Equal(int32(3), ls.HistoryLatest)
+	tt.Assert.Equal(int32(1), ls.HistoryElder)
+	_, err := ledger.FreshState(time.Hour)
+	tt.Assert.IsType(&ledger.StaleStateError{}, err, "seeded state should be stale")
+
+	app.UpdateLedgerState()
+	ls, err = ledger.FreshState(time.Hour)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(3), ls.CoreLatest)
+	tt.Assert.Equal(int32(3), ls.HistoryLatest)
+}
+
+func TestSeedLedgerState_ColdBoot(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+
+	app := NewTestApp()
+	defer app.Close()
+
+	ls := ledger.CurrentState()
+	tt.Assert.Equal(int32(0), ls.HistoryLatest)
+	tt.Assert.Equal(int32(0), ls.HistoryElder)
+
+	r, ok := ledger.CurrentReading(ledger.HistoryPrimary)
+	tt.Assert.True(ok)
+	tt.Assert.True(r.UpdatedAt.IsZero())
+}
+
+func TestUpdateStellarCoreInfo(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	// the core server fails the requests numbered in `failures`, and hangs on
+	// those numbered in `hangs`, until released.
+	var (
+		requests int32
+		failures = map[int32]bool{1: true, 2: true}
+		hangs    = map[int32]bool{}
+		release  = make(chan struct{})
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		switch {
+		case failures[n]:
+			w.WriteHeader(http.StatusInternalServerError)
+		case hangs[n]:
+			<-release
+		default:
+			fmt.Fprintln(w, `{"info": {"network": "test", "build": "test-core"}}`)
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := NewTestConfig()
+	c.StellarCoreURL = server.URL
+	c.StellarCoreInfoTimeout = 100 * time.Millisecond
+	c.StellarCoreInfoRetries = 1
+	c.StellarCoreInfoRetryBackoff = 10 * time.Millisecond
+	app, err := NewApp(c)
+	tt.Require.NoError(err)
+	defer app.Close()
+
+	// the info is stale until first loaded, here failing as the app is
+	// initialized
+	tt.Assert.Equal(int32(2), atomic.LoadInt32(&requests))
+	tt.Assert.Equal("", app.currentCoreInfo().Version)
+	tt.Assert.True(app.currentCoreInfo().Stale)
+
+	app.UpdateStellarCoreInfo()
+	tt.Assert.Equal(int32(3), atomic.LoadInt32(&requests))
+	tt.Assert.Equal("test-core", app.currentCoreInfo().Version)
+	tt.Assert.Equal("test", app.currentCoreInfo().NetworkPassphrase)
+	tt.Assert.False(app.currentCoreInfo().Stale)
+
+	// a failed request is retried
+	failures[4] = true
+	app.coreInfo.Version = ""
+	app.UpdateStellarCoreInfo()
+	tt.Assert.Equal(int32(5), atomic.LoadInt32(&requests))
+	tt.Assert.Equal("test-core", app.currentCoreInfo().Version)
+	tt.Assert.False(app.currentCoreInfo().Stale)
+
+	// requests that hang time out, leaving the last known info, marked stale
+	hangs[6] = true
+	hangs[7] = true
+	start := time.Now()
+	app.UpdateStellarCoreInfo()
+	tt.Assert.True(time.Since(start) < time.Second)
+	tt.Assert.Equal(int32(7), atomic.LoadInt32(&requests))
+	tt.Assert.Equal("test-core", app.currentCoreInfo().Version)
+	tt.Assert.Equal("test", app.currentCoreInfo().NetworkPassphrase)
+	tt.Assert.True(app.currentCoreInfo().Stale)
+
+	// and recover once stellar-core answers
+	app.UpdateStellarCoreInfo()
+	tt.Assert.False(app.currentCoreInfo().Stale)
+}
@@ -2,7 +2,10 @@
 package assets
 
 import (
+	"strings"
+
 	"github.com/go-errors/errors"
+	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/xdr"
 )
 
@@ -12,6 +15,9 @@ var ErrInvalidString = errors.New("invalid asset type: was not one of 'native',
 //ErrInvalidValue gets returned when the xdr.AssetType int value is not one of the valid enum values
 var ErrInvalidValue = errors.New("unknown asset type, cannot convert to string")
 
+// ErrInvalidCanonical gets returned when a string is not a valid canonical asset
+var ErrInvalidCanonical = errors.New("invalid asset: was not 'native' or of the form 'CODE:ISSUER'")
+
 // AssetTypeMap is the read-only (i.e. don't modify it) map from string names to xdr.AssetType
 // values
 var AssetTypeMap = map[string]xdr.AssetType{
@@ -32,6 +38,44 @@ func Parse(aType string) (result xdr.AssetType, err error) {
 	return
 }
 
+// ParseCanonical decodes an asset from its canonical string form, which is
+// either "native" or "CODE:ISSUER".
+func ParseCanonical(s string) (result xdr.Asset, err error) {
+	if s == "native" {
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 || parts[0] == "" || len(parts[0]) > 12 {
+		err = errors.New(ErrInvalidCanonical)
+		return
+	}
+
+	code := parts[0]
+	raw, err := strkey.Decode(strkey.VersionByteAccountID, parts[1])
+	if err != nil {
+		err = errors.New(ErrInvalidCanonical)
+		return
+	}
+
+	var key xdr.Uint256
+	copy(key[:], raw)
+	issuer, err := xdr.NewAccountId(xdr.CryptoKeyTypeKeyTypeEd25519, key)
+	if err != nil {
+		return
+	}
+
+	if len(code) <= 4 {
+		a := xdr.AssetAlphaNum4{Issuer: issuer}
+		copy(a.AssetCode[:], []byte(code))
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum4, a)
+	}
+
+	a := xdr.AssetAlphaNum12{Issuer: issuer}
+	copy(a.AssetCode[:], []byte(code))
+	return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum12, a)
+}
+
 //String returns the appropriate string representation of the provided xdr.AssetType.
 func String(aType xdr.AssetType) (string, error) {
 	for s, v := range AssetTypeMap {
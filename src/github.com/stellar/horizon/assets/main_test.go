@@ -55,4 +55,37 @@ func TestAssets(t *testing.T) {
 		_, err = String(xdr.AssetType(15))
 		So(errors.Is(err, ErrInvalidValue), ShouldBeTrue)
 	})
+
+	Convey("ParseCanonical", t, func() {
+		issuer := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+		result, err := ParseCanonical("native")
+		So(err, ShouldBeNil)
+		So(result.Type, ShouldEqual, xdr.AssetTypeAssetTypeNative)
+
+		result, err = ParseCanonical("USD:" + issuer)
+		So(err, ShouldBeNil)
+		So(result.Type, ShouldEqual, xdr.AssetTypeAssetTypeCreditAlphanum4)
+		var typ, code, iss string
+		So(result.Extract(&typ, &code, &iss), ShouldBeNil)
+		So(code, ShouldEqual, "USD")
+		So(iss, ShouldEqual, issuer)
+
+		result, err = ParseCanonical("LONGERCODE:" + issuer)
+		So(err, ShouldBeNil)
+		So(result.Type, ShouldEqual, xdr.AssetTypeAssetTypeCreditAlphanum12)
+
+		for _, bad := range []string{
+			"",
+			"USD",
+			"USD:",
+			":" + issuer,
+			"USD:GBAD",
+			"THIRTEENCHARS:" + issuer,
+			"USD:" + issuer + ":extra",
+		} {
+			_, err = ParseCanonical(bad)
+			So(errors.Is(err, ErrInvalidCanonical), ShouldBeTrue)
+		}
+	})
 }
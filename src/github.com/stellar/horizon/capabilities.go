@@ -0,0 +1,48 @@
+package horizon
+
+import (
+	"sort"
+)
+
+// CapabilityFn reports whether a capability is enabled for an App.
+type CapabilityFn func(*App) bool
+
+type capability struct {
+	Name    string
+	Enabled CapabilityFn
+}
+
+// capabilitySet holds the features an app advertises to clients through the
+// root resource (see App.capabilities), each registered by the initializer
+// that registers its route or consumes its flag.
+type capabilitySet []capability
+
+// Add registers a new capability, advertised by the app while `enabled`
+// returns true.  A nil `enabled` advertises it for as long as the app runs,
+// as suits a feature whose route has been registered.
+func (cs *capabilitySet) Add(name string, enabled CapabilityFn) {
+	*cs = append(*cs, capability{
+		Name:    name,
+		Enabled: enabled,
+	})
+}
+
+// Enabled returns the sorted names of the capabilities enabled for the
+// provided app.
+func (cs capabilitySet) Enabled(app *App) []string {
+	names := []string{}
+	for _, c := range cs {
+		if c.Enabled == nil || c.Enabled(app) {
+			names = append(names, c.Name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// effectsEnabled is the CapabilityFn of features that rely upon ingested
+// effects, which are unavailable when effect ingestion is disabled.
+func effectsEnabled(app *App) bool {
+	return !app.config.DisableEffectIngestion
+}
@@ -2,14 +2,17 @@ package main
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/user"
 	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/stellar/horizon"
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/schema"
 	"github.com/stellar/horizon/ingest"
@@ -26,6 +29,8 @@ var dbInitCmd = &cobra.Command{
 	Short: "install schema",
 	Long:  "init initializes the postgres database used by horizon.",
 	Run: func(cmd *cobra.Command, args []string) {
+		horizon.ConfigureLog(logConfig())
+
 		db, err := db2.Open(viper.GetString("db-url"))
 		if err != nil {
 			hlog.Error(err)
@@ -45,6 +50,7 @@ var dbMigrateCmd = &cobra.Command{
 	Short: "migrate schema",
 	Long:  "performs a schema migration command",
 	Run: func(cmd *cobra.Command, args []string) {
+		horizon.ConfigureLog(logConfig())
 
 		// Allow invokations with 1 or 2 args.  All other args counts are erroneous.
 		if len(args) < 1 || len(args) > 2 {
@@ -69,12 +75,14 @@ var dbMigrateCmd = &cobra.Command{
 
 		db, err := sql.Open("postgres", viper.GetString("db-url"))
 		if err != nil {
-			log.Fatal(err)
+			hlog.Error(err)
+			os.Exit(1)
 		}
 
 		_, err = schema.Migrate(db, dir, count)
 		if err != nil {
-			log.Fatal(err)
+			hlog.Error(err)
+			os.Exit(1)
 		}
 	},
 }
@@ -88,7 +96,8 @@ var dbReapCmd = &cobra.Command{
 
 		err := app.DeleteUnretainedHistory()
 		if err != nil {
-			log.Fatal(err)
+			hlog.Error(err)
+			os.Exit(1)
 		}
 	},
 }
@@ -99,25 +108,38 @@ var dbReingestCmd = &cobra.Command{
 	Long:  "reingest runs the ingestion pipeline over every ledger",
 	Run: func(cmd *cobra.Command, args []string) {
 		initConfig()
-		hlog.DefaultLogger.Logger.Level = config.LogLevel
+		horizon.ConfigureLog(config)
+		horizon.ConfigureAuditLog(config)
+		db2.SlowQueryThreshold = config.SlowQueryThreshold
 
 		hdb, err := db2.Open(config.DatabaseURL)
 		if err != nil {
-			log.Fatal(err)
+			hlog.Error(err)
+			os.Exit(1)
 		}
 
-		cdb, err := db2.Open(config.StellarCoreDatabaseURL)
+		cdb, err := db2.OpenReadOnly(config.StellarCoreDatabaseURL)
 		if err != nil {
-			log.Fatal(err)
+			hlog.Error(err)
+			os.Exit(1)
 		}
 
 		passphrase := viper.GetString("network-passphrase")
 		if passphrase == "" {
-			log.Fatal("network-passphrase is blank: reingestion requires manually setting passphrase")
+			hlog.Error("network-passphrase is blank: reingestion requires manually setting passphrase")
+			os.Exit(1)
 		}
 
 		i := ingest.New(passphrase, config.StellarCoreURL, cdb, hdb)
 		i.SkipCursorUpdate = config.SkipCursorUpdate
+		i.SkipEffects = config.DisableEffectIngestion
+		i.IngestFloor = int32(config.IngestFloor)
+		i.VerifyIngestedCounts = config.IngestVerifyCounts
+		i.FailedTransactionFeeEffects = config.IngestFailedTransactionFees
+		i.FailedTransactions = config.IngestFailedTransactions
+		i.LogWrites = config.IngestVerbose
+		i.LogWriteData = config.IngestVerboseData
+		i.ReadAhead = int(config.IngestReadAhead)
 
 		logStatus := func(stage string) {
 			count := i.Metrics.IngestLedgerTimer.Count()
@@ -136,7 +158,9 @@ var dbReingestCmd = &cobra.Command{
 
 		// run ingestion in separate goroutine
 		go func() {
+			event := hlog.Audit(auditActor(), "reingest", reingestParams(args))
 			_, err := reingest(i, args)
+			event.End(err)
 			done <- err
 			logStatus("complete")
 		}()
@@ -150,7 +174,8 @@ var dbReingestCmd = &cobra.Command{
 
 			case err := <-done:
 				if err != nil {
-					log.Fatal(err)
+					hlog.Error(err)
+					os.Exit(1)
 				}
 				os.Exit(0)
 			}
@@ -176,6 +201,16 @@ func reingest(i *ingest.System, args []string) (int, error) {
 		return count, err
 	}
 
+	if len(args) > 0 && args[0] == "version" {
+		minVer, maxVer, err := reingestVersions(args)
+		if err != nil {
+			return 0, err
+		}
+
+		count, err := i.ReingestVersionRange(minVer, maxVer)
+		return count, err
+	}
+
 	for idx, arg := range args {
 		seq, err := strconv.Atoi(arg)
 		if err != nil {
@@ -189,3 +224,49 @@ func reingest(i *ingest.System, args []string) (int, error) {
 	}
 	return len(args), nil
 }
+
+// reingestVersions parses the arguments of `horizon db reingest version MIN
+// [MAX]`.  MAX defaults to MIN, reingesting the ledgers of a single version.
+func reingestVersions(args []string) (minVer, maxVer int, err error) {
+	if len(args) < 2 || len(args) > 3 {
+		err = errors.New("usage: horizon db reingest version MIN [MAX]")
+		return
+	}
+
+	minVer, err = strconv.Atoi(args[1])
+	if err != nil {
+		return
+	}
+
+	maxVer = minVer
+	if len(args) == 3 {
+		maxVer, err = strconv.Atoi(args[2])
+	}
+	return
+}
+
+// reingestParams describes the ledgers reingested for `args` in audit events.
+func reingestParams(args []string) map[string]interface{} {
+	switch {
+	case len(args) == 0:
+		return map[string]interface{}{"ledgers": "all"}
+	case len(args) == 1 && args[0] == "outdated":
+		return map[string]interface{}{"ledgers": "outdated"}
+	case len(args) > 0 && args[0] == "version":
+		return map[string]interface{}{"ledgers": "version", "versions": args[1:]}
+	default:
+		return map[string]interface{}{"ledgers": args}
+	}
+}
+
+// auditActor identifies the user running this command in audit events.
+func auditActor() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	} else if env := os.Getenv("USER"); env != "" {
+		name = env
+	}
+
+	return "cli:" + name
+}
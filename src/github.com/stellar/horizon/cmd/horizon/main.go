@@ -3,13 +3,17 @@ package main
 import (
 	"log"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/throttled"
 	"github.com/Sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stellar/horizon"
+	"github.com/stellar/horizon/ingest"
 	hlog "github.com/stellar/horizon/log"
+	"github.com/stellar/horizon/txsub"
 )
 
 var app *horizon.App
@@ -33,13 +37,28 @@ func init() {
 	viper.BindEnv("port", "PORT")
 	viper.BindEnv("db-url", "DATABASE_URL")
 	viper.BindEnv("stellar-core-db-url", "STELLAR_CORE_DATABASE_URL")
+	viper.BindEnv("stellar-core-failover-db-urls", "STELLAR_CORE_FAILOVER_DATABASE_URLS")
+	viper.BindEnv("stellar-core-failover-max-lag", "STELLAR_CORE_FAILOVER_MAX_LAG")
 	viper.BindEnv("stellar-core-url", "STELLAR_CORE_URL")
 	viper.BindEnv("friendbot-secret", "FRIENDBOT_SECRET")
 	viper.BindEnv("per-hour-rate-limit", "PER_HOUR_RATE_LIMIT")
+	viper.BindEnv("rate-limit-keys-file", "RATE_LIMIT_KEYS_FILE")
+	viper.BindEnv("rate-limit-key-header", "RATE_LIMIT_KEY_HEADER")
+	viper.BindEnv("max-streams-per-ip", "MAX_STREAMS_PER_IP")
 	viper.BindEnv("redis-url", "REDIS_URL")
 	viper.BindEnv("ruby-horizon-url", "RUBY_HORIZON_URL")
 	viper.BindEnv("log-level", "LOG_LEVEL")
+	viper.BindEnv("log-format", "LOG_FORMAT")
+	viper.BindEnv("log-stack-depth", "LOG_STACK_DEPTH")
+	viper.BindEnv("log-file", "LOG_FILE")
+	viper.BindEnv("log-file-format", "LOG_FILE_FORMAT")
+	viper.BindEnv("log-file-level", "LOG_FILE_LEVEL")
+	viper.BindEnv("log-file-max-size", "LOG_FILE_MAX_SIZE")
+	viper.BindEnv("log-file-max-backups", "LOG_FILE_MAX_BACKUPS")
+	viper.BindEnv("admin-port", "ADMIN_PORT")
+	viper.BindEnv("audit-log", "AUDIT_LOG")
 	viper.BindEnv("sentry-dsn", "SENTRY_DSN")
+	viper.BindEnv("sentry-dedup-window", "SENTRY_DEDUP_WINDOW")
 	viper.BindEnv("loggly-token", "LOGGLY_TOKEN")
 	viper.BindEnv("loggly-host", "LOGGLY_HOST")
 	viper.BindEnv("tls-cert", "TLS_CERT")
@@ -48,7 +67,48 @@ func init() {
 	viper.BindEnv("network-passphrase", "NETWORK_PASSPHRASE")
 	viper.BindEnv("history-retention-count", "HISTORY_RETENTION_COUNT")
 	viper.BindEnv("history-stale-threshold", "HISTORY_STALE_THRESHOLD")
+	viper.BindEnv("ingest-stall-grace", "INGEST_STALL_GRACE")
+	viper.BindEnv("health-degraded-lag", "HEALTH_DEGRADED_LAG")
+	viper.BindEnv("health-unhealthy-lag", "HEALTH_UNHEALTHY_LAG")
+	viper.BindEnv("health-check-timeout", "HEALTH_CHECK_TIMEOUT")
+	viper.BindEnv("health-max-state-age", "HEALTH_MAX_STATE_AGE")
+	viper.BindEnv("reap-vacuum-threshold", "REAP_VACUUM_THRESHOLD")
+	viper.BindEnv("reap-vacuum", "REAP_VACUUM")
 	viper.BindEnv("skip-cursor-update", "SKIP_CURSOR_UPDATE")
+	viper.BindEnv("skip-core-schema-check", "SKIP_CORE_SCHEMA_CHECK")
+	viper.BindEnv("ingest-fast-start-count", "INGEST_FAST_START_COUNT")
+	viper.BindEnv("ingest-floor", "INGEST_FLOOR")
+	viper.BindEnv("ingest-backfill", "INGEST_BACKFILL")
+	viper.BindEnv("ingest-verify-counts", "INGEST_VERIFY_COUNTS")
+	viper.BindEnv("ingest-failed-transaction-fees", "INGEST_FAILED_TRANSACTION_FEES")
+	viper.BindEnv("ingest-failed-transactions", "INGEST_FAILED_TRANSACTIONS")
+	viper.BindEnv("ingest-read-ahead", "INGEST_READ_AHEAD")
+	viper.BindEnv("stellar-core-info-timeout", "STELLAR_CORE_INFO_TIMEOUT")
+	viper.BindEnv("stellar-core-info-retries", "STELLAR_CORE_INFO_RETRIES")
+	viper.BindEnv("stellar-core-info-retry-backoff", "STELLAR_CORE_INFO_RETRY_BACKOFF")
+	viper.BindEnv("self-heal-reingest", "SELF_HEAL_REINGEST")
+	viper.BindEnv("cors-allowed-origins", "CORS_ALLOWED_ORIGINS")
+	viper.BindEnv("cors-allowed-headers", "CORS_ALLOWED_HEADERS")
+	viper.BindEnv("cors-exposed-headers", "CORS_EXPOSED_HEADERS")
+	viper.BindEnv("cors-max-age", "CORS_MAX_AGE")
+	viper.BindEnv("cors-allow-credentials", "CORS_ALLOW_CREDENTIALS")
+	viper.BindEnv("ingest-verbose", "INGEST_VERBOSE")
+	viper.BindEnv("ingest-verbose-data", "INGEST_VERBOSE_DATA")
+	viper.BindEnv("max-response-body-size", "MAX_RESPONSE_BODY_SIZE")
+	viper.BindEnv("max-order-book-depth", "MAX_ORDER_BOOK_DEPTH")
+	viper.BindEnv("request-timeout", "REQUEST_TIMEOUT")
+	viper.BindEnv("transaction-stream-timeout", "TRANSACTION_STREAM_TIMEOUT")
+	viper.BindEnv("slow-query-threshold", "SLOW_QUERY_THRESHOLD")
+	viper.BindEnv("max-concurrent-requests", "MAX_CONCURRENT_REQUESTS")
+	viper.BindEnv("max-concurrent-streams", "MAX_CONCURRENT_STREAMS")
+	viper.BindEnv("sse-poll-interval", "SSE_POLL_INTERVAL")
+	viper.BindEnv("disable-effect-ingestion", "DISABLE_EFFECT_INGESTION")
+	viper.BindEnv("skip-transaction-network-check", "SKIP_TRANSACTION_NETWORK_CHECK")
+	viper.BindEnv("max-tx-envelope-size", "MAX_TX_ENVELOPE_SIZE")
+	viper.BindEnv("max-tx-operations", "MAX_TX_OPERATIONS")
+	viper.BindEnv("max-tx-signatures", "MAX_TX_SIGNATURES")
+	viper.BindEnv("max-submission-body-size", "MAX_SUBMISSION_BODY_SIZE")
+	viper.BindEnv("submittable-operations", "SUBMITTABLE_OPERATIONS")
 
 	rootCmd = &cobra.Command{
 		Use:   "horizon",
@@ -72,6 +132,18 @@ func init() {
 		"stellar-core postgres database to connect with",
 	)
 
+	rootCmd.Flags().String(
+		"stellar-core-failover-db-urls",
+		"",
+		"comma separated stellar-core postgres databases, in order of preference, to fail over to when the stellar-core-db-url database is unreachable or falls behind",
+	)
+
+	rootCmd.Flags().Uint(
+		"stellar-core-failover-max-lag",
+		5,
+		"the number of ledgers a stellar-core database may trail the most advanced reachable one by and still be failed over to",
+	)
+
 	rootCmd.Flags().String(
 		"stellar-core-url",
 		"",
@@ -90,6 +162,24 @@ func init() {
 		"max count of requests allowed in a one hour period, by remote ip address",
 	)
 
+	rootCmd.Flags().String(
+		"rate-limit-keys-file",
+		"",
+		"a JSON file of api keys, each mapped to its quota, by which clients presenting a key are rate limited instead of by remote ip address",
+	)
+
+	rootCmd.Flags().String(
+		"rate-limit-key-header",
+		"X-API-Key",
+		"the request header in which clients present their api key",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-streams-per-ip",
+		0,
+		"the maximum number of event streams a client without an api key may have open at once.  0 signifies no limit",
+	)
+
 	rootCmd.Flags().String(
 		"redis-url",
 		"",
@@ -102,12 +192,72 @@ func init() {
 		"Minimum log severity (debug, info, warn, error) to log",
 	)
 
+	rootCmd.Flags().String(
+		"log-format",
+		"text",
+		"Format (text, json) in which to write log entries",
+	)
+
+	rootCmd.Flags().Int(
+		"log-stack-depth",
+		hlog.MaxStackDepth,
+		"number of stack frames to log with errors that carry a stack trace.  0 disables them",
+	)
+
+	rootCmd.Flags().String(
+		"log-file",
+		"",
+		"path of a file to which to log in addition to stderr",
+	)
+
+	rootCmd.Flags().String(
+		"log-file-format",
+		"json",
+		"Format (text, json) in which to write entries to the log file",
+	)
+
+	rootCmd.Flags().String(
+		"log-file-level",
+		"debug",
+		"Minimum log severity (debug, info, warn, error) to write to the log file",
+	)
+
+	rootCmd.Flags().Int(
+		"log-file-max-size",
+		100,
+		"size, in megabytes, at which the log file is rotated",
+	)
+
+	rootCmd.Flags().Int(
+		"log-file-max-backups",
+		5,
+		"number of rotated log files to keep",
+	)
+
+	rootCmd.Flags().Int(
+		"admin-port",
+		0,
+		"port on the loopback interface on which to serve administrative endpoints.  0 disables them",
+	)
+
+	rootCmd.Flags().String(
+		"audit-log",
+		"",
+		"path of the file to which administrative operations, such as reingestion, are recorded",
+	)
+
 	rootCmd.Flags().String(
 		"sentry-dsn",
 		"",
 		"Sentry URL to which panics and errors should be reported",
 	)
 
+	rootCmd.Flags().Duration(
+		"sentry-dedup-window",
+		10*time.Minute,
+		"period for which repeats of an error reported to sentry are suppressed.  0 disables deduplication",
+	)
+
 	rootCmd.Flags().String(
 		"loggly-token",
 		"",
@@ -156,12 +306,260 @@ func init() {
 		"the minimum number of ledgers to maintain within horizon's history tables.  0 signifies an unlimited number of ledgers will be retained",
 	)
 
+	rootCmd.Flags().Uint(
+		"reap-vacuum-threshold",
+		0,
+		"the number of rows which, when exceeded by a single reaping of history, causes horizon to log the estimated dead tuples in the history tables and recommend a VACUUM.  0 disables the check",
+	)
+
+	rootCmd.Flags().Bool(
+		"reap-vacuum",
+		false,
+		"causes horizon to VACUUM the history tables itself after a reaping exceeds the reap-vacuum-threshold",
+	)
+
 	rootCmd.Flags().Uint(
 		"history-stale-threshold",
 		0,
 		"the maximum number of ledgers the history db is allowed to be out of date from the connected stellar-core db before horizon considers history stale",
 	)
 
+	rootCmd.Flags().Duration(
+		"ingest-stall-grace",
+		time.Minute,
+		"how long the history db's latest ledger may go without advancing while behind stellar-core before ingestion is considered stalled",
+	)
+
+	rootCmd.Flags().Uint(
+		"health-degraded-lag",
+		10,
+		"the number of ledgers the history db may trail stellar-core by before GET /health reports horizon as degraded.  0 disables the check",
+	)
+
+	rootCmd.Flags().Uint(
+		"health-unhealthy-lag",
+		100,
+		"the number of ledgers the history db may trail stellar-core by before GET /health reports horizon as unhealthy.  0 disables the check",
+	)
+
+	rootCmd.Flags().Duration(
+		"health-check-timeout",
+		time.Second,
+		"how long GET /health waits for each database to answer a ping before reporting it unreachable",
+	)
+
+	rootCmd.Flags().Duration(
+		"health-max-state-age",
+		30*time.Second,
+		"how long ago the ledger state may have been refreshed before GET /health reports horizon unhealthy",
+	)
+
+	rootCmd.Flags().Bool(
+		"skip-core-schema-check",
+		false,
+		"causes the ingestor to run even if the stellar-core database schema version is not known to be compatible",
+	)
+
+	rootCmd.Flags().Uint(
+		"ingest-fast-start-count",
+		0,
+		"when the history db is empty, begin ingesting this many ledgers before the latest ledger rather than at the oldest ledger available.  0 disables fast start",
+	)
+
+	rootCmd.Flags().Uint(
+		"ingest-floor",
+		0,
+		"the oldest ledger to ingest, even if stellar-core holds older ledgers.  0 ingests from the oldest ledger available",
+	)
+
+	rootCmd.Flags().Bool(
+		"ingest-backfill",
+		false,
+		"causes the ingestor to gradually ingest older ledgers that precede the oldest ledger in the history db",
+	)
+
+	rootCmd.Flags().Bool(
+		"ingest-verify-counts",
+		false,
+		"causes the ingestor to verify that the transactions and operations stored for each ingested ledger match stellar-core's",
+	)
+
+	rootCmd.Flags().Bool(
+		"ingest-failed-transaction-fees",
+		false,
+		"causes the ingestor to record the fee charged for each failed transaction as an account_debited effect",
+	)
+
+	rootCmd.Flags().Bool(
+		"ingest-failed-transactions",
+		false,
+		"causes the ingestor to store failed transactions, which can then be listed using the include_failed parameter",
+	)
+
+	rootCmd.Flags().Uint(
+		"ingest-read-ahead",
+		ingest.DefaultReadAhead,
+		"the most ledgers to load from stellar-core ahead of the ledger being ingested.  0 disables reading ahead",
+	)
+
+	rootCmd.Flags().Duration(
+		"stellar-core-info-timeout",
+		5*time.Second,
+		"how long each request for stellar-core's info waits for an answer.  0 waits indefinitely",
+	)
+
+	rootCmd.Flags().Uint(
+		"stellar-core-info-retries",
+		2,
+		"how many times a failed request for stellar-core's info is retried before the last info loaded is kept",
+	)
+
+	rootCmd.Flags().Duration(
+		"stellar-core-info-retry-backoff",
+		500*time.Millisecond,
+		"the delay before the first retry of a request for stellar-core's info, doubled before each further retry",
+	)
+
+	rootCmd.Flags().Bool(
+		"self-heal-reingest",
+		false,
+		"causes a request that finds data missing from an ingested ledger to enqueue a reingestion of the ledger, asking the client to retry shortly.  requires --ingest",
+	)
+
+	rootCmd.Flags().String(
+		"cors-allowed-origins",
+		"*",
+		"comma separated origins from which browsers may make cross-origin requests.  * allows any origin, and an origin such as https://*.example.com any subdomain",
+	)
+
+	rootCmd.Flags().String(
+		"cors-allowed-headers",
+		"*",
+		"comma separated request headers that cross-origin requests may send.  * allows any header",
+	)
+
+	rootCmd.Flags().String(
+		"cors-exposed-headers",
+		"",
+		"comma separated response headers exposed to cross-origin requests, in addition to the latest ledger and rate limit headers",
+	)
+
+	rootCmd.Flags().Duration(
+		"cors-max-age",
+		10*time.Minute,
+		"how long browsers may cache the answer to a preflight request.  0 leaves it to the browser",
+	)
+
+	rootCmd.Flags().Bool(
+		"cors-allow-credentials",
+		false,
+		"allows cross-origin requests to carry credentials, such as cookies.  requires --cors-allowed-origins to list the origins allowed, rather than *",
+	)
+
+	rootCmd.Flags().Bool(
+		"ingest-verbose",
+		false,
+		"causes the ingestor to log a summary of the rows it writes to each table for every ledger",
+	)
+
+	rootCmd.Flags().Bool(
+		"ingest-verbose-data",
+		false,
+		"causes the ingestor to log every statement it writes, with its data.  Implies --ingest-verbose",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-response-body-size",
+		0,
+		"the maximum size, in bytes, of a single response body or streamed event.  0 signifies no limit",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-order-book-depth",
+		200,
+		"the maximum number of price levels per side that may be requested from the order book endpoint",
+	)
+
+	rootCmd.Flags().Duration(
+		"request-timeout",
+		0,
+		"the maximum duration of a single non-streaming request, after which its database queries are canceled and a timeout error returned.  0 signifies no timeout",
+	)
+
+	rootCmd.Flags().Duration(
+		"transaction-stream-timeout",
+		30*time.Second,
+		"how long an event stream of a transaction's operations waits for the transaction to be ingested.  0 signifies no wait",
+	)
+
+	rootCmd.Flags().Duration(
+		"slow-query-threshold",
+		0,
+		"the duration at or beyond which a single database statement is logged as slow.  0 disables the slow query log",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-concurrent-requests",
+		0,
+		"the maximum number of non-streaming requests handled at once, beyond which requests are rejected as over capacity.  0 signifies no limit",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-concurrent-streams",
+		0,
+		"the maximum number of event streams open at once, beyond which stream requests are rejected as over capacity.  0 signifies no limit",
+	)
+
+	rootCmd.Flags().Duration(
+		"sse-poll-interval",
+		time.Second,
+		"the least time between successive checks of open event streams for new data.  0 signifies a check upon every change to the ledger state",
+	)
+
+	rootCmd.Flags().Bool(
+		"disable-effect-ingestion",
+		false,
+		"causes the ingestor to skip generating effects and trades, and disables the endpoints that serve them",
+	)
+
+	rootCmd.Flags().Bool(
+		"skip-transaction-network-check",
+		false,
+		"causes transactions signed for a different network than stellar-core's to be submitted rather than rejected",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-tx-envelope-size",
+		uint(txsub.DefaultLimits.MaxEnvelopeSize),
+		"the maximum size, in bytes, of the XDR encoding of a submitted transaction envelope.  0 signifies no limit",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-tx-operations",
+		uint(txsub.DefaultLimits.MaxOperations),
+		"the maximum number of operations in a submitted transaction.  0 signifies no limit",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-tx-signatures",
+		uint(txsub.DefaultLimits.MaxSignatures),
+		"the maximum number of signatures on a submitted transaction envelope.  0 signifies no limit",
+	)
+
+	// the default leaves room for an envelope of the default maximum size
+	// once it is base64 and form encoded.
+	rootCmd.Flags().Uint(
+		"max-submission-body-size",
+		128*1024,
+		"the maximum size, in bytes, of the body of a transaction submission request.  Larger requests are rejected without being read.  0 signifies no limit",
+	)
+
+	rootCmd.Flags().String(
+		"submittable-operations",
+		"",
+		"comma separated operation types (such as payment,path_payment) that submitted transactions may contain.  Transactions containing other types are rejected.  Empty permits every type",
+	)
+
 	rootCmd.AddCommand(dbCmd)
 
 	viper.BindPFlags(rootCmd.Flags())
@@ -191,13 +589,9 @@ func initConfig() {
 		log.Fatal("Invalid config: stellar-core-url is blank.  Please specify --stellar-core-url on the command line or set the STELLAR_CORE_URL environment variable.")
 	}
 
-	ll, err := logrus.ParseLevel(viper.GetString("log-level"))
-
-	if err != nil {
-		log.Fatalf("Could not parse log-level: %v", viper.GetString("log-level"))
-	}
-
-	hlog.DefaultLogger.Level = ll
+	lc := logConfig()
+	hlog.DefaultLogger.Level = lc.LogLevel
+	hlog.DefaultLogger.SetFormat(lc.LogFormat)
 
 	cert, key := viper.GetString("tls-cert"), viper.GetString("tls-key")
 
@@ -209,22 +603,131 @@ func initConfig() {
 	}
 
 	config = horizon.Config{
-		DatabaseURL:            viper.GetString("db-url"),
-		StellarCoreDatabaseURL: viper.GetString("stellar-core-db-url"),
-		StellarCoreURL:         viper.GetString("stellar-core-url"),
-		Port:                   viper.GetInt("port"),
-		RateLimit:              throttled.PerHour(viper.GetInt("per-hour-rate-limit")),
-		RedisURL:               viper.GetString("redis-url"),
-		LogLevel:               ll,
-		SentryDSN:              viper.GetString("sentry-dsn"),
-		LogglyToken:            viper.GetString("loggly-token"),
-		LogglyHost:             viper.GetString("loggly-host"),
-		FriendbotSecret:        viper.GetString("friendbot-secret"),
-		TLSCert:                cert,
-		TLSKey:                 key,
-		Ingest:                 viper.GetBool("ingest"),
-		HistoryRetentionCount:  uint(viper.GetInt("history-retention-count")),
-		StaleThreshold:         uint(viper.GetInt("history-stale-threshold")),
-		SkipCursorUpdate:       viper.GetBool("skip-cursor-update"),
+		DatabaseURL:                     viper.GetString("db-url"),
+		StellarCoreDatabaseURL:          viper.GetString("stellar-core-db-url"),
+		StellarCoreFailoverDatabaseURLs: splitList(viper.GetString("stellar-core-failover-db-urls")),
+		StellarCoreFailoverMaxLag:       uint(viper.GetInt("stellar-core-failover-max-lag")),
+		StellarCoreURL:                  viper.GetString("stellar-core-url"),
+		Port:                            viper.GetInt("port"),
+		AdminPort:                       viper.GetInt("admin-port"),
+		AuditLog:                        viper.GetString("audit-log"),
+		RateLimit:                       throttled.PerHour(viper.GetInt("per-hour-rate-limit")),
+		RateLimitKeysFile:               viper.GetString("rate-limit-keys-file"),
+		RateLimitKeyHeader:              viper.GetString("rate-limit-key-header"),
+		MaxStreamsPerIP:                 uint(viper.GetInt("max-streams-per-ip")),
+		RedisURL:                        viper.GetString("redis-url"),
+		LogLevel:                        lc.LogLevel,
+		LogFormat:                       lc.LogFormat,
+		LogStackDepth:                   lc.LogStackDepth,
+		LogFile:                         lc.LogFile,
+		LogFileFormat:                   lc.LogFileFormat,
+		LogFileLevel:                    lc.LogFileLevel,
+		LogFileMaxSize:                  lc.LogFileMaxSize,
+		LogFileMaxBackups:               lc.LogFileMaxBackups,
+		SentryDSN:                       viper.GetString("sentry-dsn"),
+		SentryDedupWindow:               viper.GetDuration("sentry-dedup-window"),
+		LogglyToken:                     viper.GetString("loggly-token"),
+		LogglyHost:                      viper.GetString("loggly-host"),
+		FriendbotSecret:                 viper.GetString("friendbot-secret"),
+		TLSCert:                         cert,
+		TLSKey:                          key,
+		Ingest:                          viper.GetBool("ingest"),
+		HistoryRetentionCount:           uint(viper.GetInt("history-retention-count")),
+		ReapVacuumThreshold:             uint(viper.GetInt("reap-vacuum-threshold")),
+		ReapVacuum:                      viper.GetBool("reap-vacuum"),
+		StaleThreshold:                  uint(viper.GetInt("history-stale-threshold")),
+		IngestStallGrace:                viper.GetDuration("ingest-stall-grace"),
+		HealthDegradedLag:               uint(viper.GetInt("health-degraded-lag")),
+		HealthUnhealthyLag:              uint(viper.GetInt("health-unhealthy-lag")),
+		HealthCheckTimeout:              viper.GetDuration("health-check-timeout"),
+		HealthMaxStateAge:               viper.GetDuration("health-max-state-age"),
+		SkipCursorUpdate:                viper.GetBool("skip-cursor-update"),
+		SkipCoreSchemaCheck:             viper.GetBool("skip-core-schema-check"),
+		IngestFastStartCount:            uint(viper.GetInt("ingest-fast-start-count")),
+		IngestFloor:                     uint(viper.GetInt("ingest-floor")),
+		IngestBackfill:                  viper.GetBool("ingest-backfill"),
+		IngestVerifyCounts:              viper.GetBool("ingest-verify-counts"),
+		IngestVerbose:                   viper.GetBool("ingest-verbose"),
+		IngestVerboseData:               viper.GetBool("ingest-verbose-data"),
+		IngestFailedTransactionFees:     viper.GetBool("ingest-failed-transaction-fees"),
+		IngestFailedTransactions:        viper.GetBool("ingest-failed-transactions"),
+		SelfHealReingest:                viper.GetBool("self-heal-reingest"),
+		CORSAllowedOrigins:              splitList(viper.GetString("cors-allowed-origins")),
+		CORSAllowedHeaders:              splitList(viper.GetString("cors-allowed-headers")),
+		CORSExposedHeaders:              splitList(viper.GetString("cors-exposed-headers")),
+		CORSMaxAge:                      viper.GetDuration("cors-max-age"),
+		CORSAllowCredentials:            viper.GetBool("cors-allow-credentials"),
+		MaxResponseBodySize:             uint(viper.GetInt("max-response-body-size")),
+		MaxOrderBookDepth:               uint(viper.GetInt("max-order-book-depth")),
+		RequestTimeout:                  viper.GetDuration("request-timeout"),
+		SlowQueryThreshold:              viper.GetDuration("slow-query-threshold"),
+		MaxConcurrentRequests:           uint(viper.GetInt("max-concurrent-requests")),
+		MaxConcurrentStreams:            uint(viper.GetInt("max-concurrent-streams")),
+		SSEPollInterval:                 viper.GetDuration("sse-poll-interval"),
+		DisableEffectIngestion:          viper.GetBool("disable-effect-ingestion"),
+		SkipTransactionNetworkCheck:     viper.GetBool("skip-transaction-network-check"),
+		MaxTxEnvelopeSize:               uint(viper.GetInt("max-tx-envelope-size")),
+		MaxTxOperations:                 uint(viper.GetInt("max-tx-operations")),
+		MaxTxSignatures:                 uint(viper.GetInt("max-tx-signatures")),
+		MaxSubmissionBodySize:           uint(viper.GetInt("max-submission-body-size")),
+		SubmittableOperations:           splitList(viper.GetString("submittable-operations")),
+		TransactionStreamTimeout:        viper.GetDuration("transaction-stream-timeout"),
+		IngestReadAhead:                 uint(viper.GetInt("ingest-read-ahead")),
+		StellarCoreInfoTimeout:          viper.GetDuration("stellar-core-info-timeout"),
+		StellarCoreInfoRetries:          uint(viper.GetInt("stellar-core-info-retries")),
+		StellarCoreInfoRetryBackoff:     viper.GetDuration("stellar-core-info-retry-backoff"),
+	}
+}
+
+// logConfig returns a config holding only the logging settings, which the db
+// commands that need no other settings, such as `horizon db migrate`, log
+// with.
+func logConfig() horizon.Config {
+	ll, err := logrus.ParseLevel(viper.GetString("log-level"))
+
+	if err != nil {
+		log.Fatalf("Could not parse log-level: %v", viper.GetString("log-level"))
+	}
+
+	lf, err := hlog.ParseFormat(viper.GetString("log-format"))
+
+	if err != nil {
+		log.Fatalf("Could not parse log-format: %v", viper.GetString("log-format"))
+	}
+
+	lfl, err := logrus.ParseLevel(viper.GetString("log-file-level"))
+
+	if err != nil {
+		log.Fatalf("Could not parse log-file-level: %v", viper.GetString("log-file-level"))
+	}
+
+	lff, err := hlog.ParseFormat(viper.GetString("log-file-format"))
+
+	if err != nil {
+		log.Fatalf("Could not parse log-file-format: %v", viper.GetString("log-file-format"))
+	}
+
+	return horizon.Config{
+		LogLevel:          ll,
+		LogFormat:         lf,
+		LogStackDepth:     viper.GetInt("log-stack-depth"),
+		LogFile:           viper.GetString("log-file"),
+		LogFileFormat:     lff,
+		LogFileLevel:      lfl,
+		LogFileMaxSize:    int64(viper.GetInt("log-file-max-size")) * 1024 * 1024,
+		LogFileMaxBackups: viper.GetInt("log-file-max-backups"),
+	}
+}
+
+// splitList splits the comma separated list `s`, ignoring surrounding
+// whitespace and empty elements.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
 	}
+	return list
 }
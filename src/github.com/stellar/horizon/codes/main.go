@@ -236,6 +236,19 @@ func String(code interface{}) (string, error) {
 		case xdr.InflationResultCodeInflationNotTime:
 			return "op_not_time", nil
 		}
+	case xdr.ManageDataResultCode:
+		switch code {
+		case xdr.ManageDataResultCodeManageDataSuccess:
+			return OpSuccess, nil
+		case xdr.ManageDataResultCodeManageDataNotSupportedYet:
+			return "op_not_supported_yet", nil
+		case xdr.ManageDataResultCodeManageDataNameNotFound:
+			return "op_data_name_not_found", nil
+		case xdr.ManageDataResultCodeManageDataLowReserve:
+			return OpLowReserve, nil
+		case xdr.ManageDataResultCodeManageDataInvalidName:
+			return "op_data_invalid_name", nil
+		}
 	}
 
 	return "", errors.New(ErrUnknownCode)
@@ -272,6 +285,8 @@ func ForOperationResult(opr xdr.OperationResult) (string, error) {
 		ic = ir.MustAccountMergeResult().Code
 	case xdr.OperationTypeInflation:
 		ic = ir.MustInflationResult().Code
+	case xdr.OperationTypeManageData:
+		ic = ir.MustManageDataResult().Code
 	}
 
 	return String(ic)
@@ -17,6 +17,7 @@ func TestCodes(t *testing.T) {
 			{xdr.OperationResultCodeOpBadAuth, "op_bad_auth", nil},
 			{xdr.CreateAccountResultCodeCreateAccountLowReserve, "op_low_reserve", nil},
 			{xdr.PaymentResultCodePaymentSrcNoTrust, "op_src_no_trust", nil},
+			{xdr.ManageDataResultCodeManageDataNameNotFound, "op_data_name_not_found", nil},
 			{0, "", ErrUnknownCode},
 		}
 
@@ -37,5 +38,21 @@ func TestCodes(t *testing.T) {
 		//TODO: op_inner refers to inner result code
 		//TODO: non op_inner uses the outer result code
 		//TODO: one test for each operation type
+
+		Convey("decodes manage data results", func() {
+			opr := xdr.OperationResult{
+				Code: xdr.OperationResultCodeOpInner,
+				Tr: &xdr.OperationResultTr{
+					Type: xdr.OperationTypeManageData,
+					ManageDataResult: &xdr.ManageDataResult{
+						Code: xdr.ManageDataResultCodeManageDataSuccess,
+					},
+				},
+			}
+
+			actual, err := ForOperationResult(opr)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, OpSuccess)
+		})
 	})
 }
@@ -1,8 +1,11 @@
 package horizon
 
 import (
+	"time"
+
 	"github.com/PuerkitoBio/throttled"
 	"github.com/Sirupsen/logrus"
+	"github.com/stellar/horizon/log"
 )
 
 // Config is the configuration for horizon.  It get's populated by the
@@ -15,14 +18,34 @@ type Config struct {
 	RateLimit              throttled.Quota
 	RedisURL               string
 	LogLevel               logrus.Level
+	LogFormat              log.Format
 	SentryDSN              string
 	LogglyHost             string
 	LogglyToken            string
 	FriendbotSecret        string
+	// LogStackDepth is the number of stack frames logged with an error that
+	// carries a stack trace.  Zero disables them.
+	LogStackDepth int
+	// LogFile, when set, is the path of a file to which horizon logs in
+	// addition to stderr.  Entries are written to it in LogFileFormat, omitting
+	// those less severe than LogFileLevel.  The file is rotated once it would
+	// exceed LogFileMaxSize bytes, keeping LogFileMaxBackups rotated files
+	// (see log.RotatingFile).
+	LogFile           string
+	LogFileFormat     log.Format
+	LogFileLevel      logrus.Level
+	LogFileMaxSize    int64
+	LogFileMaxBackups int
 	// TLSCert is a path to a certificate file to use for horizon's TLS config
 	TLSCert string
 	// TLSKey is the path to a private key file to use for horizon's TLS config
 	TLSKey string
+	// AdminPort, when non-zero, is the port of the loopback interface on which
+	// horizon serves administrative endpoints, such as /log_levels.
+	AdminPort int
+	// AuditLog, when set, is the path of the file to which administrative
+	// operations, such as reingestion, are recorded (see log.Auditor).
+	AuditLog string
 	// Ingest is a boolean that indicates whether or not this horizon instance
 	// should run the data ingestion subsystem.
 	Ingest bool
@@ -32,12 +55,228 @@ type Config struct {
 	// seconds of real time.
 	HistoryRetentionCount uint
 
+	// ReapVacuumThreshold is the number of rows that, when exceeded by a single
+	// reaping of history, causes horizon to log the estimated dead tuples in the
+	// history tables and recommend a VACUUM.  0 disables the check.
+	ReapVacuumThreshold uint
+
+	// ReapVacuum causes horizon to VACUUM the history tables itself after a
+	// reaping that exceeds ReapVacuumThreshold.
+	ReapVacuum bool
+
 	// StaleThreshold represents the number of ledgers a history database may be
 	// out-of-date by before horizon begins to respond with an error to history
 	// requests.
 	StaleThreshold uint
 
+	// IngestStallGrace is how long the history database's latest ledger may go
+	// without advancing while stellar-core's is ahead of it before ingestion is
+	// considered stalled (see ledger.StallDetector).  Periods in which
+	// stellar-core closes no ledgers never count as stalls.
+	IngestStallGrace time.Duration
+
+	// HealthDegradedLag is the number of ledgers the history database may
+	// trail stellar-core by before the health endpoint reports horizon as
+	// degraded.  0 disables the check.
+	HealthDegradedLag uint
+
+	// HealthUnhealthyLag is the number of ledgers the history database may
+	// trail stellar-core by before the health endpoint reports horizon as
+	// unhealthy.  0 disables the check.
+	HealthUnhealthyLag uint
+
+	// HealthCheckTimeout is how long the health endpoint waits for each
+	// database to answer a ping before reporting it unreachable.
+	HealthCheckTimeout time.Duration
+
+	// HealthMaxStateAge is how long ago the ledger state may have been
+	// refreshed before the health endpoint reports horizon as unhealthy.
+	HealthMaxStateAge time.Duration
+
+	// StellarCoreFailoverDatabaseURLs are the stellar-core databases, in order
+	// of preference, that horizon fails over to when the database at
+	// StellarCoreDatabaseURL is unreachable or falls behind.  Horizon returns
+	// to the most preferred database as soon as it is available again.
+	StellarCoreFailoverDatabaseURLs []string
+
+	// StellarCoreFailoverMaxLag is the number of ledgers a stellar-core
+	// database may trail the most advanced reachable one by and still be
+	// selected.
+	StellarCoreFailoverMaxLag uint
+
 	// SkipCursorUpdate causes the ingestor to skip reporting the "last imported
 	// ledger" state to stellar-core.
 	SkipCursorUpdate bool
+
+	// SkipCoreSchemaCheck causes the ingestor to run even when the connected
+	// stellar-core database's schema version is not one horizon is known to be
+	// compatible with.
+	SkipCoreSchemaCheck bool
+
+	// IngestFastStartCount, when non-zero, causes ingestion into an empty
+	// history database to begin this many ledgers before the latest ledger
+	// known to stellar-core, rather than at the oldest.
+	IngestFastStartCount uint
+
+	// IngestFloor, when non-zero, is the oldest ledger the ingestor will
+	// ingest, even if stellar-core holds older ledgers.
+	IngestFloor uint
+
+	// IngestBackfill causes the ingestor to gradually ingest any ledgers known
+	// to stellar-core that precede the oldest ledger in the history database.
+	IngestBackfill bool
+
+	// IngestVerifyCounts causes the ingestor to verify that the transactions
+	// and operations stored for each ingested ledger match stellar-core's
+	// record of the ledger.
+	IngestVerifyCounts bool
+
+	// IngestFailedTransactionFees causes the ingestor to record the fee charged
+	// for each failed transaction as an account_debited effect.
+	IngestFailedTransactionFees bool
+
+	// IngestFailedTransactions causes the ingestor to store failed
+	// transactions, which are otherwise omitted from the history database.
+	IngestFailedTransactions bool
+
+	// IngestVerbose causes the ingestor to log a summary of the rows it writes
+	// to each table for every ingested ledger.  IngestVerboseData additionally
+	// logs each statement written, with its data.
+	IngestVerbose     bool
+	IngestVerboseData bool
+
+	// MaxResponseBodySize is the maximum size, in bytes, of a single rendered
+	// response body or streamed event.  Larger responses are replaced with a
+	// response_too_large problem.  Zero means there is no limit.
+	MaxResponseBodySize uint
+
+	// MaxOrderBookDepth is the largest number of price levels per side that a
+	// client may request from the order book endpoint.  Zero means the default
+	// of 200 is used.
+	MaxOrderBookDepth uint
+
+	// RequestTimeout is the longest a single non-streaming request may run
+	// before its database queries are canceled and a timeout problem is
+	// returned.  Zero means requests never time out.
+	RequestTimeout time.Duration
+
+	// SlowQueryThreshold is the duration at or beyond which a single database
+	// statement is logged as slow (see db2.SlowQueryThreshold).  Zero disables
+	// the slow query log.
+	SlowQueryThreshold time.Duration
+
+	// SentryDedupWindow is the period for which repeats of an error reported to
+	// sentry are suppressed (see errors.ReportToSentry).  Zero disables
+	// deduplication.
+	SentryDedupWindow time.Duration
+
+	// MaxConcurrentRequests is the largest number of non-streaming requests
+	// that will be handled at once.  Requests beyond this limit are rejected
+	// with a server_over_capacity problem.  Zero means there is no limit.
+	MaxConcurrentRequests uint
+
+	// MaxConcurrentStreams is the largest number of event streams that will be
+	// open at once.  Stream requests beyond this limit are rejected with a
+	// server_over_capacity problem.  Zero means there is no limit.
+	MaxConcurrentStreams uint
+
+	// SSEPollInterval is the least time between successive checks of the open
+	// event streams for new data, changes to the ledger state within it being
+	// coalesced into a single check at its end.  Zero means streams check upon
+	// every change.
+	SSEPollInterval time.Duration
+
+	// DisableEffectIngestion causes the ingestor to skip generating effects
+	// (including trades), and the effect and trade endpoints to respond with a
+	// feature_disabled problem.
+	DisableEffectIngestion bool
+
+	// SkipTransactionNetworkCheck causes transactions to be submitted even
+	// when their signatures show they were made for a different network than
+	// the one reported by stellar-core.
+	SkipTransactionNetworkCheck bool
+
+	// MaxTxEnvelopeSize, MaxTxOperations and MaxTxSignatures bound the
+	// transaction envelopes accepted for submission (see txsub.Limits):  the
+	// size, in bytes, of an envelope's XDR encoding, and the number of
+	// operations and signatures it contains.  Submissions exceeding them are
+	// rejected with a transaction_too_large problem.  Zero means there is no
+	// limit.
+	MaxTxEnvelopeSize uint
+	MaxTxOperations   uint
+	MaxTxSignatures   uint
+
+	// MaxSubmissionBodySize is the largest request body, in bytes, that the
+	// transaction submission endpoint reads.  Larger bodies are rejected with a
+	// request_too_large problem without being read.  Zero means there is no
+	// limit.
+	MaxSubmissionBodySize uint
+
+	// SubmittableOperations names the operation types a submitted transaction
+	// may contain, using the names of horizon's operation resources (such as
+	// "payment").  Transactions containing any other type are rejected with an
+	// operation_not_permitted problem.  Empty permits every type.
+	SubmittableOperations []string
+
+	// TransactionStreamTimeout is how long an event stream of a transaction's
+	// operations waits for the transaction to be ingested before responding
+	// with a not_found problem.  Zero means the stream does not wait.
+	TransactionStreamTimeout time.Duration
+
+	// RateLimitKeysFile, when set, is the path of a JSON file of API keys and
+	// their quotas (see APIKeys).  Clients presenting a key in the
+	// RateLimitKeyHeader header are rate limited by the quota of their key
+	// rather than by IP address.  The file is reloaded whenever it is
+	// modified.
+	RateLimitKeysFile  string
+	RateLimitKeyHeader string
+
+	// MaxStreamsPerIP is the largest number of event streams a client without
+	// an API key may have open at once.  Stream requests beyond this limit are
+	// rejected with a rate_limit_exceeded problem.  Zero means there is no
+	// limit.
+	MaxStreamsPerIP uint
+
+	// SelfHealReingest causes a request that finds data missing from a ledger
+	// within the history database's range to enqueue a reingestion of the
+	// ledger, and respond with a ledger_repairing problem asking the client to
+	// retry shortly, rather than failing until an operator intervenes.  It
+	// requires Ingest.
+	SelfHealReingest bool
+
+	// CORSAllowedOrigins are the origins from which browsers may make
+	// cross-origin requests:  "*" allows any origin, and an origin such as
+	// "https://*.example.com" any subdomain of example.com.  CORSAllowedHeaders
+	// are the request headers they may send, "*" allowing any.
+	CORSAllowedOrigins []string
+	CORSAllowedHeaders []string
+
+	// CORSExposedHeaders are the response headers exposed to cross-origin
+	// requests, in addition to the latest ledger and rate limit headers, which
+	// are always exposed.
+	CORSExposedHeaders []string
+
+	// CORSMaxAge is how long browsers may cache the answer to a preflight
+	// request.  Zero leaves it to the browser.
+	CORSMaxAge time.Duration
+
+	// CORSAllowCredentials allows cross-origin requests to carry credentials,
+	// such as cookies.  It cannot be combined with the "*" origin.
+	CORSAllowCredentials bool
+
+	// IngestReadAhead is the most ledgers the ingestor loads from stellar-core
+	// ahead of the ledger it is ingesting.  Zero disables reading ahead.
+	IngestReadAhead uint
+
+	// StellarCoreInfoTimeout is how long each request for stellar-core's info
+	// waits for an answer.  Zero waits indefinitely.
+	StellarCoreInfoTimeout time.Duration
+
+	// StellarCoreInfoRetries is how many times a failed request for
+	// stellar-core's info is retried before horizon keeps the last info loaded.
+	StellarCoreInfoRetries uint
+
+	// StellarCoreInfoRetryBackoff is the delay before the first retry of a
+	// request for stellar-core's info, doubled before each further retry.
+	StellarCoreInfoRetryBackoff time.Duration
 }
@@ -17,13 +17,53 @@ func (ac Account) IsAuthRevocable() bool {
 	return (ac.Flags & xdr.AccountFlagsAuthRevocableFlag) != 0
 }
 
-// AccountByAddress loads a row from `accounts`, by address
+// IsAuthImmutable returns true if the account has the "AUTH_IMMUTABLE" option
+// turned on.
+func (ac Account) IsAuthImmutable() bool {
+	return (ac.Flags & xdr.AccountFlagsAuthImmutableFlag) != 0
+}
+
+// AccountByAddress loads a row from `accounts`, by address, including the
+// account's flags, thresholds, home domain and inflation destination.
 func (q *Q) AccountByAddress(dest interface{}, addy string) error {
 	sql := selectAccount.Limit(1).Where("accountid = ?", addy)
 
 	return q.Get(dest, sql)
 }
 
+// AccountsByAddresses loads the rows from `accounts` for every address in
+// `addys` into `dest`, keyed by address, using a single query.  Addresses for
+// which no account exists are absent from `dest`, allowing callers to tell
+// them apart from those that were found.  Duplicate addresses are loaded once.
+func (q *Q) AccountsByAddresses(dest map[string]Account, addys []string) error {
+	unique := make([]string, 0, len(addys))
+	seen := make(map[string]bool, len(addys))
+	for _, addy := range addys {
+		if seen[addy] {
+			continue
+		}
+		seen[addy] = true
+		unique = append(unique, addy)
+	}
+
+	if len(unique) == 0 {
+		return nil
+	}
+
+	var accounts []Account
+	sql := selectAccount.Where(sq.Eq{"a.accountid": unique})
+	err := q.Select(&accounts, sql)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		dest[account.Accountid] = account
+	}
+
+	return nil
+}
+
 // SequencesForAddresses loads the current sequence number for every accountid
 // specified in `addys`
 func (q *Q) SequencesForAddresses(dest interface{}, addys []string) error {
@@ -35,30 +75,6 @@ func (q *Q) SequencesForAddresses(dest interface{}, addys []string) error {
 	return q.Select(dest, sql)
 }
 
-// SequenceProvider returns a new sequence provider.
-func (q *Q) SequenceProvider() *SequenceProvider {
-	return &SequenceProvider{Q: q}
-}
-
-// Get implements `txsub.SequenceProvider`
-func (sp *SequenceProvider) Get(addys []string) (map[string]uint64, error) {
-	rows := []struct {
-		Address  string
-		Sequence uint64
-	}{}
-
-	err := sp.Q.SequencesForAddresses(&rows, addys)
-	if err != nil {
-		return nil, err
-	}
-
-	results := make(map[string]uint64)
-	for _, r := range rows {
-		results[r.Address] = r.Sequence
-	}
-	return results, nil
-}
-
 var selectAccount = sq.Select(
 	"a.accountid",
 	"a.balance",
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"github.com/golang/groupcache/lru"
+	"github.com/stellar/horizon/ledger"
+)
+
+// DefaultAccountCacheSize is the default number of results an account cache
+// holds.
+const DefaultAccountCacheSize = 1000
+
+// accountCacheKey identifies a cached account.  Including the ledger in the
+// key means that results loaded before a ledger closed are never returned
+// after it, and simply age out of the cache.
+type accountCacheKey struct {
+	Address string
+	Ledger  int32
+}
+
+// accountCacheEntry is a cached result.  Addresses for which no account exists
+// are cached too, so that repeated lookups of them do not reach the database.
+type accountCacheEntry struct {
+	Account Account
+	Found   bool
+}
+
+// AccountCache returns a new account cache holding up to `size` results.
+func (q *Q) AccountCache(size int) *AccountCache {
+	return &AccountCache{Q: q, lru: lru.New(size)}
+}
+
+// AccountsByAddresses behaves as Q.AccountsByAddresses, but only queries the
+// addresses whose accounts are not already cached for the current ledger.
+func (c *AccountCache) AccountsByAddresses(dest map[string]Account, addys []string) error {
+	latest := ledger.CurrentState().CoreLatest
+	var missing []string
+
+	c.lock.Lock()
+	for _, addy := range addys {
+		cached, ok := c.lru.Get(accountCacheKey{addy, latest})
+		if !ok {
+			missing = append(missing, addy)
+			continue
+		}
+
+		entry := cached.(accountCacheEntry)
+		if entry.Found {
+			dest[addy] = entry.Account
+		}
+	}
+	c.lock.Unlock()
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	loaded := map[string]Account{}
+	err := c.Q.AccountsByAddresses(loaded, missing)
+	if err != nil {
+		return err
+	}
+
+	// only cache results that are known to reflect the ledger they are keyed by
+	cacheable := ledger.CurrentState().CoreLatest == latest
+
+	c.lock.Lock()
+	for _, addy := range missing {
+		account, found := loaded[addy]
+		if found {
+			dest[addy] = account
+		}
+
+		if cacheable {
+			c.lru.Add(accountCacheKey{addy, latest}, accountCacheEntry{account, found})
+		}
+	}
+	c.lock.Unlock()
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/test"
+)
+
+func TestAccountCache(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	defer ledger.SetState(ledger.State{})
+	q := &Q{tt.CoreRepo()}
+
+	const (
+		master  = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+		missing = "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"
+	)
+
+	credit := func() {
+		_, err := tt.CoreDB.Exec(
+			`UPDATE accounts SET balance = balance + 1 WHERE accountid = $1`,
+			master,
+		)
+		tt.Require.NoError(err)
+	}
+
+	ledger.SetState(ledger.State{CoreLatest: 3})
+	cache := q.AccountCache(DefaultAccountCacheSize)
+
+	accounts := map[string]Account{}
+	err := cache.AccountsByAddresses(accounts, []string{master, missing, master})
+	tt.Require.NoError(err)
+	tt.Require.Contains(accounts, master)
+	tt.Assert.NotContains(accounts, missing)
+	initial := accounts[master].Balance
+
+	// cached within the same ledger
+	credit()
+	accounts = map[string]Account{}
+	err = cache.AccountsByAddresses(accounts, []string{master, missing})
+	tt.Require.NoError(err)
+	tt.Assert.Equal(initial, accounts[master].Balance)
+	tt.Assert.NotContains(accounts, missing)
+
+	// a ledger close invalidates the cache
+	ledger.SetState(ledger.State{CoreLatest: 4})
+	accounts = map[string]Account{}
+	err = cache.AccountsByAddresses(accounts, []string{master})
+	tt.Require.NoError(err)
+	tt.Assert.Equal(initial+xdr.Int64(1), accounts[master].Balance)
+
+	// the least recently used results are evicted
+	small := q.AccountCache(1)
+	err = small.AccountsByAddresses(map[string]Account{}, []string{master, missing})
+	tt.Require.NoError(err)
+	tt.Assert.Equal(1, small.lru.Len())
+}
@@ -10,7 +10,8 @@ func (ad AccountData) Raw() ([]byte, error) {
 	return base64.StdEncoding.DecodeString(ad.Value)
 }
 
-// AccountDataByKey loads a row from `accountdata`, by key
+// AccountDataByKey loads a row from `accountdata`, by key.  Keys are
+// case-sensitive and are compared byte-for-byte.
 func (q *Q) AccountDataByKey(dest interface{}, addy string, key string) error {
 	sql := selectAccountData.Limit(1).
 		Where("accountid = ?", addy).
@@ -19,9 +20,11 @@ func (q *Q) AccountDataByKey(dest interface{}, addy string, key string) error {
 	return q.Get(dest, sql)
 }
 
-// AllDataByAddress loads all data for `addy`
-func (q *Q) AllDataByAddress(dest interface{}, addy string) error {
-	sql := selectAccountData.Where("accountid = ?", addy)
+// AccountDataByAddress loads all data entries for `addy`, ordered by key.
+func (q *Q) AccountDataByAddress(dest *[]AccountData, addy string) error {
+	sql := selectAccountData.
+		Where("accountid = ?", addy).
+		OrderBy("ad.dataname ASC")
 	return q.Select(dest, sql)
 }
 
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stellar/horizon/test"
+)
+
+func TestAccountData(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	const addy = "GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD"
+
+	raw := []byte{0x00, 0xff, '\'', '"', '\n'}
+	keys := []string{"Name1", `it's "quoted"`, "ünïcødé", "100%_like"}
+	for _, key := range keys {
+		_, err := tt.CoreDB.Exec(
+			`INSERT INTO accountdata VALUES ($1, $2, $3)`,
+			addy, key, base64.StdEncoding.EncodeToString(raw),
+		)
+		tt.Require.NoError(err, "failed to insert accountdata")
+	}
+
+	var all []AccountData
+	err := q.AccountDataByAddress(&all, addy)
+	if tt.Assert.NoError(err) && tt.Assert.Len(all, 5) {
+		found := map[string]bool{}
+		for _, d := range all {
+			found[d.Key] = true
+		}
+		tt.Assert.True(found["name1"])
+		for _, key := range keys {
+			tt.Assert.True(found[key], "missing key: %s", key)
+		}
+	}
+
+	// keys are case-sensitive
+	var d AccountData
+	err = q.AccountDataByKey(&d, addy, "name1")
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal("MDAwMA==", d.Value)
+		value, err := d.Raw()
+		tt.Assert.NoError(err)
+		tt.Assert.Equal([]byte("0000"), value)
+	}
+
+	for _, key := range keys {
+		err = q.AccountDataByKey(&d, addy, key)
+		if tt.Assert.NoError(err, "failed to load key: %s", key) {
+			tt.Assert.Equal(key, d.Key)
+			value, err := d.Raw()
+			tt.Assert.NoError(err)
+			tt.Assert.Equal(raw, value)
+		}
+	}
+
+	// wildcards and differently-cased keys do not match
+	err = q.AccountDataByKey(&d, addy, "NAME1")
+	tt.Assert.True(q.NoRows(err))
+	err = q.AccountDataByKey(&d, addy, "100%")
+	tt.Assert.True(q.NoRows(err))
+
+	// unknown accounts have no data
+	err = q.AccountDataByAddress(&all, "GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON")
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(all, 0)
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/test"
+)
+
+func TestAccountByAddress(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("set_options")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	const addy = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+
+	var account Account
+	err := q.AccountByAddress(&account, addy)
+	tt.Require.NoError(err)
+
+	tt.Assert.Equal(addy, account.Accountid)
+	tt.Assert.Equal(xdr.Int64(9999999100), account.Balance)
+	tt.Assert.Equal("8589934601", account.Seqnum)
+	tt.Assert.Equal("nullstyle.com", account.HomeDomain.String)
+	tt.Assert.Equal(
+		"GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2",
+		account.Inflationdest.String,
+	)
+	tt.Assert.Equal(xdr.Thresholds{2, 0, 2, 2}, account.Thresholds)
+
+	// unset inflation destinations load as null
+	err = q.AccountByAddress(&account, "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2")
+	tt.Require.NoError(err)
+	tt.Assert.False(account.Inflationdest.Valid)
+	tt.Assert.Equal("", account.HomeDomain.String)
+	tt.Assert.Equal(xdr.Thresholds{1, 0, 0, 0}, account.Thresholds)
+}
+
+func TestAccountsByAddresses(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("set_options")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	const (
+		addy    = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+		other   = "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+		missing = "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"
+	)
+
+	accounts := map[string]Account{}
+	err := q.AccountsByAddresses(accounts, []string{addy, missing, other, addy})
+	tt.Require.NoError(err)
+
+	tt.Assert.Len(accounts, 2)
+	tt.Assert.NotContains(accounts, missing)
+	if tt.Assert.Contains(accounts, addy) {
+		tt.Assert.Equal("nullstyle.com", accounts[addy].HomeDomain.String)
+	}
+	if tt.Assert.Contains(accounts, other) {
+		tt.Assert.Equal(other, accounts[other].Accountid)
+	}
+
+	// no addresses, no query
+	accounts = map[string]Account{}
+	err = q.AccountsByAddresses(accounts, nil)
+	tt.Require.NoError(err)
+	tt.Assert.Len(accounts, 0)
+}
+
+func TestAccountFlags(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("set_options")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	const addy = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+
+	for flags := int32(0); flags < 8; flags++ {
+		_, err := tt.CoreDB.Exec(
+			`UPDATE accounts SET flags = $1 WHERE accountid = $2`, flags, addy,
+		)
+		tt.Require.NoError(err)
+
+		var account Account
+		err = q.AccountByAddress(&account, addy)
+		tt.Require.NoError(err)
+
+		tt.Assert.Equal(xdr.AccountFlags(flags), account.Flags)
+		tt.Assert.Equal(flags&1 != 0, account.IsAuthRequired(), "flags: %d", flags)
+		tt.Assert.Equal(flags&2 != 0, account.IsAuthRevocable(), "flags: %d", flags)
+		tt.Assert.Equal(flags&4 != 0, account.IsAuthImmutable(), "flags: %d", flags)
+	}
+}
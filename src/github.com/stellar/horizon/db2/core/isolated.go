@@ -0,0 +1,35 @@
+package core
+
+// Isolated runs `fn` with a copy of `q` that is bound to a read-only,
+// REPEATABLE READ transaction.  Every query made through the copy observes the
+// same snapshot of the stellar-core database, regardless of whether
+// stellar-core closes a ledger in the meantime.  Use it when a response is
+// assembled from several queries whose results must agree with each other,
+// such as an account and its trustlines.
+//
+// Isolation is not free: the transaction holds a single connection from the
+// pool for the duration of `fn`, and adds three round trips to the database
+// (BEGIN, SET TRANSACTION and COMMIT).  Reads made within the transaction are
+// also not retried after a lost connection.  As such, only use it where
+// consistency across queries matters; see BenchmarkIsolated for a comparison.
+func (q *Q) Isolated(fn func(q *Q) error) error {
+	repo := q.Clone()
+
+	err := repo.Begin()
+	if err != nil {
+		return err
+	}
+	defer repo.Rollback()
+
+	_, err = repo.ExecRaw("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY")
+	if err != nil {
+		return err
+	}
+
+	err = fn(&Q{repo})
+	if err != nil {
+		return err
+	}
+
+	return repo.Commit()
+}
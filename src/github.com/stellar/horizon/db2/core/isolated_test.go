@@ -0,0 +1,96 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/test"
+	tdb "github.com/stellar/horizon/test/db"
+	"github.com/stellar/horizon/test/scenarios"
+)
+
+func TestIsolated(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	const master = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+	var before, during, after Account
+	tt.Require.NoError(q.AccountByAddress(&before, master))
+
+	err := q.Isolated(func(iq *Q) error {
+		var first Account
+		err := iq.AccountByAddress(&first, master)
+		if err != nil {
+			return err
+		}
+
+		// simulate stellar-core closing a ledger mid-request
+		_, err = tt.CoreDB.Exec(
+			`UPDATE accounts SET seqnum = seqnum + 1 WHERE accountid = $1`,
+			master,
+		)
+		tt.Require.NoError(err)
+
+		return iq.AccountByAddress(&during, master)
+	})
+	tt.Require.NoError(err)
+
+	// the isolated reads did not observe the concurrent change...
+	tt.Assert.Equal(before.Seqnum, during.Seqnum)
+
+	// ...but subsequent reads do
+	tt.Require.NoError(q.AccountByAddress(&after, master))
+	tt.Assert.NotEqual(before.Seqnum, after.Seqnum)
+
+	// writes are rejected
+	err = q.Isolated(func(iq *Q) error {
+		_, err := iq.ExecRaw(`DELETE FROM accounts`)
+		return err
+	})
+	tt.Assert.Error(err)
+}
+
+func BenchmarkIsolated(b *testing.B) {
+	scenarios.Load(tdb.StellarCoreURL(), "base-core.sql")
+	q := &Q{&db2.Repo{DB: tdb.StellarCore()}}
+
+	const master = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+	load := func(q *Q) error {
+		var (
+			account    Account
+			signers    []Signer
+			trustlines []Trustline
+			data       []AccountData
+		)
+
+		if err := q.AccountByAddress(&account, master); err != nil {
+			return err
+		}
+		if err := q.SignersByAddress(&signers, master); err != nil {
+			return err
+		}
+		if err := q.TrustlinesByAddress(&trustlines, master); err != nil {
+			return err
+		}
+		return q.AccountDataByAddress(&data, master)
+	}
+
+	b.Run("plain", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := load(q); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("isolated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := q.Isolated(load); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -1,7 +1,12 @@
 package core
 
 import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-errors/errors"
 	sq "github.com/lann/squirrel"
+	"github.com/stellar/go/xdr"
 )
 
 // LedgerHeaderBySequence is a query that loads a single row from the
@@ -14,3 +19,59 @@ func (q *Q) LedgerHeaderBySequence(dest interface{}, seq int32) error {
 
 	return q.Get(dest, sql)
 }
+
+// Error implements the error interface.
+func (e *LedgerNotFoundError) Error() string {
+	return fmt.Sprintf("core: ledger %d not found", e.Sequence)
+}
+
+// IsLedgerNotFound returns true if `err` reports a missing ledger.
+func IsLedgerNotFound(err error) bool {
+	_, ok := err.(*LedgerNotFoundError)
+	return ok
+}
+
+// ensureLedger returns a *LedgerNotFoundError if there is no ledger header
+// for `seq`.
+func (q *Q) ensureLedger(seq int32) error {
+	var found int
+	sql := sq.Select("1").
+		From("ledgerheaders clh").
+		Limit(1).
+		Where("clh.ledgerseq = ?", seq)
+
+	err := q.Get(&found, sql)
+	if q.NoRows(err) {
+		return &LedgerNotFoundError{Sequence: seq}
+	}
+
+	return err
+}
+
+// ensureLedgerIfEmpty calls ensureLedger when `dest`, the destination slice of
+// a query for the contents of ledger `seq`, is empty.  This keeps the extra
+// query off the common path of loading a ledger that has transactions.
+func (q *Q) ensureLedgerIfEmpty(dest interface{}, seq int32) error {
+	v := reflect.Indirect(reflect.ValueOf(dest))
+	if v.Kind() == reflect.Slice && v.Len() > 0 {
+		return nil
+	}
+
+	return q.ensureLedger(seq)
+}
+
+// Upgrades decodes the network upgrades (protocol version, base fee, etc.)
+// that were applied when this ledger closed, in the order they were applied.
+func (lh *LedgerHeader) Upgrades() ([]xdr.LedgerUpgrade, error) {
+	raw := lh.Data.ScpValue.Upgrades
+	result := make([]xdr.LedgerUpgrade, len(raw))
+
+	for i, u := range raw {
+		err := xdr.SafeUnmarshal([]byte(u), &result[i])
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/test"
+)
+
+func TestLedgerHeaderUpgrades(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	// ledger 2 of every scenario bumps the protocol version and max tx set size
+	var header LedgerHeader
+	err := q.LedgerHeaderBySequence(&header, 2)
+	tt.Require.NoError(err)
+
+	upgrades, err := header.Upgrades()
+	tt.Require.NoError(err)
+	if tt.Assert.Len(upgrades, 2) {
+		tt.Assert.Equal(xdr.LedgerUpgradeTypeLedgerUpgradeVersion, upgrades[0].Type)
+		tt.Assert.Equal(xdr.Uint32(2), *upgrades[0].NewLedgerVersion)
+		tt.Assert.Equal(xdr.LedgerUpgradeTypeLedgerUpgradeMaxTxSetSize, upgrades[1].Type)
+		tt.Assert.Equal(xdr.Uint32(10000), *upgrades[1].NewMaxTxSetSize)
+	}
+
+	err = q.LedgerHeaderBySequence(&header, 3)
+	tt.Require.NoError(err)
+
+	upgrades, err = header.Upgrades()
+	tt.Require.NoError(err)
+	tt.Assert.Len(upgrades, 0)
+}
+
+func TestLedgerParams(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	var state ledger.State
+	err := q.LedgerParams(&state)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(2), state.ProtocolVersion)
+		tt.Assert.Equal(int32(100), state.BaseFee)
+		tt.Assert.Equal(int32(100000000), state.BaseReserve)
+		tt.Assert.Equal(int32(10000), state.MaxTxSetSize)
+	}
+
+	// falls back to the latest header when storestate doesn't record the last
+	// closed ledger
+	_, err = tt.CoreDB.Exec(`DELETE FROM storestate WHERE statename = 'lastclosedledger'`)
+	tt.Require.NoError(err)
+
+	state = ledger.State{}
+	err = q.LedgerParams(&state)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(2), state.ProtocolVersion)
+		tt.Assert.Equal(int32(10000), state.MaxTxSetSize)
+	}
+
+	// zeroes the params when the header cannot be decoded
+	_, err = tt.CoreDB.Exec(`UPDATE ledgerheaders SET data = 'garbage'`)
+	tt.Require.NoError(err)
+
+	err = q.LedgerParams(&state)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(ledger.State{}, state)
+	}
+}
+
+func TestLedgerState(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	var latest LedgerHeader
+	err := q.LedgerHeaderBySequence(&latest, 3)
+	tt.Require.NoError(err)
+
+	// other fields are untouched
+	state := ledger.State{HistoryLatest: 7}
+	err = q.LedgerState(&state)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(1), state.CoreElder)
+		tt.Assert.Equal(int32(3), state.CoreLatest)
+		tt.Assert.Equal(latest.LedgerHash, state.CoreLatestHash)
+		tt.Assert.Equal(time.Unix(latest.CloseTime, 0).UTC(), state.CoreLatestClosedAt)
+		tt.Assert.Equal(int32(2), state.ProtocolVersion)
+		tt.Assert.Equal(int32(100), state.BaseFee)
+		tt.Assert.Equal(int32(100000000), state.BaseReserve)
+		tt.Assert.Equal(int32(10000), state.MaxTxSetSize)
+		tt.Assert.Equal(int32(7), state.HistoryLatest)
+	}
+
+	// agrees with the individual queries
+	var elder, latestSeq int32
+	tt.Require.NoError(q.ElderLedger(&elder))
+	tt.Require.NoError(q.LatestLedger(&latestSeq))
+	tt.Assert.Equal(elder, state.CoreElder)
+	tt.Assert.Equal(latestSeq, state.CoreLatest)
+
+	// falls back to the latest header for the params
+	_, err = tt.CoreDB.Exec(`DELETE FROM storestate WHERE statename = 'lastclosedledger'`)
+	tt.Require.NoError(err)
+
+	state = ledger.State{}
+	err = q.LedgerState(&state)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(3), state.CoreLatest)
+		tt.Assert.Equal(int32(2), state.ProtocolVersion)
+	}
+
+	// an empty database
+	_, err = tt.CoreDB.Exec(`DELETE FROM ledgerheaders`)
+	tt.Require.NoError(err)
+
+	err = q.LedgerState(&state)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(ledger.State{}, state)
+	}
+}
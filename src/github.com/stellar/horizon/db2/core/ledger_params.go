@@ -0,0 +1,88 @@
+package core
+
+import (
+	"sync"
+
+	sq "github.com/lann/squirrel"
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/log"
+)
+
+// LedgerParams loads the network parameters (protocol version, base fee, base
+// reserve and maximum transaction set size) in effect as of the last ledger
+// closed by stellar-core into `dest`, leaving its other fields untouched.
+//
+// The last closed ledger is found using the `lastclosedledger` entry of the
+// `storestate` table, falling back to the highest sequence in `ledgerheaders`
+// for schema variants that do not record it.  If no header can be found or
+// decoded, the parameters are zeroed.
+func (q *Q) LedgerParams(dest *ledger.State) error {
+	dest.ProtocolVersion = 0
+	dest.BaseFee = 0
+	dest.BaseReserve = 0
+	dest.MaxTxSetSize = 0
+
+	sql := sq.Select("clh.data").
+		From("ledgerheaders clh").
+		Limit(1)
+
+	var hash string
+	err := q.Get(&hash, sq.Select("TRIM(state)").
+		From("storestate").
+		Limit(1).
+		Where("statename = ?", "lastclosedledger"))
+
+	switch {
+	case err == nil:
+		sql = sql.Where("clh.ledgerhash = ?", hash)
+	case q.NoRows(err):
+		warnOnce(&warnNoLastClosed, "core: storestate has no lastclosedledger, using the latest ledger header")
+		sql = sql.OrderBy("clh.ledgerseq DESC")
+	default:
+		return err
+	}
+
+	var raw string
+	err = q.Get(&raw, sql)
+	if q.NoRows(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	setLedgerParams(dest, raw)
+	return nil
+}
+
+// setLedgerParams sets the network parameters of `dest` from `raw`, a base64
+// encoded ledger header, leaving them zeroed if it cannot be decoded.
+func setLedgerParams(dest *ledger.State, raw string) {
+	var header xdr.LedgerHeader
+	err := xdr.SafeUnmarshalBase64(raw, &header)
+	if err != nil {
+		warnOnce(&warnBadHeader, "core: cannot decode ledger header, ledger params unavailable: %s", err)
+		return
+	}
+
+	dest.ProtocolVersion = int32(header.LedgerVersion)
+	dest.BaseFee = int32(header.BaseFee)
+	dest.BaseReserve = int32(header.BaseReserve)
+	dest.MaxTxSetSize = int32(header.MaxTxSetSize)
+}
+
+// warnOnce logs a warning the first time it is called with `once`, so that a
+// problem which persists for the lifetime of the process is not logged on
+// every refresh of the ledger state.
+func warnOnce(once *sync.Once, format string, args ...interface{}) {
+	once.Do(func() {
+		log.Warnf(format, args...)
+	})
+}
+
+var (
+	warnBadHeader    sync.Once
+	warnNoLastClosed sync.Once
+)
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/stellar/horizon/ledger"
+)
+
+// LedgerState loads stellar-core's side of the ledger state into `dest` using
+// a single query:  the elder and latest ledgers (see ElderLedger and
+// LatestLedger), the hash and close time of the latest ledger, and the network
+// parameters (see LedgerParams).  The other fields of `dest` are untouched.
+func (q *Q) LedgerState(dest *ledger.State) error {
+	var row struct {
+		Elder           int32          `db:"elder"`
+		Latest          int32          `db:"latest"`
+		LatestHash      string         `db:"latest_hash"`
+		LatestCloseTime int64          `db:"latest_closetime"`
+		HasLastClosed   bool           `db:"has_last_closed"`
+		ParamsHeader    sql.NullString `db:"params_header"`
+	}
+
+	err := q.GetRaw(&row, `
+		SELECT
+			CASE
+				WHEN EXISTS (SELECT 1 FROM ledgerheaders WHERE ledgerseq = 2) THEN 1
+				ELSE COALESCE((SELECT MIN(ledgerseq) FROM ledgerheaders WHERE ledgerseq > 2), 0)
+			END AS elder,
+			COALESCE(latest.ledgerseq, 0) AS latest,
+			COALESCE(latest.ledgerhash, '') AS latest_hash,
+			COALESCE(latest.closetime, 0) AS latest_closetime,
+			lcl.state IS NOT NULL AS has_last_closed,
+			CASE
+				WHEN lcl.state IS NULL THEN latest.data
+				ELSE lclh.data
+			END AS params_header
+		FROM (SELECT 1) AS one
+		LEFT JOIN (
+			SELECT ledgerseq, ledgerhash, closetime, data
+			FROM ledgerheaders
+			ORDER BY ledgerseq DESC
+			LIMIT 1
+		) AS latest ON true
+		LEFT JOIN storestate lcl ON lcl.statename = 'lastclosedledger'
+		LEFT JOIN ledgerheaders lclh ON lclh.ledgerhash = TRIM(lcl.state)
+	`)
+	if err != nil {
+		return err
+	}
+
+	dest.CoreElder = row.Elder
+	dest.CoreLatest = row.Latest
+	dest.CoreLatestHash = row.LatestHash
+	dest.CoreLatestClosedAt = time.Time{}
+	if row.Latest != 0 {
+		dest.CoreLatestClosedAt = time.Unix(row.LatestCloseTime, 0).UTC()
+	}
+
+	dest.ProtocolVersion = 0
+	dest.BaseFee = 0
+	dest.BaseReserve = 0
+	dest.MaxTxSetSize = 0
+
+	if !row.HasLastClosed && row.Latest != 0 {
+		warnOnce(&warnNoLastClosed, "core: storestate has no lastclosedledger, using the latest ledger header")
+	}
+
+	if row.ParamsHeader.Valid {
+		setLedgerParams(dest, row.ParamsHeader.String)
+	}
+
+	return nil
+}
@@ -3,6 +3,10 @@
 package core
 
 import (
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
 	"github.com/guregu/null"
 	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/xdr"
@@ -21,6 +25,17 @@ type Account struct {
 	Flags         xdr.AccountFlags
 }
 
+// AccountCache loads accounts by address, caching the results for as long as
+// the latest ledger known to stellar-core remains the same.  At most the
+// `size` provided to Q.AccountCache results are cached, evicting the least
+// recently used.  It is safe for concurrent use.
+type AccountCache struct {
+	Q *Q
+
+	lock sync.Mutex
+	lru  *lru.Cache
+}
+
 // AccountData is a row of data from the `accountdata` table
 type AccountData struct {
 	Accountid string
@@ -28,6 +43,30 @@ type AccountData struct {
 	Value     string `db:"datavalue"`
 }
 
+// AssetStat is the total balance of, and number of trustlines to, an asset,
+// aggregated from the `trustlines` table, alongside the flags of its issuer.
+type AssetStat struct {
+	Assettype   xdr.AssetType    `db:"assettype"`
+	Assetcode   string           `db:"assetcode"`
+	Issuer      string           `db:"issuer"`
+	Amount      xdr.Int64        `db:"amount"`
+	NumAccounts int32            `db:"num_accounts"`
+	Flags       xdr.AccountFlags `db:"flags"`
+}
+
+// EntryCounts are the numbers of ledger entries of each type in existence.
+type EntryCounts struct {
+	Accounts   int64 `db:"accounts"`
+	Trustlines int64 `db:"trustlines"`
+}
+
+// LedgerNotFoundError is returned by queries for the contents of a ledger
+// that the stellar-core database has no record of, allowing callers to tell a
+// missing ledger apart from an empty one or from a failed query.
+type LedgerNotFoundError struct {
+	Sequence int32
+}
+
 // LedgerHeader is row of data from the `ledgerheaders` table
 type LedgerHeader struct {
 	LedgerHash     string           `db:"ledgerhash"`
@@ -67,9 +106,18 @@ type OrderBookSummaryPriceLevel struct {
 }
 
 // OrderBookSummary is a summary of a set of offers for a given base and
-// counter currency
+// counter currency.  Amounts are the exact sums of the member offers.
 type OrderBookSummary []OrderBookSummaryPriceLevel
 
+// OrderBookRemainder summarizes the price levels of one side, "ask" or "bid",
+// of an order book that lie beyond those loaded by OrderBookSummary.  Used by
+// OrderBookRemainders.
+type OrderBookRemainder struct {
+	Type        string `db:"type"`
+	PriceLevels int32  `db:"price_levels"`
+	Amount      int64  `db:"amount"`
+}
+
 // Q is a helper struct on which to hang common queries against a stellar
 // core database.
 type Q struct {
@@ -85,9 +133,22 @@ type PriceLevel struct {
 	Amount int64   `db:"amount"`
 }
 
-// SequenceProvider implements `txsub.SequenceProvider`
+// SequenceProvider implements `txsub.SequenceProvider`.  Loaded sequence
+// numbers are cached until either `TTL` elapses or the latest ledger known to
+// stellar-core advances, and concurrent lookups of the same address are
+// coalesced into a single query.
 type SequenceProvider struct {
 	Q *Q
+
+	// TTL is the maximum amount of time a sequence number will be cached.  A
+	// zero value disables caching.
+	TTL time.Duration
+
+	lock     sync.Mutex
+	cache    map[string]uint64
+	cachedAt time.Time
+	ledger   int32
+	inflight map[string]*sequenceLoad
 }
 
 // Signer is a row of data from the `signers` table from stellar-core
@@ -115,6 +176,15 @@ type TransactionFee struct {
 	Changes         xdr.LedgerEntryChanges `db:"txchanges"`
 }
 
+// SellingLiability is the total amount of an asset that an account offers
+// for sale across its open offers, aggregated from the `offers` table.
+type SellingLiability struct {
+	AssetType xdr.AssetType `db:"sellingassettype"`
+	AssetCode null.String   `db:"sellingassetcode"`
+	Issuer    null.String   `db:"sellingissuer"`
+	Amount    xdr.Int64     `db:"amount"`
+}
+
 // Trustline is a row of data from the `trustlines` table from stellar-core
 type Trustline struct {
 	Accountid string
@@ -221,3 +291,13 @@ func (q *Q) ElderLedger(dest *int32) error {
 func (q *Q) LatestLedger(dest interface{}) error {
 	return q.GetRaw(dest, `SELECT COALESCE(MAX(ledgerseq), 0) FROM ledgerheaders`)
 }
+
+// EntryCounts loads the number of accounts and trustlines in existence into
+// `dest`.
+func (q *Q) EntryCounts(dest *EntryCounts) error {
+	return q.GetRaw(dest, `
+		SELECT
+			(SELECT COUNT(*) FROM accounts) AS accounts,
+			(SELECT COUNT(*) FROM trustlines) AS trustlines
+	`)
+}
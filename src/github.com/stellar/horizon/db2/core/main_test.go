@@ -1,9 +1,12 @@
 package core
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/test"
+	tdb "github.com/stellar/horizon/test/db"
 )
 
 func TestLatestLedger(t *testing.T) {
@@ -61,3 +64,62 @@ func TestElderLedger(t *testing.T) {
 		tt.Assert.Equal(elder, int32(10))
 	}
 }
+
+func TestSchemaVersion(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	var version int
+	err := q.SchemaVersion(&version)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(3, version)
+	}
+
+	_, err = tt.CoreDB.Exec(`
+		UPDATE storestate SET state = 'garbage' WHERE statename = 'databaseschema'
+	`)
+	tt.Require.NoError(err)
+	tt.Assert.Error(q.SchemaVersion(&version))
+
+	_, err = tt.CoreDB.Exec(`DELETE FROM storestate WHERE statename = 'databaseschema'`)
+	tt.Require.NoError(err)
+	err = q.SchemaVersion(&version)
+	tt.Assert.True(q.NoRows(err))
+}
+
+func TestReadOnly(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	repo, err := db2.OpenReadOnly(tdb.StellarCoreURL())
+	tt.Require.NoError(err)
+	defer repo.DB.Close()
+	q := &Q{repo}
+
+	var before, after int
+	tt.Require.NoError(q.GetRaw(&before, `SELECT COUNT(*) FROM accounts`))
+
+	// debug builds panic rather than returning an error
+	insert := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+
+		_, err = q.ExecRaw(`
+			INSERT INTO accounts (accountid, balance, seqnum, numsubentries, homedomain, thresholds, flags, lastmodified)
+			VALUES ('GAXI33UCLQTCKM2NMRBS7XYBR535LLEVAHL5YBN4FTCB4HZHT7ZA5CVK', 0, 0, 0, '', 'AQAAAA==', 0, 0)
+		`)
+		return
+	}
+	tt.Assert.Error(insert())
+
+	tt.Require.NoError(q.GetRaw(&after, `SELECT COUNT(*) FROM accounts`))
+	tt.Assert.Equal(before, after)
+
+	// postgres rejects writes that get past the repo
+	var setting string
+	tt.Require.NoError(q.GetRaw(&setting, `SHOW default_transaction_read_only`))
+	tt.Assert.Equal("on", setting)
+}
@@ -100,3 +100,20 @@ func (q *Q) OffersByAddress(dest interface{}, addy string, pq db2.PageQuery) err
 
 	return q.Select(dest, sql)
 }
+
+// SellingLiabilitiesByAddress loads, for each asset the given address offers
+// for sale, the total amount offered across its open offers.  Totals that
+// would overflow an int64 are capped.
+func (q *Q) SellingLiabilitiesByAddress(dest interface{}, addy string) error {
+	sql := sq.Select(
+		"co.sellingassettype",
+		"co.sellingassetcode",
+		"co.sellingissuer",
+		"LEAST(SUM(co.amount), 9223372036854775807)::bigint AS amount",
+	).
+		From("offers co").
+		Where("co.sellerid = ?", addy).
+		GroupBy("co.sellingassettype", "co.sellingassetcode", "co.sellingissuer")
+
+	return q.Select(dest, sql)
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"testing"
 
+	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/test"
 )
@@ -69,3 +70,25 @@ func TestOffersByAddress(t *testing.T) {
 		tt.Assert.Equal(int64(2), offers[0].OfferID)
 	}
 }
+
+func TestSellingLiabilitiesByAddress(t *testing.T) {
+	tt := test.Start(t).Scenario("trades")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	var liabilities []SellingLiability
+
+	// three offers of EUR are summed
+	err := q.SellingLiabilitiesByAddress(&liabilities, "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2")
+	if tt.Assert.NoError(err) && tt.Assert.Len(liabilities, 1) {
+		tt.Assert.Equal("EUR", liabilities[0].AssetCode.String)
+		tt.Assert.Equal("GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG", liabilities[0].Issuer.String)
+		tt.Assert.Equal(xdr.Int64(2200000000), liabilities[0].Amount)
+	}
+
+	liabilities = nil
+	err = q.SellingLiabilitiesByAddress(&liabilities, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(liabilities, 0)
+	}
+}
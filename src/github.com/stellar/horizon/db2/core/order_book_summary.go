@@ -19,7 +19,22 @@ type orderbookQueryBuilder struct {
 	args          []interface{}
 }
 
-var orderbookQueryTemplate *template.Template
+var (
+	orderbookQueryTemplate          *template.Template
+	orderbookRemainderQueryTemplate *template.Template
+)
+
+// DefaultOrderBookDepth is the number of price levels per side loaded by
+// OrderBookSummary when a client does not request a specific depth.
+const DefaultOrderBookDepth = 20
+
+// MaxOrderBookDepth is the largest number of price levels per side that may be
+// loaded by OrderBookSummary.  It may be changed during app initialization.
+var MaxOrderBookDepth = 200
+
+// ErrInvalidOrderBookDepth is returned by OrderBookSummary when the requested
+// depth is not between 1 and MaxOrderBookDepth.
+var ErrInvalidOrderBookDepth = errors.New("invalid order book depth")
 
 // Asks filters the summary into a slice of PriceLevelRecords where the type is 'ask'
 func (o *OrderBookSummary) Asks() []OrderBookSummaryPriceLevel {
@@ -50,10 +65,49 @@ func (o *OrderBookSummary) filter(typ string, prepend bool) []OrderBookSummaryPr
 	return result
 }
 
-// GetOrderBookSummary loads a summary of an order book identified by a
-// selling/buying pair. It is designed to drive an order book summary client
-// interface (bid/ask spread, prices and volume, etc).
-func (q *Q) GetOrderBookSummary(dest interface{}, selling xdr.Asset, buying xdr.Asset) error {
+// OrderBookSummary loads a summary of an order book identified by a
+// selling/buying pair, aggregating offers into at most `depth` price levels
+// per side. It is designed to drive an order book summary client interface
+// (bid/ask spread, prices and volume, etc).
+//
+// Offers are grouped and ordered by their exact rational price, so that
+// distinct prices are never merged, and amounts are summed exactly.
+func (q *Q) OrderBookSummary(
+	dest *OrderBookSummary,
+	selling xdr.Asset,
+	buying xdr.Asset,
+	depth int,
+) error {
+	return q.selectOrderBook(dest, orderbookQueryTemplate, selling, buying, depth)
+}
+
+// OrderBookRemainders loads, for each side of the order book identified by a
+// selling/buying pair, the number of price levels and their total amount
+// beyond the `depth` price levels that OrderBookSummary loads.  `dest` is
+// populated with a row for each side, identified by its Type, that is zero
+// when the side has no further price levels.
+func (q *Q) OrderBookRemainders(
+	dest *[]OrderBookRemainder,
+	selling xdr.Asset,
+	buying xdr.Asset,
+	depth int,
+) error {
+	return q.selectOrderBook(dest, orderbookRemainderQueryTemplate, selling, buying, depth)
+}
+
+// selectOrderBook runs the order book query rendered from `tmpl` for the
+// selling/buying pair, with `depth` as its first argument, into `dest`.
+func (q *Q) selectOrderBook(
+	dest interface{},
+	tmpl *template.Template,
+	selling xdr.Asset,
+	buying xdr.Asset,
+	depth int,
+) error {
+	if depth < 1 || depth > MaxOrderBookDepth {
+		return errors.Wrap(ErrInvalidOrderBookDepth, 1)
+	}
+
 	var sql bytes.Buffer
 	var oq orderbookQueryBuilder
 	err := selling.Extract(&oq.SellingType, &oq.SellingCode, &oq.SellingIssuer)
@@ -65,9 +119,9 @@ func (q *Q) GetOrderBookSummary(dest interface{}, selling xdr.Asset, buying xdr.
 		return err
 	}
 
-	oq.pushArg(20)
+	oq.pushArg(depth)
 
-	err = orderbookQueryTemplate.Execute(&sql, &oq)
+	err = tmpl.Execute(&sql, &oq)
 	if err != nil {
 		return errors.Wrap(err, 1)
 	}
@@ -102,6 +156,9 @@ func (q *orderbookQueryBuilder) pushArg(v interface{}) int {
 
 func init() {
 	orderbookQueryTemplate = template.Must(template.New("sql").Parse(`
+-- Prices are compared as numerics with enough scale to distinguish any two
+-- distinct ratios of int32s, rather than as floats which can tie or misorder
+-- nearby prices.
 SELECT
 	*,
 	(pricen :: double precision / priced :: double precision) as pricef
@@ -113,7 +170,7 @@ FROM
 		'ask' as type,
 		co.pricen,
 		co.priced,
-		SUM(co.amount) as amount
+		SUM(co.amount) :: bigint as amount
 
 	FROM  offers co
 
@@ -127,10 +184,9 @@ FROM
 
 	GROUP BY
 		co.pricen,
-		co.priced,
-		co.price
+		co.priced
 
-	ORDER BY co.price ASC
+	ORDER BY co.pricen :: numeric(40,30) / co.priced ASC
 
 	LIMIT $1
 
@@ -142,7 +198,7 @@ FROM
 		'bid'  as type,
 		co.priced as pricen,
 		co.pricen as priced,
-		SUM(co.amount) as amount
+		SUM(co.amount) :: bigint as amount
 
 	FROM offers co
 
@@ -156,14 +212,73 @@ FROM
 
 	GROUP BY
 		co.pricen,
-		co.priced,
-		co.price
+		co.priced
+
+	ORDER BY co.pricen :: numeric(40,30) / co.priced DESC
 
-	ORDER BY co.price DESC
-	
 	LIMIT $1
 )) summary
 
-ORDER BY type, pricef
+ORDER BY type, pricen :: numeric(40,30) / priced
+`))
+
+	// The remainder of each side is the price levels the summary query would
+	// return were it not limited, past the first $1 of them.
+	orderbookRemainderQueryTemplate = template.Must(template.New("sql").Parse(`
+SELECT
+	'ask' as type,
+	COUNT(*) as price_levels,
+	COALESCE(SUM(asks.amount), 0) :: bigint as amount
+
+FROM (
+	SELECT SUM(co.amount) as amount
+
+	FROM  offers co
+
+	WHERE 1=1
+	AND   {{ .Filter "co.sellingassettype" .SellingType }}
+	AND   {{ .Filter "co.sellingassetcode" .SellingCode}}
+	AND   {{ .Filter "co.sellingissuer"    .SellingIssuer}}
+	AND   {{ .Filter "co.buyingassettype"  .BuyingType }}
+	AND   {{ .Filter "co.buyingassetcode"  .BuyingCode}}
+	AND   {{ .Filter "co.buyingissuer"     .BuyingIssuer}}
+
+	GROUP BY
+		co.pricen,
+		co.priced
+
+	ORDER BY co.pricen :: numeric(40,30) / co.priced ASC
+
+	OFFSET $1
+) asks
+
+UNION ALL
+
+SELECT
+	'bid' as type,
+	COUNT(*) as price_levels,
+	COALESCE(SUM(bids.amount), 0) :: bigint as amount
+
+FROM (
+	SELECT SUM(co.amount) as amount
+
+	FROM offers co
+
+	WHERE 1=1
+	AND   {{ .Filter "co.sellingassettype" .BuyingType }}
+	AND   {{ .Filter "co.sellingassetcode" .BuyingCode}}
+	AND   {{ .Filter "co.sellingissuer"    .BuyingIssuer}}
+	AND   {{ .Filter "co.buyingassettype"  .SellingType }}
+	AND   {{ .Filter "co.buyingassetcode"  .SellingCode}}
+	AND   {{ .Filter "co.buyingissuer"     .SellingIssuer}}
+
+	GROUP BY
+		co.pricen,
+		co.priced
+
+	ORDER BY co.pricen :: numeric(40,30) / co.priced DESC
+
+	OFFSET $1
+) bids
 `))
 }
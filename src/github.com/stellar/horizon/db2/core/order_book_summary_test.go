@@ -3,11 +3,12 @@ package core
 import (
 	"testing"
 
+	"github.com/go-errors/errors"
 	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/test"
 )
 
-func TestGetOrderBookSummary(t *testing.T) {
+func TestOrderBookSummary(t *testing.T) {
 	tt := test.Start(t).Scenario("order_books")
 	defer tt.Finish()
 	q := &Q{tt.CoreRepo()}
@@ -18,10 +19,10 @@ func TestGetOrderBookSummary(t *testing.T) {
 	tt.Require.NoError(err)
 
 	var summary, inverted OrderBookSummary
-	err = q.GetOrderBookSummary(&summary, selling, buying)
+	err = q.OrderBookSummary(&summary, selling, buying, DefaultOrderBookDepth)
 	tt.Require.NoError(err)
 	tt.Require.Len(summary, 6)
-	err = q.GetOrderBookSummary(&inverted, buying, selling)
+	err = q.OrderBookSummary(&inverted, buying, selling, DefaultOrderBookDepth)
 	tt.Require.NoError(err)
 	tt.Require.Len(inverted, 6)
 
@@ -65,7 +66,7 @@ func TestGetOrderBookSummary(t *testing.T) {
 }
 
 // regression test for https://github.com/stellar/horizon/issues/310
-func TestGetOrderBookSummary_Regress310(t *testing.T) {
+func TestOrderBookSummary_Regress310(t *testing.T) {
 	tt := test.Start(t).Scenario("order_books_310")
 	defer tt.Finish()
 	q := &Q{tt.CoreRepo()}
@@ -76,7 +77,7 @@ func TestGetOrderBookSummary_Regress310(t *testing.T) {
 	tt.Require.NoError(err)
 
 	var summary OrderBookSummary
-	err = q.GetOrderBookSummary(&summary, selling, buying)
+	err = q.OrderBookSummary(&summary, selling, buying, DefaultOrderBookDepth)
 	tt.Require.NoError(err)
 	tt.Require.Len(summary, 20)
 
@@ -91,7 +92,7 @@ func TestGetOrderBookSummary_Regress310(t *testing.T) {
 	tt.Assert.Equal(0.3, summary[2].Pricef)
 
 	// validate the inverse order book is correct as well
-	err = q.GetOrderBookSummary(&summary, buying, selling)
+	err = q.OrderBookSummary(&summary, buying, selling, DefaultOrderBookDepth)
 	tt.Require.NoError(err)
 	tt.Require.Len(summary, 20)
 
@@ -99,3 +100,92 @@ func TestGetOrderBookSummary_Regress310(t *testing.T) {
 	tt.Assert.Equal(1.0/10.1, summary[1].Pricef)
 	tt.Assert.Equal(1.0/10.0, summary[2].Pricef)
 }
+
+func TestOrderBookSummary_Depth(t *testing.T) {
+	tt := test.Start(t).Scenario("order_books_310")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	selling, err := AssetFromDB(xdr.AssetTypeAssetTypeCreditAlphanum4, "USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	tt.Require.NoError(err)
+	buying, err := AssetFromDB(xdr.AssetTypeAssetTypeNative, "", "")
+	tt.Require.NoError(err)
+
+	var summary OrderBookSummary
+	err = q.OrderBookSummary(&summary, selling, buying, 3)
+	tt.Require.NoError(err)
+	tt.Require.Len(summary, 3)
+	tt.Assert.Equal(0.1, summary[0].Pricef)
+	tt.Assert.Equal(0.2, summary[1].Pricef)
+	tt.Assert.Equal(0.3, summary[2].Pricef)
+
+	// all 33 offers in the scenario have distinct prices, including 1/3 and
+	// 100000009/310000028 which are nearly identical
+	err = q.OrderBookSummary(&summary, selling, buying, MaxOrderBookDepth)
+	tt.Require.NoError(err)
+	tt.Require.Len(summary, 33)
+
+	var total int64
+	for _, l := range summary {
+		total += l.Amount
+	}
+	var expected int64
+	err = tt.CoreRepo().GetRaw(&expected, `SELECT SUM(amount) FROM offers`)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(expected, total)
+
+	// invalid depths
+	err = q.OrderBookSummary(&summary, selling, buying, 0)
+	tt.Assert.True(errors.Is(err, ErrInvalidOrderBookDepth))
+	err = q.OrderBookSummary(&summary, selling, buying, MaxOrderBookDepth+1)
+	tt.Assert.True(errors.Is(err, ErrInvalidOrderBookDepth))
+}
+
+func TestOrderBookRemainders(t *testing.T) {
+	tt := test.Start(t).Scenario("order_books_310")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	selling, err := AssetFromDB(xdr.AssetTypeAssetTypeCreditAlphanum4, "USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	tt.Require.NoError(err)
+	buying, err := AssetFromDB(xdr.AssetTypeAssetTypeNative, "", "")
+	tt.Require.NoError(err)
+
+	var summary OrderBookSummary
+	err = q.OrderBookSummary(&summary, selling, buying, 3)
+	tt.Require.NoError(err)
+
+	var remainders []OrderBookRemainder
+	err = q.OrderBookRemainders(&remainders, selling, buying, 3)
+	tt.Require.NoError(err)
+	tt.Require.Len(remainders, 2)
+
+	byType := map[string]OrderBookRemainder{}
+	for _, r := range remainders {
+		byType[r.Type] = r
+	}
+
+	// the summary and its remainder together account for every offer
+	var total, summed int64
+	err = tt.CoreRepo().GetRaw(&total, `SELECT SUM(amount) FROM offers`)
+	tt.Require.NoError(err)
+	for _, l := range summary {
+		summed += l.Amount
+	}
+
+	tt.Assert.Equal(int32(30), byType["ask"].PriceLevels)
+	tt.Assert.Equal(total, summed+byType["ask"].Amount)
+	tt.Assert.Equal(OrderBookRemainder{Type: "bid"}, byType["bid"])
+
+	// a depth beyond the available price levels leaves no remainder
+	err = q.OrderBookRemainders(&remainders, selling, buying, MaxOrderBookDepth)
+	tt.Require.NoError(err)
+	tt.Require.Len(remainders, 2)
+	for _, r := range remainders {
+		tt.Assert.Equal(int32(0), r.PriceLevels, r.Type)
+		tt.Assert.Equal(int64(0), r.Amount, r.Type)
+	}
+
+	err = q.OrderBookRemainders(&remainders, selling, buying, 0)
+	tt.Assert.True(errors.Is(err, ErrInvalidOrderBookDepth))
+}
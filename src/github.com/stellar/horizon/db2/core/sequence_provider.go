@@ -0,0 +1,146 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stellar/horizon/ledger"
+)
+
+// DefaultSequenceCacheTTL is the default amount of time a sequence provider
+// caches the sequence numbers it loads.
+const DefaultSequenceCacheTTL = 5 * time.Second
+
+// errSequenceLoadPanicked is the error received by the callers waiting upon a
+// load whose query panicked.
+var errSequenceLoadPanicked = errors.New("sequence load panicked")
+
+// sequenceLoad represents an in-progress query for the sequence numbers of a
+// set of addresses, which other callers may wait upon rather than issuing
+// their own query.
+type sequenceLoad struct {
+	done    chan struct{}
+	results map[string]uint64
+	err     error
+}
+
+// SequenceProvider returns a new, caching sequence provider.
+func (q *Q) SequenceProvider() *SequenceProvider {
+	return &SequenceProvider{Q: q, TTL: DefaultSequenceCacheTTL}
+}
+
+// Get implements `txsub.SequenceProvider`.  Addresses for which no account
+// exists are omitted from the result.
+func (sp *SequenceProvider) Get(addys []string) (map[string]uint64, error) {
+	results := make(map[string]uint64)
+	waiting := map[*sequenceLoad]bool{}
+	var missing []string
+
+	sp.lock.Lock()
+	sp.expire()
+
+	for _, addy := range addys {
+		if seq, ok := sp.cache[addy]; ok {
+			results[addy] = seq
+			continue
+		}
+
+		if load, ok := sp.inflight[addy]; ok {
+			waiting[load] = true
+			continue
+		}
+
+		missing = append(missing, addy)
+	}
+
+	var own *sequenceLoad
+	if len(missing) > 0 {
+		own = &sequenceLoad{done: make(chan struct{})}
+		for _, addy := range missing {
+			sp.inflight[addy] = own
+		}
+	}
+	sp.lock.Unlock()
+
+	if own != nil {
+		sp.load(own, missing)
+		if own.err != nil {
+			return nil, own.err
+		}
+		for addy, seq := range own.results {
+			results[addy] = seq
+		}
+	}
+
+	for load := range waiting {
+		<-load.done
+		if load.err != nil {
+			return nil, load.err
+		}
+
+		for _, addy := range addys {
+			if seq, ok := load.results[addy]; ok {
+				results[addy] = seq
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// expire clears the cache if it has outlived its TTL or if the ledger has
+// closed since it was populated.  Must be called while holding the lock.
+func (sp *SequenceProvider) expire() {
+	latest := ledger.CurrentState().CoreLatest
+
+	if sp.cache == nil ||
+		sp.ledger != latest ||
+		time.Since(sp.cachedAt) > sp.TTL {
+		sp.cache = map[string]uint64{}
+		sp.cachedAt = time.Now()
+		sp.ledger = latest
+	}
+
+	if sp.inflight == nil {
+		sp.inflight = map[string]*sequenceLoad{}
+	}
+}
+
+// load queries the sequence numbers for `addys`, recording the results in
+// `load` and the cache.  Callers waiting upon `load` are released however the
+// query completes, failing with errSequenceLoadPanicked should it panic.
+func (sp *SequenceProvider) load(load *sequenceLoad, addys []string) {
+	ledgerAtStart := ledger.CurrentState().CoreLatest
+
+	load.err = errSequenceLoadPanicked
+	defer func() {
+		sp.lock.Lock()
+		for _, addy := range addys {
+			delete(sp.inflight, addy)
+		}
+
+		// only cache results that are known to reflect the current ledger
+		if load.err == nil && sp.TTL > 0 && sp.ledger == ledgerAtStart {
+			for addy, seq := range load.results {
+				sp.cache[addy] = seq
+			}
+		}
+		sp.lock.Unlock()
+
+		close(load.done)
+	}()
+
+	rows := []struct {
+		Address  string
+		Sequence uint64
+	}{}
+
+	err := sp.Q.SequencesForAddresses(&rows, addys)
+	if err == nil {
+		load.results = make(map[string]uint64)
+		for _, r := range rows {
+			load.results[r.Address] = r.Sequence
+		}
+	}
+	load.err = err
+}
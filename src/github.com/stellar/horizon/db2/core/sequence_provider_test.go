@@ -0,0 +1,106 @@
+package core
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/test"
+)
+
+func TestSequenceProvider(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	defer ledger.SetState(ledger.State{})
+	q := &Q{tt.CoreRepo()}
+
+	const (
+		master  = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+		missing = "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"
+	)
+
+	bump := func() {
+		_, err := tt.CoreDB.Exec(
+			`UPDATE accounts SET seqnum = seqnum + 1 WHERE accountid = $1`,
+			master,
+		)
+		tt.Require.NoError(err)
+	}
+
+	ledger.SetState(ledger.State{CoreLatest: 3})
+	sp := q.SequenceProvider()
+
+	seqs, err := sp.Get([]string{master, missing})
+	tt.Require.NoError(err)
+	tt.Require.Contains(seqs, master)
+	tt.Assert.NotContains(seqs, missing)
+	initial := seqs[master]
+
+	// cached within the same ledger
+	bump()
+	seqs, err = sp.Get([]string{master})
+	tt.Require.NoError(err)
+	tt.Assert.Equal(initial, seqs[master])
+
+	// a ledger close invalidates the cache
+	ledger.SetState(ledger.State{CoreLatest: 4})
+	seqs, err = sp.Get([]string{master})
+	tt.Require.NoError(err)
+	tt.Assert.Equal(initial+1, seqs[master])
+
+	// the ttl invalidates the cache
+	sp.TTL = time.Millisecond
+	bump()
+	time.Sleep(5 * time.Millisecond)
+	seqs, err = sp.Get([]string{master})
+	tt.Require.NoError(err)
+	tt.Assert.Equal(initial+2, seqs[master])
+
+	// concurrent lookups agree
+	sp.TTL = DefaultSequenceCacheTTL
+	ledger.SetState(ledger.State{CoreLatest: 5})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seqs, err := sp.Get([]string{master, missing})
+			if tt.Assert.NoError(err) {
+				tt.Assert.Equal(initial+2, seqs[master])
+				tt.Assert.NotContains(seqs, missing)
+			}
+		}()
+	}
+	wg.Wait()
+	tt.Assert.Empty(sp.inflight)
+}
+
+func TestSequenceProvider_LoadPanic(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	const master = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+	// a Q without a repo panics once queried
+	sp := (&Q{}).SequenceProvider()
+	sp.expire()
+
+	load := &sequenceLoad{done: make(chan struct{})}
+	sp.inflight[master] = load
+
+	tt.Assert.Panics(func() {
+		sp.load(load, []string{master})
+	})
+
+	// waiters are released with an error, and later callers issue their own
+	// query rather than waiting upon the failed load
+	select {
+	case <-load.done:
+	default:
+		t.Fatal("load was not released")
+	}
+	tt.Assert.Equal(errSequenceLoadPanicked, load.err)
+	tt.Assert.Empty(sp.inflight)
+	tt.Assert.Empty(sp.cache)
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	sq "github.com/lann/squirrel"
+)
+
+// SchemaVersion loads the version of stellar-core's database schema, as
+// recorded by stellar-core in the `storestate` table, into `dest`.
+func (q *Q) SchemaVersion(dest *int) error {
+	var raw string
+	sql := sq.Select("state").
+		From("storestate").
+		Limit(1).
+		Where("statename = ?", "databaseschema")
+
+	err := q.Get(&raw, sql)
+	if err != nil {
+		return err
+	}
+
+	*dest, err = strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
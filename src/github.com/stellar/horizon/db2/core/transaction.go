@@ -108,6 +108,19 @@ func (tx *Transaction) ResultMetaXDR() string {
 	return out
 }
 
+// OperationCount returns the number of operations in `tx`.
+func (tx *Transaction) OperationCount() int {
+	return len(tx.Envelope.Tx.Operations)
+}
+
+// OperationResults returns the result of each operation in `tx`, in order.
+// stellar-core only records operation results for transactions that were
+// applied, whether successfully or not, so `ok` is false for transactions
+// that failed before their operations were attempted.
+func (tx *Transaction) OperationResults() (results []xdr.OperationResult, ok bool) {
+	return tx.Result.Result.Result.GetResults()
+}
+
 // Sequence returns the sequence number for `tx`
 func (tx *Transaction) Sequence() int64 {
 	return int64(tx.Envelope.Tx.SeqNum)
@@ -133,13 +146,30 @@ func (q *Q) TransactionByHash(dest interface{}, hash string) error {
 	return q.Get(dest, sql)
 }
 
+// TransactionsByHash is a query that loads the rows from `txhistory` whose
+// hash is in `hashes`.  Hashes that are not found are omitted from the
+// results.
+func (q *Q) TransactionsByHash(dest interface{}, hashes []string) error {
+	sql := sq.Select("ctxh.*").
+		From("txhistory ctxh").
+		Where(sq.Eq{"ctxh.txid": hashes})
+
+	return q.Select(dest, sql)
+}
+
 // TransactionsByLedger is a query that loads all rows from `txhistory` where
-// ledgerseq matches `Sequence.`
+// ledgerseq matches `Sequence.`  A *LedgerNotFoundError is returned if
+// stellar-core has no record of the ledger.
 func (q *Q) TransactionsByLedger(dest interface{}, seq int32) error {
 	sql := sq.Select("ctxh.*").
 		From("txhistory ctxh").
 		OrderBy("ctxh.txindex ASC").
 		Where("ctxh.ledgerseq = ?", seq)
 
-	return q.Select(dest, sql)
+	err := q.Select(dest, sql)
+	if err != nil {
+		return err
+	}
+
+	return q.ensureLedgerIfEmpty(dest, seq)
 }
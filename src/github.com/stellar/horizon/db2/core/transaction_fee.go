@@ -15,12 +15,29 @@ func (fee *TransactionFee) ChangesXDR() string {
 }
 
 // TransactionFeesByLedger is a query that loads all rows from `txfeehistory`
-// where ledgerseq matches `Sequence.`
+// where ledgerseq matches `Sequence.`  A *LedgerNotFoundError is returned if
+// stellar-core has no record of the ledger.
 func (q *Q) TransactionFeesByLedger(dest interface{}, seq int32) error {
 	sql := sq.Select("ctxfh.*").
 		From("txfeehistory ctxfh").
 		OrderBy("ctxfh.txindex ASC").
 		Where("ctxfh.ledgerseq = ?", seq)
 
+	err := q.Select(dest, sql)
+	if err != nil {
+		return err
+	}
+
+	return q.ensureLedgerIfEmpty(dest, seq)
+}
+
+// TransactionFeesByHash is a query that loads the rows from `txfeehistory`
+// whose hash is in `hashes`.  Hashes that are not found are omitted from the
+// results.
+func (q *Q) TransactionFeesByHash(dest interface{}, hashes []string) error {
+	sql := sq.Select("ctxfh.*").
+		From("txfeehistory ctxfh").
+		Where(sq.Eq{"ctxfh.txid": hashes})
+
 	return q.Select(dest, sql)
 }
@@ -16,5 +16,30 @@ func TestTransactionFeesByLedger(t *testing.T) {
 
 	if tt.Assert.NoError(err) {
 		tt.Assert.Len(fees, 3)
+		tt.Assert.Equal(int32(1), fees[0].Index)
+	}
+
+	err = q.TransactionFeesByLedger(&fees, 100)
+	tt.Assert.True(IsLedgerNotFound(err))
+}
+
+func TestTransactionFeesByHash(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	var fees []TransactionFee
+	err := q.TransactionFeesByLedger(&fees, 2)
+	tt.Require.NoError(err)
+
+	var found []TransactionFee
+	err = q.TransactionFeesByHash(&found, []string{
+		fees[0].TransactionHash,
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	})
+
+	if tt.Assert.NoError(err) && tt.Assert.Len(found, 1) {
+		tt.Assert.Equal(fees[0].TransactionHash, found[0].TransactionHash)
+		tt.Assert.Equal(fees[0].ChangesXDR(), found[0].ChangesXDR())
 	}
 }
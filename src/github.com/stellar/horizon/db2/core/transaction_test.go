@@ -19,6 +19,18 @@ func TestTransactionsQueries(t *testing.T) {
 		tt.Assert.Len(txs, 3)
 	}
 
+	// empty ledgers are not missing
+	err = q.TransactionsByLedger(&txs, 1)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(txs, 0)
+	}
+
+	err = q.TransactionsByLedger(&txs, 100)
+	if tt.Assert.Error(err) {
+		tt.Assert.True(IsLedgerNotFound(err))
+		tt.Assert.Equal(int32(100), err.(*LedgerNotFoundError).Sequence)
+	}
+
 	// Test TransactionByHash
 	var tx Transaction
 	err = q.TransactionByHash(&tx, "cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a")
@@ -27,3 +39,21 @@ func TestTransactionsQueries(t *testing.T) {
 		tt.Assert.Equal(int32(3), tx.LedgerSequence)
 	}
 }
+
+func TestTransactionOperationResults(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	q := &Q{tt.CoreRepo()}
+
+	var txs []Transaction
+	err := q.TransactionsByLedger(&txs, 2)
+	tt.Require.NoError(err)
+
+	for _, tx := range txs {
+		results, ok := tx.OperationResults()
+		if tt.Assert.True(ok) {
+			tt.Assert.Len(results, tx.OperationCount())
+		}
+		tt.Assert.Equal(1, tx.OperationCount())
+	}
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
+// IsAuthorized returns true if the trustline's issuer has authorized it to
+// hold the asset.
+func (tl Trustline) IsAuthorized() bool {
+	return tl.Flags&int32(xdr.TrustLineFlagsAuthorizedFlag) != 0
+}
+
 // AssetsForAddress loads `dest` as `[]xdr.Asset` with every asset the account
 // at `addy` can hold.
 func (q *Q) AssetsForAddress(dest interface{}, addy string) error {
@@ -36,6 +42,24 @@ func (q *Q) AssetsForAddress(dest interface{}, addy string) error {
 	return err
 }
 
+// AssetStats loads the stats of every asset trusted by an account into
+// `dest`, a *[]AssetStat, using a single grouped query over the trustlines.
+// The flags of an issuer whose account has been merged are zero.
+func (q *Q) AssetStats(dest interface{}) error {
+	return q.SelectRaw(dest, `
+		SELECT
+			tl.assettype,
+			tl.assetcode,
+			tl.issuer,
+			SUM(tl.balance) AS amount,
+			COUNT(*) AS num_accounts,
+			COALESCE(a.flags, 0) AS flags
+		FROM trustlines tl
+		LEFT JOIN accounts a ON a.accountid = tl.issuer
+		GROUP BY tl.assettype, tl.assetcode, tl.issuer, a.flags
+	`)
+}
+
 // TrustlinesByAddress loads all trustlines for `addy`
 func (q *Q) TrustlinesByAddress(dest interface{}, addy string) error {
 	sql := selectTrustline.Where("accountid = ?", addy)
@@ -0,0 +1,276 @@
+package db2
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stellar/horizon/errors"
+	"github.com/stellar/horizon/log"
+	"golang.org/x/net/context"
+)
+
+// ErrNoFailoverCandidate is returned by Failover.Check when none of the
+// databases is both reachable and sufficiently caught up.  It is
+// errors.Transient.
+var ErrNoFailoverCandidate = errors.NewTransient("db: no database available to fail over to")
+
+// DefaultFailoverCheckTimeout is how long Failover.Check waits for each
+// database to answer a ping when the failover does not specify otherwise.
+const DefaultFailoverCheckTimeout = 2 * time.Second
+
+// Failover selects, from a list of databases holding the same data, the one
+// that queries are made against.  The databases are given in order of
+// preference, the first being the primary:  the most preferred database that
+// is reachable, and whose data is no more than MaxLag behind that of the
+// most advanced reachable database, is selected.  Selection is revisited by
+// Check, so that horizon recovers onto the primary once it is back, and by
+// Fail, which a repo calls when it loses its connection to the selected
+// database.
+//
+// A repo whose Failover is set makes its queries against the currently
+// selected database rather than its own DB.  A transaction remains bound to the
+// database upon which it began.
+type Failover struct {
+	// Position, when set, loads how far along the data of a database is, such
+	// as the latest ledger of a stellar-core database.  A database for which
+	// it fails, or does not answer before the check's deadline, is considered
+	// unavailable.  The repo it is given carries that deadline in its context,
+	// which limits the statements it executes.
+	Position func(r *Repo) (int64, error)
+
+	// MaxLag is the furthest behind, as reported by Position, that a database
+	// may be of the most advanced available database and still be selected.
+	MaxLag int64
+
+	// CheckTimeout is how long Check waits for each database to answer a ping
+	// and report its position.  Zero means DefaultFailoverCheckTimeout is
+	// used.
+	CheckTimeout time.Duration
+
+	lock       sync.RWMutex
+	candidates []*failoverCandidate
+	current    int
+}
+
+// FailoverStatus describes a single database of a Failover, as of the most
+// recent check.
+type FailoverStatus struct {
+	Index     int
+	Selected  bool
+	Available bool
+	Position  int64
+	Err       error
+}
+
+type failoverCandidate struct {
+	repo      *Repo
+	health    Health
+	available bool
+	position  int64
+	err       error
+}
+
+// NewFailover returns a failover between `repos`, in order of preference.
+// Until the first check, the primary is selected.
+func NewFailover(repos ...*Repo) *Failover {
+	f := &Failover{}
+	for _, r := range repos {
+		f.candidates = append(f.candidates, &failoverCandidate{
+			repo:      r,
+			available: true,
+		})
+	}
+	return f
+}
+
+// DB returns the connection of the currently selected database.
+func (f *Failover) DB() *sqlx.DB {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.candidates[f.current].repo.DB
+}
+
+// Current returns the index of the currently selected database.
+func (f *Failover) Current() int {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.current
+}
+
+// Repo returns the repo of the database at `index`, in order of preference.
+func (f *Failover) Repo(index int) *Repo {
+	return f.candidates[index].repo
+}
+
+// Len returns the number of databases in the failover.
+func (f *Failover) Len() int {
+	return len(f.candidates)
+}
+
+// Check pings every database of the failover and loads its position,
+// selecting the most preferred available database within MaxLag of the most
+// advanced.  It returns ErrNoFailoverCandidate, leaving the selection
+// untouched, when no database is available.
+func (f *Failover) Check() error {
+	timeout := f.CheckTimeout
+	if timeout == 0 {
+		timeout = DefaultFailoverCheckTimeout
+	}
+
+	type result struct {
+		position int64
+		err      error
+	}
+	results := make([]result, len(f.candidates))
+
+	var wg sync.WaitGroup
+	for i, c := range f.candidates {
+		wg.Add(1)
+		go func(i int, c *failoverCandidate) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			err := c.health.CheckWithin(c.repo, timeout)
+			if err == nil && f.Position != nil {
+				results[i].position, err = f.positionWithin(ctx, c.repo)
+			}
+			results[i].err = err
+		}(i, c)
+	}
+	wg.Wait()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for i, c := range f.candidates {
+		c.available = results[i].err == nil
+		c.position = results[i].position
+		c.err = results[i].err
+	}
+
+	if !f.selectBestLocked() {
+		return ErrNoFailoverCandidate
+	}
+
+	return nil
+}
+
+// Fail records that the connection `db` has been lost with `err`, selecting
+// the most preferred of the other available databases within MaxLag of the
+// most advanced, as of the most recent check, should `db` be that of the
+// selected one.  The database that failed is not considered again until the
+// next Check.
+func (f *Failover) Fail(db *sqlx.DB, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	c := f.candidates[f.current]
+	if c.repo.DB != db {
+		return
+	}
+
+	c.available = false
+	c.err = err
+
+	f.selectBestLocked()
+}
+
+// Statuses returns the status of each database of the failover, as of the most
+// recent check, in order of preference.
+func (f *Failover) Statuses() []FailoverStatus {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	statuses := make([]FailoverStatus, len(f.candidates))
+	for i, c := range f.candidates {
+		statuses[i] = FailoverStatus{
+			Index:     i,
+			Selected:  i == f.current,
+			Available: c.available,
+			Position:  c.position,
+			Err:       c.err,
+		}
+	}
+	return statuses
+}
+
+// Close closes the connection of every database in the failover.
+func (f *Failover) Close() error {
+	var err error
+	for _, c := range f.candidates {
+		if cerr := c.repo.DB.Close(); cerr != nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// positionWithin calls Position for `r`, failing with ErrTimeout should it not
+// return before the deadline of `ctx`.  The deadline is also given to the
+// repo, so that postgres cancels the statements Position executes past it.
+func (f *Failover) positionWithin(ctx context.Context, r *Repo) (int64, error) {
+	r = r.Clone()
+	r.Ctx = ctx
+
+	type result struct {
+		position int64
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		position, err := f.Position(r)
+		done <- result{position, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.position, res.err
+	case <-ctx.Done():
+		return 0, ErrTimeout
+	}
+}
+
+// selectBestLocked selects the most preferred available database whose
+// position is within MaxLag of the most advanced available database,
+// returning false, and leaving the selection untouched, when no database is
+// available.  The caller must hold the lock.
+func (f *Failover) selectBestLocked() bool {
+	var best int64
+	found := false
+	for _, c := range f.candidates {
+		if c.available && (!found || c.position > best) {
+			best = c.position
+			found = true
+		}
+	}
+
+	if !found {
+		return false
+	}
+
+	for i, c := range f.candidates {
+		if c.available && best-c.position <= f.MaxLag {
+			f.selectLocked(i)
+			break
+		}
+	}
+
+	return true
+}
+
+// selectLocked selects the `i`th database, logging the change.  The caller
+// must hold the lock.
+func (f *Failover) selectLocked(i int) {
+	if i == f.current {
+		return
+	}
+
+	log.
+		WithField("from", f.current).
+		WithField("to", i).
+		Warnf("db: failing over to database %d of %d", i+1, len(f.candidates))
+	f.current = i
+}
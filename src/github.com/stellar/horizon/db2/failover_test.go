@@ -0,0 +1,133 @@
+package db2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	tdb "github.com/stellar/horizon/test/db"
+	"github.com/stellar/horizon/test/scenarios"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailover(t *testing.T) {
+	scenarios.Load(tdb.StellarCoreURL(), "base-core.sql")
+	assert := assert.New(t)
+	require := require.New(t)
+
+	open := func(p *droppingProxy) *Repo {
+		db, err := sqlx.Open("postgres", p.URL)
+		require.NoError(err)
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		return &Repo{DB: db}
+	}
+
+	primaryProxy := newDroppingProxy(t, tdb.StellarCoreURL())
+	defer primaryProxy.Close()
+	secondaryProxy := newDroppingProxy(t, tdb.StellarCoreURL())
+	defer secondaryProxy.Close()
+
+	primary := open(primaryProxy)
+	secondary := open(secondaryProxy)
+
+	positions := map[*sqlx.DB]int64{primary.DB: 10, secondary.DB: 10}
+	f := NewFailover(primary, secondary)
+	f.MaxLag = 2
+	f.Position = func(r *Repo) (int64, error) {
+		return positions[r.DB], nil
+	}
+	defer f.Close()
+
+	repo := &Repo{
+		DB:       primary.DB,
+		Retry:    &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+		Failover: f,
+	}
+
+	assert.Equal(secondary, f.Repo(1))
+
+	require.NoError(f.Check())
+	assert.Equal(0, f.Current())
+
+	// a primary that falls too far behind is failed over from, and returned to
+	// once it catches up
+	positions[primary.DB] = 7
+	require.NoError(f.Check())
+	assert.Equal(1, f.Current())
+	assert.Equal(secondary.DB, f.DB())
+
+	positions[primary.DB] = 8
+	require.NoError(f.Check())
+	assert.Equal(0, f.Current())
+
+	// reads fail over when the connection to the selected database is lost
+	var count int
+	require.NoError(repo.GetRaw(&count, "SELECT COUNT(*) FROM txhistory"))
+
+	primaryProxy.Close()
+	err := repo.GetRaw(&count, "SELECT COUNT(*) FROM txhistory")
+	assert.NoError(err)
+	assert.Equal(4, count)
+	assert.Equal(1, f.Current())
+
+	// the unreachable primary stays deselected
+	require.NoError(f.Check())
+	statuses := f.Statuses()
+	assert.False(statuses[0].Available)
+	assert.Error(statuses[0].Err)
+	assert.True(statuses[1].Available)
+	assert.True(statuses[1].Selected)
+
+	// with no database available, the selection is left alone
+	secondaryProxy.Close()
+	assert.Equal(ErrNoFailoverCandidate, f.Check())
+	assert.Equal(1, f.Current())
+}
+
+func TestFailover_Lag(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	open := func() *Repo {
+		db, err := sqlx.Open("postgres", tdb.StellarCoreURL())
+		require.NoError(err)
+		return &Repo{DB: db}
+	}
+
+	a, b, c := open(), open(), open()
+	positions := map[*sqlx.DB]int64{a.DB: 10, b.DB: 5, c.DB: 10}
+	block := make(chan struct{})
+	defer close(block)
+
+	f := NewFailover(a, b, c)
+	f.MaxLag = 2
+	f.CheckTimeout = 100 * time.Millisecond
+	f.Position = func(r *Repo) (int64, error) {
+		if positions[r.DB] < 0 {
+			<-block
+		}
+		return positions[r.DB], nil
+	}
+	defer f.Close()
+
+	require.NoError(f.Check())
+	assert.Equal(0, f.Current())
+
+	// losing the selected database skips over those lagging too far behind
+	f.Fail(a.DB, ErrTimeout)
+	assert.Equal(2, f.Current())
+
+	// a database whose position is not reported within the check's timeout
+	// is unavailable
+	positions[c.DB] = -1
+	start := time.Now()
+	require.NoError(f.Check())
+	assert.True(time.Since(start) < time.Second)
+	assert.Equal(0, f.Current())
+
+	statuses := f.Statuses()
+	assert.False(statuses[2].Available)
+	assert.Equal(ErrTimeout, statuses[2].Err)
+}
@@ -0,0 +1,99 @@
+package db2
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stellar/horizon/errors"
+)
+
+// ErrCheckTimeout is returned by Health.CheckWithin when the database does not
+// answer the check within the allowed time.  It is errors.Transient.
+var ErrCheckTimeout = errors.NewTransient("db: health check timeout")
+
+// Pinger is a database that can be checked by a Health.  *Repo is a Pinger.
+type Pinger interface {
+	Ping() error
+}
+
+// Health tracks the result of periodically pinging a database.  It is safe for
+// concurrent use, so that a single instance can be shared between the
+// background process that performs the check and any consumers (metrics,
+// failover logic, etc.) that read it.
+type Health struct {
+	lock      sync.RWMutex
+	checked   bool
+	err       error
+	checkedAt time.Time
+
+	// pending is closed once the check started by CheckWithin completes.  It
+	// is nil while no such check is running.
+	pending chan struct{}
+}
+
+// Check pings the database behind `r` and records the result.
+func (h *Health) Check(r Pinger) error {
+	err := r.Ping()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.checked = true
+	h.err = err
+	h.checkedAt = time.Now()
+
+	return err
+}
+
+// CheckWithin checks the database behind `r` as Check does, but waits at most
+// `timeout` for the database to answer, returning ErrCheckTimeout should it
+// not.  A check that times out continues in the background, recording its
+// result once the database answers, and while it does so further calls wait
+// upon it rather than pinging again, so that an unresponsive database does not
+// accumulate pings.
+func (h *Health) CheckWithin(r Pinger, timeout time.Duration) error {
+	h.lock.Lock()
+	done := h.pending
+	if done == nil {
+		done = make(chan struct{})
+		h.pending = done
+		go func() {
+			h.Check(r)
+
+			h.lock.Lock()
+			h.pending = nil
+			h.lock.Unlock()
+			close(done)
+		}()
+	}
+	h.lock.Unlock()
+
+	select {
+	case <-done:
+		return h.Err()
+	case <-time.After(timeout):
+		return ErrCheckTimeout
+	}
+}
+
+// CheckedAt returns the time of the most recent check, or the zero time if no
+// check has been performed.
+func (h *Health) CheckedAt() time.Time {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return h.checkedAt
+}
+
+// Err returns the error encountered during the most recent check, if any.
+func (h *Health) Err() error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return h.err
+}
+
+// Healthy returns true if the most recent check succeeded.  A database that has
+// not yet been checked is considered unhealthy.
+func (h *Health) Healthy() bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return h.checked && h.err == nil
+}
@@ -0,0 +1,71 @@
+package db2
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePinger answers each ping with err, once gate (when not nil) is closed.
+type fakePinger struct {
+	gate chan struct{}
+	err  error
+
+	lock  sync.Mutex
+	pings int
+}
+
+func (p *fakePinger) Ping() error {
+	p.lock.Lock()
+	p.pings++
+	p.lock.Unlock()
+
+	if p.gate != nil {
+		<-p.gate
+	}
+	return p.err
+}
+
+func (p *fakePinger) Pings() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.pings
+}
+
+func TestHealthCheckWithin(t *testing.T) {
+	var h Health
+	assert.False(t, h.Healthy())
+
+	p := &fakePinger{}
+	assert.NoError(t, h.CheckWithin(p, time.Second))
+	assert.True(t, h.Healthy())
+
+	p.err = errors.New("connection refused")
+	assert.Equal(t, p.err, h.CheckWithin(p, time.Second))
+	assert.False(t, h.Healthy())
+	assert.Equal(t, 2, p.Pings())
+}
+
+func TestHealthCheckWithin_Timeout(t *testing.T) {
+	var h Health
+	h.Check(&fakePinger{})
+
+	// an unresponsive database times out, leaving the previous result in
+	// place
+	p := &fakePinger{gate: make(chan struct{})}
+	assert.Equal(t, ErrCheckTimeout, h.CheckWithin(p, 10*time.Millisecond))
+	assert.True(t, h.Healthy())
+
+	// while the ping is outstanding, no more are made
+	assert.Equal(t, ErrCheckTimeout, h.CheckWithin(p, 10*time.Millisecond))
+	assert.Equal(t, 1, p.Pings())
+
+	// once the database answers, its answer is recorded
+	p.err = errors.New("too many connections")
+	close(p.gate)
+	assert.Equal(t, p.err, h.CheckWithin(p, time.Second))
+	assert.False(t, h.Healthy())
+}
@@ -0,0 +1,170 @@
+package history
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	sq "github.com/lann/squirrel"
+	"github.com/stellar/horizon/db2"
+)
+
+// The orderings of asset stats accepted by AssetStatsQ.Page.
+const (
+	// AssetStatsOrderByAsset orders asset stats by code, then issuer.
+	AssetStatsOrderByAsset = "asset"
+
+	// AssetStatsOrderByHolders orders asset stats by the number of accounts
+	// holding the asset, then by code and issuer.
+	AssetStatsOrderByHolders = "holders"
+
+	// AssetStatsOrderByAmount orders asset stats by the amount of the asset
+	// issued, then by code and issuer.
+	AssetStatsOrderByAmount = "amount"
+)
+
+// AssetStats provides a helper to filter rows from the `asset_stats` table
+// with pre-defined filters.  See `AssetStatsQ` methods for the available
+// filters.
+func (q *Q) AssetStats() *AssetStatsQ {
+	return &AssetStatsQ{
+		parent: q,
+		sql:    selectAssetStat,
+	}
+}
+
+// AssetStatsLedger loads the ledger as of which the `asset_stats` table is
+// current into `dest`, which is zero while the table awaits being built.
+func (q *Q) AssetStatsLedger(dest *int32) error {
+	return q.GetRaw(dest, `SELECT COALESCE(MAX(ledger_sequence), 0) FROM asset_stats_ledger`)
+}
+
+// PagingToken returns a cursor for this row when ordered by `orderBy`.  It is
+// made up of the row's code and issuer, separated by an underscore, preceded
+// by the value ordered by when that is not the asset itself.
+func (r AssetStat) PagingToken(orderBy string) string {
+	token := r.AssetCode + "_" + r.AssetIssuer
+
+	switch orderBy {
+	case AssetStatsOrderByHolders:
+		return fmt.Sprintf("%d_%s", r.NumAccounts, token)
+	case AssetStatsOrderByAmount:
+		return fmt.Sprintf("%d_%s", r.Amount, token)
+	default:
+		return token
+	}
+}
+
+// ForCode filters the query to only the assets with the code `code`.
+func (q *AssetStatsQ) ForCode(code string) *AssetStatsQ {
+	q.sql = q.sql.Where("ast.asset_code = ?", code)
+	return q
+}
+
+// ForIssuer filters the query to only the assets issued by `issuer`.
+func (q *AssetStatsQ) ForIssuer(issuer string) *AssetStatsQ {
+	q.sql = q.sql.Where("ast.asset_issuer = ?", issuer)
+	return q
+}
+
+// Page specifies the paging constraints for the query being built by `q`,
+// whose rows are ordered by `orderBy`, one of the AssetStatsOrderBy constants.
+// The page's cursor, if any, must be a paging token of a row ordered the same
+// way.
+func (q *AssetStatsQ) Page(page db2.PageQuery, orderBy string) *AssetStatsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	cols := []string{"ast.asset_code", "ast.asset_issuer"}
+	switch orderBy {
+	case AssetStatsOrderByAsset:
+	case AssetStatsOrderByHolders:
+		cols = append([]string{"ast.num_accounts"}, cols...)
+	case AssetStatsOrderByAmount:
+		cols = append([]string{"ast.amount"}, cols...)
+	default:
+		q.Err = errors.Errorf("invalid order by: %s", orderBy)
+		return q
+	}
+
+	var op, dir string
+	switch page.Order {
+	case db2.OrderAscending:
+		op, dir = ">", "asc"
+	case db2.OrderDescending:
+		op, dir = "<", "desc"
+	default:
+		q.Err = errors.New(db2.ErrInvalidOrder)
+		return q
+	}
+
+	if page.Cursor != "" {
+		values, err := parseAssetStatCursor(page.Cursor, len(cols))
+		if err != nil {
+			q.Err = err
+			return q
+		}
+
+		placeholders := strings.Repeat(", ?", len(cols))[2:]
+		q.sql = q.sql.Where(
+			fmt.Sprintf("(%s) %s (%s)", strings.Join(cols, ", "), op, placeholders),
+			values...,
+		)
+	}
+
+	for _, col := range cols {
+		q.sql = q.sql.OrderBy(col + " " + dir)
+	}
+
+	q.sql = q.sql.Limit(page.Limit)
+	return q
+}
+
+// Select loads the results of the query specified by `q` into `dest`.
+func (q *AssetStatsQ) Select(dest interface{}) error {
+	if q.Err != nil {
+		return q.Err
+	}
+
+	q.Err = q.parent.Select(dest, q.sql)
+	return q.Err
+}
+
+// parseAssetStatCursor splits `cursor`, a paging token made up of `n` parts,
+// into the values it was made from.
+func parseAssetStatCursor(cursor string, n int) ([]interface{}, error) {
+	parts := strings.Split(cursor, "_")
+	if len(parts) != n {
+		return nil, errors.New(db2.ErrInvalidCursor)
+	}
+
+	values := make([]interface{}, n)
+	for i, part := range parts {
+		if part == "" {
+			return nil, errors.New(db2.ErrInvalidCursor)
+		}
+		values[i] = part
+	}
+
+	// a leading part is the number ordered by
+	if n > 2 {
+		v, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || v < 0 {
+			return nil, errors.New(db2.ErrInvalidCursor)
+		}
+		values[0] = v
+	}
+
+	return values, nil
+}
+
+var selectAssetStat = sq.Select(
+	"ast.asset_type",
+	"ast.asset_code",
+	"ast.asset_issuer",
+	"ast.amount",
+	"ast.num_accounts",
+	"ast.flags",
+).From("asset_stats ast")
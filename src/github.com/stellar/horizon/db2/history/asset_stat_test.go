@@ -0,0 +1,78 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/test"
+)
+
+func TestAssetStatQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("paths")
+	defer tt.Finish()
+	q := &Q{tt.HorizonRepo()}
+
+	const issuer = "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN"
+
+	// ordered by asset
+	var stats []AssetStat
+	err := q.AssetStats().
+		Page(db2.MustPageQuery("", "asc", 3), AssetStatsOrderByAsset).
+		Select(&stats)
+	if tt.Assert.NoError(err) && tt.Assert.Len(stats, 3) {
+		tt.Assert.Equal("1", stats[0].AssetCode)
+		tt.Assert.Equal("21", stats[1].AssetCode)
+		tt.Assert.Equal("22", stats[2].AssetCode)
+		tt.Assert.Equal("22_"+issuer, stats[2].PagingToken(AssetStatsOrderByAsset))
+	}
+
+	err = q.AssetStats().
+		Page(db2.MustPageQuery(stats[2].PagingToken(AssetStatsOrderByAsset), "asc", 10), AssetStatsOrderByAsset).
+		Select(&stats)
+	if tt.Assert.NoError(err) && tt.Assert.Len(stats, 5) {
+		tt.Assert.Equal("31", stats[0].AssetCode)
+	}
+
+	// ordered by holders
+	err = q.AssetStats().
+		ForIssuer(issuer).
+		Page(db2.MustPageQuery("", "desc", 2), AssetStatsOrderByHolders).
+		Select(&stats)
+	if tt.Assert.NoError(err) && tt.Assert.Len(stats, 2) {
+		tt.Assert.Equal("USD", stats[0].AssetCode)
+		tt.Assert.Equal("EUR", stats[1].AssetCode)
+		tt.Assert.Equal(int32(2), stats[1].NumAccounts)
+		tt.Assert.Equal("2_EUR_"+issuer, stats[1].PagingToken(AssetStatsOrderByHolders))
+	}
+
+	err = q.AssetStats().
+		Page(db2.MustPageQuery("2_EUR_"+issuer, "desc", 1), AssetStatsOrderByHolders).
+		Select(&stats)
+	if tt.Assert.NoError(err) && tt.Assert.Len(stats, 1) {
+		tt.Assert.Equal("33", stats[0].AssetCode)
+		tt.Assert.Equal(int32(1), stats[0].NumAccounts)
+	}
+
+	// filtered by code
+	err = q.AssetStats().
+		ForCode("USD").
+		Page(db2.MustPageQuery("", "asc", 10), AssetStatsOrderByAmount).
+		Select(&stats)
+	if tt.Assert.NoError(err) && tt.Assert.Len(stats, 1) {
+		tt.Assert.Equal("credit_alphanum4", stats[0].AssetType)
+		tt.Assert.Equal(int64(50000000000), stats[0].Amount)
+	}
+
+	// invalid cursors
+	for _, cursor := range []string{"USD", "2_USD_" + issuer, "_" + issuer} {
+		err = q.AssetStats().
+			Page(db2.MustPageQuery(cursor, "asc", 10), AssetStatsOrderByAsset).
+			Select(&stats)
+		tt.Assert.Error(err, cursor)
+	}
+
+	err = q.AssetStats().
+		Page(db2.MustPageQuery("many_USD_"+issuer, "asc", 10), AssetStatsOrderByHolders).
+		Select(&stats)
+	tt.Assert.Error(err)
+}
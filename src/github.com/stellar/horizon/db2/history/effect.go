@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/go-errors/errors"
 	sq "github.com/lann/squirrel"
@@ -46,6 +47,21 @@ func (q *Q) Effects() *EffectsQ {
 	}
 }
 
+// SignerChanges provides a helper to filter the signer effects, those that
+// record an account gaining, losing or reweighting a signer, along with the
+// transaction and ledger in which each occurred.  Load the results into a
+// slice of SignerChange.
+func (q *Q) SignerChanges() *EffectsQ {
+	return &EffectsQ{
+		parent: q,
+		sql: selectSignerChange.Where(sq.Eq{"heff.type": []EffectType{
+			EffectSignerCreated,
+			EffectSignerRemoved,
+			EffectSignerUpdated,
+		}}),
+	}
+}
+
 // ForAccount filters the operations collection to a specific account
 func (q *EffectsQ) ForAccount(aid string) *EffectsQ {
 	var account Account
@@ -59,6 +75,33 @@ func (q *EffectsQ) ForAccount(aid string) *EffectsQ {
 	return q
 }
 
+// ForAsset filters the query to only effects whose details name the asset
+// `a`:  the effects crediting or debiting the asset, those of trustlines to
+// it being created, updated, removed, authorized or deauthorized, and trades
+// in which it was either sold or bought.
+func (q *EffectsQ) ForAsset(a xdr.Asset) *EffectsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var (
+		clauses []string
+		args    []interface{}
+	)
+	for _, prefix := range []string{"", "sold_", "bought_"} {
+		clause, cargs, err := assetDetailsClause(a, prefix)
+		if err != nil {
+			q.Err = err
+			return q
+		}
+		clauses = append(clauses, clause)
+		args = append(args, cargs...)
+	}
+
+	q.sql = q.sql.Where("("+strings.Join(clauses, " OR ")+")", args...)
+	return q
+}
+
 // ForLedger filters the query to only effects in a specific ledger,
 // specified by its sequence.
 func (q *EffectsQ) ForLedger(seq int32) *EffectsQ {
@@ -136,6 +179,16 @@ func (q *EffectsQ) OfType(typ EffectType) *EffectsQ {
 	return q
 }
 
+// ForTypes filters the query to only effects of any of the given types.
+func (q *EffectsQ) ForTypes(types []EffectType) *EffectsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where(sq.Eq{"heff.type": types})
+	return q
+}
+
 // Page specifies the paging constraints for the query being built by `q`.
 func (q *EffectsQ) Page(page db2.PageQuery) *EffectsQ {
 	if q.Err != nil {
@@ -189,10 +242,32 @@ func (q *EffectsQ) Select(dest interface{}) error {
 
 // OfType filters the query to only effects of the given type.
 func (q *EffectsQ) orderBookFilter(a xdr.Asset, prefix string) {
+	q.sql, q.Err = assetDetailsFilter(q.sql, a, prefix)
+}
+
+// assetDetailsFilter filters `sql` to only effects whose details name the
+// asset `a` in the fields beginning with `prefix`.
+func assetDetailsFilter(
+	sql sq.SelectBuilder,
+	a xdr.Asset,
+	prefix string,
+) (sq.SelectBuilder, error) {
+	clause, args, err := assetDetailsClause(a, prefix)
+	if err != nil {
+		return sql, err
+	}
+
+	return sql.Where(clause, args...), nil
+}
+
+// assetDetailsClause returns the condition, and its arguments, matching the
+// effects whose details name the asset `a` in the fields beginning with
+// `prefix`.
+func assetDetailsClause(a xdr.Asset, prefix string) (string, []interface{}, error) {
 	var typ, code, iss string
-	q.Err = a.Extract(&typ, &code, &iss)
-	if q.Err != nil {
-		return
+	err := a.Extract(&typ, &code, &iss)
+	if err != nil {
+		return "", nil, err
 	}
 
 	if a.Type == xdr.AssetTypeAssetTypeNative {
@@ -200,18 +275,23 @@ func (q *EffectsQ) orderBookFilter(a xdr.Asset, prefix string) {
 				(heff.details->>'%sasset_type' = ?
 		AND heff.details ?? '%sasset_code' = false
 		AND heff.details ?? '%sasset_issuer' = false)`, prefix, prefix, prefix)
-		q.sql = q.sql.Where(clause, typ)
-		return
+		return clause, []interface{}{typ}, nil
 	}
 
 	clause := fmt.Sprintf(`
 		(heff.details->>'%sasset_type' = ?
 	AND heff.details->>'%sasset_code' = ?
 	AND heff.details->>'%sasset_issuer' = ?)`, prefix, prefix, prefix)
-	q.sql = q.sql.Where(clause, typ, code, iss)
+	return clause, []interface{}{typ, code, iss}, nil
 }
 
 var selectEffect = sq.
 	Select("heff.*, hacc.address").
 	From("history_effects heff").
 	LeftJoin("history_accounts hacc ON hacc.id = heff.history_account_id")
+
+var selectSignerChange = selectEffect.
+	Columns("ht.transaction_hash, ht.ledger_sequence, hl.closed_at").
+	Join("history_operations hop ON hop.id = heff.history_operation_id").
+	Join("history_transactions ht ON ht.id = hop.transaction_id").
+	Join("history_ledgers hl ON hl.sequence = ht.ledger_sequence")
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/assets"
+	"github.com/stellar/horizon/test"
+)
+
+func TestEffectsQ_ForAsset(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	q := &Q{tt.HorizonRepo()}
+
+	asset := func(canonical string) xdr.Asset {
+		a, err := assets.ParseCanonical(canonical)
+		tt.Require.NoError(err)
+		return a
+	}
+	native := asset("native")
+
+	cases := []struct {
+		Name     string
+		Asset    xdr.Asset
+		Expected map[EffectType]int
+	}{
+		{
+			Name:  "credits and debits",
+			Asset: asset("USD:GCHC4D2CS45CJRNN4QAHT2LFZAJIU5PA7H53K3VOP6WEJ6XWHNSNZKQG"),
+			Expected: map[EffectType]int{
+				EffectAccountCredited:  1,
+				EffectAccountDebited:   1,
+				EffectTrustlineCreated: 1,
+			},
+		},
+		{
+			Name:  "trustline changes",
+			Asset: asset("USD:GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+			Expected: map[EffectType]int{
+				EffectTrustlineCreated: 1,
+				EffectTrustlineRemoved: 1,
+				EffectTrustlineUpdated: 2,
+			},
+		},
+		{
+			Name:  "trustline authorizations",
+			Asset: asset("EUR:GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF"),
+			Expected: map[EffectType]int{
+				EffectTrustlineCreated:      1,
+				EffectTrustlineAuthorized:   1,
+				EffectTrustlineDeauthorized: 1,
+			},
+		},
+		{
+			Name:  "trades",
+			Asset: asset("USD:GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD"),
+			Expected: map[EffectType]int{
+				EffectTrustlineCreated: 1,
+				EffectTrade:            2,
+			},
+		},
+		{
+			Name:  "native",
+			Asset: native,
+			Expected: map[EffectType]int{
+				EffectAccountCredited: 13,
+				EffectAccountDebited:  36,
+				EffectTrade:           8,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		var effects []Effect
+		err := q.Effects().
+			ForAsset(c.Asset).
+			Select(&effects)
+		if !tt.Assert.NoError(err, c.Name) {
+			continue
+		}
+
+		actual := map[EffectType]int{}
+		for _, effect := range effects {
+			actual[effect.Type]++
+		}
+		tt.Assert.Equal(c.Expected, actual, c.Name)
+	}
+
+	// combined with the types filter
+	var trades []Effect
+	err := q.Effects().
+		ForAsset(native).
+		ForTypes([]EffectType{EffectTrade}).
+		Select(&trades)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(trades, 8)
+	}
+}
@@ -1,8 +1,13 @@
 package history
 
 import (
+	"fmt"
+	"time"
+
 	sq "github.com/lann/squirrel"
 	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/toid"
 )
 
 // LedgerBySequence loads the single ledger at `seq` into `dest`
@@ -14,6 +19,134 @@ func (q *Q) LedgerBySequence(dest interface{}, seq int32) error {
 	return q.Get(dest, sql)
 }
 
+// LedgerByCloseTime loads into `dest` the latest ledger that closed at or
+// before `at`.  sql.ErrNoRows is returned if no such ledger has been ingested.
+func (q *Q) LedgerByCloseTime(dest interface{}, at time.Time) error {
+	sql := selectLedger.
+		Where("hl.closed_at <= ?", at.UTC()).
+		OrderBy("hl.closed_at DESC", "hl.sequence DESC").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// LedgerAfterCloseTime loads into `dest` the earliest ledger that closed after
+// `at`.  sql.ErrNoRows is returned if no such ledger has been ingested.
+func (q *Q) LedgerAfterCloseTime(dest interface{}, at time.Time) error {
+	sql := selectLedger.
+		Where("hl.closed_at > ?", at.UTC()).
+		OrderBy("hl.closed_at ASC", "hl.sequence ASC").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// OperationTotalBefore loads into `dest`, a *null.Int, the number of
+// operations in the ledgers preceding `seq`:  the sum of their operation
+// counts, which is null unless the history database holds every ledger from
+// the network's first up to `seq`.
+func (q *Q) OperationTotalBefore(dest interface{}, seq int32) error {
+	return q.GetRaw(dest, `
+		SELECT CASE WHEN COUNT(*) = $1 THEN COALESCE(SUM(operation_count), 0) END
+		FROM history_ledgers
+		WHERE sequence < $2
+	`, seq-1, seq)
+}
+
+// ShiftLedgerTotals adds `delta` to the running total in `column`, one of
+// "total_accounts", "total_trustlines" or "total_operations", of each ledger
+// that follows `after` and whose total was carried forward from it:  those up
+// to, but excluding, the first whose total is null or whose previous ledger is
+// missing, since the totals from there on were recovered from an aggregate.
+func (q *Q) ShiftLedgerTotals(column string, after int32, delta int64) error {
+	switch column {
+	case "total_accounts", "total_trustlines", "total_operations":
+	default:
+		return fmt.Errorf("not a ledger total: %s", column)
+	}
+
+	_, err := q.ExecRaw(fmt.Sprintf(`
+		UPDATE history_ledgers SET %[1]s = %[1]s + $1
+		WHERE sequence > $2 AND sequence < COALESCE((
+			SELECT MIN(hl.sequence) FROM history_ledgers hl
+			WHERE hl.sequence > $2 AND (
+				hl.%[1]s IS NULL OR NOT EXISTS (
+					SELECT 1 FROM history_ledgers prev
+					WHERE prev.sequence = hl.sequence - 1
+				)
+			)
+		), 2147483647)
+	`, column), delta, after)
+	return err
+}
+
+// LedgerRowCountsBySequence loads into `dest` the counts recorded by the
+// ledger at `seq`, and the number of rows stored for it, from which a ledger
+// that was not fully ingested can be told.  Failed transactions, which are
+// not counted by the ledger, are not counted among its rows either.
+// sql.ErrNoRows is returned if the ledger has not been ingested.
+func (q *Q) LedgerRowCountsBySequence(dest *LedgerRowCounts, seq int32) error {
+	start := toid.ID{LedgerSequence: seq}
+	end := toid.ID{LedgerSequence: seq + 1}
+
+	return q.GetRaw(dest, `
+		SELECT
+			hl.transaction_count,
+			hl.operation_count,
+			(
+				SELECT COUNT(*) FROM history_transactions
+				WHERE id >= $2 AND id < $3 AND successful IS NOT FALSE
+			) AS transactions,
+			(
+				SELECT COUNT(*) FROM history_operations
+				WHERE id >= $2 AND id < $3
+			) AS operations
+		FROM history_ledgers hl
+		WHERE hl.sequence = $1
+	`, seq, start.ToInt64(), end.ToInt64())
+}
+
+// LedgerState loads horizon's side of the ledger state into `dest` using a
+// single query:  the elder and latest ledgers (see ElderLedger and
+// LatestLedger), and the hash and close time of the latest ledger.  The other
+// fields of `dest` are untouched.
+func (q *Q) LedgerState(dest *ledger.State) error {
+	var row struct {
+		Elder          int32      `db:"elder"`
+		Latest         int32      `db:"latest"`
+		LatestHash     string     `db:"latest_hash"`
+		LatestClosedAt *time.Time `db:"latest_closed_at"`
+	}
+
+	err := q.GetRaw(&row, `
+		SELECT
+			COALESCE((SELECT MIN(sequence) FROM history_ledgers), 0) AS elder,
+			COALESCE(latest.sequence, 0) AS latest,
+			COALESCE(latest.ledger_hash, '') AS latest_hash,
+			latest.closed_at AS latest_closed_at
+		FROM (SELECT 1) AS one
+		LEFT JOIN (
+			SELECT sequence, ledger_hash, closed_at
+			FROM history_ledgers
+			ORDER BY sequence DESC
+			LIMIT 1
+		) AS latest ON true
+	`)
+	if err != nil {
+		return err
+	}
+
+	dest.HistoryElder = row.Elder
+	dest.HistoryLatest = row.Latest
+	dest.HistoryLatestHash = row.LatestHash
+	dest.HistoryLatestClosedAt = time.Time{}
+	if row.LatestClosedAt != nil {
+		dest.HistoryLatestClosedAt = row.LatestClosedAt.UTC()
+	}
+
+	return nil
+}
+
 // Ledgers provides a helper to filter rows from the `history_ledgers` table
 // with pre-defined filters.  See `LedgersQ` methods for the available filters.
 func (q *Q) Ledgers() *LedgersQ {
@@ -59,4 +192,10 @@ var selectLedger = sq.Select(
 	"hl.base_fee",
 	"hl.base_reserve",
 	"hl.max_tx_set_size",
+	"hl.total_accounts",
+	"hl.total_trustlines",
+	"hl.total_operations",
+	"hl.protocol_version",
+	"hl.failed_transaction_count",
+	"hl.ingest_duration_ms",
 ).From("history_ledgers hl")
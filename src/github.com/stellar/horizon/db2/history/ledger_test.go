@@ -3,7 +3,10 @@ package history
 import (
 	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/ledger"
 	"github.com/stellar/horizon/test"
 )
 
@@ -27,4 +30,131 @@ func TestLedgerQueries(t *testing.T) {
 	if tt.Assert.NoError(err) {
 		tt.Assert.Len(ls, 3)
 	}
+
+	// Test LedgerByCloseTime
+	at := time.Date(2016, 6, 29, 16, 33, 54, 500000000, time.UTC)
+	err = q.LedgerByCloseTime(&l, at)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(2), l.Sequence)
+	}
+
+	err = q.LedgerByCloseTime(&l, at.In(time.FixedZone("UTC+2", 2*60*60)).Add(time.Second))
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(3), l.Sequence)
+	}
+
+	err = q.LedgerByCloseTime(&l, time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC))
+	tt.Assert.Equal(err, sql.ErrNoRows)
+
+	// Test LedgerAfterCloseTime
+	err = q.LedgerAfterCloseTime(&l, at)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(3), l.Sequence)
+	}
+
+	err = q.LedgerAfterCloseTime(&l, time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC))
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(1), l.Sequence)
+	}
+
+	err = q.LedgerAfterCloseTime(&l, at.Add(time.Second))
+	tt.Assert.Equal(err, sql.ErrNoRows)
+
+	// running totals
+	err = q.LedgerBySequence(&l, 2)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int64(4), l.TotalAccounts.Int64)
+		tt.Assert.Equal(int64(0), l.TotalTrustlines.Int64)
+		tt.Assert.Equal(int64(3), l.TotalOperations.Int64)
+	}
+}
+
+func TestLedgerUpgradesBySequence(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonRepo()}
+
+	var upgrades []LedgerUpgrade
+	err := q.LedgerUpgradesBySequence(&upgrades, 2)
+	tt.Require.NoError(err)
+
+	if tt.Assert.Len(upgrades, 2) {
+		tt.Assert.Equal(int32(1), upgrades[0].ApplicationOrder)
+		tt.Assert.Equal(xdr.LedgerUpgradeTypeLedgerUpgradeVersion, upgrades[0].Type)
+		tt.Assert.Equal(int64(2), upgrades[0].Value)
+		tt.Assert.Equal(int32(2), upgrades[1].ApplicationOrder)
+		tt.Assert.Equal(xdr.LedgerUpgradeTypeLedgerUpgradeMaxTxSetSize, upgrades[1].Type)
+		tt.Assert.Equal(int64(10000), upgrades[1].Value)
+	}
+
+	err = q.LedgerUpgradesBySequence(&upgrades, 3)
+	tt.Require.NoError(err)
+	tt.Assert.Len(upgrades, 0)
+}
+
+func TestLedgerState(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonRepo()}
+
+	var latest Ledger
+	err := q.LedgerBySequence(&latest, 3)
+	tt.Require.NoError(err)
+
+	// other fields are untouched
+	state := ledger.State{CoreLatest: 7}
+	err = q.LedgerState(&state)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(1), state.HistoryElder)
+		tt.Assert.Equal(int32(3), state.HistoryLatest)
+		tt.Assert.Equal(latest.LedgerHash, state.HistoryLatestHash)
+		tt.Assert.True(latest.ClosedAt.Equal(state.HistoryLatestClosedAt))
+		tt.Assert.Equal(int32(7), state.CoreLatest)
+	}
+
+	// an empty database
+	_, err = tt.HorizonRepo().ExecRaw(`DELETE FROM history_ledgers`)
+	tt.Require.NoError(err)
+
+	state = ledger.State{}
+	err = q.LedgerState(&state)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(ledger.State{}, state)
+	}
+}
+
+func TestLedgersInVersionRange(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonRepo()}
+
+	_, err := tt.HorizonRepo().ExecRaw(`
+		UPDATE history_ledgers
+		SET importer_version = sequence + 3`)
+	tt.Require.NoError(err)
+
+	// ledgers 1, 2 and 3 were ingested by versions 4, 5 and 6
+	var seqs []int32
+	err = q.LedgersInVersionRange(&seqs, 5, 6, 0)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal([]int32{2, 3}, seqs)
+	}
+
+	seqs = nil
+	err = q.LedgersInVersionRange(&seqs, 4, 4, 0)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal([]int32{1}, seqs)
+	}
+
+	seqs = nil
+	err = q.LedgersInVersionRange(&seqs, 4, 6, 2)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal([]int32{3}, seqs)
+	}
+
+	seqs = nil
+	err = q.LedgersInVersionRange(&seqs, 7, 10, 0)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Empty(seqs)
+	}
 }
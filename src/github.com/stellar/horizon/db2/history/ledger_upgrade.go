@@ -0,0 +1,23 @@
+package history
+
+import (
+	sq "github.com/lann/squirrel"
+)
+
+// LedgerUpgradesBySequence loads the upgrades applied to the ledger at `seq`
+// into `dest`, in the order they were applied.
+func (q *Q) LedgerUpgradesBySequence(dest interface{}, seq int32) error {
+	sql := selectLedgerUpgrade.
+		Where("hlu.ledger_sequence = ?", seq).
+		OrderBy("hlu.application_order asc")
+
+	return q.Select(dest, sql)
+}
+
+var selectLedgerUpgrade = sq.Select(
+	"hlu.history_ledger_id",
+	"hlu.ledger_sequence",
+	"hlu.application_order",
+	"hlu.type",
+	"hlu.value",
+).From("history_ledger_upgrades hlu")
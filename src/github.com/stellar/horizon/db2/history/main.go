@@ -102,6 +102,12 @@ const (
 type Account struct {
 	ID      int64
 	Address string `db:"address"`
+
+	// CreatedLedger and CreatedAt are the sequence and close time of the
+	// ledger in which the account was first created, null when its creation
+	// has not been ingested.
+	CreatedLedger null.Int   `db:"created_ledger"`
+	CreatedAt     *time.Time `db:"created_at"`
 }
 
 // AccountsQ is a helper struct to aid in configuring queries that loads
@@ -112,6 +118,25 @@ type AccountsQ struct {
 	sql    sq.SelectBuilder
 }
 
+// AssetStat is a row of data from the `asset_stats` table, the stats of a
+// non-native asset maintained during ingestion.
+type AssetStat struct {
+	AssetType   string `db:"asset_type"`
+	AssetCode   string `db:"asset_code"`
+	AssetIssuer string `db:"asset_issuer"`
+	Amount      int64  `db:"amount"`
+	NumAccounts int32  `db:"num_accounts"`
+	Flags       int32  `db:"flags"`
+}
+
+// AssetStatsQ is a helper struct to aid in configuring queries that loads
+// slices of asset stat structs.
+type AssetStatsQ struct {
+	Err    error
+	parent *Q
+	sql    sq.SelectBuilder
+}
+
 // Effect is a row of data from the `history_effects` table
 type Effect struct {
 	HistoryAccountID   int64       `db:"history_account_id"`
@@ -151,6 +176,43 @@ type Ledger struct {
 	BaseFee            int32       `db:"base_fee"`
 	BaseReserve        int32       `db:"base_reserve"`
 	MaxTxSetSize       int32       `db:"max_tx_set_size"`
+
+	// TotalAccounts, TotalTrustlines and TotalOperations are running totals
+	// for the network as of the ledger, maintained during ingestion.  They are
+	// null when unknown, for example for ledgers ingested by older versions of
+	// horizon.
+	TotalAccounts   null.Int `db:"total_accounts"`
+	TotalTrustlines null.Int `db:"total_trustlines"`
+	TotalOperations null.Int `db:"total_operations"`
+
+	// ProtocolVersion, FailedTransactionCount and IngestDurationMs are
+	// recorded by ingestion, and are null for ledgers ingested by older
+	// versions of horizon.  IngestDurationMs is the time, in milliseconds,
+	// horizon took to load the ledger from stellar-core and write its history.
+	ProtocolVersion        null.Int `db:"protocol_version"`
+	FailedTransactionCount null.Int `db:"failed_transaction_count"`
+	IngestDurationMs       null.Int `db:"ingest_duration_ms"`
+}
+
+// LedgerRowCounts is a ledger's transaction and operation counts, as recorded
+// in its `history_ledgers` row, alongside the number of successful
+// transactions and of operations stored for the ledger.
+type LedgerRowCounts struct {
+	TransactionCount int32 `db:"transaction_count"`
+	OperationCount   int32 `db:"operation_count"`
+	Transactions     int32 `db:"transactions"`
+	Operations       int32 `db:"operations"`
+}
+
+// LedgerUpgrade is a row of data from the `history_ledger_upgrades` table,
+// recording a single change to a network parameter applied when a ledger
+// closed.
+type LedgerUpgrade struct {
+	LedgerID         int64                 `db:"history_ledger_id"`
+	LedgerSequence   int32                 `db:"ledger_sequence"`
+	ApplicationOrder int32                 `db:"application_order"`
+	Type             xdr.LedgerUpgradeType `db:"type"`
+	Value            int64                 `db:"value"`
 }
 
 // LedgersQ is a helper struct to aid in configuring queries that loads
@@ -170,6 +232,10 @@ type Operation struct {
 	Type             xdr.OperationType `db:"type"`
 	DetailsString    null.String       `db:"details"`
 	SourceAccount    string            `db:"source_account"`
+
+	// TxResult is the base64 encoded xdr.TransactionResult of the operation's
+	// transaction, from which the operation's own result is decoded.
+	TxResult null.String `db:"tx_result"`
 }
 
 // OperationsQ is a helper struct to aid in configuring queries that loads
@@ -186,12 +252,56 @@ type Q struct {
 	*db2.Repo
 }
 
+// AccountMerge is an account_merge operation, joined with the ledger in which
+// it was applied.
+type AccountMerge struct {
+	Operation
+	LedgerSequence int32     `db:"ledger_sequence"`
+	LedgerClosedAt time.Time `db:"closed_at"`
+}
+
+// SignerChange is a signer effect, joined with the transaction and ledger in
+// which it occurred.
+type SignerChange struct {
+	Effect
+	TransactionHash string    `db:"transaction_hash"`
+	LedgerSequence  int32     `db:"ledger_sequence"`
+	LedgerClosedAt  time.Time `db:"closed_at"`
+}
+
 // TotalOrderID represents the ID portion of rows that are identified by the
 // "TotalOrderID".  See total_order_id.go in the `db` package for details.
 type TotalOrderID struct {
 	ID int64 `db:"id"`
 }
 
+// TradeAggregation is a bucket of the trades of an asset pair made within a
+// span of time.  Each price of the bucket is given by the amounts, in units of
+// the base and counter asset, of the trade made at that price.
+type TradeAggregation struct {
+	Timestamp          int64  `db:"timestamp"`
+	TradeCount         int32  `db:"trade_count"`
+	BaseVolume         string `db:"base_volume"`
+	CounterVolume      string `db:"counter_volume"`
+	OpenBaseAmount     string `db:"open_base_amount"`
+	OpenCounterAmount  string `db:"open_counter_amount"`
+	HighBaseAmount     string `db:"high_base_amount"`
+	HighCounterAmount  string `db:"high_counter_amount"`
+	LowBaseAmount      string `db:"low_base_amount"`
+	LowCounterAmount   string `db:"low_counter_amount"`
+	CloseBaseAmount    string `db:"close_base_amount"`
+	CloseCounterAmount string `db:"close_counter_amount"`
+}
+
+// TradeAggregationsQ is a helper struct to aid in configuring queries that
+// loads slices of trade aggregation structs.
+type TradeAggregationsQ struct {
+	Err        error
+	parent     *Q
+	sql        sq.SelectBuilder
+	resolution int64
+}
+
 // Transaction is a row of data from the `history_transactions` table
 type Transaction struct {
 	TotalOrderID
@@ -212,16 +322,37 @@ type Transaction struct {
 	Memo             null.String `db:"memo"`
 	ValidAfter       null.Int    `db:"valid_after"`
 	ValidBefore      null.Int    `db:"valid_before"`
+	Successful       bool        `db:"successful"`
 	CreatedAt        time.Time   `db:"created_at"`
 	UpdatedAt        time.Time   `db:"updated_at"`
 }
 
+// TransactionMeta is the subset of a row from the `history_transactions`
+// table that records the outcome of applying a transaction, as raw XDR.
+type TransactionMeta struct {
+	TransactionHash string `db:"transaction_hash"`
+	LedgerSequence  int32  `db:"ledger_sequence"`
+	TxResult        string `db:"tx_result"`
+	TxMeta          string `db:"tx_meta"`
+	TxFeeMeta       string `db:"tx_fee_meta"`
+}
+
+// TransactionLedger is the subset of a row from the `history_transactions`
+// table that records which ledger included a transaction, and whether it
+// succeeded.
+type TransactionLedger struct {
+	TransactionHash string `db:"transaction_hash"`
+	LedgerSequence  int32  `db:"ledger_sequence"`
+	Successful      bool   `db:"successful"`
+}
+
 // TransactionsQ is a helper struct to aid in configuring queries that loads
 // slices of transaction structs.
 type TransactionsQ struct {
-	Err    error
-	parent *Q
-	sql    sq.SelectBuilder
+	Err           error
+	parent        *Q
+	sql           sq.SelectBuilder
+	includeFailed bool
 }
 
 // ElderLedger loads the oldest ledger known to the history database
@@ -244,3 +375,25 @@ func (q *Q) OldestOutdatedLedgers(dest interface{}, currentVersion int) error {
 		ORDER BY sequence ASC
 		LIMIT 1000000`, currentVersion)
 }
+
+// HasOutdatedLedgers loads into `dest` whether any ledger was ingested by a
+// version of the importer older than `version`.
+func (q *Q) HasOutdatedLedgers(dest *bool, version int) error {
+	return q.GetRaw(dest, `
+		SELECT EXISTS (
+			SELECT 1 FROM history_ledgers WHERE importer_version < $1
+		)`, version)
+}
+
+// LedgersInVersionRange populates a slice of ints with the first million
+// ledgers after `after` that were ingested by versions `minVersion` through
+// `maxVersion` of the importer, inclusive.
+func (q *Q) LedgersInVersionRange(dest interface{}, minVersion, maxVersion int, after int32) error {
+	return q.SelectRaw(dest, `
+		SELECT sequence
+		FROM history_ledgers
+		WHERE importer_version BETWEEN $1 AND $2
+		AND sequence > $3
+		ORDER BY sequence ASC
+		LIMIT 1000000`, minVersion, maxVersion, after)
+}
@@ -24,6 +24,32 @@ func (r *Operation) UnmarshalDetails(dest interface{}) error {
 	return err
 }
 
+// Result decodes this operation's result from the result of its transaction.
+// `ok` is false if the transaction's result was not loaded or does not record
+// a result for the operation.
+func (r *Operation) Result() (result xdr.OperationResult, ok bool, err error) {
+	if !r.TxResult.Valid {
+		return
+	}
+
+	var txr xdr.TransactionResult
+	err = xdr.SafeUnmarshalBase64(r.TxResult.String, &txr)
+	if err != nil {
+		err = errors.Wrap(err, 1)
+		return
+	}
+
+	results, ok := txr.Result.GetResults()
+	idx := int(r.ApplicationOrder) - 1
+	if !ok || idx < 0 || idx >= len(results) {
+		ok = false
+		return
+	}
+
+	result = results[idx]
+	return
+}
+
 // Operations provides a helper to filter the operations table with pre-defined
 // filters.  See `OperationsQ` for the available filters.
 func (q *Q) Operations() *OperationsQ {
@@ -33,6 +59,39 @@ func (q *Q) Operations() *OperationsQ {
 	}
 }
 
+// AccountMergeByAddress loads the most recent account_merge operation that
+// merged the account `addy` away into `dest`.  The operations are found through
+// the account's participation in them, so that the lookup is served by the
+// participants' index rather than by scanning every merge.
+func (q *Q) AccountMergeByAddress(dest interface{}, addy string) error {
+	var account Account
+	err := q.AccountByAddress(&account, addy)
+	if err != nil {
+		return err
+	}
+
+	sql := selectAccountMerge.
+		Join("history_operation_participants hopp ON "+
+			"hopp.history_operation_id = hop.id").
+		Where("hopp.history_account_id = ?", account.ID).
+		Where("hop.type = ? AND hop.details->>'account' = ?",
+			xdr.OperationTypeAccountMerge, addy).
+		OrderBy("hopp.history_operation_id DESC").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// Into returns the address of the account `r` merged into.
+func (r *AccountMerge) Into() (string, error) {
+	var details struct {
+		Into string `json:"into"`
+	}
+
+	err := r.UnmarshalDetails(&details)
+	return details.Into, err
+}
+
 // OperationByID loads a single operation with `id` into `dest`
 func (q *Q) OperationByID(dest interface{}, id int64) error {
 	sql := selectOperation.
@@ -58,6 +117,32 @@ func (q *OperationsQ) ForAccount(aid string) *OperationsQ {
 	return q
 }
 
+// ForAsset filters the operations collection to those that involve `asset`
+// (see participants.AssetsForOperation for the assets an operation involves).
+func (q *OperationsQ) ForAsset(asset xdr.Asset) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var typ, code, iss string
+	q.Err = asset.Extract(&typ, &code, &iss)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Join(
+		"history_operation_assets hopa ON "+
+			"hopa.history_operation_id = hop.id",
+	).Where(
+		"hopa.asset_type = ? AND hopa.asset_code = ? AND hopa.asset_issuer = ?",
+		typ,
+		code,
+		iss,
+	)
+
+	return q
+}
+
 // ForLedger filters the query to a only operations in a specific ledger,
 // specified by its sequence.
 func (q *OperationsQ) ForLedger(seq int32) *OperationsQ {
@@ -99,15 +184,86 @@ func (q *OperationsQ) ForTransaction(hash string) *OperationsQ {
 	return q
 }
 
+// PaymentTypes are the operation types in the "payment" class of operations:
+// CreateAccountOps, Payments, and PathPayments.
+var PaymentTypes = []xdr.OperationType{
+	xdr.OperationTypeCreateAccount,
+	xdr.OperationTypePayment,
+	xdr.OperationTypePathPayment,
+}
+
 // OnlyPayments filters the query being built to only include operations that
-// are in the "payment" class of operations:  CreateAccountOps, Payments, and
-// PathPayments.
+// are in the "payment" class of operations (see PaymentTypes).
 func (q *OperationsQ) OnlyPayments() *OperationsQ {
-	q.sql = q.sql.Where(sq.Eq{"hop.type": []xdr.OperationType{
-		xdr.OperationTypeCreateAccount,
-		xdr.OperationTypePayment,
-		xdr.OperationTypePathPayment,
-	}})
+	return q.ForTypes(PaymentTypes)
+}
+
+// ForTypes filters the query being built to only include operations of one of
+// `types`.
+func (q *OperationsQ) ForTypes(types []xdr.OperationType) *OperationsQ {
+	q.sql = q.sql.Where(sq.Eq{"hop.type": types})
+	return q
+}
+
+// ForPaymentAsset filters the query to only payment operations that deliver
+// `asset` to their recipient.  Account creation always delivers the native
+// asset.
+func (q *OperationsQ) ForPaymentAsset(asset xdr.Asset) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var typ, code, iss string
+	q.Err = asset.Extract(&typ, &code, &iss)
+	if q.Err != nil {
+		return q
+	}
+
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		q.sql = q.sql.Where(
+			"(hop.type = ? OR hop.details->>'asset_type' = ?)",
+			xdr.OperationTypeCreateAccount,
+			typ,
+		)
+		return q
+	}
+
+	q.sql = q.sql.Where(`
+		(hop.details->>'asset_type' = ?
+	AND hop.details->>'asset_code' = ?
+	AND hop.details->>'asset_issuer' = ?)`, typ, code, iss)
+	return q
+}
+
+// ForPaymentRecipient filters the query to only payment operations whose
+// recipient is `aid`.
+func (q *OperationsQ) ForPaymentRecipient(aid string) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where(`
+		((hop.type = ? AND hop.details->>'account' = ?)
+	OR (hop.type IN (?, ?) AND hop.details->>'to' = ?))`,
+		xdr.OperationTypeCreateAccount, aid,
+		xdr.OperationTypePayment, xdr.OperationTypePathPayment, aid,
+	)
+	return q
+}
+
+// ForPaymentSender filters the query to only payment operations whose sender
+// is `aid`.
+func (q *OperationsQ) ForPaymentSender(aid string) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where(`
+		((hop.type = ? AND hop.details->>'funder' = ?)
+	OR (hop.type IN (?, ?) AND hop.details->>'from' = ?))`,
+		xdr.OperationTypeCreateAccount, aid,
+		xdr.OperationTypePayment, xdr.OperationTypePathPayment, aid,
+	)
 	return q
 }
 
@@ -138,6 +294,11 @@ var selectOperation = sq.Select(
 		"hop.type, " +
 		"hop.details, " +
 		"hop.source_account, " +
-		"ht.transaction_hash").
+		"ht.transaction_hash, " +
+		"ht.tx_result").
 	From("history_operations hop").
 	LeftJoin("history_transactions ht ON ht.id = hop.transaction_id")
+
+var selectAccountMerge = selectOperation.
+	Columns("ht.ledger_sequence, hl.closed_at").
+	Join("history_ledgers hl ON hl.sequence = ht.ledger_sequence")
@@ -3,6 +3,8 @@ package history
 import (
 	"testing"
 
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/assets"
 	"github.com/stellar/horizon/test"
 )
 
@@ -17,6 +19,11 @@ func TestOperationQueries(t *testing.T) {
 
 	if tt.Assert.NoError(err) {
 		tt.Assert.Equal(int64(8589938689), op.ID)
+
+		result, ok, err := op.Result()
+		if tt.Assert.NoError(err) && tt.Assert.True(ok) {
+			tt.Assert.Equal(op.Type, result.MustTr().Type)
+		}
 	}
 
 	// Test Operations()
@@ -46,6 +53,28 @@ func TestOperationQueries(t *testing.T) {
 		tt.Assert.Len(ops, 1)
 	}
 
+	// type filter works
+	ops = []Operation{}
+	err = q.Operations().ForTypes([]xdr.OperationType{xdr.OperationTypePayment}).Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 1)
+	}
+
+	ops = []Operation{}
+	err = q.Operations().ForTypes([]xdr.OperationType{
+		xdr.OperationTypeCreateAccount,
+		xdr.OperationTypePayment,
+	}).Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 4)
+	}
+
+	ops = []Operation{}
+	err = q.Operations().ForTypes([]xdr.OperationType{xdr.OperationTypeManageOffer}).Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 0)
+	}
+
 	// payment filter works
 	tt.Scenario("pathed_payment")
 	ops = []Operation{}
@@ -54,4 +83,105 @@ func TestOperationQueries(t *testing.T) {
 	if tt.Assert.NoError(err) {
 		tt.Assert.Len(ops, 10)
 	}
+
+	// payment asset filter works
+	native, err := assets.ParseCanonical("native")
+	tt.Require.NoError(err)
+	eur, err := assets.ParseCanonical("EUR:GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG")
+	tt.Require.NoError(err)
+	usd, err := assets.ParseCanonical("USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	tt.Require.NoError(err)
+
+	ops = []Operation{}
+	err = q.Operations().OnlyPayments().ForPaymentAsset(native).Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 5)
+	}
+
+	ops = []Operation{}
+	err = q.Operations().OnlyPayments().ForPaymentAsset(eur).Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 3)
+	}
+
+	// payment direction filters work
+	ops = []Operation{}
+	err = q.Operations().OnlyPayments().
+		ForPaymentRecipient("GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON").
+		Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 3)
+	}
+
+	ops = []Operation{}
+	err = q.Operations().OnlyPayments().
+		ForPaymentSender("GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4").
+		Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 2)
+	}
+
+	// payment filters combine
+	ops = []Operation{}
+	err = q.Operations().OnlyPayments().
+		ForPaymentRecipient("GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON").
+		ForPaymentAsset(usd).
+		Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 1)
+	}
+
+	// asset filter works, matching any asset an operation involves
+	ops = []Operation{}
+	err = q.Operations().ForAsset(eur).Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 6)
+	}
+
+	ops = []Operation{}
+	err = q.Operations().OnlyPayments().ForAsset(eur).Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 3)
+	}
+
+	ops = []Operation{}
+	err = q.Operations().ForAsset(native).Select(&ops)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 5)
+	}
+}
+
+func TestAccountMergeByAddress(t *testing.T) {
+	tt := test.Start(t).Scenario("account_merge")
+	defer tt.Finish()
+	q := &Q{tt.HorizonRepo()}
+
+	var merge AccountMerge
+	err := q.AccountMergeByAddress(
+		&merge,
+		"GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU",
+	)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int64(12884905985), merge.ID)
+		tt.Assert.Equal(int32(3), merge.LedgerSequence)
+		tt.Assert.False(merge.LedgerClosedAt.IsZero())
+
+		into, err := merge.Into()
+		tt.Assert.NoError(err)
+		tt.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", into)
+	}
+
+	// an account that was never merged, though merged into
+	err = q.AccountMergeByAddress(
+		&merge,
+		"GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2",
+	)
+	tt.Assert.True(q.NoRows(err))
+
+	// an account unknown to the history
+	err = q.AccountMergeByAddress(
+		&merge,
+		"GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON",
+	)
+	tt.Assert.True(q.NoRows(err))
 }
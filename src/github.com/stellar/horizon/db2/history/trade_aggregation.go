@@ -0,0 +1,177 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	sq "github.com/lann/squirrel"
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/db2"
+)
+
+// TradeAggregations provides a helper to aggregate the trades of the asset
+// pair `base`/`counter` into buckets `resolution` wide, aligned to the unix
+// epoch.  The trades are those recorded as trade effects during ingestion, each
+// trade being counted once:  by the effect of the party that sold `base` for
+// `counter`.  See `TradeAggregationsQ` methods for the available filters.
+func (q *Q) TradeAggregations(
+	base, counter xdr.Asset,
+	resolution time.Duration,
+) *TradeAggregationsQ {
+	agg := &TradeAggregationsQ{parent: q}
+
+	secs := int64(resolution / time.Second)
+	if secs <= 0 {
+		agg.Err = errors.Errorf("invalid resolution: %s", resolution)
+		return agg
+	}
+	agg.resolution = secs
+
+	bucket := fmt.Sprintf(
+		"div(extract(epoch from hl.closed_at)::bigint, %d) * %d",
+		secs, secs,
+	)
+
+	agg.sql = sq.Select(
+		bucket+" AS timestamp",
+		"count(*) AS trade_count",
+		"sum("+tradeBaseAmount+") AS base_volume",
+		"sum("+tradeCounterAmount+") AS counter_volume",
+		firstTrade(tradeBaseAmount, tradeChronological)+" AS open_base_amount",
+		firstTrade(tradeCounterAmount, tradeChronological)+" AS open_counter_amount",
+		firstTrade(tradeBaseAmount, tradeByPriceDesc)+" AS high_base_amount",
+		firstTrade(tradeCounterAmount, tradeByPriceDesc)+" AS high_counter_amount",
+		firstTrade(tradeBaseAmount, tradeByPriceAsc)+" AS low_base_amount",
+		firstTrade(tradeCounterAmount, tradeByPriceAsc)+" AS low_counter_amount",
+		firstTrade(tradeBaseAmount, tradeReverseChronological)+" AS close_base_amount",
+		firstTrade(tradeCounterAmount, tradeReverseChronological)+" AS close_counter_amount",
+	).
+		From("history_effects heff").
+		Join("history_ledgers hl ON hl.sequence = heff.history_operation_id >> 32").
+		Where("heff.type = ?", EffectTrade).
+		GroupBy("timestamp")
+
+	agg.sql, agg.Err = assetDetailsFilter(agg.sql, base, "sold_")
+	if agg.Err != nil {
+		return agg
+	}
+	agg.sql, agg.Err = assetDetailsFilter(agg.sql, counter, "bought_")
+	return agg
+}
+
+// PagingToken returns a cursor for this bucket:  the unix time, in seconds,
+// at which it starts.
+func (r TradeAggregation) PagingToken() string {
+	return fmt.Sprintf("%d", r.Timestamp)
+}
+
+// ForTimeRange filters the query to the trades made in ledgers closed at or
+// after `start` and before `end`.  A zero time leaves that end of the range
+// open.  The range is also given as one of operation ids, those of the first
+// and last ledger closed within it, so that only the pair's trades within the
+// range are read from the `trade_effects_by_pair_and_operation` index.
+func (q *TradeAggregationsQ) ForTimeRange(start, end time.Time) *TradeAggregationsQ {
+	if !start.IsZero() {
+		q.sql = q.sql.
+			Where("hl.closed_at >= ?", start.UTC()).
+			Where(`heff.history_operation_id >= (
+				SELECT min(sequence)::bigint << 32
+				FROM history_ledgers
+				WHERE closed_at >= ?
+			)`, start.UTC())
+	}
+
+	if !end.IsZero() {
+		q.sql = q.sql.
+			Where("hl.closed_at < ?", end.UTC()).
+			Where(`heff.history_operation_id < (
+				SELECT (max(sequence)::bigint + 1) << 32
+				FROM history_ledgers
+				WHERE closed_at < ?
+			)`, end.UTC())
+	}
+
+	return q
+}
+
+// Page specifies the paging constraints for the query being built by `q`.  The
+// page's cursor, if any, is a bucket's paging token.
+func (q *TradeAggregationsQ) Page(page db2.PageQuery) *TradeAggregationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	cursor, err := page.CursorInt64()
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	// buckets are selected by the close time of the ledgers they are made of,
+	// the bucket that starts at `cursor`, should it not be aligned, being that
+	// which contains it.
+	start := cursor - cursor%q.resolution
+
+	switch page.Order {
+	case db2.OrderAscending:
+		if page.Cursor != "" {
+			q.sql = q.sql.Where(
+				"hl.closed_at >= ?",
+				time.Unix(start+q.resolution, 0).UTC(),
+			)
+		}
+		q.sql = q.sql.OrderBy("timestamp asc")
+	case db2.OrderDescending:
+		if page.Cursor != "" {
+			if start < cursor {
+				start += q.resolution
+			}
+			q.sql = q.sql.Where("hl.closed_at < ?", time.Unix(start, 0).UTC())
+		}
+		q.sql = q.sql.OrderBy("timestamp desc")
+	default:
+		q.Err = errors.New(db2.ErrInvalidOrder)
+		return q
+	}
+
+	q.sql = q.sql.Limit(page.Limit)
+	return q
+}
+
+// Select loads the results of the query specified by `q` into `dest`.
+func (q *TradeAggregationsQ) Select(dest interface{}) error {
+	if q.Err != nil {
+		return q.Err
+	}
+
+	q.Err = q.parent.Select(dest, q.sql)
+	return q.Err
+}
+
+// The amounts of a trade effect, in units of the base and counter asset of
+// the pair aggregated.  Amounts are recorded with seven decimal places, which
+// their sums keep.
+const (
+	tradeBaseAmount    = "(heff.details->>'sold_amount')::numeric"
+	tradeCounterAmount = "(heff.details->>'bought_amount')::numeric"
+)
+
+// The orderings of the trades within a bucket from which its open, high, low
+// and close are taken.  Prices are compared with enough decimal places to
+// distinguish any two ratios of amounts.
+const (
+	tradePrice = "(heff.details->>'bought_amount')::numeric(60, 40) / " +
+		"(heff.details->>'sold_amount')::numeric"
+
+	tradeChronological        = `heff.history_operation_id asc, heff."order" asc`
+	tradeReverseChronological = `heff.history_operation_id desc, heff."order" desc`
+	tradeByPriceDesc          = tradePrice + " desc, " + tradeChronological
+	tradeByPriceAsc           = tradePrice + " asc, " + tradeChronological
+)
+
+// firstTrade returns the aggregate expression for the value `expr` of the first
+// trade of a bucket, when ordered by `order`.
+func firstTrade(expr, order string) string {
+	return fmt.Sprintf("(array_agg(%s ORDER BY %s))[1]", expr, order)
+}
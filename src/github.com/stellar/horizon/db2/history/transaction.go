@@ -7,7 +7,8 @@ import (
 )
 
 // TransactionByHash is a query that loads a single row from the
-// `history_transactions` table based upon the provided hash.
+// `history_transactions` table based upon the provided hash.  Unlike
+// Transactions, it loads failed transactions.
 func (q *Q) TransactionByHash(dest interface{}, hash string) error {
 	sql := selectTransaction.
 		Limit(1).
@@ -16,9 +17,52 @@ func (q *Q) TransactionByHash(dest interface{}, hash string) error {
 	return q.Get(dest, sql)
 }
 
+// TransactionByID loads a single row from the `history_transactions` table by
+// its id.  Like TransactionByHash, it loads failed transactions.
+func (q *Q) TransactionByID(dest interface{}, id int64) error {
+	sql := selectTransaction.
+		Limit(1).
+		Where("ht.id = ?", id)
+
+	return q.Get(dest, sql)
+}
+
+// TransactionMetaByHash loads the result and meta XDR of a single transaction
+// from the `history_transactions` table, without the rest of the row.
+func (q *Q) TransactionMetaByHash(dest interface{}, hash string) error {
+	sql := sq.Select(
+		"ht.transaction_hash",
+		"ht.ledger_sequence",
+		"ht.tx_result",
+		"ht.tx_meta",
+		"ht.tx_fee_meta",
+	).
+		From("history_transactions ht").
+		Limit(1).
+		Where("ht.transaction_hash = ?", hash)
+
+	return q.Get(dest, sql)
+}
+
+// TransactionLedgersByHash loads the hash and ledger of each transaction whose
+// hash is in `hashes` from the `history_transactions` table.  Hashes that are
+// not found are omitted from the results.
+func (q *Q) TransactionLedgersByHash(dest interface{}, hashes []string) error {
+	sql := sq.Select(
+		"ht.transaction_hash",
+		"ht.ledger_sequence",
+		"COALESCE(ht.successful, true) AS successful",
+	).
+		From("history_transactions ht").
+		Where(sq.Eq{"ht.transaction_hash": hashes})
+
+	return q.Select(dest, sql)
+}
+
 // Transactions provides a helper to filter rows from the `history_transactions`
 // table with pre-defined filters.  See `TransactionsQ` methods for the
-// available filters.
+// available filters.  Failed transactions are excluded unless IncludeFailed is
+// called.
 func (q *Q) Transactions() *TransactionsQ {
 	return &TransactionsQ{
 		parent: q,
@@ -61,6 +105,13 @@ func (q *TransactionsQ) ForLedger(seq int32) *TransactionsQ {
 	return q
 }
 
+// IncludeFailed includes in the query the failed transactions stored by
+// ingestion, interleaved with successful ones in the order they were applied.
+func (q *TransactionsQ) IncludeFailed() *TransactionsQ {
+	q.includeFailed = true
+	return q
+}
+
 // Page specifies the paging constraints for the query being built by `q`.
 func (q *TransactionsQ) Page(page db2.PageQuery) *TransactionsQ {
 	if q.Err != nil {
@@ -77,7 +128,14 @@ func (q *TransactionsQ) Select(dest interface{}) error {
 		return q.Err
 	}
 
-	q.Err = q.parent.Select(dest, q.sql)
+	sql := q.sql
+	if !q.includeFailed {
+		// rows ingested before failed transactions were recorded are null,
+		// and succeeded.
+		sql = sql.Where("ht.successful IS NOT FALSE")
+	}
+
+	q.Err = q.parent.Select(dest, sql)
 	return q.Err
 }
 
@@ -101,6 +159,7 @@ var selectTransaction = sq.Select(
 		"ht.memo, " +
 		"lower(ht.time_bounds) AS valid_after, " +
 		"upper(ht.time_bounds) AS valid_before, " +
+		"COALESCE(ht.successful, true) AS successful, " +
 		"hl.closed_at AS ledger_close_time").
 	From("history_transactions ht").
 	LeftJoin("history_ledgers hl ON ht.ledger_sequence = hl.sequence")
@@ -45,6 +45,18 @@ type Repo struct {
 	// Ctx is the optional context in which the repo is operating under.
 	Ctx context.Context
 
+	// Retry is the optional policy used to retry reads that fail due to a lost
+	// connection. Reads made within a transaction are never retried.
+	Retry *RetryPolicy
+
+	// ReadOnly causes the repo to reject any statement other than a read.  See
+	// OpenReadOnly.
+	ReadOnly bool
+
+	// Failover, when set, selects the database the repo's queries are made
+	// against in place of DB.  See Failover.
+	Failover *Failover
+
 	tx *sqlx.Tx
 }
 
@@ -72,6 +84,19 @@ func Open(url string) (*Repo, error) {
 	return &Repo{DB: db}, nil
 }
 
+// OpenLazy returns a new *Repo using the postgres database at `url`, as Open
+// does, but without connecting to it.  The database is first connected to
+// when the repo is used, so that a database that is unreachable at startup
+// can still be used once it becomes reachable.
+func OpenLazy(url string) (*Repo, error) {
+	db, err := sqlx.Open("postgres", url)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &Repo{DB: db}, nil
+}
+
 // ensure various types conform to Conn interface
 var _ Conn = (*sqlx.Tx)(nil)
 var _ Conn = (*sqlx.DB)(nil)
@@ -0,0 +1,91 @@
+package db2
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/stellar/horizon/errors"
+)
+
+// ErrReadOnly is returned when a statement other than a read is issued through
+// a read-only repo.  In debug builds (those built with `-tags debug`) the repo
+// panics instead, so that the offending code is found during development.
+var ErrReadOnly = errors.NewPermanent("db: write attempted through read-only repo")
+
+// readOnlyStatements matches the statements that may be issued through a
+// read-only repo: queries, and the characteristics of the current transaction
+// (other than making it read-write).
+var (
+	readOnlyStatements = regexp.MustCompile(`(?i)^(SELECT|WITH|SHOW|SET\s+TRANSACTION)\b`)
+	readWriteMode      = regexp.MustCompile(`(?is)^SET\s.*\bREAD\s+WRITE\b`)
+)
+
+// OpenReadOnly opens the postgres database at `url` and returns a new, read-only
+// *Repo using it.  Every connection to the database is opened with
+// `default_transaction_read_only` set, so that postgres itself rejects writes
+// that slip past the statement whitelisting of the repo.
+func OpenReadOnly(url string) (*Repo, error) {
+	return openReadOnly(url, Open)
+}
+
+// OpenReadOnlyLazy is OpenReadOnly, but does not connect to the database until
+// the repo is used.  See OpenLazy.
+func OpenReadOnlyLazy(url string) (*Repo, error) {
+	return openReadOnly(url, OpenLazy)
+}
+
+func openReadOnly(url string, open func(string) (*Repo, error)) (*Repo, error) {
+	dsn, err := readOnlyDSN(url)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.ReadOnly = true
+	return repo, nil
+}
+
+// checkReadOnly returns ErrReadOnly (or panics, in debug builds) if the repo is
+// read-only and `query` is not a statement it allows.
+func (r *Repo) checkReadOnly(query string) error {
+	if !r.ReadOnly {
+		return nil
+	}
+
+	stmt := strings.TrimSpace(removeComments(query + "\n"))
+	if readOnlyStatements.MatchString(stmt) && !readWriteMode.MatchString(stmt) {
+		return nil
+	}
+
+	if panicOnWrite {
+		panic(fmt.Sprintf("db: write attempted through read-only repo: %s", stmt))
+	}
+
+	return ErrReadOnly
+}
+
+// readOnlyDSN adds the `default_transaction_read_only` run-time parameter to
+// the postgres connection string `dsn`, which may be either a url or a list of
+// key=value pairs.
+func readOnlyDSN(dsn string) (string, error) {
+	if !strings.HasPrefix(dsn, "postgres://") &&
+		!strings.HasPrefix(dsn, "postgresql://") {
+		return dsn + " default_transaction_read_only=on", nil
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("default_transaction_read_only", "on")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
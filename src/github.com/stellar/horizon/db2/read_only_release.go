@@ -0,0 +1,6 @@
+// +build !debug
+
+package db2
+
+// panicOnWrite causes writes attempted through a read-only repo to panic.
+const panicOnWrite = false
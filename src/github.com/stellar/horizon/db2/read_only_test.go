@@ -0,0 +1,64 @@
+package db2
+
+import (
+	"testing"
+
+	tdb "github.com/stellar/horizon/test/db"
+	"github.com/stellar/horizon/test/scenarios"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnly(t *testing.T) {
+	scenarios.Load(tdb.StellarCoreURL(), "base-core.sql")
+	assert := assert.New(t)
+	require := require.New(t)
+	repo := &Repo{DB: tdb.StellarCore(), ReadOnly: true}
+
+	var count int
+	assert.NoError(repo.GetRaw(&count, "SELECT COUNT(*) FROM txhistory"))
+	assert.NoError(repo.GetRaw(&count, "-- comment\n  select COUNT(*) FROM txhistory"))
+	assert.NoError(repo.GetRaw(&count, "WITH t AS (SELECT * FROM txhistory) SELECT COUNT(*) FROM t"))
+
+	require.NoError(repo.Begin())
+	_, err := repo.ExecRaw("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ")
+	assert.NoError(err)
+	assert.NoError(repo.Rollback())
+
+	writes := []string{
+		"DELETE FROM txhistory",
+		"/* SELECT */ DELETE FROM txhistory",
+		"UPDATE txhistory SET txindex = 0",
+		"SET default_transaction_read_only = off",
+		"SET TRANSACTION READ WRITE",
+		"SELECTED",
+	}
+
+	for _, query := range writes {
+		if panicOnWrite {
+			assert.Panics(func() { repo.ExecRaw(query) }, query)
+			continue
+		}
+
+		_, err = repo.ExecRaw(query)
+		assert.Equal(ErrReadOnly, err, query)
+	}
+
+	assert.NoError(repo.GetRaw(&count, "SELECT COUNT(*) FROM txhistory"))
+	assert.Equal(4, count)
+
+	// clones remain read-only
+	assert.True(repo.Clone().ReadOnly)
+}
+
+func TestReadOnlyDSN(t *testing.T) {
+	assert := assert.New(t)
+
+	dsn, err := readOnlyDSN("postgres://localhost:5432/core?sslmode=disable")
+	assert.NoError(err)
+	assert.Equal("postgres://localhost:5432/core?default_transaction_read_only=on&sslmode=disable", dsn)
+
+	dsn, err = readOnlyDSN("dbname=core sslmode=disable")
+	assert.NoError(err)
+	assert.Equal("dbname=core sslmode=disable default_transaction_read_only=on", dsn)
+}
@@ -19,7 +19,7 @@ func (r *Repo) Begin() error {
 		return errors.New("already in transaction")
 	}
 
-	tx, err := r.DB.Beginx()
+	tx, err := r.db().Beginx()
 	if err != nil {
 		return errors.Wrap(err, 1)
 	}
@@ -34,8 +34,11 @@ func (r *Repo) Begin() error {
 // source is currently within.
 func (r *Repo) Clone() *Repo {
 	return &Repo{
-		DB:  r.DB,
-		Ctx: r.Ctx,
+		DB:       r.DB,
+		Ctx:      r.Ctx,
+		Retry:    r.Retry,
+		ReadOnly: r.ReadOnly,
+		Failover: r.Failover,
 	}
 }
 
@@ -78,10 +81,19 @@ func (r *Repo) Get(dest interface{}, query sq.Sqlizer) error {
 // GetRaw runs `query` with `args`, setting the first result found on
 // `dest`, if any.
 func (r *Repo) GetRaw(dest interface{}, query string, args ...interface{}) error {
+	if err := r.checkReadOnly(query); err != nil {
+		return err
+	}
+
 	query = r.conn().Rebind(query)
-	start := time.Now()
-	err := r.conn().Get(dest, query, args...)
-	r.log("get", start, query, args)
+	err := r.retry(func() error {
+		return r.timed(func(conn Conn) error {
+			start := time.Now()
+			err := conn.Get(dest, query, args...)
+			r.log("get", start, query, args)
+			return err
+		})
+	})
 
 	if err == nil {
 		return nil
@@ -125,10 +137,18 @@ func (r *Repo) ExecAll(script string) error {
 
 // ExecRaw runs `query` with `args`
 func (r *Repo) ExecRaw(query string, args ...interface{}) (sql.Result, error) {
+	if err := r.checkReadOnly(query); err != nil {
+		return nil, err
+	}
+
 	query = r.conn().Rebind(query)
-	start := time.Now()
-	result, err := r.conn().Exec(query, args...)
-	r.log("exec", start, query, args)
+	var result sql.Result
+	err := r.timed(func(conn Conn) (err error) {
+		start := time.Now()
+		result, err = conn.Exec(query, args...)
+		r.log("exec", start, query, args)
+		return
+	})
 
 	if err == nil {
 		return result, nil
@@ -147,6 +167,15 @@ func (r *Repo) NoRows(err error) bool {
 	return err == sql.ErrNoRows
 }
 
+// Ping verifies that the database behind the repo is reachable.
+func (r *Repo) Ping() error {
+	err := r.db().Ping()
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return nil
+}
+
 // Query runs `query`, returns a *sqlx.Rows instance
 func (r *Repo) Query(query sq.Sqlizer) (*sqlx.Rows, error) {
 	sql, args, err := r.build(query)
@@ -156,12 +185,25 @@ func (r *Repo) Query(query sq.Sqlizer) (*sqlx.Rows, error) {
 	return r.QueryRaw(sql, args...)
 }
 
-// QueryRaw runs `query` with `args`
+// QueryRaw runs `query` with `args`.  Since the returned rows outlive the call,
+// the query is not limited by the deadline of the repo's context, though it
+// will not be started if the deadline has already passed.
 func (r *Repo) QueryRaw(query string, args ...interface{}) (*sqlx.Rows, error) {
+	if err := r.checkReadOnly(query); err != nil {
+		return nil, err
+	}
+
 	query = r.conn().Rebind(query)
-	start := time.Now()
-	result, err := r.conn().Queryx(query, args...)
-	r.log("query", start, query, args)
+	var result *sqlx.Rows
+	err := r.retry(func() (err error) {
+		if deadline, ok := r.deadline(); ok && !time.Now().Before(deadline) {
+			return ErrTimeout
+		}
+		start := time.Now()
+		result, err = r.conn().Queryx(query, args...)
+		r.log("query", start, query, args)
+		return
+	})
 
 	if err == nil {
 		return result, nil
@@ -201,11 +243,20 @@ func (r *Repo) SelectRaw(
 	query string,
 	args ...interface{},
 ) error {
-	r.clearSliceIfPossible(dest)
+	if err := r.checkReadOnly(query); err != nil {
+		return err
+	}
+
 	query = r.conn().Rebind(query)
-	start := time.Now()
-	err := r.conn().Select(dest, query, args...)
-	r.log("select", start, query, args)
+	err := r.retry(func() error {
+		return r.timed(func(conn Conn) error {
+			r.clearSliceIfPossible(dest)
+			start := time.Now()
+			err := conn.Select(dest, query, args...)
+			r.log("select", start, query, args)
+			return err
+		})
+	})
 
 	if err == nil {
 		return nil
@@ -238,51 +289,85 @@ func (r *Repo) clearSliceIfPossible(dest interface{}) {
 	vt := v.Type()
 
 	if vt.Kind() != reflect.Ptr {
-		log.Warn("cannot clear slice: dest is not pointer")
+		r.logger().Warn("cannot clear slice: dest is not pointer")
 		return
 	}
 
 	if vt.Elem().Kind() != reflect.Slice {
-		log.Warn("cannot clear slice: dest is a pointer, but not to a slice")
+		r.logger().Warn("cannot clear slice: dest is a pointer, but not to a slice")
 		return
 	}
 
 	reflect.Indirect(v).SetLen(0)
 }
 
+// retry runs `fn` according to the repo's retry policy.  Reads made within a
+// transaction cannot be safely retried, since the transaction is invalidated
+// along with its connection.  When the repo has a failover, a lost connection
+// is reported to it, so that the read is retried against the next database.
+// Waiting to retry ends early once the repo's context is done.
+func (r *Repo) retry(fn func() error) error {
+	if r.tx != nil {
+		return fn()
+	}
+
+	return r.Retry.do(r.Ctx, func() error {
+		db := r.db()
+		err := fn()
+		if r.Failover != nil && IsConnectionError(err) {
+			r.Failover.Fail(db, err)
+		}
+		return err
+	})
+}
+
 func (r *Repo) conn() Conn {
 	if r.tx != nil {
 		return r.tx
 	}
 
+	return r.db()
+}
+
+// db returns the database the repo's queries are made against:  that selected
+// by its failover, if any, or else DB.
+func (r *Repo) db() *sqlx.DB {
+	if r.Failover != nil {
+		return r.Failover.DB()
+	}
+
 	return r.DB
 }
 
 func (r *Repo) log(typ string, start time.Time, query string, args []interface{}) {
-	log.
-		Ctx(r.logCtx()).
+	dur := time.Since(start)
+	r.logger().
 		WithField("args", args).
 		WithField("sql", query).
-		WithField("dur", time.Since(start).String()).
+		WithField("dur", dur.String()).
 		Debugf("sql: %s", typ)
+	r.observe(typ, dur, query, args)
 }
 
 func (r *Repo) logBegin() {
-	log.Ctx(r.logCtx()).Debug("sql: begin")
+	r.logger().Debug("sql: begin")
 }
 
 func (r *Repo) logCommit() {
-	log.Ctx(r.logCtx()).Debug("sql: commit")
+	r.logger().Debug("sql: commit")
 }
 
 func (r *Repo) logRollback() {
-	log.Ctx(r.logCtx()).Debug("sql: rollback")
+	r.logger().Debug("sql: rollback")
 }
 
-func (r *Repo) logCtx() context.Context {
-	if r.Ctx != nil {
-		return r.Ctx
+// logger returns the logger bound to the repo's context (see log.Ctx), logging
+// as part of the db subsystem.
+func (r *Repo) logger() *log.Entry {
+	ctx := r.Ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	return context.Background()
+	return log.Ctx(ctx).In(log.DBSubsystem)
 }
@@ -0,0 +1,75 @@
+package db2
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/stellar/horizon/errors"
+	"github.com/stellar/horizon/log"
+	"golang.org/x/net/context"
+)
+
+// DefaultRetryPolicy is a reasonable retry policy for a repo whose database
+// may be restarted from underneath horizon, such as stellar-core's.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     100 * time.Millisecond,
+}
+
+// RetryPolicy describes how a Repo retries read queries that fail because the
+// connection to the database was lost.  Queries that fail for any other reason
+// (bad sql, missing rows, etc.) are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a single read will be
+	// attempted.  A value less than 2 disables retries.
+	MaxAttempts int
+
+	// Backoff is the base delay to wait before retrying.  The delay grows
+	// linearly with each attempt and has up to an equal amount of random jitter
+	// added, so that many callers do not reconnect in lock step.
+	Backoff time.Duration
+}
+
+// IsConnectionError returns true if `err` was caused by a lost or refused
+// connection to the database, rather than by the query itself.  See
+// errors.IsConnectionError.
+func IsConnectionError(err error) bool {
+	return errors.IsConnectionError(err)
+}
+
+// do runs `fn`, retrying it according to the policy for as long as it fails
+// with a connection error.  Should `ctx` be done while waiting to retry, the
+// error of the last attempt is returned at once.
+func (p *RetryPolicy) do(ctx context.Context, fn func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		err = fn()
+
+		if p == nil || attempt >= p.MaxAttempts || !IsConnectionError(err) {
+			return err
+		}
+
+		delay := p.Backoff * time.Duration(attempt)
+		if delay > 0 {
+			delay += time.Duration(rand.Int63n(int64(delay)))
+		}
+
+		log.
+			WithField("attempt", attempt).
+			WithField("delay", delay).
+			Warnf("db: retrying after connection error: %s", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
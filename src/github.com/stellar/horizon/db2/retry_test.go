@@ -0,0 +1,197 @@
+package db2
+
+import (
+	"database/sql"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	tdb "github.com/stellar/horizon/test/db"
+	"github.com/stellar/horizon/test/scenarios"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestIsConnectionError(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(IsConnectionError(nil))
+	assert.False(IsConnectionError(sql.ErrNoRows))
+	assert.False(IsConnectionError(errors.Wrap(sql.ErrNoRows, 0)))
+	assert.False(IsConnectionError(&pq.Error{Code: "42601"}))
+	assert.False(IsConnectionError(&pq.Error{Code: "42P01"}))
+
+	assert.True(IsConnectionError(io.EOF))
+	assert.True(IsConnectionError(errors.Wrap(io.ErrUnexpectedEOF, 0)))
+	assert.True(IsConnectionError(&pq.Error{Code: "08006"}))
+	assert.True(IsConnectionError(&pq.Error{Code: "57P01"}))
+	assert.True(IsConnectionError(&net.OpError{Op: "dial", Err: io.EOF}))
+}
+
+func TestRepoRetry(t *testing.T) {
+	scenarios.Load(tdb.StellarCoreURL(), "base-core.sql")
+	assert := assert.New(t)
+	require := require.New(t)
+
+	proxy := newDroppingProxy(t, tdb.StellarCoreURL())
+	defer proxy.Close()
+
+	db, err := sqlx.Open("postgres", proxy.URL)
+	require.NoError(err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	repo := &Repo{
+		DB:    db,
+		Retry: &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+	}
+
+	var count int
+	require.NoError(repo.GetRaw(&count, "SELECT COUNT(*) FROM txhistory"))
+	assert.Equal(4, count)
+
+	// reads survive a dropped connection
+	proxy.Drop()
+	err = repo.GetRaw(&count, "SELECT COUNT(*) FROM txhistory")
+	assert.NoError(err)
+	assert.Equal(4, count)
+
+	proxy.Drop()
+	var ids []string
+	err = repo.SelectRaw(&ids, "SELECT txid FROM txhistory")
+	assert.NoError(err)
+	assert.Len(ids, 4)
+
+	// non-connection errors are not retried
+	err = repo.GetRaw(&count, "SELECT COUNT(*) FROM not_a_table")
+	assert.Error(err)
+	assert.False(IsConnectionError(err))
+
+	// without a policy, the dropped connection surfaces to the caller
+	repo.Retry = nil
+	proxy.Drop()
+	err = repo.GetRaw(&count, "SELECT COUNT(*) FROM txhistory")
+	if assert.Error(err) {
+		assert.True(IsConnectionError(err))
+	}
+}
+
+func TestRetryPolicyCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &RetryPolicy{MaxAttempts: 3, Backoff: time.Hour}
+
+	attempts := 0
+	start := time.Now()
+	err := p.do(ctx, func() error {
+		attempts++
+		cancel()
+		return io.EOF
+	})
+
+	// the backoff is abandoned once the context is done
+	assert.Equal(io.EOF, err)
+	assert.Equal(1, attempts)
+	assert.True(time.Since(start) < time.Minute)
+}
+
+func TestHealth(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	proxy := newDroppingProxy(t, tdb.StellarCoreURL())
+
+	db, err := sqlx.Open("postgres", proxy.URL)
+	require.NoError(err)
+	defer db.Close()
+
+	var h Health
+	assert.False(h.Healthy())
+
+	assert.NoError(h.Check(&Repo{DB: db}))
+	assert.True(h.Healthy())
+	assert.False(h.CheckedAt().IsZero())
+
+	proxy.Close()
+	db.SetMaxIdleConns(0)
+
+	assert.Error(h.Check(&Repo{DB: db}))
+	assert.False(h.Healthy())
+	assert.Error(h.Err())
+}
+
+// droppingProxy is a tcp proxy in front of a postgres server that can sever
+// all of its open connections on demand, simulating a server restart.
+type droppingProxy struct {
+	URL string
+
+	listener net.Listener
+	target   string
+
+	lock  sync.Mutex
+	conns []net.Conn
+}
+
+func newDroppingProxy(t *testing.T, dsn string) *droppingProxy {
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	p := &droppingProxy{listener: l, target: u.Host}
+	u.Host = l.Addr().String()
+	p.URL = u.String()
+
+	go p.accept()
+	return p
+}
+
+func (p *droppingProxy) accept() {
+	for {
+		client, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		server, err := net.Dial("tcp", p.target)
+		if err != nil {
+			client.Close()
+			continue
+		}
+
+		p.lock.Lock()
+		p.conns = append(p.conns, client, server)
+		p.lock.Unlock()
+
+		go io.Copy(server, client)
+		go io.Copy(client, server)
+	}
+}
+
+// Close stops accepting connections and drops all open ones.
+func (p *droppingProxy) Close() {
+	p.listener.Close()
+	p.Drop()
+}
+
+// Drop closes all currently open connections.
+func (p *droppingProxy) Drop() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, c := range p.conns {
+		c.Close()
+	}
+
+	p.conns = nil
+}
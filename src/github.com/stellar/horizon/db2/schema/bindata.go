@@ -4,6 +4,15 @@
 // migrations/1_initial_schema.sql
 // migrations/2_index_participants_by_toid.sql
 // migrations/3_use_sequence_in_history_accounts.sql
+// migrations/4_add_history_ledger_upgrades.sql
+// migrations/5_add_history_operation_assets.sql
+// migrations/6_add_history_ledger_totals.sql
+// migrations/7_add_history_account_creation.sql
+// migrations/8_add_asset_stats.sql
+// migrations/9_add_history_transaction_successful.sql
+// migrations/10_add_history_ledger_stats.sql
+// migrations/11_add_asset_stats_ledger.sql
+// migrations/12_index_trade_effects_by_pair.sql
 // DO NOT EDIT!
 
 package schema
@@ -71,7 +80,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xc4\x5a\x6d\x6f\xda\x4e\x12\x7f\x9f\x4f\xb1\xea\x1b\x88\x04\x51\x20\x6d\x9a\x12\xb5\x12\x4d\xdc\x2b\x3a\x62\xda\x60\xae\xad\x4e\x27\x6b\xb1\x17\xf0\xd5\x78\x5d\xaf\x49\x93\x9e\xee\xbb\xff\xc7\x4f\xe0\x87\x5d\xaf\x4d\xec\xfc\xfb\xa6\x0a\x3b\x9e\x99\xdf\xcc\xf8\xb7\x33\xeb\xed\xf7\x4f\xfa\x7d\xf4\x85\x32\x7f\xed\x91\xf9\xd7\x29\x32\xb1\x8f\x97\x98\x11\x64\xee\xb6\x2e\xac\x9d\x9c\xcc\x15\x0d\x31\x1f\xfb\x64\x4b\x1c\x5f\xf7\xad\x2d\xa1\x3b\x1f\xbd\x47\xe7\xd7\xe1\x92\x4d\x8d\x9f\xc5\x5f\x0d\xdb\x0a\xa4\x89\x63\x50\xd3\x72\xd6\xb0\xd0\x59\x68\x9f\xae\x3a\xd7\x89\x3a\xc7\xc4\x9e\xa9\x1b\xd4\x59\x51\x6f\x0b\x12\x3a\xf3\x3d\xf8\x8f\x81\x24\x75\x62\x1d\x1b\x02\xaa\x57\x3b\xc7\xf0\x2d\xea\xe8\x4b\xd0\x44\x82\xf5\x15\xb6\x19\xc9\x98\x01\x05\xfa\x96\x30\x86\xd7\xa1\xc0\x6f\xec\x39\xa0\xeb\x3a\xf6\x9d\x60\xcf\xd8\xe8\x2e\xf6\x37\xb0\xe6\xee\x96\xb6\x65\xf4\x90\xbb\xd6\x0d\x80\x6a\xd3\x44\xcc\x24\x2b\xbc\xb3\x01\x20\x5e\xda\x84\xb9\xd8\x20\x81\xd3\x9d\xdc\xea\x6f\xcb\xdf\xe8\xd4\x32\x53\x7e\x9c\x44\x31\x54\xf1\x96\x8c\xd0\x9a\x7a\x2e\xb8\xb3\xf6\x70\xe0\x33\xbb\x46\xda\x93\x0b\x3f\x6b\xe3\x8f\x53\xe5\x1a\xcd\x01\xd2\x16\x8f\x62\x27\xae\xd1\xec\xb7\x43\xbc\x11\xea\x83\xd8\xde\xea\x08\x85\x51\xbf\xb9\x57\xc6\x9a\x12\x3d\x98\xd7\x8a\xba\x27\x08\xfe\x59\x26\xf2\xc9\xa3\x8f\xd4\x99\x86\xd4\xc5\x74\xda\x0b\x7f\xc5\xae\x0b\x41\x31\x75\xec\xa3\x20\x2b\x10\xea\xad\x8b\x02\xb7\xc3\x3f\xd1\x1f\xea\x90\x93\x53\xf0\x3a\xe3\xf6\xc6\x62\x3e\xf5\x9e\x74\x6c\x18\x74\xe7\xf8\x4c\xb7\x4c\x9d\x91\x5f\x89\xfb\x73\xe5\xeb\x42\x51\x6f\x4a\x10\xa4\x7d\x4e\xa4\x45\x5a\x43\x37\xe7\xda\xf8\x5e\x43\xdf\x26\xda\x67\x34\x08\x7f\x98\xa8\xf0\xf8\x9d\xa2\x6a\xe8\xe3\x8f\xf8\x27\x75\x86\xee\x26\xea\xbf\xc6\xd3\x85\xb2\xff\x7b\xfc\xfd\xf0\xf7\xcd\xf8\xe6\xb3\x82\x06\x32\x30\x0d\x25\x21\xaf\xf6\x90\x85\xa5\xb5\xb6\x1c\x1f\xdd\x2a\x9f\xc6\x8b\xa9\x86\x1c\x48\xca\x03\xb6\xbb\x1d\x01\xfe\xce\x68\xe4\x91\xb5\x61\x63\xc6\x4e\xf3\xc9\x33\x4d\x0f\xea\x18\x4a\x1f\x7b\xd8\xf0\x89\x87\x1e\xb0\xf7\x04\xb5\xdc\xbd\x7c\x7d\x2a\x4e\x1b\x59\xad\x88\xd1\x38\xd0\x58\x6b\x8c\x33\x07\x46\x3f\xe0\xce\x42\x48\xe4\xa8\x4b\xa2\x72\x15\x4a\xbe\xa2\x9e\x49\xbc\x57\x08\x56\xc8\x1a\xa0\x66\x57\x7d\x80\x22\x58\x32\x89\x8f\x2d\x9b\xa1\xff\x32\xea\x2c\xc5\x51\xb1\x89\x09\xcf\x36\x1d\x95\x58\x6b\x1c\x15\x48\xe7\x0e\x48\x4e\xe4\x69\x24\xac\x6f\x30\xdb\xf0\x73\x9a\x93\x77\x3d\xf2\x60\xd1\x1d\xd3\xa5\x0f\xc6\x41\xf2\xb0\xc3\x70\xc4\x8f\x61\x5a\xf6\x7e\x24\xc5\x78\x9e\xb3\x70\x48\x4b\x35\x79\xc3\xa6\x8c\xc7\x26\x01\xdb\xef\x09\x25\xff\x8c\x47\x60\xbb\x90\x3d\x14\xc9\xee\x5c\xb3\xb2\xec\xbe\x90\xe2\x3f\xb7\x2e\xf5\x20\x2c\xfa\x03\xe4\x03\x10\x15\xb0\x0c\xf2\x25\x45\x81\xf0\x01\xb7\x05\x14\xca\xad\xc8\x15\x21\xba\x4b\xa9\xcd\x5f\x0d\xb6\x45\x1d\x44\x04\xb9\x0e\x97\xe1\xed\x25\xde\x83\x48\x64\x8b\x1f\x75\xff\x11\x38\xc0\xd7\x99\xf5\xa7\x28\x25\xae\xe5\x43\xda\x5c\xec\xf9\x96\x61\xb9\xb8\x79\x66\xe3\x1b\x39\xf0\x1c\x1f\x54\xf5\x17\x5e\x4e\x21\x75\x03\xd0\xec\x3e\x55\x6a\xe3\xa5\x76\xad\x5a\x40\xd1\xec\x9b\xaa\xdc\x82\x6d\x09\xe2\xf1\x54\x53\xee\x6b\x02\xde\xeb\x96\x88\x9f\x59\xa6\x14\x4b\x6b\x95\x5a\xdc\x85\x73\xaf\x7c\x8a\x20\x45\x32\x61\xc7\x64\x44\xc0\xc2\x2d\xe9\x99\x3b\x52\xf4\x13\xa3\x3b\xcf\x20\x49\xad\x0b\xd8\x3f\x61\xaa\x0e\xf4\x04\x05\x89\x0a\x6f\x45\x1a\x5e\x8b\xc4\x20\x32\x53\x95\x1a\xaa\x64\xe1\x39\xe4\x20\xf2\xaf\x59\x7a\x90\x58\x79\x29\x82\xa8\x09\xf6\x99\x14\x21\xb1\x56\x24\x09\xd1\x03\x25\x34\x91\x7a\xa4\xc5\xca\x4d\xaa\x35\xed\x60\xe5\xc6\x2c\xee\xc7\x24\xed\x5e\x55\x26\x29\x27\x05\xae\xec\xc1\xb4\xb8\x73\xc1\xc2\x17\x51\xd4\xf5\xfd\x2d\x7d\x1b\x74\x40\xc4\x79\x20\x36\x38\xc5\x1b\x60\x61\x19\xba\x28\x18\xb6\x05\x8b\x5b\xe0\x5a\xc1\x52\x10\x05\xd1\x32\xb3\xd6\x0e\xf6\x77\xa0\x9a\x13\xf6\x77\x97\xa7\xff\xfe\xcf\x81\x8d\xff\xf7\x7f\x1e\x1f\x83\x44\xae\x9d\x23\x5b\xaa\x87\xbb\x42\x91\xbb\xf7\xba\x1c\x08\x43\x29\xbb\x1f\x74\x15\xd5\xc4\xc8\x20\x9c\xfa\x12\x12\x67\xb2\x20\x73\x57\x50\xc0\x6b\xce\x10\x0f\x2f\x58\xfc\xf2\xc4\xc6\x2b\xbd\xf1\xd1\xfb\x32\x53\xa7\xb2\x7d\x1e\x45\xf2\x37\xb3\xe9\xe2\x4e\x0d\x72\x1a\x9c\x8b\x08\x67\xde\xd2\xd6\x22\x3d\x01\xb7\x86\x42\xb8\x69\xd5\xc2\x21\xe1\x3f\x3e\x92\x5b\x0c\x35\xb8\xa2\x5e\x85\x43\x21\x74\x3b\xd6\xc6\x12\x88\x13\x75\xae\xc0\xae\x32\x51\xb5\x59\xe1\x28\x28\xdc\x36\xe6\xa8\xdb\x19\xe8\x96\x63\xf9\x16\x0c\x38\x2c\xd4\x75\xc6\x7e\xd9\x9d\x1e\xea\x0c\xcf\x07\x97\xfd\xf3\xcb\xfe\xf0\x0a\x0d\xde\x8c\x06\xc3\xd1\xf9\xf0\xec\xf5\xd5\xc5\xf0\xcd\xb0\x7f\xfe\xb6\x03\x4e\x57\xd2\x3e\x04\xed\x26\x79\xcc\x86\x60\x09\xe1\xa1\x96\x59\x6e\xe9\x72\x38\x1c\xd4\xb1\x74\xa1\xef\x60\x8e\x4a\xd8\x0e\xcc\xea\xf9\x63\x94\x72\x7b\x6f\xaf\x5e\xbf\x8b\xed\x09\xd2\x51\x7a\x3e\x54\x25\x1f\x47\x9d\x9d\x05\x65\x26\xd1\x3b\x57\xa6\xca\x8d\x96\x3a\x9a\x3c\x83\x49\xb1\xf4\x24\xa9\x87\x06\xbd\xe8\x1c\x52\x0e\x97\x77\x48\x54\x07\xad\x40\x2d\xef\x94\xa5\x01\xb5\x15\x06\xde\xe3\x53\x55\x6f\xc6\x6a\x22\x71\xe5\x74\x58\x27\x8d\x82\x99\xaa\x81\x90\x57\x1a\x26\x8e\x0f\x7a\xdd\xbe\xb5\x89\xb0\xcb\xd8\xbb\x4e\xe0\x85\x5d\x6a\xfd\x90\xe4\xc8\x4f\x77\x7f\x92\xa7\x44\xe5\xcd\x4c\x9d\x6b\xf7\x63\x20\xc9\x5a\xdd\x6f\x61\x1b\xcc\xd9\x08\x1b\x89\xf1\xed\x6d\x4a\x3f\xd7\x0d\xf4\xe5\x7e\x72\x37\xbe\xff\x81\xfe\xa9\xfc\x40\x5d\xcb\xac\x7b\x20\xd3\x06\x94\x72\x93\x3c\x64\x15\x9c\xac\x0c\x54\x58\x43\x6d\x42\x15\x19\x2d\x03\x5b\xea\xa8\x14\xee\x72\xbf\xbb\x24\x98\x26\xea\xad\xf2\xfd\x98\x11\x2c\x7c\x30\xa5\x10\xa0\xf1\x07\xb2\xc5\x7c\xa2\xfe\x03\x2d\x7d\x8f\x10\xd4\x8d\x85\x7b\x85\x89\x87\xe7\x6a\x30\xb8\x35\xe7\x67\x38\x06\x56\x72\x32\x3f\x3c\xf2\x7c\x8b\x76\xc4\xe6\xbc\x8b\xf4\x55\xf3\x2f\x37\xa7\xf6\x8a\x23\x29\xb7\xce\x75\x12\x34\x73\xe1\xfa\xb3\xfd\x5e\xa8\x13\x60\xf0\xd8\xfd\x9c\xf2\x34\x88\xe4\xf3\x52\xc6\x7f\xde\x61\x72\x2f\xf9\x52\x24\x72\xfd\x30\x32\x34\xea\x34\x8c\x06\x55\xdd\x3d\x1c\x5a\xf5\xb8\xe7\xe1\x12\x08\xd4\xd5\xdd\x76\x50\xc4\x9a\xd3\x40\x04\xd3\xdd\x51\xb8\xf8\x70\x60\x12\x6f\x09\x4e\xac\x59\xf0\x2e\x1c\x09\x28\x7b\x3a\x59\x84\x04\x31\x0c\x38\x82\x36\x80\x28\x86\x72\xd0\x78\x6c\x62\xca\x93\xb0\xff\x7e\x08\x56\x1a\xcf\x43\x56\x79\x1a\x40\xf2\x69\x34\xe3\x31\xdf\xbf\x74\xcc\xdb\x71\xb2\x60\xa1\x1a\x81\xf2\xdc\xf5\xa3\x74\xf9\xcd\x15\xc0\x41\xe3\xf1\xa5\x2c\x29\xdb\x68\x5e\x2f\x0c\x8e\x20\x1c\x5f\x32\x68\x36\xe2\x52\x73\x69\xa0\xfb\x2b\x14\xd9\x06\x20\x12\xac\x81\xa4\xe9\xb2\x29\xb3\x24\xf7\x5f\x9a\x84\x78\x0b\x09\xf4\x05\xa7\x86\x0d\x15\x53\xa9\x0d\xe9\x0e\x16\x08\x49\xdc\x8e\x5f\xeb\x40\xe5\xfe\x36\x40\x2b\xbe\xf3\x0c\x49\xf9\x65\x2f\x59\x1d\x45\xbb\x65\x93\x31\x74\x0c\x3d\x8a\xd5\xe5\x2e\x3c\xb4\x9d\x84\xc2\x05\x0b\x29\x98\xdc\x03\xd5\xa1\xa5\xee\xbb\xbc\x50\x6e\xd2\x37\x6c\x64\xb8\x52\xb2\xd5\x21\xf1\xee\xf2\xbc\x10\x36\xee\x35\x22\x19\x48\xde\x43\xd5\xd1\x26\x13\xc7\x0b\x21\xdc\x7f\x0d\x93\xa1\x12\x0e\x91\x59\xd5\x87\x53\xb5\xf6\x09\x22\x6f\x8b\xdb\x03\xd6\xa5\x89\xac\xd2\x6c\x6f\xd0\x0a\x4f\x94\x19\xac\x82\xa8\x56\xfb\x92\x33\xd6\xd6\xe6\x59\x34\x53\x09\x89\x7c\x0b\x4d\xf7\x9b\xed\x17\x58\xd1\xda\xd1\xbd\x2f\x08\x9b\x64\xdf\x54\x24\x63\xbc\xbe\xa4\xf4\x67\x43\x19\x28\xb1\x20\x6d\x5e\xba\xdd\xe4\xe2\x4d\xff\xc3\x07\xd4\x61\xd4\x86\x46\x80\x05\xd7\xeb\x82\x9c\x74\x46\xa3\xe0\x3b\xf0\xe9\x69\x0f\x89\x05\x0d\x6a\x56\x13\xb4\x18\xdb\x11\x4f\x2c\xba\xa4\xbb\xf5\xc6\xaf\x64\x3e\x23\x5a\xee\x40\x46\x34\xe7\xc2\x29\xfa\xf6\x59\xb9\x57\xa2\x02\x44\xef\xd1\xc5\x45\x2a\x7d\xa2\x2b\xfd\xc8\xa0\x5b\xd7\x26\x3e\x09\x33\xf1\x57\x00\x00\x00\xff\xff\xbf\xc3\xb7\xc7\xff\x2f\x00\x00")
+var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x5c\xdd\x6f\xdb\x46\x12\x7f\xf7\x5f\xb1\xe8\x8b\x6c\x40\x0a\x2c\x25\x71\x1c\x19\x2d\xa0\xda\x6c\x23\x9c\x22\xb7\x96\x7c\x69\x70\x38\x10\x2b\x72\x25\xb3\x21\xb9\x2c\xb9\x74\x9c\x1e\xee\x7f\xbf\x59\x7e\x48\xfc\xd8\xe5\x2e\x65\xd2\xd7\x87\xe6\xc5\x10\x77\x38\x33\xbf\x99\xd9\xd9\xd9\xe1\x20\xa3\xd1\xc9\x68\x84\x7e\xa1\x11\xdb\x85\x64\xf5\xeb\x02\xd9\x98\xe1\x0d\x8e\x08\xb2\x63\x2f\x80\xb5\x93\x93\x95\xb1\x46\x11\xc3\x8c\x78\xc4\x67\x26\x73\x3c\x42\x63\x86\xbe\x47\xe7\x57\xc9\x92\x4b\xad\x2f\xf5\xa7\x96\xeb\x70\x6a\xe2\x5b\xd4\x76\xfc\x1d\x2c\x0c\xee\xd7\x3f\x5d\x0e\xae\x72\x76\xbe\x8d\x43\xdb\xb4\xa8\xbf\xa5\xa1\x07\x14\x66\xc4\x42\xf8\x13\x01\x25\xf5\x33\x1e\x0f\x04\x58\x6f\x63\xdf\x62\x0e\xf5\xcd\x0d\x70\x22\x7c\x7d\x8b\xdd\x88\x94\xc4\x00\x03\xd3\x23\x51\x84\x77\x09\xc1\x57\x1c\xfa\xc0\xeb\x2a\xd3\x9d\xe0\xd0\x7a\x30\x03\xcc\x1e\x60\x2d\x88\x37\xae\x63\x0d\x51\xb0\x33\x2d\x80\xea\xd2\x9c\xcc\x26\x5b\x1c\xbb\x00\x10\x6f\x5c\x12\x05\xd8\x22\x5c\xe9\x41\x65\xf5\xab\xc3\x1e\x4c\xea\xd8\x05\x3d\xb8\x91\xc0\x86\x4b\xec\x91\x29\xc2\x51\x44\x98\xc9\xcd\x15\x5d\xa1\xf5\xb7\x00\x1e\xad\x67\x3f\x2e\x8c\x2b\xb4\x02\x38\x1e\x9e\x66\x0a\x5c\xa1\xdb\xaf\x3e\x09\xa7\x68\x04\x64\x7b\x89\x53\x94\x58\xfc\xfa\xce\x98\xad\x8d\xf4\xc5\x22\x47\x74\x7a\x82\xe0\x5f\xfa\x84\x01\x73\x30\x11\x0e\xb1\xc5\x48\x88\x1e\x71\xf8\x0d\x30\x9f\x5e\xbc\x39\x43\xcb\xdb\x35\x5a\xde\x2f\x16\xc3\x02\x39\xf8\x41\x44\x3e\x9e\x88\xc9\x9d\x28\x8a\x81\xac\xfe\xc2\xdb\x8b\xda\x0b\x1e\x8d\x7d\x86\x36\xce\xce\x81\x3f\xe5\x35\x3f\xf6\x4c\x6c\x59\x9c\x20\x42\xb0\x4c\x76\xc0\xaa\x4c\xb2\x75\xf1\xae\xbe\x76\x72\x06\x86\x95\x59\xd6\x74\x89\x0d\xc4\xdd\x1b\x38\x63\x9c\xd9\x39\xfd\x61\x46\xe4\x8f\x18\xe2\x98\x68\xe8\xb8\xa3\x61\x00\xc1\xb8\x0b\x31\x8f\xd8\xae\x22\xa0\xc2\x35\xd3\xce\xb1\x11\x23\x4f\x55\x83\xe3\x20\x80\x2d\x61\x9b\x98\x21\xbe\x27\x01\x95\x17\x20\x1e\xb4\xc9\x4f\xf4\x27\xf5\x49\x5d\xed\x07\x27\x62\x34\xfc\xb6\xf7\x94\xe9\xd8\x1c\x75\xae\xfe\xca\xf8\xf5\xde\x58\x5e\x37\x20\x28\xea\x9c\x53\xcb\xb8\x26\x6a\xae\xd6\xb3\xbb\x35\xfa\x34\x5f\x7f\x40\xe3\xe4\xc1\x7c\x09\xaf\x7f\x34\x96\x6b\xf4\xe3\xe7\xec\xd1\xf2\x16\x7d\x9c\x2f\xff\x39\x5b\xdc\x1b\xfb\xdf\xb3\xdf\x0e\xbf\xaf\x67\xd7\x1f\x0c\x34\x56\x81\xe9\xc8\x09\x55\xb6\x07\x2f\x64\x81\x7f\x63\xfc\x34\xbb\x5f\xac\x91\x0f\x4e\x79\xc4\xee\xe9\x40\x82\x7f\x30\x9d\x86\x64\x67\xb9\x10\x76\xb5\x9d\x64\xdb\x21\x64\x31\xf1\xae\x4e\x49\xac\x90\x40\x26\xb6\xf3\x40\xcd\x42\xb2\xbc\x56\xf3\x3d\xcf\xcc\x1a\xee\x27\xdb\x2d\xb1\x3a\x37\x58\xc6\x35\xb3\x57\xc5\x28\xe6\xc1\x7e\x65\x53\xe4\x74\x34\x20\x69\xd8\x4b\x29\xbf\xa3\xa1\x4d\xc2\xef\x24\xd9\x25\xc9\x92\xe2\x25\x9b\x30\xec\xb8\x11\xfa\x3d\xa2\xfe\x46\x6e\x95\x2c\x0b\xc4\x01\xec\x3f\x9b\x74\x6d\x9d\x0a\xf7\x8a\x95\xb2\x55\x19\x74\x55\x82\x2a\xa4\x04\x2b\x35\x62\x62\xab\xf6\xa6\x82\x78\x8e\x49\x55\x07\x95\xc9\xfa\x31\x55\x6e\x22\x05\xe8\xcc\x34\x0f\x38\x7a\xd0\x3a\x24\x83\x90\x3c\x3a\x34\xce\x4f\x80\x86\x17\x33\x63\x85\xd8\x8f\x70\x5a\x98\x24\x91\xbc\xd7\x23\xcf\x03\xe7\x15\x09\x87\x48\xd6\xa3\xb7\x5c\x1a\x29\x37\x73\xf5\x1d\xad\x0c\x90\xd2\xc6\x81\xad\x4d\xbb\x0f\xc0\xec\xa7\x17\xd0\x10\xcc\x62\x3e\x82\x3f\x00\x51\x0d\xcb\xb8\x1a\x5a\x14\x2a\x2d\xc0\xed\xc0\xe9\x25\x8c\xe4\x2d\x21\x66\x40\xa9\x2b\x5e\xe5\xf5\xa8\x09\x24\x12\x5f\x27\xcb\x90\x38\x49\xf8\x28\x23\xf1\xf0\x93\xc9\x9e\xcc\xe4\xa0\x77\xfe\x94\x51\xa5\x6a\xee\x33\x7c\x11\x72\xba\xc4\xc2\x38\x62\xae\xe3\x13\xd1\xe2\xde\xc1\xe5\xc5\x20\xa4\x8c\x5a\xd4\xad\x1a\x2b\x03\x0e\x29\x08\x9c\x20\x0d\xa7\xcc\xe0\x3e\x14\xb6\xcc\xb4\xe3\x2c\x82\xbc\x7d\xc1\x24\xdf\x84\x87\x78\x4b\x0a\x9c\xae\x77\x63\x95\x7d\x25\x73\xa9\xf3\xf6\x5f\xa6\x8a\xd5\x31\x61\x80\x43\xe6\x58\x4e\x80\xfd\x1e\x0d\x59\x14\x72\x28\x2f\xc4\xa1\xaa\x6f\x67\xf5\x89\xdb\xd6\x00\xdd\x96\x87\x8d\x32\x5e\xaa\x58\x6c\x05\x14\xdd\x7e\x5a\x1a\x37\x20\x5b\x81\x78\xb6\x58\x1b\x77\x2d\x01\xef\x79\x2b\xc8\x5f\x39\xb6\x12\x4b\x6f\x91\x5a\x2f\x7e\x2b\x79\xb4\x90\xcd\xa4\xdb\xff\xf9\x55\x49\xa9\x80\x4b\x1f\x45\x34\x0e\x2d\x92\xc7\xba\x24\xb1\xe4\xa7\xd4\x00\x4a\xf1\x1a\x85\xc6\xae\x28\xc2\xeb\x31\x31\xc8\xc4\xe8\xa6\x06\x1d\x2f\x3c\x27\x39\xc8\xf4\xeb\x36\x3d\x28\xa4\xbc\x54\x82\x68\x09\xf6\x99\x29\x42\x21\xad\x9e\x24\x64\x2f\x34\xa4\x89\xc2\x2b\x3d\x46\x6e\x1e\xad\x45\x05\xb5\x8b\xf2\x6e\xef\x37\xcd\x49\x41\x48\x7b\x10\x2d\xaf\x5a\xb1\x74\x23\xca\x2a\xfe\xff\x4b\xcd\x0e\xd5\x2f\xf1\x1f\x89\x0b\x4a\x89\xfa\x46\xb0\x0c\x15\x74\xec\x32\xc9\xa2\x07\xb9\x56\xb2\xc4\xad\x20\x5b\x8e\x9c\x9d\x8f\x59\x0c\xac\x05\x66\x7f\x7f\x71\xf6\xaf\x7f\x1f\xb2\xf1\x7f\xfe\x2b\xca\xc7\x40\x51\x29\xe5\x89\x47\x25\x65\xe3\x81\x97\x0f\x66\x68\xcc\xee\x07\x5e\x75\x36\x19\x32\x30\xa7\xb9\x01\xc7\xd9\x49\xb1\x7d\x09\x01\xbc\xcb\x4c\x1b\xc5\x96\x45\xa2\x68\x1b\xc3\x7d\x05\x2e\x2d\x04\xfb\xf5\x2c\x09\x1b\x2f\xdb\x54\x99\x52\x5a\x99\x20\xdd\x47\xb7\xcb\x85\xea\xfc\x47\x29\xfd\xf5\xed\xe2\xfe\xe3\x92\xfb\x9a\x37\xa9\xa5\x2d\xa8\xc6\x92\xa3\xd8\x90\xea\x0d\x85\xf4\x30\x6b\x85\x43\x91\x17\xc5\x48\x6e\x30\xc4\xe6\x96\x86\x1a\x3d\x5a\x74\x33\x5b\xcf\x14\x10\xe7\xcb\x95\x01\xa7\xcd\x7c\xb9\xbe\xad\x75\x66\x93\xe3\x64\x85\x4e\x07\x63\xd3\xf1\x1d\xe6\xc0\xad\x30\x4a\x78\xbd\x8a\xfe\x70\x07\x43\x34\x98\x9c\x8f\x2f\x46\xe7\x17\xa3\xc9\x25\x1a\xbf\x9d\x8e\x27\xd3\xf3\xc9\xab\x37\x97\xaf\x27\x6f\x27\xa3\xf3\x77\x03\x50\x5a\x8b\xfb\x04\xb8\xdb\xe4\xa9\x6c\x82\x0d\x98\x87\x3a\x76\xb3\xa4\x8b\xc9\x64\xdc\x46\xd2\x6b\x33\x86\xbb\x75\x9e\x05\x41\xac\x59\xed\x6a\x36\xcb\x7b\x77\xf9\xe6\x7d\x1b\x79\x6f\x4c\x6c\xdb\xa6\xa4\x39\xd6\xad\xa8\xb7\x25\x51\xd5\xeb\x6c\xb7\xb2\x2e\x44\xb0\x92\xae\x41\xc7\x82\xde\x95\x04\xe5\xa7\x58\x72\xc4\x00\x61\xb7\xb2\x2e\x13\x59\x85\xaf\x27\xdd\xb2\x7f\x5f\x82\x52\xdc\xf9\x87\xf4\xdb\xad\xc4\xf1\xb9\xc8\x4d\x3d\x40\x1b\x8f\xab\xa6\xcb\x84\x75\x2c\x26\xcf\x13\x8c\x6f\x9f\xbc\x11\xcf\x13\x05\x14\x2d\xfa\xb2\x24\x79\xb4\xf1\x3b\x8b\x4e\x22\x3d\xea\x1b\x14\x3f\x1f\x14\x7c\x57\xc6\xc2\xb8\x5e\x17\x3e\xf0\xbe\x02\x33\x37\x7e\x91\x19\xa2\xf1\x30\xfd\x9a\xab\x86\x2b\xfa\x48\xd2\x06\xad\x84\x6d\xd3\x57\x86\xce\xd8\x77\xce\xb6\xb1\xc7\xd8\x29\x7f\xe9\x3d\xfb\xf8\x48\x6b\xd7\xf3\xe9\x22\xee\x9a\xcb\xb0\x36\x51\x28\xe9\xf1\x74\x60\x72\xad\xe6\xc6\xf1\x46\x6f\x7b\x8f\xee\xc2\xec\xaa\xaa\xb1\x8d\xe1\xa5\xb7\xe6\xf6\x26\xa9\xe4\x6d\x33\xf8\x42\xbe\xe5\x2c\xaf\x6f\x97\xab\xf5\xdd\x0c\xf2\x7b\xab\xdb\x78\xad\xfc\xae\xc8\x48\x6e\x2f\xb3\x9b\x9b\x02\x7f\xa1\x1a\xe8\x97\xbb\xf9\xc7\xd9\xdd\x67\xf4\x0f\xe3\x33\x3a\x75\xec\xb6\x0d\xe2\x3e\xa0\x34\x8b\x14\x21\xd3\x50\x52\x1b\xa8\x34\x86\xfa\x84\x2a\x13\xda\x04\xb6\x51\x51\x25\xdc\xcd\xfe\x70\xcc\x31\xcd\x97\x37\xc6\x6f\xc7\xb4\x84\x92\x17\x0b\x0c\x01\x9a\xb8\x41\x74\xbf\x9a\x2f\x7f\x46\x1b\x16\x12\x82\x4e\x33\xe2\x61\xad\x03\x23\x52\x95\x37\x92\xba\xd3\x33\x69\x4b\x69\x29\x59\x6d\x66\x89\x74\x2b\x8f\x28\x3d\x5f\xbb\x6c\xe0\x43\x4b\xbf\x4a\xdf\x6c\x58\x6f\x91\x09\xe3\xdc\x24\xbc\x36\x4c\xd6\x9f\xad\xf7\xfd\x72\x0e\x19\x3c\x53\xbf\xc2\xbc\x08\x22\x9f\x0e\x29\xe9\x2f\xfa\xb8\x35\xcc\x07\x3d\x64\xaa\x1f\x5a\x15\x9d\x2a\xed\xd8\xda\xea\x1e\x9a\xe8\x43\x74\x04\x04\x1a\x98\x41\x3f\x28\x32\xce\x45\x20\x92\xae\xd2\x51\xb8\xc4\x70\xd8\x53\x5f\x70\x32\xce\x92\xbd\x70\x24\xa0\xf2\xd7\x92\x3a\x24\xb0\x21\xcf\x11\xb4\x03\x44\x19\x94\x03\xc7\x63\x1d\xd3\xec\x84\xfd\x2c\x0b\x48\xe9\xdc\x0f\x65\xe6\x45\x00\xf9\x98\x4e\x49\x63\xb1\x7e\x45\x9b\xf7\xa3\x64\x4d\x82\x5e\x02\x15\xa9\xcb\x52\x77\xb1\xee\x02\xe0\xc0\xf1\xf8\x50\x56\x84\x6d\x7a\xff\x2f\x36\x1a\xf8\x5d\xcd\xeb\xf0\x80\x6f\x90\xc0\x51\x15\xa7\x97\xcb\x07\xbd\x97\x9d\xf3\xfb\xb9\x8e\x61\x69\x68\x43\x13\x0a\xff\xd9\x6d\xd4\xc8\xe5\x34\xe3\x79\x16\x8e\x94\xb6\x4f\x97\x64\xa3\x30\x6a\x08\x6d\xd4\x2e\x8e\x77\xf7\xa9\x7c\x69\x8c\xbc\x09\x42\x91\xb0\x75\x6c\xd5\xda\x43\xdc\xf1\xe9\x48\x6e\x1f\x21\xd6\x20\xae\x98\x0f\xf6\xb8\xcb\xbe\x4a\x09\x5b\x20\xe9\x3a\xbb\x36\x49\x52\xeb\x2f\xcd\x55\x95\x4a\x8b\xf3\xe3\x1f\xfb\x3a\x8d\x2e\x89\x0c\x65\xa1\xc7\x89\x14\x6a\x57\xfa\x78\x9c\x75\xa5\x1a\xef\xd3\x0b\x6a\xe9\xf5\x93\xfa\x30\x7b\xfc\xdc\x3b\x84\x48\x97\x44\x87\xfd\x28\x6b\x2f\x5e\x14\x09\x52\x16\x24\x7b\x4a\x7d\x14\xfd\x6e\xa0\x92\xa0\x63\xea\x29\x39\xbb\xca\xb4\x6e\xdf\x4e\xa8\x4d\x07\x2b\xc1\x54\x5e\xd0\x87\x56\x18\xd6\x7e\x21\xdf\x14\xc7\xc3\x55\xb8\x0a\xb4\xfa\x90\x44\x83\xe8\x2f\x84\x4d\x38\x03\xaf\x02\x29\x7a\x49\x1f\xed\xcb\x25\xc5\x92\x38\x25\x2a\x69\xd7\xa9\xcc\xba\xfa\xfd\xa3\xd7\x92\x54\x29\x54\x7c\x8d\xcc\xc6\xb3\x05\x95\x1e\x3f\xce\xe4\x45\x92\xe6\x5d\x5f\xad\xdb\xfe\x59\x2f\x89\xa7\x51\xa2\xbe\x45\x9e\x83\xf5\x05\x4e\x87\xaa\x2c\x21\xb0\xb6\x67\x44\x99\x69\xf9\x26\xd9\xaf\xaf\x04\x02\x75\x10\x69\x5d\x76\x25\xc2\xfa\xaa\x21\xeb\x62\xb4\x90\xa8\x2b\xc9\x62\x77\xa2\xff\x00\xab\x4b\x3b\xba\x53\x52\x9b\x36\x48\xaa\x45\x73\x43\xe9\x97\x8e\x3c\xd0\x20\x41\x59\xc3\x9f\x9e\xe6\x63\xe3\xa3\x1f\x7e\x40\x83\x88\xba\xf9\x20\x06\xf7\xc9\x60\x3a\xe5\x53\x8c\x67\x67\x43\x24\x27\xe4\xb9\x52\x8b\x30\x4d\xa4\x72\xd2\x0d\x8d\x77\x0f\x4c\x4b\x7c\x89\xb4\x59\x81\x12\x69\x45\x85\x33\xf4\xe9\x83\x71\x67\xa4\x01\x88\xbe\x47\xaf\x5f\xab\xdd\xc7\x87\x45\x4c\xec\xdb\x9d\x27\x72\x0d\x49\x7f\xbb\xb3\xd1\x9d\x92\x33\xba\xc9\xc9\xb2\xff\xea\x01\x59\xd4\x0b\x5c\xc2\x48\xe2\xa7\xff\x01\x61\xcc\xca\x20\x17\x42\x00\x00")
 
 func latestSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -86,7 +95,7 @@ func latestSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "latest.sql", size: 12287, mode: os.FileMode(420), modTime: time.Unix(1467151922, 0)}
+	info := bindataFileInfo{name: "latest.sql", size: 16919, mode: os.FileMode(420), modTime: time.Unix(1792154370, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -151,6 +160,186 @@ func migrations3_use_sequence_in_history_accountsSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations4_add_history_ledger_upgradesSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7d\x90\xcd\x0a\xc2\x30\x10\x84\xef\x79\x8a\x3d\x2a\xda\x27\xe8\xa9\xda\x20\x85\x92\x6a\x6d\xc0\x5b\xa8\x66\xa9\x81\x9a\xc6\x34\xf5\xe7\xed\x0d\xa2\x20\xd1\xba\xc7\x9d\x6f\x97\x99\x89\x22\x98\x9d\x54\x63\x6b\x87\xc0\x0d\x59\x96\x34\xa9\x28\x54\xc9\x22\xa7\x70\x54\xbd\xeb\xec\x5d\xb4\x28\x1b\xb4\x62\x30\x1e\x93\xd8\xc3\x84\x80\x9f\x40\x55\x12\xf6\xaa\x51\xda\x01\x2b\x2a\x60\x3c\xcf\xe7\x4f\xec\x25\xf7\x78\x1e\x50\x1f\x10\x3c\x81\x7e\x11\x50\xb5\x31\xad\x3a\xd4\x4e\x75\x5a\x74\x56\x7a\xfd\x37\xe7\xee\x66\xec\xc5\xa5\x6e\x07\x0c\x3d\x90\x69\xfc\xce\xc4\x59\xb6\xe1\x14\x32\x96\xd2\x9d\x7f\x21\xf1\x26\x46\x02\x0a\x6f\x22\xb4\x5d\xb0\xd1\x3a\xf8\x36\x63\x2b\xd8\x3b\x8b\x08\x93\xe0\x6e\xfe\x9d\xcc\x3b\x22\xd1\x47\xeb\x69\x77\xd5\x24\x2d\x8b\xf5\xff\xd6\x63\xf2\x00\x24\x8e\x9c\xf2\xab\x01\x00\x00")
+
+func migrations4_add_history_ledger_upgradesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations4_add_history_ledger_upgradesSql,
+		"migrations/4_add_history_ledger_upgrades.sql",
+	)
+}
+
+func migrations4_add_history_ledger_upgradesSql() (*asset, error) {
+	bytes, err := migrations4_add_history_ledger_upgradesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/4_add_history_ledger_upgrades.sql", size: 427, mode: os.FileMode(420), modTime: time.Unix(1792139621, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations5_add_history_operation_assetsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8d\x92\x41\x8b\xc2\x30\x10\x85\xef\xf9\x15\x73\xac\xd8\x1e\x94\xd5\x8b\x27\xd7\x06\x29\x94\xd4\xd5\x06\xf6\x16\x62\x1b\xea\x1c\x4c\x4a\x92\x55\xfb\xef\xb7\x08\x5a\x17\x5b\xb6\x39\x0d\x99\xef\x31\xef\x0d\x13\x45\x30\x3d\x63\x65\xa5\x57\xc0\x6b\xb2\xd9\xd3\x75\x4e\x21\x5f\x7f\xa6\x14\x4e\xe8\xbc\xb1\x8d\x30\xb5\x6a\xfb\x68\xb4\x90\xce\x29\xef\x20\x20\xd0\xbe\xf7\x36\x96\x70\xc4\x0a\xb5\x07\x96\xe5\xc0\x78\x9a\x86\x77\xf2\x2e\x13\xbe\xa9\x15\x14\x27\x69\x65\xe1\x95\x85\x8b\xb4\x0d\xea\x2a\x58\x7e\x4c\x7a\xf1\xc2\x94\x7d\xf8\x6c\xde\x8f\xa3\x73\x3f\x2d\xf6\x2e\x58\x2c\x3b\x01\x99\xac\x1e\x11\x39\x4b\xbe\x38\x85\x84\xc5\xf4\x1b\x50\x97\xea\x26\x86\xf2\x8a\x47\x05\x19\x1b\x5e\x0a\x3f\x24\x6c\x0b\x47\x6f\x95\x82\xa0\x4b\x1c\xbe\xc4\x09\xff\x78\x0d\x7b\x37\xd8\x39\x1c\x6b\xed\xf9\x37\xde\xde\xc0\x60\x12\xbd\x5c\x43\x6c\xae\x9a\xc4\xfb\x6c\xf7\xcf\x35\xac\xc8\x2f\x39\xf6\xfc\xb5\x44\x02\x00\x00")
+
+func migrations5_add_history_operation_assetsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations5_add_history_operation_assetsSql,
+		"migrations/5_add_history_operation_assets.sql",
+	)
+}
+
+func migrations5_add_history_operation_assetsSql() (*asset, error) {
+	bytes, err := migrations5_add_history_operation_assetsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/5_add_history_operation_assets.sql", size: 580, mode: os.FileMode(420), modTime: time.Unix(1792141800, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations6_add_history_ledger_totalsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9d\xcf\x41\x0e\xc2\x20\x10\x85\xe1\x3d\xa7\x98\xbd\xe1\x04\xac\x50\xba\x43\x6b\x9a\x76\xdd\x60\x25\x38\x09\x32\x0d\x4c\x63\xbc\xbd\xee\x70\x61\x4c\xf0\x00\xdf\xcb\xfb\xa5\x84\xdd\x1d\x43\x76\xec\x61\x5a\x85\xb6\x63\x37\xc0\xa8\xf7\xb6\x83\x1b\x16\xa6\xfc\x9c\xa3\xbf\x06\x9f\x0b\x68\x63\xe0\xd0\xdb\xe9\x78\x02\x26\x76\x71\x76\xcb\x42\x5b\xe2\x02\x17\x0c\x98\x58\xb5\x69\xce\x5b\xe1\x88\xc9\xff\xe9\x69\xf5\xef\xd7\x48\xa9\x7a\x21\x3f\x6a\x0c\x3d\xd2\xcf\x45\x33\xf4\xe7\xef\x41\xaa\xd1\xd5\x94\x56\x59\x23\x94\x78\x01\xf9\xf7\x21\x81\x8b\x01\x00\x00")
+
+func migrations6_add_history_ledger_totalsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations6_add_history_ledger_totalsSql,
+		"migrations/6_add_history_ledger_totals.sql",
+	)
+}
+
+func migrations6_add_history_ledger_totalsSql() (*asset, error) {
+	bytes, err := migrations6_add_history_ledger_totalsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/6_add_history_ledger_totals.sql", size: 395, mode: os.FileMode(420), modTime: time.Unix(1792146738, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations7_add_history_account_creationSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x95\x8e\xb1\x0a\x83\x30\x14\x45\xf7\x7c\xc5\xdd\x4b\xbe\x20\x53\xda\xb8\xd9\x5a\x44\x67\x09\xf1\xa1\x81\x26\x91\xe4\x89\xb4\x5f\x5f\xa1\x4b\x87\x52\x70\xba\x70\xe1\x70\x8e\x94\x38\x05\x3f\x65\xcb\x84\x7e\x11\xba\xee\xaa\x16\x9d\x3e\xd7\x15\x66\x5f\x38\xe5\xe7\x60\x9d\x4b\x6b\xe4\x02\x6d\x0c\x2e\x4d\xdd\x5f\x6f\x70\x99\x76\x62\x1c\x1e\x34\x4e\x94\xe1\x23\xd3\xbe\xea\x30\x6f\x19\xec\x03\x15\xb6\x61\xc1\xe6\x79\x4e\xeb\xe7\xc1\x2b\x45\x52\x42\xc8\xaf\x3e\x93\xb6\xf8\xdf\x60\xda\xe6\xfe\x3b\x51\x1d\x07\x2d\x2b\xf1\x06\x9b\x41\x06\x57\x1f\x01\x00\x00")
+
+func migrations7_add_history_account_creationSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations7_add_history_account_creationSql,
+		"migrations/7_add_history_account_creation.sql",
+	)
+}
+
+func migrations7_add_history_account_creationSql() (*asset, error) {
+	bytes, err := migrations7_add_history_account_creationSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/7_add_history_account_creation.sql", size: 287, mode: os.FileMode(420), modTime: time.Unix(1792148273, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations8_add_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xa5\x92\x51\x6b\xc2\x30\x10\xc7\xdf\xf3\x29\xee\xb1\xb2\xf6\x61\x63\xf3\xc5\x27\xb5\x61\x14\x4a\x3a\x5d\x03\xbe\x95\x18\xb3\x2c\xb0\xa6\x92\xa4\x53\xbf\xbd\xd9\x74\xd8\x50\xa9\xc8\xf2\x12\x72\xf7\xbb\xff\x71\xff\x5c\x92\xc0\x43\xad\xa4\x61\x4e\x00\xdd\xa2\xf9\x12\x4f\x4b\x0c\xe5\x74\x96\x63\x60\xd6\x0a\x57\x59\xc7\x9c\x85\x08\x81\x3f\xa7\x88\x3b\x6c\x05\xf0\x4f\x66\x18\x77\xc2\xc0\x37\x33\x07\xa5\x65\x34\x7e\x1e\x01\x29\x4a\x20\x34\xcf\xe3\x0e\xce\x9b\xcd\x35\xfc\xf1\xe9\x3a\xae\xac\x6d\x3d\xd6\x2f\x78\x19\xf7\x0a\xea\xa6\xd5\x0e\xd6\x4a\x2a\x7f\x85\x39\xdd\xd6\x15\xe3\xfc\x07\xb0\xe0\xd3\x42\x7a\xa9\x10\xf9\xf8\x62\xb2\x9f\x43\xa3\xc9\x9f\x0d\x94\x64\x0b\x8a\x21\x23\x29\x5e\x79\x6e\x23\xf6\x55\xc7\x93\xaa\xd1\xa7\x27\x14\x24\xf0\x8a\xbe\x67\xe4\x15\xd6\xce\x08\x01\xd1\xc5\x83\x38\x18\xf0\xd2\x65\x40\xfe\xec\xc5\x6d\xfd\x3b\x34\x03\x63\x86\x94\xbb\x60\x0c\xff\x9a\xe3\xfc\x51\x83\x73\xfc\x22\x83\x7d\x50\xd2\x59\xd6\xb4\xd9\x69\x94\x2e\x8b\xb7\xfe\xb2\x4e\xd0\x11\xa2\x6f\x2b\xf6\xd6\x02\x00\x00")
+
+func migrations8_add_asset_statsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations8_add_asset_statsSql,
+		"migrations/8_add_asset_stats.sql",
+	)
+}
+
+func migrations8_add_asset_statsSql() (*asset, error) {
+	bytes, err := migrations8_add_asset_statsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/8_add_asset_stats.sql", size: 726, mode: os.FileMode(420), modTime: time.Unix(1792148467, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations9_add_history_transaction_successfulSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\xcc\x2b\x4e\x4c\x2e\xc9\xcc\xcf\x2b\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x2e\x4d\x4e\x4e\x2d\x2e\x4e\x2b\xcd\x51\x48\xca\xcf\xcf\x49\x4d\xcc\xb3\xe6\xe2\xd2\x45\x32\xcf\x25\xbf\x3c\x8f\xb0\x89\x2e\x41\xfe\x01\x98\x46\x5a\x73\x01\x00\x6e\xa3\x33\x50\x9a\x00\x00\x00")
+
+func migrations9_add_history_transaction_successfulSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations9_add_history_transaction_successfulSql,
+		"migrations/9_add_history_transaction_successful.sql",
+	)
+}
+
+func migrations9_add_history_transaction_successfulSql() (*asset, error) {
+	bytes, err := migrations9_add_history_transaction_successfulSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/9_add_history_transaction_successful.sql", size: 154, mode: os.FileMode(420), modTime: time.Unix(1792150052, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations10_add_history_ledger_statsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x95\xcf\xbd\x0e\x82\x30\x10\xc0\xf1\x9d\xa7\xb8\xdd\xf4\x09\x98\xaa\x65\x43\x31\x04\xe6\xa6\x29\x15\x2f\x81\x1e\x69\x0f\x8d\x6f\x6f\x9d\x34\x31\x98\xba\xde\xc7\x2f\xf9\x0b\x01\xbb\x19\xc7\x60\xd8\x41\xbf\x14\xb2\xee\xaa\x16\x3a\xb9\xaf\x2b\xb8\x62\x64\x0a\x0f\x3d\xb9\x61\x74\x21\x82\x54\x0a\x0e\x4d\xdd\x1f\x4f\xb0\x04\x62\xb2\x34\xe9\x5b\x5a\x20\x79\x40\xcf\x2e\x1d\x95\xb9\xc0\xc5\x60\x9a\x6a\x0e\xc6\x47\x63\x39\x11\xda\xd2\xea\xf9\x6f\x08\xfd\xe8\x22\xeb\x61\x4d\x05\x2f\x65\x8e\x6f\xa2\x10\x1f\x71\x8a\xee\xfe\x27\xaa\xda\xe6\xbc\xd5\x57\x66\x7f\x6e\x85\xe5\x0b\xdf\x45\x65\xf1\x04\xa1\x51\xa3\x65\xa6\x01\x00\x00")
+
+func migrations10_add_history_ledger_statsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations10_add_history_ledger_statsSql,
+		"migrations/10_add_history_ledger_stats.sql",
+	)
+}
+
+func migrations10_add_history_ledger_statsSql() (*asset, error) {
+	bytes, err := migrations10_add_history_ledger_statsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/10_add_history_ledger_stats.sql", size: 422, mode: os.FileMode(420), modTime: time.Unix(1792151631, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations11_add_asset_stats_ledgerSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x75\x4f\xbd\x0e\x82\x30\x18\xdc\xfb\x14\x37\x6a\xb4\x4f\xc0\x84\x52\x27\x04\x43\x60\x26\x05\x3e\xb1\x11\x0a\xb6\x1f\x31\xbe\xbd\x18\x8c\x61\xf1\xb6\xcb\xe5\xfe\xa4\xc4\xae\x37\xad\xd3\x4c\x28\x46\x71\xcc\x54\x98\x2b\xe4\xe1\x21\x56\xd0\xde\x13\x97\x9e\x35\xfb\xb2\xa3\xa6\x25\x87\x8d\xc0\x8c\x85\x94\x9e\x1e\x13\xd9\x9a\x60\x2c\xd3\x47\x4d\xd2\x1c\x49\x11\xc7\x62\x1b\x08\x21\xe5\x3a\x00\xc6\xc3\x51\x35\x99\x8e\x51\xbd\x66\x47\x4b\x9e\xcd\x60\xf7\xd0\xb6\x01\xdf\xc8\xe2\x4e\x23\xa3\x9e\x9c\x23\xcb\xb8\xba\xa1\x07\xe9\xfa\xf6\x2d\x13\x91\x8a\xd5\xbc\xec\x94\xa5\xe7\x75\xee\x52\xf4\xbb\x10\x0d\x4f\x2b\xa2\x2c\xbd\xfc\xbd\x10\x88\x37\x5d\xd8\xaa\x90\xf3\x00\x00\x00")
+
+func migrations11_add_asset_stats_ledgerSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations11_add_asset_stats_ledgerSql,
+		"migrations/11_add_asset_stats_ledger.sql",
+	)
+}
+
+func migrations11_add_asset_stats_ledgerSql() (*asset, error) {
+	bytes, err := migrations11_add_asset_stats_ledgerSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/11_add_asset_stats_ledger.sql", size: 243, mode: os.FileMode(420), modTime: time.Unix(1792154008, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations12_index_trade_effects_by_pairSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8d\xd1\x41\x0b\x82\x40\x10\x05\xe0\xfb\xfe\x8a\xb9\xa5\x94\x27\x6f\x49\x41\xe4\x52\x5d\x2c\xac\xa8\xdb\xb2\xba\xa3\x2e\x94\x2b\xbb\x23\xe5\xbf\xaf\x0e\x45\x11\x85\xf7\x6f\x98\xc7\x7b\x41\x00\xc3\xb3\x2e\xad\x24\x84\x7d\xc3\xe6\x29\x9f\xed\x38\xac\x92\x98\x1f\x81\xac\x54\x28\xb0\x28\x30\x27\x27\xb2\x4e\x34\x52\x5b\x21\x6b\x25\x4c\x83\xf7\x0b\x6d\x6a\x58\x27\x50\x69\x47\xc6\x76\x4f\x08\xfb\xed\x2a\x59\x40\x46\x16\x11\x3c\xcf\x53\x48\x52\x9f\x1c\x04\xd3\x29\x0c\x9c\x39\x29\x21\x9d\x43\x12\xd4\x35\x38\x18\x8f\x09\xaf\xe4\xfb\x23\xf8\x0d\x73\xa3\xfa\x41\xed\x5c\x8b\xf6\x37\xcd\x4c\x5b\x56\xd4\xeb\xfd\x07\xfd\x1f\xe0\x83\x7e\x45\x78\x96\xf3\x6a\x4c\x68\xe5\xc3\x61\xc9\x53\x0e\xde\x23\x03\x4c\x20\x0c\xfd\x88\xb1\xe0\x6d\x89\xd8\x5c\x6a\x16\xa7\xeb\x4d\xff\x25\x22\x76\x03\x2b\x4e\xc3\xe0\xcb\x01\x00\x00")
+
+func migrations12_index_trade_effects_by_pairSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations12_index_trade_effects_by_pairSql,
+		"migrations/12_index_trade_effects_by_pair.sql",
+	)
+}
+
+func migrations12_index_trade_effects_by_pairSql() (*asset, error) {
+	bytes, err := migrations12_index_trade_effects_by_pairSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/12_index_trade_effects_by_pair.sql", size: 459, mode: os.FileMode(420), modTime: time.Unix(1792154370, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -207,6 +396,15 @@ var _bindata = map[string]func() (*asset, error){
 	"migrations/1_initial_schema.sql": migrations1_initial_schemaSql,
 	"migrations/2_index_participants_by_toid.sql": migrations2_index_participants_by_toidSql,
 	"migrations/3_use_sequence_in_history_accounts.sql": migrations3_use_sequence_in_history_accountsSql,
+	"migrations/4_add_history_ledger_upgrades.sql": migrations4_add_history_ledger_upgradesSql,
+	"migrations/5_add_history_operation_assets.sql": migrations5_add_history_operation_assetsSql,
+	"migrations/6_add_history_ledger_totals.sql": migrations6_add_history_ledger_totalsSql,
+	"migrations/7_add_history_account_creation.sql": migrations7_add_history_account_creationSql,
+	"migrations/8_add_asset_stats.sql": migrations8_add_asset_statsSql,
+	"migrations/9_add_history_transaction_successful.sql": migrations9_add_history_transaction_successfulSql,
+	"migrations/10_add_history_ledger_stats.sql": migrations10_add_history_ledger_statsSql,
+	"migrations/11_add_asset_stats_ledger.sql": migrations11_add_asset_stats_ledgerSql,
+	"migrations/12_index_trade_effects_by_pair.sql": migrations12_index_trade_effects_by_pairSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -251,9 +449,18 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"latest.sql": &bintree{latestSql, map[string]*bintree{}},
 	"migrations": &bintree{nil, map[string]*bintree{
+		"10_add_history_ledger_stats.sql": &bintree{migrations10_add_history_ledger_statsSql, map[string]*bintree{}},
+		"11_add_asset_stats_ledger.sql": &bintree{migrations11_add_asset_stats_ledgerSql, map[string]*bintree{}},
+		"12_index_trade_effects_by_pair.sql": &bintree{migrations12_index_trade_effects_by_pairSql, map[string]*bintree{}},
 		"1_initial_schema.sql": &bintree{migrations1_initial_schemaSql, map[string]*bintree{}},
 		"2_index_participants_by_toid.sql": &bintree{migrations2_index_participants_by_toidSql, map[string]*bintree{}},
 		"3_use_sequence_in_history_accounts.sql": &bintree{migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
+		"4_add_history_ledger_upgrades.sql": &bintree{migrations4_add_history_ledger_upgradesSql, map[string]*bintree{}},
+		"5_add_history_operation_assets.sql": &bintree{migrations5_add_history_operation_assetsSql, map[string]*bintree{}},
+		"6_add_history_ledger_totals.sql": &bintree{migrations6_add_history_ledger_totalsSql, map[string]*bintree{}},
+		"7_add_history_account_creation.sql": &bintree{migrations7_add_history_account_creationSql, map[string]*bintree{}},
+		"8_add_asset_stats.sql": &bintree{migrations8_add_asset_statsSql, map[string]*bintree{}},
+		"9_add_history_transaction_successful.sql": &bintree{migrations9_add_history_transaction_successfulSql, map[string]*bintree{}},
 	}},
 }}
 
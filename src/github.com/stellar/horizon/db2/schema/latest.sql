@@ -15,6 +15,29 @@ SET default_tablespace = '';
 
 SET default_with_oids = false;
 
+--
+-- Name: asset_stats; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE asset_stats (
+    asset_type character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL,
+    amount bigint NOT NULL,
+    num_accounts integer NOT NULL,
+    flags integer NOT NULL
+);
+
+
+--
+-- Name: asset_stats_ledger; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE asset_stats_ledger (
+    ledger_sequence integer NOT NULL
+);
+
+
 --
 -- Name: gorp_migrations; Type: TABLE; Schema: public; Owner: -; Tablespace: 
 --
@@ -43,7 +66,9 @@ CREATE SEQUENCE history_accounts_id_seq
 
 CREATE TABLE history_accounts (
     id bigint DEFAULT nextval('history_accounts_id_seq'::regclass) NOT NULL,
-    address character varying(64)
+    address character varying(64),
+    created_ledger integer,
+    created_at timestamp without time zone
 );
 
 
@@ -60,6 +85,19 @@ CREATE TABLE history_effects (
 );
 
 
+--
+-- Name: history_ledger_upgrades; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE history_ledger_upgrades (
+    history_ledger_id bigint NOT NULL,
+    ledger_sequence integer NOT NULL,
+    application_order integer NOT NULL,
+    type integer NOT NULL,
+    value bigint NOT NULL
+);
+
+
 --
 -- Name: history_ledgers; Type: TABLE; Schema: public; Owner: -; Tablespace: 
 --
@@ -79,7 +117,25 @@ CREATE TABLE history_ledgers (
     fee_pool bigint NOT NULL,
     base_fee integer NOT NULL,
     base_reserve integer NOT NULL,
-    max_tx_set_size integer NOT NULL
+    max_tx_set_size integer NOT NULL,
+    total_accounts bigint,
+    total_trustlines bigint,
+    total_operations bigint,
+    protocol_version integer,
+    failed_transaction_count integer,
+    ingest_duration_ms integer
+);
+
+
+--
+-- Name: history_operation_assets; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE history_operation_assets (
+    history_operation_id bigint NOT NULL,
+    asset_type character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL
 );
 
 
@@ -179,7 +235,8 @@ CREATE TABLE history_transactions (
     signatures character varying(96)[] DEFAULT '{}'::character varying[] NOT NULL,
     memo_type character varying DEFAULT 'none'::character varying NOT NULL,
     memo character varying,
-    time_bounds int8range
+    time_bounds int8range,
+    successful boolean
 );
 
 
@@ -204,6 +261,15 @@ ALTER TABLE ONLY history_transaction_participants ALTER COLUMN id SET DEFAULT ne
 INSERT INTO gorp_migrations VALUES ('1_initial_schema.sql', '2016-06-28 15:12:02.483252-07');
 INSERT INTO gorp_migrations VALUES ('2_index_participants_by_toid.sql', '2016-06-28 15:12:02.486221-07');
 INSERT INTO gorp_migrations VALUES ('3_use_sequence_in_history_accounts.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('4_add_history_ledger_upgrades.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('5_add_history_operation_assets.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('6_add_history_ledger_totals.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('7_add_history_account_creation.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('8_add_asset_stats.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('9_add_history_transaction_successful.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('10_add_history_ledger_stats.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('11_add_asset_stats_ledger.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('12_index_trade_effects_by_pair.sql', '2016-06-28 15:12:02.487849-07');
 
 
 --
@@ -225,12 +291,24 @@ SELECT pg_catalog.setval('history_accounts_id_seq', 1, false);
 
 
 
+--
+-- Data for Name: history_ledger_upgrades; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_ledgers; Type: TABLE DATA; Schema: public; Owner: -
 --
 
 
 
+--
+-- Data for Name: history_operation_assets; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_operation_participants; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -370,6 +448,34 @@ CREATE UNIQUE INDEX hs_transaction_by_id ON history_transactions USING btree (id
 CREATE INDEX htp_by_htid ON history_transaction_participants USING btree (history_transaction_id);
 
 
+--
+-- Name: index_asset_stats_on_amount; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_amount ON asset_stats USING btree (amount, asset_code, asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_asset; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_asset_stats_on_asset ON asset_stats USING btree (asset_code, asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_issuer; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_issuer ON asset_stats USING btree (asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_num_accounts; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_num_accounts ON asset_stats USING btree (num_accounts, asset_code, asset_issuer);
+
+
 --
 -- Name: index_history_accounts_on_address; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -391,6 +497,13 @@ CREATE UNIQUE INDEX index_history_accounts_on_id ON history_accounts USING btree
 CREATE INDEX index_history_effects_on_type ON history_effects USING btree (type);
 
 
+--
+-- Name: index_history_ledger_upgrades_on_ledger_sequence; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_history_ledger_upgrades_on_ledger_sequence ON history_ledger_upgrades USING btree (ledger_sequence, application_order);
+
+
 --
 -- Name: index_history_ledgers_on_closed_at; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -433,6 +546,20 @@ CREATE UNIQUE INDEX index_history_ledgers_on_previous_ledger_hash ON history_led
 CREATE UNIQUE INDEX index_history_ledgers_on_sequence ON history_ledgers USING btree (sequence);
 
 
+--
+-- Name: index_history_operation_assets_on_asset; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_history_operation_assets_on_asset ON history_operation_assets USING btree (asset_type, asset_code, asset_issuer, history_operation_id);
+
+
+--
+-- Name: index_history_operation_assets_on_operation; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_history_operation_assets_on_operation ON history_operation_assets USING btree (history_operation_id);
+
+
 --
 -- Name: index_history_operations_on_id; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -468,6 +595,13 @@ CREATE UNIQUE INDEX index_history_transactions_on_id ON history_transactions USI
 CREATE INDEX trade_effects_by_order_book ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), ((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text))) WHERE (type = 33);
 
 
+--
+-- Name: trade_effects_by_pair_and_operation; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX trade_effects_by_pair_and_operation ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), ((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text)), history_operation_id) WHERE (type = 33);
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE history_ledgers ADD COLUMN protocol_version integer;
+ALTER TABLE history_ledgers ADD COLUMN failed_transaction_count integer;
+ALTER TABLE history_ledgers ADD COLUMN ingest_duration_ms integer;
+
+-- +migrate Down
+ALTER TABLE history_ledgers DROP COLUMN protocol_version;
+ALTER TABLE history_ledgers DROP COLUMN failed_transaction_count;
+ALTER TABLE history_ledgers DROP COLUMN ingest_duration_ms;
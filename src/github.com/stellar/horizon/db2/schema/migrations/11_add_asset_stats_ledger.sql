@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE asset_stats_ledger (
+    ledger_sequence integer NOT NULL
+);
+
+-- asset_stats is rebuilt by ingestion, and then kept current from each ledger
+DELETE FROM asset_stats;
+
+-- +migrate Down
+DROP TABLE asset_stats_ledger;
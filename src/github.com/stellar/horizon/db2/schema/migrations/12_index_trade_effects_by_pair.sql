@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX trade_effects_by_pair_and_operation ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), ((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text)), history_operation_id) WHERE (type = 33);
+
+-- +migrate Down
+DROP INDEX trade_effects_by_pair_and_operation;
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE history_ledger_upgrades (
+    history_ledger_id bigint NOT NULL,
+    ledger_sequence integer NOT NULL,
+    application_order integer NOT NULL,
+    type integer NOT NULL,
+    value bigint NOT NULL
+);
+CREATE UNIQUE INDEX index_history_ledger_upgrades_on_ledger_sequence ON history_ledger_upgrades USING btree (ledger_sequence, application_order);
+
+-- +migrate Down
+DROP TABLE history_ledger_upgrades;
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE history_operation_assets (
+    history_operation_id bigint NOT NULL,
+    asset_type character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL
+);
+CREATE UNIQUE INDEX index_history_operation_assets_on_asset ON history_operation_assets USING btree (asset_type, asset_code, asset_issuer, history_operation_id);
+CREATE INDEX index_history_operation_assets_on_operation ON history_operation_assets USING btree (history_operation_id);
+
+-- +migrate Down
+DROP TABLE history_operation_assets;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE history_ledgers ADD COLUMN total_accounts bigint;
+ALTER TABLE history_ledgers ADD COLUMN total_trustlines bigint;
+ALTER TABLE history_ledgers ADD COLUMN total_operations bigint;
+
+-- +migrate Down
+ALTER TABLE history_ledgers DROP COLUMN total_accounts;
+ALTER TABLE history_ledgers DROP COLUMN total_trustlines;
+ALTER TABLE history_ledgers DROP COLUMN total_operations;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE history_accounts ADD COLUMN created_ledger integer;
+ALTER TABLE history_accounts ADD COLUMN created_at timestamp without time zone;
+
+-- +migrate Down
+ALTER TABLE history_accounts DROP COLUMN created_ledger;
+ALTER TABLE history_accounts DROP COLUMN created_at;
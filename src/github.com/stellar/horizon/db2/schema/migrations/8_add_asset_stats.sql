@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE asset_stats (
+    asset_type character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL,
+    amount bigint NOT NULL,
+    num_accounts integer NOT NULL,
+    flags integer NOT NULL
+);
+CREATE UNIQUE INDEX index_asset_stats_on_asset ON asset_stats USING btree (asset_code, asset_issuer);
+CREATE INDEX index_asset_stats_on_issuer ON asset_stats USING btree (asset_issuer);
+CREATE INDEX index_asset_stats_on_num_accounts ON asset_stats USING btree (num_accounts, asset_code, asset_issuer);
+CREATE INDEX index_asset_stats_on_amount ON asset_stats USING btree (amount, asset_code, asset_issuer);
+
+-- +migrate Down
+DROP TABLE asset_stats;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE history_transactions ADD COLUMN successful boolean;
+
+-- +migrate Down
+ALTER TABLE history_transactions DROP COLUMN successful;
@@ -0,0 +1,60 @@
+package db2
+
+import (
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+)
+
+// SlowQueryThreshold is the duration at or beyond which a statement run by a
+// Repo is logged as slow, at warn level.  Zero disables the slow query log.
+var SlowQueryThreshold time.Duration
+
+// MaxSlowQueryLength is the number of characters of a slow statement's sql that
+// are logged.  Longer statements are truncated.
+var MaxSlowQueryLength = 500
+
+// QueryMetrics counts the statements run by every Repo.
+type QueryMetrics struct {
+	// Queries counts every statement run.
+	Queries metrics.Counter
+
+	// SlowQueries counts the statements that took SlowQueryThreshold or longer.
+	SlowQueries metrics.Counter
+}
+
+// DefaultQueryMetrics counts the statements run by every Repo.
+var DefaultQueryMetrics = QueryMetrics{
+	Queries:     metrics.NewCounter(),
+	SlowQueries: metrics.NewCounter(),
+}
+
+// observe records a statement of type `typ` that took `dur` to run, logging it
+// if it was slow.  Only the number of `args` is logged, never their values,
+// which may include secrets such as account seeds.
+func (r *Repo) observe(typ string, dur time.Duration, query string, args []interface{}) {
+	DefaultQueryMetrics.Queries.Inc(1)
+
+	if SlowQueryThreshold <= 0 || dur < SlowQueryThreshold {
+		return
+	}
+
+	DefaultQueryMetrics.SlowQueries.Inc(1)
+	r.logger().
+		WithField("type", typ).
+		WithField("sql", truncateSQL(query, MaxSlowQueryLength)).
+		WithField("arg_count", len(args)).
+		WithField("dur", dur.String()).
+		Warn("sql: slow query")
+}
+
+// truncateSQL returns `query` shortened to at most `max` characters, with an
+// ellipsis marking any truncation.
+func truncateSQL(query string, max int) string {
+	runes := []rune(query)
+	if max <= 0 || len(runes) <= max {
+		return query
+	}
+
+	return string(runes[:max]) + "..."
+}
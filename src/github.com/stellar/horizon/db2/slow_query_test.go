@@ -0,0 +1,142 @@
+package db2
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/stellar/horizon/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestSlowQueryLog(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// the fake driver is used with postgres bind vars, as horizon's repos are
+	conn, err := sql.Open("slow", "20ms")
+	require.NoError(err)
+	db := sqlx.NewDb(conn, "postgres")
+	defer db.Close()
+
+	out := new(bytes.Buffer)
+	l, _ := log.New()
+	l.Logger.Out = out
+	l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+	l.Logger.Level = logrus.InfoLevel
+	ctx := log.Set(context.Background(), l.WithField("req", "abc123"))
+
+	repo := &Repo{DB: db, Ctx: ctx}
+
+	defer func(threshold time.Duration) { SlowQueryThreshold = threshold }(SlowQueryThreshold)
+	queries := DefaultQueryMetrics.Queries.Count()
+	slow := DefaultQueryMetrics.SlowQueries.Count()
+
+	// disabled by default
+	SlowQueryThreshold = 0
+	_, err = repo.ExecRaw("DELETE FROM accounts")
+	require.NoError(err)
+	assert.Equal(queries+1, DefaultQueryMetrics.Queries.Count())
+	assert.Equal(slow, DefaultQueryMetrics.SlowQueries.Count())
+	assert.Empty(out.String())
+
+	// statements under the threshold are not logged
+	SlowQueryThreshold = time.Hour
+	_, err = repo.ExecRaw("DELETE FROM accounts")
+	require.NoError(err)
+	assert.Equal(slow, DefaultQueryMetrics.SlowQueries.Count())
+	assert.Empty(out.String())
+
+	// slow statements are logged with the ambient fields, but without the
+	// values of their args
+	SlowQueryThreshold = 10 * time.Millisecond
+	seed := "SBQWY3DNPFWGSZTFNV4WQZLBOJ2GQYLTMJSWK3TTMVQXEZLBNFXGSZTF"
+	var ids []string
+	err = repo.SelectRaw(&ids, "SELECT id FROM accounts WHERE seed = ?", seed)
+	require.NoError(err)
+
+	assert.Equal(queries+3, DefaultQueryMetrics.Queries.Count())
+	assert.Equal(slow+1, DefaultQueryMetrics.SlowQueries.Count())
+
+	logged := out.String()
+	assert.Contains(logged, "sql: slow query")
+	assert.Contains(logged, "level=warning")
+	assert.Contains(logged, "subsystem=db")
+	assert.Contains(logged, "req=abc123")
+	assert.Contains(logged, "type=select")
+	assert.Contains(logged, "arg_count=1")
+	assert.Contains(logged, "SELECT id FROM accounts WHERE seed = $1")
+	assert.NotContains(logged, seed)
+}
+
+func TestTruncateSQL(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("SELECT 1", truncateSQL("SELECT 1", 10))
+	assert.Equal("SELECT", truncateSQL("SELECT", 6))
+	assert.Equal("SELE...", truncateSQL("SELECT 1", 4))
+	assert.Equal("SELECT 'é...", truncateSQL("SELECT 'éé'", 9))
+	assert.Equal(strings.Repeat("x", 3), truncateSQL("xxx", 0))
+}
+
+// slowDriver is a database/sql driver whose statements succeed, returning no
+// rows, after sleeping for the duration given as the data source name.
+type slowDriver struct{}
+
+func (slowDriver) Open(name string) (driver.Conn, error) {
+	delay, err := time.ParseDuration(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return slowConn(delay), nil
+}
+
+type slowConn time.Duration
+
+func (c slowConn) Prepare(query string) (driver.Stmt, error) {
+	return slowStmt(c), nil
+}
+
+func (c slowConn) Close() error { return nil }
+
+func (c slowConn) Begin() (driver.Tx, error) { return slowTx{}, nil }
+
+type slowStmt time.Duration
+
+func (s slowStmt) Close() error { return nil }
+
+func (s slowStmt) NumInput() int { return -1 }
+
+func (s slowStmt) Exec(args []driver.Value) (driver.Result, error) {
+	time.Sleep(time.Duration(s))
+	return driver.RowsAffected(0), nil
+}
+
+func (s slowStmt) Query(args []driver.Value) (driver.Rows, error) {
+	time.Sleep(time.Duration(s))
+	return emptyRows{}, nil
+}
+
+type slowTx struct{}
+
+func (slowTx) Commit() error   { return nil }
+func (slowTx) Rollback() error { return nil }
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return []string{"id"} }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+func init() {
+	sql.Register("slow", slowDriver{})
+}
@@ -0,0 +1,91 @@
+package db2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stellar/horizon/errors"
+)
+
+// ErrTimeout is returned when a query cannot be completed before the deadline
+// of the repo's context.  It is errors.Transient.
+var ErrTimeout = errors.NewTransient("db: query timeout")
+
+// IsTimeoutError returns true if `err` was caused by postgres canceling a
+// statement that exceeded its statement_timeout.
+func IsTimeoutError(err error) bool {
+	pqerr, ok := err.(*pq.Error)
+	return ok && pqerr.Code == "57014"
+}
+
+// timed runs `fn` against the repo's connection, limiting the statements it
+// executes to the time remaining before the deadline of the repo's context,
+// if any.
+//
+// database/sql cannot cancel an in-flight query, so the deadline is enforced
+// by postgres itself using `SET LOCAL statement_timeout`.  Since that setting
+// only lasts for the current transaction, statements made outside of one are
+// run within a short-lived transaction of their own, at the cost of three
+// extra round trips to the database.
+func (r *Repo) timed(fn func(conn Conn) error) error {
+	deadline, ok := r.deadline()
+	if !ok {
+		return fn(r.conn())
+	}
+
+	remaining := deadline.Sub(time.Now())
+	if remaining <= 0 {
+		return ErrTimeout
+	}
+
+	// statement_timeout is measured in milliseconds, and zero disables it
+	ms := int64(remaining / time.Millisecond)
+	if ms < 1 {
+		ms = 1
+	}
+	set := fmt.Sprintf("SET LOCAL statement_timeout = %d", ms)
+
+	if r.tx != nil {
+		if _, err := r.tx.Exec(set); err != nil {
+			return timeoutErr(err)
+		}
+		return timeoutErr(fn(r.tx))
+	}
+
+	tx, err := r.db().Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(set); err != nil {
+		return timeoutErr(err)
+	}
+
+	err = fn(tx)
+	if err != nil {
+		return timeoutErr(err)
+	}
+
+	return tx.Commit()
+}
+
+// deadline returns the deadline of the repo's context, if any.
+func (r *Repo) deadline() (time.Time, bool) {
+	if r.Ctx == nil {
+		return time.Time{}, false
+	}
+
+	return r.Ctx.Deadline()
+}
+
+// timeoutErr replaces statement timeouts reported by postgres with ErrTimeout,
+// leaving any other error untouched.
+func timeoutErr(err error) error {
+	if IsTimeoutError(err) {
+		return ErrTimeout
+	}
+
+	return err
+}
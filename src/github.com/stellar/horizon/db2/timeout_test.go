@@ -0,0 +1,50 @@
+package db2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/lib/pq"
+	tdb "github.com/stellar/horizon/test/db"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestRepoTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	repo := &Repo{DB: tdb.StellarCore(), Ctx: ctx}
+
+	// queries that finish before the deadline succeed
+	var one int
+	err := repo.GetRaw(&one, "SELECT 1")
+	assert.NoError(err)
+	assert.Equal(1, one)
+
+	// queries that run past the deadline are canceled
+	start := time.Now()
+	_, err = repo.ExecRaw("SELECT pg_sleep(5)")
+	assert.True(errors.Is(err, ErrTimeout), "expected timeout, got %v", err)
+	assert.True(time.Since(start) < time.Second)
+
+	// once the deadline has passed, queries are not started
+	err = repo.SelectRaw(&[]int{}, "SELECT 1")
+	assert.True(errors.Is(err, ErrTimeout), "expected timeout, got %v", err)
+
+	// repos without a deadline are unaffected
+	repo.Ctx = context.Background()
+	err = repo.GetRaw(&one, "SELECT 1")
+	assert.NoError(err)
+}
+
+func TestIsTimeoutError(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsTimeoutError(&pq.Error{Code: "57014"}))
+	assert.False(IsTimeoutError(&pq.Error{Code: "42601"}))
+	assert.False(IsTimeoutError(ErrTimeout))
+	assert.False(IsTimeoutError(nil))
+}
@@ -0,0 +1,142 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MaxBreadcrumbs is the number of breadcrumbs kept for each session.  Once a
+// session has recorded as many, each new breadcrumb replaces its oldest.
+const MaxBreadcrumbs = 32
+
+// MaxBreadcrumbSessions is the number of sessions whose breadcrumbs are kept.
+// Starting another discards the breadcrumbs of the oldest.
+const MaxBreadcrumbSessions = 16
+
+// SessionKey is the field in which an error, or a report to sentry, carries
+// the id of the session it occurred in.  Reports of errors that carry the id
+// of a session with breadcrumbs include them, under BreadcrumbsKey.
+const SessionKey = "session"
+
+// BreadcrumbsKey is the key of the extra data in which a report carries the
+// breadcrumbs of the session the error occurred in.
+const BreadcrumbsKey = "breadcrumbs"
+
+// Breadcrumb is a noteworthy step taken by a session, such as finishing
+// writing a ledger.
+type Breadcrumb struct {
+	At time.Time
+
+	// Ledger is the sequence of the ledger the step concerned, or 0 if it
+	// concerned none.
+	Ledger int32
+
+	Message string
+}
+
+// String returns the breadcrumb as reported to sentry, for example
+// "12:00:01.250 ledger 12345: written".
+func (b Breadcrumb) String() string {
+	at := b.At.UTC().Format("15:04:05.000")
+	if b.Ledger == 0 {
+		return fmt.Sprintf("%s %s", at, b.Message)
+	}
+
+	return fmt.Sprintf("%s ledger %d: %s", at, b.Ledger, b.Message)
+}
+
+// Breadcrumbs records the most recent MaxBreadcrumbs steps of a session.  It
+// is safe for concurrent use, and recording a breadcrumb does not allocate.  A
+// nil *Breadcrumbs records nothing.
+type Breadcrumbs struct {
+	lock  sync.Mutex
+	ring  [MaxBreadcrumbs]Breadcrumb
+	count int
+}
+
+// Add records that the step described by `msg` was taken for `ledger`.  The
+// message should be a constant: it is only formatted when reported.
+func (b *Breadcrumbs) Add(ledger int32, msg string) {
+	if b == nil {
+		return
+	}
+
+	now := time.Now()
+
+	b.lock.Lock()
+	b.ring[b.count%MaxBreadcrumbs] = Breadcrumb{At: now, Ledger: ledger, Message: msg}
+	b.count++
+	b.lock.Unlock()
+}
+
+// List returns the recorded breadcrumbs, oldest first.
+func (b *Breadcrumbs) List() []Breadcrumb {
+	if b == nil {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	n := b.count
+	if n > MaxBreadcrumbs {
+		n = MaxBreadcrumbs
+	}
+
+	result := make([]Breadcrumb, n)
+	for i := range result {
+		result[i] = b.ring[(b.count-n+i)%MaxBreadcrumbs]
+	}
+	return result
+}
+
+// StartBreadcrumbs returns a new trail of breadcrumbs for the session `id`,
+// which reports of errors carrying the id under SessionKey will include.
+func StartBreadcrumbs(id string) *Breadcrumbs {
+	return sessionBreadcrumbs.start(id)
+}
+
+// BreadcrumbsFor returns the breadcrumbs of the session `id`, or nil if it
+// has none or they were discarded.
+func BreadcrumbsFor(id string) *Breadcrumbs {
+	return sessionBreadcrumbs.get(id)
+}
+
+// sessionBreadcrumbs holds the breadcrumbs of recent sessions.
+var sessionBreadcrumbs = &trails{}
+
+// trails holds the breadcrumbs of the last MaxBreadcrumbSessions sessions.
+type trails struct {
+	lock  sync.Mutex
+	byID  map[string]*Breadcrumbs
+	order []string
+}
+
+func (t *trails) start(id string) *Breadcrumbs {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.byID == nil {
+		t.byID = map[string]*Breadcrumbs{}
+	}
+
+	if _, ok := t.byID[id]; !ok {
+		t.order = append(t.order, id)
+	}
+
+	for len(t.order) > MaxBreadcrumbSessions {
+		delete(t.byID, t.order[0])
+		t.order = t.order[1:]
+	}
+
+	b := &Breadcrumbs{}
+	t.byID[id] = b
+	return b
+}
+
+func (t *trails) get(id string) *Breadcrumbs {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.byID[id]
+}
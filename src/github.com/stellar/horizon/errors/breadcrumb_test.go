@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// useFreshBreadcrumbs replaces the breadcrumbs of past sessions with an empty
+// record.  The returned func restores them.
+func useFreshBreadcrumbs() func() {
+	old := sessionBreadcrumbs
+	sessionBreadcrumbs = &trails{}
+	return func() { sessionBreadcrumbs = old }
+}
+
+func TestBreadcrumbs(t *testing.T) {
+	assert := assert.New(t)
+
+	var b Breadcrumbs
+	assert.Empty(b.List())
+
+	b.Add(0, "session started")
+	b.Add(12, "written")
+	if list := b.List(); assert.Len(list, 2) {
+		assert.Equal("session started", list[0].Message)
+		assert.Equal(int32(12), list[1].Ledger)
+		assert.Equal("written", list[1].Message)
+	}
+
+	// only the most recent are kept, oldest first
+	for i := 0; i < MaxBreadcrumbs+5; i++ {
+		b.Add(int32(100+i), "written")
+	}
+	if list := b.List(); assert.Len(list, MaxBreadcrumbs) {
+		assert.Equal(int32(105), list[0].Ledger)
+		assert.Equal(int32(100+MaxBreadcrumbs+4), list[MaxBreadcrumbs-1].Ledger)
+	}
+
+	// a nil trail records nothing
+	var none *Breadcrumbs
+	none.Add(12, "written")
+	assert.Nil(none.List())
+}
+
+func TestBreadcrumbString(t *testing.T) {
+	assert := assert.New(t)
+	at := time.Date(2017, 1, 2, 12, 0, 1, 250000000, time.UTC)
+
+	assert.Equal("12:00:01.250 session started", Breadcrumb{At: at, Message: "session started"}.String())
+	assert.Equal("12:00:01.250 ledger 12345: written", Breadcrumb{At: at, Ledger: 12345, Message: "written"}.String())
+}
+
+func TestBreadcrumbSessions(t *testing.T) {
+	assert := assert.New(t)
+	defer useFreshBreadcrumbs()()
+
+	first := StartBreadcrumbs("first")
+	assert.Equal(first, BreadcrumbsFor("first"))
+	assert.Nil(BreadcrumbsFor("unknown"))
+
+	// the oldest sessions' breadcrumbs are discarded
+	for i := 0; i < MaxBreadcrumbSessions; i++ {
+		StartBreadcrumbs(fmt.Sprintf("session-%d", i))
+	}
+	assert.Nil(BreadcrumbsFor("first"))
+	assert.NotNil(BreadcrumbsFor("session-0"))
+	assert.Len(sessionBreadcrumbs.byID, MaxBreadcrumbSessions)
+	assert.Len(sessionBreadcrumbs.order, MaxBreadcrumbSessions)
+}
+
+func TestReportToSentryBreadcrumbs(t *testing.T) {
+	assert := assert.New(t)
+	fake, restore := useFakeSentry()
+	defer restore()
+	defer useFreshBreadcrumbs()()
+
+	b := StartBreadcrumbs("abcd1234")
+	b.Add(0, "session started")
+	b.Add(12345, "cleared")
+
+	err := errors.New("import session panicked")
+	ReportToSentryWithFields(err, nil, map[string]interface{}{SessionKey: "abcd1234"})
+	if assert.Len(fake.packets, 1) {
+		crumbs, ok := fake.packets[0].Extra[BreadcrumbsKey].([]string)
+		if assert.True(ok) && assert.Len(crumbs, 2) {
+			assert.Contains(crumbs[0], "session started")
+			assert.Contains(crumbs[1], "ledger 12345: cleared")
+		}
+	}
+
+	// the session id does not distinguish otherwise repeated reports
+	ReportToSentryWithFields(err, nil, map[string]interface{}{SessionKey: "ef567890"})
+	assert.Len(fake.packets, 1)
+
+	// errors without a session have no breadcrumbs
+	ReportToSentry(errors.New("other"), nil)
+	if assert.Len(fake.packets, 2) {
+		assert.NotContains(fake.packets[1].Extra, BreadcrumbsKey)
+	}
+}
+
+func BenchmarkBreadcrumbsAdd(b *testing.B) {
+	var crumbs Breadcrumbs
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		crumbs.Add(int32(i), "written")
+	}
+}
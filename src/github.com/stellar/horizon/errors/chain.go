@@ -0,0 +1,128 @@
+package errors
+
+import (
+	"reflect"
+
+	goerrors "github.com/go-errors/errors"
+)
+
+// Fielder is implemented by errors that carry structured context about the
+// failure, such as the ledger being processed.  Fields collects it from an
+// error's chain, and it is attached to log entries and reports to sentry.
+type Fielder interface {
+	Fields() map[string]interface{}
+}
+
+// As finds the first error in the chain of `err` that is assignable to the
+// value `target` points to, and if there is one, sets it and returns true.
+// The chain is `err` followed by the errors it wraps, unwrapping errors created
+// by github.com/pkg/errors and github.com/go-errors/errors.  The members of a
+// Multi in the chain are searched in turn.  As panics if `target` is not a
+// non-nil pointer to an interface type or to a type implementing error.
+func As(err error, target interface{}) bool {
+	if target == nil {
+		panic("errors: target cannot be nil")
+	}
+
+	val := reflect.ValueOf(target)
+	typ := val.Type()
+	if typ.Kind() != reflect.Ptr || val.IsNil() {
+		panic("errors: target must be a non-nil pointer")
+	}
+
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	targetType := typ.Elem()
+	if targetType.Kind() != reflect.Interface && !targetType.Implements(errorType) {
+		panic("errors: *target must be interface or implement error")
+	}
+
+	return as(err, val, targetType)
+}
+
+func as(err error, val reflect.Value, targetType reflect.Type) bool {
+	for err != nil {
+		if reflect.TypeOf(err).AssignableTo(targetType) {
+			val.Elem().Set(reflect.ValueOf(err))
+			return true
+		}
+
+		if m, ok := err.(*Multi); ok {
+			for _, member := range m.Errors() {
+				if as(member, val, targetType) {
+					return true
+				}
+			}
+			return false
+		}
+
+		err = Unwrap(err)
+	}
+
+	return false
+}
+
+// Is returns true if any error in the chain of `err` (see As), or in the
+// chains of the members of a Multi within it, is `target`.
+func Is(err, target error) bool {
+	for err != nil {
+		if reflect.TypeOf(err).Comparable() && err == target {
+			return true
+		}
+
+		if m, ok := err.(*Multi); ok {
+			for _, member := range m.Errors() {
+				if Is(member, target) {
+					return true
+				}
+			}
+			return false
+		}
+
+		err = Unwrap(err)
+	}
+
+	return false
+}
+
+// Fields returns the fields carried by the errors in the chain of `err` that
+// implement Fielder.  Where two errors carry the same field, the value of the
+// outermost is used.  It returns nil if no error in the chain carries fields.
+func Fields(err error) map[string]interface{} {
+	var result map[string]interface{}
+
+	for ; err != nil; err = Unwrap(err) {
+		f, ok := err.(Fielder)
+		if !ok {
+			continue
+		}
+
+		for k, v := range f.Fields() {
+			if result == nil {
+				result = map[string]interface{}{}
+			}
+			if _, set := result[k]; !set {
+				result[k] = v
+			}
+		}
+	}
+
+	return result
+}
+
+// Unwrap returns the error wrapped by `err`, or nil if it wraps none.  It
+// unwraps errors created by github.com/pkg/errors and
+// github.com/go-errors/errors, and any other error with a Cause method.
+func Unwrap(err error) error {
+	type causer interface {
+		Cause() error
+	}
+
+	switch e := err.(type) {
+	case *goerrors.Error:
+		return e.Err
+	case causer:
+		return e.Cause()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	goerrors "github.com/go-errors/errors"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type seqError struct {
+	seq int
+	err error
+}
+
+func (e *seqError) Error() string { return e.err.Error() }
+func (e *seqError) Cause() error  { return e.err }
+func (e *seqError) Fields() map[string]interface{} {
+	return map[string]interface{}{"ledger": e.seq, "phase": "write"}
+}
+
+func TestAs(t *testing.T) {
+	assert := assert.New(t)
+	inner := &seqError{seq: 12, err: errors.New("broken")}
+
+	cases := []struct {
+		name string
+		err  error
+	}{
+		{"unwrapped", inner},
+		{"pkg/errors", pkgerrors.Wrap(inner, "outer")},
+		{"go-errors", goerrors.Wrap(inner, 0)},
+		{"both", pkgerrors.Wrap(goerrors.Wrap(inner, 0), "outer")},
+		{"classified", WithClass(inner, Transient)},
+	}
+
+	for _, c := range cases {
+		var found *seqError
+		if assert.True(As(c.err, &found), c.name) {
+			assert.Equal(inner, found, c.name)
+		}
+	}
+
+	var found *seqError
+	assert.False(As(errors.New("other"), &found))
+	assert.False(As(nil, &found))
+	assert.Nil(found)
+
+	// interface targets
+	var fielder Fielder
+	if assert.True(As(pkgerrors.Wrap(inner, "outer"), &fielder)) {
+		assert.Equal(inner, fielder)
+	}
+
+	assert.Panics(func() { As(inner, nil) })
+	assert.Panics(func() { As(inner, found) })
+	assert.Panics(func() { As(inner, new(int)) })
+}
+
+func TestFields(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(Fields(nil))
+	assert.Nil(Fields(errors.New("broken")))
+
+	inner := &seqError{seq: 12, err: errors.New("broken")}
+	assert.Equal(
+		map[string]interface{}{"ledger": 12, "phase": "write"},
+		Fields(pkgerrors.Wrap(goerrors.Wrap(inner, 0), "outer")),
+	)
+
+	// the outermost error's fields win
+	outer := &seqError{seq: 20, err: inner}
+	assert.Equal(20, Fields(outer)["ledger"])
+}
@@ -0,0 +1,235 @@
+package errors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	goerrors "github.com/go-errors/errors"
+	"github.com/lib/pq"
+)
+
+// Class describes what kind of failure an error represents, and therefore
+// how horizon should react to it.  See Classify.
+type Class int
+
+const (
+	// None is the class of a nil error.
+	None Class = iota
+
+	// Transient errors are expected to go away if the operation is retried,
+	// such as lost connections, serialization failures and timeouts.
+	Transient
+
+	// Permanent errors will recur however often the operation is retried, such
+	// as constraint violations and bad input.
+	Permanent
+
+	// Corruption errors show that stored data is inconsistent, such as a ledger
+	// whose hash does not match its successor's or a missing ledger.  They
+	// require an operator's attention.
+	Corruption
+)
+
+// String returns the class' name.
+func (c Class) String() string {
+	switch c {
+	case None:
+		return "none"
+	case Transient:
+		return "transient"
+	case Permanent:
+		return "permanent"
+	case Corruption:
+		return "corruption"
+	default:
+		return fmt.Sprintf("class(%d)", int(c))
+	}
+}
+
+// Classifier is implemented by errors that know their own class.  Packages
+// may implement it on their own error types rather than creating them with
+// NewTransient and friends.
+type Classifier interface {
+	Class() Class
+}
+
+// ClassifiedError is an error explicitly assigned a class.
+type ClassifiedError struct {
+	Err   error
+	class Class
+}
+
+// Error implements error.
+func (e *ClassifiedError) Error() string {
+	return e.Err.Error()
+}
+
+// Class implements Classifier.
+func (e *ClassifiedError) Class() Class {
+	return e.class
+}
+
+// Cause returns the error that was classified, for compatibility with
+// github.com/pkg/errors.
+func (e *ClassifiedError) Cause() error {
+	return e.Err
+}
+
+// Sentinel errors reported when ingestion finds stellar-core's ledger chain to
+// be inconsistent.
+var (
+	ErrHashMismatch  = NewCorruption("ledger hashes do not match")
+	ErrMissingLedger = NewCorruption("ledger is missing")
+)
+
+// NewTransient returns a new Transient error with the message `msg`.
+func NewTransient(msg string) error {
+	return WithClass(errors.New(msg), Transient)
+}
+
+// NewPermanent returns a new Permanent error with the message `msg`.
+func NewPermanent(msg string) error {
+	return WithClass(errors.New(msg), Permanent)
+}
+
+// NewCorruption returns a new Corruption error with the message `msg`.
+func NewCorruption(msg string) error {
+	return WithClass(errors.New(msg), Corruption)
+}
+
+// WithClass returns `err` assigned `class`, overriding the class Classify would
+// otherwise find for it.  It returns nil if `err` is nil.
+func WithClass(err error, class Class) error {
+	if err == nil {
+		return nil
+	}
+
+	return &ClassifiedError{Err: err, class: class}
+}
+
+// Classify returns the class of `err`.  It unwraps errors created by
+// github.com/pkg/errors and github.com/go-errors/errors until it finds one that
+// implements Classifier, or one it recognizes:  database connection failures,
+// postgres errors (by their error code) and network errors.  Any other error
+// is Permanent, so that unexpected failures are not retried forever.
+func Classify(err error) Class {
+	type causer interface {
+		Cause() error
+	}
+
+	for err != nil {
+		if c, ok := err.(Classifier); ok {
+			return c.Class()
+		}
+
+		if class, ok := classifyKnown(err); ok {
+			return class
+		}
+
+		switch e := err.(type) {
+		case *goerrors.Error:
+			err = e.Err
+		case causer:
+			err = e.Cause()
+		default:
+			return Permanent
+		}
+	}
+
+	return None
+}
+
+// IsTransient returns true if `err` is Transient.
+func IsTransient(err error) bool {
+	return Classify(err) == Transient
+}
+
+// IsConnectionError returns true if `err` was caused by a lost or refused
+// connection to the database, rather than by the query itself.  Such errors
+// are Transient.
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if werr, ok := err.(*goerrors.Error); ok {
+		err = werr.Err
+	}
+
+	switch err {
+	case sql.ErrNoRows, sql.ErrTxDone:
+		return false
+	case driver.ErrBadConn, io.EOF, io.ErrUnexpectedEOF:
+		return true
+	}
+
+	switch err := err.(type) {
+	case *pq.Error:
+		// class 08 is "connection exception", and 57P01-57P03 are emitted when the
+		// server is shutting down or not yet accepting connections.
+		if err.Code.Class() == "08" {
+			return true
+		}
+		switch err.Code {
+		case "57P01", "57P02", "57P03":
+			return true
+		}
+		return false
+	case net.Error:
+		return true
+	}
+
+	// lib/pq reports some connection failures as plain strings
+	msg := err.Error()
+	return strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "connection reset by peer") ||
+		strings.Contains(msg, "broken pipe")
+}
+
+// classifyKnown returns the class of the errors that Classify recognizes
+// without their declaring it, and false for any other.
+func classifyKnown(err error) (Class, bool) {
+	if pqerr, ok := err.(*pq.Error); ok {
+		return classifyPQ(pqerr), true
+	}
+
+	switch err {
+	case sql.ErrNoRows, sql.ErrTxDone:
+		return Permanent, true
+	}
+
+	if IsConnectionError(err) {
+		return Transient, true
+	}
+
+	return None, false
+}
+
+// classifyPQ returns the class of an error reported by postgres, based on its
+// error code.  See https://www.postgresql.org/docs/current/static/errcodes-appendix.html
+func classifyPQ(err *pq.Error) Class {
+	switch err.Code {
+	case "57014": // query_canceled, as by statement_timeout
+		return Transient
+	case "57P01", "57P02", "57P03": // shutting down or starting up
+		return Transient
+	case "XX001", "XX002": // data_corrupted, index_corrupted
+		return Corruption
+	}
+
+	switch err.Code.Class() {
+	case "08": // connection exception
+		return Transient
+	case "40": // transaction rollback, e.g. serialization failure or deadlock
+		return Transient
+	case "53": // insufficient resources
+		return Transient
+	default: // constraint violations, bad data, syntax errors and the like
+		return Permanent
+	}
+}
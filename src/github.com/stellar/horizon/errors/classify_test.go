@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	goerrors "github.com/go-errors/errors"
+	"github.com/lib/pq"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type classifiedType struct{}
+
+func (classifiedType) Error() string { return "classified" }
+func (classifiedType) Class() Class  { return Corruption }
+
+func TestClassify(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		name     string
+		err      error
+		expected Class
+	}{
+		{"nil", nil, None},
+
+		// declared classes
+		{"NewTransient", NewTransient("busy"), Transient},
+		{"NewPermanent", NewPermanent("bad"), Permanent},
+		{"NewCorruption", NewCorruption("broken"), Corruption},
+		{"WithClass overrides", WithClass(io.EOF, Permanent), Permanent},
+		{"Classifier", classifiedType{}, Corruption},
+		{"ErrHashMismatch", ErrHashMismatch, Corruption},
+		{"ErrMissingLedger", ErrMissingLedger, Corruption},
+
+		// wrapped errors
+		{"pkg/errors", pkgerrors.Wrap(ErrMissingLedger, "loading"), Corruption},
+		{"go-errors", goerrors.Wrap(NewTransient("busy"), 0), Transient},
+		{"go-errors pq", goerrors.Wrap(&pq.Error{Code: "40001"}, 0), Transient},
+		{"nested", pkgerrors.Wrap(goerrors.Wrap(io.EOF, 0), "reading"), Transient},
+
+		// postgres errors
+		{"query canceled", &pq.Error{Code: "57014"}, Transient},
+		{"admin shutdown", &pq.Error{Code: "57P01"}, Transient},
+		{"crash shutdown", &pq.Error{Code: "57P02"}, Transient},
+		{"cannot connect now", &pq.Error{Code: "57P03"}, Transient},
+		{"connection failure", &pq.Error{Code: "08006"}, Transient},
+		{"serialization failure", &pq.Error{Code: "40001"}, Transient},
+		{"deadlock", &pq.Error{Code: "40P01"}, Transient},
+		{"too many connections", &pq.Error{Code: "53300"}, Transient},
+		{"data corrupted", &pq.Error{Code: "XX001"}, Corruption},
+		{"index corrupted", &pq.Error{Code: "XX002"}, Corruption},
+		{"unique violation", &pq.Error{Code: "23505"}, Permanent},
+		{"invalid text", &pq.Error{Code: "22P02"}, Permanent},
+		{"syntax error", &pq.Error{Code: "42601"}, Permanent},
+
+		// connection errors
+		{"bad conn", driver.ErrBadConn, Transient},
+		{"EOF", io.EOF, Transient},
+		{"unexpected EOF", io.ErrUnexpectedEOF, Transient},
+		{"net", &net.OpError{Op: "dial", Err: io.EOF}, Transient},
+		{"refused", errors.New("dial tcp: connection refused"), Transient},
+		{"reset", errors.New("read: connection reset by peer"), Transient},
+		{"broken pipe", errors.New("write: broken pipe"), Transient},
+
+		// everything else
+		{"no rows", sql.ErrNoRows, Permanent},
+		{"tx done", sql.ErrTxDone, Permanent},
+		{"wrapped no rows", goerrors.Wrap(sql.ErrNoRows, 0), Permanent},
+		{"unknown", errors.New("something else"), Permanent},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.expected, Classify(c.err), c.name)
+	}
+
+	assert.True(IsTransient(NewTransient("busy")))
+	assert.False(IsTransient(NewPermanent("bad")))
+	assert.False(IsTransient(nil))
+}
+
+func TestWithClass(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(WithClass(nil, Transient))
+
+	err := WithClass(io.EOF, Corruption)
+	assert.Equal("EOF", err.Error())
+	assert.Equal(io.EOF, pkgerrors.Cause(err))
+}
+
+func TestClassString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("none", None.String())
+	assert.Equal("transient", Transient.String())
+	assert.Equal("permanent", Permanent.String())
+	assert.Equal("corruption", Corruption.String())
+	assert.Equal("class(7)", Class(7).String())
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/getsentry/raven-go"
 	"github.com/go-errors/errors"
 )
 
@@ -21,19 +20,13 @@ func FromPanic(rec interface{}) error {
 // ReportToSentry reports err to the configured sentry server.  Optionally,
 // specifying a non-nil `r` will include information in the report about the
 // current http request.
+//
+// Repeats of a report are suppressed for SentryDedupWindow after it is sent:
+// reports of errors of the same type whose messages differ only in the numbers
+// within them are considered repeats.  The next report sent after the window
+// carries the number of repeats suppressed (see SuppressedCountKey).
 func ReportToSentry(err error, r *http.Request) {
-	st := raven.NewStacktrace(4, 3, []string{"github.org/stellar"})
-	exc := raven.NewException(err, st)
-
-	var packet *raven.Packet
-	if r != nil {
-		h := raven.NewHttp(r)
-		packet = raven.NewPacket(err.Error(), exc, h)
-	} else {
-		packet = raven.NewPacket(err.Error(), exc)
-	}
-
-	raven.Capture(packet, nil)
+	report(err, r, nil, false)
 }
 
 // Stack returns the stack, as a string, if one can be extracted from `err`.
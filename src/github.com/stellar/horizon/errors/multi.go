@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxMultiErrors is the number of errors a Multi retains.  Errors appended
+// beyond it are counted, but not kept, so that collecting the failures of a
+// long running operation uses bounded memory.
+const MaxMultiErrors = 100
+
+// Multi is an error made up of many, such as the failures of each batch of a
+// long running operation.  The zero value is empty and ready to use.  As and
+// Is find errors among its members.
+type Multi struct {
+	errs    []error
+	dropped int
+}
+
+// Append adds `errs` to `m`, ignoring nils.  The members of an appended Multi
+// are added individually.
+func (m *Multi) Append(errs ...error) {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if other, ok := err.(*Multi); ok {
+			if other == nil {
+				continue
+			}
+			m.Append(other.errs...)
+			m.dropped += other.dropped
+			continue
+		}
+
+		if len(m.errs) >= MaxMultiErrors {
+			m.dropped++
+			continue
+		}
+		m.errs = append(m.errs, err)
+	}
+}
+
+// Len returns the number of errors appended to `m`, including those not
+// retained.
+func (m *Multi) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.errs) + m.dropped
+}
+
+// Errors returns the errors retained by `m`, in the order they were appended.
+func (m *Multi) Errors() []error {
+	if m == nil {
+		return nil
+	}
+
+	return m.errs
+}
+
+// ErrorOrNil returns `m` if any errors were appended to it, and nil otherwise,
+// so that it may be returned as an error.
+func (m *Multi) ErrorOrNil() error {
+	if m.Len() == 0 {
+		return nil
+	}
+
+	return m
+}
+
+// Error implements error.  The messages of the errors are sorted, and those
+// repeated are listed once along with how many times they occurred, so that
+// the same errors are always described the same way, for example:
+//
+//	3 errors: ledger 12: load failed: sql: no rows in result set (2 times); pq: deadlock detected
+func (m *Multi) Error() string {
+	if len(m.errs) == 1 && m.dropped == 0 {
+		return m.errs[0].Error()
+	}
+
+	counts := map[string]int{}
+	for _, err := range m.errs {
+		counts[err.Error()]++
+	}
+
+	msgs := make([]string, 0, len(counts))
+	for msg := range counts {
+		msgs = append(msgs, msg)
+	}
+	sort.Strings(msgs)
+
+	parts := make([]string, len(msgs))
+	for i, msg := range msgs {
+		parts[i] = msg
+		if counts[msg] > 1 {
+			parts[i] = fmt.Sprintf("%s (%d times)", msg, counts[msg])
+		}
+	}
+
+	if m.dropped > 0 {
+		parts = append(parts, fmt.Sprintf("and %d more", m.dropped))
+	}
+
+	return fmt.Sprintf("%d errors: %s", m.Len(), strings.Join(parts, "; "))
+}
@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	goerrors "github.com/go-errors/errors"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiErrorOrNil(t *testing.T) {
+	assert := assert.New(t)
+
+	var m Multi
+	assert.Nil(m.ErrorOrNil())
+
+	m.Append(nil, nil)
+	assert.Nil(m.ErrorOrNil())
+	assert.Equal(0, m.Len())
+
+	var none *Multi
+	assert.Nil(none.ErrorOrNil())
+
+	m.Append(errors.New("broken"))
+	if assert.Error(m.ErrorOrNil()) {
+		assert.Equal("broken", m.ErrorOrNil().Error())
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	assert := assert.New(t)
+
+	var m Multi
+	m.Append(errors.New("ledger 12: load failed"))
+	m.Append(errors.New("deadlock detected"))
+	m.Append(errors.New("ledger 12: load failed"))
+	assert.Equal(
+		"3 errors: deadlock detected; ledger 12: load failed (2 times)",
+		m.Error(),
+	)
+
+	// the order errors are appended in does not matter
+	var other Multi
+	other.Append(errors.New("ledger 12: load failed"))
+	other.Append(errors.New("ledger 12: load failed"))
+	other.Append(errors.New("deadlock detected"))
+	assert.Equal(m.Error(), other.Error())
+
+	// appending a Multi adds its members
+	var outer Multi
+	outer.Append(&m, errors.New("timeout"))
+	assert.Equal(4, outer.Len())
+	assert.Equal(
+		"4 errors: deadlock detected; ledger 12: load failed (2 times); timeout",
+		outer.Error(),
+	)
+}
+
+func TestMultiCap(t *testing.T) {
+	assert := assert.New(t)
+
+	var m Multi
+	for i := 0; i < MaxMultiErrors+5; i++ {
+		m.Append(fmt.Errorf("batch %d failed", i))
+	}
+
+	assert.Equal(MaxMultiErrors+5, m.Len())
+	assert.Len(m.Errors(), MaxMultiErrors)
+	assert.Contains(m.Error(), fmt.Sprintf("%d errors: ", MaxMultiErrors+5))
+	assert.Contains(m.Error(), "; and 5 more")
+}
+
+func TestMultiUnwrapping(t *testing.T) {
+	assert := assert.New(t)
+	errMissing := errors.New("missing")
+	inner := &seqError{seq: 12, err: errMissing}
+
+	var m Multi
+	m.Append(errors.New("timeout"))
+	m.Append(pkgerrors.Wrap(goerrors.Wrap(inner, 0), "batch 2"))
+	err := pkgerrors.Wrap(m.ErrorOrNil(), "reingest")
+
+	var found *seqError
+	if assert.True(As(err, &found)) {
+		assert.Equal(inner, found)
+	}
+
+	var multi *Multi
+	if assert.True(As(err, &multi)) {
+		assert.Equal(2, multi.Len())
+	}
+
+	assert.True(Is(err, errMissing))
+	assert.False(Is(err, errors.New("missing")))
+	assert.False(Is(nil, errMissing))
+
+	// errors of uncomparable types are skipped rather than compared
+	var uncomparable Multi
+	uncomparable.Append(uncomparableError{"a"})
+	assert.False(Is(&uncomparable, errMissing))
+}
+
+// uncomparableError is an error of an uncomparable type.
+type uncomparableError []string
+
+func (e uncomparableError) Error() string { return "uncomparable" }
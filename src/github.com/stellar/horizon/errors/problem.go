@@ -0,0 +1,32 @@
+package errors
+
+// Problem is an error to be reported to an API client.  Its Message is safe
+// to show to clients and its Status is the http status of the response,
+// whereas Err, the internal cause, is only logged and reported to sentry.
+// See problem.FromError, which renders it.
+type Problem struct {
+	Status  int
+	Message string
+	Err     error
+}
+
+// NewProblem returns a Problem reported to clients with `status` and `msg`,
+// caused by `err`, which may be nil.
+func NewProblem(status int, msg string, err error) error {
+	return &Problem{Status: status, Message: msg, Err: err}
+}
+
+// Error implements error.  Unlike Message, it includes the internal cause.
+func (p *Problem) Error() string {
+	if p.Err == nil {
+		return p.Message
+	}
+
+	return p.Message + ": " + p.Err.Error()
+}
+
+// Cause returns the internal cause of the problem, for compatibility with
+// github.com/pkg/errors.
+func (p *Problem) Cause() error {
+	return p.Err
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"github.com/stellar/horizon/log"
+)
+
+// Recover, when deferred, recovers from a panic in the calling goroutine,
+// such that it does not bring down the whole horizon process.  The panic is
+// converted to an error (see FromPanic), logged along with the name of the
+// `component` that panicked and `fields`, and reported to sentry with the same
+// fields (see ReportToSentryWithFields).
+//
+// In debug builds (those built with `-tags debug`) the panic is logged and
+// reported, then raised again, so that it is not overlooked during
+// development.
+//
+// Recover must be deferred directly, as in:
+//
+//	defer errors.Recover("reaper", nil)
+func Recover(component string, fields map[string]interface{}) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+
+	recovered(rec, component, fields, log.DefaultLogger)
+}
+
+// RecoverWith is like Recover, but calls `fields` and `logger` once a panic
+// is recovered, rather than when RecoverWith is deferred, so that the fields
+// logged and reported describe the state the panic interrupted.  The panic is
+// logged through the entry `logger` returns.
+//
+// Like Recover, RecoverWith must be deferred directly, as in:
+//
+//	defer errors.RecoverWith("import session", is.panicFields, is.logger)
+func RecoverWith(
+	component string,
+	fields func() map[string]interface{},
+	logger func() *log.Entry,
+) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+
+	recovered(rec, component, fields(), logger())
+}
+
+// recovered logs and reports `rec`, a value recovered from a panic in
+// `component`, through `l`, then raises it again in debug builds.
+func recovered(
+	rec interface{},
+	component string,
+	fields map[string]interface{},
+	l *log.Entry,
+) {
+	err := FromPanic(rec)
+
+	all := log.F{"component": component}
+	for k, v := range fields {
+		all[k] = v
+	}
+
+	l.WithFields(all).
+		WithError(err).
+		Errorf("%s panicked", component)
+	ReportToSentryWithFields(err, nil, all)
+
+	if repanic {
+		panic(rec)
+	}
+}
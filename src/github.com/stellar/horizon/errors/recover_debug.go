@@ -0,0 +1,6 @@
+// +build debug
+
+package errors
+
+// repanic causes Recover to raise recovered panics again.
+const repanic = true
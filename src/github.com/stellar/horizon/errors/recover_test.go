@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stellar/horizon/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecover(t *testing.T) {
+	if repanic {
+		t.Skip("Recover raises panics again in debug builds")
+	}
+
+	assert := assert.New(t)
+	fake, restore := useFakeSentry()
+	defer restore()
+
+	out := new(bytes.Buffer)
+	l, _ := log.New()
+	l.Logger.Out = out
+	l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+	defer func(old *log.Entry) { log.DefaultLogger = old }(log.DefaultLogger)
+	log.DefaultLogger = l
+
+	// a hook callback that panics, run alongside siblings that do not
+	hook := func() {
+		var m map[string]int
+		m["boom"]++
+	}
+
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		finished int
+	)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer Recover("session hook", map[string]interface{}{"first_ledger": 12})
+
+			if i == 2 {
+				hook()
+			}
+
+			lock.Lock()
+			finished++
+			lock.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(3, finished)
+
+	logged := out.String()
+	assert.Contains(logged, "session hook panicked")
+	assert.Contains(logged, `component="session hook"`)
+	assert.Contains(logged, "first_ledger=12")
+	assert.Contains(logged, "assignment to entry in nil map")
+
+	if assert.Len(fake.packets, 1) {
+		assert.Equal("session hook", fake.packets[0].Extra["component"])
+		assert.Equal(12, fake.packets[0].Extra["first_ledger"])
+	}
+
+	// without a panic, nothing is logged or reported
+	out.Reset()
+	func() {
+		defer Recover("quiet", nil)
+	}()
+	assert.Empty(out.String())
+	assert.Len(fake.packets, 1)
+}
+
+func TestRecoverWith(t *testing.T) {
+	if repanic {
+		t.Skip("RecoverWith raises panics again in debug builds")
+	}
+
+	assert := assert.New(t)
+	fake, restore := useFakeSentry()
+	defer restore()
+
+	out := new(bytes.Buffer)
+	l, _ := log.New()
+	l.Logger.Out = out
+	l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+
+	// the fields and logger are those current when the panic is recovered
+	ledger := 12
+	func() {
+		defer RecoverWith(
+			"import session",
+			func() map[string]interface{} {
+				return map[string]interface{}{"ledger": ledger}
+			},
+			func() *log.Entry { return l.WithField("session", "abc") },
+		)
+
+		ledger = 13
+		panic("boom")
+	}()
+
+	logged := out.String()
+	assert.Contains(logged, "import session panicked")
+	assert.Contains(logged, "ledger=13")
+	assert.Contains(logged, "session=abc")
+
+	if assert.Len(fake.packets, 1) {
+		assert.Equal(13, fake.packets[0].Extra["ledger"])
+	}
+}
@@ -0,0 +1,288 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getsentry/raven-go"
+	goerrors "github.com/go-errors/errors"
+	"github.com/stellar/horizon/log"
+)
+
+// SentryDedupWindow is the period for which repeats of a report made to sentry
+// are suppressed (see ReportToSentry).  Zero disables deduplication.
+var SentryDedupWindow = 10 * time.Minute
+
+// SentryLedgerBucket is the number of consecutive ledger sequences that are
+// considered the same when deduplicating reports by their "ledger" field.
+var SentryLedgerBucket int64 = 1000
+
+// SuppressedCountKey is the key of the extra data in which a report carries the
+// number of its repeats that were suppressed since it was last sent.
+const SuppressedCountKey = "suppressed_count"
+
+// ReportToSentryWithFields is like ReportToSentry, but also attaches `fields`
+// to the report as extra data, alongside any fields carried by `err` (see
+// Fields).  Reports of the same error with different
+// values for a field are not considered repeats of one another, except that
+// the values of the "ledger" field, and of fields whose names end in
+// "_ledger", are first rounded down to a multiple of SentryLedgerBucket, so
+// that reports made for each of a run of ledgers are.
+func ReportToSentryWithFields(err error, r *http.Request, fields map[string]interface{}) {
+	report(err, r, fields, false)
+}
+
+// ReportCriticalToSentry is like ReportToSentry, but the report is sent even
+// when it repeats one sent within SentryDedupWindow.
+func ReportCriticalToSentry(err error, r *http.Request) {
+	report(err, r, nil, true)
+}
+
+// report sends a report of `err` to sentry, unless it is a repeat to be
+// suppressed.
+func report(err error, r *http.Request, fields map[string]interface{}, critical bool) {
+	fields = mergeFields(Fields(err), fields)
+
+	suppressed := 0
+	if !critical {
+		var ok bool
+		ok, suppressed = sentryDedup.allow(fingerprint(err, fields), time.Now())
+		if !ok {
+			return
+		}
+	}
+
+	st := raven.NewStacktrace(5, 3, []string{"github.org/stellar"})
+	exc := raven.NewException(err, st)
+
+	var packet *raven.Packet
+	if r != nil {
+		h := raven.NewHttp(r)
+		packet = raven.NewPacket(err.Error(), exc, h)
+	} else {
+		packet = raven.NewPacket(err.Error(), exc)
+	}
+
+	crumbs := breadcrumbsOf(fields)
+
+	if len(fields) > 0 || suppressed > 0 || len(crumbs) > 0 {
+		packet.Extra = map[string]interface{}{}
+		for k, v := range fields {
+			packet.Extra[k] = v
+		}
+		if suppressed > 0 {
+			packet.Extra[SuppressedCountKey] = suppressed
+		}
+		if len(crumbs) > 0 {
+			packet.Extra[BreadcrumbsKey] = crumbs
+		}
+	}
+
+	sentryCapture(packet)
+}
+
+// mergeFields returns the fields carried by an error overlaid with those
+// passed explicitly.
+func mergeFields(carried, explicit map[string]interface{}) map[string]interface{} {
+	if len(carried) == 0 {
+		return explicit
+	}
+
+	result := map[string]interface{}{}
+	for k, v := range carried {
+		result[k] = v
+	}
+	for k, v := range explicit {
+		result[k] = v
+	}
+	return result
+}
+
+// breadcrumbsOf returns the breadcrumbs of the session named by the
+// SessionKey field of a report, formatted for sentry, oldest first.
+func breadcrumbsOf(fields map[string]interface{}) []string {
+	id, ok := fields[SessionKey].(string)
+	if !ok {
+		return nil
+	}
+
+	list := BreadcrumbsFor(id).List()
+	result := make([]string, len(list))
+	for i, b := range list {
+		result[i] = b.String()
+	}
+	return result
+}
+
+// sentryCapture sends `packet` to the configured sentry server.  It is replaced
+// by tests.
+var sentryCapture = func(packet *raven.Packet) {
+	raven.Capture(packet, nil)
+}
+
+// sentryDedup tracks the reports recently sent to sentry.
+var sentryDedup = &dedup{}
+
+// dedup tracks when each distinct report was last sent, and how many of its
+// repeats were suppressed since.
+type dedup struct {
+	lock sync.Mutex
+	seen map[string]*dedupEntry
+}
+
+type dedupEntry struct {
+	sentAt     time.Time
+	suppressed int
+}
+
+// allow returns true if the report identified by `fp` should be sent at `now`,
+// along with the number of its repeats that were suppressed since it was last
+// sent.  It returns false, counting the report as suppressed, if it was last
+// sent within SentryDedupWindow.
+//
+// Reports last sent before SentryDedupWindow are forgotten, so that the
+// record of them does not grow without bound.  Forgetting a report whose
+// repeats were suppressed logs how many were, since no later report of it
+// will carry the count.
+func (d *dedup) allow(fp string, now time.Time) (bool, int) {
+	ok, suppressed, flushed := d.check(fp, now)
+
+	for k, n := range flushed {
+		log.WithField("fingerprint", k).
+			WithField(SuppressedCountKey, n).
+			Warn("sentry: repeats of a report were suppressed")
+	}
+
+	return ok, suppressed
+}
+
+// check is allow, but returns the suppressed counts of the reports it forgot
+// rather than logging them, so that they are logged without holding the lock.
+func (d *dedup) check(fp string, now time.Time) (bool, int, map[string]int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if SentryDedupWindow <= 0 {
+		return true, 0, nil
+	}
+
+	if d.seen == nil {
+		d.seen = map[string]*dedupEntry{}
+	}
+
+	e, ok := d.seen[fp]
+	if ok && now.Sub(e.sentAt) < SentryDedupWindow {
+		e.suppressed++
+		return false, 0, nil
+	}
+
+	var flushed map[string]int
+	for k, old := range d.seen {
+		if k == fp || now.Sub(old.sentAt) < SentryDedupWindow {
+			continue
+		}
+
+		if old.suppressed > 0 {
+			if flushed == nil {
+				flushed = map[string]int{}
+			}
+			flushed[k] = old.suppressed
+		}
+		delete(d.seen, k)
+	}
+
+	suppressed := 0
+	if ok {
+		suppressed = e.suppressed
+	}
+	d.seen[fp] = &dedupEntry{sentAt: now}
+	return true, suppressed, flushed
+}
+
+// digits matches the numbers in an error message, such as ids and sequences,
+// which vary between repeats of the same error.
+var digits = regexp.MustCompile(`[0-9]+`)
+
+// fingerprint identifies the reports that are repeats of one another:  those of
+// errors of the same type, with the same message but for any numbers within
+// it, and the same key fields, other than SessionKey.
+func fingerprint(err error, fields map[string]interface{}) string {
+	cause := innermost(err)
+	parts := []string{
+		fmt.Sprintf("%T", cause),
+		digits.ReplaceAllString(cause.Error(), "N"),
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		// every session has its own id, so it would keep any report from
+		// being a repeat
+		if k == SessionKey {
+			continue
+		}
+
+		v := fields[k]
+		if k == "ledger" || strings.HasSuffix(k, "_ledger") {
+			v = ledgerBucket(v)
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// innermost returns the error that caused `err`, unwrapping errors created by
+// github.com/pkg/errors and github.com/go-errors/errors.
+func innermost(err error) error {
+	type causer interface {
+		Cause() error
+	}
+
+	for {
+		switch e := err.(type) {
+		case *goerrors.Error:
+			err = e.Err
+		case causer:
+			if e.Cause() == nil {
+				return err
+			}
+			err = e.Cause()
+		default:
+			return err
+		}
+	}
+}
+
+// ledgerBucket rounds a ledger sequence down to a multiple of
+// SentryLedgerBucket.  Values that are not integers are returned unchanged.
+func ledgerBucket(v interface{}) interface{} {
+	var seq int64
+	switch v := v.(type) {
+	case int:
+		seq = int64(v)
+	case int32:
+		seq = int64(v)
+	case int64:
+		seq = v
+	case uint32:
+		seq = int64(v)
+	default:
+		return v
+	}
+
+	if SentryLedgerBucket <= 1 {
+		return seq
+	}
+
+	return seq - seq%SentryLedgerBucket
+}
@@ -0,0 +1,229 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/getsentry/raven-go"
+	goerrors "github.com/go-errors/errors"
+	"github.com/stellar/horizon/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSentry records the packets reported to sentry in place of sending them.
+type fakeSentry struct {
+	packets []*raven.Packet
+}
+
+func (f *fakeSentry) capture(packet *raven.Packet) {
+	f.packets = append(f.packets, packet)
+}
+
+// useFakeSentry causes reports to be captured by the returned fakeSentry, with
+// a fresh record of the reports sent and a one hour deduplication window.  The
+// returned func restores the real sentry.
+func useFakeSentry() (*fakeSentry, func()) {
+	fake := &fakeSentry{}
+	capture, seen, window := sentryCapture, sentryDedup, SentryDedupWindow
+
+	sentryCapture = fake.capture
+	sentryDedup = &dedup{}
+	SentryDedupWindow = time.Hour
+
+	return fake, func() {
+		sentryCapture, sentryDedup, SentryDedupWindow = capture, seen, window
+	}
+}
+
+// expire moves the last report of every error back past the deduplication
+// window.
+func expire() {
+	for _, e := range sentryDedup.seen {
+		e.sentAt = e.sentAt.Add(-2 * SentryDedupWindow)
+	}
+}
+
+func TestReportToSentry(t *testing.T) {
+	assert := assert.New(t)
+	fake, restore := useFakeSentry()
+	defer restore()
+
+	stuck := func(seq int) error {
+		return goerrors.Wrap(fmt.Errorf("failed to load ledger %d", seq), 0)
+	}
+
+	// repeats are suppressed within the window
+	ReportToSentry(stuck(12), nil)
+	ReportToSentry(stuck(12), nil)
+	ReportToSentry(stuck(13), nil)
+	if assert.Len(fake.packets, 1) {
+		assert.Equal("failed to load ledger 12", fake.packets[0].Message)
+		assert.Empty(fake.packets[0].Extra)
+	}
+
+	// the next report after the window carries the number suppressed
+	expire()
+	ReportToSentry(stuck(14), nil)
+	if assert.Len(fake.packets, 2) {
+		assert.Equal(2, fake.packets[1].Extra[SuppressedCountKey])
+	}
+
+	// and the count starts over
+	expire()
+	ReportToSentry(stuck(15), nil)
+	if assert.Len(fake.packets, 3) {
+		assert.Empty(fake.packets[2].Extra)
+	}
+
+	// other errors, including those of another type with the same message, are
+	// reported
+	ReportToSentry(errors.New("something else"), nil)
+	ReportToSentry(&loadError{}, nil)
+	assert.Len(fake.packets, 5)
+
+	// including within requests
+	r, _ := http.NewRequest("GET", "http://localhost/ledgers", nil)
+	ReportToSentry(errors.New("request failed"), r)
+	assert.Len(fake.packets, 6)
+}
+
+func TestReportToSentryWithFields(t *testing.T) {
+	assert := assert.New(t)
+	fake, restore := useFakeSentry()
+	defer restore()
+
+	err := errors.New("import session panicked")
+	report := func(ledger int32) {
+		ReportToSentryWithFields(err, nil, map[string]interface{}{"ledger": ledger})
+	}
+
+	report(1001)
+	if assert.Len(fake.packets, 1) {
+		assert.Equal(int32(1001), fake.packets[0].Extra["ledger"])
+	}
+
+	// ledgers within the same bucket are repeats
+	report(1999)
+	assert.Len(fake.packets, 1)
+
+	// ledgers in other buckets are not
+	report(2000)
+	report(999)
+	assert.Len(fake.packets, 3)
+
+	// nor are reports with other fields
+	ReportToSentryWithFields(err, nil, map[string]interface{}{"ledger": int32(1001), "stage": "backfill"})
+	ReportToSentryWithFields(err, nil, nil)
+	assert.Len(fake.packets, 5)
+}
+
+func TestReportToSentryCarriedFields(t *testing.T) {
+	assert := assert.New(t)
+	fake, restore := useFakeSentry()
+	defer restore()
+
+	err := goerrors.Wrap(&seqError{seq: 1001, err: errors.New("broken")}, 0)
+
+	ReportToSentry(err, nil)
+	if assert.Len(fake.packets, 1) {
+		assert.Equal(1001, fake.packets[0].Extra["ledger"])
+		assert.Equal("write", fake.packets[0].Extra["phase"])
+	}
+
+	// explicit fields override those carried by the error
+	ReportToSentryWithFields(err, nil, map[string]interface{}{"phase": "load"})
+	if assert.Len(fake.packets, 2) {
+		assert.Equal("load", fake.packets[1].Extra["phase"])
+	}
+}
+
+func TestReportCriticalToSentry(t *testing.T) {
+	assert := assert.New(t)
+	fake, restore := useFakeSentry()
+	defer restore()
+
+	err := errors.New("history database is corrupt")
+	ReportToSentry(err, nil)
+	ReportCriticalToSentry(err, nil)
+	ReportCriticalToSentry(err, nil)
+	ReportToSentry(err, nil)
+	assert.Len(fake.packets, 3)
+}
+
+func TestSentryDedupDisabled(t *testing.T) {
+	assert := assert.New(t)
+	fake, restore := useFakeSentry()
+	defer restore()
+
+	SentryDedupWindow = 0
+	err := errors.New("failed")
+	ReportToSentry(err, nil)
+	ReportToSentry(err, nil)
+	assert.Len(fake.packets, 2)
+}
+
+func TestDedupForgetsOldReports(t *testing.T) {
+	assert := assert.New(t)
+	_, restore := useFakeSentry()
+	defer restore()
+
+	now := time.Now()
+	d := &dedup{}
+
+	ok, _ := d.allow("a", now)
+	assert.True(ok)
+	ok, _ = d.allow("b", now)
+	assert.True(ok)
+	ok, _ = d.allow("b", now.Add(time.Minute))
+	assert.False(ok)
+
+	// a report whose repeats were suppressed is forgotten along with those
+	// that did not recur, logging its suppressed count
+	out := new(bytes.Buffer)
+	l, _ := log.New()
+	l.Logger.Out = out
+	defer func(old *log.Entry) { log.DefaultLogger = old }(log.DefaultLogger)
+	log.DefaultLogger = l
+
+	ok, suppressed := d.allow("c", now.Add(2*time.Hour))
+	assert.True(ok)
+	assert.Equal(0, suppressed)
+	assert.NotContains(d.seen, "a")
+	assert.NotContains(d.seen, "b")
+	assert.Contains(out.String(), "repeats of a report were suppressed")
+	assert.Contains(out.String(), "fingerprint=b")
+	assert.Contains(out.String(), SuppressedCountKey+"=1")
+	assert.NotContains(out.String(), "fingerprint=a")
+
+	// a report expired when it recurs carries its own count instead
+	ok, _ = d.allow("d", now.Add(2*time.Hour))
+	assert.True(ok)
+	ok, _ = d.allow("d", now.Add(2*time.Hour+time.Minute))
+	assert.False(ok)
+
+	out.Reset()
+	ok, suppressed = d.allow("d", now.Add(4*time.Hour))
+	assert.True(ok)
+	assert.Equal(1, suppressed)
+	assert.NotContains(out.String(), "fingerprint=d")
+}
+
+func TestLedgerBucket(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(int64(1000), ledgerBucket(1234))
+	assert.Equal(int64(1000), ledgerBucket(int32(1999)))
+	assert.Equal(int64(2000), ledgerBucket(int64(2000)))
+	assert.Equal(int64(0), ledgerBucket(uint32(999)))
+	assert.Equal("latest", ledgerBucket("latest"))
+}
+
+// loadError is an error of a type other than those returned by errors.New,
+// with the same message as the errors reported in TestReportToSentry.
+type loadError struct{}
+
+func (*loadError) Error() string { return "failed to load ledger 12" }
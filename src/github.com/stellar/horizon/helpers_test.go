@@ -26,7 +26,10 @@ func NewTestConfig() Config {
 		DatabaseURL:            test.DatabaseURL(),
 		StellarCoreDatabaseURL: test.StellarCoreDatabaseURL(),
 		RateLimit:              throttled.PerHour(1000),
+		CORSAllowedOrigins:     []string{"*"},
+		CORSAllowedHeaders:     []string{"*"},
 		LogLevel:               hlog.InfoLevel,
+		LogStackDepth:          hlog.MaxStackDepth,
 	}
 }
 
@@ -0,0 +1,179 @@
+package ingest
+
+import (
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/db2/core"
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/errors"
+)
+
+// ErrAssetStatsDiverged is returned when a ledger's changes cannot be applied
+// to the `asset_stats` table, as they would leave an asset with fewer than no
+// trustlines.  The table is then rebuilt (see Session.Run).
+var ErrAssetStatsDiverged = errors.NewPermanent("ingest: asset stats diverged from the ledger")
+
+// AssetStatChange is the net change a ledger made to the stats of an asset:
+// to the amount held of it, and to the number of accounts that trust it.
+type AssetStatChange struct {
+	Asset       xdr.Asset
+	Amount      xdr.Int64
+	NumAccounts int32
+}
+
+// IssuerFlags are the flags an account was left with by a ledger that changed
+// them.
+type IssuerFlags struct {
+	Issuer xdr.AccountId
+	Flags  xdr.AccountFlags
+}
+
+// ingestAssetStats applies the changes the current ledger made to trustlines
+// and account flags to the `asset_stats` table, provided the table is current
+// as of the preceding ledger.  The table is left alone for the ledgers it
+// already reflects, such as those being reingested or backfilled, and while it
+// awaits being built.  Should the ledger not follow the one the table is
+// current as of, or its changes not be known, the table is emptied to be
+// rebuilt once ingestion catches up with stellar-core.
+func (is *Session) ingestAssetStats() {
+	if is.Err != nil {
+		return
+	}
+
+	var current int32
+	q := &history.Q{Repo: is.Ingestion.DB}
+	is.Err = q.AssetStatsLedger(&current)
+	if is.Err != nil {
+		return
+	}
+
+	seq := is.Cursor.LedgerSequence()
+	if current == 0 || seq <= current {
+		return
+	}
+
+	changes, flags, ok := is.Cursor.SuccessfulLedgerAssetStatChanges()
+	if seq != current+1 || !ok {
+		is.logger().
+			WithField("asset_stats_ledger", current).
+			Warn("ingest: asset stats cannot follow the ledger, rebuilding")
+		is.Err = is.Ingestion.ResetAssetStats()
+		return
+	}
+
+	cq := &core.Q{Repo: is.Cursor.DB}
+	for _, change := range changes {
+		var created bool
+		created, is.Err = is.Ingestion.AssetStatChange(change)
+		if is.Err == ErrAssetStatsDiverged {
+			is.logger().
+				WithField("asset", change.Asset.String()).
+				Warn("ingest: asset stats diverged, rebuilding")
+			is.Err = is.Ingestion.ResetAssetStats()
+			return
+		}
+		if is.Err != nil {
+			return
+		}
+
+		if !created {
+			continue
+		}
+
+		// the issuer's flags are not part of the ledger's changes unless it
+		// changed them, so they are taken from stellar-core.  Should they have
+		// changed since, the later ledger that changed them corrects them.
+		var (
+			typ    xdr.AssetType
+			code   string
+			issuer xdr.AccountId
+			f      xdr.AccountFlags
+		)
+		is.Err = change.Asset.Extract(&typ, &code, &issuer)
+		if is.Err != nil {
+			return
+		}
+
+		f, is.Err = issuerFlags(cq, issuer)
+		if is.Err != nil {
+			return
+		}
+
+		is.Err = is.Ingestion.AssetIssuerFlags(issuer, f)
+		if is.Err != nil {
+			return
+		}
+	}
+
+	for _, f := range flags {
+		is.Err = is.Ingestion.AssetIssuerFlags(f.Issuer, f.Flags)
+		if is.Err != nil {
+			return
+		}
+	}
+
+	is.Err = is.Ingestion.AssetStatsLedger(seq)
+}
+
+// buildAssetStats builds the `asset_stats` table from stellar-core's
+// trustlines, using a single grouped query, should it await being built and
+// the session have ingested stellar-core's latest ledger.  stellar-core's
+// trustlines are only known to reflect that ledger should no other ledger
+// close while they are read, so the build is otherwise left to a later
+// session.
+func (is *Session) buildAssetStats() {
+	if is.Err != nil {
+		return
+	}
+
+	var current int32
+	q := &history.Q{Repo: is.Ingestion.DB}
+	is.Err = q.AssetStatsLedger(&current)
+	if is.Err != nil || current != 0 {
+		return
+	}
+
+	var (
+		before, after int32
+		stats         []core.AssetStat
+	)
+	cq := &core.Q{Repo: is.Cursor.DB}
+
+	is.Err = cq.LatestLedger(&before)
+	if is.Err != nil || before != is.Cursor.LastLedger {
+		return
+	}
+
+	is.Err = cq.AssetStats(&stats)
+	if is.Err != nil {
+		return
+	}
+
+	is.Err = cq.LatestLedger(&after)
+	if is.Err != nil || after != before {
+		return
+	}
+
+	is.Err = is.Ingestion.AssetStats(stats, is.Cursor.LastLedger)
+	if is.Err != nil {
+		return
+	}
+
+	is.logger().
+		WithField("assets", len(stats)).
+		Info("ingest: built asset stats")
+}
+
+// issuerFlags returns the flags of the account `issuer`, which are zero once
+// the account has been merged.
+func issuerFlags(q *core.Q, issuer xdr.AccountId) (xdr.AccountFlags, error) {
+	var account core.Account
+	err := q.AccountByAddress(&account, issuer.Address())
+	if q.NoRows(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return account.Flags, nil
+}
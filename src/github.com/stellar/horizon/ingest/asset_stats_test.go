@@ -0,0 +1,79 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/test"
+)
+
+func TestAssetStats(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("allow_trust")
+	defer tt.Finish()
+
+	s := ingest(tt)
+	tt.Require.NoError(s.Err)
+
+	q := &history.Q{Repo: tt.HorizonRepo()}
+	load := func() (stats []history.AssetStat, seq int32) {
+		err := q.AssetStats().
+			Page(db2.MustPageQuery("", "asc", 10), history.AssetStatsOrderByAsset).
+			Select(&stats)
+		tt.Require.NoError(err)
+		tt.Require.NoError(q.AssetStatsLedger(&seq))
+		return
+	}
+	set := func(seq int32) {
+		_, err := tt.HorizonRepo().ExecRaw(`DELETE FROM asset_stats`)
+		tt.Require.NoError(err)
+		_, err = tt.HorizonRepo().ExecRaw(`UPDATE asset_stats_ledger SET ledger_sequence = ?`, seq)
+		tt.Require.NoError(err)
+	}
+
+	expected := []history.AssetStat{{
+		AssetType:   "credit_alphanum4",
+		AssetCode:   "USD",
+		AssetIssuer: "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4",
+		Amount:      0,
+		NumAccounts: 2,
+		Flags:       3,
+	}}
+
+	// built from stellar-core once ingestion reached its latest ledger
+	stats, seq := load()
+	tt.Assert.Equal(expected, stats)
+	tt.Assert.Equal(int32(9), seq)
+
+	sys := sys(tt)
+
+	// kept current from each ledger's changes, including the flags of the
+	// issuer of a new asset
+	set(3)
+	_, err := sys.ReingestRange(4, 9)
+	tt.Require.NoError(err)
+	stats, seq = load()
+	tt.Assert.Equal(expected, stats)
+	tt.Assert.Equal(int32(9), seq)
+
+	// ledgers the stats already reflect are not applied again
+	_, err = sys.ReingestRange(4, 9)
+	tt.Require.NoError(err)
+	stats, seq = load()
+	tt.Assert.Equal(expected, stats)
+	tt.Assert.Equal(int32(9), seq)
+
+	// a ledger that does not follow the stats' leaves them to be rebuilt
+	set(2)
+	_, err = sys.ReingestRange(4, 8)
+	tt.Require.NoError(err)
+	stats, seq = load()
+	tt.Assert.Len(stats, 0)
+	tt.Assert.Equal(int32(0), seq)
+
+	_, err = sys.ReingestRange(9, 9)
+	tt.Require.NoError(err)
+	stats, seq = load()
+	tt.Assert.Equal(expected, stats)
+	tt.Assert.Equal(int32(9), seq)
+}
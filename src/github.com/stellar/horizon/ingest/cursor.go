@@ -5,6 +5,7 @@ import (
 
 	"github.com/stellar/go/meta"
 	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/toid"
 )
@@ -91,20 +92,30 @@ func (c *Cursor) NextLedger() bool {
 	}
 
 	if c.lg > c.LastLedger {
+		c.Close()
 		c.data = nil
 		c.lg = 0
 		return false
 	}
 
-	c.data = &LedgerBundle{Sequence: c.lg}
-	start := time.Now()
-	c.Err = c.data.Load(c.DB)
+	var loaded loadedLedger
+	if c.ReadAhead > 0 {
+		if c.ahead == nil {
+			c.startReadAhead()
+		}
+		loaded = <-c.ahead
+	} else {
+		loaded = loadLedger(c.DB, c.lg)
+	}
+
+	c.data, c.loaded, c.Err = loaded.data, loaded.duration, loaded.err
 	if c.Err != nil {
+		c.Close()
 		return false
 	}
 
 	if c.Metrics != nil {
-		c.Metrics.LoadLedgerTimer.Update(time.Since(start))
+		c.Metrics.LoadLedgerTimer.Update(c.loaded)
 	}
 
 	c.tx = -1
@@ -113,6 +124,63 @@ func (c *Cursor) NextLedger() bool {
 	return true
 }
 
+// Close stops loading ledgers ahead of `c`, if it is.  It is safe to call
+// more than once, and must be called should the iteration be abandoned
+// before NextLedger returns false.
+func (c *Cursor) Close() {
+	if c.stopAhead == nil {
+		return
+	}
+
+	close(c.stopAhead)
+	c.stopAhead = nil
+	c.ahead = nil
+}
+
+// startReadAhead starts loading the ledgers from the current one through
+// LastLedger in the background, keeping at most ReadAhead of them loaded
+// ahead of the cursor.  Loading stops at the first ledger that fails to load.
+func (c *Cursor) startReadAhead() {
+	ahead := make(chan loadedLedger, c.ReadAhead)
+	stop := make(chan struct{})
+	c.ahead, c.stopAhead = ahead, stop
+
+	// the cursor's repo is not used while reading ahead, but is cloned all the
+	// same so that the two never share a transaction.
+	db := c.DB.Clone()
+	first, last := c.lg, c.LastLedger
+
+	go func() {
+		for seq := first; seq <= last; seq++ {
+			loaded := loadLedger(db, seq)
+
+			select {
+			case ahead <- loaded:
+			case <-stop:
+				return
+			}
+
+			if loaded.err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// loadLedger loads the ledger `seq` from `db`.
+func loadLedger(db *db2.Repo, seq int32) loadedLedger {
+	data := &LedgerBundle{Sequence: seq}
+	start := time.Now()
+	err := data.Load(db)
+	return loadedLedger{data: data, duration: time.Since(start), err: err}
+}
+
+// LoadDuration returns the time taken to load the current ledger from
+// stellar-core.
+func (c *Cursor) LoadDuration() time.Duration {
+	return c.loaded
+}
+
 // NextOp advances `c` to the next operation in the current transaction.  Returns
 // false if the current transaction has nothing left to visit.
 func (c *Cursor) NextOp() bool {
@@ -220,6 +288,144 @@ func (c *Cursor) SuccessfulLedgerOperationCount() (ret int) {
 	return
 }
 
+// SuccessfulLedgerEntryChanges returns the net number of accounts and
+// trustlines created by the successful transactions of the current ledger:
+// the number created less the number removed.
+func (c *Cursor) SuccessfulLedgerEntryChanges() (accounts, trustlines int64) {
+	for i := range c.data.Transactions {
+		if !c.data.Transactions[i].IsSuccessful() {
+			continue
+		}
+
+		for _, op := range c.data.Transactions[i].ResultMeta.MustOperations() {
+			for _, change := range op.Changes {
+				var (
+					typ   xdr.LedgerEntryType
+					delta int64
+				)
+
+				switch change.Type {
+				case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+					typ, delta = change.MustCreated().Data.Type, 1
+				case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+					typ, delta = change.MustRemoved().Type, -1
+				default:
+					continue
+				}
+
+				switch typ {
+				case xdr.LedgerEntryTypeAccount:
+					accounts += delta
+				case xdr.LedgerEntryTypeTrustline:
+					trustlines += delta
+				}
+			}
+		}
+	}
+	return
+}
+
+// SuccessfulLedgerAssetStatChanges returns the net changes the successful
+// transactions of the current ledger made to the stats of each asset, from the
+// trustlines they created, updated and removed, and the new flags of the
+// accounts whose flags they may have changed.  The flags of a removed account
+// are zero.  ok is false should the meta of a trustline's update or removal not
+// be preceded by the trustline's state, without which its change cannot be
+// known.
+func (c *Cursor) SuccessfulLedgerAssetStatChanges() (
+	changes []AssetStatChange,
+	flags []IssuerFlags,
+	ok bool,
+) {
+	byAsset := map[string]int{}
+	change := func(a xdr.Asset, amount xdr.Int64, numAccounts int32) {
+		i, found := byAsset[a.String()]
+		if !found {
+			i = len(changes)
+			byAsset[a.String()] = i
+			changes = append(changes, AssetStatChange{Asset: a})
+		}
+		changes[i].Amount += amount
+		changes[i].NumAccounts += numAccounts
+	}
+
+	byAccount := map[string]int{}
+	setFlags := func(aid xdr.AccountId, f xdr.AccountFlags) {
+		i, found := byAccount[aid.Address()]
+		if !found {
+			i = len(flags)
+			byAccount[aid.Address()] = i
+			flags = append(flags, IssuerFlags{Issuer: aid})
+		}
+		flags[i].Flags = f
+	}
+
+	trustlineKey := func(aid xdr.AccountId, a xdr.Asset) string {
+		return aid.Address() + "/" + a.String()
+	}
+
+	for i := range c.data.Transactions {
+		if !c.data.Transactions[i].IsSuccessful() {
+			continue
+		}
+
+		for _, op := range c.data.Transactions[i].ResultMeta.MustOperations() {
+			// the state of each entry an operation changes precedes the change
+			trustlines := map[string]xdr.TrustLineEntry{}
+			accounts := map[string]xdr.AccountEntry{}
+
+			for _, ch := range op.Changes {
+				switch ch.Type {
+				case xdr.LedgerEntryChangeTypeLedgerEntryState:
+					data := ch.MustState().Data
+					if tl, isTL := data.GetTrustLine(); isTL {
+						trustlines[trustlineKey(tl.AccountId, tl.Asset)] = tl
+					}
+					if acc, isAcc := data.GetAccount(); isAcc {
+						accounts[acc.AccountId.Address()] = acc
+					}
+				case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+					if tl, isTL := ch.MustCreated().Data.GetTrustLine(); isTL {
+						change(tl.Asset, tl.Balance, 1)
+					}
+				case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+					data := ch.MustUpdated().Data
+					if tl, isTL := data.GetTrustLine(); isTL {
+						prev, found := trustlines[trustlineKey(tl.AccountId, tl.Asset)]
+						if !found {
+							return nil, nil, false
+						}
+						change(tl.Asset, tl.Balance-prev.Balance, 0)
+					}
+					// the state of an account is not always recorded, in which
+					// case its flags may have changed
+					if acc, isAcc := data.GetAccount(); isAcc {
+						prev, found := accounts[acc.AccountId.Address()]
+						if !found || acc.Flags != prev.Flags {
+							setFlags(acc.AccountId, xdr.AccountFlags(acc.Flags))
+						}
+					}
+				case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+					key := ch.MustRemoved()
+					if tlk, isTL := key.GetTrustLine(); isTL {
+						prev, found := trustlines[trustlineKey(tlk.AccountId, tlk.Asset)]
+						if !found {
+							return nil, nil, false
+						}
+						change(tlk.Asset, -prev.Balance, -1)
+					}
+					if acck, isAcc := key.GetAccount(); isAcc {
+						setFlags(acck.AccountId, 0)
+					}
+				}
+			}
+		}
+	}
+
+	ok = true
+	return
+}
+
 // SuccessfulTransactionCount returns the count of transactions in the current
 // ledger that succeeded.
 func (c *Cursor) SuccessfulTransactionCount() (ret int) {
@@ -231,6 +437,18 @@ func (c *Cursor) SuccessfulTransactionCount() (ret int) {
 	return
 }
 
+// FailedTransactionCount returns the count of transactions in the current
+// ledger that failed.
+func (c *Cursor) FailedTransactionCount() int {
+	return c.TransactionCount() - c.SuccessfulTransactionCount()
+}
+
+// TransactionCount returns the count of transactions in the current ledger,
+// whether or not they succeeded.
+func (c *Cursor) TransactionCount() int {
+	return len(c.data.Transactions)
+}
+
 // TransactionID returns the current tranaction's id, as used by the history
 // system.
 func (c *Cursor) TransactionID() int64 {
@@ -57,3 +57,48 @@ func TestCursor(t *testing.T) {
 
 	tt.Require.False(c.NextLedger())
 }
+
+func TestCursor_ReadAhead(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+
+	c := Cursor{
+		FirstLedger: 7,
+		LastLedger:  10,
+		DB:          tt.CoreRepo(),
+		ReadAhead:   2,
+	}
+
+	// the ledgers are visited in order, as without reading ahead
+	for seq := int32(7); seq <= 10; seq++ {
+		tt.Require.True(c.NextLedger())
+		tt.Assert.Equal(seq, c.LedgerSequence())
+		tt.Assert.Equal(uint32(seq), c.Ledger().Sequence)
+	}
+	tt.Require.False(c.NextLedger())
+	tt.Assert.NoError(c.Err)
+
+	// an abandoned iteration stops reading ahead
+	c = Cursor{
+		FirstLedger: 1,
+		LastLedger:  10,
+		DB:          tt.CoreRepo(),
+		ReadAhead:   1,
+	}
+	tt.Require.True(c.NextLedger())
+	c.Close()
+	c.Close()
+	tt.Assert.Nil(c.ahead)
+
+	// a ledger that fails to load ends the iteration
+	c = Cursor{
+		FirstLedger: 9,
+		LastLedger:  1000,
+		DB:          tt.CoreRepo(),
+		ReadAhead:   2,
+	}
+	for c.NextLedger() {
+	}
+	tt.Assert.Error(c.Err)
+	tt.Assert.Equal(int32(60), c.lg)
+}
@@ -24,6 +24,10 @@ func (ingest *Ingestion) Clear(start int64, end int64) error {
 	if err != nil {
 		return err
 	}
+	err = clear(start, end, "history_operation_assets", "history_operation_id")
+	if err != nil {
+		return err
+	}
 	err = clear(start, end, "history_operations", "id")
 	if err != nil {
 		return err
@@ -36,6 +40,10 @@ func (ingest *Ingestion) Clear(start int64, end int64) error {
 	if err != nil {
 		return err
 	}
+	err = clear(start, end, "history_ledger_upgrades", "history_ledger_id")
+	if err != nil {
+		return err
+	}
 	err = clear(start, end, "history_ledgers", "id")
 	if err != nil {
 		return err
@@ -44,6 +52,147 @@ func (ingest *Ingestion) Clear(start int64, end int64) error {
 	return nil
 }
 
+// AccountCreated records that the account `aid` was created in the ledger
+// `seq`, closed at `closedAt`, onto its row in the `history_accounts` table.
+// An earlier creation of the account, such as before it was merged and
+// created anew, takes precedence.
+func (ingest *Ingestion) AccountCreated(aid xdr.AccountId, seq int32, closedAt time.Time) error {
+	id, err := ingest.getParticipantID(aid)
+	if err != nil {
+		return err
+	}
+
+	sql := sq.Update("history_accounts").
+		Set("created_ledger", seq).
+		Set("created_at", closedAt).
+		Where("id = ?", id).
+		Where("(created_ledger IS NULL OR created_ledger > ?)", seq)
+
+	return ingest.exec(sql)
+}
+
+// AssetStatChange applies `change` to the row of the `asset_stats` table for
+// its asset, creating the row, with flags of zero, for an asset that had no
+// trustlines and removing it once the asset has none.  created reports whether
+// the row was created, such that the caller may record its issuer's flags.  A
+// change that would leave the asset with fewer than no trustlines fails with
+// ErrAssetStatsDiverged.
+func (ingest *Ingestion) AssetStatChange(change AssetStatChange) (created bool, err error) {
+	var typ, code, issuer string
+	err = change.Asset.Extract(&typ, &code, &issuer)
+	if err != nil {
+		return
+	}
+
+	const update = `UPDATE asset_stats
+		SET amount = amount + ?, num_accounts = num_accounts + ?
+		WHERE asset_code = ? AND asset_issuer = ?
+		RETURNING num_accounts`
+	args := []interface{}{int64(change.Amount), change.NumAccounts, code, issuer}
+	if ingest.Writes != nil {
+		ingest.Writes.Record(update, args)
+	}
+
+	var numAccounts int32
+	err = ingest.DB.GetRaw(&numAccounts, update, args...)
+
+	// the asset had no trustlines
+	if ingest.DB.NoRows(err) {
+		if change.NumAccounts == 0 && change.Amount == 0 {
+			return false, nil
+		}
+		if change.NumAccounts <= 0 {
+			return false, ErrAssetStatsDiverged
+		}
+
+		err = ingest.exec(ingest.asset_stats.Values(
+			typ, code, issuer, int64(change.Amount), change.NumAccounts, 0,
+		))
+		return err == nil, err
+	}
+
+	if err != nil {
+		return
+	}
+
+	switch {
+	case numAccounts < 0:
+		err = ErrAssetStatsDiverged
+	case numAccounts == 0:
+		err = ingest.exec(sq.Delete("asset_stats").Where(sq.Eq{
+			"asset_code":   code,
+			"asset_issuer": issuer,
+		}))
+	}
+
+	return
+}
+
+// AssetIssuerFlags updates the flags recorded in the `asset_stats` table for
+// the assets issued by `issuer`.
+func (ingest *Ingestion) AssetIssuerFlags(issuer xdr.AccountId, flags xdr.AccountFlags) error {
+	sql := sq.Update("asset_stats").
+		Set("flags", int32(flags)).
+		Where("asset_issuer = ?", issuer.Address())
+
+	return ingest.exec(sql)
+}
+
+// AssetStats replaces the contents of the `asset_stats` table with `stats`,
+// recording them as current as of the ledger `seq`.
+func (ingest *Ingestion) AssetStats(stats []core.AssetStat, seq int32) error {
+	err := ingest.ResetAssetStats()
+	if err != nil {
+		return err
+	}
+
+	for _, stat := range stats {
+		a, err := core.AssetFromDB(stat.Assettype, stat.Assetcode, stat.Issuer)
+		if err != nil {
+			return err
+		}
+
+		var typ, code, issuer string
+		err = a.Extract(&typ, &code, &issuer)
+		if err != nil {
+			return err
+		}
+
+		err = ingest.exec(ingest.asset_stats.Values(
+			typ, code, issuer, int64(stat.Amount), stat.NumAccounts, int32(stat.Flags),
+		))
+		if err != nil {
+			return err
+		}
+	}
+
+	return ingest.AssetStatsLedger(seq)
+}
+
+// AssetStatsLedger records that the `asset_stats` table is current as of the
+// ledger `seq`.
+func (ingest *Ingestion) AssetStatsLedger(seq int32) error {
+	err := ingest.exec(sq.Delete("asset_stats_ledger"))
+	if err != nil {
+		return err
+	}
+
+	return ingest.exec(sq.Insert("asset_stats_ledger").
+		Columns("ledger_sequence").
+		Values(seq))
+}
+
+// ResetAssetStats empties the `asset_stats` table, leaving it current as of no
+// ledger until it is rebuilt (see Session.Run).
+func (ingest *Ingestion) ResetAssetStats() error {
+	err := ingest.exec(sq.Delete("asset_stats"))
+	if err != nil {
+		return err
+	}
+
+	return ingest.exec(sq.Delete("asset_stats_ledger"))
+}
+
 // Close finishes the current transaction and finishes this ingestion.
 func (ingest *Ingestion) Close() error {
 	return ingest.commit()
@@ -58,7 +207,7 @@ func (ingest *Ingestion) Effect(aid int64, opid int64, order int, typ history.Ef
 
 	sql := ingest.effects.Values(aid, opid, order, typ, djson)
 
-	_, err = ingest.DB.Exec(sql)
+	err = ingest.exec(sql)
 	if err != nil {
 		return err
 	}
@@ -83,6 +232,8 @@ func (ingest *Ingestion) Ledger(
 	header *core.LedgerHeader,
 	txs int,
 	ops int,
+	totals LedgerTotals,
+	stats LedgerStats,
 ) error {
 
 	sql := ingest.ledgers.Values(
@@ -101,14 +252,20 @@ func (ingest *Ingestion) Ledger(
 		time.Now().UTC(),
 		txs,
 		ops,
+		totals.Accounts,
+		totals.Trustlines,
+		totals.Operations,
+		header.Data.LedgerVersion,
+		stats.FailedTransactions,
+		int64(stats.IngestDuration/time.Millisecond),
 	)
 
-	_, err := ingest.DB.Exec(sql)
+	err := ingest.exec(sql)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return ingest.ledgerUpgrades(id, header)
 }
 
 // Operation ingests the provided operation data into a new row in the
@@ -128,7 +285,7 @@ func (ingest *Ingestion) Operation(
 	}
 
 	sql := ingest.operations.Values(id, txid, order, source.Address(), typ, djson)
-	_, err = ingest.DB.Exec(sql)
+	err = ingest.exec(sql)
 	if err != nil {
 		return err
 	}
@@ -149,7 +306,7 @@ func (ingest *Ingestion) OperationParticipants(op int64, aids []xdr.AccountId) e
 		sql = sql.Values(op, haid)
 	}
 
-	_, err := ingest.DB.Exec(sql)
+	err := ingest.exec(sql)
 	if err != nil {
 		return err
 	}
@@ -157,6 +314,28 @@ func (ingest *Ingestion) OperationParticipants(op int64, aids []xdr.AccountId) e
 	return nil
 }
 
+// OperationAssets ingests the provided assets as involved in the operation
+// with id `op`, creating a new row in the `history_operation_assets` table for
+// each.
+func (ingest *Ingestion) OperationAssets(op int64, assets []xdr.Asset) error {
+	if len(assets) == 0 {
+		return nil
+	}
+
+	sql := ingest.operation_assets
+	for _, a := range assets {
+		var typ, code, iss string
+		err := a.Extract(&typ, &code, &iss)
+		if err != nil {
+			return err
+		}
+		sql = sql.Values(op, typ, code, iss)
+	}
+
+	err := ingest.exec(sql)
+	return err
+}
+
 // Rollback aborts this ingestions transaction
 func (ingest *Ingestion) Rollback() (err error) {
 	err = ingest.DB.Rollback()
@@ -202,9 +381,10 @@ func (ingest *Ingestion) Transaction(
 		tx.Memo(),
 		time.Now().UTC(),
 		time.Now().UTC(),
+		tx.IsSuccessful(),
 	)
 
-	_, err := ingest.DB.Exec(sql)
+	err := ingest.exec(sql)
 	if err != nil {
 		return err
 	}
@@ -226,7 +406,7 @@ func (ingest *Ingestion) TransactionParticipants(tx int64, aids []xdr.AccountId)
 		sql = sql.Values(tx, haid)
 	}
 
-	_, err := ingest.DB.Exec(sql)
+	err := ingest.exec(sql)
 	if err != nil {
 		return err
 	}
@@ -251,6 +431,20 @@ func (ingest *Ingestion) createInsertBuilders() {
 		"updated_at",
 		"transaction_count",
 		"operation_count",
+		"total_accounts",
+		"total_trustlines",
+		"total_operations",
+		"protocol_version",
+		"failed_transaction_count",
+		"ingest_duration_ms",
+	)
+
+	ingest.ledger_upgrades = sq.Insert("history_ledger_upgrades").Columns(
+		"history_ledger_id",
+		"ledger_sequence",
+		"application_order",
+		"type",
+		"value",
 	)
 
 	ingest.accounts = sq.Insert("history_accounts").Columns(
@@ -276,6 +470,7 @@ func (ingest *Ingestion) createInsertBuilders() {
 		"memo",
 		"created_at",
 		"updated_at",
+		"successful",
 	)
 
 	ingest.transaction_participants = sq.Insert("history_transaction_participants").Columns(
@@ -297,6 +492,22 @@ func (ingest *Ingestion) createInsertBuilders() {
 		"history_account_id",
 	)
 
+	ingest.operation_assets = sq.Insert("history_operation_assets").Columns(
+		"history_operation_id",
+		"asset_type",
+		"asset_code",
+		"asset_issuer",
+	)
+
+	ingest.asset_stats = sq.Insert("asset_stats").Columns(
+		"asset_type",
+		"asset_code",
+		"asset_issuer",
+		"amount",
+		"num_accounts",
+		"flags",
+	)
+
 	ingest.effects = sq.Insert("history_effects").Columns(
 		"history_account_id",
 		"history_operation_id",
@@ -306,6 +517,39 @@ func (ingest *Ingestion) createInsertBuilders() {
 	)
 }
 
+// ledgerUpgrades records the network upgrades applied by the ledger described
+// by `header` into the `history_ledger_upgrades` table.
+func (ingest *Ingestion) ledgerUpgrades(id int64, header *core.LedgerHeader) error {
+	upgrades, err := header.Upgrades()
+	if err != nil {
+		return err
+	}
+
+	if len(upgrades) == 0 {
+		return nil
+	}
+
+	sql := ingest.ledger_upgrades
+	for i, u := range upgrades {
+		var value xdr.Uint32
+		switch u.Type {
+		case xdr.LedgerUpgradeTypeLedgerUpgradeVersion:
+			value = u.MustNewLedgerVersion()
+		case xdr.LedgerUpgradeTypeLedgerUpgradeBaseFee:
+			value = u.MustNewBaseFee()
+		case xdr.LedgerUpgradeTypeLedgerUpgradeMaxTxSetSize:
+			value = u.MustNewMaxTxSetSize()
+		default:
+			return fmt.Errorf("unknown ledger upgrade type: %d", u.Type)
+		}
+
+		sql = sql.Values(id, header.Sequence, i+1, u.Type, int64(value))
+	}
+
+	err = ingest.exec(sql)
+	return err
+}
+
 func (ingest *Ingestion) commit() error {
 	err := ingest.DB.Commit()
 	if err != nil {
@@ -332,11 +576,12 @@ func (ingest *Ingestion) getParticipantID(
 		result = existing.ID
 		return
 	}
-	err = ingest.DB.GetRaw(
-		&result,
-		`INSERT INTO history_accounts (address) VALUES (?) RETURNING id`,
-		aid.Address(),
-	)
+	const insert = `INSERT INTO history_accounts (address) VALUES (?) RETURNING id`
+	if ingest.Writes != nil {
+		ingest.Writes.Record(insert, []interface{}{aid.Address()})
+	}
+
+	err = ingest.DB.GetRaw(&result, insert, aid.Address())
 	if err != nil {
 		return
 	}
@@ -0,0 +1,77 @@
+package ingest
+
+import (
+	"fmt"
+
+	"github.com/stellar/horizon/errors"
+)
+
+// Phase names the step of ingesting a ledger during which a LedgerError
+// occurred.
+type Phase string
+
+const (
+	// PhaseClear is the removal of the rows previously ingested for a ledger,
+	// when reingesting.
+	PhaseClear Phase = "clear"
+
+	// PhaseLoad is the loading of a ledger from stellar-core's database.
+	PhaseLoad Phase = "load"
+
+	// PhaseWrite is the writing of a ledger's rows to the history database.
+	PhaseWrite Phase = "write"
+
+	// PhaseValidate is the checking of a ledger against its neighbours or
+	// against the rows ingested for it.
+	PhaseValidate Phase = "validate"
+)
+
+// LedgerError is the error returned when ingesting a particular ledger fails.
+// It records which ledger and during which phase, so that the failing ledger
+// can be found from the error however far it is passed up.  Use errors.As to
+// find it in an error's chain.
+type LedgerError struct {
+	Sequence int32
+	Phase    Phase
+	Err      error
+}
+
+// Cause returns the error that caused ingestion to fail, for compatibility
+// with github.com/pkg/errors.
+func (e *LedgerError) Cause() error {
+	return e.Err
+}
+
+// Class implements errors.Classifier:  the class is that of the underlying
+// error.
+func (e *LedgerError) Class() errors.Class {
+	return errors.Classify(e.Err)
+}
+
+func (e *LedgerError) Error() string {
+	return fmt.Sprintf("ledger %d: %s failed: %s", e.Sequence, e.Phase, e.Err)
+}
+
+// Fields implements errors.Fielder.
+func (e *LedgerError) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"ledger": e.Sequence,
+		"phase":  string(e.Phase),
+	}
+}
+
+// ledgerError returns `err` as a LedgerError for the ledger `seq` and
+// `phase`.  It returns nil if `err` is nil, and `err` unchanged if it already
+// has a LedgerError in its chain.
+func ledgerError(seq int32, phase Phase, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var lerr *LedgerError
+	if errors.As(err, &lerr) {
+		return err
+	}
+
+	return &LedgerError{Sequence: seq, Phase: phase, Err: err}
+}
@@ -0,0 +1,107 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	hlog "github.com/stellar/horizon/log"
+	"github.com/stellar/horizon/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// TestLogEvents compares representative log events of an ingestion session,
+// in each log format, against the golden files in testdata.  Run the test with
+// -update to regenerate them.
+func TestLogEvents(t *testing.T) {
+	for _, format := range []hlog.Format{hlog.TextFormat, hlog.JSONFormat} {
+		output := new(bytes.Buffer)
+		l, _ := hlog.NewWithFormat(format)
+		l.Logger.Out = output
+		l.Logger.Level = logrus.DebugLevel
+		if f, ok := l.Logger.Formatter.(*logrus.TextFormatter); ok {
+			f.DisableColors = true
+		}
+
+		test.OverrideLogger(l)
+		logEvents()
+		test.RestoreLogger()
+
+		got := normalizeLog(t, format, output.Bytes())
+		path := filepath.Join("testdata", "log_events."+format.String()+".golden")
+
+		if *update {
+			require.NoError(t, ioutil.WriteFile(path, got, 0644))
+			continue
+		}
+
+		want, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "format: %s", format)
+	}
+}
+
+// logEvents logs events as a session does while backfilling, failing with an
+// error that spans several lines, and failing verification.
+func logEvents() {
+	is := &Session{Cursor: &Cursor{FirstLedger: 10, LastLedger: 20}}
+	is.logger().Info("ingest: backfilling")
+
+	is.Cursor.lg = 12
+	is.logger().
+		WithField("err", errors.New("failed to load ledger 12:\nno rows in result set")).
+		Error("import session failed")
+
+	is.logger().
+		WithField("count", "operation_count").
+		WithField("expected", 3).
+		WithField("stored", 2).
+		WithField("err", &CountMismatchError{
+			Sequence: 12,
+			Count:    "operation_count",
+			Expected: 3,
+			Stored:   2,
+		}).
+		Error("ingest: verification failed, ingested counts do not match stellar-core")
+}
+
+var (
+	logTime = regexp.MustCompile(`time="[^"]*" `)
+	logPid  = regexp.MustCompile(` pid=\d+`)
+	logEOL  = regexp.MustCompile(`(?m)[ \t]+$`)
+)
+
+// normalizeLog removes the parts of logged entries that vary between runs:
+// the time and the process id.  JSON entries are re-encoded, which sorts their
+// keys.
+func normalizeLog(t *testing.T, format hlog.Format, logged []byte) []byte {
+	if format == hlog.TextFormat {
+		logged = logTime.ReplaceAll(logged, nil)
+		logged = logPid.ReplaceAll(logged, nil)
+		return logEOL.ReplaceAll(logged, nil)
+	}
+
+	var ret bytes.Buffer
+	for _, line := range bytes.Split(bytes.TrimSpace(logged), []byte("\n")) {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(line, &entry))
+		delete(entry, "time")
+		delete(entry, "pid")
+
+		normalized, err := json.Marshal(entry)
+		require.NoError(t, err)
+		ret.Write(normalized)
+		ret.WriteByte('\n')
+	}
+
+	return ret.Bytes()
+}
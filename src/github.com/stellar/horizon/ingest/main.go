@@ -4,12 +4,19 @@
 package ingest
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"sync"
+	"time"
 
 	sq "github.com/lann/squirrel"
 	"github.com/rcrowley/go-metrics"
 	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/db2/core"
+	"github.com/stellar/horizon/errors"
+	"github.com/stellar/horizon/ledger"
+	hlog "github.com/stellar/horizon/log"
+	"golang.org/x/net/context"
 )
 
 const (
@@ -21,9 +28,65 @@ const (
 	// Scripts, that have yet to be ported to this codebase can then be leveraged
 	// to re-ingest old data with the new algorithm, providing a seamless
 	// transition when the ingested data's structure changes.
-	CurrentVersion = 8
+	CurrentVersion = 14
+
+	// AccountCreationVersion is the earliest version of the ingestion
+	// algorithm to record the ledger in which each account was created (see
+	// Ingestion.AccountCreated).
+	AccountCreationVersion = 12
+
+	// MinCoreSchemaVersion is the oldest stellar-core database schema that the
+	// ingestion system is known to be compatible with.
+	MinCoreSchemaVersion = 2
+
+	// MaxCoreSchemaVersion is the newest stellar-core database schema that the
+	// ingestion system is known to be compatible with.  When stellar-core
+	// introduces a new schema, this should be bumped only after confirming
+	// the queries in the db2/core package still work against it.
+	MaxCoreSchemaVersion = 4
+
+	// CoreSchemaCheckInterval is how often the stellar-core schema version is
+	// re-checked while the ingestion system is running.
+	CoreSchemaCheckInterval = 1 * time.Minute
+
+	// BackfillBatchSize is the maximum number of ledgers ingested by a single
+	// backfill session.
+	BackfillBatchSize = 100
+
+	// DefaultReadAhead is the number of ledgers a session loads from
+	// stellar-core ahead of the ledger it is ingesting, unless configured
+	// otherwise (see System.ReadAhead).
+	DefaultReadAhead = 4
+
+	// MaxLedgerStateAge is the oldest the cached ledger state (see
+	// System.LedgerState) may be for the ingestion system to act upon it, when
+	// the state cannot be refreshed on demand because no ledger.Loader is
+	// registered.
+	MaxLedgerStateAge = 10 * time.Second
 )
 
+// log is the logger used throughout the ingest package, whose level may be set
+// independently of the rest of horizon's (see hlog.SetLevelFor).
+var log = hlog.For(hlog.IngestSubsystem)
+
+// inProgressLog samples the message that would otherwise be logged on every
+// tick for as long as a long reingestion or backfill is in progress.  The gaps
+// ingestion cannot get past are sampled per System and gap instead (see
+// System.gapSampler).
+var inProgressLog = hlog.Every(time.Minute)
+
+// CoreSchemaError is the error returned when the connected stellar-core
+// database's schema version cannot be read or is outside of the range this
+// version of horizon is compatible with.
+type CoreSchemaError struct {
+	// Version is the schema version reported by stellar-core, or 0 if it could
+	// not be read.
+	Version int
+
+	// Err is the error encountered while reading the schema version, if any.
+	Err error
+}
+
 // Cursor iterates through a stellar core database's ledgers
 type Cursor struct {
 	// FirstLedger is the beginning of the range of ledgers (inclusive) that will
@@ -37,13 +100,24 @@ type Cursor struct {
 
 	Metrics *IngesterMetrics
 
+	// ReadAhead, when non-zero, is the most ledgers to load from stellar-core,
+	// in the background, ahead of the current ledger.  This overlaps the
+	// loading of the next ledgers with the ingestion of the current one.
+	// Reading ahead must be stopped with Close should the iteration not run to
+	// completion.
+	ReadAhead int
+
 	// Err is the error that caused this iteration to fail, if any.
 	Err error
 
-	lg   int32
-	tx   int
-	op   int
-	data *LedgerBundle
+	lg     int32
+	tx     int
+	op     int
+	data   *LedgerBundle
+	loaded time.Duration
+
+	ahead     chan loadedLedger
+	stopAhead chan struct{}
 }
 
 // EffectIngestion is a helper struct to smooth the ingestion of effects.  this
@@ -57,6 +131,25 @@ type EffectIngestion struct {
 	parent      *Ingestion
 }
 
+// loadedLedger is a ledger loaded from stellar-core ahead of a Cursor.
+type loadedLedger struct {
+	data     *LedgerBundle
+	duration time.Duration
+	err      error
+}
+
+// LedgerStats are the statistics of a ledger's ingestion recorded with it
+// (see history.Ledger).
+type LedgerStats struct {
+	// FailedTransactions is the count of transactions in the ledger that
+	// failed, whether or not they are stored.
+	FailedTransactions int
+
+	// IngestDuration is the time taken to load the ledger from stellar-core
+	// and write its history.
+	IngestDuration time.Duration
+}
+
 // LedgerBundle represents a single ledger's worth of novelty created by one
 // ledger close
 type LedgerBundle struct {
@@ -77,6 +170,10 @@ type System struct {
 
 	Metrics IngesterMetrics
 
+	// LedgerState provides the ledger state that determines which ledgers are
+	// ingested.  When nil, ledger.Default is used.
+	LedgerState ledger.StateProvider
+
 	// Network is the passphrase for the network being imported
 	Network string
 
@@ -89,8 +186,83 @@ type System struct {
 	// stellar-core
 	SkipCursorUpdate bool
 
-	lock    sync.Mutex
-	current *Session
+	// SkipCoreSchemaCheck causes the ingestor to run even when the connected
+	// stellar-core database reports a schema version outside of the known
+	// compatible range.
+	SkipCoreSchemaCheck bool
+
+	// FastStartCount, when non-zero, causes ingestion into an empty history
+	// database to begin at the ledger `FastStartCount` ledgers before the latest
+	// ledger known to stellar-core, rather than at stellar-core's elder ledger.
+	// This allows a fresh horizon to serve recent data quickly.
+	FastStartCount int32
+
+	// IngestFloor, when non-zero, is the oldest ledger the ingestor will ingest
+	// when catching up, reingesting all ledgers or backfilling, even if
+	// stellar-core holds older ledgers.  The history database's elder ledger
+	// then never precedes it.
+	IngestFloor int32
+
+	// Backfill causes the ingestor to gradually ingest the ledgers between
+	// stellar-core's elder ledger and the history database's elder ledger,
+	// working backwards, after keeping up with new ledgers each tick.
+	Backfill bool
+
+	// RetentionCount, when non-zero, is the number of the latest ledgers the
+	// reaper retains in the history database (see reap.System).  Backfilling
+	// stops at the oldest of them, rather than ingesting ledgers the reaper
+	// would delete.
+	RetentionCount uint
+
+	// SkipEffects causes the ingestor to skip generating effects (including
+	// trades) and writing them to the history database.
+	SkipEffects bool
+
+	// FailedTransactionFeeEffects causes the ingestor to record the fee charged
+	// for each failed transaction as an account_debited effect of its source
+	// account.  Failed transactions are otherwise not ingested.
+	FailedTransactionFeeEffects bool
+
+	// FailedTransactions causes the ingestor to store each failed transaction,
+	// and its participants, in the history database with `successful` false.
+	// Its operations, which had no effect, are not stored.
+	FailedTransactions bool
+
+	// VerifyIngestedCounts causes the ingestor to check, after ingesting each
+	// ledger, that the transactions and operations stored for it match
+	// stellar-core's record of the ledger.  A mismatch fails the session with a
+	// *CountMismatchError.  It is off by default, as it costs additional
+	// queries per ledger.
+	VerifyIngestedCounts bool
+
+	// LogWrites causes the ingestor to log, after ingesting each ledger, a
+	// summary of the rows it wrote to each table of the history database.
+	// LogWriteData additionally logs every statement with its arguments.
+	// Both are meant for debugging ingestion and are off by default.
+	LogWrites    bool
+	LogWriteData bool
+
+	// ReadAhead is the most ledgers each session loads from stellar-core ahead
+	// of the ledger it is ingesting (see Cursor.ReadAhead).  Zero disables
+	// reading ahead.  New sets it to DefaultReadAhead.
+	ReadAhead int
+
+	// current is the session in progress, if any, whether started by a tick,
+	// by backfilling or by reingesting a range.  currentDone is closed once it
+	// finishes.
+	lock            sync.Mutex
+	current         *Session
+	currentDone     chan struct{}
+	currentBackfill bool
+	currentReingest bool
+
+	coreSchemaCheckedAt time.Time
+	coreSchemaErr       error
+
+	// gapLog samples the message logged on every tick that cannot get past the
+	// gap preceding gapLedger.  Only accessed by the session in progress.
+	gapLog    *hlog.Sampler
+	gapLedger int32
 }
 
 // IngesterMetrics tracks all the metrics for the ingestion subsystem
@@ -106,18 +278,43 @@ type Ingestion struct {
 	// database.
 	DB *db2.Repo
 
+	// Writes, when not nil, records the statements the ingestion executes.
+	Writes *WriteLog
+
 	ledgers                  sq.InsertBuilder
+	ledger_upgrades          sq.InsertBuilder
 	transactions             sq.InsertBuilder
 	transaction_participants sq.InsertBuilder
 	operations               sq.InsertBuilder
 	operation_participants   sq.InsertBuilder
+	operation_assets         sq.InsertBuilder
 	effects                  sq.InsertBuilder
 	accounts                 sq.InsertBuilder
+	asset_stats              sq.InsertBuilder
 }
 
 // Session represents a single attempt at ingesting data into the history
 // database.
 type Session struct {
+	// ID identifies the session in log entries.  It is generated by NewSession.
+	ID string
+
+	// CreatedAt is the time at which NewSession created the session.
+	CreatedAt time.Time
+
+	// Ctx is the context to which the session's logger is bound (see hlog.Set),
+	// such that the entries logged for the session, including those logged by
+	// its database repos, carry the session's ID.
+	Ctx context.Context
+
+	// Breadcrumbs records the session's recent steps, which reports to sentry
+	// of errors carrying the session's ID include.  It is started by
+	// NewSession, unless the session has no ledgers to ingest:  such sessions,
+	// created by every tick that finds no new ledger, would otherwise push the
+	// trails of sessions that did ingest out of those kept.  It is nil, and
+	// records nothing, for an empty session.
+	Breadcrumbs *errors.Breadcrumbs
+
 	Cursor    *Cursor
 	Ingestion *Ingestion
 	// Network is the passphrase for the network being imported
@@ -136,6 +333,21 @@ type Session struct {
 	// stellar-core
 	SkipCursorUpdate bool
 
+	// SkipEffects causes the session to skip generating effects.
+	SkipEffects bool
+
+	// FailedTransactionFeeEffects causes the session to record the fees of
+	// failed transactions as effects; see System.FailedTransactionFeeEffects.
+	FailedTransactionFeeEffects bool
+
+	// FailedTransactions causes the session to store failed transactions; see
+	// System.FailedTransactions.
+	FailedTransactions bool
+
+	// VerifyIngestedCounts causes the session to verify the counts of each
+	// ingested ledger; see System.VerifyIngestedCounts.
+	VerifyIngestedCounts bool
+
 	// Metrics is a reference to where the session should record its metric information
 	Metrics *IngesterMetrics
 
@@ -159,6 +371,8 @@ func New(network string, coreURL string, core, horizon *db2.Repo) *System {
 		StellarCoreURL: coreURL,
 		HorizonDB:      horizon,
 		CoreDB:         core,
+		LedgerState:    ledger.Default,
+		ReadAhead:      DefaultReadAhead,
 	}
 
 	i.Metrics.ClearLedgerTimer = metrics.NewTimer()
@@ -168,23 +382,70 @@ func New(network string, coreURL string, core, horizon *db2.Repo) *System {
 }
 
 // NewSession initialize a new ingestion session, from `first` to `last` using
-// `i`.
+// `i`.  The session is given a new ID, which every entry logged for it carries.
 func NewSession(first, last int32, i *System) *Session {
+	id := newSessionID()
+
+	parent := i.HorizonDB.Ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx := hlog.PushContext(parent, func(l *hlog.Entry) *hlog.Entry {
+		return l.In(hlog.IngestSubsystem).WithField("session", id)
+	})
+
 	hdb := i.HorizonDB.Clone()
+	hdb.Ctx = ctx
+	cdb := i.CoreDB.Clone()
+	cdb.Ctx = ctx
+
+	var writes *WriteLog
+	if i.LogWrites || i.LogWriteData {
+		writes = &WriteLog{Data: i.LogWriteData}
+	}
+
+	var crumbs *errors.Breadcrumbs
+	if first <= last {
+		crumbs = errors.StartBreadcrumbs(id)
+	}
 
 	return &Session{
+		ID:          id,
+		CreatedAt:   time.Now(),
+		Ctx:         ctx,
+		Breadcrumbs: crumbs,
 		Ingestion: &Ingestion{
-			DB: hdb,
+			DB:     hdb,
+			Writes: writes,
 		},
 		Cursor: &Cursor{
 			FirstLedger: first,
 			LastLedger:  last,
-			DB:          i.CoreDB,
+			DB:          cdb,
 			Metrics:     &i.Metrics,
+			ReadAhead:   i.ReadAhead,
 		},
 		Network:          i.Network,
 		StellarCoreURL:   i.StellarCoreURL,
 		SkipCursorUpdate: i.SkipCursorUpdate,
+		SkipEffects:      i.SkipEffects,
 		Metrics:          &i.Metrics,
+
+		FailedTransactionFeeEffects: i.FailedTransactionFeeEffects,
+		FailedTransactions:          i.FailedTransactions,
+		VerifyIngestedCounts:        i.VerifyIngestedCounts,
+	}
+}
+
+// newSessionID returns a short, random identifier for an ingestion session.
+func newSessionID() string {
+	var b [4]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		// the id only distinguishes sessions in the logs, so a constant one is
+		// better than none.
+		return "00000000"
 	}
+
+	return hex.EncodeToString(b[:])
 }
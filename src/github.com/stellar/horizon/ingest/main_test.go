@@ -1,10 +1,22 @@
 package ingest
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/db2/core"
+	"github.com/stellar/horizon/db2/history"
+	herrors "github.com/stellar/horizon/errors"
+	"github.com/stellar/horizon/ledger"
 	"github.com/stellar/horizon/test"
+	"golang.org/x/net/context"
 )
 
 func TestIngest(t *testing.T) {
@@ -20,11 +32,29 @@ func TestIngest(t *testing.T) {
 	s.Run()
 	tt.Require.Error(s.Err, "Reimport didn't fail as expected")
 
+	// paging tokens are derived from each record's position in the ledger
+	// history, and so must survive reingestion unchanged.
+	tokens := func() (ops []string, effects []string) {
+		err := tt.HorizonRepo().SelectRaw(&ops,
+			`SELECT id::text FROM history_operations ORDER BY id`)
+		tt.Require.NoError(err)
+		err = tt.HorizonRepo().SelectRaw(&effects,
+			`SELECT history_operation_id || '-' || "order" FROM history_effects
+			 ORDER BY history_operation_id, "order"`)
+		tt.Require.NoError(err)
+		return
+	}
+	opsBefore, effectsBefore := tokens()
+
 	// Test that re-importing fails with allowing clear succeeds
 	s.Err = nil
 	s.ClearExisting = true
 	s.Run()
 	tt.Require.NoError(s.Err, "Couldn't re-import, even with clear allowed")
+
+	opsAfter, effectsAfter := tokens()
+	tt.Assert.Equal(opsBefore, opsAfter)
+	tt.Assert.Equal(effectsBefore, effectsAfter)
 }
 
 func TestTick(t *testing.T) {
@@ -37,10 +67,576 @@ func TestTick(t *testing.T) {
 	tt.Require.NoError(s.Err)
 	tt.Require.Nil(sys.current)
 
+	updateState(tt, sys)
+	s = sys.Tick()
+	tt.Require.NotNil(s)
+	tt.Require.NoError(s.Err)
+}
+
+func TestCurrentSession(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	_, ok := sys.CurrentSession()
+	tt.Assert.False(ok)
+
+	is := NewSession(2, 3, sys)
+	sys.current = is
+	status, ok := sys.CurrentSession()
+	if tt.Assert.True(ok) {
+		tt.Assert.Equal(is.ID, status.ID)
+		tt.Assert.Equal(int32(2), status.FirstLedger)
+		tt.Assert.Equal(int32(3), status.LastLedger)
+		tt.Assert.Equal(is.CreatedAt, status.CreatedAt)
+		tt.Assert.False(status.Backfill)
+	}
+
+	// the session is no longer reported once it has run
+	sys.runOnce(ledger.CurrentState())
+	_, ok = sys.CurrentSession()
+	tt.Assert.False(ok)
+}
+
+func TestTickStaleState(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	simulated(sys).Advance(2 * MaxLedgerStateAge)
+
+	s := sys.Tick()
+	tt.Require.NotNil(s)
+	tt.Assert.IsType(&ledger.StaleStateError{}, s.Err)
+	tt.Assert.Equal(0, s.Ingested)
+
+	// once refreshed, ingestion proceeds
+	updateState(tt, sys)
+	s = sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.NotEqual(0, s.Ingested)
+}
+
+func TestTickRefresh(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	sim := simulated(sys)
+	sim.SetLoader(func(ctx context.Context) (ledger.State, error) {
+		return tt.LoadLedgerState()
+	})
+
+	// the cached state is refreshed before ingesting, regardless of its age
+	sim.Advance(2 * MaxLedgerStateAge)
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.NotEqual(0, s.Ingested)
+
+	// ...and again after, so that the ingested ledgers are visible immediately
+	ls := sim.CurrentState()
+	tt.Assert.Equal(ls.CoreLatest, ls.HistoryLatest)
+	tt.Assert.NotEqual(int32(0), ls.HistoryElder)
+
+	// a failed refresh prevents ingestion
+	boom := errors.New("boom")
+	sim.SetLoader(func(ctx context.Context) (ledger.State, error) {
+		return ledger.State{}, boom
+	})
+
+	s = sys.Tick()
+	tt.Require.NotNil(s)
+	tt.Assert.Equal(boom, s.Err)
+}
+
+func TestTickTrustsPrimaries(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	sim := simulated(sys)
+	primary := sim.CurrentState()
+
+	// a secondary core ahead of the primary must not affect which ledgers are
+	// ingested.
+	sim.SetReading(ledger.Reading{
+		Source: ledger.CoreSecondary,
+		Latest: primary.CoreLatest + 10,
+		Elder:  primary.CoreElder,
+	})
+
+	ls, err := sys.refreshLedgerState()
+	tt.Require.NoError(err)
+	tt.Assert.Equal(primary.CoreLatest, ls.CoreLatest)
+	tt.Assert.Equal(primary.HistoryLatest, ls.HistoryLatest)
+
+	is := sys.newTickSession(ls)
+	tt.Assert.Equal(primary.CoreElder, is.Cursor.FirstLedger)
+	tt.Assert.Equal(primary.CoreLatest, is.Cursor.LastLedger)
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.NotEqual(0, s.Ingested)
+}
+
+func TestTickAfterBoot(t *testing.T) {
+	// warm boot: history is caught up, but only the seeded state is known
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	// seeding applies to the package-level ledger state
+	sys.LedgerState = ledger.Default
+	ledger.Reset()
+
+	ledger.Seed(ledger.Reading{Source: ledger.HistoryPrimary, Latest: 3, Elder: 1})
+	tt.Assert.Equal(int32(3), ledger.CurrentState().HistoryLatest)
+
+	s := sys.Tick()
+	tt.Require.NotNil(s)
+	tt.Assert.IsType(&ledger.StaleStateError{}, s.Err)
+	tt.Assert.Equal(0, s.Ingested)
+	tt.Assert.NotContains(tt.LogBuffer.String(), "history db is empty")
+
 	tt.UpdateLedgerState()
 	s = sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(0, s.Ingested)
+	tt.Assert.NotContains(tt.LogBuffer.String(), "history db is empty")
+}
+
+func TestTickAfterColdBoot(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+	sys.LedgerState = ledger.Default
+	ledger.Reset()
+
+	// an empty history db seeds zeros, which are not acted upon either
+	ledger.Seed(ledger.Reading{Source: ledger.HistoryPrimary})
+	s := sys.Tick()
 	tt.Require.NotNil(s)
+	tt.Assert.IsType(&ledger.StaleStateError{}, s.Err)
+	tt.Assert.Equal(0, s.Ingested)
+
+	tt.UpdateLedgerState()
+	s = sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(int32(1), s.Cursor.FirstLedger)
+	tt.Assert.NotEqual(0, s.Ingested)
+}
+
+func TestFastStartAndBackfill(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+	sys.FastStartCount = 10
+
+	// an empty history db starts near the latest ledger
+	updateState(tt, sys)
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(int32(49), s.Cursor.FirstLedger)
+	tt.Assert.Equal(11, s.Ingested)
+
+	// subsequent ticks backfill towards the core elder ledger
+	sys.Backfill = true
+	updateState(tt, sys)
+	s = sys.Tick()
+	tt.Require.NoError(s.Err)
+
+	updateState(tt, sys)
+	ls := simulated(sys).CurrentState()
+	tt.Assert.Equal(ls.CoreElder, ls.HistoryElder)
+	tt.Assert.Equal(ls.CoreLatest, ls.HistoryLatest)
+
+	// backfilling a complete history is a no-op
+	s = sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(0, s.Ingested)
+}
+
+func TestIngestFloor(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+	sys.IngestFloor = 50
+	sys.Backfill = true
+
+	// ingestion starts at the floor rather than stellar-core's elder ledger
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(int32(50), s.Cursor.FirstLedger)
+	tt.Assert.Equal(10, s.Ingested)
+
+	// ...and backfilling stops at it
+	updateState(tt, sys)
+	s = sys.Tick()
+	tt.Require.NoError(s.Err)
+
+	updateState(tt, sys)
+	ls := simulated(sys).CurrentState()
+	tt.Assert.Equal(int32(1), ls.CoreElder)
+	tt.Assert.Equal(int32(50), ls.HistoryElder)
+
+	// as does reingesting all ledgers
+	n, err := sys.ReingestAll()
+	tt.Require.NoError(err)
+	tt.Assert.Equal(10, n)
+
+	// a floor beyond stellar-core's latest ledger ingests nothing, and says so
+	sys.IngestFloor = ls.CoreLatest + 1
+	tt.LogBuffer.Reset()
+	n, err = sys.ReingestAll()
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, n)
+	tt.Assert.Contains(tt.LogBuffer.String(), "ingest floor is beyond stellar-core's latest ledger")
+}
+
+func TestBackfillRetentionCount(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+	sys.FastStartCount = 10
+	sys.RetentionCount = 20
+	sys.Backfill = true
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(int32(49), s.Cursor.FirstLedger)
+
+	// backfilling stops at the oldest ledger the reaper retains
+	updateState(tt, sys)
+	s = sys.Tick()
+	tt.Require.NoError(s.Err)
+
+	updateState(tt, sys)
+	ls := simulated(sys).CurrentState()
+	tt.Assert.Equal(int32(40), ls.HistoryElder)
+}
+
+func TestBackfillHashMismatch(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+	sys.FastStartCount = 10
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(int32(49), s.Cursor.FirstLedger)
+
+	_, err := tt.HorizonRepo().ExecRaw(
+		`UPDATE history_ledgers SET previous_ledger_hash = ? WHERE sequence = 49`,
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	tt.Require.NoError(err)
+
+	// a batch that does not chain into the elder ledger is not ingested
+	sys.Backfill = true
+	tt.LogBuffer.Reset()
+	updateState(tt, sys)
+	s = sys.Tick()
 	tt.Require.NoError(s.Err)
+
+	updateState(tt, sys)
+	ls := simulated(sys).CurrentState()
+	tt.Assert.Equal(int32(49), ls.HistoryElder)
+	tt.Assert.Contains(tt.LogBuffer.String(), "backfill does not chain into the history elder ledger")
+}
+
+func TestSessionLogContext(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+	ls := simulated(sys).CurrentState()
+
+	is := NewSession(ls.CoreElder, ls.CoreLatest, sys)
+	other := NewSession(ls.CoreElder, ls.CoreLatest, sys)
+	tt.Assert.NotEqual(is.ID, other.ID)
+
+	tt.LogBuffer.Reset()
+	is.Run()
+	tt.Require.NoError(is.Err)
+
+	// every entry logged while the session ran carries its id, including the
+	// queries logged by its database repos.
+	logged := strings.TrimSpace(tt.LogBuffer.String())
+	tt.Assert.Contains(logged, "subsystem=db")
+	for _, line := range strings.Split(logged, "\n") {
+		tt.Assert.Contains(line, "session="+is.ID)
+	}
+}
+
+func TestSessionBreadcrumbs(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+	ls := simulated(sys).CurrentState()
+
+	is := NewSession(ls.CoreElder, ls.CoreLatest, sys)
+	tt.Assert.NotNil(is.Breadcrumbs)
+	tt.Assert.Equal(is.Breadcrumbs, herrors.BreadcrumbsFor(is.ID))
+
+	// a session with no ledgers to ingest starts no trail
+	empty := NewSession(ls.CoreLatest+1, ls.CoreLatest, sys)
+	tt.Assert.Nil(empty.Breadcrumbs)
+	tt.Assert.Nil(herrors.BreadcrumbsFor(empty.ID))
+}
+
+func TestAccountCreation(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+
+	s := ingest(tt)
+	tt.Require.NoError(s.Err)
+
+	q := &history.Q{Repo: tt.HorizonRepo()}
+	load := func(address string) (account history.Account) {
+		tt.Require.NoError(q.AccountByAddress(&account, address))
+		return
+	}
+
+	// the root account is created by the genesis ledger, not an operation
+	root := load("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	tt.Assert.False(root.CreatedLedger.Valid)
+	tt.Assert.Nil(root.CreatedAt)
+
+	var ledger history.Ledger
+	tt.Require.NoError(q.LedgerBySequence(&ledger, 2))
+
+	const address = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+	account := load(address)
+	tt.Assert.Equal(int64(2), account.CreatedLedger.Int64)
+	if tt.Assert.NotNil(account.CreatedAt) {
+		tt.Assert.Equal(ledger.ClosedAt.Unix(), account.CreatedAt.Unix())
+	}
+
+	// reingesting keeps the earliest creation recorded
+	reingest := func(createdLedger int) int64 {
+		_, err := tt.HorizonRepo().ExecRaw(
+			`UPDATE history_accounts SET created_ledger = ? WHERE address = ?`,
+			createdLedger, address,
+		)
+		tt.Require.NoError(err)
+
+		s.Err = nil
+		s.ClearExisting = true
+		s.Run()
+		tt.Require.NoError(s.Err)
+		return load(address).CreatedLedger.Int64
+	}
+
+	tt.Assert.Equal(int64(1), reingest(1))
+	tt.Assert.Equal(int64(2), reingest(3))
+}
+
+func TestSkipEffects(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+	sys.SkipEffects = true
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(59, s.Ingested)
+
+	var operations, effects int
+	err := tt.HorizonRepo().GetRaw(&operations, "SELECT COUNT(*) FROM history_operations")
+	tt.Require.NoError(err)
+	err = tt.HorizonRepo().GetRaw(&effects, "SELECT COUNT(*) FROM history_effects")
+	tt.Require.NoError(err)
+
+	tt.Assert.NotEqual(0, operations)
+	tt.Assert.Equal(0, effects)
+}
+
+func TestFailedTransactionFeeEffects(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+	sys.FailedTransactionFeeEffects = true
+
+	hash := addFailedTransaction(tt, "cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a")
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+
+	// the transaction itself is not ingested...
+	var txs int
+	err := tt.HorizonRepo().GetRaw(&txs,
+		"SELECT COUNT(*) FROM history_transactions WHERE transaction_hash = ?", hash)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, txs)
+
+	// ...nor are fees recorded for successful transactions
+	var fees int
+	err = tt.HorizonRepo().GetRaw(&fees,
+		"SELECT COUNT(*) FROM history_effects WHERE details->>'fee' = 'true'")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(1, fees)
+
+	// ...but its fee is
+	var fee struct {
+		Account string `db:"address"`
+		Amount  string `db:"amount"`
+	}
+	err = tt.HorizonRepo().GetRaw(&fee, `
+		SELECT ha.address, he.details->>'amount' AS amount
+		FROM history_effects he
+		JOIN history_accounts ha ON ha.id = he.history_account_id
+		WHERE he.type = ?
+		AND he.details->>'fee' = 'true'
+		AND he.details->>'transaction_hash' = ?`,
+		history.EffectAccountDebited, hash,
+	)
+	tt.Require.NoError(err)
+	tt.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", fee.Account)
+	tt.Assert.Equal("0.0000100", fee.Amount)
+}
+
+func TestFailedTransactions(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+	sys.FailedTransactions = true
+	sys.VerifyIngestedCounts = true
+
+	hash := addFailedTransaction(tt, "cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a")
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+
+	// the failed transaction is stored, with its participants...
+	var tx struct {
+		ID         int64 `db:"id"`
+		Successful bool  `db:"successful"`
+	}
+	err := tt.HorizonRepo().GetRaw(&tx,
+		"SELECT id, successful FROM history_transactions WHERE transaction_hash = ?", hash)
+	tt.Require.NoError(err)
+	tt.Assert.False(tx.Successful)
+
+	var participants int
+	err = tt.HorizonRepo().GetRaw(&participants,
+		"SELECT COUNT(*) FROM history_transaction_participants WHERE history_transaction_id = ?", tx.ID)
+	tt.Require.NoError(err)
+	tt.Assert.NotEqual(0, participants)
+
+	// ...but not its operations
+	var ops int
+	err = tt.HorizonRepo().GetRaw(&ops,
+		"SELECT COUNT(*) FROM history_operations WHERE transaction_id = ?", tx.ID)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, ops)
+
+	// while successful transactions are stored as such
+	var failed, successful int
+	err = tt.HorizonRepo().GetRaw(&failed,
+		"SELECT COUNT(*) FROM history_transactions WHERE NOT successful")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(1, failed)
+	err = tt.HorizonRepo().GetRaw(&successful,
+		"SELECT COUNT(*) FROM history_transactions WHERE successful")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(4, successful)
+}
+
+func TestLedgerStats(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	addFailedTransaction(tt, "cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a")
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+
+	q := &history.Q{Repo: tt.HorizonRepo()}
+	var ledgers []history.Ledger
+	err := q.Ledgers().
+		Page(db2.PageQuery{Order: db2.OrderAscending, Limit: 10}).
+		Select(&ledgers)
+	tt.Require.NoError(err)
+	tt.Require.Len(ledgers, 3)
+
+	failed := int64(0)
+	for _, l := range ledgers {
+		tt.Assert.True(l.ProtocolVersion.Valid)
+		tt.Assert.True(l.FailedTransactionCount.Valid)
+		tt.Assert.True(l.IngestDurationMs.Valid)
+		failed += l.FailedTransactionCount.Int64
+	}
+	tt.Assert.Equal(int64(1), failed)
+
+	// the protocol version is that in force once the ledger closed, after its
+	// upgrades.
+	tt.Assert.Equal(int64(0), ledgers[0].ProtocolVersion.Int64)
+	tt.Assert.Equal(int64(2), ledgers[1].ProtocolVersion.Int64)
+}
+
+func TestFailedTransactionFeeEffects_Disabled(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	addFailedTransaction(tt, "cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a")
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+
+	var fees int
+	err := tt.HorizonRepo().GetRaw(&fees,
+		"SELECT COUNT(*) FROM history_effects WHERE details->>'fee' = 'true'")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, fees)
+}
+
+// addFailedTransaction adds a failed transaction to the stellar-core
+// database, returning its hash.  It is a copy of the transaction `hash`,
+// applied after the other transactions of its ledger, that failed, charging a
+// fee of 100 stroops.  None of the scenarios include a failed transaction, and
+// those they do include are left as they are.
+func addFailedTransaction(tt *test.T, hash string) string {
+	q := &core.Q{Repo: tt.CoreRepo()}
+
+	var tx core.Transaction
+	err := q.TransactionByHash(&tx, hash)
+	tt.Require.NoError(err)
+
+	id := sha256.Sum256([]byte("failed:" + hash))
+	failed := hex.EncodeToString(id[:])
+
+	tx.Result.TransactionHash = xdr.Hash(id)
+	tx.Result.Result.FeeCharged = 100
+	tx.Result.Result.Result.Code = xdr.TransactionResultCodeTxFailed
+	tx.ResultMeta.Operations = &[]xdr.OperationMeta{}
+	result, err := xdr.MarshalBase64(tx.Result)
+	tt.Require.NoError(err)
+	meta, err := xdr.MarshalBase64(tx.ResultMeta)
+	tt.Require.NoError(err)
+
+	_, err = tt.CoreRepo().ExecRaw(`
+		INSERT INTO txhistory (txid, ledgerseq, txindex, txbody, txresult, txmeta)
+		SELECT ?, ledgerseq, (
+			SELECT MAX(txindex) + 1 FROM txhistory WHERE ledgerseq = t.ledgerseq
+		), txbody, ?, ?
+		FROM txhistory t WHERE txid = ?`,
+		failed, result, meta, hash,
+	)
+	tt.Require.NoError(err)
+
+	_, err = tt.CoreRepo().ExecRaw(`
+		INSERT INTO txfeehistory (txid, ledgerseq, txindex, txchanges)
+		SELECT ?, ledgerseq, (
+			SELECT MAX(txindex) FROM txhistory WHERE ledgerseq = f.ledgerseq
+		), txchanges
+		FROM txfeehistory f WHERE txid = ?`,
+		failed, hash,
+	)
+	tt.Require.NoError(err)
+
+	return failed
 }
 
 func ingest(tt *test.T) *Session {
@@ -48,11 +644,115 @@ func ingest(tt *test.T) *Session {
 	return sys.Tick()
 }
 
+// sys returns an ingestion system for the test databases, whose ledger state
+// is simulated (see simulated) rather than the package-level one.  The state
+// is initially that of the test databases.
 func sys(tt *test.T) *System {
-	return New(
+	sys := New(
 		network.TestNetworkPassphrase,
 		"",
 		tt.CoreRepo(),
 		tt.HorizonRepo(),
 	)
+	sys.LedgerState = ledger.NewSimulated(time.Now())
+	updateState(tt, sys)
+	return sys
+}
+
+// simulated returns the simulated ledger state of `sys`.
+func simulated(sys *System) *ledger.Simulated {
+	return sys.LedgerState.(*ledger.Simulated)
+}
+
+// updateState sets the simulated ledger state of `sys` to that of the test
+// databases, as taken now.
+func updateState(tt *test.T, sys *System) {
+	next, err := tt.LoadLedgerState()
+	tt.Require.NoError(err)
+	simulated(sys).SetState(next)
+}
+
+func TestReingestRangeSession(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	tick := NewSession(2, 3, sys)
+	sys.lock.Lock()
+	sys.startSessionLocked(tick)
+	sys.lock.Unlock()
+
+	done := make(chan error)
+	go func() {
+		_, err := sys.ReingestRange(2, 3)
+		done <- err
+	}()
+
+	// the reingestion waits for the session in progress to finish
+	select {
+	case err := <-done:
+		t.Fatalf("reingestion did not wait for the session in progress: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	status, ok := sys.CurrentSession()
+	if tt.Assert.True(ok) {
+		tt.Assert.Equal(tick.ID, status.ID)
+		tt.Assert.False(status.Reingest)
+	}
+
+	sys.finishSession()
+	tt.Require.NoError(<-done)
+
+	// and is no longer reported once it has run
+	_, ok = sys.CurrentSession()
+	tt.Assert.False(ok)
+}
+
+func TestStartSessionPanic(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	// a panic while building a session leaves no session in progress, nor the
+	// system locked
+	func() {
+		defer func() { recover() }()
+		sys.startSession(func() *Session {
+			panic("boom")
+		})
+	}()
+
+	done := make(chan bool)
+	go func() {
+		_, ok := sys.CurrentSession()
+		done <- ok
+	}()
+
+	select {
+	case ok := <-done:
+		tt.Assert.False(ok)
+	case <-time.After(time.Second):
+		t.Fatal("the system was left locked")
+	}
+}
+
+func TestGapSampler(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	sys := &System{}
+
+	// the same gap is sampled...
+	s := sys.gapSampler(10)
+	_, ok := s.Sample(log.WithField("gap", 10))
+	tt.Assert.True(ok)
+	_, ok = sys.gapSampler(10).Sample(log.WithField("gap", 10))
+	tt.Assert.False(ok)
+
+	// ...but another is logged immediately
+	_, ok = sys.gapSampler(20).Sample(log.WithField("gap", 20))
+	tt.Assert.True(ok)
+
+	// as is the same gap in another system
+	_, ok = (&System{}).gapSampler(20).Sample(log.WithField("gap", 20))
+	tt.Assert.True(ok)
 }
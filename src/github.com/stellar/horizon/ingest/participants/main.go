@@ -1,11 +1,13 @@
 // Package participants contains functions to derive a set of "participant"
-// addresses for various data structures in the Stellar network's ledger.
+// addresses (and, for operations, assets) for various data structures in the
+// Stellar network's ledger.
 package participants
 
 import (
 	"fmt"
 
 	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/assets"
 )
 
 // ForOperation returns all the participating accounts from the
@@ -52,6 +54,54 @@ func ForOperation(
 	return
 }
 
+// AssetsForOperation returns all the assets referenced by the provided
+// operation:  the assets sent, received or traded, and the asset of a
+// trustline that is changed or authorized.  Account creation always involves
+// the native asset.
+func AssetsForOperation(
+	tx *xdr.Transaction,
+	op *xdr.Operation,
+) (result []xdr.Asset, err error) {
+
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		result = append(result, xdr.Asset{Type: xdr.AssetTypeAssetTypeNative})
+	case xdr.OperationTypePayment:
+		result = append(result, op.Body.MustPaymentOp().Asset)
+	case xdr.OperationTypePathPayment:
+		pp := op.Body.MustPathPaymentOp()
+		result = append(result, pp.SendAsset, pp.DestAsset)
+		result = append(result, pp.Path...)
+	case xdr.OperationTypeManageOffer:
+		mo := op.Body.MustManageOfferOp()
+		result = append(result, mo.Selling, mo.Buying)
+	case xdr.OperationTypeCreatePassiveOffer:
+		po := op.Body.MustCreatePassiveOfferOp()
+		result = append(result, po.Selling, po.Buying)
+	case xdr.OperationTypeSetOptions:
+		// no assets are involved
+	case xdr.OperationTypeChangeTrust:
+		result = append(result, op.Body.MustChangeTrustOp().Line)
+	case xdr.OperationTypeAllowTrust:
+		source := tx.SourceAccount
+		if op.SourceAccount != nil {
+			source = *op.SourceAccount
+		}
+		result = append(result, op.Body.MustAllowTrustOp().Asset.ToAsset(source))
+	case xdr.OperationTypeAccountMerge:
+		// no assets are involved
+	case xdr.OperationTypeInflation:
+		// no assets are involved
+	case xdr.OperationTypeManageData:
+		// no assets are involved
+	default:
+		err = fmt.Errorf("Unknown operation type: %s", op.Body.Type)
+	}
+
+	result = dedupeAssets(result)
+	return
+}
+
 // ForTransaction returns all the participating accounts from the provided
 // transaction.
 func ForTransaction(
@@ -99,6 +149,25 @@ func dedupe(in []xdr.AccountId) (out []xdr.AccountId) {
 	return
 }
 
+// dedupeAssets removes any duplicate assets from `in`, preserving the order in
+// which they first appear.
+func dedupeAssets(in []xdr.Asset) (out []xdr.Asset) {
+	for _, a := range in {
+		dupe := false
+		for _, seen := range out {
+			if assets.Equals(a, seen) {
+				dupe = true
+				break
+			}
+		}
+
+		if !dupe {
+			out = append(out, a)
+		}
+	}
+	return
+}
+
 func forChanges(
 	changes *xdr.LedgerEntryChanges,
 ) (result []xdr.AccountId, err error) {
@@ -86,6 +86,61 @@ func TestForOperation(t *testing.T) {
 	tt.Assert.Contains(p, aid("GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD"))
 }
 
+func TestAssetsForOperation(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	q := &core.Q{Repo: tt.CoreRepo()}
+
+	load := func(lg int32, tx int, op int) []string {
+		var txs []core.Transaction
+
+		err := q.TransactionsByLedger(&txs, lg)
+		tt.Require.NoError(err, "failed to load transaction data")
+		xtx := txs[tx].Envelope.Tx
+		xop := xtx.Operations[op]
+		ret, err := AssetsForOperation(&xtx, &xop)
+		tt.Require.NoError(err, "AssetsForOperation() errored")
+		return canonical(ret)
+	}
+
+	// test create account
+	tt.Assert.Equal([]string{"native"}, load(3, 0, 0))
+
+	// test payment
+	tt.Assert.Equal([]string{
+		"USD:GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU",
+	}, load(18, 0, 0))
+
+	// test path payment
+	tt.Assert.Equal([]string{
+		"USD:GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU",
+		"EUR:GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU",
+		"native",
+	}, load(19, 0, 0))
+
+	// test manage offer
+	tt.Assert.Equal([]string{
+		"native",
+		"USD:GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU",
+	}, load(18, 1, 0))
+
+	// test set options
+	tt.Assert.Len(load(28, 0, 0), 0)
+
+	// test change trust
+	tt.Assert.Equal([]string{
+		"USD:GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD",
+	}, load(22, 0, 0))
+
+	// test allow trust
+	tt.Assert.Equal([]string{
+		"EUR:GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF",
+	}, load(42, 0, 0))
+
+	// test account merge
+	tt.Assert.Len(load(44, 0, 0), 0)
+}
+
 func TestForTransaction(t *testing.T) {
 	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
 	defer tt.Finish()
@@ -114,6 +169,24 @@ func TestForTransaction(t *testing.T) {
 	tt.Assert.Contains(p, aid("GAXI33UCLQTCKM2NMRBS7XYBR535LLEVAHL5YBN4FTCB4HZHT7ZA5CVK"))
 }
 
+// helper function to convert assets into their canonical string form
+func canonical(in []xdr.Asset) (out []string) {
+	for _, a := range in {
+		var typ, code, iss string
+		err := a.Extract(&typ, &code, &iss)
+		if err != nil {
+			panic(err)
+		}
+
+		if a.Type == xdr.AssetTypeAssetTypeNative {
+			out = append(out, "native")
+			continue
+		}
+		out = append(out, code+":"+iss)
+	}
+	return
+}
+
 // helper function to convert an address into an accountid
 func aid(addy string) (ret xdr.AccountId) {
 	err := ret.SetAddress(addy)
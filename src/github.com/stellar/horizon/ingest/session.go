@@ -15,7 +15,9 @@ import (
 	"github.com/stellar/go/meta"
 	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/errors"
 	"github.com/stellar/horizon/ingest/participants"
+	hlog "github.com/stellar/horizon/log"
 )
 
 // Run starts an attempt to ingest the range of ledgers specified in this
@@ -27,17 +29,39 @@ func (is *Session) Run() {
 	}
 
 	defer is.Ingestion.Rollback()
+	defer is.Cursor.Close()
+
+	is.Breadcrumbs.Add(0, "session started")
+
+	var stored *history.Ledger
+	if is.ClearExisting {
+		stored, is.Err = is.storedTotals()
+	}
 
 	for is.Cursor.NextLedger() {
 		if is.Err != nil {
 			return
 		}
 
-		is.clearLedger()
-		is.ingestLedger()
-		is.flush()
+		if is.ClearExisting {
+			is.step(PhaseClear, is.clearLedger, "cleared")
+		}
+		is.step(PhaseWrite, is.ingestLedger, "written")
+		if is.VerifyIngestedCounts {
+			is.step(PhaseValidate, is.verifyLedger, "validation passed")
+		}
+		is.step(PhaseWrite, is.flush, "flushed")
+	}
+
+	// a ledger that fails to load ends the iteration early, rather than
+	// silently shortening the session
+	if is.Err == nil && is.Cursor.Err != nil {
+		is.Err = ledgerError(is.Cursor.lg, PhaseLoad, is.Cursor.Err)
 	}
 
+	is.buildAssetStats()
+	is.carryTotalsForward(stored)
+
 	if is.Err != nil {
 		is.Ingestion.Rollback()
 		return
@@ -47,10 +71,73 @@ func (is *Session) Run() {
 	if is.Err != nil {
 		return
 	}
+	is.Breadcrumbs.Add(0, "session committed")
 
 	is.Err = is.reportCursorState()
 }
 
+// step runs `fn`, one phase of ingesting the current ledger, and records any
+// error it leaves in is.Err as a LedgerError.  Should it succeed, `done` is
+// recorded as a breadcrumb.
+func (is *Session) step(phase Phase, fn func(), done string) {
+	if is.Err != nil {
+		return
+	}
+
+	seq := is.Cursor.LedgerSequence()
+	fn()
+	is.Err = ledgerError(seq, phase, is.Err)
+	if is.Err == nil {
+		is.Breadcrumbs.Add(seq, done)
+	}
+}
+
+// panicFields returns the fields logged and reported to sentry should the
+// session panic (see errors.RecoverWith):  its ID, under errors.SessionKey so
+// that reports carry its breadcrumbs, and the range of ledgers it ingests.  It
+// may be called on a nil session.
+func (is *Session) panicFields() map[string]interface{} {
+	if is == nil || is.Cursor == nil {
+		return nil
+	}
+
+	return map[string]interface{}{
+		errors.SessionKey: is.ID,
+		"first_ledger":    is.Cursor.FirstLedger,
+		"last_ledger":     is.Cursor.LastLedger,
+	}
+}
+
+// logger returns a log entry carrying the fields that identify the session:
+// its ID, the range of ledgers it ingests and, while it is ingesting one, the
+// current ledger's sequence.  It may be called on a nil session, returning the
+// package's logger.
+func (is *Session) logger() *hlog.Entry {
+	if is == nil {
+		return log
+	}
+
+	l := log
+	if is.Ctx != nil {
+		l = hlog.Ctx(is.Ctx)
+	}
+
+	if is.Cursor == nil {
+		return l.WithFields(hlog.F{})
+	}
+
+	l = l.WithFields(hlog.F{
+		"first_ledger": is.Cursor.FirstLedger,
+		"last_ledger":  is.Cursor.LastLedger,
+	})
+
+	if is.Cursor.InLedger() {
+		l = l.WithField("ledger", is.Cursor.lg)
+	}
+
+	return l
+}
+
 func (is *Session) clearLedger() {
 	if is.Err != nil {
 		return
@@ -86,11 +173,16 @@ func (is *Session) flush() {
 	if is.Err != nil {
 		return
 	}
+
+	if is.Ingestion.Writes != nil {
+		is.Ingestion.Writes.Flush(is.logger())
+	}
+
 	is.Err = is.Ingestion.Flush()
 }
 
 func (is *Session) ingestEffects() {
-	if is.Err != nil {
+	if is.Err != nil || is.SkipEffects {
 		return
 	}
 
@@ -315,21 +407,39 @@ func (is *Session) ingestLedger() {
 	}
 
 	start := time.Now()
+	totals, err := is.ledgerTotals()
+	if err != nil {
+		is.Err = err
+		return
+	}
+
+	for is.Cursor.NextTx() {
+		is.ingestTransaction()
+	}
+
+	is.ingestAssetStats()
+	if is.Err != nil {
+		return
+	}
+
+	// the ledger is written last, so that the time taken to ingest it can be
+	// recorded with it.
 	is.Err = is.Ingestion.Ledger(
 		is.Cursor.LedgerID(),
 		is.Cursor.Ledger(),
 		is.Cursor.SuccessfulTransactionCount(),
 		is.Cursor.SuccessfulLedgerOperationCount(),
+		totals,
+		LedgerStats{
+			FailedTransactions: is.Cursor.FailedTransactionCount(),
+			IngestDuration:     is.Cursor.LoadDuration() + time.Since(start),
+		},
 	)
 
 	if is.Err != nil {
 		return
 	}
 
-	for is.Cursor.NextTx() {
-		is.ingestTransaction()
-	}
-
 	is.Ingested++
 	if is.Metrics != nil {
 		is.Metrics.IngestLedgerTimer.Update(time.Since(start))
@@ -356,9 +466,30 @@ func (is *Session) ingestOperation() {
 	}
 
 	is.ingestOperationParticipants()
+	is.ingestOperationAssets()
+	is.ingestAccountCreation()
 	is.ingestEffects()
 }
 
+// ingestAccountCreation records the creation of the account created by the
+// current operation, if it is a create_account operation.
+func (is *Session) ingestAccountCreation() {
+	if is.Err != nil {
+		return
+	}
+
+	if is.Cursor.OperationType() != xdr.OperationTypeCreateAccount {
+		return
+	}
+
+	op := is.Cursor.Operation().Body.MustCreateAccountOp()
+	is.Err = is.Ingestion.AccountCreated(
+		op.Destination,
+		is.Cursor.LedgerSequence(),
+		time.Unix(is.Cursor.Ledger().CloseTime, 0).UTC(),
+	)
+}
+
 func (is *Session) ingestOperationParticipants() {
 	if is.Err != nil {
 		return
@@ -380,6 +511,23 @@ func (is *Session) ingestOperationParticipants() {
 	}
 }
 
+func (is *Session) ingestOperationAssets() {
+	if is.Err != nil {
+		return
+	}
+
+	var a []xdr.Asset
+	a, is.Err = participants.AssetsForOperation(
+		&is.Cursor.Transaction().Envelope.Tx,
+		is.Cursor.Operation(),
+	)
+	if is.Err != nil {
+		return
+	}
+
+	is.Err = is.Ingestion.OperationAssets(is.Cursor.OperationID(), a)
+}
+
 func (is *Session) ingestSignerEffects(effects *EffectIngestion, op xdr.SetOptionsOp) {
 	source := is.Cursor.OperationSourceAccount()
 
@@ -460,8 +608,11 @@ func (is *Session) ingestTransaction() {
 		return
 	}
 
-	// skip ingesting failed transactions
+	// skip ingesting the operations of failed transactions, which had no
+	// effect
 	if !is.Cursor.Transaction().IsSuccessful() {
+		is.ingestFailedTransactionFee()
+		is.ingestFailedTransaction()
 		return
 	}
 
@@ -478,6 +629,57 @@ func (is *Session) ingestTransaction() {
 	is.ingestTransactionParticipants()
 }
 
+// ingestFailedTransactionFee records the fee charged for the current, failed,
+// transaction as an account_debited effect of its source account, when
+// enabled.  Even when failed transactions are stored (see
+// ingestFailedTransaction), none of their effects are, so without it the fee
+// would be missing from the account's effects.  Since a failed transaction has
+// no ingested operations, the effect is attributed to the id of the
+// transaction itself.
+func (is *Session) ingestFailedTransactionFee() {
+	if is.Err != nil || is.SkipEffects || !is.FailedTransactionFeeEffects {
+		return
+	}
+
+	tx := is.Cursor.Transaction()
+	effects := &EffectIngestion{
+		Dest:        is.Ingestion,
+		OperationID: is.Cursor.TransactionID(),
+		parent:      is.Ingestion,
+	}
+
+	effects.Add(is.Cursor.TransactionSourceAccount(), history.EffectAccountDebited,
+		map[string]interface{}{
+			"asset_type":       "native",
+			"amount":           amount.String(tx.Result.Result.FeeCharged),
+			"fee":              true,
+			"transaction_hash": tx.TransactionHash,
+		},
+	)
+
+	is.Err = effects.Finish()
+}
+
+// ingestFailedTransaction stores the current, failed, transaction and its
+// participants, when enabled, so that clients can learn why a transaction they
+// submitted is missing from their history.
+func (is *Session) ingestFailedTransaction() {
+	if is.Err != nil || !is.FailedTransactions {
+		return
+	}
+
+	is.Err = is.Ingestion.Transaction(
+		is.Cursor.TransactionID(),
+		is.Cursor.Transaction(),
+		is.Cursor.TransactionFee(),
+	)
+	if is.Err != nil {
+		return
+	}
+
+	is.ingestTransactionParticipants()
+}
+
 func (is *Session) ingestTransactionParticipants() {
 	if is.Err != nil {
 		return
@@ -685,6 +887,11 @@ func (is *Session) operationFlagDetails(result map[string]interface{}, f int32,
 // to report that it has finished processing the data being ingested.  This
 // allows stellar-core to free that storage when next it runs its own
 // maintenance.
+//
+// This is the only change horizon makes to stellar-core's state.  It is made
+// through stellar-core's http interface, as the stellar-core database is
+// opened read-only (see db2.OpenReadOnly) and must never be written to
+// directly.
 func (is *Session) reportCursorState() error {
 	// TODO(scott): with the introduction of
 	// SkipCursorUpdate, this should probably be removed.
@@ -1,21 +1,35 @@
 package ingest
 
 import (
+	"fmt"
+	"time"
+
 	err2 "github.com/pkg/errors"
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/errors"
 	"github.com/stellar/horizon/ledger"
-	"github.com/stellar/horizon/log"
+	hlog "github.com/stellar/horizon/log"
+	"golang.org/x/net/context"
 )
 
 // ReingestAll re-ingests all ledgers
 func (i *System) ReingestAll() (int, error) {
-	ls := ledger.CurrentState()
-	return i.ReingestRange(ls.CoreElder, ls.CoreLatest)
+	return i.ReingestRange(i.ReingestBounds())
+}
+
+// ReingestBounds returns the range of ledgers ReingestAll reingests:  from the
+// oldest ledger ingestion may ingest (see IngestFloor) to stellar-core's latest
+// ledger.
+func (i *System) ReingestBounds() (start, end int32) {
+	ls := i.ledgerState().CurrentState()
+	return i.elder(ls.CoreElder, ls.CoreLatest), ls.CoreLatest
 }
 
-// ReingestOutdated finds old ledgers and reimports them.
+// ReingestOutdated finds old ledgers and reimports them.  The outdated ledgers
+// are reingested in runs of consecutive ledgers.  A run that fails does not
+// stop the others of the same batch from being reingested, but the batch's
+// failures are returned together, as an errors.Multi, once it is done.
 func (i *System) ReingestOutdated() (n int, err error) {
 	q := history.Q{Repo: i.HorizonDB}
 
@@ -37,52 +51,126 @@ func (i *System) ReingestOutdated() (n int, err error) {
 			WithField("batch_size", len(outdated)).
 			Info("reingest: outdated")
 
-		var start, end int32
-		flush := func() error {
-			ingested, ferr := i.ReingestRange(start, end)
+		var ingested int
+		ingested, err = i.reingestBatch(outdated)
+		n += ingested
 
-			if ferr != nil {
-				return ferr
-			}
-			n += ingested
-			return nil
+		// the failed ledgers remain outdated, so another batch would only
+		// retry them
+		if err != nil {
+			return
 		}
+	}
+}
 
-		for idx := range outdated {
-			seq := outdated[idx]
+// ReingestVersionRange reingests the ledgers that were ingested by versions
+// `minVer` through `maxVer` (inclusive) of the ingestion algorithm, leaving the
+// ledgers ingested by other versions untouched.  Use it to repair the ledgers
+// ingested by a release with a bug that is known to affect only them.  As with
+// ReingestOutdated, the failures of a batch are returned together.
+func (i *System) ReingestVersionRange(minVer, maxVer int) (n int, err error) {
+	if minVer > maxVer {
+		err = fmt.Errorf("invalid version range: %d is greater than %d", minVer, maxVer)
+		return
+	}
 
-			if start == 0 {
-				start = seq
-				end = seq
-				continue
-			}
+	q := history.Q{Repo: i.HorizonDB}
 
-			if seq == end+1 {
-				end = seq
-				continue
-			}
+	// Reingested ledgers are recorded with CurrentVersion, which may itself be
+	// within the range, so each batch starts after the last ledger of the
+	// previous one.
+	var after int32
+	for {
+		found := []int32{}
+		err = q.LedgersInVersionRange(&found, minVer, maxVer, after)
+		if err != nil {
+			return
+		}
 
-			err = flush()
-			if err != nil {
-				return
-			}
+		if len(found) == 0 {
+			return
+		}
+
+		log.
+			WithField("lowest_sequence", found[0]).
+			WithField("batch_size", len(found)).
+			WithField("min_version", minVer).
+			WithField("max_version", maxVer).
+			Info("reingest: version range")
+
+		var ingested int
+		ingested, err = i.reingestBatch(found)
+		n += ingested
+		if err != nil {
+			return
+		}
+
+		after = found[len(found)-1]
+	}
+}
 
+// reingestBatch reingests the ledgers of `seqs`, which must be sorted, in runs
+// of consecutive ledgers.  A run that fails does not stop the others from being
+// reingested, and the failures are returned together as an errors.Multi.
+func (i *System) reingestBatch(seqs []int32) (n int, err error) {
+	var (
+		start, end int32
+		failures   errors.Multi
+	)
+	flush := func() {
+		ingested, ferr := i.ReingestRange(start, end)
+
+		if ferr != nil {
+			// the error records the ledger that failed, and in which phase
+			// (see LedgerError), which WithError logs alongside it.
+			log.
+				WithError(ferr).
+				WithField("batch_start", start).
+				WithField("batch_end", end).
+				WithField("class", errors.Classify(ferr).String()).
+				Error("reingest: failed")
+			failures.Append(ferr)
+			return
+		}
+		n += ingested
+	}
+
+	for idx := range seqs {
+		seq := seqs[idx]
+
+		if start == 0 {
 			start = seq
 			end = seq
+			continue
 		}
 
-		err = flush()
-		if err != nil {
-			return
+		if seq == end+1 {
+			end = seq
+			continue
 		}
+
+		flush()
+		start = seq
+		end = seq
 	}
+
+	flush()
+
+	err = failures.ErrorOrNil()
+	return
 }
 
 // ReingestRange reingests a range of ledgers, from `start` to `end`, inclusive.
+// The reingestion is reported as the session in progress (see CurrentSession)
+// while it runs.  Should another session be in progress, it first waits for
+// that session to finish, and ticks skip ingesting until it is done.
 func (i *System) ReingestRange(start, end int32) (int, error) {
 	is := NewSession(start, end, i)
 	is.ClearExisting = true
 
+	i.awaitSession(is)
+	defer i.finishSession()
+
 	is.Run()
 	return is.Ingested, is.Err
 }
@@ -93,36 +181,304 @@ func (i *System) ReingestSingle(sequence int32) error {
 	return err
 }
 
+// CheckCoreSchema reads the schema version of the connected stellar-core
+// database and confirms it is within the range this version of horizon is
+// compatible with, returning a *CoreSchemaError when it is not.
+func (i *System) CheckCoreSchema() error {
+	var version int
+	q := &core.Q{i.CoreDB}
+	err := q.SchemaVersion(&version)
+
+	switch {
+	case err != nil:
+		err = &CoreSchemaError{Err: err}
+	case version < MinCoreSchemaVersion || version > MaxCoreSchemaVersion:
+		err = &CoreSchemaError{Version: version}
+	}
+
+	i.lock.Lock()
+	i.coreSchemaCheckedAt = time.Now()
+	i.coreSchemaErr = err
+	i.lock.Unlock()
+
+	if err != nil && i.SkipCoreSchemaCheck {
+		log.WithError(err).Warn("ingest: ignoring core schema check failure")
+		return nil
+	}
+
+	return err
+}
+
 // Tick triggers the ingestion system to ingest any new ledger data, provided
-// that there currently is not an import session in progress.
+// that there currently is not an import session in progress.  When the
+// connected stellar-core database has an incompatible schema, no ingestion is
+// attempted and the returned session's Err is a *CoreSchemaError.
+//
+// The ledger state is refreshed (see System.LedgerState) right before the
+// session is built and again after it finishes.  If the first refresh fails,
+// its error is the returned session's Err.  When the provider has no
+// ledger.Loader, its cached ledger state is used instead, provided it is no
+// older than MaxLedgerStateAge; otherwise the returned session's Err is a
+// *ledger.StaleStateError.
+//
+// Tick is run in a goroutine of its own, so a panic while ticking is recovered
+// (see errors.Recover) and nil returned.  Panics within a session are
+// recovered by the session.
 func (i *System) Tick() *Session {
+	defer errors.Recover("ingest", nil)
+
+	err := i.ensureCoreSchema()
+	if err != nil {
+		log.WithError(err).Error("ingest: refusing to ingest")
+		return &Session{Err: err}
+	}
+
+	// the session's range and the gap check are derived from the ledger state,
+	// which must reflect the databases as they are now.
+	ls, err := i.refreshLedgerState()
+	if err != nil {
+		log.WithError(err).Warn("ingest: refusing to ingest")
+		return &Session{Err: err}
+	}
+
+	is := i.startSession(func() *Session {
+		return i.newTickSession(ls)
+	})
+	if is == nil {
+		if l, ok := inProgressLog.Sample(log); ok {
+			l.Info("ingest: already in progress")
+		}
+		return nil
+	}
+
+	i.runOnce(ls)
+
+	// make the newly ingested ledgers visible immediately, rather than at the
+	// next app tick.
+	_, err = i.refreshLedgerState()
+	if err != nil {
+		is.logger().
+			WithError(err).
+			Error("ingest: failed to refresh ledger state after session")
+	}
+
+	if i.Backfill && is.Err == nil {
+		i.backfillOnce()
+	}
+
+	return is
+}
+
+// backfillOnce ingests up to BackfillBatchSize ledgers immediately preceding
+// the history database's elder ledger, provided stellar-core still has them.
+// The history elder ledger, and therefore the elder cursor reported by horizon,
+// moves backwards as each batch completes.
+func (i *System) backfillOnce() {
+	var (
+		historyElder  int32
+		historyLatest int32
+		coreElder     int32
+	)
+
+	hq := &history.Q{Repo: i.HorizonDB}
+	err := hq.ElderLedger(&historyElder)
+	if err != nil {
+		log.WithError(err).Error("ingest: backfill failed to load history elder")
+		return
+	}
+
+	err = hq.LatestLedger(&historyLatest)
+	if err != nil {
+		log.WithError(err).Error("ingest: backfill failed to load history latest")
+		return
+	}
+
+	cq := &core.Q{Repo: i.CoreDB}
+	err = cq.ElderLedger(&coreElder)
+	if err != nil {
+		log.WithError(err).Error("ingest: backfill failed to load core elder")
+		return
+	}
+	coreElder = i.elder(coreElder, i.ledgerState().CurrentState().CoreLatest)
+
+	// the ledgers the reaper would delete are never backfilled, using the
+	// reaper's own reckoning of the oldest ledger it retains
+	if i.RetentionCount > 0 {
+		retained := historyLatest - int32(i.RetentionCount) + 1
+		if retained > coreElder {
+			coreElder = retained
+		}
+	}
+
+	// nothing to backfill: either history is empty, or already complete
+	if historyElder == 0 || historyElder <= coreElder {
+		return
+	}
+
+	end := historyElder - 1
+	start := end - BackfillBatchSize + 1
+	if start < coreElder {
+		start = coreElder
+	}
+
+	err = i.validateBackfill(end, historyElder)
+	if err != nil {
+		log.WithError(err).
+			WithField("class", errors.Classify(err).String()).
+			Error("ingest: backfill does not chain into the history elder ledger")
+		return
+	}
+
+	is := i.startSession(func() *Session {
+		is := NewSession(start, end, i)
+		// a backfill must never move stellar-core's cursor backwards
+		is.SkipCursorUpdate = true
+		i.currentBackfill = true
+		return is
+	})
+	if is == nil {
+		return
+	}
+
+	defer i.finishSession()
+	defer errors.RecoverWith("backfill session", is.panicFields, is.logger)
+
+	is.logger().Info("ingest: backfilling")
+
+	is.Run()
+	if is.Err != nil {
+		is.logger().WithError(is.Err).Error("ingest: backfill session failed")
+	}
+}
+
+// SessionStatus describes the ingestion session a System is running.
+type SessionStatus struct {
+	ID          string
+	FirstLedger int32
+	LastLedger  int32
+	CreatedAt   time.Time
+
+	// Backfill is true for a session started by backfilling (see
+	// System.Backfill), and Reingest for one reingesting a range (see
+	// System.ReingestRange), rather than by a tick.
+	Backfill bool
+	Reingest bool
+}
+
+// CurrentSession returns the status of the ingestion session in progress.  ok
+// is false when no session is in progress.  Only what is fixed when a session
+// is created is reported, so that it may safely be called while the session
+// runs.
+func (i *System) CurrentSession() (status SessionStatus, ok bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.current == nil {
+		return
+	}
+
+	return SessionStatus{
+		ID:          i.current.ID,
+		FirstLedger: i.current.Cursor.FirstLedger,
+		LastLedger:  i.current.Cursor.LastLedger,
+		CreatedAt:   i.current.CreatedAt,
+		Backfill:    i.currentBackfill,
+		Reingest:    i.currentReingest,
+	}, true
+}
+
+// startSession makes the session returned by `build`, which is called with
+// the lock held, the session in progress.  Should a session already be in
+// progress, it returns nil without calling `build`.  The lock is released by a
+// deferred call, so that a panic while building the session does not leave it
+// held once the panic is recovered, such as by Tick.
+func (i *System) startSession(build func() *Session) *Session {
 	i.lock.Lock()
+	defer i.lock.Unlock()
+
 	if i.current != nil {
-		log.Info("ingest: already in progress")
-		i.lock.Unlock()
 		return nil
 	}
 
-	is := i.newTickSession()
+	is := build()
+	i.startSessionLocked(is)
+	return is
+}
+
+// startSessionLocked makes `is` the session in progress.  The caller must hold
+// the lock, and have found no session in progress.
+func (i *System) startSessionLocked(is *Session) {
 	i.current = is
+	i.currentDone = make(chan struct{})
+}
+
+// awaitSession makes `is`, a reingestion, the session in progress once no
+// other session is, waiting for those in progress to finish.
+func (i *System) awaitSession(is *Session) {
+	for {
+		i.lock.Lock()
+		if i.current == nil {
+			i.startSessionLocked(is)
+			i.currentReingest = true
+			i.lock.Unlock()
+			return
+		}
+		done := i.currentDone
+		i.lock.Unlock()
+
+		is.logger().Info("ingest: waiting for the session in progress to reingest")
+		<-done
+	}
+}
+
+// finishSession records that the session in progress has finished, releasing
+// any reingestion waiting for it.
+func (i *System) finishSession() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.currentDone != nil {
+		close(i.currentDone)
+	}
+	i.current = nil
+	i.currentDone = nil
+	i.currentBackfill = false
+	i.currentReingest = false
+}
+
+// ensureCoreSchema returns the result of the most recent core schema check,
+// re-checking if it is older than CoreSchemaCheckInterval.
+func (i *System) ensureCoreSchema() error {
+	i.lock.Lock()
+	stale := time.Since(i.coreSchemaCheckedAt) > CoreSchemaCheckInterval
+	err := i.coreSchemaErr
 	i.lock.Unlock()
 
-	i.runOnce()
-	return is
+	if stale {
+		return i.CheckCoreSchema()
+	}
+
+	if err != nil && i.SkipCoreSchemaCheck {
+		return nil
+	}
+
+	return err
 }
 
 // newTickSession creates an unverified new ingestion session that reflects the
-// current cached ledger state.
-func (i *System) newTickSession() *Session {
-	var (
-		start int32
-		ls    = ledger.CurrentState()
-	)
+// provided ledger state, which callers should build from the primary readings
+// (see refreshLedgerState) rather than take from the current state.
+func (i *System) newTickSession(ls ledger.State) *Session {
+	var start int32
 
 	if ls.HistoryLatest == 0 {
-		start = ls.CoreElder
+		start = i.elder(ls.CoreElder, ls.CoreLatest)
+
+		if i.FastStartCount > 0 && ls.CoreLatest-i.FastStartCount > start {
+			start = ls.CoreLatest - i.FastStartCount
+		}
 	} else {
-		start = ls.HistoryLatest + 1
+		start = i.elder(ls.HistoryLatest+1, ls.CoreLatest)
 	}
 
 	end := ls.CoreLatest
@@ -130,19 +486,77 @@ func (i *System) newTickSession() *Session {
 	return NewSession(start, end, i)
 }
 
-// run causes the importer to check stellar-core to see if we can import new
-// data.
-func (i *System) runOnce() {
-	defer func() {
-		if rec := recover(); rec != nil {
-			err := errors.FromPanic(rec)
-			log.Errorf("import session panicked: %s", err)
-			errors.ReportToSentry(err, nil)
-		}
-	}()
+// gapSampler returns the sampler for the message logged when ingestion cannot
+// get past the gap preceding `seq`.  A gap found elsewhere gets a new sampler,
+// so that its first occurrence is logged however recently another gap's was.
+func (i *System) gapSampler(seq int32) *hlog.Sampler {
+	if i.gapLog == nil || i.gapLedger != seq {
+		i.gapLog = hlog.Every(time.Minute)
+		i.gapLedger = seq
+	}
 
-	ls := ledger.CurrentState()
+	return i.gapLog
+}
+
+// elder returns the oldest ledger the system may ingest, given stellar-core's
+// elder ledger:  the later of `coreElder` and IngestFloor.  Should IngestFloor
+// be beyond stellar-core's latest ledger, in which case nothing is ingested
+// until stellar-core reaches it, a warning is logged.
+func (i *System) elder(coreElder, coreLatest int32) int32 {
+	if i.IngestFloor > coreLatest {
+		log.
+			WithField("ingest_floor", i.IngestFloor).
+			WithField("core_latest", coreLatest).
+			Warn("ingest: ingest floor is beyond stellar-core's latest ledger")
+	}
 
+	if i.IngestFloor > coreElder {
+		return i.IngestFloor
+	}
+
+	return coreElder
+}
+
+// refreshLedgerState refreshes the ledger state, returning the view of it that
+// ingestion trusts:  the readings of the primary stellar-core and horizon
+// databases, which are the ones it reads from and writes to.  Readings from
+// secondary cores may lag or lead the primary and must never determine which
+// ledgers are ingested.  When the provider has no
+// ledger.Loader, its cached readings are used provided they are no older than
+// MaxLedgerStateAge.
+func (i *System) refreshLedgerState() (ledger.State, error) {
+	provider := i.ledgerState()
+
+	_, err := provider.Refresh(context.Background())
+	switch {
+	case err == ledger.ErrNoLoader:
+		return provider.FreshStateFrom(MaxLedgerStateAge, ingestCoreSource, ingestHistorySource)
+	case err != nil:
+		return ledger.State{}, err
+	}
+
+	return provider.StateFrom(ingestCoreSource, ingestHistorySource), nil
+}
+
+// ledgerState returns the ledger state provider of the system, defaulting to
+// ledger.Default.
+func (i *System) ledgerState() ledger.StateProvider {
+	if i.LedgerState == nil {
+		return ledger.Default
+	}
+
+	return i.LedgerState
+}
+
+// The sources whose readings ingestion acts upon; see refreshLedgerState.
+const (
+	ingestCoreSource    = ledger.CorePrimary
+	ingestHistorySource = ledger.HistoryPrimary
+)
+
+// run causes the importer to check stellar-core to see if we can import new
+// data, as of the provided ledger state.
+func (i *System) runOnce(ls ledger.State) {
 	// 1. stash a copy of the current ingestion session (assigned from the tick)
 	// 2. output "initial ingestion" message if the
 	// 3. import until none available
@@ -152,11 +566,9 @@ func (i *System) runOnce() {
 	is := i.current
 	i.lock.Unlock()
 
-	defer func() {
-		i.lock.Lock()
-		i.current = nil
-		i.lock.Unlock()
-	}()
+	defer errors.RecoverWith("import session", is.panicFields, is.logger)
+
+	defer i.finishSession()
 
 	if is == nil {
 		log.Warn("ingest: runOnce ran with a nil current session")
@@ -169,18 +581,20 @@ func (i *System) runOnce() {
 
 	// 2.
 	if ls.HistoryLatest == 0 {
-		log.Infof(
-			"history db is empty, starting ingestion from ledger %d",
-			is.Cursor.FirstLedger,
-		)
+		is.logger().Info("history db is empty, starting ingestion")
 	}
 
 	if is.Cursor.FirstLedger != ls.CoreElder {
-		err := i.validateLedgerChain(is.Cursor.FirstLedger)
+		err := i.validateContinuity(ls, is.Cursor.FirstLedger)
 		if err != nil {
-			log.
-				WithField("start", is.Cursor.FirstLedger).
-				Errorf("ledger gap detected (possible db corruption): %s", err)
+			if l, ok := i.gapSampler(is.Cursor.FirstLedger).Sample(is.logger()); ok {
+				l = l.WithError(err).WithField("class", errors.Classify(err).String())
+				if errors.Classify(err) == errors.Corruption {
+					l.Error("ledger gap detected (possible db corruption)")
+				} else {
+					l.Warn("ingest: failed to check for a ledger gap")
+				}
+			}
 			return
 		}
 	}
@@ -188,13 +602,78 @@ func (i *System) runOnce() {
 	// 3.
 	is.Run()
 
-	if is.Err != nil {
-		log.Errorf("import session failed: %s", is.Err)
+	// failed sessions are retried by the next tick, which is futile unless the
+	// failure is transient.
+	switch errors.Classify(is.Err) {
+	case errors.None:
+	case errors.Transient:
+		is.logger().
+			WithError(is.Err).
+			WithField("class", errors.Transient.String()).
+			Warn("import session failed, retrying next tick")
+	default:
+		is.logger().
+			WithError(is.Err).
+			WithField("class", errors.Classify(is.Err).String()).
+			Error("import session failed")
 	}
 
 	return
 }
 
+// validateContinuity ensures the ledger at `seq` may be ingested without
+// leaving a gap.  When `seq` immediately follows the history database's latest
+// ledger, it is checked against that ledger's hash, as cached in `ls`, which
+// requires loading only the ledger at `seq` and also catches stellar-core and
+// horizon having diverged.  Otherwise it falls back to validateLedgerChain.
+func (i *System) validateContinuity(ls ledger.State, seq int32) error {
+	if ls.HistoryLatestHash == "" || seq != ls.HistoryLatest+1 {
+		return i.validateLedgerChain(seq)
+	}
+
+	var cur core.LedgerHeader
+	q := &core.Q{i.CoreDB}
+
+	err := q.LedgerHeaderBySequence(&cur, seq)
+	if err != nil {
+		return ledgerError(seq, PhaseValidate, err2.Wrap(err, "validateContinuity: failed to load cur ledger"))
+	}
+
+	if cur.PrevHash != ls.HistoryLatestHash {
+		return ledgerError(seq, PhaseValidate, err2.Wrap(errors.ErrHashMismatch, "cur ledger does not follow the latest ledger in history"))
+	}
+
+	return nil
+}
+
+// validateBackfill ensures that the ledger at `end`, the last of a batch to be
+// backfilled, is the parent of the history database's elder ledger at `elder`,
+// such that the backfilled ledgers chain into those already ingested.
+func (i *System) validateBackfill(end, elder int32) error {
+	var (
+		last        core.LedgerHeader
+		elderLedger history.Ledger
+	)
+
+	cq := &core.Q{i.CoreDB}
+	err := cq.LedgerHeaderBySequence(&last, end)
+	if err != nil {
+		return ledgerError(end, PhaseValidate, err2.Wrap(err, "validateBackfill: failed to load last ledger"))
+	}
+
+	hq := &history.Q{i.HorizonDB}
+	err = hq.LedgerBySequence(&elderLedger, elder)
+	if err != nil {
+		return ledgerError(end, PhaseValidate, err2.Wrap(err, "validateBackfill: failed to load elder ledger"))
+	}
+
+	if elderLedger.PreviousLedgerHash.String != last.LedgerHash {
+		return ledgerError(end, PhaseValidate, err2.Wrap(errors.ErrHashMismatch, "last ledger is not the parent of the elder ledger in history"))
+	}
+
+	return nil
+}
+
 // validateLedgerChain helps to ensure the chain of ledger entries is contiguous
 // within horizon.  It ensures the ledger at `seq` is a child of `seq - 1`.
 func (i *System) validateLedgerChain(seq int32) error {
@@ -207,17 +686,43 @@ func (i *System) validateLedgerChain(seq int32) error {
 
 	err := q.LedgerHeaderBySequence(&cur, seq)
 	if err != nil {
-		return err2.Wrap(err, "validateLedgerChain: failed to load cur ledger")
+		return ledgerError(seq, PhaseValidate, err2.Wrap(err, "validateLedgerChain: failed to load cur ledger"))
 	}
 
 	err = q.LedgerHeaderBySequence(&prev, seq-1)
+	if q.NoRows(err) {
+		return ledgerError(seq, PhaseValidate, err2.Wrap(errors.ErrMissingLedger, "validateLedgerChain: failed to load prev ledger"))
+	}
 	if err != nil {
-		return err2.Wrap(err, "validateLedgerChain: failed to load prev ledger")
+		return ledgerError(seq, PhaseValidate, err2.Wrap(err, "validateLedgerChain: failed to load prev ledger"))
 	}
 
 	if cur.PrevHash != prev.LedgerHash {
-		return err2.New("cur and prev ledger hashes don't match")
+		return ledgerError(seq, PhaseValidate, err2.Wrap(errors.ErrHashMismatch, "cur and prev ledger hashes don't match"))
 	}
 
 	return nil
 }
+
+// Class implements errors.Classifier:  an unsupported schema version will not
+// change until stellar-core is upgraded.
+func (e *CoreSchemaError) Class() errors.Class {
+	if e.Err != nil {
+		return errors.Classify(e.Err)
+	}
+
+	return errors.Permanent
+}
+
+func (e *CoreSchemaError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("could not read stellar-core schema version: %s", e.Err)
+	}
+
+	return fmt.Sprintf(
+		"stellar-core schema version %d is not supported (supported: %d-%d)",
+		e.Version,
+		MinCoreSchemaVersion,
+		MaxCoreSchemaVersion,
+	)
+}
@@ -3,7 +3,9 @@ package ingest
 import (
 	"testing"
 
-	"github.com/stellar/go/network"
+	"github.com/stellar/horizon/db2/core"
+	"github.com/stellar/horizon/errors"
+	"github.com/stellar/horizon/ledger"
 	"github.com/stellar/horizon/test"
 )
 
@@ -11,7 +13,7 @@ func TestValidation(t *testing.T) {
 	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
 	defer tt.Finish()
 
-	sys := New(network.TestNetworkPassphrase, "", tt.CoreRepo(), tt.HorizonRepo())
+	sys := sys(tt)
 
 	// intact chain
 	for i := int32(2); i <= 59; i++ {
@@ -31,6 +33,13 @@ func TestValidation(t *testing.T) {
 	err = sys.validateLedgerChain(6)
 	tt.Assert.Error(err)
 	tt.Assert.Contains(err.Error(), "failed to load prev ledger")
+	tt.Assert.Equal(errors.Corruption, errors.Classify(err))
+
+	var lerr *LedgerError
+	if tt.Assert.True(errors.As(err, &lerr)) {
+		tt.Assert.Equal(int32(6), lerr.Sequence)
+		tt.Assert.Equal(PhaseValidate, lerr.Phase)
+	}
 
 	// mismatched header
 	_, err = tt.CoreRepo().ExecRaw(`
@@ -42,4 +51,208 @@ func TestValidation(t *testing.T) {
 	err = sys.validateLedgerChain(9)
 	tt.Assert.Error(err)
 	tt.Assert.Contains(err.Error(), "cur and prev ledger hashes don't match")
+	tt.Assert.Equal(errors.Corruption, errors.Classify(err))
+}
+
+func TestCoreSchemaCheck(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+
+	sys := sys(tt)
+	tt.Assert.NoError(sys.CheckCoreSchema())
+
+	setVersion := func(v string) {
+		_, err := tt.CoreRepo().ExecRaw(
+			`UPDATE storestate SET state = ? WHERE statename = 'databaseschema'`, v,
+		)
+		tt.Require.NoError(err)
+	}
+
+	// too old
+	setVersion("1")
+	err := sys.CheckCoreSchema()
+	if tt.Assert.IsType(&CoreSchemaError{}, err) {
+		tt.Assert.Equal(1, err.(*CoreSchemaError).Version)
+	}
+
+	// too new
+	setVersion("99")
+	err = sys.CheckCoreSchema()
+	if tt.Assert.IsType(&CoreSchemaError{}, err) {
+		tt.Assert.Equal(99, err.(*CoreSchemaError).Version)
+		tt.Assert.Contains(err.Error(), "not supported")
+	}
+
+	// Tick refuses to ingest
+	is := sys.Tick()
+	if tt.Assert.NotNil(is) {
+		tt.Assert.IsType(&CoreSchemaError{}, is.Err)
+		tt.Assert.Equal(0, is.Ingested)
+	}
+
+	// unreadable
+	setVersion("garbage")
+	err = sys.CheckCoreSchema()
+	if tt.Assert.IsType(&CoreSchemaError{}, err) {
+		tt.Assert.Equal(0, err.(*CoreSchemaError).Version)
+		tt.Assert.Error(err.(*CoreSchemaError).Err)
+	}
+
+	_, err = tt.CoreRepo().ExecRaw(
+		`DELETE FROM storestate WHERE statename = 'databaseschema'`,
+	)
+	tt.Require.NoError(err)
+	tt.Assert.IsType(&CoreSchemaError{}, sys.CheckCoreSchema())
+
+	// operators can override the check
+	sys.SkipCoreSchemaCheck = true
+	tt.Assert.NoError(sys.CheckCoreSchema())
+	tt.Assert.NoError(sys.ensureCoreSchema())
+}
+
+func TestVerifyIngestedCounts(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+
+	sys := sys(tt)
+	sys.VerifyIngestedCounts = true
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.NotEqual(0, s.Ingested)
+
+	// lose an operation of a ledger that has some
+	var seq int32
+	err := tt.HorizonRepo().GetRaw(&seq, `
+		SELECT ledger_sequence
+		FROM history_transactions
+		WHERE operation_count > 0
+		ORDER BY id LIMIT 1`)
+	tt.Require.NoError(err)
+
+	is := NewSession(seq, seq, sys)
+	tt.Require.True(is.Cursor.NextLedger())
+	is.verifyLedger()
+	tt.Require.NoError(is.Err)
+
+	start, end := is.Cursor.LedgerRange()
+	_, err = tt.HorizonRepo().ExecRaw(`
+		DELETE FROM history_operations
+		WHERE id = (SELECT MIN(id) FROM history_operations WHERE id >= ? AND id < ?)`,
+		start, end,
+	)
+	tt.Require.NoError(err)
+
+	is.verifyLedger()
+	if tt.Assert.IsType(&CountMismatchError{}, is.Err) {
+		err := is.Err.(*CountMismatchError)
+		tt.Assert.Equal(seq, err.Sequence)
+		tt.Assert.Equal("history_operations rows", err.Count)
+		tt.Assert.Equal(err.Expected-1, err.Stored)
+		tt.Assert.Equal(errors.Corruption, errors.Classify(err))
+	}
+
+	// verification is skipped unless enabled
+	is.Err = nil
+	is.VerifyIngestedCounts = false
+	is.verifyLedger()
+	tt.Assert.NoError(is.Err)
+}
+
+func TestValidateContinuity(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+
+	sys := sys(tt)
+	q := &core.Q{tt.CoreRepo()}
+
+	var prev core.LedgerHeader
+	tt.Require.NoError(q.LedgerHeaderBySequence(&prev, 9))
+
+	// follows history's latest ledger
+	ls := ledger.State{HistoryLatest: 9, HistoryLatestHash: prev.LedgerHash}
+	tt.Assert.NoError(sys.validateContinuity(ls, 10))
+
+	// history and stellar-core have diverged
+	ls.HistoryLatestHash = "00000"
+	err := sys.validateContinuity(ls, 10)
+	if tt.Assert.Error(err) {
+		tt.Assert.Contains(err.Error(), "does not follow the latest ledger in history")
+		tt.Assert.Equal(errors.Corruption, errors.Classify(err))
+	}
+
+	// without a cached hash, or when not following history, the chain within
+	// stellar-core is checked instead
+	tt.Assert.NoError(sys.validateContinuity(ledger.State{HistoryLatest: 9}, 10))
+	tt.Assert.NoError(sys.validateContinuity(ls, 20))
+
+	_, err = tt.CoreRepo().ExecRaw(`DELETE FROM ledgerheaders WHERE ledgerseq = ?`, 10)
+	tt.Require.NoError(err)
+	err = sys.validateContinuity(ledger.State{}, 10)
+	if tt.Assert.Error(err) {
+		tt.Assert.Contains(err.Error(), "failed to load cur ledger")
+	}
+}
+
+func TestSessionLedgerError(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	_, err := tt.CoreRepo().ExecRaw(`DELETE FROM ledgerheaders WHERE ledgerseq = ?`, 5)
+	tt.Require.NoError(err)
+
+	// a ledger that cannot be loaded fails the session, rather than ending it
+	// early
+	is := NewSession(2, 10, sys)
+	is.Run()
+
+	var lerr *LedgerError
+	if tt.Assert.True(errors.As(is.Err, &lerr)) {
+		tt.Assert.Equal(int32(5), lerr.Sequence)
+		tt.Assert.Equal(PhaseLoad, lerr.Phase)
+		tt.Assert.Contains(is.Err.Error(), "ledger 5: load failed")
+	}
+
+	// and nothing it ingested is kept
+	var count int
+	err = tt.HorizonRepo().GetRaw(&count, "SELECT COUNT(*) FROM history_ledgers")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, count)
+}
+
+func TestReingestVersionRange(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	_, err := sys.ReingestRange(1, 10)
+	tt.Require.NoError(err)
+
+	// ledgers 4 through 6 were ingested by version 3, and 8 by version 5
+	_, err = tt.HorizonRepo().ExecRaw(`
+		UPDATE history_ledgers
+		SET importer_version = CASE WHEN sequence = 8 THEN 5 ELSE 3 END
+		WHERE sequence BETWEEN 4 AND 6 OR sequence = 8`)
+	tt.Require.NoError(err)
+
+	n, err := sys.ReingestVersionRange(3, 4)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(3, n)
+
+	var versions []int
+	err = tt.HorizonRepo().SelectRaw(&versions, `
+		SELECT importer_version FROM history_ledgers
+		WHERE sequence BETWEEN 4 AND 8 ORDER BY sequence`)
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]int{CurrentVersion, CurrentVersion, CurrentVersion, CurrentVersion, 5}, versions)
+
+	// reingested ledgers are not revisited when the range includes the
+	// current version
+	n, err = sys.ReingestVersionRange(1, CurrentVersion)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(10, n)
+
+	_, err = sys.ReingestVersionRange(4, 3)
+	tt.Assert.Error(err)
 }
@@ -0,0 +1,179 @@
+package ingest
+
+import (
+	"github.com/guregu/null"
+	"github.com/stellar/horizon/db2/core"
+	"github.com/stellar/horizon/db2/history"
+)
+
+// LedgerTotals are the running totals for the network recorded with each
+// ingested ledger (see history.Ledger).  A total that is not known is null.
+type LedgerTotals struct {
+	Accounts   null.Int
+	Trustlines null.Int
+	Operations null.Int
+}
+
+// ledgerTotals returns the totals to record with the current ledger:  those of
+// the previous ledger, plus the accounts and trustlines the current ledger
+// created (less those it removed) and its operations.
+//
+// A total that is not known for the previous ledger, because the history
+// database lacks it or it was ingested by an older version of horizon, is
+// instead taken from an aggregate.  The operations are summed from the history
+// database, provided it holds every ledger since the network's first.  The
+// accounts and trustlines are counted in stellar-core, provided the current
+// ledger is stellar-core's latest, whose state its tables hold.  Otherwise the
+// total remains null, until a later ledger can recover it.
+func (is *Session) ledgerTotals() (LedgerTotals, error) {
+	seq := is.Cursor.LedgerSequence()
+	q := history.Q{Repo: is.Ingestion.DB}
+
+	var prev history.Ledger
+	err := q.LedgerBySequence(&prev, seq-1)
+	switch {
+	case q.NoRows(err):
+		// the network's first ledger holds its root account alone
+		if seq == 1 {
+			prev.TotalAccounts = null.IntFrom(1)
+			prev.TotalTrustlines = null.IntFrom(0)
+			prev.TotalOperations = null.IntFrom(0)
+		}
+	case err != nil:
+		return LedgerTotals{}, err
+	}
+
+	accounts, trustlines := is.Cursor.SuccessfulLedgerEntryChanges()
+	ops := int64(is.Cursor.SuccessfulLedgerOperationCount())
+
+	totals := LedgerTotals{
+		Accounts:   addToTotal(prev.TotalAccounts, accounts),
+		Trustlines: addToTotal(prev.TotalTrustlines, trustlines),
+		Operations: addToTotal(prev.TotalOperations, ops),
+	}
+
+	// summing the operations is only worthwhile should the history database
+	// begin with the network's first ledger, which is cheap to check.
+	if !totals.Operations.Valid {
+		var elder int32
+		err = q.ElderLedger(&elder)
+		if err != nil {
+			return LedgerTotals{}, err
+		}
+
+		if elder == 1 {
+			var before null.Int
+			err = q.OperationTotalBefore(&before, seq)
+			if err != nil {
+				return LedgerTotals{}, err
+			}
+			totals.Operations = addToTotal(before, ops)
+		}
+	}
+
+	if !totals.Accounts.Valid || !totals.Trustlines.Valid {
+		var counts core.EntryCounts
+		ok, err := is.coreEntryCounts(&counts)
+		if err != nil {
+			return LedgerTotals{}, err
+		}
+		if ok {
+			totals.Accounts = null.IntFrom(counts.Accounts)
+			totals.Trustlines = null.IntFrom(counts.Trustlines)
+		}
+	}
+
+	return totals, nil
+}
+
+// storedTotals loads the ledger at the end of a reingesting session's range
+// as it was ingested before, or returns nil if it was not, so that
+// carryTotalsForward can tell how reingesting changed its totals.
+func (is *Session) storedTotals() (*history.Ledger, error) {
+	q := history.Q{Repo: is.Ingestion.DB}
+
+	var stored history.Ledger
+	err := q.LedgerBySequence(&stored, is.Cursor.LastLedger)
+	if q.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+// carryTotalsForward updates the running totals of the ledgers following a
+// reingested range, which were carried forward from the totals its last ledger
+// had before:  should reingesting change a total of `stored`, the difference
+// is added to those ledgers' (see history.Q.ShiftLedgerTotals).  A total that
+// was unknown before, or is still unknown, is left to later ledgers to recover.
+func (is *Session) carryTotalsForward(stored *history.Ledger) {
+	if is.Err != nil || stored == nil {
+		return
+	}
+
+	q := history.Q{Repo: is.Ingestion.DB}
+
+	var reingested history.Ledger
+	is.Err = q.LedgerBySequence(&reingested, is.Cursor.LastLedger)
+	if is.Err != nil {
+		return
+	}
+
+	shifts := []struct {
+		column     string
+		before, in null.Int
+	}{
+		{"total_accounts", stored.TotalAccounts, reingested.TotalAccounts},
+		{"total_trustlines", stored.TotalTrustlines, reingested.TotalTrustlines},
+		{"total_operations", stored.TotalOperations, reingested.TotalOperations},
+	}
+
+	for _, s := range shifts {
+		if !s.before.Valid || !s.in.Valid || s.before.Int64 == s.in.Int64 {
+			continue
+		}
+
+		is.Err = q.ShiftLedgerTotals(s.column, is.Cursor.LastLedger, s.in.Int64-s.before.Int64)
+		if is.Err != nil {
+			return
+		}
+	}
+}
+
+// coreEntryCounts loads the number of accounts and trustlines in existence as
+// of the current ledger into `dest`, provided it is stellar-core's latest
+// ledger and no other closes while they are counted.  ok is false otherwise.
+func (is *Session) coreEntryCounts(dest *core.EntryCounts) (ok bool, err error) {
+	var before, after int32
+	cq := &core.Q{Repo: is.Cursor.DB}
+
+	err = cq.LatestLedger(&before)
+	if err != nil || before != is.Cursor.LedgerSequence() {
+		return
+	}
+
+	err = cq.EntryCounts(dest)
+	if err != nil {
+		return
+	}
+
+	err = cq.LatestLedger(&after)
+	if err != nil {
+		return
+	}
+
+	ok = after == before
+	return
+}
+
+// addToTotal returns `total` plus `n`, or null if `total` is null.
+func addToTotal(total null.Int, n int64) null.Int {
+	if !total.Valid {
+		return total
+	}
+
+	return null.IntFrom(total.Int64 + n)
+}
@@ -0,0 +1,127 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/test"
+)
+
+func TestLedgerTotals(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+	q := &history.Q{Repo: tt.HorizonRepo()}
+
+	count := func(repo interface {
+		GetRaw(interface{}, string, ...interface{}) error
+	}, query string) int64 {
+		var n int64
+		tt.Require.NoError(repo.GetRaw(&n, query))
+		return n
+	}
+
+	// ingested from the network's first ledger, the totals of the latest match
+	// stellar-core's state
+	_, err := sys.ReingestRange(1, 59)
+	tt.Require.NoError(err)
+
+	var l history.Ledger
+	tt.Require.NoError(q.LedgerBySequence(&l, 59))
+	tt.Assert.Equal(count(tt.CoreRepo(), `SELECT COUNT(*) FROM accounts`), l.TotalAccounts.Int64)
+	tt.Assert.Equal(count(tt.CoreRepo(), `SELECT COUNT(*) FROM trustlines`), l.TotalTrustlines.Int64)
+	tt.Assert.Equal(count(tt.HorizonRepo(), `SELECT SUM(operation_count) FROM history_ledgers`), l.TotalOperations.Int64)
+	tt.Assert.True(l.TotalAccounts.Valid)
+	tt.Assert.True(l.TotalTrustlines.Valid)
+
+	// operations unknown for the previous ledger are summed from the history
+	// database, which holds every earlier ledger
+	_, err = tt.HorizonRepo().ExecRaw(`
+		UPDATE history_ledgers SET total_operations = NULL WHERE sequence = 40`)
+	tt.Require.NoError(err)
+	_, err = sys.ReingestRange(41, 41)
+	tt.Require.NoError(err)
+
+	tt.Require.NoError(q.LedgerBySequence(&l, 41))
+	tt.Assert.Equal(count(tt.HorizonRepo(), `SELECT SUM(operation_count) FROM history_ledgers WHERE sequence <= 41`), l.TotalOperations.Int64)
+	tt.Assert.True(l.TotalOperations.Valid)
+	tt.Assert.True(l.TotalAccounts.Valid)
+
+	// accounts and trustlines unknown for the previous ledger remain unknown,
+	// short of stellar-core's latest ledger, whose are counted
+	_, err = tt.HorizonRepo().ExecRaw(`
+		UPDATE history_ledgers SET total_accounts = NULL, total_trustlines = NULL
+		WHERE sequence IN (40, 58)`)
+	tt.Require.NoError(err)
+	_, err = sys.ReingestRange(41, 41)
+	tt.Require.NoError(err)
+	_, err = sys.ReingestRange(59, 59)
+	tt.Require.NoError(err)
+
+	tt.Require.NoError(q.LedgerBySequence(&l, 41))
+	tt.Assert.False(l.TotalAccounts.Valid)
+	tt.Assert.False(l.TotalTrustlines.Valid)
+	tt.Require.NoError(q.LedgerBySequence(&l, 59))
+	tt.Assert.Equal(count(tt.CoreRepo(), `SELECT COUNT(*) FROM accounts`), l.TotalAccounts.Int64)
+	tt.Assert.Equal(count(tt.CoreRepo(), `SELECT COUNT(*) FROM trustlines`), l.TotalTrustlines.Int64)
+	tt.Assert.True(l.TotalAccounts.Valid)
+	tt.Assert.True(l.TotalTrustlines.Valid)
+
+	// without the earlier ledgers, the operations since the network's first
+	// ledger are unknown, rather than counted afresh
+	_, err = tt.HorizonRepo().ExecRaw(`DELETE FROM history_ledgers WHERE sequence < 30`)
+	tt.Require.NoError(err)
+	_, err = sys.ReingestRange(30, 59)
+	tt.Require.NoError(err)
+
+	tt.Require.NoError(q.LedgerBySequence(&l, 58))
+	tt.Assert.False(l.TotalOperations.Valid)
+	tt.Assert.False(l.TotalAccounts.Valid)
+	tt.Require.NoError(q.LedgerBySequence(&l, 59))
+	tt.Assert.False(l.TotalOperations.Valid)
+	tt.Assert.Equal(count(tt.CoreRepo(), `SELECT COUNT(*) FROM accounts`), l.TotalAccounts.Int64)
+	tt.Assert.Equal(count(tt.CoreRepo(), `SELECT COUNT(*) FROM trustlines`), l.TotalTrustlines.Int64)
+}
+
+func TestLedgerTotalsCarriedForward(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+	q := &history.Q{Repo: tt.HorizonRepo()}
+
+	_, err := sys.ReingestRange(1, 59)
+	tt.Require.NoError(err)
+
+	var want history.Ledger
+	tt.Require.NoError(q.LedgerBySequence(&want, 59))
+
+	// totals that went wrong from ledger 30 onwards, short of a gap in their
+	// chain at ledger 50, are corrected by reingesting ledger 30 alone
+	_, err = tt.HorizonRepo().ExecRaw(`
+		UPDATE history_ledgers SET
+			total_accounts = total_accounts + 5,
+			total_operations = total_operations + 7
+		WHERE sequence >= 30`)
+	tt.Require.NoError(err)
+	_, err = tt.HorizonRepo().ExecRaw(`
+		UPDATE history_ledgers SET total_operations = NULL WHERE sequence = 50`)
+	tt.Require.NoError(err)
+
+	_, err = sys.ReingestRange(30, 30)
+	tt.Require.NoError(err)
+
+	var l history.Ledger
+	tt.Require.NoError(q.LedgerBySequence(&l, 59))
+	tt.Assert.Equal(want.TotalAccounts, l.TotalAccounts)
+	tt.Assert.Equal(want.TotalTrustlines, l.TotalTrustlines)
+
+	// the operations beyond the gap were not carried forward from ledger 30,
+	// so are left alone
+	var ops int64
+	tt.Require.NoError(tt.HorizonRepo().GetRaw(&ops, `
+		SELECT SUM(operation_count) FROM history_ledgers WHERE sequence <= 49`))
+	tt.Require.NoError(q.LedgerBySequence(&l, 49))
+	tt.Assert.Equal(ops, l.TotalOperations.Int64)
+	tt.Require.NoError(q.LedgerBySequence(&l, 59))
+	tt.Assert.Equal(want.TotalOperations.Int64+7, l.TotalOperations.Int64)
+}
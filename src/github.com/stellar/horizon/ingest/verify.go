@@ -0,0 +1,113 @@
+package ingest
+
+import (
+	"fmt"
+
+	sq "github.com/lann/squirrel"
+	"github.com/stellar/horizon/errors"
+)
+
+// CountMismatchError is the error returned when verification (see
+// System.VerifyIngestedCounts) finds that the rows stored for a ledger do not
+// agree with stellar-core's record of the ledger.
+type CountMismatchError struct {
+	// Sequence is the ledger whose counts disagree.
+	Sequence int32
+
+	// Count names the mismatched count, for example "transaction_count" or
+	// "history_operations rows".
+	Count string
+
+	Expected int
+	Stored   int
+}
+
+// Class implements errors.Classifier.
+func (err *CountMismatchError) Class() errors.Class {
+	return errors.Corruption
+}
+
+func (err *CountMismatchError) Error() string {
+	return fmt.Sprintf(
+		"ledger %d: %s is %d, expected %d",
+		err.Sequence,
+		err.Count,
+		err.Stored,
+		err.Expected,
+	)
+}
+
+// verifyLedger checks that the rows ingested for the current ledger agree with
+// the transactions stellar-core recorded alongside the ledger's header: that
+// the history_ledgers row's transaction_count and operation_count, as well as
+// the number of history_transactions and history_operations rows, equal the
+// number of successful transactions and their operations, failed transactions
+// being counted among the rows when they are stored.  It runs within the
+// ingestion's transaction, before the ledger is flushed, so that a mismatch
+// rolls the ledger back.
+func (is *Session) verifyLedger() {
+	if is.Err != nil || !is.VerifyIngestedCounts {
+		return
+	}
+
+	seq := is.Cursor.LedgerSequence()
+	start, end := is.Cursor.LedgerRange()
+	expectedTxs := is.Cursor.SuccessfulTransactionCount()
+	expectedOps := is.Cursor.SuccessfulLedgerOperationCount()
+	expectedTxRows := expectedTxs
+	if is.FailedTransactions {
+		expectedTxRows = is.Cursor.TransactionCount()
+	}
+
+	var stored struct {
+		Transactions int `db:"transaction_count"`
+		Operations   int `db:"operation_count"`
+	}
+	is.Err = is.Ingestion.DB.Get(&stored, sq.
+		Select("hl.transaction_count", "hl.operation_count").
+		From("history_ledgers hl").
+		Where("hl.sequence = ?", seq))
+	if is.Err != nil {
+		return
+	}
+
+	var txRows, opRows int
+	is.Err = is.Ingestion.DB.Get(&txRows, sq.
+		Select("COUNT(*)").
+		From("history_transactions").
+		Where("id >= ? AND id < ?", start, end))
+	if is.Err != nil {
+		return
+	}
+
+	is.Err = is.Ingestion.DB.Get(&opRows, sq.
+		Select("COUNT(*)").
+		From("history_operations").
+		Where("id >= ? AND id < ?", start, end))
+	if is.Err != nil {
+		return
+	}
+
+	checks := []CountMismatchError{
+		{Count: "transaction_count", Expected: expectedTxs, Stored: stored.Transactions},
+		{Count: "operation_count", Expected: expectedOps, Stored: stored.Operations},
+		{Count: "history_transactions rows", Expected: expectedTxRows, Stored: txRows},
+		{Count: "history_operations rows", Expected: expectedOps, Stored: opRows},
+	}
+
+	for _, c := range checks {
+		if c.Expected == c.Stored {
+			continue
+		}
+
+		c.Sequence = seq
+		is.Err = &c
+		is.logger().
+			WithField("count", c.Count).
+			WithField("expected", c.Expected).
+			WithField("stored", c.Stored).
+			WithError(is.Err).
+			Error("ingest: verification failed, ingested counts do not match stellar-core")
+		return
+	}
+}
@@ -0,0 +1,120 @@
+package ingest
+
+import (
+	"strings"
+
+	sq "github.com/lann/squirrel"
+	hlog "github.com/stellar/horizon/log"
+)
+
+// WriteLog records the statements executed by an Ingestion, such that the
+// writes made for each ledger can be logged (see System.LogWrites).
+type WriteLog struct {
+	// Data causes every statement to be logged with its full SQL and
+	// arguments, in addition to the per-table summary.
+	Data bool
+
+	tables     []*tableWrites
+	statements []loggedStatement
+}
+
+// tableWrites summarizes the inserts made into a single table.
+type tableWrites struct {
+	Table      string
+	Columns    string
+	Statements int
+	Rows       int
+}
+
+type loggedStatement struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Record adds the statement `sql`, executed with `args`, to the log.
+func (w *WriteLog) Record(sql string, args []interface{}) {
+	table, columns := parseInsert(sql)
+
+	var tw *tableWrites
+	for _, t := range w.tables {
+		if t.Table == table {
+			tw = t
+			break
+		}
+	}
+	if tw == nil {
+		tw = &tableWrites{Table: table, Columns: columns}
+		w.tables = append(w.tables, tw)
+	}
+
+	tw.Statements++
+	if n := strings.Count(columns, ",") + 1; columns != "" && len(args) >= n {
+		tw.Rows += len(args) / n
+	}
+
+	if w.Data {
+		w.statements = append(w.statements, loggedStatement{sql, args})
+	}
+}
+
+// Flush logs the statements recorded since the last flush to `l`, then
+// clears them.
+func (w *WriteLog) Flush(l *hlog.Entry) {
+	for _, t := range w.tables {
+		l.WithFields(hlog.F{
+			"table":      t.Table,
+			"columns":    t.Columns,
+			"statements": t.Statements,
+			"rows":       t.Rows,
+		}).Info("ingest: wrote rows")
+	}
+
+	for _, s := range w.statements {
+		l.WithField("sql", s.SQL).
+			WithField("args", s.Args).
+			Info("ingest: wrote statement")
+	}
+
+	w.tables = nil
+	w.statements = nil
+}
+
+// exec executes `sql` against the ingestion's database, recording it on the
+// ingestion's write log, if any.
+func (ingest *Ingestion) exec(sql sq.Sqlizer) error {
+	if ingest.Writes != nil {
+		query, args, err := sql.ToSql()
+		if err != nil {
+			return err
+		}
+		ingest.Writes.Record(query, args)
+	}
+
+	_, err := ingest.DB.Exec(sql)
+	return err
+}
+
+// parseInsert returns the table and comma separated columns of the INSERT
+// statement `sql`.  Either is empty if it cannot be found.
+func parseInsert(sql string) (table, columns string) {
+	const prefix = "INSERT INTO "
+	if !strings.HasPrefix(sql, prefix) {
+		return "", ""
+	}
+
+	rest := sql[len(prefix):]
+	end := strings.IndexAny(rest, " (")
+	if end == -1 {
+		return rest, ""
+	}
+	table = rest[:end]
+	rest = rest[end:]
+
+	open := strings.Index(rest, "(")
+	close := strings.Index(rest, ")")
+	if open == -1 || close < open {
+		return table, ""
+	}
+
+	return table, strings.Replace(rest[open+1:close], " ", "", -1)
+}
@@ -0,0 +1,66 @@
+package ingest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	sq "github.com/lann/squirrel"
+	hlog "github.com/stellar/horizon/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteLog(t *testing.T) {
+	output := new(bytes.Buffer)
+	l, _ := hlog.New()
+	l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+	l.Logger.Out = output
+	l.Logger.Level = logrus.InfoLevel
+
+	record := func(w *WriteLog, b sq.InsertBuilder) {
+		sql, args, err := b.ToSql()
+		require.NoError(t, err)
+		w.Record(sql, args)
+	}
+
+	ops := sq.Insert("history_operations").Columns("id", "type", "details")
+	effects := sq.Insert("history_effects").Columns("history_account_id", "type")
+
+	w := &WriteLog{}
+	record(w, ops.Values(1, 0, `{"secret":"data"}`))
+	record(w, ops.Values(2, 1, "{}").Values(3, 1, "{}"))
+	record(w, effects.Values(4, 2))
+
+	w.Flush(l)
+	out := output.String()
+	assert.Contains(t, out, "table=history_operations")
+	assert.Contains(t, out, "id,type,details")
+	assert.Contains(t, out, "rows=3")
+	assert.Contains(t, out, "statements=2")
+	assert.Contains(t, out, "table=history_effects")
+	assert.NotContains(t, out, "secret", "data is only logged when requested")
+
+	// flushing clears the log
+	output.Reset()
+	w.Flush(l)
+	assert.Equal(t, "", output.String())
+
+	w.Data = true
+	record(w, ops.Values(1, 0, `{"secret":"data"}`))
+	w.Flush(l)
+	assert.Contains(t, output.String(), "INSERT INTO history_operations")
+	assert.Contains(t, output.String(), "secret")
+}
+
+func TestParseInsert(t *testing.T) {
+	table, columns := parseInsert(
+		"INSERT INTO history_accounts (address) VALUES (?) RETURNING id",
+	)
+	assert.Equal(t, "history_accounts", table)
+	assert.Equal(t, "address", columns)
+
+	table, columns = parseInsert("DELETE FROM history_effects")
+	assert.Equal(t, "", table)
+	assert.Equal(t, "", columns)
+}
@@ -8,6 +8,8 @@ import (
 )
 
 func initHorizonDb(app *App) {
+	db2.SlowQueryThreshold = app.config.SlowQueryThreshold
+
 	repo, err := db2.Open(app.config.DatabaseURL)
 
 	if err != nil {
@@ -17,18 +19,72 @@ func initHorizonDb(app *App) {
 	repo.DB.SetMaxOpenConns(12)
 
 	app.historyQ = &history.Q{repo}
+	app.horizonHealth = &db2.Health{}
 }
 
 func initCoreDb(app *App) {
-	repo, err := db2.Open(app.config.StellarCoreDatabaseURL)
+	urls := append(
+		[]string{app.config.StellarCoreDatabaseURL},
+		app.config.StellarCoreFailoverDatabaseURLs...,
+	)
 
-	if err != nil {
-		log.Panic(err)
+	// with failover configured, horizon must start even while some of the
+	// databases are unreachable.
+	open := db2.OpenReadOnly
+	if len(urls) > 1 {
+		open = db2.OpenReadOnlyLazy
+	}
+
+	// horizon must never write to stellar-core's database, so the repos reject
+	// any statement that is not a read.
+	repos := make([]*db2.Repo, len(urls))
+	for i, url := range urls {
+		r, err := open(url)
+		if err != nil {
+			log.Panic(err)
+		}
+
+		r.DB.SetMaxIdleConns(4)
+		r.DB.SetMaxOpenConns(12)
+		repos[i] = r
+	}
+	repo := repos[0].Clone()
+
+	// stellar-core's database may be restarted from underneath us, so reads
+	// against it are retried when the connection is lost.
+	retry := db2.DefaultRetryPolicy
+	repo.Retry = &retry
+
+	if len(repos) > 1 {
+		app.coreFailover = newCoreFailover(repos, app.config.StellarCoreFailoverMaxLag)
+		repo.Failover = app.coreFailover
+
+		err := app.coreFailover.Check()
+		if err != nil {
+			log.Warnf("no stellar-core database is available: %s", err)
+		}
+	}
+
+	if app.config.MaxOrderBookDepth != 0 {
+		core.MaxOrderBookDepth = int(app.config.MaxOrderBookDepth)
 	}
 
-	repo.DB.SetMaxIdleConns(4)
-	repo.DB.SetMaxOpenConns(12)
 	app.coreQ = &core.Q{repo}
+	app.coreHealth = &db2.Health{}
+}
+
+// newCoreFailover returns a failover between the stellar-core databases
+// `repos`, which prefers those trailing the most advanced by no more than
+// `maxLag` ledgers.
+func newCoreFailover(repos []*db2.Repo, maxLag uint) *db2.Failover {
+	f := db2.NewFailover(repos...)
+	f.MaxLag = int64(maxLag)
+	f.Position = func(r *db2.Repo) (int64, error) {
+		var latest int32
+		err := (&core.Q{r}).LatestLedger(&latest)
+		return int64(latest), err
+	}
+	return f
 }
 
 func init() {
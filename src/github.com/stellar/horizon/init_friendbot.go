@@ -6,6 +6,10 @@ import (
 )
 
 func initFriendbot(app *App) {
+	app.capabilities.Add("friendbot", func(app *App) bool {
+		return app.friendbot != nil
+	})
+
 	if app.config.FriendbotSecret == "" {
 		return
 	}
@@ -16,7 +20,7 @@ func initFriendbot(app *App) {
 	app.friendbot = &friendbot.Bot{
 		Secret:    app.config.FriendbotSecret,
 		Submitter: app.submitter,
-		Network:   app.networkPassphrase,
+		Network:   app.currentCoreInfo().NetworkPassphrase,
 	}
 
 }
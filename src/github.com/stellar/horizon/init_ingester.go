@@ -7,22 +7,59 @@ import (
 )
 
 func initIngester(app *App) {
+	app.capabilities.Add("failed_transactions", func(app *App) bool {
+		return app.config.IngestFailedTransactions
+	})
+	app.capabilities.Add("failed_transaction_fees", func(app *App) bool {
+		return app.config.IngestFailedTransactionFees && effectsEnabled(app)
+	})
+	app.capabilities.Add("self_heal_reingest", func(app *App) bool {
+		return app.selfHealer != nil
+	})
+
 	if !app.config.Ingest {
+		if app.config.SelfHealReingest {
+			log.Print("Self-healing requires ingestion to be enabled, and is disabled.")
+		}
 		return
 	}
 
-	if app.networkPassphrase == "" {
+	passphrase := app.currentCoreInfo().NetworkPassphrase
+	if passphrase == "" {
 		log.Fatal("Cannot start ingestion without network passphrase.  Please confirm connectivity with stellar-core.")
 	}
 
 	app.ingester = ingest.New(
-		app.networkPassphrase,
+		passphrase,
 		app.config.StellarCoreURL,
 		app.CoreRepo(nil),
 		app.HorizonRepo(nil),
 	)
 
 	app.ingester.SkipCursorUpdate = app.config.SkipCursorUpdate
+	app.ingester.SkipCoreSchemaCheck = app.config.SkipCoreSchemaCheck
+	app.ingester.FastStartCount = int32(app.config.IngestFastStartCount)
+	app.ingester.IngestFloor = int32(app.config.IngestFloor)
+	app.ingester.Backfill = app.config.IngestBackfill
+	app.ingester.RetentionCount = app.config.HistoryRetentionCount
+	app.ingester.SkipEffects = app.config.DisableEffectIngestion
+	app.ingester.VerifyIngestedCounts = app.config.IngestVerifyCounts
+	app.ingester.FailedTransactionFeeEffects = app.config.IngestFailedTransactionFees
+	app.ingester.FailedTransactions = app.config.IngestFailedTransactions
+	app.ingester.LogWrites = app.config.IngestVerbose
+	app.ingester.LogWriteData = app.config.IngestVerboseData
+	app.ingester.ReadAhead = int(app.config.IngestReadAhead)
+
+	app.reingestJobs = newReingestJobs(app.ctx, app.ingester)
+
+	if app.config.SelfHealReingest {
+		app.selfHealer = newSelfHealer(app.reingestJobs)
+	}
+
+	err := app.ingester.CheckCoreSchema()
+	if err != nil {
+		log.Printf("Ingestion will not run until stellar-core's schema is compatible: %s.  Use --skip-core-schema-check to override.", err)
+	}
 }
 
 func init() {
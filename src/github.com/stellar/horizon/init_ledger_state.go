@@ -0,0 +1,20 @@
+package horizon
+
+import (
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/log"
+)
+
+func initLedgerState(app *App) {
+	ledger.SetLoader(app.LoadLedgerState)
+	app.ingestStall = &ledger.StallDetector{Grace: app.config.IngestStallGrace}
+
+	err := app.SeedLedgerState()
+	if err != nil {
+		log.Warnf("failed to seed ledger state from history: %s", err)
+	}
+}
+
+func init() {
+	appInit.Add("ledger-state", initLedgerState, "app-context", "horizon-db", "core-db")
+}
@@ -1,14 +1,83 @@
 package horizon
 
 import (
+	"os"
+
+	"github.com/Sirupsen/logrus"
 	"github.com/getsentry/raven-go"
+	"github.com/stellar/horizon/errors"
 	"github.com/stellar/horizon/log"
 )
 
 // initLog initialized the logging subsystem, attaching app.log and
-// app.logMetrics.  It also configured the logger's level using Config.LogLevel.
+// app.logMetrics.  See ConfigureLog.
 func initLog(app *App) {
-	log.DefaultLogger.Logger.Level = app.config.LogLevel
+	ConfigureLog(app.config)
+	ConfigureAuditLog(app.config)
+}
+
+// ConfigureLog configures log.DefaultLogger using the logging settings of
+// `config`:  the logger's level, format and the depth of logged stack traces
+// using Config.LogLevel, Config.LogFormat and Config.LogStackDepth, and the
+// log file (see initLogFile).  Commands that run without an App, such as those
+// of `horizon db`, use it to log as horizon does.
+func ConfigureLog(config Config) {
+	log.DefaultLogger.Logger.Level = config.LogLevel
+	log.DefaultLogger.SetFormat(config.LogFormat)
+	log.MaxStackDepth = config.LogStackDepth
+	initLogFile(config)
+}
+
+// initLogFile configures log.DefaultLogger to write to both stderr and the
+// rotated file at config.LogFile, if set.  When the file is to receive more
+// detail than stderr, the logger's level is raised to the file's and stderr is
+// held to config.LogLevel.  Horizon logs only to stderr if the file cannot be
+// opened.
+func initLogFile(config Config) {
+	if config.LogFile == "" {
+		return
+	}
+
+	f, err := log.OpenRotatingFile(
+		config.LogFile,
+		config.LogFileMaxSize,
+		config.LogFileMaxBackups,
+	)
+	if err != nil {
+		log.WithError(err).
+			WithField("path", config.LogFile).
+			Error("failed to open log file")
+		return
+	}
+
+	consoleLevel := logrus.DebugLevel
+	if config.LogFileLevel > config.LogLevel {
+		log.DefaultLogger.Logger.Level = config.LogFileLevel
+		consoleLevel = config.LogLevel
+	}
+
+	log.DefaultLogger.AddSink(log.NewSink(os.Stderr, config.LogFormat, consoleLevel))
+	log.DefaultLogger.AddSink(log.NewSink(f, config.LogFileFormat, config.LogFileLevel))
+}
+
+// ConfigureAuditLog configures log.DefaultAuditor to record events to the
+// file at config.AuditLog, if set.  Horizon runs without an audit log if the
+// file cannot be opened.  Commands that run without an App and record audit
+// events, such as `horizon db reingest`, use it alongside ConfigureLog.
+func ConfigureAuditLog(config Config) {
+	if config.AuditLog == "" {
+		return
+	}
+
+	sink, err := log.OpenAuditFile(config.AuditLog)
+	if err != nil {
+		log.WithError(err).
+			WithField("path", config.AuditLog).
+			Error("audit: failed to open audit log")
+		return
+	}
+
+	log.DefaultAuditor.Sink = sink
 }
 
 // initSentry initialized the default sentry client with the configured DSN
@@ -18,6 +87,7 @@ func initSentry(app *App) {
 	}
 
 	log.WithField("dsn", app.config.SentryDSN).Info("Initializing sentry")
+	errors.SentryDedupWindow = app.config.SentryDedupWindow
 	err := raven.SetDSN(app.config.SentryDSN)
 
 	if err != nil {
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/rcrowley/go-metrics"
+	"github.com/stellar/horizon/db2"
 	"github.com/stellar/horizon/log"
+	"github.com/stellar/horizon/render/sse"
 )
 
 func initMetrics(app *App) {
@@ -19,6 +21,9 @@ func initDbMetrics(app *App) {
 
 	app.horizonConnGauge = metrics.NewGauge()
 	app.coreConnGauge = metrics.NewGauge()
+	app.coreHealthyGauge = metrics.NewGauge()
+	app.coreSelectedDBGauge = metrics.NewGauge()
+	app.ingestStalledGauge = metrics.NewGauge()
 	app.goroutineGauge = metrics.NewGauge()
 	app.metrics.Register("history.latest_ledger", app.historyLatestLedgerGauge)
 	app.metrics.Register("history.elder_ledger", app.historyElderLedgerGauge)
@@ -26,7 +31,12 @@ func initDbMetrics(app *App) {
 	app.metrics.Register("stellar_core.elder_ledger", app.coreElderLedgerGauge)
 	app.metrics.Register("history.open_connections", app.horizonConnGauge)
 	app.metrics.Register("stellar_core.open_connections", app.coreConnGauge)
+	app.metrics.Register("stellar_core.healthy", app.coreHealthyGauge)
+	app.metrics.Register("stellar_core.selected_db", app.coreSelectedDBGauge)
+	app.metrics.Register("history.ingestion_stalled", app.ingestStalledGauge)
 	app.metrics.Register("goroutines", app.goroutineGauge)
+	app.metrics.Register("db.queries", db2.DefaultQueryMetrics.Queries)
+	app.metrics.Register("db.slow_queries", db2.DefaultQueryMetrics.SlowQueries)
 }
 
 func initIngesterMetrics(app *App) {
@@ -37,6 +47,8 @@ func initIngesterMetrics(app *App) {
 		app.ingester.Metrics.IngestLedgerTimer)
 	app.metrics.Register("ingester.clear_ledger",
 		app.ingester.Metrics.ClearLedgerTimer)
+	app.metrics.Register("ingester.load_ledger",
+		app.ingester.Metrics.LoadLedgerTimer)
 }
 
 func initLogMetrics(app *App) {
@@ -61,6 +73,10 @@ func initWebMetrics(app *App) {
 	app.metrics.Register("requests.total", app.web.requestTimer)
 	app.metrics.Register("requests.succeeded", app.web.successMeter)
 	app.metrics.Register("requests.failed", app.web.failureMeter)
+	app.metrics.Register("requests.over_capacity", app.web.overCapacityMeter)
+	app.metrics.Register("sse.open_streams", sse.DefaultStreamMetrics.Open)
+	app.metrics.Register("sse.streams", sse.DefaultStreamMetrics.Opened)
+	app.metrics.Register("sse.over_capacity", app.web.streamsOverCapacityMeter)
 }
 
 func init() {
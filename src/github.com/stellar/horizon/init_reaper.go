@@ -6,6 +6,8 @@ import (
 
 func initReaper(app *App) {
 	app.reaper = reap.New(app.config.HistoryRetentionCount, app.HorizonRepo(nil))
+	app.reaper.VacuumThreshold = app.config.ReapVacuumThreshold
+	app.reaper.Vacuum = app.config.ReapVacuum
 }
 
 func init() {
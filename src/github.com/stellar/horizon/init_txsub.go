@@ -1,17 +1,28 @@
 package horizon
 
 import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/log"
+	"github.com/stellar/horizon/resource/operations"
 	"github.com/stellar/horizon/txsub"
 	"github.com/stellar/horizon/txsub/results/db"
 	"github.com/stellar/horizon/txsub/sequence"
-	"net/http"
 )
 
 func initSubmissionSystem(app *App) {
 	cq := &core.Q{Repo: app.CoreRepo(nil)}
 
+	permitted, err := submittableOperations(app.config.SubmittableOperations)
+	if err != nil {
+		log.Panic(err)
+	}
+
 	app.submitter = &txsub.System{
 		Pending:         txsub.NewDefaultSubmissionList(),
 		Submitter:       txsub.NewDefaultSubmitter(http.DefaultClient, app.config.StellarCoreURL),
@@ -21,8 +32,50 @@ func initSubmissionSystem(app *App) {
 			History: &history.Q{Repo: app.HorizonRepo(nil)},
 		},
 		Sequences:         cq.SequenceProvider(),
-		NetworkPassphrase: app.networkPassphrase,
+		NetworkPassphrase: app.currentCoreInfo().NetworkPassphrase,
+		Limits: txsub.Limits{
+			MaxEnvelopeSize: int(app.config.MaxTxEnvelopeSize),
+			MaxOperations:   int(app.config.MaxTxOperations),
+			MaxSignatures:   int(app.config.MaxTxSignatures),
+		},
+		SubmittableOperations: permitted,
+	}
+}
+
+// submittableOperations returns the set of operation types named by `names`,
+// which are the names used by horizon's operation resources (see
+// operations.TypeNames).  No names permits every type, and yields a nil set.
+func submittableOperations(names []string) (txsub.OperationTypes, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	types := map[string]xdr.OperationType{}
+	for typ, name := range operations.TypeNames {
+		types[name] = typ
+	}
+
+	permitted := txsub.OperationTypes{}
+	for _, name := range names {
+		typ, ok := types[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown submittable operation type: %s", name)
+		}
+		permitted[typ] = true
+	}
+
+	return permitted, nil
+}
+
+// operationTypeNames returns the names of the operation types in `types`, in
+// alphabetical order.
+func operationTypeNames(types txsub.OperationTypes) []string {
+	names := make([]string, 0, len(types))
+	for typ := range types {
+		names = append(names, operations.TypeNames[typ])
 	}
+	sort.Strings(names)
+	return names
 }
 
 func init() {
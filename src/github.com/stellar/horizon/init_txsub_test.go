@@ -0,0 +1,26 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/txsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmittableOperations(t *testing.T) {
+	permitted, err := submittableOperations(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, permitted)
+
+	permitted, err = submittableOperations([]string{"payment", "path_payment"})
+	assert.NoError(t, err)
+	assert.Equal(t, txsub.OperationTypes{
+		xdr.OperationTypePayment:     true,
+		xdr.OperationTypePathPayment: true,
+	}, permitted)
+	assert.Equal(t, []string{"path_payment", "payment"}, operationTypeNames(permitted))
+
+	_, err = submittableOperations([]string{"payment", "pay"})
+	assert.EqualError(t, err, "unknown submittable operation type: pay")
+}
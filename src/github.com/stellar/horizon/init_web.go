@@ -4,12 +4,15 @@ import (
 	"database/sql"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/PuerkitoBio/throttled"
 	"github.com/PuerkitoBio/throttled/store"
 	"github.com/rcrowley/go-metrics"
-	"github.com/rs/cors"
 	"github.com/sebest/xff"
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/log"
+	"github.com/stellar/horizon/render"
 	"github.com/stellar/horizon/render/problem"
 	"github.com/stellar/horizon/txsub/sequence"
 	"github.com/zenazn/goji/web"
@@ -21,24 +24,88 @@ import (
 type Web struct {
 	router      *web.Mux
 	rateLimiter *throttled.Throttler
+	cors        *corsPolicy
+
+	// apiKeys, when non-nil, holds the API keys that clients may present in
+	// the apiKeyHeader header to be rate limited by the quota of their key
+	// rather than by IP address.  keyedRateLimiters holds a rate limiter for
+	// each distinct hourly quota of those keys, sharing rateLimitStore.
+	apiKeys               *APIKeys
+	apiKeyHeader          string
+	rateLimitStore        throttled.Store
+	keyedRateLimiters     map[int]*throttled.Throttler
+	keyedRateLimitersLock sync.Mutex
+
+	// clientStreams holds the number of event streams each client, keyed by
+	// its API key or IP address, has open.  Clients without an API key may
+	// open no more than maxStreamsPerIP, zero meaning no limit.
+	clientStreams     map[string]uint
+	clientStreamsLock sync.Mutex
+	maxStreamsPerIP   uint
 
 	requestTimer metrics.Timer
 	failureMeter metrics.Meter
 	successMeter metrics.Meter
+
+	// routeTimers holds a timer for each route that has served a request,
+	// keyed by its method and pattern, such as "GET /ledgers/:id".
+	routeTimers metrics.Registry
+
+	// requestSlots holds a value for each non-streaming request currently
+	// being handled.  It is nil when concurrency is unlimited.
+	requestSlots      chan struct{}
+	overCapacityMeter metrics.Meter
+
+	// streamSlots holds a value for each event stream currently open.  It is
+	// nil when streams are unlimited.
+	streamSlots              chan struct{}
+	streamsOverCapacityMeter metrics.Meter
 }
 
 // initWeb installed a new Web instance onto the provided app object.
 func initWeb(app *App) {
+	cors, err := newCORSPolicy(app.config)
+	if err != nil {
+		log.Panic(err)
+	}
+
 	app.web = &Web{
-		router:       web.New(),
-		requestTimer: metrics.NewTimer(),
-		failureMeter: metrics.NewMeter(),
-		successMeter: metrics.NewMeter(),
+		router:                   web.New(),
+		requestTimer:             metrics.NewTimer(),
+		failureMeter:             metrics.NewMeter(),
+		successMeter:             metrics.NewMeter(),
+		routeTimers:              metrics.NewRegistry(),
+		overCapacityMeter:        metrics.NewMeter(),
+		streamsOverCapacityMeter: metrics.NewMeter(),
+		cors:                     cors,
 	}
 
+	if app.config.MaxConcurrentRequests > 0 {
+		app.web.requestSlots = make(chan struct{}, app.config.MaxConcurrentRequests)
+	}
+
+	if app.config.MaxConcurrentStreams > 0 {
+		app.web.streamSlots = make(chan struct{}, app.config.MaxConcurrentStreams)
+	}
+
+	render.SetMaxBodySize(int64(app.config.MaxResponseBodySize))
+
 	// register problems
 	problem.RegisterError(sql.ErrNoRows, problem.NotFound)
 	problem.RegisterError(sequence.ErrNoMoreRoom, problem.ServerOverCapacity)
+	problem.RegisterError(db2.ErrTimeout, problem.RequestTimeout)
+	problem.RegisterError(render.ErrBodyTooLarge, problem.ResponseTooLarge)
+	problem.RegisterError(db2.ErrInvalidCursor, invalidPaging("cursor"))
+	problem.RegisterError(db2.ErrInvalidOrder, invalidPaging("order"))
+	problem.RegisterError(db2.ErrInvalidLimit, invalidPaging("limit"))
+}
+
+// invalidPaging returns the problem rendered when the paging parameter
+// `field` is invalid.
+func invalidPaging(field string) problem.P {
+	p := problem.BadRequest
+	p.Extras = map[string]interface{}{"invalid_field": field}
+	return p
 }
 
 // initWebMiddleware installs the middleware stack used for horizon onto the
@@ -51,35 +118,47 @@ func initWebMiddleware(app *App) {
 	r.Use(app.Middleware)
 	r.Use(middleware.RequestID)
 	r.Use(contextMiddleware(app.ctx))
+	r.Use(requestTimeoutMiddleware(app.config.RequestTimeout))
 	r.Use(xff.Handler)
 	r.Use(LoggerMiddleware)
 	r.Use(requestMetricsMiddleware)
+	r.Use(latestLedgerMiddleware)
 	r.Use(RecoverMiddleware)
 	r.Use(middleware.AutomaticOptions)
 
-	c := cors.New(cors.Options{
-		AllowedOrigins: []string{"*"},
-		AllowedHeaders: []string{"*"},
-	})
-	r.Use(c.Handler)
+	r.Use(app.web.CORSMiddleware)
 
 	r.Use(app.web.RateLimitMiddleware)
+	r.Use(app.web.ConcurrencyLimitMiddleware)
+
+	// route within the middleware stack, rather than after it, so that the
+	// matched route is known to requestMetricsMiddleware
+	r.Use(r.Router)
 }
 
 // initWebActions installs the routing configuration of horizon onto the
-// provided app.  All route registration should be implemented here.
+// provided app.  All route registration should be implemented here, along
+// with the capabilities the routes provide.
 func initWebActions(app *App) {
 	r := app.web.router
+	caps := &app.capabilities
 	r.Get("/", &RootAction{})
+	r.Get("/stats", &StatsAction{})
+	r.Get("/health", &HealthAction{})
 	r.Get("/metrics", &MetricsAction{})
 
 	// ledger actions
 	r.Get("/ledgers", &LedgerIndexAction{})
+	r.Get("/ledgers/by_time", &LedgerByTimeAction{})
 	r.Get("/ledgers/:id", &LedgerShowAction{})
+	r.Get("/ledgers/:id/export", &LedgerExportAction{})
+	caps.Add("ledger_export", effectsEnabled)
+	caps.Add("ledger_export_ndjson", effectsEnabled)
 	r.Get("/ledgers/:ledger_id/transactions", &TransactionIndexAction{})
 	r.Get("/ledgers/:ledger_id/operations", &OperationIndexAction{})
 	r.Get("/ledgers/:ledger_id/payments", &PaymentsIndexAction{})
 	r.Get("/ledgers/:ledger_id/effects", &EffectIndexAction{})
+	r.Get("/ledgers/:ledger_id/upgrades", &LedgerUpgradeIndexAction{})
 
 	// account actions
 	r.Get("/accounts/:id", &AccountShowAction{})
@@ -89,7 +168,10 @@ func initWebActions(app *App) {
 	r.Get("/accounts/:account_id/effects", &EffectIndexAction{})
 	r.Get("/accounts/:account_id/offers", &OffersByAccountAction{})
 	r.Get("/accounts/:account_id/trades", &TradeIndexAction{})
+	r.Get("/accounts/:account_id/signer_history", &SignerHistoryAction{})
 	r.Get("/accounts/:account_id/data/:key", &DataShowAction{})
+	r.Get("/accounts/:account_id/spendable_assets", &AccountSpendableAssetsAction{})
+	caps.Add("spendable_assets", nil)
 
 	// transaction history actions
 	r.Get("/transactions", &TransactionIndexAction{})
@@ -97,21 +179,35 @@ func initWebActions(app *App) {
 	r.Get("/transactions/:tx_id/operations", &OperationIndexAction{})
 	r.Get("/transactions/:tx_id/payments", &PaymentsIndexAction{})
 	r.Get("/transactions/:tx_id/effects", &EffectIndexAction{})
+	r.Get("/transactions/:tx_id/meta", &TransactionMetaAction{})
+	caps.Add("include_raw", nil)
 
 	// operation actions
 	r.Get("/operations", &OperationIndexAction{})
 	r.Get("/operations/:id", &OperationShowAction{})
+	r.Get("/operations/:id/locator", &OperationLocatorAction{})
 	r.Get("/operations/:op_id/effects", &EffectIndexAction{})
+	caps.Add("operation_filters", nil)
+	caps.Add("operation_locator", nil)
 
 	r.Get("/payments", &PaymentsIndexAction{})
 	r.Get("/effects", &EffectIndexAction{})
+	caps.Add("effects", effectsEnabled)
+	caps.Add("effect_filters", effectsEnabled)
 
+	r.Get("/assets", &AssetsIndexAction{})
+	caps.Add("assets", nil)
 	r.Get("/offers/:id", &NotImplementedAction{})
 	r.Get("/order_book", &OrderBookShowAction{})
 	r.Get("/order_book/trades", &TradeIndexAction{})
+	r.Get("/trade_aggregations", &TradeAggregationIndexAction{})
+	caps.Add("trades", effectsEnabled)
+	caps.Add("trades_streaming", effectsEnabled)
 
 	// Transaction submission API
 	r.Post("/transactions", &TransactionCreateAction{})
+	r.Post("/transactions/status", &TransactionStatusAction{})
+	caps.Add("transaction_status", nil)
 	r.Get("/paths", &PathIndexAction{})
 
 	// friendbot
@@ -136,6 +232,47 @@ func initWebRateLimiter(app *App) {
 
 	rateLimiter.DeniedHandler = &RateLimitExceededAction{App: app, Action: Action{}}
 	app.web.rateLimiter = rateLimiter
+	app.web.rateLimitStore = rateLimitStore
+	app.web.keyedRateLimiters = map[int]*throttled.Throttler{}
+	app.web.clientStreams = map[string]uint{}
+	app.web.maxStreamsPerIP = app.config.MaxStreamsPerIP
+
+	if app.config.RateLimitKeysFile != "" {
+		keys, err := LoadAPIKeys(app.config.RateLimitKeysFile)
+		if err != nil {
+			log.Panic(err)
+		}
+		app.web.apiKeys = keys
+		app.web.apiKeyHeader = app.config.RateLimitKeyHeader
+	}
+
+	app.capabilities.Add("api_keys", func(app *App) bool {
+		return app.web.apiKeys != nil
+	})
+}
+
+// keyedRateLimiter returns the rate limiter for API keys allowed `perHour`
+// requests an hour, which limits each key separately.
+func (web *Web) keyedRateLimiter(perHour int) *throttled.Throttler {
+	web.keyedRateLimitersLock.Lock()
+	defer web.keyedRateLimitersLock.Unlock()
+
+	limiter, ok := web.keyedRateLimiters[perHour]
+	if ok {
+		return limiter
+	}
+
+	header := web.apiKeyHeader
+	limiter = throttled.RateLimit(
+		throttled.PerHour(perHour),
+		&throttled.VaryBy{Custom: func(r *http.Request) string {
+			return "key:" + r.Header.Get(header)
+		}},
+		web.rateLimitStore,
+	)
+	limiter.DeniedHandler = web.rateLimiter.DeniedHandler
+	web.keyedRateLimiters[perHour] = limiter
+	return limiter
 }
 
 func remoteAddrIP(r *http.Request) string {
@@ -171,4 +308,5 @@ func init() {
 
 		"web.init",
 	)
+
 }
@@ -6,19 +6,57 @@
 package ledger
 
 import (
+	"fmt"
 	"sync"
+	"time"
 )
 
 // State represents a snapshot of both horizon's and stellar-core's view of the
-// ledger.
+// ledger.  The snapshot returned by CurrentState aggregates the readings (see
+// Reading) of the primary databases:  its core sequences are those of
+// CorePrimary and its history sequences those of HistoryPrimary.  Use StateFrom
+// to build a snapshot from other sources.
 type State struct {
 	CoreLatest    int32 `db:"core_latest"`
 	CoreElder     int32 `db:"core_elder"`
 	HistoryLatest int32 `db:"history_latest"`
 	HistoryElder  int32 `db:"history_elder"`
+
+	// The hash and close time of the latest ledgers known to stellar-core and
+	// horizon.  They are empty when the corresponding database has no ledgers.
+	CoreLatestHash        string    `db:"core_latest_hash"`
+	CoreLatestClosedAt    time.Time `db:"core_latest_closed_at"`
+	HistoryLatestHash     string    `db:"history_latest_hash"`
+	HistoryLatestClosedAt time.Time `db:"history_latest_closed_at"`
+
+	// The network parameters in effect as of stellar-core's latest ledger.
+	ProtocolVersion int32 `db:"protocol_version"`
+	BaseFee         int32 `db:"base_fee"`
+	BaseReserve     int32 `db:"base_reserve"`
+	MaxTxSetSize    int32 `db:"max_tx_set_size"`
+
+	// UpdatedAt is the time at which the snapshot was taken.  For a snapshot
+	// built from several readings, it is the time of the oldest one.
+	UpdatedAt time.Time `db:"-"`
+}
+
+// StaleStateError is returned by FreshState when the cached snapshot of ledger
+// state is older than the caller allows.
+type StaleStateError struct {
+	Age    time.Duration
+	MaxAge time.Duration
+}
+
+func (err *StaleStateError) Error() string {
+	return fmt.Sprintf(
+		"ledger state is stale: last updated %s ago (max age: %s)",
+		err.Age,
+		err.MaxAge,
+	)
 }
 
-// CurrentState returns the cached snapshot of ledger state
+// CurrentState returns the cached snapshot of ledger state, regardless of how
+// long ago it was taken.
 func CurrentState() State {
 	lock.RLock()
 	ret := current
@@ -26,12 +64,77 @@ func CurrentState() State {
 	return ret
 }
 
-// SetState updates the cached snapshot of the ledger state
+// CurrentStateWithAge returns the cached snapshot of ledger state along with
+// the time elapsed since it was taken.
+func CurrentStateWithAge() (State, time.Duration) {
+	ret := CurrentState()
+	return ret, now().Sub(ret.UpdatedAt)
+}
+
+// FreshState returns the cached snapshot of ledger state, or a
+// *StaleStateError if the snapshot was taken more than `maxAge` ago.  Use it
+// where acting upon an outdated view of the ledger would do harm.
+func FreshState(maxAge time.Duration) (State, error) {
+	ret := CurrentState()
+	return ret, checkAge(ret, maxAge, now())
+}
+
+// checkAge returns a *StaleStateError if, as of `at`, `s` was taken more than
+// `maxAge` ago.
+func checkAge(s State, maxAge time.Duration, at time.Time) error {
+	age := at.Sub(s.UpdatedAt)
+	if age > maxAge {
+		return &StaleStateError{Age: age, MaxAge: maxAge}
+	}
+
+	return nil
+}
+
+// Reset discards the cached snapshot and the readings of every source.  It is
+// intended for use by tests.
+func Reset() {
+	subLock.Lock()
+	defer subLock.Unlock()
+
+	lock.Lock()
+	prev := current
+	current = State{}
+	readings = map[Source]Reading{}
+	lock.Unlock()
+
+	if sequencesChanged(prev, State{}) {
+		publish(State{})
+	}
+}
+
+// SetState updates the cached snapshot of the ledger state, recording its
+// sequences as readings of CorePrimary and HistoryPrimary.  If `next` does not
+// specify when it was taken, it is considered to have been taken now.  When
+// any of its ledger sequences changed, `next` is published to subscribers (see
+// Subscribe).
 func SetState(next State) {
+	if next.UpdatedAt.IsZero() {
+		next.UpdatedAt = now()
+	}
+
+	// holding subLock throughout ensures concurrent updates are published in
+	// the order they were applied.
+	subLock.Lock()
+	defer subLock.Unlock()
+
 	lock.Lock()
+	prev := current
 	current = next
+	readings[CorePrimary], readings[HistoryPrimary] = next.primaryReadings()
 	lock.Unlock()
+
+	if sequencesChanged(prev, next) {
+		publish(next)
+	}
 }
 
 var current State
 var lock sync.RWMutex
+
+// now is the clock used to stamp and age snapshots, replaced in tests.
+var now = time.Now
@@ -0,0 +1,179 @@
+package ledger
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestStateAge(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return clock }
+	defer func() {
+		now = time.Now
+		SetState(State{})
+	}()
+
+	// snapshots are stamped when set
+	SetState(State{CoreLatest: 3})
+	state, age := CurrentStateWithAge()
+	assert.Equal(int32(3), state.CoreLatest)
+	assert.Equal(clock, state.UpdatedAt)
+	assert.Equal(time.Duration(0), age)
+
+	clock = clock.Add(5 * time.Second)
+	_, age = CurrentStateWithAge()
+	assert.Equal(5*time.Second, age)
+
+	state, err := FreshState(5 * time.Second)
+	assert.NoError(err)
+	assert.Equal(int32(3), state.CoreLatest)
+
+	state, err = FreshState(4 * time.Second)
+	assert.Equal(&StaleStateError{Age: 5 * time.Second, MaxAge: 4 * time.Second}, err)
+	assert.Equal(int32(3), state.CoreLatest, "stale state should still be returned")
+
+	// the permissive call ignores age
+	assert.Equal(int32(3), CurrentState().CoreLatest)
+
+	// an explicit timestamp is preserved
+	SetState(State{CoreLatest: 4, UpdatedAt: clock.Add(-time.Minute)})
+	_, age = CurrentStateWithAge()
+	assert.Equal(time.Minute, age)
+}
+
+func TestSubscribe(t *testing.T) {
+	assert := assert.New(t)
+	defer SetState(State{})
+
+	SetState(State{CoreLatest: 1, HistoryLatest: 1})
+	ch := Subscribe()
+	defer Unsubscribe(ch)
+
+	// unchanged sequences are not published
+	SetState(State{CoreLatest: 1, HistoryLatest: 1})
+	select {
+	case <-ch:
+		t.Error("received state whose sequences did not change")
+	default:
+	}
+
+	SetState(State{CoreLatest: 2, HistoryLatest: 1})
+	select {
+	case s := <-ch:
+		assert.Equal(int32(2), s.CoreLatest)
+	default:
+		t.Error("did not receive changed state")
+	}
+
+	// a subscriber that falls behind receives only the newest state
+	SetState(State{CoreLatest: 3, HistoryLatest: 1})
+	SetState(State{CoreLatest: 3, HistoryLatest: 3})
+	SetState(State{CoreLatest: 4, HistoryLatest: 3})
+	select {
+	case s := <-ch:
+		assert.Equal(int32(4), s.CoreLatest)
+		assert.Equal(int32(3), s.HistoryLatest)
+	default:
+		t.Error("did not receive changed state")
+	}
+	select {
+	case <-ch:
+		t.Error("received stale state")
+	default:
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	defer SetState(State{})
+
+	ch := Subscribe()
+	Unsubscribe(ch)
+	Unsubscribe(ch)
+
+	SetState(State{CoreLatest: 5})
+	_, ok := <-ch
+	assert.False(t, ok, "channel should be closed")
+
+	// unsubscribing while states are being published
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := int32(0); i < 1000; i++ {
+			SetState(State{CoreLatest: i})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			Unsubscribe(Subscribe())
+		}
+	}()
+	wg.Wait()
+}
+
+func TestRefresh(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	defer func() {
+		SetLoader(nil)
+		SetState(State{})
+	}()
+
+	_, err := Refresh(ctx)
+	assert.Equal(ErrNoLoader, err)
+
+	// concurrent refreshes share a single load
+	var (
+		loads   int32
+		release = make(chan struct{})
+	)
+	SetLoader(func(ctx context.Context) (State, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return State{CoreLatest: 3, HistoryLatest: 2}, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make(chan State, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := Refresh(ctx)
+			assert.NoError(err)
+			results <- s
+		}()
+	}
+
+	// give every caller a chance to join the load in progress
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	assert.Equal(int32(1), atomic.LoadInt32(&loads))
+	for s := range results {
+		assert.Equal(int32(3), s.CoreLatest)
+		assert.False(s.UpdatedAt.IsZero())
+	}
+	assert.Equal(int32(3), CurrentState().CoreLatest)
+
+	// failures are returned, leaving the cached state alone
+	boom := errors.New("boom")
+	SetLoader(func(ctx context.Context) (State, error) {
+		return State{}, boom
+	})
+
+	_, err = Refresh(ctx)
+	assert.Equal(boom, err)
+	assert.Equal(int32(3), CurrentState().CoreLatest)
+}
@@ -0,0 +1,61 @@
+package ledger
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// StateProvider provides snapshots of the ledger state to the systems that act
+// upon it.  Systems that take a StateProvider as a dependency, rather than
+// calling this package's functions directly, can be tested against a Simulated
+// ledger state.
+type StateProvider interface {
+	// CurrentState returns the snapshot of ledger state, regardless of how long
+	// ago it was taken.  See CurrentState.
+	CurrentState() State
+
+	// FreshState is like CurrentState, but returns a *StaleStateError if the
+	// snapshot was taken more than `maxAge` ago.  See FreshState.
+	FreshState(maxAge time.Duration) (State, error)
+
+	// StateFrom returns a snapshot built from the readings of the `core` and
+	// `history` sources.  See StateFrom.
+	StateFrom(core, history Source) State
+
+	// FreshStateFrom is like StateFrom, but returns a *StaleStateError if either
+	// reading was taken more than `maxAge` ago.  See FreshStateFrom.
+	FreshStateFrom(maxAge time.Duration, core, history Source) (State, error)
+
+	// Refresh loads a new snapshot of the ledger state, returning ErrNoLoader
+	// if it has no means of doing so.  See Refresh.
+	Refresh(ctx context.Context) (State, error)
+}
+
+// Default is the StateProvider backed by this package's cached snapshot and
+// readings, as updated by SetState, SetReading and Refresh.  It is used by
+// systems whose provider is not configured.
+var Default StateProvider = cachedProvider{}
+
+// cachedProvider implements StateProvider using the package-level functions.
+type cachedProvider struct{}
+
+func (cachedProvider) CurrentState() State {
+	return CurrentState()
+}
+
+func (cachedProvider) FreshState(maxAge time.Duration) (State, error) {
+	return FreshState(maxAge)
+}
+
+func (cachedProvider) StateFrom(core, history Source) State {
+	return StateFrom(core, history)
+}
+
+func (cachedProvider) FreshStateFrom(maxAge time.Duration, core, history Source) (State, error) {
+	return FreshStateFrom(maxAge, core, history)
+}
+
+func (cachedProvider) Refresh(ctx context.Context) (State, error) {
+	return Refresh(ctx)
+}
@@ -0,0 +1,93 @@
+package ledger
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// Loader loads a new snapshot of the ledger state from horizon's and
+// stellar-core's databases.
+type Loader func(ctx context.Context) (State, error)
+
+// ErrNoLoader is returned by Refresh when no Loader has been registered using
+// SetLoader.
+var ErrNoLoader = errors.New("ledger: no loader registered")
+
+// SetLoader registers the Loader used by Refresh.  Passing nil unregisters the
+// current loader.
+func SetLoader(l Loader) {
+	refreshLock.Lock()
+	loader = l
+	refreshLock.Unlock()
+}
+
+// Refresh immediately loads a new snapshot of the ledger state using the
+// registered Loader, caching it (see SetState) and returning it.  Concurrent
+// calls are deduplicated:  a call made while another is loading waits for and
+// shares that load's result (which uses the context of the call that started
+// it) rather than querying the databases again.
+//
+// When loading fails the error is returned and the cached snapshot is left
+// untouched.
+func Refresh(ctx context.Context) (State, error) {
+	refreshLock.Lock()
+	load := loader
+	if load == nil {
+		refreshLock.Unlock()
+		return State{}, ErrNoLoader
+	}
+
+	if call := inflight; call != nil {
+		refreshLock.Unlock()
+
+		select {
+		case <-call.done:
+			return call.state, call.err
+		case <-ctx.Done():
+			return State{}, ctx.Err()
+		}
+	}
+
+	// the error is replaced by the result of the load, unless it panics
+	call := &refreshCall{
+		done: make(chan struct{}),
+		err:  errors.New("ledger: loader panicked"),
+	}
+	inflight = call
+	refreshLock.Unlock()
+
+	defer func() {
+		refreshLock.Lock()
+		inflight = nil
+		refreshLock.Unlock()
+		close(call.done)
+	}()
+
+	call.state, call.err = load(ctx)
+	if call.err != nil {
+		return call.state, call.err
+	}
+
+	if call.state.UpdatedAt.IsZero() {
+		call.state.UpdatedAt = now()
+	}
+
+	SetState(call.state)
+	return call.state, nil
+}
+
+// refreshCall is a load of the ledger state that is in progress or complete.
+// state and err are only valid once done is closed.
+type refreshCall struct {
+	done  chan struct{}
+	state State
+	err   error
+}
+
+var (
+	loader      Loader
+	inflight    *refreshCall
+	refreshLock sync.Mutex
+)
@@ -0,0 +1,153 @@
+package ledger
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Simulated is a StateProvider intended for tests.  Unlike Default, its ledger
+// state is set explicitly (see SetState and SetReading) and its age is measured
+// against a clock that only moves when told to (see SetTime and Advance), so
+// that tests can arrange for the state to be fresh or stale without sleeping or
+// touching the package-level snapshot.  A Simulated is safe for concurrent use.
+type Simulated struct {
+	lock     sync.RWMutex
+	now      time.Time
+	current  State
+	readings map[Source]Reading
+	loader   Loader
+}
+
+// NewSimulated returns a Simulated ledger state with no readings, whose clock
+// is set to `now`.
+func NewSimulated(now time.Time) *Simulated {
+	return &Simulated{
+		now:      now,
+		readings: map[Source]Reading{},
+	}
+}
+
+// Now returns the current time of the simulated clock.
+func (s *Simulated) Now() time.Time {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.now
+}
+
+// SetTime sets the simulated clock to `now`.
+func (s *Simulated) SetTime(now time.Time) {
+	s.lock.Lock()
+	s.now = now
+	s.lock.Unlock()
+}
+
+// Advance moves the simulated clock forward by `d`.
+func (s *Simulated) Advance(d time.Duration) {
+	s.lock.Lock()
+	s.now = s.now.Add(d)
+	s.lock.Unlock()
+}
+
+// SetLoader registers the Loader used by Refresh.  Passing nil unregisters the
+// current loader.
+func (s *Simulated) SetLoader(l Loader) {
+	s.lock.Lock()
+	s.loader = l
+	s.lock.Unlock()
+}
+
+// SetState replaces the simulated snapshot, recording its sequences as readings
+// of CorePrimary and HistoryPrimary.  If `next` does not specify when it was
+// taken, it is considered to have been taken at the simulated clock's time.
+func (s *Simulated) SetState(next State) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if next.UpdatedAt.IsZero() {
+		next.UpdatedAt = s.now
+	}
+
+	s.current = next
+	s.readings[CorePrimary], s.readings[HistoryPrimary] = next.primaryReadings()
+}
+
+// SetReading records the reading of a single source.  If `r` does not specify
+// when it was taken, it is considered to have been taken at the simulated
+// clock's time.  As with the package-level SetReading, only readings of the
+// primary sources affect CurrentState.
+func (s *Simulated) SetReading(r Reading) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = s.now
+	}
+
+	s.readings[r.Source] = r
+	switch r.Source {
+	case CorePrimary:
+		s.current.setCore(r)
+	case HistoryPrimary:
+		s.current.setHistory(r)
+	}
+	s.current.UpdatedAt = older(s.readings[CorePrimary], s.readings[HistoryPrimary])
+}
+
+// CurrentState implements StateProvider.
+func (s *Simulated) CurrentState() State {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.current
+}
+
+// FreshState implements StateProvider, measuring age against the simulated
+// clock.
+func (s *Simulated) FreshState(maxAge time.Duration) (State, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.current, checkAge(s.current, maxAge, s.now)
+}
+
+// StateFrom implements StateProvider.
+func (s *Simulated) StateFrom(core, history Source) State {
+	checkSources(core, history)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ret := s.current
+	ret.setReadings(s.readings[core], s.readings[history])
+	return ret
+}
+
+// FreshStateFrom implements StateProvider, measuring age against the simulated
+// clock.
+func (s *Simulated) FreshStateFrom(maxAge time.Duration, core, history Source) (State, error) {
+	ret := s.StateFrom(core, history)
+	return ret, checkAge(ret, maxAge, s.Now())
+}
+
+// Refresh implements StateProvider, loading a new snapshot using the
+// registered Loader and replacing the simulated snapshot with it (see
+// SetState).  Without a registered Loader, it returns ErrNoLoader.
+func (s *Simulated) Refresh(ctx context.Context) (State, error) {
+	s.lock.RLock()
+	load := s.loader
+	s.lock.RUnlock()
+
+	if load == nil {
+		return State{}, ErrNoLoader
+	}
+
+	next, err := load(ctx)
+	if err != nil {
+		return next, err
+	}
+
+	s.SetState(next)
+	return s.CurrentState(), nil
+}
+
+var _ StateProvider = &Simulated{}
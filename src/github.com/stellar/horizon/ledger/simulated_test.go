@@ -0,0 +1,93 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestSimulated(t *testing.T) {
+	assert := assert.New(t)
+	Reset()
+
+	clock := time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC)
+	sim := NewSimulated(clock)
+
+	// states are stamped using the simulated clock
+	sim.SetState(State{CoreLatest: 10, CoreElder: 1, HistoryLatest: 9, HistoryElder: 1})
+	state, err := sim.FreshState(time.Second)
+	assert.NoError(err)
+	assert.Equal(int32(10), state.CoreLatest)
+	assert.Equal(clock, state.UpdatedAt)
+
+	// ...which only moves when advanced
+	sim.Advance(2 * time.Second)
+	assert.Equal(clock.Add(2*time.Second), sim.Now())
+	_, err = sim.FreshState(time.Second)
+	if assert.IsType(&StaleStateError{}, err) {
+		assert.Equal(2*time.Second, err.(*StaleStateError).Age)
+	}
+
+	// readings of other sources are only visible through StateFrom
+	sim.SetReading(Reading{Source: CoreSecondary, Latest: 12, Elder: 5})
+	assert.Equal(int32(10), sim.CurrentState().CoreLatest)
+	state, err = sim.FreshStateFrom(time.Second, CoreSecondary, HistoryPrimary)
+	assert.Equal(int32(12), state.CoreLatest)
+	assert.Equal(int32(9), state.HistoryLatest)
+	assert.IsType(&StaleStateError{}, err)
+
+	// a primary reading updates the current state
+	sim.SetReading(Reading{Source: HistoryPrimary, Latest: 10, Elder: 1})
+	sim.SetTime(clock)
+	assert.Equal(int32(10), sim.CurrentState().HistoryLatest)
+	assert.Equal(clock, sim.CurrentState().UpdatedAt)
+
+	// the package-level state is untouched
+	assert.Equal(State{}, CurrentState())
+	_, ok := CurrentReading(CoreSecondary)
+	assert.False(ok)
+}
+
+func TestSimulatedRefresh(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	clock := time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC)
+	sim := NewSimulated(clock)
+
+	_, err := sim.Refresh(ctx)
+	assert.Equal(ErrNoLoader, err)
+
+	sim.SetLoader(func(ctx context.Context) (State, error) {
+		return State{CoreLatest: 3, HistoryLatest: 2}, nil
+	})
+	state, err := sim.Refresh(ctx)
+	assert.NoError(err)
+	assert.Equal(int32(3), state.CoreLatest)
+	assert.Equal(clock, state.UpdatedAt)
+	assert.Equal(state, sim.CurrentState())
+
+	// a failed refresh leaves the state untouched
+	boom := errors.New("boom")
+	sim.SetLoader(func(ctx context.Context) (State, error) {
+		return State{}, boom
+	})
+	_, err = sim.Refresh(ctx)
+	assert.Equal(boom, err)
+	assert.Equal(int32(3), sim.CurrentState().CoreLatest)
+}
+
+func TestDefaultProvider(t *testing.T) {
+	assert := assert.New(t)
+	defer Reset()
+
+	SetState(State{CoreLatest: 10, HistoryLatest: 9})
+	assert.Equal(CurrentState(), Default.CurrentState())
+	assert.Equal(StateFrom(CorePrimary, HistoryPrimary), Default.StateFrom(CorePrimary, HistoryPrimary))
+
+	_, err := Default.FreshState(time.Hour)
+	assert.NoError(err)
+}
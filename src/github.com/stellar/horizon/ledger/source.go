@@ -0,0 +1,224 @@
+package ledger
+
+import (
+	"fmt"
+	"time"
+)
+
+// Source identifies the database from which a Reading of ledger sequences was
+// taken.
+type Source int
+
+const (
+	// CorePrimary is the stellar-core database horizon ingests from.
+	CorePrimary Source = iota
+	// CoreSecondary is the most preferred available stellar-core database, other
+	// than the primary, of those configured for failover.
+	CoreSecondary
+	// HistoryPrimary is the horizon database ingestion writes to.
+	HistoryPrimary
+)
+
+// IsCore returns true if `s` is one of the stellar-core sources.
+func (s Source) IsCore() bool {
+	return s == CorePrimary || s == CoreSecondary
+}
+
+// IsHistory returns true if `s` is one of the horizon (history) sources.
+func (s Source) IsHistory() bool {
+	return s == HistoryPrimary
+}
+
+func (s Source) String() string {
+	switch s {
+	case CorePrimary:
+		return "core_primary"
+	case CoreSecondary:
+		return "core_secondary"
+	case HistoryPrimary:
+		return "history_primary"
+	default:
+		return fmt.Sprintf("Source(%d)", int(s))
+	}
+}
+
+// Reading is a snapshot of the elder and latest ledgers known to a single
+// database.
+type Reading struct {
+	Source Source
+	Latest int32
+	Elder  int32
+
+	// LatestHash and LatestClosedAt describe the latest ledger.
+	LatestHash     string
+	LatestClosedAt time.Time
+
+	// UpdatedAt is the time at which the reading was taken.
+	UpdatedAt time.Time
+}
+
+// CurrentReading returns the cached reading for `src`.  ok is false if no
+// reading has been recorded for it.
+func CurrentReading(src Source) (r Reading, ok bool) {
+	lock.RLock()
+	r, ok = readings[src]
+	lock.RUnlock()
+	return
+}
+
+// SetReading records the latest reading of a single database.  If `r` does not
+// specify when it was taken, it is considered to have been taken now.
+//
+// Readings from the primary databases also update the aggregate snapshot
+// returned by CurrentState, publishing it to subscribers when its sequences
+// change.  Readings from other sources are only visible through
+// CurrentReading and StateFrom.
+func SetReading(r Reading) {
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = now()
+	}
+
+	record(r, false)
+}
+
+// Seed records `r` as the reading of its source, unless a reading has already
+// been recorded for that source.  Unlike SetReading, a seeded reading is never
+// stamped:  its UpdatedAt is kept as given (usually zero), so that FreshState
+// and FreshStateFrom consider it stale until a real reading replaces it.
+//
+// Seed is used at startup so that callers consulting the cached state before
+// the first refresh completes see recently persisted values rather than zeros,
+// while consumers that act upon the state (such as ingestion) still wait for a
+// real refresh.
+func Seed(r Reading) {
+	record(r, true)
+}
+
+// record stores `r` and updates the aggregate snapshot from the primary
+// readings, publishing it if its sequences changed.  When `seed` is true, `r`
+// is dropped if its source already has a reading.
+func record(r Reading, seed bool) {
+	subLock.Lock()
+	defer subLock.Unlock()
+
+	lock.Lock()
+	if _, ok := readings[r.Source]; ok && seed {
+		lock.Unlock()
+		return
+	}
+
+	readings[r.Source] = r
+	prev := current
+	switch r.Source {
+	case CorePrimary:
+		current.setCore(r)
+	case HistoryPrimary:
+		current.setHistory(r)
+	}
+	current.UpdatedAt = older(readings[CorePrimary], readings[HistoryPrimary])
+	next := current
+	lock.Unlock()
+
+	if sequencesChanged(prev, next) {
+		publish(next)
+	}
+}
+
+// StateFrom builds a snapshot of the ledger state from the readings of the
+// `core` and `history` sources, for consumers that must explicitly choose
+// which databases they trust.  The network parameters are those of the
+// aggregate snapshot, and UpdatedAt is that of the older of the two readings.
+// A source with no reading contributes zero sequences and a zero UpdatedAt,
+// making the result infinitely stale.
+//
+// StateFrom panics if `core` is not a stellar-core source or `history` is not
+// a horizon source.
+func StateFrom(core, history Source) State {
+	checkSources(core, history)
+
+	lock.RLock()
+	ret := current
+	c := readings[core]
+	h := readings[history]
+	lock.RUnlock()
+
+	ret.setReadings(c, h)
+	return ret
+}
+
+// FreshStateFrom is like StateFrom, but also returns a *StaleStateError if
+// either reading was taken more than `maxAge` ago.
+func FreshStateFrom(maxAge time.Duration, core, history Source) (State, error) {
+	ret := StateFrom(core, history)
+	return ret, checkAge(ret, maxAge, now())
+}
+
+// checkSources panics unless `core` is a stellar-core source and `history` a
+// horizon source.
+func checkSources(core, history Source) {
+	if !core.IsCore() {
+		panic(fmt.Sprintf("ledger: %s is not a core source", core))
+	}
+
+	if !history.IsHistory() {
+		panic(fmt.Sprintf("ledger: %s is not a history source", history))
+	}
+}
+
+// primaryReadings returns the readings of CorePrimary and HistoryPrimary that
+// `s` is made of.
+func (s State) primaryReadings() (core, history Reading) {
+	core = Reading{
+		Source:         CorePrimary,
+		Latest:         s.CoreLatest,
+		Elder:          s.CoreElder,
+		LatestHash:     s.CoreLatestHash,
+		LatestClosedAt: s.CoreLatestClosedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	history = Reading{
+		Source:         HistoryPrimary,
+		Latest:         s.HistoryLatest,
+		Elder:          s.HistoryElder,
+		LatestHash:     s.HistoryLatestHash,
+		LatestClosedAt: s.HistoryLatestClosedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	return
+}
+
+// setReadings replaces both sides of `s` with readings `core` and `history`,
+// taking the time of the older one.
+func (s *State) setReadings(core, history Reading) {
+	s.setCore(core)
+	s.setHistory(history)
+	s.UpdatedAt = older(core, history)
+}
+
+// setCore replaces the stellar-core side of `s` with reading `r`.
+func (s *State) setCore(r Reading) {
+	s.CoreLatest = r.Latest
+	s.CoreElder = r.Elder
+	s.CoreLatestHash = r.LatestHash
+	s.CoreLatestClosedAt = r.LatestClosedAt
+}
+
+// setHistory replaces the horizon side of `s` with reading `r`.
+func (s *State) setHistory(r Reading) {
+	s.HistoryLatest = r.Latest
+	s.HistoryElder = r.Elder
+	s.HistoryLatestHash = r.LatestHash
+	s.HistoryLatestClosedAt = r.LatestClosedAt
+}
+
+// older returns the earlier of the times at which `a` and `b` were taken.
+func older(a, b Reading) time.Time {
+	if a.UpdatedAt.Before(b.UpdatedAt) {
+		return a.UpdatedAt
+	}
+
+	return b.UpdatedAt
+}
+
+// readings holds the latest Reading of each source, guarded by lock.
+var readings = map[Source]Reading{}
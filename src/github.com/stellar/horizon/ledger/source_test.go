@@ -0,0 +1,140 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadings(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return clock }
+	defer func() {
+		now = time.Now
+		Reset()
+	}()
+
+	SetState(State{
+		CoreLatest:     10,
+		CoreElder:      1,
+		CoreLatestHash: "core",
+		HistoryLatest:  9,
+		HistoryElder:   2,
+		BaseFee:        100,
+	})
+
+	// SetState records the primary readings
+	r, ok := CurrentReading(CorePrimary)
+	assert.True(ok)
+	assert.Equal(Reading{Source: CorePrimary, Latest: 10, Elder: 1, LatestHash: "core", UpdatedAt: clock}, r)
+	r, ok = CurrentReading(HistoryPrimary)
+	assert.True(ok)
+	assert.Equal(int32(9), r.Latest)
+	_, ok = CurrentReading(CoreSecondary)
+	assert.False(ok)
+
+	// the sources disagree: a secondary core is ahead, but read a while ago
+	clock = clock.Add(time.Second)
+	SetReading(Reading{
+		Source:         CoreSecondary,
+		Latest:         12,
+		Elder:          5,
+		LatestHash:     "secondary",
+		LatestClosedAt: clock.Add(-2 * time.Minute),
+		UpdatedAt:      clock.Add(-time.Minute),
+	})
+
+	// ...which the aggregate view ignores
+	state := CurrentState()
+	assert.Equal(int32(10), state.CoreLatest)
+	assert.Equal(int32(9), state.HistoryLatest)
+	assert.Equal(clock.Add(-time.Second), state.UpdatedAt)
+
+	// consumers selecting their sources see exactly those readings
+	state = StateFrom(CoreSecondary, HistoryPrimary)
+	assert.Equal(int32(12), state.CoreLatest)
+	assert.Equal(int32(5), state.CoreElder)
+	assert.Equal("secondary", state.CoreLatestHash)
+	assert.Equal(clock.Add(-2*time.Minute), state.CoreLatestClosedAt)
+	assert.Equal(int32(9), state.HistoryLatest)
+	assert.Equal(int32(2), state.HistoryElder)
+	assert.Equal(int32(100), state.BaseFee)
+	assert.Equal(clock.Add(-time.Minute), state.UpdatedAt, "should use the older reading")
+
+	state = StateFrom(CorePrimary, HistoryPrimary)
+	assert.Equal(int32(10), state.CoreLatest)
+	assert.Equal(int32(9), state.HistoryLatest)
+
+	// staleness is judged by the selected readings
+	_, err := FreshStateFrom(5*time.Second, CorePrimary, HistoryPrimary)
+	assert.NoError(err)
+	_, err = FreshStateFrom(5*time.Second, CoreSecondary, HistoryPrimary)
+	assert.Equal(&StaleStateError{Age: time.Minute, MaxAge: 5 * time.Second}, err)
+
+	// a primary reading updates the aggregate view
+	SetReading(Reading{Source: HistoryPrimary, Latest: 10, Elder: 2})
+	state = CurrentState()
+	assert.Equal(int32(10), state.HistoryLatest)
+	assert.Equal(int32(100), state.BaseFee)
+	assert.Equal(clock.Add(-time.Second), state.UpdatedAt, "should use the older primary")
+
+	// an unknown source contributes nothing, and is infinitely stale
+	Reset()
+	state, err = FreshStateFrom(time.Hour, CorePrimary, HistoryPrimary)
+	assert.Equal(int32(0), state.CoreLatest)
+	assert.IsType(&StaleStateError{}, err)
+
+	assert.Panics(func() { StateFrom(HistoryPrimary, HistoryPrimary) })
+	assert.Panics(func() { StateFrom(CorePrimary, CoreSecondary) })
+}
+
+func TestSetReadingPublishes(t *testing.T) {
+	defer Reset()
+
+	SetState(State{CoreLatest: 1, HistoryLatest: 1})
+	ch := Subscribe()
+	defer Unsubscribe(ch)
+
+	SetReading(Reading{Source: CoreSecondary, Latest: 3})
+	select {
+	case <-ch:
+		t.Error("received state for a secondary reading")
+	default:
+	}
+
+	SetReading(Reading{Source: CorePrimary, Latest: 2})
+	select {
+	case s := <-ch:
+		assert.Equal(t, int32(2), s.CoreLatest)
+		assert.Equal(t, int32(1), s.HistoryLatest)
+	default:
+		t.Error("did not receive changed state")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	assert := assert.New(t)
+	defer Reset()
+
+	// a seeded reading is visible, but stale
+	Seed(Reading{Source: HistoryPrimary, Latest: 9, Elder: 2})
+	state := CurrentState()
+	assert.Equal(int32(9), state.HistoryLatest)
+	assert.Equal(int32(2), state.HistoryElder)
+	assert.True(state.UpdatedAt.IsZero())
+
+	_, err := FreshState(time.Hour)
+	assert.IsType(&StaleStateError{}, err)
+	_, err = FreshStateFrom(time.Hour, CorePrimary, HistoryPrimary)
+	assert.IsType(&StaleStateError{}, err)
+
+	// a real reading replaces it, and is not overwritten by later seeds
+	SetState(State{CoreLatest: 12, CoreElder: 1, HistoryLatest: 10, HistoryElder: 2})
+	Seed(Reading{Source: HistoryPrimary, Latest: 9, Elder: 2})
+	state, err = FreshState(time.Hour)
+	assert.NoError(err)
+	assert.Equal(int32(10), state.HistoryLatest)
+}
@@ -0,0 +1,71 @@
+package ledger
+
+import (
+	"sync"
+	"time"
+)
+
+// Stall describes whether horizon's ingestion of stellar-core's ledgers has
+// stalled, as judged by a StallDetector.
+type Stall struct {
+	// Stalled is true when stellar-core has closed ledgers that horizon has not
+	// ingested, and horizon's latest ledger has not advanced for the grace
+	// period.
+	Stalled bool
+
+	// Behind is the number of ledgers by which horizon trails stellar-core.
+	Behind int32
+
+	// Since is the time of the snapshot at which horizon's latest ledger was
+	// last seen to advance while behind stellar-core.  It is zero when horizon
+	// has caught up.
+	Since time.Time
+}
+
+// StallDetector decides whether ingestion has stalled from successive
+// snapshots of the ledger state.  It judges by the movement of stellar-core's
+// latest ledger rather than by the wall clock:  while stellar-core closes no
+// new ledgers, such as while the network is quiet or stellar-core is down for
+// maintenance, horizon has nothing to ingest and is never stalled.  Only once
+// stellar-core has advanced past horizon's latest ledger, and horizon's latest
+// ledger then stays put for Grace, is ingestion stalled.
+type StallDetector struct {
+	// Grace is how long horizon's latest ledger may go without advancing
+	// while behind stellar-core before ingestion is considered stalled.
+	Grace time.Duration
+
+	lock          sync.Mutex
+	current       Stall
+	historyLatest int32
+}
+
+// Observe updates the detector with the snapshot `s`, returning the resulting
+// state.  Snapshots must be observed in the order they were taken.
+func (d *StallDetector) Observe(s State) Stall {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if s.HistoryLatest >= s.CoreLatest {
+		d.current = Stall{}
+	} else {
+		// restart the grace period whenever horizon falls behind or makes
+		// progress catching up
+		if d.current.Since.IsZero() || s.HistoryLatest != d.historyLatest {
+			d.current.Since = s.UpdatedAt
+		}
+
+		d.current.Behind = s.CoreLatest - s.HistoryLatest
+		d.current.Stalled = s.UpdatedAt.Sub(d.current.Since) >= d.Grace
+	}
+
+	d.historyLatest = s.HistoryLatest
+	return d.current
+}
+
+// Current returns the state resulting from the most recently observed
+// snapshot.
+func (d *StallDetector) Current() Stall {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.current
+}
@@ -0,0 +1,61 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStallDetector(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC)
+	d := &StallDetector{Grace: time.Minute}
+	observe := func(after time.Duration, core, history int32) Stall {
+		return d.Observe(State{
+			CoreLatest:    core,
+			HistoryLatest: history,
+			UpdatedAt:     start.Add(after),
+		})
+	}
+
+	// caught up
+	assert.Equal(Stall{}, observe(0, 10, 10))
+
+	// a quiet network, or stellar-core down for maintenance, closes no ledgers
+	// however long it lasts
+	assert.Equal(Stall{}, observe(10*time.Minute, 10, 10))
+
+	// stellar-core advances, and horizon has yet to ingest the new ledger
+	s := observe(11*time.Minute, 11, 10)
+	assert.False(s.Stalled)
+	assert.Equal(int32(1), s.Behind)
+	assert.Equal(start.Add(11*time.Minute), s.Since)
+
+	// horizon catching up, however slowly, restarts the grace period
+	s = observe(11*time.Minute+50*time.Second, 20, 11)
+	assert.False(s.Stalled)
+	s = observe(12*time.Minute+40*time.Second, 30, 12)
+	assert.False(s.Stalled)
+	assert.Equal(int32(18), s.Behind)
+	assert.Equal(start.Add(12*time.Minute+40*time.Second), s.Since)
+
+	// but horizon not advancing for the grace period is a stall
+	s = observe(13*time.Minute+39*time.Second, 31, 12)
+	assert.False(s.Stalled)
+	s = observe(13*time.Minute+40*time.Second, 31, 12)
+	assert.True(s.Stalled)
+	assert.Equal(int32(19), s.Behind)
+	assert.Equal(s, d.Current())
+
+	// which lasts until horizon advances again
+	s = observe(20*time.Minute, 40, 12)
+	assert.True(s.Stalled)
+	s = observe(20*time.Minute+5*time.Second, 40, 13)
+	assert.False(s.Stalled)
+
+	// and is over once horizon catches up
+	assert.Equal(Stall{}, observe(25*time.Minute, 40, 40))
+	assert.Equal(Stall{}, d.Current())
+}
@@ -0,0 +1,66 @@
+package ledger
+
+import (
+	"sync"
+)
+
+// Subscribe returns a channel that receives the new ledger state each time
+// SetState changes any of the ledger sequences it holds.  Publishing never
+// blocks:  the channel buffers a single state, and a subscriber that falls
+// behind has its buffered state replaced by the newest one, so that it always
+// eventually receives the latest state without seeing every intermediate one.
+//
+// Call Unsubscribe with the returned channel once it is no longer needed.
+func Subscribe() <-chan State {
+	ch := make(chan State, 1)
+
+	subLock.Lock()
+	subscribers[ch] = ch
+	subLock.Unlock()
+
+	return ch
+}
+
+// Unsubscribe stops the delivery of states to `ch`, a channel previously
+// returned by Subscribe, and closes it.  It is safe to call while states are
+// being published, and calling it more than once has no effect.
+func Unsubscribe(ch <-chan State) {
+	subLock.Lock()
+	defer subLock.Unlock()
+
+	send, ok := subscribers[ch]
+	if !ok {
+		return
+	}
+
+	delete(subscribers, ch)
+	close(send)
+}
+
+// publish delivers `next` to every subscriber, replacing any state still
+// buffered for a subscriber that has not yet received it.  The caller must
+// hold subLock.
+func publish(next State) {
+	for _, ch := range subscribers {
+		// only publish sends on `ch`, and it holds subLock, so once any stale
+		// state is drained there is always room for `next`.
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- next
+	}
+}
+
+// sequencesChanged returns true if any of the ledger sequences of `prev` and
+// `next` differ.
+func sequencesChanged(prev, next State) bool {
+	return prev.CoreLatest != next.CoreLatest ||
+		prev.CoreElder != next.CoreElder ||
+		prev.HistoryLatest != next.HistoryLatest ||
+		prev.HistoryElder != next.HistoryElder
+}
+
+var subLock sync.Mutex
+var subscribers = map[<-chan State]chan State{}
@@ -0,0 +1,144 @@
+package log
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Results recorded on an AuditEvent.
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditEvent is the record of a single administrative operation, such as
+// reingesting ledgers or changing a log level.
+type AuditEvent struct {
+	// Actor identifies who requested the operation.
+	Actor string `json:"actor"`
+	// Action names the operation.
+	Action     string                 `json:"action"`
+	Parameters map[string]interface{} `json:"parameters"`
+	StartedAt  time.Time              `json:"started_at"`
+	EndedAt    time.Time              `json:"ended_at"`
+	// Result is either AuditSuccess or AuditFailure.
+	Result string `json:"result"`
+	// Error is the error the operation failed with, if any.
+	Error string `json:"error,omitempty"`
+
+	auditor *Auditor
+}
+
+// End completes the event with the outcome of the operation, which failed
+// if `err` is not nil, and writes it to its auditor's sink.
+func (e *AuditEvent) End(err error) {
+	e.EndedAt = time.Now()
+	e.Result = AuditSuccess
+	if err != nil {
+		e.Result = AuditFailure
+		e.Error = err.Error()
+	}
+
+	e.auditor.write(e)
+}
+
+// AuditSink persists audit events.
+type AuditSink interface {
+	WriteAudit(*AuditEvent) error
+}
+
+// Auditor records administrative operations as AuditEvents, written to a sink
+// separate from horizon's log.  Failing to write an event never fails the
+// operation it records:  the failure is logged instead.
+type Auditor struct {
+	// Sink receives each completed event.  A nil sink discards them.
+	Sink AuditSink
+	// Logger receives the errors returned by Sink.  A nil logger uses
+	// DefaultLogger.
+	Logger *Entry
+}
+
+// DefaultAuditor is the auditor used by Audit.  It discards events until a
+// sink is configured.
+var DefaultAuditor = &Auditor{}
+
+// Begin starts the event recording `actor` performing `action` with
+// `params`.  Call End on the result once the action completes.
+func (a *Auditor) Begin(
+	actor string,
+	action string,
+	params map[string]interface{},
+) *AuditEvent {
+	return &AuditEvent{
+		Actor:      actor,
+		Action:     action,
+		Parameters: params,
+		StartedAt:  time.Now(),
+		auditor:    a,
+	}
+}
+
+func (a *Auditor) write(e *AuditEvent) {
+	if a.Sink == nil {
+		return
+	}
+
+	err := a.Sink.WriteAudit(e)
+	if err == nil {
+		return
+	}
+
+	l := a.Logger
+	if l == nil {
+		l = DefaultLogger
+	}
+
+	l.WithError(err).
+		WithField("audit_action", e.Action).
+		WithField("audit_actor", e.Actor).
+		Error("audit: failed to record event")
+}
+
+// Audit calls Begin on DefaultAuditor.
+func Audit(
+	actor string,
+	action string,
+	params map[string]interface{},
+) *AuditEvent {
+	return DefaultAuditor.Begin(actor, action, params)
+}
+
+// AuditWriter is an AuditSink writing each event to W as a line of JSON.
+type AuditWriter struct {
+	W io.Writer
+
+	lock sync.Mutex
+}
+
+// OpenAuditFile opens the file at `path` for appending, creating it if
+// needed, and returns a sink writing to it.
+func OpenAuditFile(path string) (*AuditWriter, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditWriter{W: f}, nil
+}
+
+// WriteAudit implements AuditSink.
+func (w *AuditWriter) WriteAudit(e *AuditEvent) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	_, err = w.W.Write(append(line, '\n'))
+	return err
+}
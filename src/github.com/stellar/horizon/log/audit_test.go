@@ -0,0 +1,73 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type failingSink struct{}
+
+func (failingSink) WriteAudit(*AuditEvent) error {
+	return errors.New("disk full")
+}
+
+func TestAuditor(t *testing.T) {
+	Convey("Auditor", t, func() {
+		output := new(bytes.Buffer)
+		l, _ := New()
+		l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+		l.Logger.Out = output
+
+		sink := new(bytes.Buffer)
+		a := &Auditor{Sink: &AuditWriter{W: sink}, Logger: l}
+
+		Convey("writes a line of JSON for each event", func() {
+			a.Begin("admin", "reingest", map[string]interface{}{"ledgers": "all"}).
+				End(nil)
+			a.Begin("admin", "reingest", nil).
+				End(errors.New("boom"))
+
+			lines := bytes.Split(bytes.TrimSpace(sink.Bytes()), []byte("\n"))
+			So(len(lines), ShouldEqual, 2)
+
+			var e AuditEvent
+			So(json.Unmarshal(lines[0], &e), ShouldBeNil)
+			So(e.Actor, ShouldEqual, "admin")
+			So(e.Action, ShouldEqual, "reingest")
+			So(e.Parameters["ledgers"], ShouldEqual, "all")
+			So(e.Result, ShouldEqual, AuditSuccess)
+			So(e.Error, ShouldEqual, "")
+			So(e.StartedAt.IsZero(), ShouldBeFalse)
+			So(e.EndedAt.Before(e.StartedAt), ShouldBeFalse)
+
+			e = AuditEvent{}
+			So(json.Unmarshal(lines[1], &e), ShouldBeNil)
+			So(e.Result, ShouldEqual, AuditFailure)
+			So(e.Error, ShouldEqual, "boom")
+		})
+
+		Convey("does not write events to the log", func() {
+			a.Begin("admin", "reingest", nil).End(nil)
+			So(output.Len(), ShouldEqual, 0)
+		})
+
+		Convey("logs sink failures", func() {
+			a.Sink = failingSink{}
+			a.Begin("admin", "reingest", nil).End(nil)
+			So(output.String(), ShouldContainSubstring, "audit: failed to record event")
+			So(output.String(), ShouldContainSubstring, "err=\"disk full\"")
+			So(output.String(), ShouldContainSubstring, "audit_action=reingest")
+		})
+
+		Convey("discards events without a sink", func() {
+			a.Sink = nil
+			a.Begin("admin", "reingest", nil).End(nil)
+			So(output.Len(), ShouldEqual, 0)
+		})
+	})
+}
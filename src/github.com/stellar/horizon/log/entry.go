@@ -1,72 +1,133 @@
 package log
 
 import (
+	"fmt"
+
 	"github.com/Sirupsen/logrus"
-	"github.com/stellar/horizon/errors"
+	ge "github.com/go-errors/errors"
 )
 
 type Entry struct {
 	logrus.Entry
+
+	// subsystem is the subsystem the entry logs for, if it was derived from a
+	// subsystem's logger (see For).
+	subsystem *subsystem
 }
 
 func (e *Entry) WithField(key string, value interface{}) *Entry {
-	return &Entry{*e.Entry.WithField(key, value)}
+	return &Entry{*e.Entry.WithField(key, value), e.subsystem}
 }
 
 func (e *Entry) WithFields(fields F) *Entry {
-	return &Entry{*e.Entry.WithFields(logrus.Fields(fields))}
+	return &Entry{*e.Entry.WithFields(logrus.Fields(fields)), e.subsystem}
 }
 
 func (e *Entry) WithStack(err error) *Entry {
-	return e.WithField("stack", errors.Stack(err))
+	stack := "unknown"
+	if se, ok := err.(*ge.Error); ok {
+		stack = string(se.Stack())
+	}
+
+	return e.WithField("stack", stack)
 }
 
 // Debugf logs a message at the debug severity.
 func (e *Entry) Debugf(format string, args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.DebugLevel, fmt.Sprintf(format, args...))
+		return
+	}
+
 	e.Entry.Debugf(format, args...)
 }
 
 // Debug logs a message at the debug severity.
 func (e *Entry) Debug(args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.DebugLevel, fmt.Sprint(args...))
+		return
+	}
+
 	e.Entry.Debug(args...)
 }
 
 // Infof logs a message at the Info severity.
 func (e *Entry) Infof(format string, args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.InfoLevel, fmt.Sprintf(format, args...))
+		return
+	}
+
 	e.Entry.Infof(format, args...)
 }
 
 // Info logs a message at the Info severity.
 func (e *Entry) Info(args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.InfoLevel, fmt.Sprint(args...))
+		return
+	}
+
 	e.Entry.Info(args...)
 }
 
 // Warnf logs a message at the Warn severity.
 func (e *Entry) Warnf(format string, args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.WarnLevel, fmt.Sprintf(format, args...))
+		return
+	}
+
 	e.Entry.Warnf(format, args...)
 }
 
 // Warn logs a message at the Warn severity.
 func (e *Entry) Warn(args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.WarnLevel, fmt.Sprint(args...))
+		return
+	}
+
 	e.Entry.Warn(args...)
 }
 
 // Errorf logs a message at the Error severity.
 func (e *Entry) Errorf(format string, args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.ErrorLevel, fmt.Sprintf(format, args...))
+		return
+	}
+
 	e.Entry.Errorf(format, args...)
 }
 
 // Error logs a message at the Error severity.
 func (e *Entry) Error(args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.ErrorLevel, fmt.Sprint(args...))
+		return
+	}
+
 	e.Entry.Error(args...)
 }
 
 // Panicf logs a message at the Panic severity.
 func (e *Entry) Panicf(format string, args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.PanicLevel, fmt.Sprintf(format, args...))
+		return
+	}
+
 	e.Entry.Panicf(format, args...)
 }
 
 // Panic logs a message at the Panic severity.
 func (e *Entry) Panic(args ...interface{}) {
+	if e.subsystem != nil {
+		e.subsystem.log(&e.Entry, logrus.PanicLevel, fmt.Sprint(args...))
+		return
+	}
+
 	e.Entry.Panic(args...)
 }
@@ -0,0 +1,165 @@
+package log
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	ge "github.com/go-errors/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ErrorKey is the field under which WithError records an error.
+	ErrorKey = "err"
+	// StackKey is the field under which WithError records an error's stack
+	// trace.
+	StackKey = "stack"
+)
+
+// MaxStackDepth is the number of frames of an error's stack trace recorded by
+// WithError.  Zero disables stack traces.
+var MaxStackDepth = 10
+
+// StackTrace is the stack trace of an error, as recorded by WithError.  Each
+// element describes a single frame, innermost first.  It is written as a list
+// by the JSON format and as a single comma separated value by the text format.
+type StackTrace []string
+
+func (s StackTrace) String() string {
+	return strings.Join(s, ",")
+}
+
+// stackTracer is implemented by errors created or wrapped by
+// github.com/pkg/errors.
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// causer is implemented by errors wrapped by github.com/pkg/errors.
+type causer interface {
+	Cause() error
+}
+
+// fielder is implemented by errors that carry structured context about the
+// failure.  It matches horizon/errors.Fielder.
+type fielder interface {
+	Fields() map[string]interface{}
+}
+
+// WithError returns an entry that records `err` under ErrorKey.  If `err`, or
+// any error it wraps, carries a stack trace, the trace closest to where the
+// error originated is recorded under StackKey, truncated to MaxStackDepth
+// frames.  Stack traces are recorded from errors created by
+// github.com/pkg/errors and github.com/go-errors/errors.  Fields carried by
+// the errors in the chain, such as the ledger being ingested, are recorded
+// too, unless the entry already has them.
+func (e *Entry) WithError(err error) *Entry {
+	result := e.WithField(ErrorKey, err)
+
+	if fields := fieldsOf(err); len(fields) > 0 {
+		for k := range fields {
+			if _, ok := result.Data[k]; ok {
+				delete(fields, k)
+			}
+		}
+		result = result.WithFields(fields)
+	}
+
+	stack := stackTraceOf(err, MaxStackDepth)
+	if len(stack) == 0 {
+		return result
+	}
+
+	return result.WithField(StackKey, stack)
+}
+
+// WithError calls WithError on the default logger.
+func WithError(err error) *Entry {
+	return DefaultLogger.WithError(err)
+}
+
+// fieldsOf returns the fields carried by `err` and the errors it wraps,
+// preferring those of the outermost where two carry the same field.
+func fieldsOf(err error) F {
+	var result F
+
+	for err != nil {
+		if f, ok := err.(fielder); ok {
+			for k, v := range f.Fields() {
+				if result == nil {
+					result = F{}
+				}
+				if _, set := result[k]; !set {
+					result[k] = v
+				}
+			}
+		}
+
+		if ges, ok := err.(*ge.Error); ok {
+			err = ges.Err
+			continue
+		}
+
+		c, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = c.Cause()
+	}
+
+	return result
+}
+
+// stackTraceOf returns the innermost stack trace carried by `err` or the
+// errors it wraps, limited to `depth` frames.
+func stackTraceOf(err error, depth int) StackTrace {
+	if depth <= 0 {
+		return nil
+	}
+
+	var found errors.StackTrace
+	for err != nil {
+		if st, ok := err.(stackTracer); ok {
+			found = st.StackTrace()
+		}
+
+		// go-errors does not wrap other stack carrying errors, so an error
+		// from it is always the innermost.
+		if ges, ok := err.(*ge.Error); ok {
+			return goErrorsStack(ges, depth)
+		}
+
+		c, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = c.Cause()
+	}
+
+	if len(found) > depth {
+		found = found[:depth]
+	}
+
+	result := make(StackTrace, len(found))
+	for i, f := range found {
+		result[i] = fmt.Sprintf("%n(%s:%d)", f, f, f)
+	}
+
+	return result
+}
+
+// goErrorsStack returns the stack trace of `err`, limited to `depth` frames.
+func goErrorsStack(err *ge.Error, depth int) StackTrace {
+	frames := err.StackFrames()
+	if len(frames) > depth {
+		frames = frames[:depth]
+	}
+
+	result := make(StackTrace, len(frames))
+	for i, f := range frames {
+		result[i] = fmt.Sprintf("%s(%s:%d)", f.Name, filepath.Base(f.File), f.LineNumber)
+	}
+
+	return result
+}
@@ -0,0 +1,133 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	ge "github.com/go-errors/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func failingQuery() error {
+	return errors.New("pq: relation does not exist")
+}
+
+func loadLedger() error {
+	return errors.Wrap(failingQuery(), "failed to load cur ledger")
+}
+
+// ledgerFailure is an error carrying fields, like ingest.LedgerError.
+type ledgerFailure struct {
+	seq int32
+	err error
+}
+
+func (e *ledgerFailure) Error() string { return e.err.Error() }
+func (e *ledgerFailure) Cause() error  { return e.err }
+func (e *ledgerFailure) Fields() map[string]interface{} {
+	return map[string]interface{}{"ledger": e.seq, "phase": "load"}
+}
+
+func TestWithError(t *testing.T) {
+	Convey("WithError", t, func() {
+		output := new(bytes.Buffer)
+		defer func(depth int) { MaxStackDepth = depth }(MaxStackDepth)
+
+		Convey("records the error without a stack if it carries none", func() {
+			l, _ := New()
+			l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+			l.Logger.Out = output
+
+			l.WithError(fmt.Errorf("no stack")).Error("failed")
+			So(output.String(), ShouldContainSubstring, `err="no stack"`)
+			So(output.String(), ShouldNotContainSubstring, "stack=")
+		})
+
+		Convey("renders the innermost stack in the text format", func() {
+			l, _ := New()
+			l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+			l.Logger.Out = output
+
+			l.WithError(loadLedger()).Error("failed")
+			So(output.String(), ShouldContainSubstring, "err=\"failed to load cur ledger: pq: relation does not exist\"")
+			So(output.String(), ShouldContainSubstring, "stack=failingQuery(error_test.go:")
+			So(output.String(), ShouldContainSubstring, ",loadLedger(error_test.go:")
+		})
+
+		Convey("records the stack of a go-errors error", func() {
+			l, _ := New()
+			l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+			l.Logger.Out = output
+
+			l.WithError(ge.New("broken")).Error("failed")
+			So(output.String(), ShouldContainSubstring, "err=broken")
+			So(output.String(), ShouldContainSubstring, "(error_test.go:")
+		})
+
+		Convey("renders the stack as a list in the JSON format", func() {
+			l, _ := NewWithFormat(JSONFormat)
+			l.Logger.Out = output
+
+			l.WithError(loadLedger()).Error("failed")
+
+			var entry struct {
+				Err   string   `json:"err"`
+				Stack []string `json:"stack"`
+			}
+			So(json.Unmarshal(output.Bytes(), &entry), ShouldBeNil)
+			So(entry.Err, ShouldEqual, "failed to load cur ledger: pq: relation does not exist")
+			So(len(entry.Stack), ShouldBeGreaterThan, 1)
+			So(entry.Stack[0], ShouldStartWith, "failingQuery(error_test.go:")
+			So(entry.Stack[1], ShouldStartWith, "loadLedger(error_test.go:")
+		})
+
+		Convey("truncates the stack to MaxStackDepth", func() {
+			l, _ := NewWithFormat(JSONFormat)
+			l.Logger.Out = output
+			MaxStackDepth = 1
+
+			l.WithError(loadLedger()).Error("failed")
+
+			var entry struct {
+				Stack []string `json:"stack"`
+			}
+			So(json.Unmarshal(output.Bytes(), &entry), ShouldBeNil)
+			So(len(entry.Stack), ShouldEqual, 1)
+		})
+
+		Convey("records the fields carried by errors in the chain", func() {
+			l, _ := New()
+			l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+			l.Logger.Out = output
+
+			err := errors.Wrap(&ledgerFailure{seq: 12, err: failingQuery()}, "session failed")
+			l.WithError(err).Error("failed")
+			So(output.String(), ShouldContainSubstring, "ledger=12")
+			So(output.String(), ShouldContainSubstring, "phase=load")
+		})
+
+		Convey("does not override the entry's own fields", func() {
+			l, _ := New()
+			l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+			l.Logger.Out = output
+
+			err := &ledgerFailure{seq: 12, err: failingQuery()}
+			l.WithField("ledger", 10).WithError(err).Error("failed")
+			So(output.String(), ShouldContainSubstring, "ledger=10")
+			So(output.String(), ShouldNotContainSubstring, "ledger=12")
+		})
+
+		Convey("omits the stack when MaxStackDepth is zero", func() {
+			l, _ := NewWithFormat(JSONFormat)
+			l.Logger.Out = output
+			MaxStackDepth = 0
+
+			l.WithError(loadLedger()).Error("failed")
+			So(output.String(), ShouldNotContainSubstring, `"stack"`)
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package log
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// Format identifies the format in which a logger writes its entries.
+type Format int
+
+const (
+	// TextFormat writes each entry as a line of key=value pairs.  It is the
+	// default.
+	TextFormat Format = iota
+	// JSONFormat writes each entry as a JSON object on a line of its own,
+	// suitable for log aggregators.
+	JSONFormat
+)
+
+// ParseFormat returns the Format named `name`, either "text" or "json".
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "text":
+		return TextFormat, nil
+	case "json":
+		return JSONFormat, nil
+	default:
+		return TextFormat, fmt.Errorf("not a valid log format: %q", name)
+	}
+}
+
+func (f Format) String() string {
+	switch f {
+	case TextFormat:
+		return "text"
+	case JSONFormat:
+		return "json"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// SetFormat changes the format in which entries are written by the logger
+// underlying `e`, which is shared by every entry derived from the same call to
+// New.  Subsystem loggers (see For) write through DefaultLogger, and so change
+// its format.  A formatter already writing `format` is kept, along with any options
+// set on it.
+func (e *Entry) SetFormat(format Format) {
+	if e.subsystem != nil {
+		DefaultLogger.SetFormat(format)
+		return
+	}
+
+	if current, ok := formatOf(e.Logger.Formatter); ok && current == format {
+		return
+	}
+
+	e.Logger.Formatter = newFormatter(format)
+}
+
+// formatOf returns the Format written by `f`.  ok is false if `f` was not
+// created by newFormatter.
+func formatOf(f logrus.Formatter) (format Format, ok bool) {
+	switch f.(type) {
+	case *logrus.TextFormatter:
+		return TextFormat, true
+	case errorsAsStrings:
+		return JSONFormat, true
+	default:
+		return TextFormat, false
+	}
+}
+
+// newFormatter returns the logrus formatter implementing `format`.
+func newFormatter(format Format) logrus.Formatter {
+	switch format {
+	case JSONFormat:
+		return errorsAsStrings{&logrus.JSONFormatter{}}
+	default:
+		return &logrus.TextFormatter{}
+	}
+}
+
+// errorsAsStrings wraps a formatter such that fields whose values are errors
+// are formatted as the errors' messages.  Without it, encoding/json would
+// serialize most errors, whose fields are unexported, as an empty object.
+type errorsAsStrings struct {
+	logrus.Formatter
+}
+
+func (f errorsAsStrings) Format(entry *logrus.Entry) ([]byte, error) {
+	var copied bool
+
+	for k, v := range entry.Data {
+		err, ok := v.(error)
+		if !ok {
+			continue
+		}
+
+		// entry.Data may be shared with the entry it was derived from, so it
+		// must not be modified.
+		if !copied {
+			entry = copyEntry(entry)
+			copied = true
+		}
+
+		entry.Data[k] = err.Error()
+	}
+
+	return f.Formatter.Format(entry)
+}
+
+// copyEntry returns a copy of `entry` with its own copy of entry.Data.
+func copyEntry(entry *logrus.Entry) *logrus.Entry {
+	ret := *entry
+	ret.Data = make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		ret.Data[k] = v
+	}
+
+	return &ret
+}
@@ -26,14 +26,22 @@ func init() {
 	DefaultLogger, DefaultMetrics = New()
 }
 
-// New creates a new logger according to horizon specifications.
+// New creates a new logger according to horizon specifications, writing
+// entries in TextFormat.
 func New() (result *Entry, m *Metrics) {
+	return NewWithFormat(TextFormat)
+}
+
+// NewWithFormat is like New, but the returned logger writes entries in
+// `format`.
+func NewWithFormat(format Format) (result *Entry, m *Metrics) {
 	m = NewMetrics()
 	l := logrus.New()
 	l.Level = logrus.WarnLevel
+	l.Formatter = newFormatter(format)
 	l.Hooks.Add(m)
 
-	result = &Entry{*logrus.NewEntry(l).WithField("pid", os.Getpid())}
+	result = &Entry{Entry: *logrus.NewEntry(l).WithField("pid", os.Getpid())}
 	return
 }
 
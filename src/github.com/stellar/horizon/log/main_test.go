@@ -2,6 +2,7 @@ package log
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -158,6 +159,55 @@ func TestLogPackage(t *testing.T) {
 		})
 	})
 
+	Convey("Formats", t, func() {
+		output := new(bytes.Buffer)
+
+		Convey("ParseFormat", func() {
+			f, err := ParseFormat("json")
+			So(err, ShouldBeNil)
+			So(f, ShouldEqual, JSONFormat)
+
+			f, err = ParseFormat("text")
+			So(err, ShouldBeNil)
+			So(f, ShouldEqual, TextFormat)
+
+			_, err = ParseFormat("xml")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("JSON entries keep their fields, with errors as strings", func() {
+			l, _ := NewWithFormat(JSONFormat)
+			l.Logger.Out = output
+
+			parent := l.WithField("err", errors.New("multi\nline"))
+			parent.WithField("ledger", 3).Error("failed")
+
+			var entry map[string]interface{}
+			So(json.Unmarshal(output.Bytes(), &entry), ShouldBeNil)
+			So(entry["msg"], ShouldEqual, "failed")
+			So(entry["level"], ShouldEqual, "error")
+			So(entry["ledger"], ShouldEqual, float64(3))
+			So(entry["err"], ShouldEqual, "multi\nline")
+
+			// the entry the error was attached to is untouched
+			_, ok := parent.Data["err"].(error)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("SetFormat switches an existing logger", func() {
+			l, _ := New()
+			l.Logger.Out = output
+			l.SetFormat(JSONFormat)
+			l.Warn("hello")
+			So(output.String(), ShouldStartWith, "{")
+
+			l.SetFormat(TextFormat)
+			output.Reset()
+			l.Warn("hello")
+			So(output.String(), ShouldContainSubstring, "msg=hello")
+		})
+	})
+
 	Convey("Metrics", t, func() {
 		output := new(bytes.Buffer)
 		l, m := New()
@@ -0,0 +1,134 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ErrFileClosed is returned when writing to a closed RotatingFile.
+var ErrFileClosed = errors.New("log: file closed")
+
+// RotatingFile is an io.Writer appending to the file at Path.  Once a write
+// would grow the file past MaxSize bytes, the file is rotated first:  it is
+// renamed to Path.1, and any previous Path.1 to Path.2, and so on, keeping at
+// most MaxBackups rotated files.  Should rotation fail, writes carry on to the
+// file at Path, and the next write retries it.  Writes are safe to make
+// concurrently, and each is written whole to a single file.
+type RotatingFile struct {
+	Path       string
+	MaxSize    int64
+	MaxBackups int
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+// OpenRotatingFile opens the file at `path` for appending, creating it if
+// needed.
+func OpenRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	r := &RotatingFile{Path: path, MaxSize: maxSize, MaxBackups: maxBackups}
+
+	err := r.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Write implements io.Writer.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.file == nil {
+		return 0, ErrFileClosed
+	}
+
+	if r.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.MaxSize {
+		err := r.rotate()
+		if r.file == nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file.  Later writes fail.
+func (r *RotatingFile) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open opens the file at r.Path, recording its current size.
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotate shifts the rotated files along, dropping the oldest, moves the
+// current file into their place and opens a new one.  The file at r.Path is
+// reopened whether or not the files could be shifted along, so that r.file is
+// only left nil when it cannot be.
+func (r *RotatingFile) rotate() error {
+	err := r.file.Close()
+	r.file = nil
+	if err == nil {
+		err = r.shift()
+	}
+
+	openErr := r.open()
+	if openErr != nil {
+		return openErr
+	}
+
+	return err
+}
+
+// shift shifts the rotated files along, dropping the oldest, and moves the
+// closed current file into their place.
+func (r *RotatingFile) shift() (err error) {
+	if r.MaxBackups <= 0 {
+		err = os.Remove(r.Path)
+	} else {
+		for i := r.MaxBackups - 1; i >= 1; i-- {
+			err = os.Rename(r.backup(i), r.backup(i+1))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		err = os.Rename(r.Path, r.backup(1))
+	}
+	return
+}
+
+// backup returns the path of the i-th most recent rotated file.
+func (r *RotatingFile) backup(i int) string {
+	return fmt.Sprintf("%s.%d", r.Path, i)
+}
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRotatingFile(t *testing.T) {
+	Convey("RotatingFile", t, func() {
+		dir, err := ioutil.TempDir("", "horizon-log")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "horizon.log")
+
+		Convey("rotates without losing or splitting lines written concurrently", func() {
+			f, err := OpenRotatingFile(path, 4096, 1000)
+			So(err, ShouldBeNil)
+
+			l, _ := New()
+			l.Logger.Level = logrus.InfoLevel
+			l.AddSink(NewSink(f, JSONFormat, logrus.InfoLevel))
+
+			const writers, lines = 8, 200
+			var wg sync.WaitGroup
+			for w := 0; w < writers; w++ {
+				wg.Add(1)
+				go func(w int) {
+					defer wg.Done()
+					for i := 0; i < lines; i++ {
+						l.WithField("writer", w).WithField("line", i).Info("session progress")
+					}
+				}(w)
+			}
+			wg.Wait()
+			So(f.Close(), ShouldBeNil)
+
+			paths, err := filepath.Glob(path + "*")
+			So(err, ShouldBeNil)
+			So(len(paths), ShouldBeGreaterThan, 1)
+
+			seen := map[string]bool{}
+			for _, p := range paths {
+				info, err := os.Stat(p)
+				So(err, ShouldBeNil)
+				So(info.Size(), ShouldBeLessThanOrEqualTo, 4096)
+
+				r, err := os.Open(p)
+				So(err, ShouldBeNil)
+				s := bufio.NewScanner(r)
+				for s.Scan() {
+					var entry struct {
+						Writer int    `json:"writer"`
+						Line   int    `json:"line"`
+						Msg    string `json:"msg"`
+					}
+					So(json.Unmarshal(s.Bytes(), &entry), ShouldBeNil)
+					So(entry.Msg, ShouldEqual, "session progress")
+
+					key := fmt.Sprintf("%d/%d", entry.Writer, entry.Line)
+					So(seen[key], ShouldBeFalse)
+					seen[key] = true
+				}
+				So(s.Err(), ShouldBeNil)
+				r.Close()
+			}
+
+			So(len(seen), ShouldEqual, writers*lines)
+		})
+
+		Convey("keeps at most MaxBackups rotated files", func() {
+			f, err := OpenRotatingFile(path, 10, 2)
+			So(err, ShouldBeNil)
+
+			for i := 0; i < 5; i++ {
+				_, err := fmt.Fprintf(f, "line %d\n", i)
+				So(err, ShouldBeNil)
+			}
+			So(f.Close(), ShouldBeNil)
+
+			current, _ := ioutil.ReadFile(path)
+			So(string(current), ShouldEqual, "line 4\n")
+			previous, _ := ioutil.ReadFile(path + ".1")
+			So(string(previous), ShouldEqual, "line 3\n")
+			oldest, _ := ioutil.ReadFile(path + ".2")
+			So(string(oldest), ShouldEqual, "line 2\n")
+
+			_, err = os.Stat(path + ".3")
+			So(os.IsNotExist(err), ShouldBeTrue)
+
+			_, err = f.Write([]byte("late\n"))
+			So(err, ShouldEqual, ErrFileClosed)
+		})
+
+		Convey("carries on writing to the current file when rotation fails", func() {
+			// a directory in the place of the rotated file cannot be replaced
+			So(os.MkdirAll(filepath.Join(path+".1", "taken"), 0755), ShouldBeNil)
+
+			f, err := OpenRotatingFile(path, 10, 1)
+			So(err, ShouldBeNil)
+
+			for i := 0; i < 3; i++ {
+				_, err := fmt.Fprintf(f, "line %d\n", i)
+				So(err, ShouldBeNil)
+			}
+
+			current, _ := ioutil.ReadFile(path)
+			So(string(current), ShouldEqual, "line 0\nline 1\nline 2\n")
+
+			// and rotates once it can
+			So(os.RemoveAll(path+".1"), ShouldBeNil)
+			_, err = fmt.Fprintln(f, "line 3")
+			So(err, ShouldBeNil)
+			So(f.Close(), ShouldBeNil)
+
+			current, _ = ioutil.ReadFile(path)
+			So(string(current), ShouldEqual, "line 3\n")
+			previous, _ := ioutil.ReadFile(path + ".1")
+			So(string(previous), ShouldEqual, "line 0\nline 1\nline 2\n")
+		})
+
+		Convey("appends to an existing file", func() {
+			So(ioutil.WriteFile(path, []byte("before\n"), 0644), ShouldBeNil)
+
+			f, err := OpenRotatingFile(path, 1024, 1)
+			So(err, ShouldBeNil)
+			fmt.Fprintln(f, "after")
+			f.Close()
+
+			contents, _ := ioutil.ReadFile(path)
+			So(string(contents), ShouldEqual, "before\nafter\n")
+		})
+	})
+}
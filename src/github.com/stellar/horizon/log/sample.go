@@ -0,0 +1,67 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// Sampler limits how often a repetitive message is logged:  at most once per
+// interval, with the first occurrence logged immediately.  Occurrences in
+// between are suppressed but counted, and the next entry logged reports their
+// number in its "suppressed" field, such that the suppression is visible in
+// the log.  Use one Sampler per logging statement.  A Sampler is safe for
+// concurrent use.
+//
+//	var inProgress = log.Every(time.Minute)
+//
+//	if l, ok := inProgress.Sample(log.WithField("ledger", seq)); ok {
+//		l.Info("ingest: already in progress")
+//	}
+type Sampler struct {
+	interval time.Duration
+
+	// now returns the current time.  It is replaced in tests.
+	now func() time.Time
+
+	lock       sync.Mutex
+	next       time.Time
+	suppressed int
+}
+
+// Every returns a Sampler that allows a message to be logged at most once per
+// `interval`.
+func Every(interval time.Duration) *Sampler {
+	return &Sampler{interval: interval, now: time.Now}
+}
+
+// Sample reports whether an occurrence of the sampled message should be
+// logged now.  If so, it returns `e` with the "suppressed" field set to the
+// number of occurrences suppressed since the last one logged, and the
+// "sample_interval" field set to the sampler's interval.  Otherwise, the
+// occurrence is counted as suppressed and ok is false.
+func (s *Sampler) Sample(e *Entry) (l *Entry, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	if now.Before(s.next) {
+		s.suppressed++
+		return nil, false
+	}
+
+	l = e.WithFields(F{
+		"suppressed":      s.suppressed,
+		"sample_interval": s.interval.String(),
+	})
+	s.next = now.Add(s.interval)
+	s.suppressed = 0
+	return l, true
+}
+
+// Suppressed returns the number of occurrences suppressed since the last one
+// logged.
+func (s *Sampler) Suppressed() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.suppressed
+}
@@ -0,0 +1,70 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSampler(t *testing.T) {
+	Convey("Sampler", t, func() {
+		output := new(bytes.Buffer)
+		l, _ := New()
+		l.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+		l.Logger.Out = output
+		l.Logger.Level = logrus.InfoLevel
+
+		clock := time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC)
+		s := Every(time.Minute)
+		s.now = func() time.Time { return clock }
+
+		log := func() bool {
+			e, ok := s.Sample(l)
+			if ok {
+				e.Info("repeated")
+			}
+			return ok
+		}
+
+		Convey("logs the first occurrence immediately", func() {
+			So(log(), ShouldBeTrue)
+			So(output.String(), ShouldContainSubstring, "msg=repeated")
+			So(output.String(), ShouldContainSubstring, "suppressed=0")
+			So(output.String(), ShouldContainSubstring, "sample_interval=1m0s")
+		})
+
+		Convey("counts the occurrences it suppresses", func() {
+			So(log(), ShouldBeTrue)
+			output.Reset()
+
+			for i := 0; i < 240; i++ {
+				clock = clock.Add(time.Second / 10)
+				So(log(), ShouldBeFalse)
+			}
+			So(output.String(), ShouldEqual, "")
+			So(s.Suppressed(), ShouldEqual, 240)
+
+			clock = clock.Add(time.Minute)
+			So(log(), ShouldBeTrue)
+			So(output.String(), ShouldContainSubstring, "suppressed=240")
+			So(s.Suppressed(), ShouldEqual, 0)
+
+			// the count starts over
+			output.Reset()
+			So(log(), ShouldBeFalse)
+			clock = clock.Add(time.Minute)
+			So(log(), ShouldBeTrue)
+			So(output.String(), ShouldContainSubstring, "suppressed=1")
+		})
+
+		Convey("leaves the sampled entry untouched", func() {
+			e, ok := s.Sample(l)
+			So(ok, ShouldBeTrue)
+			So(e, ShouldNotEqual, l)
+			So(l.Data, ShouldNotContainKey, "suppressed")
+		})
+	})
+}
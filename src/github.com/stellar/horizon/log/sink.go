@@ -0,0 +1,83 @@
+package log
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// Sink is a destination for log entries, written in its own format and
+// filtered by its own level.  Sinks are added to a logger using AddSink.
+type Sink struct {
+	// Out receives each entry as a single call to Write.
+	Out io.Writer
+
+	formatter logrus.Formatter
+	level     logrus.Level
+	lock      sync.Mutex
+}
+
+// NewSink returns a sink writing entries to `out` in `format`.  Entries are
+// first filtered by the level of the logger writing them (or of their
+// subsystem; see For), then by `level`, which allows a sink to be quieter
+// than the logger:  a sink at InfoLevel omits debug entries even when the
+// logger writes them to other sinks.
+func NewSink(out io.Writer, format Format, level logrus.Level) *Sink {
+	f := newFormatter(format)
+	if tf, ok := f.(*logrus.TextFormatter); ok && out != os.Stderr && out != os.Stdout {
+		tf.DisableColors = true
+	}
+
+	return &Sink{Out: out, formatter: f, level: level}
+}
+
+// Levels implements logrus.Hook, returning the levels written to the sink.
+func (s *Sink) Levels() []logrus.Level {
+	var ret []logrus.Level
+	for _, l := range []logrus.Level{
+		logrus.PanicLevel,
+		logrus.FatalLevel,
+		logrus.ErrorLevel,
+		logrus.WarnLevel,
+		logrus.InfoLevel,
+		logrus.DebugLevel,
+	} {
+		if l <= s.level {
+			ret = append(ret, l)
+		}
+	}
+
+	return ret
+}
+
+// Fire implements logrus.Hook, writing `e` to the sink.  Loggers writing
+// concurrently may share a sink:  each entry is written whole.
+func (s *Sink) Fire(e *logrus.Entry) error {
+	line, err := s.formatter.Format(e)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, err = s.Out.Write(line)
+	return err
+}
+
+// AddSink causes the logger underlying `e` to write its entries to `s`.  The
+// first sink added replaces the logger's own output (its Out), which is
+// discarded from then on.  Like SetFormat, it applies to DefaultLogger when
+// `e` is a subsystem logger.
+func (e *Entry) AddSink(s *Sink) {
+	if e.subsystem != nil {
+		DefaultLogger.AddSink(s)
+		return
+	}
+
+	e.Logger.Out = ioutil.Discard
+	e.Logger.Hooks.Add(s)
+}
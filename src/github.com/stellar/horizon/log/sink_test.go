@@ -0,0 +1,54 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSinks(t *testing.T) {
+	Convey("Sinks", t, func() {
+		out := new(bytes.Buffer)
+		console := new(bytes.Buffer)
+		file := new(bytes.Buffer)
+
+		l, _ := New()
+		l.Logger.Out = out
+		l.Logger.Level = logrus.DebugLevel
+		l.AddSink(NewSink(console, TextFormat, logrus.InfoLevel))
+		l.AddSink(NewSink(file, JSONFormat, logrus.DebugLevel))
+
+		Convey("replace the logger's own output", func() {
+			l.Warn("hello")
+			So(out.Len(), ShouldEqual, 0)
+			So(console.String(), ShouldContainSubstring, "msg=hello")
+			So(file.String(), ShouldStartWith, "{")
+		})
+
+		Convey("write in their own format, filtered by their own level", func() {
+			l.WithField("ledger", 3).Debug("detail")
+			l.Info("summary")
+
+			So(console.String(), ShouldNotContainSubstring, "detail")
+			So(console.String(), ShouldContainSubstring, "msg=summary")
+
+			lines := bytes.Split(bytes.TrimSpace(file.Bytes()), []byte("\n"))
+			So(len(lines), ShouldEqual, 2)
+
+			var entry map[string]interface{}
+			So(json.Unmarshal(lines[0], &entry), ShouldBeNil)
+			So(entry["msg"], ShouldEqual, "detail")
+			So(entry["ledger"], ShouldEqual, float64(3))
+		})
+
+		Convey("are filtered by the logger's level first", func() {
+			l.Logger.Level = logrus.WarnLevel
+			l.Info("quiet")
+			So(console.Len(), ShouldEqual, 0)
+			So(file.Len(), ShouldEqual, 0)
+		})
+	})
+}
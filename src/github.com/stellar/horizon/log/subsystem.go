@@ -0,0 +1,211 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// The names of the subsystems whose log levels may be set independently of
+// DefaultLogger's (see For and SetLevelFor).
+const (
+	IngestSubsystem = "ingest"
+	TxSubSubsystem  = "txsub"
+	RenderSubsystem = "render"
+	DBSubsystem     = "db"
+)
+
+// For returns the logger of the named subsystem.  Its entries are written by
+// DefaultLogger (whichever logger that is at the time of writing) with an
+// additional "subsystem" field, but they are filtered by the subsystem's own
+// level when one has been set using SetLevelFor, rather than by
+// DefaultLogger's.  For panics if `name` is not a known subsystem.
+func For(name string) *Entry {
+	return &Entry{Entry: logrus.Entry{Data: logrus.Fields{}}, subsystem: mustFind(name)}
+}
+
+// In returns an entry that carries e's fields and is written by e's logger,
+// but that logs as part of the named subsystem, as the loggers returned by For
+// do.  It is typically applied to a logger bound to a context (see Ctx), such
+// that entries keep the fields identifying the request or ingestion session
+// they are logged for.  In panics if `name` is not a known subsystem.
+func (e *Entry) In(name string) *Entry {
+	return &Entry{Entry: e.Entry, subsystem: mustFind(name)}
+}
+
+// SetLevelFor sets the minimum severity logged by the named subsystem.  The
+// change is safe to make while the subsystem is logging, and applies to every
+// entry logged after it, including those of loggers obtained before it.
+func SetLevelFor(name string, level logrus.Level) error {
+	s, ok := subsystems[name]
+	if !ok {
+		return fmt.Errorf("unknown log subsystem: %q", name)
+	}
+
+	atomic.StoreInt32(&s.level, int32(level))
+	return nil
+}
+
+// ResetLevelFor causes the named subsystem to once again follow
+// DefaultLogger's level.
+func ResetLevelFor(name string) error {
+	s, ok := subsystems[name]
+	if !ok {
+		return fmt.Errorf("unknown log subsystem: %q", name)
+	}
+
+	atomic.StoreInt32(&s.level, levelUnset)
+	return nil
+}
+
+// LevelFor returns the minimum severity currently logged by the named
+// subsystem.  explicit is false if the subsystem follows DefaultLogger's level.
+func LevelFor(name string) (level logrus.Level, explicit bool, err error) {
+	s, ok := subsystems[name]
+	if !ok {
+		return 0, false, fmt.Errorf("unknown log subsystem: %q", name)
+	}
+
+	set := atomic.LoadInt32(&s.level)
+	if set == levelUnset {
+		return DefaultLogger.Logger.Level, false, nil
+	}
+
+	return logrus.Level(set), true, nil
+}
+
+// Subsystems returns the names of the known subsystems, in alphabetical order.
+func Subsystems() []string {
+	ret := make([]string, 0, len(subsystems))
+	for name := range subsystems {
+		ret = append(ret, name)
+	}
+
+	sort.Strings(ret)
+	return ret
+}
+
+// subsystem is the state shared by the loggers of a single subsystem.
+type subsystem struct {
+	name string
+
+	// level is the logrus.Level set for the subsystem, or levelUnset.  It is
+	// accessed atomically.
+	level int32
+
+	// verbose writes the entries that the level of root, the logger they are
+	// written by, would filter out, while the subsystem's level is the more
+	// verbose.  It shares everything with root but its level, and is guarded
+	// by lock.
+	lock    sync.Mutex
+	root    *logrus.Logger
+	verbose *logrus.Logger
+}
+
+const levelUnset = -1
+
+// log writes `msg` at `level`, along with the fields of `e`, unless the
+// subsystem's level filters it out.  The entry is written by e's logger, or by
+// DefaultLogger when `e` was obtained from For, unless that logger's own level
+// would filter it out (see verboseLogger).
+func (s *subsystem) log(e *logrus.Entry, level logrus.Level, msg string) {
+	root, fields := e.Logger, e.Data
+	if root == nil {
+		root = DefaultLogger.Logger
+		fields = mergeFields(DefaultLogger.Data, e.Data)
+	}
+
+	set := atomic.LoadInt32(&s.level)
+	min := root.Level
+	if set != levelUnset {
+		min = logrus.Level(set)
+	}
+
+	if min < level {
+		return
+	}
+
+	l := root
+	if root.Level < level {
+		l = s.verboseLogger(root)
+	}
+
+	entry := logrus.NewEntry(l).
+		WithFields(fields).
+		WithField("subsystem", s.name)
+
+	switch level {
+	case logrus.DebugLevel:
+		entry.Debug(msg)
+	case logrus.InfoLevel:
+		entry.Info(msg)
+	case logrus.WarnLevel:
+		entry.Warn(msg)
+	case logrus.ErrorLevel:
+		entry.Error(msg)
+	default:
+		entry.Panic(msg)
+	}
+}
+
+// verboseLogger returns a logger that shares everything with `root` but its
+// level, which is DebugLevel, such that the root's level does not filter the
+// entries of a subsystem set to be more verbose.  The logger is built once,
+// and again only once `root`, or its output or formatter, change.
+func (s *subsystem) verboseLogger(root *logrus.Logger) *logrus.Logger {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.root != root || s.verbose.Out != root.Out || s.verbose.Formatter != root.Formatter {
+		s.root = root
+		s.verbose = &logrus.Logger{
+			Out:       root.Out,
+			Formatter: root.Formatter,
+			Hooks:     root.Hooks,
+			Level:     logrus.DebugLevel,
+		}
+	}
+
+	return s.verbose
+}
+
+// mergeFields returns the fields of `base`, overridden by those of `fields`.
+func mergeFields(base, fields logrus.Fields) logrus.Fields {
+	ret := make(logrus.Fields, len(base)+len(fields))
+	for k, v := range base {
+		ret[k] = v
+	}
+	for k, v := range fields {
+		ret[k] = v
+	}
+
+	return ret
+}
+
+// mustFind returns the named subsystem, panicking if it is not known.
+func mustFind(name string) *subsystem {
+	s, ok := subsystems[name]
+	if !ok {
+		panic(fmt.Sprintf("log: unknown subsystem %q", name))
+	}
+
+	return s
+}
+
+// subsystems holds every known subsystem.  It is not modified after
+// initialization, and so may be read without locking.
+var subsystems = map[string]*subsystem{}
+
+func init() {
+	for _, name := range []string{
+		IngestSubsystem,
+		TxSubSubsystem,
+		RenderSubsystem,
+		DBSubsystem,
+	} {
+		subsystems[name] = &subsystem{name: name, level: levelUnset}
+	}
+}
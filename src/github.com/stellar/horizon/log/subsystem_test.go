@@ -0,0 +1,131 @@
+package log
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSubsystems(t *testing.T) {
+	Convey("Subsystem loggers", t, func() {
+		output := new(bytes.Buffer)
+		root, _ := New()
+		root.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+		root.Logger.Out = output
+
+		old := DefaultLogger
+		DefaultLogger = root
+		defer func() {
+			DefaultLogger = old
+			ResetLevelFor(IngestSubsystem)
+		}()
+
+		l := For(IngestSubsystem).WithField("ledger", 3)
+
+		Convey("follow the default level until set", func() {
+			l.Info("hidden")
+			So(output.String(), ShouldEqual, "")
+
+			l.Warn("shown")
+			So(output.String(), ShouldContainSubstring, "msg=shown")
+			So(output.String(), ShouldContainSubstring, "subsystem=ingest")
+			So(output.String(), ShouldContainSubstring, "ledger=3")
+			So(output.String(), ShouldContainSubstring, "pid=")
+
+			level, explicit, err := LevelFor(IngestSubsystem)
+			So(err, ShouldBeNil)
+			So(explicit, ShouldBeFalse)
+			So(level, ShouldEqual, logrus.WarnLevel)
+		})
+
+		Convey("can be more verbose than the default", func() {
+			So(SetLevelFor(IngestSubsystem, logrus.DebugLevel), ShouldBeNil)
+			l.Debug("debugging")
+			So(output.String(), ShouldContainSubstring, "msg=debugging")
+
+			// other loggers are unaffected
+			root.Debug("root")
+			For(TxSubSubsystem).Debug("txsub")
+			So(output.String(), ShouldNotContainSubstring, "msg=root")
+			So(output.String(), ShouldNotContainSubstring, "msg=txsub")
+		})
+
+		Convey("build no logger per entry", func() {
+			So(SetLevelFor(IngestSubsystem, logrus.DebugLevel), ShouldBeNil)
+			s := subsystems[IngestSubsystem]
+
+			l.Debug("first")
+			verbose := s.verbose
+			So(verbose, ShouldNotBeNil)
+			l.Debug("second")
+			So(s.verbose, ShouldEqual, verbose)
+
+			// entries the default level allows are written by the default logger
+			l.Warn("third")
+			So(s.verbose, ShouldEqual, verbose)
+			So(output.String(), ShouldContainSubstring, "msg=second")
+			So(output.String(), ShouldContainSubstring, "msg=third")
+
+			// a change of format is followed
+			root.SetFormat(JSONFormat)
+			l.Debug("fourth")
+			So(s.verbose, ShouldNotEqual, verbose)
+			So(output.String(), ShouldContainSubstring, `"msg":"fourth"`)
+		})
+
+		Convey("can be quieter than the default", func() {
+			So(SetLevelFor(IngestSubsystem, logrus.ErrorLevel), ShouldBeNil)
+			l.Warn("quiet")
+			So(output.String(), ShouldEqual, "")
+
+			So(ResetLevelFor(IngestSubsystem), ShouldBeNil)
+			l.Warn("loud")
+			So(output.String(), ShouldContainSubstring, "msg=loud")
+		})
+
+		Convey("may change level while logging", func() {
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					l.Debug("looping")
+				}
+			}()
+
+			SetLevelFor(IngestSubsystem, logrus.DebugLevel)
+			wg.Wait()
+			l.Debug("after")
+			So(output.String(), ShouldContainSubstring, "msg=after")
+		})
+
+		Convey("may be derived from another logger", func() {
+			other := new(bytes.Buffer)
+			bound, _ := New()
+			bound.Logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+			bound.Logger.Out = other
+			bound.Logger.Level = logrus.InfoLevel
+
+			l := bound.WithField("req", "abc").In(IngestSubsystem)
+
+			l.Info("ambient")
+			So(output.String(), ShouldEqual, "")
+			So(other.String(), ShouldContainSubstring, "msg=ambient")
+			So(other.String(), ShouldContainSubstring, "req=abc")
+			So(other.String(), ShouldContainSubstring, "subsystem=ingest")
+
+			So(SetLevelFor(IngestSubsystem, logrus.WarnLevel), ShouldBeNil)
+			l.WithField("ledger", 4).Info("filtered")
+			So(other.String(), ShouldNotContainSubstring, "msg=filtered")
+		})
+
+		Convey("must be known", func() {
+			So(func() { For("nope") }, ShouldPanic)
+			So(SetLevelFor("nope", logrus.DebugLevel), ShouldNotBeNil)
+			So(Subsystems(), ShouldResemble, []string{"db", "ingest", "render", "txsub"})
+		})
+	})
+}
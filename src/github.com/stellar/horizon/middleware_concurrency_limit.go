@@ -0,0 +1,56 @@
+package horizon
+
+import (
+	"net/http"
+
+	gctx "github.com/goji/context"
+	"github.com/stellar/horizon/render/problem"
+	"github.com/zenazn/goji/web"
+)
+
+// streamsOverCapacity is the problem rendered for a stream request made when
+// the configured number of streams are already open.
+var streamsOverCapacity = problem.P{
+	Type:   problem.ServerOverCapacity.Type,
+	Title:  problem.ServerOverCapacity.Title,
+	Status: problem.ServerOverCapacity.Status,
+	Detail: "This horizon server has as many event streams open as it allows.  " +
+		"Please retry your request later, or page through the resource " +
+		"without streaming.",
+}
+
+// ConcurrencyLimitMiddleware rejects requests with a server_over_capacity
+// problem when the configured number of requests are already being handled,
+// rather than letting them queue up for database connections.  Non-streaming
+// requests and event streams are limited separately, since a stream holds its
+// slot for as long as it is open.
+func (web *Web) ConcurrencyLimitMiddleware(c *web.C, next http.Handler) http.Handler {
+	if web.requestSlots == nil && web.streamSlots == nil {
+		return next
+	}
+
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := gctx.FromC(*c)
+
+		slots, meter, p := web.requestSlots, web.overCapacityMeter, problem.ServerOverCapacity
+		if isStreamingRequest(ctx, r) {
+			slots, meter, p = web.streamSlots, web.streamsOverCapacityMeter, streamsOverCapacity
+		}
+
+		if slots == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			next.ServeHTTP(w, r)
+		default:
+			meter.Mark(1)
+			problem.Render(ctx, w, p)
+		}
+	}
+
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,80 @@
+package horizon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/test"
+)
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	c := NewTestConfig()
+	c.MaxConcurrentRequests = 1
+	app, err := NewApp(c)
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	w := rh.Get("/ledgers")
+	tt.Assert.Equal(200, w.Code)
+
+	// occupy the only slot, as though another request were in flight
+	app.web.requestSlots <- struct{}{}
+	w = rh.Get("/ledgers")
+	tt.Assert.Equal(503, w.Code)
+	tt.Assert.Contains(w.Body.String(), "server_over_capacity")
+	<-app.web.requestSlots
+
+	w = rh.Get("/ledgers")
+	tt.Assert.Equal(200, w.Code)
+}
+
+func TestConcurrencyLimitMiddleware_Streams(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	c := NewTestConfig()
+	c.MaxConcurrentStreams = 1
+	app, err := NewApp(c)
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	w := rh.Get("/ledgers?limit=1", test.RequestHelperStreaming)
+	tt.Assert.Equal(200, w.Code)
+
+	// occupy the only slot, as though another stream were open
+	app.web.streamSlots <- struct{}{}
+	w = rh.Get("/ledgers?limit=1", test.RequestHelperStreaming)
+	tt.Assert.Equal(503, w.Code)
+	tt.Assert.Contains(w.Body.String(), "server_over_capacity")
+	tt.Assert.Contains(w.Body.String(), "retry")
+	tt.Assert.Equal(int64(1), app.web.streamsOverCapacityMeter.Count())
+
+	// non-streaming requests are unaffected
+	w = rh.Get("/ledgers")
+	tt.Assert.Equal(200, w.Code)
+	<-app.web.streamSlots
+
+	w = rh.Get("/ledgers?limit=1", test.RequestHelperStreaming)
+	tt.Assert.Equal(200, w.Code)
+}
+
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	c := NewTestConfig()
+	c.RequestTimeout = time.Nanosecond
+	app, err := NewApp(c)
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	w := rh.Get("/ledgers")
+	tt.Assert.Equal(503, w.Code)
+	tt.Assert.Contains(w.Body.String(), "timeout")
+}
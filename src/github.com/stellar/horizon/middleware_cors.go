@@ -0,0 +1,200 @@
+package horizon
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zenazn/goji/web"
+)
+
+// corsAllowedMethods are the methods a cross-origin request may use.
+var corsAllowedMethods = []string{"GET", "POST"}
+
+// corsExposedHeaders are the response headers always exposed to cross-origin
+// requests, in addition to those configured.
+var corsExposedHeaders = []string{
+	LatestLedgerHeader,
+	LatestLedgerClosedAtHeader,
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+	"Retry-After",
+}
+
+// corsPolicy decides which cross-origin requests are allowed, and how they
+// are answered (see CORSMiddleware).
+type corsPolicy struct {
+	// origins are the allowed origins:  "*" allows any, and an origin
+	// beginning with "*." any subdomain of the rest of it, such as
+	// "https://*.example.com".
+	origins []string
+
+	// headers are the request headers allowed, "*" allowing any.
+	headers []string
+
+	exposed     string
+	maxAge      time.Duration
+	credentials bool
+}
+
+// errCORSCredentialsFromAnyOrigin is returned by newCORSPolicy when
+// credentials are allowed along with any origin, which would let every site a
+// user visits make requests carrying the user's credentials.
+var errCORSCredentialsFromAnyOrigin = errors.New(
+	"cors: credentials cannot be allowed from any origin; list the origins allowed instead of *",
+)
+
+// newCORSPolicy returns the corsPolicy configured by `config`, failing should
+// the configuration be unsafe.
+func newCORSPolicy(config Config) (*corsPolicy, error) {
+	if config.CORSAllowCredentials {
+		for _, origin := range config.CORSAllowedOrigins {
+			if origin == "*" {
+				return nil, errCORSCredentialsFromAnyOrigin
+			}
+		}
+	}
+
+	exposed := append([]string(nil), corsExposedHeaders...)
+	exposed = append(exposed, config.CORSExposedHeaders...)
+
+	return &corsPolicy{
+		origins:     config.CORSAllowedOrigins,
+		headers:     config.CORSAllowedHeaders,
+		exposed:     strings.Join(exposed, ", "),
+		maxAge:      config.CORSMaxAge,
+		credentials: config.CORSAllowCredentials,
+	}, nil
+}
+
+// allowsOrigin returns true if `origin` is allowed.
+func (p *corsPolicy) allowsOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, allowed := range p.origins {
+		allowed = strings.ToLower(allowed)
+
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+
+		// "https://*.example.com" allows "https://api.example.com", but not
+		// "https://example.com".
+		i := strings.Index(allowed, "*.")
+		if i < 0 {
+			continue
+		}
+		prefix, suffix := allowed[:i], allowed[i+1:]
+		if len(origin) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) &&
+			strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowsMethod returns true if cross-origin requests may use `method`.
+func (p *corsPolicy) allowsMethod(method string) bool {
+	for _, allowed := range corsAllowedMethods {
+		if strings.EqualFold(method, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHeaders returns true if every header in the comma separated list
+// `requested` is allowed.
+func (p *corsPolicy) allowsHeaders(requested string) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+
+		allowed := false
+		for _, h := range p.headers {
+			if h == "*" || strings.EqualFold(h, header) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// preflight answers the preflight request `r`, from an allowed origin,
+// listing what the request it precedes may do.  Nothing is allowed should it
+// ask for a method or headers that are not.
+func (p *corsPolicy) preflight(w http.ResponseWriter, r *http.Request) {
+	method := r.Header.Get("Access-Control-Request-Method")
+	headers := r.Header.Get("Access-Control-Request-Headers")
+	if !p.allowsMethod(method) || !p.allowsHeaders(headers) {
+		return
+	}
+
+	h := w.Header()
+	p.allowOrigin(w, r)
+	h.Set("Access-Control-Allow-Methods", strings.Join(corsAllowedMethods, ", "))
+	if headers != "" {
+		h.Set("Access-Control-Allow-Headers", headers)
+	}
+	if p.maxAge > 0 {
+		h.Set("Access-Control-Max-Age", strconv.Itoa(int(p.maxAge/time.Second)))
+	}
+}
+
+// allowOrigin sets the headers allowing the origin of `r` to read the
+// response.  The origin is echoed rather than answered with "*", which
+// browsers refuse for requests that carry credentials.
+func (p *corsPolicy) allowOrigin(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	if p.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// CORSMiddleware allows cross-origin requests from the origins configured by
+// Config.CORSAllowedOrigins.  Preflight requests are answered by the
+// middleware itself, without invoking the action they precede, and any other
+// request from an allowed origin is handled as usual, with headers allowing
+// its origin to read the response and the headers exposed to it.
+func (web *Web) CORSMiddleware(c *web.C, next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		allowed := web.cors.allowsOrigin(origin)
+
+		if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			if allowed {
+				web.cors.preflight(w, r)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if allowed {
+			web.cors.allowOrigin(w, r)
+			w.Header().Set("Access-Control-Expose-Headers", web.cors.exposed)
+		}
+		next.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,118 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// corsPreflight returns a request modifier turning a request into the
+// preflight, from `origin`, of a `method` request sending `headers`.
+func corsPreflight(origin, method, headers string) func(*http.Request) {
+	return func(r *http.Request) {
+		r.Method = "OPTIONS"
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Access-Control-Request-Method", method)
+		if headers != "" {
+			r.Header.Set("Access-Control-Request-Headers", headers)
+		}
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	c := NewTestConfig()
+	c.CORSAllowedOrigins = []string{"https://wallet.example.com", "https://*.example.org"}
+	c.CORSAllowedHeaders = []string{"Content-Type", "Last-Event-ID", "X-Client-Name"}
+	c.CORSExposedHeaders = []string{"X-Request-Id"}
+	c.CORSMaxAge = 10 * time.Minute
+	c.CORSAllowCredentials = true
+	app, err := NewApp(c)
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	// the preflight of a transaction submission is answered without
+	// submitting anything
+	w := rh.Post("/transactions", nil, corsPreflight("https://wallet.example.com", "POST", "content-type, x-client-name"))
+	if tt.Assert.Equal(204, w.Code) {
+		tt.Assert.Empty(w.Body.String())
+		tt.Assert.Equal("https://wallet.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		tt.Assert.Equal("GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+		tt.Assert.Equal("content-type, x-client-name", w.Header().Get("Access-Control-Allow-Headers"))
+		tt.Assert.Equal("600", w.Header().Get("Access-Control-Max-Age"))
+		tt.Assert.Equal("true", w.Header().Get("Access-Control-Allow-Credentials"))
+		tt.Assert.Empty(w.Header().Get("X-RateLimit-Limit"))
+	}
+
+	// as is that of a stream, from a subdomain of a wildcard origin
+	w = rh.Get("/ledgers", corsPreflight("https://api.example.org", "GET", "Last-Event-ID"))
+	if tt.Assert.Equal(204, w.Code) {
+		tt.Assert.Equal("https://api.example.org", w.Header().Get("Access-Control-Allow-Origin"))
+		tt.Assert.Equal("Last-Event-ID", w.Header().Get("Access-Control-Allow-Headers"))
+	}
+
+	// preflights that ask for what is not allowed allow nothing
+	for _, mod := range []func(*http.Request){
+		corsPreflight("https://example.org", "GET", ""),
+		corsPreflight("https://wallet.example.com.evil.com", "GET", ""),
+		corsPreflight("https://wallet.example.com", "DELETE", ""),
+		corsPreflight("https://wallet.example.com", "POST", "X-Secret"),
+	} {
+		w = rh.Get("/ledgers", mod)
+		if tt.Assert.Equal(204, w.Code) {
+			tt.Assert.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+			tt.Assert.Empty(w.Header().Get("Access-Control-Allow-Methods"))
+		}
+	}
+
+	// requests from allowed origins may read the response and its headers
+	origin := func(o string) func(*http.Request) {
+		return test.RequestHelperHeader("Origin", o)
+	}
+
+	w = rh.Get("/ledgers", origin("https://wallet.example.com"))
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.Equal("https://wallet.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		tt.Assert.Equal("true", w.Header().Get("Access-Control-Allow-Credentials"))
+		exposed := w.Header().Get("Access-Control-Expose-Headers")
+		tt.Assert.Contains(exposed, LatestLedgerHeader)
+		tt.Assert.Contains(exposed, "X-RateLimit-Remaining")
+		tt.Assert.Contains(exposed, "X-Request-Id")
+	}
+
+	// streams included, which name the origin rather than allowing any
+	w = rh.Get("/ledgers?limit=1", origin("https://api.example.org"), test.RequestHelperStreaming)
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.Equal("https://api.example.org", w.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	// while those from other origins are handled without
+	w = rh.Get("/ledgers", origin("https://elsewhere.com"))
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+		tt.Assert.Empty(w.Header().Get("Access-Control-Expose-Headers"))
+	}
+}
+
+func TestNewCORSPolicy(t *testing.T) {
+	c := NewTestConfig()
+	c.CORSAllowedOrigins = []string{"https://wallet.example.com", "*"}
+
+	_, err := newCORSPolicy(c)
+	assert.NoError(t, err)
+
+	// credentials are never allowed from any origin
+	c.CORSAllowCredentials = true
+	_, err = newCORSPolicy(c)
+	assert.Equal(t, errCORSCredentialsFromAnyOrigin, err)
+
+	c.CORSAllowedOrigins = []string{"https://wallet.example.com"}
+	_, err = newCORSPolicy(c)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,43 @@
+package horizon
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/horizon/ledger"
+	"github.com/zenazn/goji/web"
+)
+
+const (
+	// LatestLedgerHeader is the response header reporting the latest ledger
+	// ingested into the history database when the request was received.
+	LatestLedgerHeader = "Latest-Ledger"
+
+	// LatestLedgerClosedAtHeader is the response header reporting the close
+	// time of the ledger reported by LatestLedgerHeader, in RFC 3339 format.
+	LatestLedgerClosedAtHeader = "Latest-Ledger-Closed-At"
+)
+
+// latestLedgerMiddleware adds the LatestLedgerHeader and
+// LatestLedgerClosedAtHeader headers to every response, reflecting the cached
+// ledger state as of the request, so that clients can detect stale reads
+// without an additional request.  Headers already set are left alone, and the
+// close time is omitted while unknown.
+func latestLedgerMiddleware(c *web.C, next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ls := ledger.CurrentState()
+		h := w.Header()
+
+		if h.Get(LatestLedgerHeader) == "" {
+			h.Set(LatestLedgerHeader, strconv.FormatInt(int64(ls.HistoryLatest), 10))
+		}
+
+		if h.Get(LatestLedgerClosedAtHeader) == "" && !ls.HistoryLatestClosedAt.IsZero() {
+			h.Set(LatestLedgerClosedAtHeader, ls.HistoryLatestClosedAt.UTC().Format(time.RFC3339))
+		}
+
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,41 @@
+package horizon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/test"
+)
+
+func TestLatestLedgerMiddleware(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	app, err := NewApp(NewTestConfig())
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	ls := ledger.CurrentState()
+	ls.HistoryLatestClosedAt = time.Date(2016, 9, 1, 12, 0, 0, 0, time.UTC)
+	ledger.SetState(ls)
+
+	// successful responses...
+	w := rh.Get("/ledgers")
+	tt.Assert.Equal(200, w.Code)
+	tt.Assert.Equal("3", w.Header().Get(LatestLedgerHeader))
+	tt.Assert.Equal("2016-09-01T12:00:00Z", w.Header().Get(LatestLedgerClosedAtHeader))
+
+	// ...and errors alike carry the headers
+	w = rh.Get("/ledgers/100")
+	tt.Assert.Equal(404, w.Code)
+	tt.Assert.Equal("3", w.Header().Get(LatestLedgerHeader))
+
+	// the close time is omitted while unknown
+	ls.HistoryLatestClosedAt = time.Time{}
+	ledger.SetState(ls)
+	w = rh.Get("/")
+	tt.Assert.Equal("3", w.Header().Get(LatestLedgerHeader))
+	tt.Assert.Empty(w.Header().Get(LatestLedgerClosedAtHeader))
+}
@@ -0,0 +1,34 @@
+package horizon
+
+import (
+	"strings"
+	"testing"
+
+	hlog "github.com/stellar/horizon/log"
+)
+
+func TestLoggerMiddleware_RequestID(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	hlog.SetLevelFor(hlog.DBSubsystem, hlog.DebugLevel)
+	defer hlog.ResetLevelFor(hlog.DBSubsystem)
+
+	ht.LogBuffer.Reset()
+	w := ht.Get("/ledgers/1")
+	ht.Assert.Equal(200, w.Code)
+
+	// the queries made while handling the request are logged with its id,
+	// although the code issuing them never names it.
+	var queries int
+	for _, line := range strings.Split(ht.LogBuffer.String(), "\n") {
+		if !strings.Contains(line, "subsystem=db") {
+			continue
+		}
+
+		queries++
+		ht.Assert.Contains(line, "req=")
+	}
+
+	ht.Assert.NotEqual(0, queries)
+}
@@ -1,10 +1,102 @@
 package horizon
 
 import (
-	"github.com/zenazn/goji/web"
 	"net/http"
+
+	gctx "github.com/goji/context"
+	"github.com/stellar/horizon/render/problem"
+	"github.com/zenazn/goji/web"
 )
 
+// invalidAPIKey is the problem rendered for a request whose API key header
+// names a key that is not in the configured file of API keys.
+var invalidAPIKey = problem.P{
+	Type:   "invalid_api_key",
+	Title:  "Invalid API Key",
+	Status: http.StatusUnauthorized,
+	Detail: "The API key provided with this request is not valid.  Please " +
+		"check the key, or omit it to be rate limited by IP address instead.",
+}
+
+// clientStreamsExceeded is the problem rendered for a stream request made when
+// the client already has as many event streams open as its quota allows.
+var clientStreamsExceeded = problem.P{
+	Type:   problem.RateLimitExceeded.Type,
+	Title:  problem.RateLimitExceeded.Title,
+	Status: problem.RateLimitExceeded.Status,
+	Detail: "The requesting client has as many event streams open as it is " +
+		"allowed.  Please close a stream before opening another.",
+}
+
+// RateLimitMiddleware limits the rate of requests made by each client:  a
+// client presenting an API key in the configured header is limited by the
+// quota of its key, and any other by its IP address.  Event streams count
+// against a separate quota of the streams a client may hold open at once.
 func (web *Web) RateLimitMiddleware(c *web.C, next http.Handler) http.Handler {
-	return web.rateLimiter.Throttle(next)
+	if web.apiKeys == nil && web.maxStreamsPerIP == 0 {
+		return web.rateLimiter.Throttle(next)
+	}
+
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := gctx.FromC(*c)
+
+		limiter, client, maxStreams := web.rateLimiter, "ip:"+remoteAddrIP(r), web.maxStreamsPerIP
+		if key := web.apiKey(r); key != "" {
+			quota, ok := web.apiKeys.Quota(key)
+			if !ok {
+				problem.Render(ctx, w, invalidAPIKey)
+				return
+			}
+			limiter, client, maxStreams = web.keyedRateLimiter(quota.PerHour), "key:"+key, quota.MaxStreams
+		}
+
+		h := next
+		if maxStreams > 0 && isStreamingRequest(ctx, r) {
+			h = web.clientStreamLimit(c, client, maxStreams, next)
+		}
+		limiter.Throttle(h).ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// apiKey returns the API key presented with `r`, if API keys are enabled.
+func (web *Web) apiKey(r *http.Request) string {
+	if web.apiKeys == nil {
+		return ""
+	}
+	return r.Header.Get(web.apiKeyHeader)
+}
+
+// clientStreamLimit wraps `next` such that `client` may have no more than
+// `max` event streams open through it at once.
+func (web *Web) clientStreamLimit(
+	c *web.C,
+	client string,
+	max uint,
+	next http.Handler,
+) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		web.clientStreamsLock.Lock()
+		if web.clientStreams[client] >= max {
+			web.clientStreamsLock.Unlock()
+			problem.Render(gctx.FromC(*c), w, clientStreamsExceeded)
+			return
+		}
+		web.clientStreams[client]++
+		web.clientStreamsLock.Unlock()
+
+		defer func() {
+			web.clientStreamsLock.Lock()
+			web.clientStreams[client]--
+			if web.clientStreams[client] == 0 {
+				delete(web.clientStreams, client)
+			}
+			web.clientStreamsLock.Unlock()
+		}()
+
+		next.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
 }
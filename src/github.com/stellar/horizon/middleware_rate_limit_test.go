@@ -1,8 +1,11 @@
 package horizon
 
 import (
+	"io/ioutil"
+	"os"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/PuerkitoBio/throttled"
 	. "github.com/smartystreets/goconvey/convey"
@@ -108,3 +111,131 @@ func TestRateLimitMiddleware(t *testing.T) {
 		So(w.Code, ShouldEqual, 200)
 	})
 }
+
+func TestRateLimitMiddleware_APIKeys(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	f, err := ioutil.TempFile("", "horizon-api-keys")
+	tt.Require.NoError(err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"gold": {"per_hour": 20}, "silver": {"per_hour": 5}}`)
+	tt.Require.NoError(err)
+	tt.Require.NoError(f.Close())
+
+	c := NewTestConfig()
+	c.RateLimit = throttled.PerHour(10)
+	c.RateLimitKeysFile = f.Name()
+	c.RateLimitKeyHeader = "X-API-Key"
+	app, err := NewApp(c)
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	gold := test.RequestHelperHeader("X-API-Key", "gold")
+	silver := test.RequestHelperHeader("X-API-Key", "silver")
+
+	// keyed requests are limited by the quota of their key
+	w := rh.Get("/", gold)
+	tt.Assert.Equal(200, w.Code)
+	tt.Assert.Equal("20", w.Header().Get("X-RateLimit-Limit"))
+	tt.Assert.Equal("19", w.Header().Get("X-RateLimit-Remaining"))
+
+	for i := 0; i < 5; i++ {
+		w = rh.Get("/", silver)
+		tt.Assert.Equal(200, w.Code)
+	}
+	w = rh.Get("/", silver)
+	tt.Assert.Equal(429, w.Code)
+	tt.Assert.Contains(w.Body.String(), "rate_limit_exceeded")
+	tt.Assert.Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+
+	// ...separately from each other, and from the ip address they come from
+	w = rh.Get("/", gold)
+	tt.Assert.Equal(200, w.Code)
+	tt.Assert.Equal("18", w.Header().Get("X-RateLimit-Remaining"))
+
+	// unkeyed requests are limited by ip address
+	w = rh.Get("/")
+	tt.Assert.Equal(200, w.Code)
+	tt.Assert.Equal("10", w.Header().Get("X-RateLimit-Limit"))
+	tt.Assert.Equal("9", w.Header().Get("X-RateLimit-Remaining"))
+
+	// invalid keys are rejected, rather than falling back to the ip address
+	w = rh.Get("/", test.RequestHelperHeader("X-API-Key", "bronze"))
+	tt.Assert.Equal(401, w.Code)
+	tt.Assert.Contains(w.Body.String(), "invalid_api_key")
+
+	// keys are reloaded once the file is modified
+	tt.Require.NoError(ioutil.WriteFile(f.Name(), []byte(`{"bronze": {"per_hour": 3}}`), 0600))
+	later := time.Now().Add(time.Minute)
+	tt.Require.NoError(os.Chtimes(f.Name(), later, later))
+	app.web.apiKeys.Reload()
+
+	w = rh.Get("/", test.RequestHelperHeader("X-API-Key", "bronze"))
+	tt.Assert.Equal(200, w.Code)
+	tt.Assert.Equal("3", w.Header().Get("X-RateLimit-Limit"))
+	w = rh.Get("/", gold)
+	tt.Assert.Equal(401, w.Code)
+
+	// an invalid file leaves the keys loaded in place
+	tt.Require.NoError(ioutil.WriteFile(f.Name(), []byte(`{`), 0600))
+	later = later.Add(time.Minute)
+	tt.Require.NoError(os.Chtimes(f.Name(), later, later))
+	app.web.apiKeys.Reload()
+
+	w = rh.Get("/", test.RequestHelperHeader("X-API-Key", "bronze"))
+	tt.Assert.Equal(200, w.Code)
+}
+
+func TestRateLimitMiddleware_Streams(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	f, err := ioutil.TempFile("", "horizon-api-keys")
+	tt.Require.NoError(err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"gold": {"per_hour": 20, "max_streams": 2}}`)
+	tt.Require.NoError(err)
+	tt.Require.NoError(f.Close())
+
+	c := NewTestConfig()
+	c.RateLimitKeysFile = f.Name()
+	c.RateLimitKeyHeader = "X-API-Key"
+	c.MaxStreamsPerIP = 1
+	app, err := NewApp(c)
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	gold := test.RequestHelperHeader("X-API-Key", "gold")
+
+	w := rh.Get("/ledgers?limit=1", test.RequestHelperStreaming)
+	tt.Assert.Equal(200, w.Code)
+	tt.Assert.Empty(app.web.clientStreams)
+
+	// occupy the only stream of the ip address, as though it were open
+	app.web.clientStreams["ip:127.0.0.1"] = 1
+	w = rh.Get("/ledgers?limit=1", test.RequestHelperStreaming)
+	tt.Assert.Equal(429, w.Code)
+	tt.Assert.Contains(w.Body.String(), "event streams")
+
+	// non-streaming requests, and other ip addresses, are unaffected
+	w = rh.Get("/ledgers")
+	tt.Assert.Equal(200, w.Code)
+	w = rh.Get("/ledgers?limit=1", test.RequestHelperStreaming, test.RequestHelperRemoteAddr("127.0.0.2"))
+	tt.Assert.Equal(200, w.Code)
+
+	// keyed streams count against the quota of their key
+	w = rh.Get("/ledgers?limit=1", test.RequestHelperStreaming, gold)
+	tt.Assert.Equal(200, w.Code)
+
+	app.web.clientStreams["key:gold"] = 2
+	w = rh.Get("/ledgers?limit=1", test.RequestHelperStreaming, gold)
+	tt.Assert.Equal(429, w.Code)
+
+	app.web.clientStreams["key:gold"] = 1
+	w = rh.Get("/ledgers?limit=1", test.RequestHelperStreaming, gold)
+	tt.Assert.Equal(200, w.Code)
+	tt.Assert.Equal(uint(1), app.web.clientStreams["key:gold"])
+}
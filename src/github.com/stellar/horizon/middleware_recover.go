@@ -18,8 +18,8 @@ func RecoverMiddleware(c *web.C, h http.Handler) http.Handler {
 
 		defer func() {
 			if rec := recover(); rec != nil {
+				// rendering the error reports it to sentry, as it is unexpected
 				err := errors.FromPanic(rec)
-				errors.ReportToSentry(err, r)
 				problem.Render(ctx, w, err)
 			}
 		}()
@@ -1,23 +1,33 @@
 package horizon
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/rcrowley/go-metrics"
 	"github.com/zenazn/goji/web"
 	"github.com/zenazn/goji/web/mutil"
 )
 
 // Middleware that records metrics.
 //
-// It records success and failures using a meter, and times every request
+// It records success and failures using a meter, and times every request,
+// both in total and for the route that served it.  Requests that match no
+// route are only timed in total.
 func requestMetricsMiddleware(c *web.C, h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		app := c.Env["app"].(*App)
 		mw := mutil.WrapWriter(w)
 
-		app.web.requestTimer.Time(func() {
-			h.ServeHTTP(mw.(http.ResponseWriter), r)
-		})
+		start := time.Now()
+		h.ServeHTTP(mw.(http.ResponseWriter), r)
+		app.web.requestTimer.UpdateSince(start)
+
+		if match := web.GetMatch(*c); match.Handler != nil {
+			key := fmt.Sprintf("%s %v", r.Method, match.RawPattern())
+			metrics.GetOrRegisterTimer(key, app.web.routeTimers).UpdateSince(start)
+		}
 
 		if 200 <= mw.Status() && mw.Status() < 400 {
 			// a success is in [200, 400)
@@ -0,0 +1,45 @@
+package horizon
+
+import (
+	"net/http"
+	"time"
+
+	gctx "github.com/goji/context"
+	"github.com/stellar/horizon/render"
+	"github.com/zenazn/goji/web"
+	"golang.org/x/net/context"
+)
+
+// requestTimeoutMiddleware bounds the context of each non-streaming request by
+// `timeout`, such that any database queries still running once it has elapsed
+// are canceled.  Streaming requests are expected to stay open indefinitely and
+// so are left alone.  A zero timeout disables the middleware.
+func requestTimeoutMiddleware(timeout time.Duration) func(c *web.C, next http.Handler) http.Handler {
+	return func(c *web.C, next http.Handler) http.Handler {
+		if timeout == 0 {
+			return next
+		}
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := gctx.FromC(*c)
+
+			if isStreamingRequest(ctx, r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			gctx.Set(c, ctx)
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// isStreamingRequest returns true if `r` will be responded to with a stream
+// of server sent events.
+func isStreamingRequest(ctx context.Context, r *http.Request) bool {
+	return render.Negotiate(ctx, r) == render.MimeEventStream
+}
@@ -0,0 +1,74 @@
+package paths
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// Explainer is implemented by finders that can describe how they searched
+// for paths, to help diagnose why a payment could not be routed.
+type Explainer interface {
+	Explain(Query) ([]Path, *Explanation, error)
+}
+
+// Explanation is a diagnostic breakdown of a single path search.
+type Explanation struct {
+	// DestinationTrusted is false when the destination account can not hold
+	// the destination asset, in which case no path can ever be used.
+	DestinationTrusted bool
+
+	// Considered lists, in the order the search reached them, every asset
+	// that was considered as a step towards one of the source assets.  The
+	// first entry is the destination asset.
+	Considered []Step
+
+	// StoppedBy is one of the Stopped* constants and describes why the
+	// search ended.
+	StoppedBy string
+}
+
+// Step records what happened when a search considered a single asset.
+type Step struct {
+	Asset xdr.Asset
+
+	// Depth is the number of hops between this asset and the destination
+	// asset.
+	Depth int
+
+	// Source is true when the asset is one of the query's source assets.
+	Source bool
+
+	// Outcome is one of the Outcome* constants.
+	Outcome string
+
+	// OrderBooks is the number of order books that connect this asset to
+	// another.
+	OrderBooks int
+
+	// Insufficient lists the connected assets that were not explored because
+	// their order books could not satisfy the destination amount.
+	Insufficient []xdr.Asset
+}
+
+// Outcomes for a single step of a search.
+const (
+	// OutcomeExtended means the search continued through the asset.
+	OutcomeExtended = "extended"
+	// OutcomeNoOrderBooks means no order books connect the asset to another.
+	OutcomeNoOrderBooks = "no_order_books"
+	// OutcomeInsufficientLiquidity means every connected order book was too
+	// shallow to satisfy the destination amount.
+	OutcomeInsufficientLiquidity = "insufficient_liquidity"
+	// OutcomeMaxDepth means the asset is as far from the destination as a
+	// path payment allows.
+	OutcomeMaxDepth = "max_depth"
+)
+
+// Reasons a search may stop.
+const (
+	// StoppedExhausted means every reachable asset was considered.
+	StoppedExhausted = "exhausted"
+	// StoppedMaxResults means the search found as many paths as it returns.
+	StoppedMaxResults = "max_results"
+	// StoppedError means the search was aborted by an error.
+	StoppedError = "error"
+)
@@ -5,8 +5,10 @@
 package reap
 
 import (
-	"github.com/stellar/horizon/db2"
 	"time"
+
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/ledger"
 )
 
 // System represents the history reaping subsystem of horizon.
@@ -14,6 +16,20 @@ type System struct {
 	HorizonDB      *db2.Repo
 	RetentionCount uint
 
+	// LedgerState provides the ledger state that determines which ledgers are
+	// retained.  When nil, ledger.Default is used.
+	LedgerState ledger.StateProvider
+
+	// VacuumThreshold is the number of rows that, when exceeded by a single
+	// reaping, causes the reaper to recommend (or, if Vacuum is set, perform) a
+	// VACUUM of the reaped tables.  0 disables the check.
+	VacuumThreshold uint
+
+	// Vacuum causes the reaper to issue a VACUUM ANALYZE of each reaped table
+	// after a reaping that exceeds VacuumThreshold, rather than only logging a
+	// recommendation to do so.
+	Vacuum bool
+
 	nextRun time.Time
 }
 
@@ -23,6 +39,7 @@ func New(retention uint, horizon *db2.Repo) *System {
 	r := &System{
 		HorizonDB:      horizon,
 		RetentionCount: retention,
+		LedgerState:    ledger.Default,
 	}
 
 	r.nextRun = time.Now().Add(1 * time.Hour)
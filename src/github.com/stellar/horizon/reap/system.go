@@ -1,8 +1,11 @@
 package reap
 
 import (
+	"fmt"
 	"time"
 
+	sq "github.com/lann/squirrel"
+
 	"github.com/stellar/horizon/errors"
 	"github.com/stellar/horizon/ledger"
 	"github.com/stellar/horizon/log"
@@ -17,7 +20,7 @@ func (r *System) DeleteUnretainedHistory() error {
 	}
 
 	var (
-		latest      = ledger.CurrentState()
+		latest      = r.ledgerState().CurrentState()
 		targetElder = (latest.HistoryLatest - int32(r.RetentionCount)) + 1
 	)
 
@@ -25,16 +28,31 @@ func (r *System) DeleteUnretainedHistory() error {
 		return nil
 	}
 
-	err := r.clearBefore(targetElder)
+	deleted, err := r.clearBefore(targetElder)
 	if err != nil {
 		return err
 	}
 
 	log.
 		WithField("new_elder", targetElder).
+		WithField("rows_deleted", deleted).
 		Info("reaper succeeded")
 
-	return nil
+	if r.VacuumThreshold == 0 || deleted <= int64(r.VacuumThreshold) {
+		return nil
+	}
+
+	return r.afterLargeReap(deleted)
+}
+
+// ledgerState returns the ledger state provider of the reaper, defaulting to
+// ledger.Default.
+func (r *System) ledgerState() ledger.StateProvider {
+	if r.LedgerState == nil {
+		return ledger.Default
+	}
+
+	return r.LedgerState
 }
 
 // Tick triggers the reaper system to update itself, deleted unretained history
@@ -49,13 +67,7 @@ func (r *System) Tick() {
 }
 
 func (r *System) runOnce() {
-	defer func() {
-		if rec := recover(); rec != nil {
-			err := errors.FromPanic(rec)
-			log.Errorf("reaper panicked: %s", err)
-			errors.ReportToSentry(err, nil)
-		}
-	}()
+	defer errors.Recover("reaper", nil)
 
 	err := r.DeleteUnretainedHistory()
 	if err != nil {
@@ -63,36 +75,50 @@ func (r *System) runOnce() {
 	}
 }
 
-func (r *System) clearBefore(seq int32) error {
+// reapedTables lists the history tables cleared by the reaper, along with the
+// column used to determine which rows belong to an unretained ledger.  The
+// order is significant: rows are deleted from dependent tables first.
+var reapedTables = []struct {
+	Table string
+	IDCol string
+}{
+	{"history_effects", "history_operation_id"},
+	{"history_operation_participants", "history_operation_id"},
+	{"history_operation_assets", "history_operation_id"},
+	{"history_operations", "id"},
+	{"history_transaction_participants", "history_transaction_id"},
+	{"history_transactions", "id"},
+	{"history_ledger_upgrades", "history_ledger_id"},
+	{"history_ledgers", "id"},
+}
+
+// clearBefore deletes the history for all ledgers before `seq`, returning the
+// total number of rows deleted.
+func (r *System) clearBefore(seq int32) (int64, error) {
 	log.WithField("new_elder", seq).Info("reaper: clearing")
 
-	clear := r.HorizonDB.DeleteRange
 	end := toid.New(seq, 0, 0).ToInt64()
 
-	err := clear(0, end, "history_effects", "history_operation_id")
-	if err != nil {
-		return err
-	}
-	err = clear(0, end, "history_operation_participants", "history_operation_id")
-	if err != nil {
-		return err
-	}
-	err = clear(0, end, "history_operations", "id")
-	if err != nil {
-		return err
-	}
-	err = clear(0, end, "history_transaction_participants", "history_transaction_id")
-	if err != nil {
-		return err
-	}
-	err = clear(0, end, "history_transactions", "id")
-	if err != nil {
-		return err
-	}
-	err = clear(0, end, "history_ledgers", "id")
-	if err != nil {
-		return err
+	var total int64
+	for _, t := range reapedTables {
+		del := sq.Delete(t.Table).Where(
+			fmt.Sprintf("%s >= ? AND %s < ?", t.IDCol, t.IDCol),
+			0,
+			end,
+		)
+
+		res, err := r.HorizonDB.Exec(del)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		total += n
 	}
 
-	return nil
+	return total, nil
 }
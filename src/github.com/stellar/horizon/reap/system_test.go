@@ -46,3 +46,30 @@ func TestDeleteUnretainedHistory(t *testing.T) {
 		tt.Assert.Equal(1, cur)
 	}
 }
+
+func TestDeleteUnretainedHistory_Vacuum(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+
+	db := tt.HorizonRepo()
+	tt.UpdateLedgerState()
+
+	sys := New(10, db)
+	sys.VacuumThreshold = 1
+	sys.Vacuum = true
+
+	err := sys.DeleteUnretainedHistory()
+	tt.Assert.NoError(err)
+
+	dead, err := sys.loadDeadTuples()
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(dead, len(reapedTables))
+	}
+
+	// a reaping below the threshold neither recommends nor performs a vacuum
+	tt.UpdateLedgerState()
+	sys.RetentionCount = 1
+	sys.VacuumThreshold = 1000000
+	err = sys.DeleteUnretainedHistory()
+	tt.Assert.NoError(err)
+}
@@ -0,0 +1,68 @@
+package reap
+
+import (
+	sq "github.com/lann/squirrel"
+	"github.com/stellar/horizon/log"
+)
+
+// deadTuples holds postgres' estimate of the number of dead tuples in a table.
+type deadTuples struct {
+	Table string `db:"relname"`
+	Count int64  `db:"n_dead_tup"`
+}
+
+// afterLargeReap is called after a reaping that deleted more than the
+// configured threshold of rows.  Postgres does not return the space used by
+// deleted rows until the table is vacuumed, so depending upon the configuration
+// the reaper either recommends a VACUUM or performs one itself.
+func (r *System) afterLargeReap(deleted int64) error {
+	dead, err := r.loadDeadTuples()
+	if err != nil {
+		return err
+	}
+
+	fields := log.F{"rows_deleted": deleted}
+	for _, d := range dead {
+		fields["dead_tuples."+d.Table] = d.Count
+	}
+
+	if !r.Vacuum {
+		log.
+			WithFields(fields).
+			Warn("reaper: large reap, running VACUUM ANALYZE on the history tables is recommended")
+		return nil
+	}
+
+	log.WithFields(fields).Info("reaper: large reap, vacuuming")
+
+	for _, t := range reapedTables {
+		// NOTE: VACUUM cannot run inside a transaction block.  The reaper never
+		// opens one on its repo, so each statement below runs on its own.
+		_, err := r.HorizonDB.ExecRaw("VACUUM ANALYZE " + t.Table)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("reaper: vacuum finished")
+	return nil
+}
+
+// loadDeadTuples loads the estimated dead tuple count of each reaped table.
+// The counts are gathered by postgres' statistics collector and so may lag
+// slightly behind the deletions that were just performed.
+func (r *System) loadDeadTuples() ([]deadTuples, error) {
+	names := make([]string, len(reapedTables))
+	for i, t := range reapedTables {
+		names[i] = t.Table
+	}
+
+	var dead []deadTuples
+	err := r.HorizonDB.Select(&dead, sq.
+		Select("relname", "n_dead_tup").
+		From("pg_stat_user_tables").
+		Where(sq.Eq{"relname": names}).
+		OrderBy("relname"))
+
+	return dead, err
+}
@@ -0,0 +1,108 @@
+package hal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Encode writes the json rendering of `data` to `w`, indented if `pretty` is
+// set.  The records of a page (see BasePage) are rendered and written one at a
+// time, so that writing to a render.LimitedBuffer fails as soon as the page
+// crosses the limit, holding no more than one record beyond it, rather than
+// once the whole page has been rendered.  The output is that of
+// RenderToString.
+func Encode(w io.Writer, data interface{}, pretty bool) error {
+	switch page := data.(type) {
+	case Page:
+		if records := page.Embedded.Records; records != nil {
+			page.Embedded.Records = []Pageable{}
+			return encodePage(w, page, records, pretty)
+		}
+	case *Page:
+		return Encode(w, *page, pretty)
+	case BasePage:
+		if records := page.Embedded.Records; records != nil {
+			page.Embedded.Records = []Pageable{}
+			return encodePage(w, page, records, pretty)
+		}
+	case *BasePage:
+		return Encode(w, *page, pretty)
+	}
+
+	js, err := RenderToString(data, pretty)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(js)
+	return err
+}
+
+// encodePage writes `envelope`, a page whose records have been emptied, with
+// `records` spliced in, each rendered separately.
+func encodePage(w io.Writer, envelope interface{}, records []Pageable, pretty bool) error {
+	js, err := RenderToString(envelope, pretty)
+	if err != nil {
+		return err
+	}
+
+	marker := []byte(`"records":[]`)
+	if pretty {
+		marker = []byte(`"records": []`)
+	}
+
+	at := bytes.Index(js, marker)
+	if at < 0 {
+		return errors.New("hal: records not found in rendered page")
+	}
+	open := at + len(marker) - 1
+
+	// the indentation of the records' key, from which that of each record and
+	// of the closing bracket follows.
+	indent := ""
+	if pretty {
+		indent = string(js[bytes.LastIndexByte(js[:at], '\n')+1 : at])
+	}
+
+	_, err = w.Write(js[:open])
+	if err != nil {
+		return err
+	}
+
+	for i, record := range records {
+		var rjs []byte
+		if pretty {
+			rjs, err = json.MarshalIndent(record, indent+"  ", "  ")
+		} else {
+			rjs, err = json.Marshal(record)
+		}
+		if err != nil {
+			return err
+		}
+
+		sep := ""
+		if i > 0 {
+			sep = ","
+		}
+		if pretty {
+			sep += "\n" + indent + "  "
+		}
+
+		_, err = w.Write(append([]byte(sep), rjs...))
+		if err != nil {
+			return err
+		}
+	}
+
+	if pretty && len(records) > 0 {
+		_, err = w.Write([]byte("\n" + indent))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write(js[open:])
+	return err
+}
@@ -0,0 +1,67 @@
+package hal
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stellar/horizon/render"
+)
+
+type testRecord struct {
+	ID     string   `json:"id"`
+	Values []string `json:"values"`
+}
+
+func (r testRecord) PagingToken() string {
+	return r.ID
+}
+
+func TestEncode(t *testing.T) {
+
+	Convey("hal.Encode", t, func() {
+		page := Page{BasePath: "/records", Order: "asc", Limit: 10}
+		page.PopulateLinks()
+
+		check := func(data interface{}) {
+			for _, pretty := range []bool{true, false} {
+				expected, err := RenderToString(data, pretty)
+				So(err, ShouldBeNil)
+
+				var actual bytes.Buffer
+				So(Encode(&actual, data, pretty), ShouldBeNil)
+				So(actual.String(), ShouldEqual, string(expected))
+			}
+		}
+
+		Convey("renders pages as RenderToString does", func() {
+			check(page)
+			check(&page.BasePage)
+
+			page.Add(testRecord{ID: "1", Values: []string{"a", "b"}})
+			check(page)
+			check(&page)
+
+			page.Add(testRecord{ID: "2", Values: []string{"<c>"}})
+			page.Add(testRecord{ID: "3"})
+			check(page)
+			check(page.BasePage)
+
+			check(Page{})
+			check(testRecord{ID: "4", Values: []string{"d"}})
+		})
+
+		Convey("abandons a page once it crosses the limit", func() {
+			for i := 0; i < 100; i++ {
+				page.Add(testRecord{ID: "a record", Values: []string{"of some size"}})
+			}
+
+			render.SetMaxBodySize(1024)
+			defer render.SetMaxBodySize(0)
+
+			buf := render.NewLimitedBuffer()
+			So(Encode(buf, page, true), ShouldEqual, render.ErrBodyTooLarge)
+			So(buf.Len(), ShouldBeLessThanOrEqualTo, 1024)
+		})
+	})
+}
@@ -3,6 +3,10 @@ package hal
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/stellar/horizon/render"
+	"github.com/stellar/horizon/render/problem"
+	"golang.org/x/net/context"
 )
 
 // RenderToString renders the provided data as a json string
@@ -14,9 +18,23 @@ func RenderToString(data interface{}, pretty bool) ([]byte, error) {
 	return json.Marshal(data)
 }
 
-// Render write data to w, after marshalling to json
-func Render(w http.ResponseWriter, data interface{}) {
-	js, err := RenderToString(data, true)
+// Render write data to w, after marshalling to json.  Should the rendered
+// data exceed the configured maximum body size (see render.SetMaxBodySize), a
+// response_too_large problem is written instead, rendering of the data being
+// abandoned once it crosses the limit (see Encode).
+func Render(ctx context.Context, w http.ResponseWriter, data interface{}) {
+	RenderStatus(ctx, w, http.StatusOK, data)
+}
+
+// RenderStatus is like Render, but responds with `status` rather than 200 OK.
+func RenderStatus(ctx context.Context, w http.ResponseWriter, status int, data interface{}) {
+	js := render.NewLimitedBuffer()
+	err := Encode(js, data, true)
+
+	if err == render.ErrBodyTooLarge {
+		problem.Render(ctx, w, problem.ResponseTooLarge)
+		return
+	}
 
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -24,5 +42,6 @@ func Render(w http.ResponseWriter, data interface{}) {
 	}
 
 	w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
-	w.Write(js)
+	w.WriteHeader(status)
+	w.Write(js.Bytes())
 }
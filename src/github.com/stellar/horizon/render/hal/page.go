@@ -2,6 +2,7 @@ package hal
 
 import (
 	"net/url"
+	"strings"
 )
 
 // BasePage represents the simplest page: one with no links and only embedded records.
@@ -41,12 +42,19 @@ type Page struct {
 	Order    string `json:"-"`
 	Limit    uint64 `json:"-"`
 	Cursor   string `json:"-"`
+
+	// Filters are any additional query parameters that must be preserved in
+	// the page's links, such that following them continues the same query.
+	Filters url.Values `json:"-"`
 }
 
 // PopulateLinks sets the common links for a page.
 func (p *Page) PopulateLinks() {
 	p.Init()
 	fmts := p.BasePath + "?order=%s&limit=%d&cursor=%s"
+	if len(p.Filters) > 0 {
+		fmts += "&" + strings.Replace(p.Filters.Encode(), "%", "%%", -1)
+	}
 	lb := LinkBuilder{p.BaseURL}
 
 	p.Links.Self = lb.Linkf(fmts, p.Order, p.Limit, p.Cursor)
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrBodyTooLarge is returned when a rendered response body (or, for streamed
+// responses, a single event) exceeds the configured maximum size.
+var ErrBodyTooLarge = errors.New("rendered body exceeds the maximum allowed size")
+
+var maxBodySize int64
+
+// MaxBodySize returns the maximum size, in bytes, of a single rendered
+// response body.  Zero means there is no limit.
+func MaxBodySize() int64 {
+	return atomic.LoadInt64(&maxBodySize)
+}
+
+// SetMaxBodySize configures the maximum size, in bytes, of a single rendered
+// response body.  Pass zero to remove the limit.
+func SetMaxBodySize(size int64) {
+	atomic.StoreInt64(&maxBodySize, size)
+}
+
+// CheckBodySize returns ErrBodyTooLarge if `body` exceeds the configured
+// maximum size.
+func CheckBodySize(body []byte) error {
+	max := MaxBodySize()
+	if max > 0 && int64(len(body)) > max {
+		return ErrBodyTooLarge
+	}
+
+	return nil
+}
+
+// LimitedBuffer is a buffer for a rendered body that refuses, with
+// ErrBodyTooLarge, any write that would grow it beyond the maximum size
+// configured when it was created.  A body rendered into it piece by piece is
+// abandoned once it crosses the limit, rather than once it is complete.
+type LimitedBuffer struct {
+	bytes.Buffer
+	max int64
+}
+
+// NewLimitedBuffer returns an empty LimitedBuffer bounded by the configured
+// maximum body size.
+func NewLimitedBuffer() *LimitedBuffer {
+	return &LimitedBuffer{max: MaxBodySize()}
+}
+
+// Write appends `p` to the buffer, or returns ErrBodyTooLarge, writing
+// nothing, if doing so would exceed the buffer's limit.
+func (b *LimitedBuffer) Write(p []byte) (int, error) {
+	if b.max > 0 && int64(b.Len()+len(p)) > b.max {
+		return 0, ErrBodyTooLarge
+	}
+
+	return b.Buffer.Write(p)
+}
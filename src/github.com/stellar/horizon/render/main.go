@@ -9,9 +9,12 @@ import (
 )
 
 // Negotiate inspects the Accept header of the provided request and determines
-// what the most appropriate response type should be.  Defaults to HAL.
-func Negotiate(ctx context.Context, r *http.Request) string {
-	alternatives := []string{MimeHal, MimeJSON, MimeEventStream, MimeRaw}
+// what the most appropriate response type should be.  Defaults to HAL.  The
+// types of `extra`, such as MimeText, are offered in addition to those every
+// action may respond with.
+func Negotiate(ctx context.Context, r *http.Request, extra ...string) string {
+	alternatives := []string{MimeHal, MimeJSON, MimeEventStream, MimeRaw, MimeNDJSON}
+	alternatives = append(alternatives, extra...)
 	accept := r.Header.Get("Accept")
 
 	if accept == "" {
@@ -20,7 +23,7 @@ func Negotiate(ctx context.Context, r *http.Request) string {
 
 	result := goautoneg.Negotiate(r.Header.Get("Accept"), alternatives)
 
-	log.Ctx(ctx).WithFields(log.F{
+	log.Ctx(ctx).In(log.RenderSubsystem).WithFields(log.F{
 		"content_type": result,
 		"accept":       accept,
 	}).Debug("Negotiated content type")
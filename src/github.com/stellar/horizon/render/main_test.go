@@ -41,5 +41,52 @@ func TestRenderPackage(t *testing.T) {
 			So(Negotiate(ctx, r), ShouldEqual, "")
 		})
 
+		Convey("Negotiates the extra types offered, as prometheus requests", func() {
+			r.Header.Set("Accept", "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,text/plain;version=0.0.4;q=0.3,*/*;q=0.1")
+			So(Negotiate(ctx, r), ShouldEqual, MimeHal)
+			So(Negotiate(ctx, r, MimeText), ShouldEqual, MimeText)
+
+			r.Header.Set("Accept", "*/*")
+			So(Negotiate(ctx, r, MimeText), ShouldEqual, MimeHal)
+		})
+
+		Convey("Negotiates newline delimited JSON", func() {
+			r.Header.Set("Accept", "application/x-ndjson")
+			So(Negotiate(ctx, r), ShouldEqual, MimeNDJSON)
+		})
+
+	})
+
+	Convey("render.CheckBodySize", t, func() {
+		defer SetMaxBodySize(0)
+
+		So(CheckBodySize(make([]byte, 1024)), ShouldBeNil)
+
+		SetMaxBodySize(10)
+		So(CheckBodySize(make([]byte, 10)), ShouldBeNil)
+		So(CheckBodySize(make([]byte, 11)), ShouldEqual, ErrBodyTooLarge)
+	})
+
+	Convey("render.LimitedBuffer", t, func() {
+		defer SetMaxBodySize(0)
+
+		SetMaxBodySize(10)
+		buf := NewLimitedBuffer()
+		_, err := buf.Write(make([]byte, 6))
+		So(err, ShouldBeNil)
+		_, err = buf.Write(make([]byte, 4))
+		So(err, ShouldBeNil)
+
+		// a write crossing the limit is refused whole
+		_, err = buf.Write(make([]byte, 1))
+		So(err, ShouldEqual, ErrBodyTooLarge)
+		So(buf.Len(), ShouldEqual, 10)
+
+		// the limit is that configured when the buffer was made
+		SetMaxBodySize(0)
+		_, err = buf.Write(make([]byte, 1))
+		So(err, ShouldEqual, ErrBodyTooLarge)
+		_, err = NewLimitedBuffer().Write(make([]byte, 100))
+		So(err, ShouldBeNil)
 	})
 }
@@ -8,8 +8,12 @@ const (
 	MimeHal = "application/hal+json"
 	//MimeJSON is the mime type for "application/json"
 	MimeJSON = "application/json"
+	//MimeNDJSON is the mime type for "application/x-ndjson"
+	MimeNDJSON = "application/x-ndjson"
 	//MimeProblem is the mime type for application/problem+json"
 	MimeProblem = "application/problem+json"
 	//MimeRaw is the mime type for "application/octet-stream"
 	MimeRaw = "application/octet-stream"
+	//MimeText is the mime type for "text/plain"
+	MimeText = "text/plain"
 )
@@ -0,0 +1,67 @@
+// Package ndjson renders responses as newline delimited JSON, a JSON document
+// per line, so that a collection too large for a single document may be sent,
+// and read, a record at a time.
+package ndjson
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stellar/horizon/render"
+)
+
+// Writer writes records to an http response, one per line.
+type Writer struct {
+	w    http.ResponseWriter
+	sent int
+}
+
+// NewWriter returns a Writer writing to `w`.
+func NewWriter(w http.ResponseWriter) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes `record` as the next line of the response, and flushes it to
+// the client.  The response's headers are written before its first line.  A
+// record whose rendering exceeds the configured maximum body size (see
+// render.SetMaxBodySize) is not written, and render.ErrBodyTooLarge returned.
+func (nw *Writer) Write(record interface{}) error {
+	js, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	err = render.CheckBodySize(js)
+	if err != nil {
+		return err
+	}
+
+	if nw.sent == 0 {
+		nw.w.Header().Set("Content-Type", render.MimeNDJSON+"; charset=utf-8")
+		nw.w.WriteHeader(http.StatusOK)
+	}
+
+	_, err = nw.w.Write(append(js, '\n'))
+	if err != nil {
+		return err
+	}
+	nw.sent++
+
+	if f, ok := nw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// Err writes `p`, the problem that ended the response after some of its
+// records were written, as its last line:  an object whose sole "error"
+// attribute is the problem.
+func (nw *Writer) Err(p interface{}) {
+	nw.Write(map[string]interface{}{"error": p})
+}
+
+// SentCount returns the number of lines written.
+func (nw *Writer) SentCount() int {
+	return nw.sent
+}
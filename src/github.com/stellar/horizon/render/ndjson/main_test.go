@@ -0,0 +1,37 @@
+package ndjson
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/horizon/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	w := httptest.NewRecorder()
+	nw := NewWriter(w)
+
+	assert.NoError(t, nw.Write(map[string]int{"a": 1}))
+	assert.NoError(t, nw.Write(map[string]int{"b": 2}))
+	nw.Err(map[string]string{"type": "server_error"})
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "application/x-ndjson; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"a\":1}\n{\"b\":2}\n{\"error\":{\"type\":\"server_error\"}}\n", w.Body.String())
+	assert.Equal(t, 3, nw.SentCount())
+	assert.True(t, w.Flushed)
+}
+
+func TestWriter_BodySize(t *testing.T) {
+	defer render.SetMaxBodySize(0)
+	render.SetMaxBodySize(10)
+
+	w := httptest.NewRecorder()
+	nw := NewWriter(w)
+
+	assert.NoError(t, nw.Write("short"))
+	assert.Equal(t, render.ErrBodyTooLarge, nw.Write("far too long a line"))
+	assert.Equal(t, "\"short\"\n", w.Body.String())
+	assert.Equal(t, 1, nw.SentCount())
+}
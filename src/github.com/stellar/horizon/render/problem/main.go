@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/stellar/horizon/context/requestid"
+	herrors "github.com/stellar/horizon/errors"
+	"github.com/stellar/horizon/httpx"
 	"github.com/stellar/horizon/log"
 	"golang.org/x/net/context"
 )
@@ -91,7 +95,7 @@ func render(ctx context.Context, w http.ResponseWriter, p P) {
 
 	if err != nil {
 		err := errors.Wrap(err, 1)
-		log.Ctx(ctx).WithStack(err).Error(err)
+		log.Ctx(ctx).In(log.RenderSubsystem).WithStack(err).Error(err)
 		http.Error(w, "error rendering problem", http.StatusInternalServerError)
 		return
 	}
@@ -101,22 +105,62 @@ func render(ctx context.Context, w http.ResponseWriter, p P) {
 }
 
 func renderErr(ctx context.Context, w http.ResponseWriter, err error) {
-	origErr := err
+	render(ctx, w, FromError(ctx, err))
+}
 
-	if err, ok := err.(*errors.Error); ok {
-		origErr = err.Err
+// FromError returns the problem to render to clients for `err`.  A problem
+// carried by `err`, or by any error it wraps, is used as is:  a *P, an
+// implementor of HasProblem, an *errors.Problem, or an error registered with
+// RegisterError.  Any other error is unexpected, and its message, which may
+// include sql or file paths, is never shown to clients:  it is logged and
+// reported to sentry instead, and ServerError is returned in its place.  The
+// causes of *errors.Problems with a 5xx status are logged and reported too.
+func FromError(ctx context.Context, err error) P {
+	for e := err; e != nil; e = herrors.Unwrap(e) {
+		switch e := e.(type) {
+		case *P:
+			return *e
+		case HasProblem:
+			return e.Problem()
+		case *herrors.Problem:
+			p := fromProblem(e)
+			if p.Status >= http.StatusInternalServerError {
+				reportUnexpected(ctx, err)
+			}
+			return p
+		}
+
+		// errors of uncomparable types cannot be map keys, and so cannot have
+		// been registered
+		if !reflect.TypeOf(e).Comparable() {
+			continue
+		}
+
+		if p, ok := errToProblemMap[e]; ok {
+			return p
+		}
 	}
 
-	p, ok := errToProblemMap[origErr]
+	reportUnexpected(ctx, err)
+	return ServerError
+}
 
-	// If this error is not a registered error
-	// log it and replace it with a 500 error
-	if !ok {
-		log.Ctx(ctx).WithStack(err).Error(err)
-		p = ServerError
+// fromProblem returns the problem to render for `p`, typed by its status.
+func fromProblem(p *herrors.Problem) P {
+	title := http.StatusText(p.Status)
+	return P{
+		Type:   strings.Replace(strings.ToLower(title), " ", "_", -1),
+		Title:  title,
+		Status: p.Status,
+		Detail: p.Message,
 	}
+}
 
-	render(ctx, w, p)
+// reportUnexpected logs `err`, and reports it to sentry along with the request
+// being served, if any.
+func reportUnexpected(ctx context.Context, err error) {
+	log.Ctx(ctx).In(log.RenderSubsystem).WithStack(err).Error(err)
+	herrors.ReportToSentry(err, httpx.RequestFromContext(ctx))
 }
 
 // Well-known and reused problems below:
@@ -132,6 +176,19 @@ var (
 			"data in our database could be found with the parameters provided.",
 	}
 
+	// NotFoundMaybePending is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	NotFoundMaybePending = P{
+		Type:   "not_found_maybe_pending",
+		Title:  "Transaction Not Found",
+		Status: http.StatusNotFound,
+		Detail: "No transaction with the requested hash has been recorded by " +
+			"this horizon instance.  The transaction may not have been " +
+			"submitted, may still be pending inclusion in a ledger, or may not " +
+			"yet have been ingested.  Try this request again after the next " +
+			"ledger closes.",
+	}
+
 	// ServerError is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	ServerError = P{
@@ -145,6 +202,17 @@ var (
 			" Please include this response in your issue.",
 	}
 
+	// ResponseTooLarge is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	ResponseTooLarge = P{
+		Type:   "response_too_large",
+		Title:  "Response Too Large",
+		Status: http.StatusInternalServerError,
+		Detail: "The response to this request is larger than this horizon " +
+			"server is configured to send.  If requesting a collection, try " +
+			"requesting a smaller page of results using the `limit` parameter.",
+	}
+
 	// RateLimitExceeded is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	RateLimitExceeded = P{
@@ -168,6 +236,17 @@ var (
 			"been completed.",
 	}
 
+	// FeatureDisabled is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	FeatureDisabled = P{
+		Type:   "feature_disabled",
+		Title:  "Feature Disabled",
+		Status: http.StatusNotImplemented,
+		Detail: "The resource at the url requested has been disabled by the " +
+			"operator of this horizon server.  Please use another horizon " +
+			"server to access this resource.",
+	}
+
 	// NotAcceptable is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	NotAcceptable = P{
@@ -206,6 +285,28 @@ var (
 			"request again.",
 	}
 
+	// RequestTimeout is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	RequestTimeout = P{
+		Type:   "timeout",
+		Title:  "Timeout",
+		Status: http.StatusServiceUnavailable,
+		Detail: "Your request could not be completed within the time allowed by " +
+			"this horizon server, which is usually caused by the server being " +
+			"under heavy load.  Please try your request again later.",
+	}
+
+	// RequestTooLarge is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	RequestTooLarge = P{
+		Type:   "request_too_large",
+		Title:  "Request Too Large",
+		Status: http.StatusRequestEntityTooLarge,
+		Detail: "The body of this request is larger than this horizon server " +
+			"accepts, and was not read.  The `extras.max` field of this " +
+			"response gives the largest body, in bytes, that is accepted.",
+	}
+
 	// UnsupportedMediaType is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	UnsupportedMediaType = P{
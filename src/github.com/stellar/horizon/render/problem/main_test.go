@@ -2,12 +2,15 @@ package problem
 
 import (
 	"errors"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	ge "github.com/go-errors/errors"
+	pkgerrors "github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stellar/horizon/context/requestid"
+	herrors "github.com/stellar/horizon/errors"
 	"github.com/stellar/horizon/test"
 	"golang.org/x/net/context"
 )
@@ -110,4 +113,64 @@ func TestProblemPackage(t *testing.T) {
 		})
 	})
 
+	Convey("problem.FromError", t, func() {
+		ctx, _ := test.ContextWithLogBuffer()
+		errMissing := errors.New("missing")
+		RegisterError(errMissing, NotFound)
+		defer delete(errToProblemMap, errMissing)
+
+		Convey("finds registered errors however they are wrapped", func() {
+			p := FromError(ctx, pkgerrors.Wrap(ge.Wrap(errMissing, 0), "load account"))
+			So(p.Type, ShouldEqual, "not_found")
+			So(p.Status, ShouldEqual, 404)
+		})
+
+		Convey("finds wrapped problems", func() {
+			p := FromError(ctx, pkgerrors.Wrap(&BeforeHistory, "load page"))
+			So(p.Type, ShouldEqual, "before_history")
+		})
+
+		Convey("renders the public message of an errors.Problem", func() {
+			err := herrors.NewProblem(http.StatusConflict, "The offer was updated.", errors.New("pq: could not serialize access"))
+			w := testRender(ctx, pkgerrors.Wrap(err, "update offer"))
+			So(w.Code, ShouldEqual, 409)
+			So(w.Body.String(), ShouldContainSubstring, "conflict")
+			So(w.Body.String(), ShouldContainSubstring, "The offer was updated.")
+			So(w.Body.String(), ShouldNotContainSubstring, "pq:")
+		})
+
+		Convey("never renders the internal details of unexpected errors", func() {
+			ctx, log := test.ContextWithLogBuffer()
+			internal := pkgerrors.Wrap(
+				errors.New(`pq: relation "history_operations" does not exist`),
+				"open /srv/horizon/config.toml",
+			)
+
+			w := testRender(ctx, internal)
+			So(w.Code, ShouldEqual, 500)
+			So(w.Body.String(), ShouldContainSubstring, "server_error")
+			So(w.Body.String(), ShouldNotContainSubstring, "pq:")
+			So(w.Body.String(), ShouldNotContainSubstring, "history_operations")
+			So(w.Body.String(), ShouldNotContainSubstring, "/srv/horizon")
+
+			// but logs them
+			So(log.String(), ShouldContainSubstring, "history_operations")
+
+			w = testRender(ctx, herrors.NewProblem(http.StatusServiceUnavailable, "Try again later.", internal))
+			So(w.Code, ShouldEqual, 503)
+			So(w.Body.String(), ShouldContainSubstring, "Try again later.")
+			So(w.Body.String(), ShouldNotContainSubstring, "history_operations")
+		})
+
+		Convey("does not panic on errors of uncomparable types", func() {
+			p := FromError(ctx, multiError{errors.New("a"), errors.New("b")})
+			So(p.Type, ShouldEqual, "server_error")
+		})
+	})
+
 }
+
+// multiError is an error of an uncomparable type.
+type multiError []error
+
+func (m multiError) Error() string { return "multiple errors" }
@@ -0,0 +1,138 @@
+package prometheus
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+)
+
+// quantiles are the quantiles reported for summaries.
+var quantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+// Desc describes how a go-metrics value is exposed.
+type Desc struct {
+	// Name is the name of the family the value belongs to.  Values of the
+	// same family are distinguished by their Labels.
+	Name string
+	Help string
+
+	// Type, when set, overrides the type implied by the value, such as to
+	// expose a counter that is also decremented as a gauge.
+	Type string
+
+	Labels Labels
+}
+
+// Collector groups go-metrics values into families.  The zero value is ready
+// to use.
+type Collector struct {
+	families map[string]*Family
+}
+
+// Add adds the samples of `metric`, a go-metrics value, to the family
+// described by `d`.  Counters and meters are exposed as counters of their
+// count, gauges as gauges, and histograms and timers as summaries, the values
+// of timers being converted from nanoseconds to seconds.  Other values are
+// ignored.  The help and type of a family are those of the first value added
+// to it.
+func (c *Collector) Add(d Desc, metric interface{}) {
+	var (
+		typ     string
+		samples []Sample
+	)
+
+	switch m := metric.(type) {
+	case metrics.Counter:
+		typ = Counter
+		samples = []Sample{{Value: float64(m.Count())}}
+	case metrics.Meter:
+		typ = Counter
+		samples = []Sample{{Value: float64(m.Count())}}
+	case metrics.Gauge:
+		typ = Gauge
+		samples = []Sample{{Value: float64(m.Value())}}
+	case metrics.GaugeFloat64:
+		typ = Gauge
+		samples = []Sample{{Value: m.Value()}}
+	case metrics.Histogram:
+		h := m.Snapshot()
+		typ = Summary
+		samples = summary(h.Percentiles(quantiles), float64(h.Sum()), h.Count(), 1)
+	case metrics.Timer:
+		t := m.Snapshot()
+		typ = Summary
+		samples = summary(t.Percentiles(quantiles), float64(t.Sum()), t.Count(), float64(time.Second))
+	default:
+		return
+	}
+
+	if d.Type != "" {
+		typ = d.Type
+	}
+
+	f, ok := c.families[d.Name]
+	if !ok {
+		if c.families == nil {
+			c.families = map[string]*Family{}
+		}
+		f = &Family{Name: d.Name, Help: d.Help, Type: typ}
+		c.families[d.Name] = f
+	}
+
+	for _, s := range samples {
+		s.Labels = mergeLabels(d.Labels, s.Labels)
+		f.Samples = append(f.Samples, s)
+	}
+}
+
+// Families returns the families collected, ordered by name.
+func (c *Collector) Families() []Family {
+	names := make([]string, 0, len(c.families))
+	for name := range c.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	families := make([]Family, len(names))
+	for i, name := range names {
+		families[i] = *c.families[name]
+	}
+	return families
+}
+
+// summary returns the samples of a summary whose values are divided by
+// `unit`.
+func summary(ps []float64, sum float64, count int64, unit float64) []Sample {
+	samples := make([]Sample, 0, len(ps)+2)
+	for i, q := range quantiles {
+		samples = append(samples, Sample{
+			Labels: Labels{"quantile": strconv.FormatFloat(q, 'g', -1, 64)},
+			Value:  ps[i] / unit,
+		})
+	}
+
+	return append(samples,
+		Sample{Suffix: "_sum", Value: sum / unit},
+		Sample{Suffix: "_count", Value: float64(count)},
+	)
+}
+
+func mergeLabels(a, b Labels) Labels {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+
+	merged := Labels{}
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		merged[k] = v
+	}
+	return merged
+}
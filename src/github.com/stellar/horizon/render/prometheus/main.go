@@ -0,0 +1,136 @@
+// Package prometheus renders metrics in the Prometheus text exposition format
+// (version 0.0.4), so that horizon can be scraped by Prometheus.  Metrics are
+// collected from go-metrics values by a Collector, which groups them into
+// families by name.
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// The types of a metric family.
+const (
+	Counter = "counter"
+	Gauge   = "gauge"
+	Summary = "summary"
+	Untyped = "untyped"
+)
+
+// Labels are the labels of a sample, by name.
+type Labels map[string]string
+
+// Sample is a single value of a metric family.
+type Sample struct {
+	// Suffix is appended to the family's name to give the sample's name, such
+	// as "_sum" and "_count" for the samples of a summary.
+	Suffix string
+	Labels Labels
+	Value  float64
+}
+
+// Family is a group of samples that share a name, type and help string.
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// Write writes `families` to `w` in the text exposition format.
+func Write(w io.Writer, families []Family) error {
+	bw := bufio.NewWriter(w)
+
+	for _, f := range families {
+		fmt.Fprintf(bw, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+		fmt.Fprintf(bw, "# TYPE %s %s\n", f.Name, f.Type)
+
+		for _, s := range f.Samples {
+			bw.WriteString(f.Name)
+			bw.WriteString(s.Suffix)
+			writeLabels(bw, s.Labels)
+			bw.WriteByte(' ')
+			bw.WriteString(formatValue(s.Value))
+			bw.WriteByte('\n')
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Render writes `families` as the body of an http response.
+func Render(w http.ResponseWriter, families []Family) {
+	w.Header().Set("Content-Type", ContentType)
+	Write(w, families)
+}
+
+// Name returns `name` with the characters not permitted in a metric name
+// replaced by underscores.
+func Name(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', r == '_', r == ':':
+			return r
+		case '0' <= r && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func writeLabels(bw *bufio.Writer, labels Labels) {
+	if len(labels) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		fmt.Fprintf(bw, "%s=\"%s\"", name, escapeLabelValue(labels[name]))
+	}
+	bw.WriteByte('}')
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelEscaper.Replace(s)
+}
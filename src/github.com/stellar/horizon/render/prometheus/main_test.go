@@ -0,0 +1,184 @@
+package prometheus
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	families := []Family{
+		{
+			Name: "test_requests_total",
+			Help: "Requests,\nby \\ method.",
+			Type: Counter,
+			Samples: []Sample{
+				{Labels: Labels{"method": "GET", "route": `/a/"b"\c`}, Value: 3},
+				{Labels: Labels{"method": "POST", "route": "/a\nb"}, Value: 1},
+			},
+		},
+		{
+			Name: "test_lag",
+			Type: Gauge,
+			Samples: []Sample{
+				{Value: math.NaN()},
+			},
+		},
+		{
+			Name: "test_duration_seconds",
+			Help: "Durations.",
+			Type: Summary,
+			Samples: []Sample{
+				{Labels: Labels{"quantile": "0.5"}, Value: 0.25},
+				{Labels: Labels{"quantile": "0.99"}, Value: math.Inf(1)},
+				{Suffix: "_sum", Value: 1.5},
+				{Suffix: "_count", Value: 4},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, families))
+
+	out := buf.String()
+	assert.Contains(t, out, "# HELP test_requests_total Requests,\\nby \\\\ method.\n")
+	assert.Contains(t, out, "# TYPE test_requests_total counter\n")
+	assert.Contains(t, out, `test_requests_total{method="GET",route="/a/\"b\"\\c"} 3`+"\n")
+	assert.Contains(t, out, `test_requests_total{method="POST",route="/a\nb"} 1`+"\n")
+	assert.Contains(t, out, "test_lag NaN\n")
+	assert.Contains(t, out, `test_duration_seconds{quantile="0.99"} +Inf`+"\n")
+	assert.Contains(t, out, "test_duration_seconds_sum 1.5\n")
+	assert.Contains(t, out, "test_duration_seconds_count 4\n")
+
+	// prometheus parses the output back into the families it was written from
+	parsed := parse(t, &buf)
+	require.Len(t, parsed, 3)
+
+	requests := parsed["test_requests_total"]
+	assert.Equal(t, "Requests,\nby \\ method.", requests.GetHelp())
+	assert.Equal(t, dto.MetricType_COUNTER, requests.GetType())
+	require.Len(t, requests.GetMetric(), 2)
+	for i, m := range requests.GetMetric() {
+		assert.Equal(t, families[0].Samples[i].Labels, labels(m))
+		assert.Equal(t, families[0].Samples[i].Value, m.GetCounter().GetValue())
+	}
+
+	lag := parsed["test_lag"]
+	assert.Equal(t, dto.MetricType_GAUGE, lag.GetType())
+	require.Len(t, lag.GetMetric(), 1)
+	assert.True(t, math.IsNaN(lag.GetMetric()[0].GetGauge().GetValue()))
+
+	duration := parsed["test_duration_seconds"]
+	assert.Equal(t, dto.MetricType_SUMMARY, duration.GetType())
+	require.Len(t, duration.GetMetric(), 1)
+	summary := duration.GetMetric()[0].GetSummary()
+	assert.Equal(t, uint64(4), summary.GetSampleCount())
+	assert.Equal(t, 1.5, summary.GetSampleSum())
+	require.Len(t, summary.GetQuantile(), 2)
+	assert.Equal(t, 0.5, summary.GetQuantile()[0].GetQuantile())
+	assert.Equal(t, 0.25, summary.GetQuantile()[0].GetValue())
+	assert.True(t, math.IsInf(summary.GetQuantile()[1].GetValue(), 1))
+}
+
+func TestCollector(t *testing.T) {
+	counter := metrics.NewCounter()
+	counter.Inc(3)
+
+	meter := metrics.NewMeter()
+	meter.Mark(2)
+	defer meter.Stop()
+
+	gauge := metrics.NewGauge()
+	gauge.Update(7)
+
+	timer := metrics.NewTimer()
+	timer.Update(2 * time.Second)
+	timer.Update(4 * time.Second)
+	defer timer.Stop()
+
+	get := metrics.NewTimer()
+	get.Update(time.Second)
+	defer get.Stop()
+
+	var c Collector
+	c.Add(Desc{Name: "test_counter_total", Help: "A counter."}, counter)
+	c.Add(Desc{Name: "test_meter_total"}, meter)
+	c.Add(Desc{Name: "test_gauge"}, gauge)
+	c.Add(Desc{Name: "test_open", Type: Gauge}, counter)
+	c.Add(Desc{Name: "test_duration_seconds", Labels: Labels{"method": "POST"}}, timer)
+	c.Add(Desc{Name: "test_duration_seconds", Labels: Labels{"method": "GET"}}, get)
+	c.Add(Desc{Name: "test_healthcheck"}, metrics.NewHealthcheck(func(metrics.Healthcheck) {}))
+
+	families := c.Families()
+	require.Len(t, families, 6)
+
+	byName := map[string]Family{}
+	for _, f := range families {
+		byName[f.Name] = f
+	}
+
+	assert.Equal(t, "test_counter_total", families[0].Name, "families are ordered by name")
+	assert.NotContains(t, byName, "test_healthcheck")
+
+	f := byName["test_counter_total"]
+	assert.Equal(t, Counter, f.Type)
+	assert.Equal(t, "A counter.", f.Help)
+	assert.Equal(t, []Sample{{Value: 3}}, f.Samples)
+
+	assert.Equal(t, Counter, byName["test_meter_total"].Type)
+	assert.Equal(t, float64(2), byName["test_meter_total"].Samples[0].Value)
+	assert.Equal(t, Gauge, byName["test_gauge"].Type)
+	assert.Equal(t, float64(7), byName["test_gauge"].Samples[0].Value)
+	assert.Equal(t, Gauge, byName["test_open"].Type)
+
+	f = byName["test_duration_seconds"]
+	assert.Equal(t, Summary, f.Type)
+	require.Len(t, f.Samples, 2*(len(quantiles)+2))
+
+	post := f.Samples[:len(quantiles)+2]
+	for _, s := range post {
+		assert.Equal(t, "POST", s.Labels["method"])
+	}
+	assert.Equal(t, "0.5", post[0].Labels["quantile"])
+	assert.Equal(t, float64(3), post[0].Value, "timers are reported in seconds")
+	assert.Equal(t, Sample{Suffix: "_sum", Labels: Labels{"method": "POST"}, Value: 6}, post[len(quantiles)])
+	assert.Equal(t, Sample{Suffix: "_count", Labels: Labels{"method": "POST"}, Value: 2}, post[len(quantiles)+1])
+
+	// what is collected is valid exposition
+	w := httptest.NewRecorder()
+	Render(w, families)
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+
+	assert.Len(t, parse(t, w.Body), 5)
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "horizon_requests_total", Name("horizon_requests.total"))
+	assert.Equal(t, "a:b_c_d", Name("a:b-c d"))
+}
+
+// parse parses the text exposition format in `r` as prometheus does.
+func parse(t *testing.T, r io.Reader) map[string]*dto.MetricFamily {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	require.NoError(t, err)
+	return families
+}
+
+// labels returns the labels of the parsed metric `m`.
+func labels(m *dto.Metric) Labels {
+	ret := Labels{}
+	for _, l := range m.GetLabel() {
+		ret[l.GetName()] = l.GetValue()
+	}
+	return ret
+}
@@ -1,12 +1,13 @@
 package sse
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"sync"
 
 	"github.com/stellar/horizon/log"
+	"github.com/stellar/horizon/render"
+	"github.com/stellar/horizon/render/hal"
 	"golang.org/x/net/context"
 )
 
@@ -64,7 +65,6 @@ func WritePreamble(ctx context.Context, w http.ResponseWriter) bool {
 	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.WriteHeader(200)
 
 	WriteEvent(ctx, w, helloEvent)
@@ -73,14 +73,31 @@ func WritePreamble(ctx context.Context, w http.ResponseWriter) bool {
 }
 
 // WriteEvent does the actual work of formatting an SSE compliant message
-// sending it over the provided ResponseWriter and flushing.
+// sending it over the provided ResponseWriter and flushing.  An event whose
+// data exceeds the configured maximum body size (see render.SetMaxBodySize) is
+// replaced by an error event.
 func WriteEvent(ctx context.Context, w http.ResponseWriter, e Event) {
+	writeEvent(ctx, w, e)
+}
+
+// writeEvent writes `e` to `w`, returning render.ErrBodyTooLarge if the event
+// was replaced by an error event due to its size.
+func writeEvent(ctx context.Context, w http.ResponseWriter, e Event) error {
 	if e.Error != nil {
 		fmt.Fprint(w, "event: err\n")
 		fmt.Fprintf(w, "data: %s\n\n", e.Error.Error())
 		w.(http.Flusher).Flush()
-		log.Ctx(ctx).Error(e.Error)
-		return
+		log.Ctx(ctx).In(log.RenderSubsystem).Error(e.Error)
+		return nil
+	}
+
+	data := render.NewLimitedBuffer()
+	if err := hal.Encode(data, e.Data, false); err != nil {
+		if err != render.ErrBodyTooLarge {
+			panic(err)
+		}
+		writeEvent(ctx, w, Event{Error: err})
+		return err
 	}
 
 	// TODO: add tests to ensure retry get's properly rendered
@@ -96,8 +113,9 @@ func WriteEvent(ctx context.Context, w http.ResponseWriter, e Event) {
 		fmt.Fprintf(w, "event: %s\n", e.Event)
 	}
 
-	fmt.Fprintf(w, "data: %s\n\n", getJSON(e.Data))
+	fmt.Fprintf(w, "data: %s\n\n", data.Bytes())
 	w.(http.Flusher).Flush()
+	return nil
 }
 
 // Upon successful completion of a query (i.e. the client didn't disconnect
@@ -122,16 +140,6 @@ var helloEvent = Event{
 var lock sync.Mutex
 var nextTick chan struct{}
 
-func getJSON(val interface{}) string {
-	js, err := json.Marshal(val)
-
-	if err != nil {
-		panic(err)
-	}
-
-	return string(js)
-}
-
 func init() {
 	lock.Lock()
 	nextTick = make(chan struct{})
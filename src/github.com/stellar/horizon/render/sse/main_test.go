@@ -2,10 +2,12 @@ package sse
 
 import (
 	"errors"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stellar/horizon/render"
 	"github.com/stellar/horizon/test"
 )
 
@@ -31,10 +33,46 @@ func TestSsePackage(t *testing.T) {
 		}
 	})
 
+	Convey("sse.WriteEvent replaces oversized events with an error", t, func() {
+		render.SetMaxBodySize(16)
+		defer render.SetMaxBodySize(0)
+
+		w := httptest.NewRecorder()
+		WriteEvent(ctx, w, Event{Data: "small"})
+		So(w.Body.String(), ShouldContainSubstring, "data: \"small\"\n\n")
+
+		w = httptest.NewRecorder()
+		WriteEvent(ctx, w, Event{Data: "a string much longer than sixteen bytes"})
+		So(w.Body.String(), ShouldNotContainSubstring, "sixteen")
+		So(w.Body.String(), ShouldContainSubstring, "event: err\n")
+
+		Convey("and ends the stream", func() {
+			r, _ := http.NewRequest("GET", "/", nil)
+			s := NewStream(ctx, httptest.NewRecorder(), r)
+			s.Send(Event{Data: "a string much longer than sixteen bytes"})
+			So(s.IsDone(), ShouldBeTrue)
+			So(s.SentCount(), ShouldEqual, 0)
+		})
+	})
+
 	Convey("sse.WriteEvent logs errors", t, func() {
 		w := httptest.NewRecorder()
 		WriteEvent(ctx, w, Event{Error: errors.New("busted")})
 		So(log.String(), ShouldContainSubstring, "level=error")
 		So(log.String(), ShouldContainSubstring, "busted")
 	})
+
+	Convey("sse.NewStream counts the streams open and opened", t, func() {
+		open := DefaultStreamMetrics.Open.Count()
+		opened := DefaultStreamMetrics.Opened.Count()
+
+		r, _ := http.NewRequest("GET", "/", nil)
+		s := NewStream(ctx, httptest.NewRecorder(), r)
+		So(DefaultStreamMetrics.Open.Count(), ShouldEqual, open+1)
+		So(DefaultStreamMetrics.Opened.Count(), ShouldEqual, opened+1)
+
+		s.Close()
+		So(DefaultStreamMetrics.Open.Count(), ShouldEqual, open)
+		So(DefaultStreamMetrics.Opened.Count(), ShouldEqual, opened+1)
+	})
 }
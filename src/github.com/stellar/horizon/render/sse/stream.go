@@ -3,9 +3,25 @@ package sse
 import (
 	"net/http"
 
+	"github.com/rcrowley/go-metrics"
 	"golang.org/x/net/context"
 )
 
+// StreamMetrics counts the streams created by NewStream.
+type StreamMetrics struct {
+	// Open counts the streams that have not yet been closed.
+	Open metrics.Counter
+
+	// Opened counts every stream created.
+	Opened metrics.Counter
+}
+
+// DefaultStreamMetrics counts the streams created by NewStream.
+var DefaultStreamMetrics = StreamMetrics{
+	Open:   metrics.NewCounter(),
+	Opened: metrics.NewCounter(),
+}
+
 // Stream represents an output stream that data can be written to
 type Stream interface {
 	Send(Event)
@@ -14,10 +30,17 @@ type Stream interface {
 	SetLimit(limit int)
 	IsDone() bool
 	Err(error)
+
+	// Close records that the request served by the stream has been handled.
+	// It must be called once for each stream.
+	Close()
 }
 
 // NewStream creates a new stream against the provided response writer
 func NewStream(ctx context.Context, w http.ResponseWriter, r *http.Request) Stream {
+	DefaultStreamMetrics.Open.Inc(1)
+	DefaultStreamMetrics.Opened.Inc(1)
+
 	result := &stream{ctx, w, r, false, 0, 0}
 	return result
 }
@@ -40,7 +63,14 @@ func (s *stream) Send(e Event) {
 		}
 	}
 
-	WriteEvent(s.ctx, s.w, e)
+	err := writeEvent(s.ctx, s.w, e)
+	if err != nil {
+		// the client has been notified via an error event; end the stream
+		// rather than silently skipping data.
+		s.done = true
+		return
+	}
+
 	s.sent++
 }
 
@@ -69,3 +99,7 @@ func (s *stream) Err(err error) {
 	WriteEvent(s.ctx, s.w, Event{Error: err})
 	s.done = true
 }
+
+func (s *stream) Close() {
+	DefaultStreamMetrics.Open.Dec(1)
+}
@@ -2,7 +2,9 @@ package resource
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/guregu/null"
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/httpx"
@@ -26,6 +28,12 @@ func (this *Account) Populate(
 	this.InflationDestination = ca.Inflationdest.String
 	this.HomeDomain = ca.HomeDomain.String
 
+	this.CreatedLedger = ha.CreatedLedger
+	this.CreatedAt = ha.CreatedAt
+	if ha.CreatedLedger.Valid {
+		this.CreatedBeforeHistory = null.BoolFrom(false)
+	}
+
 	this.Flags.Populate(ca)
 	this.Thresholds.Populate(ca)
 
@@ -44,6 +52,8 @@ func (this *Account) Populate(
 		return
 	}
 
+	sort.Sort(canonicalBalances(this.Balances))
+
 	// populate data
 	this.Data = make(map[string]string)
 	for _, d := range cd {
@@ -7,4 +7,5 @@ import (
 func (this *AccountFlags) Populate(row core.Account) {
 	this.AuthRequired = row.IsAuthRequired()
 	this.AuthRevocable = row.IsAuthRevocable()
+	this.AuthImmutable = row.IsAuthImmutable()
 }
@@ -0,0 +1,30 @@
+package resource
+
+import (
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/db2/core"
+	"github.com/stellar/horizon/db2/history"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource's fields, its paging token being that of
+// `row` when ordered by `orderBy`.
+func (res *AssetStat) Populate(
+	ctx context.Context,
+	row history.AssetStat,
+	orderBy string,
+) {
+	res.Type = row.AssetType
+	res.Code = row.AssetCode
+	res.Issuer = row.AssetIssuer
+	res.PT = row.PagingToken(orderBy)
+	res.Amount = amount.String(xdr.Int64(row.Amount))
+	res.NumAccounts = row.NumAccounts
+	res.Flags.Populate(core.Account{Flags: xdr.AccountFlags(row.Flags)})
+}
+
+// PagingToken implementation for hal.Pageable
+func (res AssetStat) PagingToken() string {
+	return res.PT
+}
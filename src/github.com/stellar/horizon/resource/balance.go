@@ -18,6 +18,9 @@ func (this *Balance) Populate(ctx context.Context, row core.Trustline) (err erro
 	this.Limit = amount.String(row.Tlimit)
 	this.Issuer = row.Issuer
 	this.Code = row.Assetcode
+
+	authorized := row.IsAuthorized()
+	this.IsAuthorized = &authorized
 	return
 }
 
@@ -31,5 +34,27 @@ func (this *Balance) PopulateNative(stroops xdr.Int64) (err error) {
 	this.Limit = ""
 	this.Issuer = ""
 	this.Code = ""
+	this.IsAuthorized = nil
 	return
 }
+
+// canonicalBalances sorts balances into their canonical order, so that the
+// balances of an account render identically from one request to the next:
+// lumens first, then credits by asset code and then by issuer.
+type canonicalBalances []Balance
+
+func (b canonicalBalances) Len() int      { return len(b) }
+func (b canonicalBalances) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+
+func (b canonicalBalances) Less(i, j int) bool {
+	ni, nj := b[i].Type == "native", b[j].Type == "native"
+	if ni != nj {
+		return ni
+	}
+
+	if b[i].Code != b[j].Code {
+		return b[i].Code < b[j].Code
+	}
+
+	return b[i].Issuer < b[j].Issuer
+}
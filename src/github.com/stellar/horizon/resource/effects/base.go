@@ -4,6 +4,7 @@ import (
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/httpx"
 	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/toid"
 	"golang.org/x/net/context"
 )
 
@@ -20,7 +21,13 @@ func (this *Base) Populate(ctx context.Context, row history.Effect) {
 	this.populateType(row)
 
 	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
-	this.Links.Operation = lb.Linkf("/operations/%d", row.HistoryOperationID)
+
+	// the fee of a failed transaction is recorded against the transaction,
+	// which has no operations to link to
+	if toid.Parse(row.HistoryOperationID).OperationOrder != 0 {
+		op := lb.Linkf("/operations/%d", row.HistoryOperationID)
+		this.Links.Operation = &op
+	}
 	this.Links.Succeeds = lb.Linkf("/effects?order=desc&cursor=%s", this.PT)
 	this.Links.Precedes = lb.Linkf("/effects?order=asc&cursor=%s", this.PT)
 }
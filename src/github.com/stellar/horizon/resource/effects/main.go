@@ -110,9 +110,9 @@ func New(
 
 type Base struct {
 	Links struct {
-		Operation hal.Link `json:"operation"`
-		Succeeds  hal.Link `json:"succeeds"`
-		Precedes  hal.Link `json:"precedes"`
+		Operation *hal.Link `json:"operation,omitempty"`
+		Succeeds  hal.Link  `json:"succeeds"`
+		Precedes  hal.Link  `json:"precedes"`
 	} `json:"_links"`
 
 	ID      string `json:"id"`
@@ -137,6 +137,11 @@ type AccountDebited struct {
 	Base
 	base.Asset
 	Amount string `json:"amount"`
+
+	// Fee and TransactionHash are set on effects recording the fee charged for
+	// a failed transaction.
+	Fee             bool   `json:"fee,omitempty"`
+	TransactionHash string `json:"transaction_hash,omitempty"`
 }
 
 type AccountThresholdsUpdated struct {
@@ -0,0 +1,76 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/stellar/horizon/httpx"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/render/hal"
+	"golang.org/x/net/context"
+)
+
+// The statuses of a Health.
+const (
+	HealthHealthy   = "healthy"
+	HealthDegraded  = "degraded"
+	HealthUnhealthy = "unhealthy"
+)
+
+// HealthLimits are the thresholds beyond which horizon is judged degraded or
+// unhealthy (see Health.Populate).  A threshold of 0 is disabled.
+type HealthLimits struct {
+	// DegradedLag and UnhealthyLag are the number of ledgers the history
+	// database may trail stellar-core by.
+	DegradedLag  int32
+	UnhealthyLag int32
+
+	// MaxStateAge is how long ago the ledger state may have been refreshed.
+	MaxStateAge time.Duration
+}
+
+// Populate fills out the health of horizon from the outcome of pinging its
+// databases, `coreErr` and `horizonErr`, the ledger state `ls`, refreshed
+// `age` ago, and the judgement of whether ingestion has stalled, `stall`.
+// Horizon is unhealthy should either database fail to answer, ingestion have
+// stalled, the ledger state be older than the limits allow, or the history
+// database trail stellar-core by more than their unhealthy lag.  It is
+// degraded should history trail by more than their degraded lag.  The age of
+// the ledger state matters as the lag is taken from it:  were its refresh to
+// hang, the lag would freeze at its last value while ingestion falls behind.
+func (res *Health) Populate(
+	ctx context.Context,
+	ls ledger.State,
+	age time.Duration,
+	stall ledger.Stall,
+	coreErr, horizonErr error,
+	limits HealthLimits,
+) {
+	res.CoreDB.Reachable = coreErr == nil
+	res.HorizonDB.Reachable = horizonErr == nil
+	res.CoreLatest = ls.CoreLatest
+	res.HistoryLatest = ls.HistoryLatest
+	res.LedgerStateAge = int64(age / time.Millisecond)
+	res.IngestionStalled = stall.Stalled
+
+	if ls.CoreLatest > ls.HistoryLatest {
+		res.IngestionLag = ls.CoreLatest - ls.HistoryLatest
+	}
+
+	switch {
+	case !res.CoreDB.Reachable || !res.HorizonDB.Reachable:
+		res.Status = HealthUnhealthy
+	case res.IngestionStalled:
+		res.Status = HealthUnhealthy
+	case limits.MaxStateAge > 0 && age > limits.MaxStateAge:
+		res.Status = HealthUnhealthy
+	case limits.UnhealthyLag > 0 && res.IngestionLag > limits.UnhealthyLag:
+		res.Status = HealthUnhealthy
+	case limits.DegradedLag > 0 && res.IngestionLag > limits.DegradedLag:
+		res.Status = HealthDegraded
+	default:
+		res.Status = HealthHealthy
+	}
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Link("/health")
+}
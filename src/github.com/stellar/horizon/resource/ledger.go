@@ -32,10 +32,23 @@ func (this *Ledger) Populate(ctx context.Context, row history.Ledger) {
 	this.Links.Operations = lb.PagedLink(self, "operations")
 	this.Links.Payments = lb.PagedLink(self, "payments")
 	this.Links.Effects = lb.PagedLink(self, "effects")
+	this.Links.Upgrades = lb.Link(self, "upgrades")
 
 	return
 }
 
+// IncludeExtended includes in the resource the extended attributes of `row`
+// (see LedgerExtended).
+func (this *Ledger) IncludeExtended(row history.Ledger) {
+	this.LedgerExtended = &LedgerExtended{
+		ProtocolVersion:            row.ProtocolVersion,
+		SuccessfulTransactionCount: row.TransactionCount,
+		FailedTransactionCount:     row.FailedTransactionCount,
+		IngestedAt:                 row.CreatedAt,
+		IngestDurationMs:           row.IngestDurationMs,
+	}
+}
+
 func (this Ledger) PagingToken() string {
 	return this.PT
 }
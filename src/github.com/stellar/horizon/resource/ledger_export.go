@@ -0,0 +1,85 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/toid"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource from the records of `ledger`:  its
+// transactions, operations and effects, each in the order they were applied.
+// The raw xdr of the transactions named by `raws` is warned about when
+// unavailable, as by Transaction.IncludeRaw.
+func (res *LedgerExport) Populate(
+	ctx context.Context,
+	ledger history.Ledger,
+	txs []history.Transaction,
+	ops []history.Operation,
+	effects []history.Effect,
+	raws []string,
+) error {
+	res.Ledger.Populate(ctx, ledger)
+
+	// the positions of each transaction, and of each operation within its
+	// transaction, by id.
+	txIndex := map[int64]int{}
+	opIndex := map[int64][2]int{}
+
+	res.Transactions = make([]LedgerExportTransaction, 0, len(txs))
+	for _, row := range txs {
+		var tx LedgerExportTransaction
+		tx.Transaction.Populate(ctx, row)
+		tx.Transaction.IncludeRaw(row, raws)
+		tx.Operations = []LedgerExportOperation{}
+
+		txIndex[row.ID] = len(res.Transactions)
+		res.Transactions = append(res.Transactions, tx)
+	}
+
+	for _, row := range ops {
+		i, ok := txIndex[row.TransactionID]
+		if !ok {
+			return fmt.Errorf("operation %d: transaction %d not exported", row.ID, row.TransactionID)
+		}
+
+		op, err := NewOperation(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		tx := &res.Transactions[i]
+		opIndex[row.ID] = [2]int{i, len(tx.Operations)}
+		tx.Operations = append(tx.Operations, LedgerExportOperation{
+			Operation: op,
+			Effects:   []hal.Pageable{},
+		})
+	}
+
+	for _, row := range effects {
+		effect, err := NewEffect(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		if pos, ok := opIndex[row.HistoryOperationID]; ok {
+			op := &res.Transactions[pos[0]].Operations[pos[1]]
+			op.Effects = append(op.Effects, effect)
+			continue
+		}
+
+		id := toid.Parse(row.HistoryOperationID)
+		id.OperationOrder = 0
+		if i, ok := txIndex[id.ToInt64()]; ok {
+			tx := &res.Transactions[i]
+			tx.Effects = append(tx.Effects, effect)
+			continue
+		}
+
+		res.Effects = append(res.Effects, effect)
+	}
+
+	return nil
+}
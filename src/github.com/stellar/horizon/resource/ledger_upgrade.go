@@ -0,0 +1,29 @@
+package resource
+
+import (
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/db2/history"
+	"golang.org/x/net/context"
+)
+
+// LedgerUpgradeTypeNames maps from ledger upgrade type to the name used for
+// that type in the `type` field of a LedgerUpgrade resource.
+var LedgerUpgradeTypeNames = map[xdr.LedgerUpgradeType]string{
+	xdr.LedgerUpgradeTypeLedgerUpgradeVersion:      "version",
+	xdr.LedgerUpgradeTypeLedgerUpgradeBaseFee:      "base_fee",
+	xdr.LedgerUpgradeTypeLedgerUpgradeMaxTxSetSize: "max_tx_set_size",
+}
+
+// Populate fills out the resource's fields
+func (this *LedgerUpgrade) Populate(ctx context.Context, row history.LedgerUpgrade) {
+	this.Ledger = row.LedgerSequence
+	this.ApplicationOrder = row.ApplicationOrder
+	this.Type = LedgerUpgradeTypeNames[row.Type]
+	this.TypeI = int32(row.Type)
+	this.Value = row.Value
+}
+
+// stub implementation to satisfy pageable interface
+func (this LedgerUpgrade) PagingToken() string {
+	return ""
+}
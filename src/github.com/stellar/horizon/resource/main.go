@@ -5,6 +5,7 @@ package resource
 import (
 	"time"
 
+	"github.com/guregu/null"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/render/hal"
 	"github.com/stellar/horizon/resource/base"
@@ -34,12 +35,21 @@ type Account struct {
 	Balances             []Balance         `json:"balances"`
 	Signers              []Signer          `json:"signers"`
 	Data                 map[string]string `json:"data"`
+
+	// CreatedLedger and CreatedAt identify the ledger in which the account was
+	// first created, and are null when it is not known.  CreatedBeforeHistory
+	// is true when that ledger is known to precede the history horizon has
+	// ingested, and null when neither is known.
+	CreatedLedger        null.Int   `json:"created_ledger"`
+	CreatedAt            *time.Time `json:"created_at"`
+	CreatedBeforeHistory null.Bool  `json:"created_before_history"`
 }
 
 // AccountFlags represents the state of an account's flags
 type AccountFlags struct {
 	AuthRequired  bool `json:"auth_required"`
 	AuthRevocable bool `json:"auth_revocable"`
+	AuthImmutable bool `json:"auth_immutable"`
 }
 
 // AccountThresholds represents an accounts "thresholds", the numerical values
@@ -53,11 +63,58 @@ type AccountThresholds struct {
 // Asset represents a single asset
 type Asset base.Asset
 
+// AssetStat represents the stats of a single non-native asset, as maintained
+// during ingestion.
+type AssetStat struct {
+	base.Asset
+	PT          string       `json:"paging_token"`
+	Amount      string       `json:"amount"`
+	NumAccounts int32        `json:"num_accounts"`
+	Flags       AccountFlags `json:"flags"`
+}
+
 // Balance represents an account's holdings for a single currency type
 type Balance struct {
 	Balance string `json:"balance"`
 	Limit   string `json:"limit,omitempty"`
 	base.Asset
+
+	// IsAuthorized reports whether the issuer of the asset has authorized the
+	// account to hold it.  It is omitted from the lumens balance.
+	IsAuthorized *bool `json:"is_authorized,omitempty"`
+}
+
+// Health reports whether a horizon instance is fit to serve requests:  whether
+// its databases answer, and how far ingestion trails stellar-core.
+type Health struct {
+	Links struct {
+		Self hal.Link `json:"self"`
+	} `json:"_links"`
+
+	// Status is HealthHealthy, HealthDegraded or HealthUnhealthy.
+	Status string `json:"status"`
+
+	CoreDB        DatabaseHealth `json:"core_db"`
+	HorizonDB     DatabaseHealth `json:"horizon_db"`
+	CoreLatest    int32          `json:"core_latest_ledger"`
+	HistoryLatest int32          `json:"history_latest_ledger"`
+
+	// IngestionLag is the number of ledgers by which the history database
+	// trails stellar-core.
+	IngestionLag int32 `json:"ingestion_lag"`
+
+	// LedgerStateAge is the number of milliseconds since the ledger state the
+	// latest ledgers are taken from was refreshed.
+	LedgerStateAge int64 `json:"ledger_state_age_ms"`
+
+	// IngestionStalled is set when the history database has not advanced for
+	// the ingestion stall grace period while behind stellar-core.
+	IngestionStalled bool `json:"ingestion_stalled"`
+}
+
+// DatabaseHealth reports whether a database answered horizon's ping.
+type DatabaseHealth struct {
+	Reachable bool `json:"reachable"`
 }
 
 // HistoryAccount is a simple resource, used for the account collection actions.
@@ -76,6 +133,7 @@ type Ledger struct {
 		Operations   hal.Link `json:"operations"`
 		Payments     hal.Link `json:"payments"`
 		Effects      hal.Link `json:"effects"`
+		Upgrades     hal.Link `json:"upgrades"`
 	} `json:"_links"`
 	ID               string    `json:"id"`
 	PT               string    `json:"paging_token"`
@@ -90,6 +148,58 @@ type Ledger struct {
 	BaseFee          int32     `json:"base_fee"`
 	BaseReserve      string    `json:"base_reserve"`
 	MaxTxSetSize     int32     `json:"max_tx_set_size"`
+
+	// The extended attributes are included only when requested (see
+	// IncludeExtended).
+	*LedgerExtended
+}
+
+// LedgerExtended holds the attributes of a Ledger that are included only when
+// requested with the `extended` parameter.  Those recorded by ingestion are
+// null for ledgers ingested by older versions of horizon.
+type LedgerExtended struct {
+	ProtocolVersion            null.Int  `json:"protocol_version"`
+	SuccessfulTransactionCount int32     `json:"successful_transaction_count"`
+	FailedTransactionCount     null.Int  `json:"failed_transaction_count"`
+	IngestedAt                 time.Time `json:"ingested_at"`
+	IngestDurationMs           null.Int  `json:"ingest_duration_ms"`
+}
+
+// LedgerExport is the full contents of a single closed ledger:  its header,
+// and every transaction it applied, each with its operations and their
+// effects.
+type LedgerExport struct {
+	Ledger       Ledger                    `json:"ledger"`
+	Transactions []LedgerExportTransaction `json:"transactions"`
+
+	// Effects are those not attributable to a transaction of the export, such
+	// as the fees charged for failed transactions that were not stored.
+	Effects []hal.Pageable `json:"effects,omitempty"`
+}
+
+// LedgerExportTransaction is a transaction of a LedgerExport, with its
+// operations.  Effects are those attributed to the transaction itself rather
+// than to an operation, such as the fee charged for a failed transaction.
+type LedgerExportTransaction struct {
+	Transaction Transaction             `json:"transaction"`
+	Operations  []LedgerExportOperation `json:"operations"`
+	Effects     []hal.Pageable          `json:"effects,omitempty"`
+}
+
+// LedgerExportOperation is an operation of a LedgerExport, with its effects.
+type LedgerExportOperation struct {
+	Operation hal.Pageable   `json:"operation"`
+	Effects   []hal.Pageable `json:"effects"`
+}
+
+// LedgerUpgrade represents a single change to a network parameter that was
+// applied when a ledger closed.
+type LedgerUpgrade struct {
+	Ledger           int32  `json:"ledger"`
+	ApplicationOrder int32  `json:"application_order"`
+	Type             string `json:"type"`
+	TypeI            int32  `json:"type_i"`
+	Value            int64  `json:"value"`
 }
 
 // Offer is the display form of an offer to trade currency.
@@ -109,12 +219,42 @@ type Offer struct {
 	Price   string `json:"price"`
 }
 
+// OperationLocator is an operation id, as used in paging tokens, decoded into
+// the position it names:  the ledger, the order of the transaction within it
+// and the index of the operation within that transaction.  The transaction is
+// linked only when it is found in history.
+type OperationLocator struct {
+	Links struct {
+		Self        hal.Link  `json:"self"`
+		Operation   hal.Link  `json:"operation"`
+		Ledger      hal.Link  `json:"ledger"`
+		Transaction *hal.Link `json:"transaction,omitempty"`
+	} `json:"_links"`
+	ID               string `json:"id"`
+	LedgerSequence   int32  `json:"ledger_sequence"`
+	TransactionOrder int32  `json:"transaction_order"`
+	OperationIndex   int32  `json:"operation_index"`
+	TransactionHash  string `json:"transaction_hash,omitempty"`
+}
+
 // OrderBookSummary represents a snapshot summary of a given order book
 type OrderBookSummary struct {
 	Bids    []PriceLevel `json:"bids"`
 	Asks    []PriceLevel `json:"asks"`
 	Selling Asset        `json:"base"`
 	Buying  Asset        `json:"counter"`
+
+	// BidsRemainder and AsksRemainder summarize the price levels of each side
+	// beyond those included in Bids and Asks.
+	BidsRemainder OrderBookRemainder `json:"bids_remainder"`
+	AsksRemainder OrderBookRemainder `json:"asks_remainder"`
+}
+
+// OrderBookRemainder summarizes the price levels of one side of an order book
+// that are beyond the depth of an OrderBookSummary.
+type OrderBookRemainder struct {
+	PriceLevels int32  `json:"price_levels"`
+	Amount      string `json:"amount"`
 }
 
 // Path represents a single payment path.
@@ -130,6 +270,25 @@ type Path struct {
 	Path                   []Asset `json:"path"`
 }
 
+// PathExplanation is a diagnostic breakdown of a path search, describing
+// which assets were considered and where the search ended.
+type PathExplanation struct {
+	DestinationTrusted bool                  `json:"destination_trusted"`
+	StoppedBy          string                `json:"stopped_by"`
+	Considered         []PathExplanationStep `json:"considered"`
+}
+
+// PathExplanationStep describes how a single asset was handled by a path
+// search.
+type PathExplanationStep struct {
+	Asset                 Asset   `json:"asset"`
+	Depth                 int     `json:"depth"`
+	SourceAsset           bool    `json:"source_asset"`
+	Outcome               string  `json:"outcome"`
+	OrderBooks            int     `json:"order_books"`
+	InsufficientLiquidity []Asset `json:"insufficient_liquidity"`
+}
+
 // Price represents a price
 type Price base.Price
 
@@ -153,13 +312,23 @@ type Root struct {
 		Transactions        hal.Link `json:"transactions"`
 	} `json:"_links"`
 
-	HorizonVersion       string `json:"horizon_version"`
-	StellarCoreVersion   string `json:"core_version"`
-	HorizonSequence      int32  `json:"history_latest_ledger"`
-	HistoryElderSequence int32  `json:"history_elder_ledger"`
-	CoreSequence         int32  `json:"core_latest_ledger"`
-	CoreElderSequence    int32  `json:"core_elder_ledger"`
-	NetworkPassphrase    string `json:"network_passphrase"`
+	HorizonVersion        string    `json:"horizon_version"`
+	StellarCoreVersion    string    `json:"core_version"`
+	CoreInfoStale         bool      `json:"core_info_stale"`
+	HorizonSequence       int32     `json:"history_latest_ledger"`
+	HistoryLatestHash     string    `json:"history_latest_ledger_hash"`
+	HistoryLatestClosedAt time.Time `json:"history_latest_ledger_closed_at"`
+	HistoryElderSequence  int32     `json:"history_elder_ledger"`
+	CoreSequence          int32     `json:"core_latest_ledger"`
+	CoreLatestHash        string    `json:"core_latest_ledger_hash"`
+	CoreLatestClosedAt    time.Time `json:"core_latest_ledger_closed_at"`
+	CoreElderSequence     int32     `json:"core_elder_ledger"`
+	NetworkPassphrase     string    `json:"network_passphrase"`
+	ProtocolVersion       int32     `json:"protocol_version"`
+	BaseFee               int32     `json:"base_fee"`
+	BaseReserve           int32     `json:"base_reserve"`
+	MaxTxSetSize          int32     `json:"max_tx_set_size"`
+	Capabilities          []string  `json:"capabilities"`
 }
 
 // Signer represents one of an account's signers.
@@ -168,6 +337,66 @@ type Signer struct {
 	Weight    int32  `json:"weight"`
 }
 
+// SignerChange represents a single change to an account's signers: the
+// addition, removal or reweighting of one signer.
+type SignerChange struct {
+	Links struct {
+		Account     hal.Link `json:"account"`
+		Operation   hal.Link `json:"operation"`
+		Transaction hal.Link `json:"transaction"`
+		Ledger      hal.Link `json:"ledger"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	Account         string    `json:"account"`
+	Type            string    `json:"type"`
+	TypeI           int32     `json:"type_i"`
+	PublicKey       string    `json:"public_key"`
+	Weight          int32     `json:"weight"`
+	TransactionHash string    `json:"transaction_hash"`
+	Ledger          int32     `json:"ledger"`
+	LedgerCloseTime time.Time `json:"created_at"`
+}
+
+// SpendableAsset is the amount of an asset held by an account that it may
+// spend:  its balance, less the amount it offers for sale and, for lumens, the
+// reserve it must maintain.
+type SpendableAsset struct {
+	Balance            string `json:"balance"`
+	SellingLiabilities string `json:"selling_liabilities"`
+	Reserve            string `json:"reserve,omitempty"`
+	Spendable          string `json:"spendable"`
+	base.Asset
+}
+
+// Stats summarizes the network's activity from the running totals maintained
+// during ingestion:  the totals as of the latest ledger in the history
+// database, and the operations of recent ledgers.  Figures horizon does not
+// know, for example because the history database does not reach back far
+// enough, are null.
+type Stats struct {
+	Links struct {
+		Self   hal.Link `json:"self"`
+		Ledger hal.Link `json:"ledger"`
+	} `json:"_links"`
+
+	LatestLedger    int32     `json:"latest_ledger"`
+	ClosedAt        time.Time `json:"closed_at"`
+	TotalAccounts   null.Int  `json:"total_accounts"`
+	TotalTrustlines null.Int  `json:"total_trustlines"`
+	TotalOperations null.Int  `json:"total_operations"`
+
+	// OperationsLast24h counts the operations of the ledgers that closed in
+	// the 24 hours before the latest ledger closed.
+	OperationsLast24h null.Int `json:"operations_last_24h"`
+
+	// AverageOperationsPerLedger is the mean number of operations of the
+	// latest AverageWindow ledgers.
+	AverageOperationsPerLedger null.Float `json:"average_operations_per_ledger"`
+	AverageWindow              int32      `json:"average_window"`
+}
+
 // Trade represents a trade effect
 type Trade struct {
 	Links struct {
@@ -188,6 +417,22 @@ type Trade struct {
 	BoughtAssetIssuer string `json:"bought_asset_issuer,omitempty"`
 }
 
+// TradeAggregation summarizes the trades of an asset pair made within a span
+// of time:  their volumes, and the prices of the first, last, highest and
+// lowest priced of them, in units of the counter asset per unit of the base
+// asset.
+type TradeAggregation struct {
+	Timestamp     time.Time `json:"timestamp"`
+	PT            string    `json:"paging_token"`
+	TradeCount    int32     `json:"trade_count"`
+	BaseVolume    string    `json:"base_volume"`
+	CounterVolume string    `json:"counter_volume"`
+	Open          string    `json:"open"`
+	High          string    `json:"high"`
+	Low           string    `json:"low"`
+	Close         string    `json:"close"`
+}
+
 // Transaction represents a single, successful transaction
 type Transaction struct {
 	Links struct {
@@ -208,6 +453,7 @@ type Transaction struct {
 	AccountSequence string    `json:"source_account_sequence"`
 	FeePaid         int32     `json:"fee_paid"`
 	OperationCount  int32     `json:"operation_count"`
+	Successful      bool      `json:"successful"`
 	EnvelopeXdr     string    `json:"envelope_xdr"`
 	ResultXdr       string    `json:"result_xdr"`
 	ResultMetaXdr   string    `json:"result_meta_xdr"`
@@ -217,6 +463,39 @@ type Transaction struct {
 	Signatures      []string  `json:"signatures"`
 	ValidAfter      string    `json:"valid_after,omitempty"`
 	ValidBefore     string    `json:"valid_before,omitempty"`
+
+	// ApplicationOrder is the position, from 1, at which the transaction was
+	// applied within its ledger.
+	ApplicationOrder int32 `json:"application_order"`
+
+	// Warnings explain why any raw xdr requested through the `include_raw`
+	// parameter is unavailable (see IncludeRaw).
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// TransactionStatus reports whether a single transaction, identified by its
+// hash, has been applied to the ledger.  Status is one of "success", "failed"
+// or "pending".
+type TransactionStatus struct {
+	Hash       string `json:"hash"`
+	Status     string `json:"status"`
+	Found      bool   `json:"found"`
+	Successful bool   `json:"successful"`
+	Ledger     int32  `json:"ledger,omitempty"`
+}
+
+// TransactionMeta is the raw XDR describing the outcome of applying a single
+// transaction, for clients that process it themselves.
+type TransactionMeta struct {
+	Links struct {
+		Self        hal.Link `json:"self"`
+		Transaction hal.Link `json:"transaction"`
+	} `json:"_links"`
+	Hash          string `json:"hash"`
+	Ledger        int32  `json:"ledger"`
+	ResultXdr     string `json:"result_xdr"`
+	ResultMetaXdr string `json:"result_meta_xdr"`
+	FeeMetaXdr    string `json:"fee_meta_xdr"`
 }
 
 // TransactionResultCodes represent a summary of result codes returned from
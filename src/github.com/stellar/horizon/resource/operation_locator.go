@@ -0,0 +1,39 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/httpx"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/toid"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource from the operation id `id` and, when it was
+// found in history, the transaction `tx` the id names.
+func (res *OperationLocator) Populate(
+	ctx context.Context,
+	id int64,
+	tx *history.Transaction,
+) {
+	parsed := toid.Parse(id)
+
+	*res = OperationLocator{
+		ID:               fmt.Sprintf("%d", id),
+		LedgerSequence:   parsed.LedgerSequence,
+		TransactionOrder: parsed.TransactionOrder,
+		OperationIndex:   parsed.OperationOrder,
+	}
+
+	lb := hal.LinkBuilder{Base: httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Link("/operations", res.ID, "locator")
+	res.Links.Operation = lb.Link("/operations", res.ID)
+	res.Links.Ledger = lb.Link("/ledgers", fmt.Sprintf("%d", res.LedgerSequence))
+
+	if tx != nil {
+		res.TransactionHash = tx.TransactionHash
+		link := lb.Link("/transactions", tx.TransactionHash)
+		res.Links.Transaction = &link
+	}
+}
@@ -2,6 +2,8 @@ package operations
 
 import (
 	"fmt"
+
+	"github.com/stellar/horizon/codes"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/httpx"
 	"github.com/stellar/horizon/render/hal"
@@ -18,6 +20,7 @@ func (this *Base) Populate(ctx context.Context, row history.Operation) {
 	this.ID = fmt.Sprintf("%d", row.ID)
 	this.PT = row.PagingToken()
 	this.SourceAccount = row.SourceAccount
+	this.ApplicationOrder = row.ApplicationOrder
 	this.populateType(row)
 
 	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
@@ -38,3 +41,15 @@ func (this *Base) populateType(row history.Operation) {
 		this.Type = "unknown"
 	}
 }
+
+// populateResultCode sets the result code of the operation, if its
+// transaction's result is available.
+func (this *Base) populateResultCode(row history.Operation) error {
+	opr, ok, err := row.Result()
+	if err != nil || !ok {
+		return err
+	}
+
+	this.ResultCode, err = codes.ForOperationResult(opr)
+	return err
+}
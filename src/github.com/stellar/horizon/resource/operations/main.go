@@ -33,6 +33,10 @@ func New(
 
 	base := Base{}
 	base.Populate(ctx, row)
+	err = base.populateResultCode(row)
+	if err != nil {
+		return
+	}
 
 	switch row.Type {
 	case xdr.OperationTypeCreateAccount:
@@ -103,6 +107,14 @@ type Base struct {
 	SourceAccount string `json:"source_account"`
 	Type          string `json:"type"`
 	TypeI         int32  `json:"type_i"`
+
+	// ApplicationOrder is the position, from 1, at which the operation was
+	// applied within its transaction.
+	ApplicationOrder int32 `json:"application_order"`
+
+	// ResultCode is the operation's result code, as decoded from its
+	// transaction's result, for example "op_success".
+	ResultCode string `json:"result_code,omitempty"`
 }
 
 // CreateAccount is the json resource representing a single operation whose type
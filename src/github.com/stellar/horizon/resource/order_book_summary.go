@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"github.com/stellar/go/amount"
 	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/db2/core"
 	"golang.org/x/net/context"
@@ -11,6 +12,7 @@ func (this *OrderBookSummary) Populate(
 	selling xdr.Asset,
 	buying xdr.Asset,
 	row core.OrderBookSummary,
+	remainders []core.OrderBookRemainder,
 ) error {
 
 	err := this.Selling.Populate(ctx, selling)
@@ -25,6 +27,22 @@ func (this *OrderBookSummary) Populate(
 	this.populateLevels(&this.Bids, row.Bids())
 	this.populateLevels(&this.Asks, row.Asks())
 
+	this.BidsRemainder = OrderBookRemainder{Amount: amount.String(0)}
+	this.AsksRemainder = OrderBookRemainder{Amount: amount.String(0)}
+	for _, r := range remainders {
+		rem := OrderBookRemainder{
+			PriceLevels: r.PriceLevels,
+			Amount:      amount.String(xdr.Int64(r.Amount)),
+		}
+
+		switch r.Type {
+		case "bid":
+			this.BidsRemainder = rem
+		case "ask":
+			this.AsksRemainder = rem
+		}
+	}
+
 	return nil
 }
 
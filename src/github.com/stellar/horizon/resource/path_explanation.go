@@ -0,0 +1,53 @@
+package resource
+
+import (
+	"github.com/stellar/horizon/paths"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the details of the explanation from the provided
+// finder output.
+func (this *PathExplanation) Populate(
+	ctx context.Context,
+	e *paths.Explanation,
+) (err error) {
+	this.DestinationTrusted = e.DestinationTrusted
+	this.StoppedBy = e.StoppedBy
+	this.Considered = make([]PathExplanationStep, len(e.Considered))
+
+	for i, step := range e.Considered {
+		err = this.Considered[i].Populate(ctx, step)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Populate fills out the details of the step from the provided finder
+// output.
+func (this *PathExplanationStep) Populate(
+	ctx context.Context,
+	s paths.Step,
+) (err error) {
+	err = this.Asset.Populate(ctx, s.Asset)
+	if err != nil {
+		return
+	}
+
+	this.Depth = s.Depth
+	this.SourceAsset = s.Source
+	this.Outcome = s.Outcome
+	this.OrderBooks = s.OrderBooks
+	this.InsufficientLiquidity = make([]Asset, len(s.Insufficient))
+
+	for i, a := range s.Insufficient {
+		err = this.InsufficientLiquidity[i].Populate(ctx, a)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -7,20 +7,31 @@ import (
 	"golang.org/x/net/context"
 )
 
-// Populate fills in the details
+// Populate fills in the details.  `capabilities` names the features enabled
+// on the horizon instance.
 func (res *Root) Populate(
 	ctx context.Context,
 	ledgerState ledger.State,
 	hVersion, cVersion string,
 	passphrase string,
+	capabilities []string,
 ) {
 	res.HorizonSequence = ledgerState.HistoryLatest
+	res.HistoryLatestHash = ledgerState.HistoryLatestHash
+	res.HistoryLatestClosedAt = ledgerState.HistoryLatestClosedAt
 	res.HistoryElderSequence = ledgerState.HistoryElder
 	res.CoreSequence = ledgerState.CoreLatest
+	res.CoreLatestHash = ledgerState.CoreLatestHash
+	res.CoreLatestClosedAt = ledgerState.CoreLatestClosedAt
 	res.CoreElderSequence = ledgerState.CoreElder
 	res.HorizonVersion = hVersion
 	res.StellarCoreVersion = cVersion
 	res.NetworkPassphrase = passphrase
+	res.ProtocolVersion = ledgerState.ProtocolVersion
+	res.BaseFee = ledgerState.BaseFee
+	res.BaseReserve = ledgerState.BaseReserve
+	res.MaxTxSetSize = ledgerState.MaxTxSetSize
+	res.Capabilities = capabilities
 
 	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
 	res.Links.Account = lb.Link("/accounts/{account_id}")
@@ -0,0 +1,57 @@
+package resource
+
+import (
+	"errors"
+
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/httpx"
+	"github.com/stellar/horizon/render/hal"
+	"github.com/stellar/horizon/resource/effects"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the details
+func (res *SignerChange) Populate(
+	ctx context.Context,
+	row history.SignerChange,
+) error {
+	switch row.Type {
+	case history.EffectSignerCreated,
+		history.EffectSignerRemoved,
+		history.EffectSignerUpdated:
+	default:
+		return errors.New("invalid effect; not a signer change")
+	}
+
+	var details struct {
+		PublicKey string `json:"public_key"`
+		Weight    int32  `json:"weight"`
+	}
+	err := row.UnmarshalDetails(&details)
+	if err != nil {
+		return err
+	}
+
+	res.ID = row.ID()
+	res.PT = row.PagingToken()
+	res.Account = row.Account
+	res.Type = effects.TypeNames[row.Type]
+	res.TypeI = int32(row.Type)
+	res.PublicKey = details.PublicKey
+	res.Weight = details.Weight
+	res.TransactionHash = row.TransactionHash
+	res.Ledger = row.LedgerSequence
+	res.LedgerCloseTime = row.LedgerClosedAt
+
+	lb := hal.LinkBuilder{Base: httpx.BaseURL(ctx)}
+	res.Links.Account = lb.Link("/accounts", res.Account)
+	res.Links.Operation = lb.Linkf("/operations/%d", row.HistoryOperationID)
+	res.Links.Transaction = lb.Link("/transactions", res.TransactionHash)
+	res.Links.Ledger = lb.Linkf("/ledgers/%d", res.Ledger)
+	return nil
+}
+
+// PagingToken implementation for hal.Pageable
+func (res SignerChange) PagingToken() string {
+	return res.PT
+}
@@ -0,0 +1,71 @@
+package resource
+
+import (
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/assets"
+	"github.com/stellar/horizon/db2/core"
+)
+
+// Populate fills out the spendable amount of the asset held in `tl`, of which
+// `selling` is offered for sale.  Nothing may be spent from a trustline that
+// is not authorized.
+func (res *SpendableAsset) Populate(tl core.Trustline, selling xdr.Int64) (err error) {
+	res.Type, err = assets.String(tl.Assettype)
+	if err != nil {
+		return
+	}
+
+	res.Code = tl.Assetcode
+	res.Issuer = tl.Issuer
+	res.Balance = amount.String(tl.Balance)
+	res.SellingLiabilities = amount.String(selling)
+	res.Reserve = ""
+
+	if !tl.IsAuthorized() {
+		res.Spendable = amount.String(0)
+		return
+	}
+
+	res.Spendable = amount.String(spendable(tl.Balance, selling, 0))
+	return
+}
+
+// PopulateNative fills out the spendable amount of the lumens held by `ca`, of
+// which `selling` is offered for sale.  The account must maintain a reserve of
+// two base reserves, plus one for each of its subentries.
+func (res *SpendableAsset) PopulateNative(
+	ca core.Account,
+	selling xdr.Int64,
+	baseReserve int32,
+) (err error) {
+	res.Type, err = assets.String(xdr.AssetTypeAssetTypeNative)
+	if err != nil {
+		return
+	}
+
+	reserve := xdr.Int64(2+ca.Numsubentries) * xdr.Int64(baseReserve)
+
+	res.Code = ""
+	res.Issuer = ""
+	res.Balance = amount.String(ca.Balance)
+	res.SellingLiabilities = amount.String(selling)
+	res.Reserve = amount.String(reserve)
+	res.Spendable = amount.String(spendable(ca.Balance, selling, reserve))
+	return
+}
+
+// stub implementation to satisfy pageable interface
+func (res SpendableAsset) PagingToken() string {
+	return ""
+}
+
+// spendable returns what remains of `balance` once `selling` and `reserve`
+// are set aside, or zero if they exceed it.
+func spendable(balance, selling, reserve xdr.Int64) xdr.Int64 {
+	free := balance - selling
+	if free <= reserve {
+		return 0
+	}
+	return free - reserve
+}
@@ -0,0 +1,56 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/guregu/null"
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/httpx"
+	"github.com/stellar/horizon/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the stats from `latest`, the latest ledger in the history
+// database, `dayStart`, the first ledger that closed in the 24 hours before
+// it, and `windowStart`, the first of the ledgers averaged over.
+func (res *Stats) Populate(
+	ctx context.Context,
+	latest history.Ledger,
+	dayStart history.Ledger,
+	windowStart history.Ledger,
+) {
+	res.LatestLedger = latest.Sequence
+	res.ClosedAt = latest.ClosedAt
+	res.TotalAccounts = latest.TotalAccounts
+	res.TotalTrustlines = latest.TotalTrustlines
+	res.TotalOperations = latest.TotalOperations
+	res.OperationsLast24h = operationsBetween(dayStart, latest)
+
+	ops := operationsBetween(windowStart, latest)
+	if ops.Valid {
+		res.AverageWindow = latest.Sequence - windowStart.Sequence + 1
+		res.AverageOperationsPerLedger = null.FloatFrom(
+			float64(ops.Int64) / float64(res.AverageWindow),
+		)
+	}
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Link("/stats")
+	res.Links.Ledger = lb.Link(fmt.Sprintf("/ledgers/%d", latest.Sequence))
+}
+
+// operationsBetween returns the number of operations in the ledgers from
+// `first` through `last`, inclusive, from their running totals.  It is null
+// if either total is unknown.  As the totals count the operations since the
+// network's first ledger, rather than since the history database's (see
+// ingest.LedgerTotals), they remain comparable across a gap in the history
+// database.
+func operationsBetween(first, last history.Ledger) null.Int {
+	if first.Sequence == 0 || !first.TotalOperations.Valid || !last.TotalOperations.Valid {
+		return null.Int{}
+	}
+
+	return null.IntFrom(
+		last.TotalOperations.Int64 - first.TotalOperations.Int64 + int64(first.OperationCount),
+	)
+}
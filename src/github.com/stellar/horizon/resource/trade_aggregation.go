@@ -0,0 +1,63 @@
+package resource
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/horizon/db2/history"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the details
+func (res *TradeAggregation) Populate(
+	ctx context.Context,
+	row history.TradeAggregation,
+) (err error) {
+	res.Timestamp = time.Unix(row.Timestamp, 0).UTC()
+	res.PT = row.PagingToken()
+	res.TradeCount = row.TradeCount
+	res.BaseVolume = row.BaseVolume
+	res.CounterVolume = row.CounterVolume
+
+	res.Open, err = tradePrice(row.OpenBaseAmount, row.OpenCounterAmount)
+	if err != nil {
+		return
+	}
+	res.High, err = tradePrice(row.HighBaseAmount, row.HighCounterAmount)
+	if err != nil {
+		return
+	}
+	res.Low, err = tradePrice(row.LowBaseAmount, row.LowCounterAmount)
+	if err != nil {
+		return
+	}
+	res.Close, err = tradePrice(row.CloseBaseAmount, row.CloseCounterAmount)
+	return
+}
+
+// PagingToken implementation for hal.Pageable
+func (res TradeAggregation) PagingToken() string {
+	return res.PT
+}
+
+// tradePrice returns the exact price, rounded to seven decimal places, of a
+// trade of `base` for `counter`.
+func tradePrice(base, counter string) (string, error) {
+	b, err := amount.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	c, err := amount.Parse(counter)
+	if err != nil {
+		return "", err
+	}
+
+	if b == 0 {
+		return "", errors.New("invalid trade; no base amount")
+	}
+
+	return big.NewRat(int64(c), int64(b)).FloatString(7), nil
+}
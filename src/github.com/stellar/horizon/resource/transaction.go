@@ -23,10 +23,12 @@ func (res *Transaction) Populate(
 	res.Hash = row.TransactionHash
 	res.Ledger = row.LedgerSequence
 	res.LedgerCloseTime = row.LedgerCloseTime
+	res.ApplicationOrder = row.ApplicationOrder
 	res.Account = row.Account
 	res.AccountSequence = row.AccountSequence
 	res.FeePaid = row.FeePaid
 	res.OperationCount = row.OperationCount
+	res.Successful = row.Successful
 	res.EnvelopeXdr = row.TxEnvelope
 	res.ResultXdr = row.TxResult
 	res.ResultMetaXdr = row.TxMeta
@@ -48,6 +50,40 @@ func (res *Transaction) Populate(
 	return
 }
 
+// The raw xdr that may be requested through the `include_raw` parameter of the
+// transaction endpoints.  TransactionRawMeta names both the result meta and
+// the fee meta.
+const (
+	TransactionRawEnvelope = "envelope"
+	TransactionRawResult   = "result"
+	TransactionRawMeta     = "meta"
+)
+
+// TransactionRaws are all the raw xdr that may be requested.
+var TransactionRaws = []string{
+	TransactionRawEnvelope,
+	TransactionRawResult,
+	TransactionRawMeta,
+}
+
+// IncludeRaw sets the raw xdr of `row` named by `raws`, each a TransactionRaw
+// constant, on the resource.  Populate already sets all of it, so this only
+// matters for a row whose missing xdr was since loaded from stellar-core.  A
+// requested xdr that `row` still does not hold is left blank, with a warning.
+func (res *Transaction) IncludeRaw(row history.Transaction, raws []string) {
+	for _, raw := range raws {
+		switch raw {
+		case TransactionRawEnvelope:
+			res.EnvelopeXdr = res.rawXdr("envelope_xdr", row.TxEnvelope)
+		case TransactionRawResult:
+			res.ResultXdr = res.rawXdr("result_xdr", row.TxResult)
+		case TransactionRawMeta:
+			res.ResultMetaXdr = res.rawXdr("result_meta_xdr", row.TxMeta)
+			res.FeeMetaXdr = res.rawXdr("fee_meta_xdr", row.TxFeeMeta)
+		}
+	}
+}
+
 // PagingToken implementation for hal.Pageable
 func (res Transaction) PagingToken() string {
 	return res.PT
@@ -59,3 +95,14 @@ func (res *Transaction) timeString(in null.Int) string {
 
 	return time.Unix(in.Int64, 0).UTC().Format(time.RFC3339)
 }
+
+// rawXdr returns `value`, the raw xdr field `field`, warning that it is
+// unavailable if it is blank.
+func (res *Transaction) rawXdr(field, value string) string {
+	if value == "" {
+		res.Warnings = append(res.Warnings, field+" is unavailable:  horizon "+
+			"did not store it, and stellar-core no longer holds the ledger")
+	}
+
+	return value
+}
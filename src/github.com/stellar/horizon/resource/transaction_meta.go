@@ -0,0 +1,24 @@
+package resource
+
+import (
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/httpx"
+	"github.com/stellar/horizon/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the details
+func (res *TransactionMeta) Populate(
+	ctx context.Context,
+	row history.TransactionMeta,
+) {
+	res.Hash = row.TransactionHash
+	res.Ledger = row.LedgerSequence
+	res.ResultXdr = row.TxResult
+	res.ResultMetaXdr = row.TxMeta
+	res.FeeMetaXdr = row.TxFeeMeta
+
+	lb := hal.LinkBuilder{Base: httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Link("/transactions", res.Hash, "meta")
+	res.Links.Transaction = lb.Link("/transactions", res.Hash)
+}
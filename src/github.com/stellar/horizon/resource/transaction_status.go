@@ -0,0 +1,48 @@
+package resource
+
+import (
+	"github.com/stellar/horizon/db2/core"
+	"github.com/stellar/horizon/db2/history"
+)
+
+// Statuses reported by TransactionStatus.
+const (
+	TransactionStatusSuccess = "success"
+	TransactionStatusFailed  = "failed"
+	TransactionStatusPending = "pending"
+)
+
+// Populate fills out the status of the transaction with hash `hash`.  A
+// transaction found in history succeeded, unless it was recorded as failed.
+// Otherwise, a transaction stellar-core recorded as failed is reported as such,
+// since failed transactions are only ingested into history when enabled.  Any
+// other transaction, including a successful one that has yet to be ingested,
+// is pending.
+func (res *TransactionStatus) Populate(
+	hash string,
+	hrow *history.TransactionLedger,
+	crow *core.Transaction,
+) {
+	*res = TransactionStatus{Hash: hash, Status: TransactionStatusPending}
+
+	switch {
+	case hrow != nil && !hrow.Successful:
+		res.Status = TransactionStatusFailed
+		res.Found = true
+		res.Ledger = hrow.LedgerSequence
+	case hrow != nil:
+		res.Status = TransactionStatusSuccess
+		res.Found = true
+		res.Successful = true
+		res.Ledger = hrow.LedgerSequence
+	case crow != nil && !crow.IsSuccessful():
+		res.Status = TransactionStatusFailed
+		res.Found = true
+		res.Ledger = crow.LedgerSequence
+	}
+}
+
+// stub implementation to satisfy pageable interface
+func (res TransactionStatus) PagingToken() string {
+	return ""
+}
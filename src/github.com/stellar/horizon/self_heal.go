@@ -0,0 +1,180 @@
+package horizon
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stellar/horizon/db2/history"
+	"github.com/stellar/horizon/ingest"
+	"github.com/stellar/horizon/ledger"
+	"github.com/stellar/horizon/log"
+	"github.com/stellar/horizon/render/problem"
+)
+
+const (
+	// selfHealCooldown is the least time between the reingestions of a ledger
+	// enqueued by self-healing, so that a ledger that cannot be repaired, such
+	// as one stellar-core no longer holds, is not reingested upon every
+	// request for it.
+	selfHealCooldown = time.Minute
+
+	// selfHealRetryAfter is the number of seconds a client is asked to wait
+	// before retrying a request for a ledger being repaired.
+	selfHealRetryAfter = 5
+
+	// selfHealActor is the actor by which self-healing reingestions are
+	// recorded by log.DefaultAuditor.
+	selfHealActor = "self-heal"
+)
+
+// ledgerRepairing is the problem rendered for a request that found data
+// missing from a ledger within the history database's range, a reingestion
+// of which has been enqueued.
+var ledgerRepairing = problem.P{
+	Type:   "ledger_repairing",
+	Title:  "Ledger Is Being Repaired",
+	Status: http.StatusServiceUnavailable,
+	Detail: "The data requested belongs to a ledger that this horizon server " +
+		"has found to be incompletely ingested, and is now reingesting.  " +
+		"Please retry your request shortly.",
+}
+
+// selfHealer enqueues reingestions of the ledgers that actions find to be
+// missing or inconsistent (see Action.HealLedger), when enabled by
+// Config.SelfHealReingest.
+type selfHealer struct {
+	jobs     *reingestJobs
+	cooldown time.Duration
+
+	lock      sync.Mutex
+	attempted map[int32]time.Time
+}
+
+// newSelfHealer returns a selfHealer enqueuing reingestions with `jobs`.
+func newSelfHealer(jobs *reingestJobs) *selfHealer {
+	return &selfHealer{
+		jobs:      jobs,
+		cooldown:  selfHealCooldown,
+		attempted: map[int32]time.Time{},
+	}
+}
+
+// Heal enqueues a reingestion of ledger `seq`, found to be missing or
+// inconsistent because of `reason`, unless a reingestion of it is running or
+// was enqueued within the cooldown.
+func (h *selfHealer) Heal(seq int32, reason error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	now := time.Now()
+	for s, at := range h.attempted {
+		if now.Sub(at) >= h.cooldown {
+			delete(h.attempted, s)
+		}
+	}
+
+	if _, ok := h.attempted[seq]; ok {
+		return
+	}
+	h.attempted[seq] = now
+
+	l := log.WithField("ledger", seq).WithField("reason", reason.Error())
+
+	job, err := h.jobs.Start(seq, seq, selfHealActor)
+	if _, ok := err.(*overlapError); ok {
+		return
+	}
+	if err != nil {
+		l.WithError(err).Error("self-heal: failed to enqueue reingestion")
+		return
+	}
+
+	l.WithField("job", job.ID).Warn("self-heal: reingesting ledger")
+}
+
+// HealLedger should follow a lookup of data that ledger `seq` holds.  Should
+// self-healing be enabled, it checks that the ledger, if it lies within the
+// range of the history database, in which no ledger should be missing, has
+// been fully ingested:  that the lookup found it, and that the number of
+// successful transactions and of operations stored for it agree with the
+// counts recorded in its header.  A reingestion of a ledger found missing or
+// inconsistent is then enqueued, and the action's error replaced by a
+// ledger_repairing problem asking the client to retry shortly.
+func (action *Action) HealLedger(seq int32) {
+	if action.App.selfHealer == nil {
+		return
+	}
+
+	q := action.HistoryQ()
+	if action.Err != nil && !q.NoRows(action.Err) {
+		return
+	}
+
+	// the cached ledger state may trail reaping, which must not be undone, so
+	// the range is loaded afresh.
+	var ls ledger.State
+	err := q.LedgerState(&ls)
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	if seq < ls.HistoryElder || seq > ls.HistoryLatest {
+		return
+	}
+
+	reason := action.Err
+	if reason == nil {
+		reason, err = ledgerInconsistency(q, seq)
+		if err != nil {
+			action.Err = err
+			return
+		}
+	}
+
+	if reason == nil {
+		return
+	}
+
+	action.App.selfHealer.Heal(seq, reason)
+	action.W.Header().Set("Retry-After", strconv.Itoa(selfHealRetryAfter))
+	action.Err = &ledgerRepairing
+}
+
+// ledgerInconsistency returns the reason ledger `seq` is found to be missing
+// or incompletely ingested, or nil should its stored rows agree with its
+// header.
+func ledgerInconsistency(q *history.Q, seq int32) (reason, err error) {
+	var counts history.LedgerRowCounts
+	err = q.LedgerRowCountsBySequence(&counts, seq)
+	if q.NoRows(err) {
+		return err, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []ingest.CountMismatchError{
+		{
+			Count:    "history_transactions rows",
+			Expected: int(counts.TransactionCount),
+			Stored:   int(counts.Transactions),
+		},
+		{
+			Count:    "history_operations rows",
+			Expected: int(counts.OperationCount),
+			Stored:   int(counts.Operations),
+		},
+	}
+
+	for _, c := range checks {
+		if c.Expected != c.Stored {
+			c.Sequence = seq
+			return &c, nil
+		}
+	}
+
+	return nil, nil
+}
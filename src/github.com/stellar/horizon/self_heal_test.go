@@ -0,0 +1,114 @@
+package horizon
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestSelfHeal(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// ledger 2, between the elder and latest ledgers, goes missing
+	_, err := ht.App.historyQ.ExecRaw(`DELETE FROM history_ledgers WHERE sequence = 2`)
+	ht.Require.NoError(err)
+
+	// without self-healing, the missing ledger is not found
+	w := ht.Get("/ledgers/2")
+	ht.Assert.Equal(404, w.Code)
+
+	sys := &fakeReingester{}
+	jobs := newReingestJobs(context.Background(), sys)
+	ht.App.selfHealer = newSelfHealer(jobs)
+
+	w = ht.Get("/ledgers/2")
+	if ht.Assert.Equal(503, w.Code) {
+		ht.Assert.Contains(w.Body.String(), "ledger_repairing")
+		ht.Assert.Equal("5", w.Header().Get("Retry-After"))
+	}
+	jobs.Wait()
+	ht.Assert.Equal([]string{"2-2"}, sys.Calls())
+
+	// requests for the ledger's data report it being repaired too, without
+	// reingesting it again within the cooldown
+	for _, path := range []string{
+		"/ledgers/2/transactions",
+		"/ledgers/2/operations",
+		"/ledgers/2/payments",
+		"/ledgers/2/effects",
+	} {
+		w = ht.Get(path)
+		ht.Assert.Equal(503, w.Code, path)
+	}
+	jobs.Wait()
+	ht.Assert.Equal([]string{"2-2"}, sys.Calls())
+
+	// ...after which it is reingested again
+	ht.App.selfHealer.cooldown = 0
+	w = ht.Get("/ledgers/2")
+	ht.Assert.Equal(503, w.Code)
+	jobs.Wait()
+	ht.Assert.Equal([]string{"2-2", "2-2"}, sys.Calls())
+
+	// ledgers beyond the history database's range are simply not found
+	w = ht.Get("/ledgers/100")
+	ht.Assert.Equal(404, w.Code)
+	w = ht.Get("/ledgers/100/transactions")
+	ht.Assert.Equal(404, w.Code)
+	jobs.Wait()
+	ht.Assert.Len(sys.Calls(), 2)
+}
+
+func TestSelfHeal_Inconsistent(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	sys := &fakeReingester{}
+	jobs := newReingestJobs(context.Background(), sys)
+	ht.App.selfHealer = newSelfHealer(jobs)
+
+	// a fully ingested ledger is served as usual
+	w := ht.Get("/ledgers/3")
+	ht.Assert.Equal(200, w.Code)
+	w = ht.Get("/ledgers/3/operations")
+	ht.Assert.Equal(200, w.Code)
+	jobs.Wait()
+	ht.Assert.Len(sys.Calls(), 0)
+
+	// ledger 3 is left with fewer operations than its header records
+	_, err := ht.App.historyQ.ExecRaw(
+		`DELETE FROM history_operations WHERE id = 12884905985`,
+	)
+	ht.Require.NoError(err)
+
+	for _, path := range []string{
+		"/ledgers/3",
+		"/ledgers/3/operations",
+	} {
+		w = ht.Get(path)
+		if ht.Assert.Equal(503, w.Code, path) {
+			ht.Assert.Contains(w.Body.String(), "ledger_repairing", path)
+		}
+	}
+	jobs.Wait()
+	ht.Assert.Equal([]string{"3-3"}, sys.Calls())
+
+	// as is one left with fewer transactions
+	ht.App.selfHealer.cooldown = 0
+	_, err = ht.App.historyQ.ExecRaw(
+		`UPDATE history_ledgers SET operation_count = 0 WHERE sequence = 3`,
+	)
+	ht.Require.NoError(err)
+	w = ht.Get("/ledgers/3/transactions")
+	ht.Assert.Equal(200, w.Code)
+
+	_, err = ht.App.historyQ.ExecRaw(
+		`UPDATE history_ledgers SET transaction_count = 2 WHERE sequence = 3`,
+	)
+	ht.Require.NoError(err)
+	w = ht.Get("/ledgers/3/transactions")
+	ht.Assert.Equal(503, w.Code)
+	jobs.Wait()
+	ht.Assert.Equal([]string{"3-3", "3-3"}, sys.Calls())
+}
@@ -2,6 +2,8 @@ package simplepath
 
 import (
 	"github.com/go-errors/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/horizon/assets"
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/log"
 	"github.com/stellar/horizon/paths"
@@ -16,11 +18,41 @@ type Finder struct {
 	Q *core.Q
 }
 
-// ensure the struct is paths.Finder compliant
+// ensure the struct is paths.Finder and paths.Explainer compliant
 var _ paths.Finder = &Finder{}
+var _ paths.Explainer = &Finder{}
 
 // Find performs a path find with the provided query.
 func (f *Finder) Find(q paths.Query) (result []paths.Path, err error) {
+	result, err = f.find(q, nil)
+	return
+}
+
+// Explain performs a path find with the provided query, recording each step
+// of the search in the returned explanation.
+func (f *Finder) Explain(q paths.Query) (
+	result []paths.Path,
+	explanation *paths.Explanation,
+	err error,
+) {
+	explanation = &paths.Explanation{}
+
+	explanation.DestinationTrusted, err = f.trusts(
+		q.DestinationAddress,
+		q.DestinationAsset,
+	)
+	if err != nil {
+		return
+	}
+
+	result, err = f.find(q, explanation)
+	return
+}
+
+func (f *Finder) find(
+	q paths.Query,
+	explanation *paths.Explanation,
+) (result []paths.Path, err error) {
 	log.WithField("source_assets", q.SourceAssets).
 		WithField("destination_asset", q.DestinationAsset).
 		WithField("destination_amount", q.DestinationAmount).
@@ -32,8 +64,9 @@ func (f *Finder) Find(q paths.Query) (result []paths.Path, err error) {
 	}
 
 	s := &search{
-		Query:  q,
-		Finder: f,
+		Query:       q,
+		Finder:      f,
+		Explanation: explanation,
 	}
 
 	s.Init()
@@ -46,3 +79,31 @@ func (f *Finder) Find(q paths.Query) (result []paths.Path, err error) {
 		Info("Finished pathfind")
 	return
 }
+
+// trusts returns true if the account at addy can hold the provided asset,
+// either by issuing it or through a trustline.
+func (f *Finder) trusts(addy string, asset xdr.Asset) (bool, error) {
+	var typ, code, issuer string
+	err := asset.Extract(&typ, &code, &issuer)
+	if err != nil {
+		return false, err
+	}
+
+	if issuer == addy {
+		return true, nil
+	}
+
+	var held []xdr.Asset
+	err = f.Q.AssetsForAddress(&held, addy)
+	if err != nil {
+		return false, err
+	}
+
+	for _, a := range held {
+		if assets.Equals(a, asset) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -64,3 +64,62 @@ func TestFinder(t *testing.T) {
 		tt.Assert.Len(p, 2)
 	}
 }
+
+func TestFinder_Explain(t *testing.T) {
+	tt := test.Start(t).Scenario("paths")
+	defer tt.Finish()
+
+	finder := &Finder{
+		Q: &core.Q{Repo: tt.CoreRepo()},
+	}
+
+	usd := makeAsset(
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"USD",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN")
+	eur := makeAsset(
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"EUR",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN")
+
+	query := paths.Query{
+		DestinationAddress: "GAEDTJ4PPEFVW5XV2S7LUXBEHNQMX5Q2GM562RJGOQG7GVCE5H3HIB4V",
+		DestinationAsset:   eur,
+		DestinationAmount:  xdr.Int64(200000000),
+		SourceAssets:       []xdr.Asset{usd},
+	}
+
+	p, e, err := finder.Explain(query)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 3)
+		tt.Assert.True(e.DestinationTrusted)
+		tt.Assert.Equal(paths.StoppedExhausted, e.StoppedBy)
+		if tt.Assert.NotEmpty(e.Considered) {
+			tt.Assert.Equal(eur.String(), e.Considered[0].Asset.String())
+			tt.Assert.Equal(0, e.Considered[0].Depth)
+			tt.Assert.Equal(paths.OutcomeExtended, e.Considered[0].Outcome)
+		}
+	}
+
+	// too large an amount: the search bottoms out at the destination asset's
+	// order books
+	query.DestinationAmount = xdr.Int64(500000001)
+	p, e, err = finder.Explain(query)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 0)
+		tt.Assert.Equal(paths.StoppedExhausted, e.StoppedBy)
+		if tt.Assert.Len(e.Considered, 1) {
+			step := e.Considered[0]
+			tt.Assert.Equal(paths.OutcomeInsufficientLiquidity, step.Outcome)
+			tt.Assert.Len(step.Insufficient, step.OrderBooks)
+		}
+	}
+
+	// a destination without a trustline to the asset
+	query.DestinationAddress = "GARSFJNXJIHO6ULUBK3DBYKVSIZE7SC72S5DYBCHU7DKL22UXKVD7MXP"
+	query.DestinationAmount = xdr.Int64(200000000)
+	_, e, err = finder.Explain(query)
+	if tt.Assert.NoError(err) {
+		tt.Assert.False(e.DestinationTrusted)
+	}
+}
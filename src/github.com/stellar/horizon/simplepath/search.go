@@ -19,6 +19,9 @@ type search struct {
 	Query  paths.Query
 	Finder *Finder
 
+	// Explanation, if set, records each step of the search as it is run.
+	Explanation *paths.Explanation
+
 	// Fields below are initialized by a call to Init() after
 	// setting the fields above
 	queue   []*pathNode
@@ -64,6 +67,10 @@ func (s *search) Run() {
 	for s.hasMore() {
 		s.runOnce()
 	}
+
+	if s.Explanation != nil {
+		s.Explanation.StoppedBy = s.stoppedBy()
+	}
 }
 
 // pop removes the head from the search queue, returning it to the caller
@@ -79,13 +86,47 @@ func (s *search) hasMore() bool {
 		return false
 	}
 
-	if len(s.Results) > 4 {
+	if s.hasEnoughResults() {
 		return false
 	}
 
 	return len(s.queue) > 0
 }
 
+// hasEnoughResults returns true once the search has found as many paths as
+// it will return.
+func (s *search) hasEnoughResults() bool {
+	return len(s.Results) > 4
+}
+
+// stoppedBy describes why a finished search stopped.
+func (s *search) stoppedBy() string {
+	switch {
+	case s.Err != nil:
+		return paths.StoppedError
+	case s.hasEnoughResults():
+		return paths.StoppedMaxResults
+	default:
+		return paths.StoppedExhausted
+	}
+}
+
+// explain records a step of the search, returning nil if the search is not
+// being explained.
+func (s *search) explain(cur *pathNode) *paths.Step {
+	if s.Explanation == nil {
+		return nil
+	}
+
+	id := cur.Asset.String()
+	s.Explanation.Considered = append(s.Explanation.Considered, paths.Step{
+		Asset:  cur.Asset,
+		Depth:  cur.Depth() - 1,
+		Source: s.isTarget(id),
+	})
+	return &s.Explanation.Considered[len(s.Explanation.Considered)-1]
+}
+
 // isTarget returns true if the asset id provided is one of the targets
 // for this search (i.e. one of the requesting account's trusted assets)
 func (s *search) isTarget(id string) bool {
@@ -118,18 +159,26 @@ func (s *search) runOnce() {
 		return
 	}
 
+	step := s.explain(cur)
+
 	// A PathPaymentOp's path cannot be over 5 elements in length, and so
 	// we abort our search if the current linked list is over 7 (since the list
 	// includes both source and destination in addition to the path)
 	if cur.Depth() > 7 {
+		if step != nil {
+			step.Outcome = paths.OutcomeMaxDepth
+		}
 		return
 	}
 
-	s.extendSearch(cur)
+	s.extendSearch(cur, step)
 
 }
 
-func (s *search) extendSearch(cur *pathNode) {
+// extendSearch queues a path for each asset connected to cur by an order
+// book deep enough to satisfy the query.  If step is not nil, the outcome is
+// recorded upon it.
+func (s *search) extendSearch(cur *pathNode, step *paths.Step) {
 	// find connected assets
 	var connected []xdr.Asset
 	s.Err = s.Finder.Q.ConnectedAssets(&connected, cur.Asset)
@@ -151,11 +200,28 @@ func (s *search) extendSearch(cur *pathNode) {
 		}
 
 		if !hasEnough {
+			if step != nil {
+				step.Insufficient = append(step.Insufficient, a)
+			}
 			continue
 		}
 
 		s.queue = append(s.queue, newPath)
 	}
+
+	if step == nil {
+		return
+	}
+
+	step.OrderBooks = len(connected)
+	switch {
+	case len(connected) == 0:
+		step.Outcome = paths.OutcomeNoOrderBooks
+	case len(step.Insufficient) == len(connected):
+		step.Outcome = paths.OutcomeInsufficientLiquidity
+	default:
+		step.Outcome = paths.OutcomeExtended
+	}
 }
 
 func (s *search) hasEnoughDepth(path *pathNode) (bool, error) {
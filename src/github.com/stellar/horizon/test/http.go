@@ -29,6 +29,12 @@ func RequestHelperXFF(xff string) func(r *http.Request) {
 	}
 }
 
+func RequestHelperHeader(name, value string) func(r *http.Request) {
+	return func(r *http.Request) {
+		r.Header.Set(name, value)
+	}
+}
+
 func RequestHelperRaw(r *http.Request) {
 	r.Header.Set("Accept", "application/octet-stream")
 }
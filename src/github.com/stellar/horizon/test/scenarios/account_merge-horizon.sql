@@ -11,18 +11,26 @@ SET client_min_messages = warning;
 
 SET search_path = public, pg_catalog;
 
+DROP INDEX IF EXISTS public.trade_effects_by_pair_and_operation;
 DROP INDEX IF EXISTS public.trade_effects_by_order_book;
 DROP INDEX IF EXISTS public.index_history_transactions_on_id;
 DROP INDEX IF EXISTS public.index_history_operations_on_type;
 DROP INDEX IF EXISTS public.index_history_operations_on_transaction_id;
 DROP INDEX IF EXISTS public.index_history_operations_on_id;
+DROP INDEX IF EXISTS public.index_history_operation_assets_on_operation;
+DROP INDEX IF EXISTS public.index_history_operation_assets_on_asset;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_sequence;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_previous_ledger_hash;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_ledger_hash;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_importer_version;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_id;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_closed_at;
+DROP INDEX IF EXISTS public.index_history_ledger_upgrades_on_ledger_sequence;
 DROP INDEX IF EXISTS public.index_history_effects_on_type;
+DROP INDEX IF EXISTS public.index_asset_stats_on_num_accounts;
+DROP INDEX IF EXISTS public.index_asset_stats_on_issuer;
+DROP INDEX IF EXISTS public.index_asset_stats_on_asset;
+DROP INDEX IF EXISTS public.index_asset_stats_on_amount;
 DROP INDEX IF EXISTS public.index_history_accounts_on_id;
 DROP INDEX IF EXISTS public.index_history_accounts_on_address;
 DROP INDEX IF EXISTS public.htp_by_htid;
@@ -47,11 +55,15 @@ DROP TABLE IF EXISTS public.history_transaction_participants;
 DROP TABLE IF EXISTS public.history_operations;
 DROP SEQUENCE IF EXISTS public.history_operation_participants_id_seq;
 DROP TABLE IF EXISTS public.history_operation_participants;
+DROP TABLE IF EXISTS public.history_operation_assets;
 DROP TABLE IF EXISTS public.history_ledgers;
+DROP TABLE IF EXISTS public.history_ledger_upgrades;
 DROP TABLE IF EXISTS public.history_effects;
 DROP TABLE IF EXISTS public.history_accounts;
 DROP SEQUENCE IF EXISTS public.history_accounts_id_seq;
 DROP TABLE IF EXISTS public.gorp_migrations;
+DROP TABLE IF EXISTS public.asset_stats_ledger;
+DROP TABLE IF EXISTS public.asset_stats;
 DROP EXTENSION IF EXISTS plpgsql;
 DROP SCHEMA IF EXISTS public;
 --
@@ -88,6 +100,29 @@ SET default_tablespace = '';
 
 SET default_with_oids = false;
 
+--
+-- Name: asset_stats; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE asset_stats (
+    asset_type character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL,
+    amount bigint NOT NULL,
+    num_accounts integer NOT NULL,
+    flags integer NOT NULL
+);
+
+
+--
+-- Name: asset_stats_ledger; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE asset_stats_ledger (
+    ledger_sequence integer NOT NULL
+);
+
+
 --
 -- Name: gorp_migrations; Type: TABLE; Schema: public; Owner: -; Tablespace: 
 --
@@ -116,7 +151,9 @@ CREATE SEQUENCE history_accounts_id_seq
 
 CREATE TABLE history_accounts (
     id bigint DEFAULT nextval('history_accounts_id_seq'::regclass) NOT NULL,
-    address character varying(64)
+    address character varying(64),
+    created_ledger integer,
+    created_at timestamp without time zone
 );
 
 
@@ -133,6 +170,19 @@ CREATE TABLE history_effects (
 );
 
 
+--
+-- Name: history_ledger_upgrades; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE history_ledger_upgrades (
+    history_ledger_id bigint NOT NULL,
+    ledger_sequence integer NOT NULL,
+    application_order integer NOT NULL,
+    type integer NOT NULL,
+    value bigint NOT NULL
+);
+
+
 --
 -- Name: history_ledgers; Type: TABLE; Schema: public; Owner: -; Tablespace: 
 --
@@ -152,7 +202,25 @@ CREATE TABLE history_ledgers (
     fee_pool bigint NOT NULL,
     base_fee integer NOT NULL,
     base_reserve integer NOT NULL,
-    max_tx_set_size integer NOT NULL
+    max_tx_set_size integer NOT NULL,
+    total_accounts bigint,
+    total_trustlines bigint,
+    total_operations bigint,
+    protocol_version integer,
+    failed_transaction_count integer,
+    ingest_duration_ms integer
+);
+
+
+--
+-- Name: history_operation_assets; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE history_operation_assets (
+    history_operation_id bigint NOT NULL,
+    asset_type character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL
 );
 
 
@@ -252,7 +320,8 @@ CREATE TABLE history_transactions (
     signatures character varying(96)[] DEFAULT '{}'::character varying[] NOT NULL,
     memo_type character varying DEFAULT 'none'::character varying NOT NULL,
     memo character varying,
-    time_bounds int8range
+    time_bounds int8range,
+    successful boolean
 );
 
 
@@ -270,6 +339,19 @@ ALTER TABLE ONLY history_operation_participants ALTER COLUMN id SET DEFAULT next
 ALTER TABLE ONLY history_transaction_participants ALTER COLUMN id SET DEFAULT nextval('history_transaction_participants_id_seq'::regclass);
 
 
+--
+-- Data for Name: asset_stats; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
+--
+-- Data for Name: asset_stats_ledger; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+INSERT INTO asset_stats_ledger VALUES (3);
+
+
 --
 -- Data for Name: gorp_migrations; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -277,15 +359,24 @@ ALTER TABLE ONLY history_transaction_participants ALTER COLUMN id SET DEFAULT ne
 INSERT INTO gorp_migrations VALUES ('1_initial_schema.sql', '2016-06-28 15:12:02.483252-07');
 INSERT INTO gorp_migrations VALUES ('2_index_participants_by_toid.sql', '2016-06-28 15:12:02.486221-07');
 INSERT INTO gorp_migrations VALUES ('3_use_sequence_in_history_accounts.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('4_add_history_ledger_upgrades.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('5_add_history_operation_assets.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('6_add_history_ledger_totals.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('7_add_history_account_creation.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('8_add_asset_stats.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('9_add_history_transaction_successful.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('10_add_history_ledger_stats.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('11_add_asset_stats_ledger.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('12_index_trade_effects_by_pair.sql', '2016-06-28 15:12:02.487849-07');
 
 
 --
 -- Data for Name: history_accounts; Type: TABLE DATA; Schema: public; Owner: -
 --
 
-INSERT INTO history_accounts VALUES (1, 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H');
-INSERT INTO history_accounts VALUES (2, 'GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU');
-INSERT INTO history_accounts VALUES (3, 'GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2');
+INSERT INTO history_accounts VALUES (1, 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H', NULL, NULL);
+INSERT INTO history_accounts VALUES (2, 'GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU', 2, '2016-06-29 16:33:44');
+INSERT INTO history_accounts VALUES (3, 'GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2', 2, '2016-06-29 16:33:44');
 
 
 --
@@ -310,13 +401,29 @@ INSERT INTO history_effects VALUES (3, 12884905985, 2, 2, '{"amount": "999.99999
 INSERT INTO history_effects VALUES (2, 12884905985, 3, 1, '{}');
 
 
+--
+-- Data for Name: history_ledger_upgrades; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+INSERT INTO history_ledger_upgrades VALUES (8589934592, 2, 1, 1, 2);
+INSERT INTO history_ledger_upgrades VALUES (8589934592, 2, 2, 3, 10000);
+
+
 --
 -- Data for Name: history_ledgers; Type: TABLE DATA; Schema: public; Owner: -
 --
 
-INSERT INTO history_ledgers VALUES (1, '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', NULL, 0, 0, '1970-01-01 00:00:00', '2016-06-29 16:33:46.407633', '2016-06-29 16:33:46.407633', 4294967296, 8, 1000000000000000000, 0, 100, 100000000, 100);
-INSERT INTO history_ledgers VALUES (2, '036778c7ea2abd620731c3ff163c174d4a3e2bd1c49c353d79eeb36e81097dd1', '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', 2, 2, '2016-06-29 16:33:44', '2016-06-29 16:33:46.416539', '2016-06-29 16:33:46.416539', 8589934592, 8, 1000000000000000000, 200, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (3, '34c65926bc66835ebe8f0396c212e71885a38c4e506b41baa757d5e1ea5be570', '036778c7ea2abd620731c3ff163c174d4a3e2bd1c49c353d79eeb36e81097dd1', 1, 1, '2016-06-29 16:33:45', '2016-06-29 16:33:46.427041', '2016-06-29 16:33:46.427041', 12884901888, 8, 1000000000000000000, 300, 100, 100000000, 10000);
+INSERT INTO history_ledgers VALUES (1, '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', NULL, 0, 0, '1970-01-01 00:00:00', '2016-06-29 16:33:46.407633', '2016-06-29 16:33:46.407633', 4294967296, 12, 1000000000000000000, 0, 100, 100000000, 100, 1, 0, 0);
+INSERT INTO history_ledgers VALUES (2, '036778c7ea2abd620731c3ff163c174d4a3e2bd1c49c353d79eeb36e81097dd1', '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', 2, 2, '2016-06-29 16:33:44', '2016-06-29 16:33:46.416539', '2016-06-29 16:33:46.416539', 8589934592, 12, 1000000000000000000, 200, 100, 100000000, 10000, 3, 0, 2);
+INSERT INTO history_ledgers VALUES (3, '34c65926bc66835ebe8f0396c212e71885a38c4e506b41baa757d5e1ea5be570', '036778c7ea2abd620731c3ff163c174d4a3e2bd1c49c353d79eeb36e81097dd1', 1, 1, '2016-06-29 16:33:45', '2016-06-29 16:33:46.427041', '2016-06-29 16:33:46.427041', 12884901888, 12, 1000000000000000000, 300, 100, 100000000, 10000, 2, 0, 3);
+
+
+--
+-- Data for Name: history_operation_assets; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+INSERT INTO history_operation_assets VALUES (8589938689, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (8589942785, 'native', '', '');
 
 
 --
@@ -476,6 +583,34 @@ CREATE UNIQUE INDEX hs_transaction_by_id ON history_transactions USING btree (id
 CREATE INDEX htp_by_htid ON history_transaction_participants USING btree (history_transaction_id);
 
 
+--
+-- Name: index_asset_stats_on_amount; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_amount ON asset_stats USING btree (amount, asset_code, asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_asset; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_asset_stats_on_asset ON asset_stats USING btree (asset_code, asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_issuer; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_issuer ON asset_stats USING btree (asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_num_accounts; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_num_accounts ON asset_stats USING btree (num_accounts, asset_code, asset_issuer);
+
+
 --
 -- Name: index_history_accounts_on_address; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -497,6 +632,13 @@ CREATE UNIQUE INDEX index_history_accounts_on_id ON history_accounts USING btree
 CREATE INDEX index_history_effects_on_type ON history_effects USING btree (type);
 
 
+--
+-- Name: index_history_ledger_upgrades_on_ledger_sequence; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_history_ledger_upgrades_on_ledger_sequence ON history_ledger_upgrades USING btree (ledger_sequence, application_order);
+
+
 --
 -- Name: index_history_ledgers_on_closed_at; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -539,6 +681,20 @@ CREATE UNIQUE INDEX index_history_ledgers_on_previous_ledger_hash ON history_led
 CREATE UNIQUE INDEX index_history_ledgers_on_sequence ON history_ledgers USING btree (sequence);
 
 
+--
+-- Name: index_history_operation_assets_on_asset; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_history_operation_assets_on_asset ON history_operation_assets USING btree (asset_type, asset_code, asset_issuer, history_operation_id);
+
+
+--
+-- Name: index_history_operation_assets_on_operation; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_history_operation_assets_on_operation ON history_operation_assets USING btree (history_operation_id);
+
+
 --
 -- Name: index_history_operations_on_id; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -574,6 +730,13 @@ CREATE UNIQUE INDEX index_history_transactions_on_id ON history_transactions USI
 CREATE INDEX trade_effects_by_order_book ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), ((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text))) WHERE (type = 33);
 
 
+--
+-- Name: trade_effects_by_pair_and_operation; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX trade_effects_by_pair_and_operation ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), ((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text)), history_operation_id) WHERE (type = 33);
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -11,18 +11,26 @@ SET client_min_messages = warning;
 
 SET search_path = public, pg_catalog;
 
+DROP INDEX IF EXISTS public.trade_effects_by_pair_and_operation;
 DROP INDEX IF EXISTS public.trade_effects_by_order_book;
 DROP INDEX IF EXISTS public.index_history_transactions_on_id;
 DROP INDEX IF EXISTS public.index_history_operations_on_type;
 DROP INDEX IF EXISTS public.index_history_operations_on_transaction_id;
 DROP INDEX IF EXISTS public.index_history_operations_on_id;
+DROP INDEX IF EXISTS public.index_history_operation_assets_on_operation;
+DROP INDEX IF EXISTS public.index_history_operation_assets_on_asset;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_sequence;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_previous_ledger_hash;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_ledger_hash;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_importer_version;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_id;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_closed_at;
+DROP INDEX IF EXISTS public.index_history_ledger_upgrades_on_ledger_sequence;
 DROP INDEX IF EXISTS public.index_history_effects_on_type;
+DROP INDEX IF EXISTS public.index_asset_stats_on_num_accounts;
+DROP INDEX IF EXISTS public.index_asset_stats_on_issuer;
+DROP INDEX IF EXISTS public.index_asset_stats_on_asset;
+DROP INDEX IF EXISTS public.index_asset_stats_on_amount;
 DROP INDEX IF EXISTS public.index_history_accounts_on_id;
 DROP INDEX IF EXISTS public.index_history_accounts_on_address;
 DROP INDEX IF EXISTS public.htp_by_htid;
@@ -47,11 +55,15 @@ DROP TABLE IF EXISTS public.history_transaction_participants;
 DROP TABLE IF EXISTS public.history_operations;
 DROP SEQUENCE IF EXISTS public.history_operation_participants_id_seq;
 DROP TABLE IF EXISTS public.history_operation_participants;
+DROP TABLE IF EXISTS public.history_operation_assets;
 DROP TABLE IF EXISTS public.history_ledgers;
+DROP TABLE IF EXISTS public.history_ledger_upgrades;
 DROP TABLE IF EXISTS public.history_effects;
 DROP TABLE IF EXISTS public.history_accounts;
 DROP SEQUENCE IF EXISTS public.history_accounts_id_seq;
 DROP TABLE IF EXISTS public.gorp_migrations;
+DROP TABLE IF EXISTS public.asset_stats_ledger;
+DROP TABLE IF EXISTS public.asset_stats;
 DROP EXTENSION IF EXISTS plpgsql;
 DROP SCHEMA IF EXISTS public;
 --
@@ -88,6 +100,29 @@ SET default_tablespace = '';
 
 SET default_with_oids = false;
 
+--
+-- Name: asset_stats; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE asset_stats (
+    asset_type character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL,
+    amount bigint NOT NULL,
+    num_accounts integer NOT NULL,
+    flags integer NOT NULL
+);
+
+
+--
+-- Name: asset_stats_ledger; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE asset_stats_ledger (
+    ledger_sequence integer NOT NULL
+);
+
+
 --
 -- Name: gorp_migrations; Type: TABLE; Schema: public; Owner: -; Tablespace: 
 --
@@ -116,7 +151,9 @@ CREATE SEQUENCE history_accounts_id_seq
 
 CREATE TABLE history_accounts (
     id bigint DEFAULT nextval('history_accounts_id_seq'::regclass) NOT NULL,
-    address character varying(64)
+    address character varying(64),
+    created_ledger integer,
+    created_at timestamp without time zone
 );
 
 
@@ -133,6 +170,19 @@ CREATE TABLE history_effects (
 );
 
 
+--
+-- Name: history_ledger_upgrades; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE history_ledger_upgrades (
+    history_ledger_id bigint NOT NULL,
+    ledger_sequence integer NOT NULL,
+    application_order integer NOT NULL,
+    type integer NOT NULL,
+    value bigint NOT NULL
+);
+
+
 --
 -- Name: history_ledgers; Type: TABLE; Schema: public; Owner: -; Tablespace: 
 --
@@ -152,7 +202,25 @@ CREATE TABLE history_ledgers (
     fee_pool bigint NOT NULL,
     base_fee integer NOT NULL,
     base_reserve integer NOT NULL,
-    max_tx_set_size integer NOT NULL
+    max_tx_set_size integer NOT NULL,
+    total_accounts bigint,
+    total_trustlines bigint,
+    total_operations bigint,
+    protocol_version integer,
+    failed_transaction_count integer,
+    ingest_duration_ms integer
+);
+
+
+--
+-- Name: history_operation_assets; Type: TABLE; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE TABLE history_operation_assets (
+    history_operation_id bigint NOT NULL,
+    asset_type character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL
 );
 
 
@@ -252,7 +320,8 @@ CREATE TABLE history_transactions (
     signatures character varying(96)[] DEFAULT '{}'::character varying[] NOT NULL,
     memo_type character varying DEFAULT 'none'::character varying NOT NULL,
     memo character varying,
-    time_bounds int8range
+    time_bounds int8range,
+    successful boolean
 );
 
 
@@ -270,6 +339,25 @@ ALTER TABLE ONLY history_operation_participants ALTER COLUMN id SET DEFAULT next
 ALTER TABLE ONLY history_transaction_participants ALTER COLUMN id SET DEFAULT nextval('history_transaction_participants_id_seq'::regclass);
 
 
+--
+-- Data for Name: asset_stats; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+INSERT INTO asset_stats VALUES ('credit_alphanum4', 'EUR', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU', 3000000000, 1, 0);
+INSERT INTO asset_stats VALUES ('credit_alphanum4', 'EUR', 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF', 0, 1, 3);
+INSERT INTO asset_stats VALUES ('credit_alphanum4', 'USD', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU', 0, 1, 0);
+INSERT INTO asset_stats VALUES ('credit_alphanum4', 'USD', 'GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD', 200000000, 1, 0);
+INSERT INTO asset_stats VALUES ('credit_alphanum4', 'USD', 'GCHC4D2CS45CJRNN4QAHT2LFZAJIU5PA7H53K3VOP6WEJ6XWHNSNZKQG', 100000000, 1, 0);
+INSERT INTO asset_stats VALUES ('credit_alphanum4', 'USD', 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF', 0, 1, 3);
+
+
+--
+-- Data for Name: asset_stats_ledger; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+INSERT INTO asset_stats_ledger VALUES (59);
+
+
 --
 -- Data for Name: gorp_migrations; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -277,37 +365,46 @@ ALTER TABLE ONLY history_transaction_participants ALTER COLUMN id SET DEFAULT ne
 INSERT INTO gorp_migrations VALUES ('1_initial_schema.sql', '2016-06-28 15:12:02.483252-07');
 INSERT INTO gorp_migrations VALUES ('2_index_participants_by_toid.sql', '2016-06-28 15:12:02.486221-07');
 INSERT INTO gorp_migrations VALUES ('3_use_sequence_in_history_accounts.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('4_add_history_ledger_upgrades.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('5_add_history_operation_assets.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('6_add_history_ledger_totals.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('7_add_history_account_creation.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('8_add_asset_stats.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('9_add_history_transaction_successful.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('10_add_history_ledger_stats.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('11_add_asset_stats_ledger.sql', '2016-06-28 15:12:02.487849-07');
+INSERT INTO gorp_migrations VALUES ('12_index_trade_effects_by_pair.sql', '2016-06-28 15:12:02.487849-07');
 
 
 --
 -- Data for Name: history_accounts; Type: TABLE DATA; Schema: public; Owner: -
 --
 
-INSERT INTO history_accounts VALUES (1, 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H');
-INSERT INTO history_accounts VALUES (2, 'GAXI33UCLQTCKM2NMRBS7XYBR535LLEVAHL5YBN4FTCB4HZHT7ZA5CVK');
-INSERT INTO history_accounts VALUES (3, 'GDXFAGJCSCI4CK2YHK6YRLA6TKEXFRX7BMGVMQOBMLIEUJRJ5YQNLMIB');
-INSERT INTO history_accounts VALUES (4, 'GA46VRKBCLI2X6DXLX7AIEVRFLH3UA7XBE3NGNP6O74HQ5LXHMGTV2JB');
-INSERT INTO history_accounts VALUES (5, 'GAG52TW6QAB6TGNMOTL32Y4M3UQQLNNNHPEHYAIYRP6SFF6ZAVRF5ZQY');
-INSERT INTO history_accounts VALUES (6, 'GDCVTBGSEEU7KLXUMHMSXBALXJ2T4I2KOPXW2S5TRLKDRIAXD5UDHAYO');
-INSERT INTO history_accounts VALUES (7, 'GCB7FPYGLL6RJ37HKRAYW5TAWMFBGGFGM4IM6ERBCZXI2BZ4OOOX2UAY');
-INSERT INTO history_accounts VALUES (8, 'GCHC4D2CS45CJRNN4QAHT2LFZAJIU5PA7H53K3VOP6WEJ6XWHNSNZKQG');
-INSERT INTO history_accounts VALUES (9, 'GANZGPKY5WSHWG5YOZMNG52GCK5SCJ4YGUWMJJVGZSK2FP4BI2JIJN2C');
-INSERT INTO history_accounts VALUES (10, 'GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD');
-INSERT INTO history_accounts VALUES (11, 'GACAR2AEYEKITE2LKI5RMXF5MIVZ6Q7XILROGDT22O7JX4DSWFS7FDDP');
-INSERT INTO history_accounts VALUES (12, 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
-INSERT INTO history_accounts VALUES (13, 'GBOK7BOUSOWPHBANBYM6MIRYZJIDIPUYJPXHTHADF75UEVIVYWHHONQC');
-INSERT INTO history_accounts VALUES (14, 'GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD');
-INSERT INTO history_accounts VALUES (15, 'GB6GN3LJUW6JYR7EDOJ47VBH7D45M4JWHXGK6LHJRAEI5JBSN2DBQY7Q');
-INSERT INTO history_accounts VALUES (16, 'GCIFFRQKHMH6JD7CK5OI4XVCYCMNRNF6PYA7JTCR3FPHPJZQTYYFB5ES');
-INSERT INTO history_accounts VALUES (17, 'GCJKJXPKBFIHOO3455WXWG5CDBZXQNYFRRGICYMPUQ35CPQ4WVS3KZLG');
-INSERT INTO history_accounts VALUES (18, 'GCVW5LCRZFP7PENXTAGOVIQXADDNUXXZJCNKF4VQB2IK7W2LPJWF73UG');
-INSERT INTO history_accounts VALUES (19, 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF');
-INSERT INTO history_accounts VALUES (20, 'GCHPXGVDKPF5KT4CNAT7X77OXYZ7YVE4JHKFDUHCGCVWCL4K4PQ67KKZ');
-INSERT INTO history_accounts VALUES (21, 'GDR53WAEIKOU3ZKN34CSHAWH7HV6K63CBJRUTWUDBFSMY7RRQK3SPKOS');
-INSERT INTO history_accounts VALUES (22, 'GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD');
-INSERT INTO history_accounts VALUES (23, 'GACJPE4YUR22VP4CM2BDFDAHY3DLEF3H7NENKUQ53DT5TEI2GAHT5N4X');
-INSERT INTO history_accounts VALUES (24, 'GANFZDRBCNTUXIODCJEYMACPMCSZEVE4WZGZ3CZDZ3P2SXK4KH75IK6Y');
-INSERT INTO history_accounts VALUES (25, 'GAKNI7DPN7BGAB62BU4GQC364JCFXFV7KIVTTDVOQK2ZPBPSJETABDQU');
+INSERT INTO history_accounts VALUES (1, 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H', NULL, NULL);
+INSERT INTO history_accounts VALUES (2, 'GAXI33UCLQTCKM2NMRBS7XYBR535LLEVAHL5YBN4FTCB4HZHT7ZA5CVK', 3, '2016-06-29 16:34:05');
+INSERT INTO history_accounts VALUES (3, 'GDXFAGJCSCI4CK2YHK6YRLA6TKEXFRX7BMGVMQOBMLIEUJRJ5YQNLMIB', 4, '2016-06-29 16:34:06');
+INSERT INTO history_accounts VALUES (4, 'GA46VRKBCLI2X6DXLX7AIEVRFLH3UA7XBE3NGNP6O74HQ5LXHMGTV2JB', 7, '2016-06-29 16:34:09');
+INSERT INTO history_accounts VALUES (5, 'GAG52TW6QAB6TGNMOTL32Y4M3UQQLNNNHPEHYAIYRP6SFF6ZAVRF5ZQY', 9, '2016-06-29 16:34:11');
+INSERT INTO history_accounts VALUES (6, 'GDCVTBGSEEU7KLXUMHMSXBALXJ2T4I2KOPXW2S5TRLKDRIAXD5UDHAYO', 11, '2016-06-29 16:34:13');
+INSERT INTO history_accounts VALUES (7, 'GCB7FPYGLL6RJ37HKRAYW5TAWMFBGGFGM4IM6ERBCZXI2BZ4OOOX2UAY', 12, '2016-06-29 16:34:14');
+INSERT INTO history_accounts VALUES (8, 'GCHC4D2CS45CJRNN4QAHT2LFZAJIU5PA7H53K3VOP6WEJ6XWHNSNZKQG', 13, '2016-06-29 16:34:15');
+INSERT INTO history_accounts VALUES (9, 'GANZGPKY5WSHWG5YOZMNG52GCK5SCJ4YGUWMJJVGZSK2FP4BI2JIJN2C', 13, '2016-06-29 16:34:15');
+INSERT INTO history_accounts VALUES (10, 'GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD', 16, '2016-06-29 16:34:18');
+INSERT INTO history_accounts VALUES (11, 'GACAR2AEYEKITE2LKI5RMXF5MIVZ6Q7XILROGDT22O7JX4DSWFS7FDDP', 16, '2016-06-29 16:34:18');
+INSERT INTO history_accounts VALUES (12, 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU', 16, '2016-06-29 16:34:18');
+INSERT INTO history_accounts VALUES (13, 'GBOK7BOUSOWPHBANBYM6MIRYZJIDIPUYJPXHTHADF75UEVIVYWHHONQC', 21, '2016-06-29 16:34:23');
+INSERT INTO history_accounts VALUES (14, 'GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD', 21, '2016-06-29 16:34:23');
+INSERT INTO history_accounts VALUES (15, 'GB6GN3LJUW6JYR7EDOJ47VBH7D45M4JWHXGK6LHJRAEI5JBSN2DBQY7Q', 25, '2016-06-29 16:34:27');
+INSERT INTO history_accounts VALUES (16, 'GCIFFRQKHMH6JD7CK5OI4XVCYCMNRNF6PYA7JTCR3FPHPJZQTYYFB5ES', 27, '2016-06-29 16:34:29');
+INSERT INTO history_accounts VALUES (17, 'GCJKJXPKBFIHOO3455WXWG5CDBZXQNYFRRGICYMPUQ35CPQ4WVS3KZLG', 33, '2016-06-29 16:34:35');
+INSERT INTO history_accounts VALUES (18, 'GCVW5LCRZFP7PENXTAGOVIQXADDNUXXZJCNKF4VQB2IK7W2LPJWF73UG', 38, '2016-06-29 16:34:40');
+INSERT INTO history_accounts VALUES (19, 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF', 38, '2016-06-29 16:34:40');
+INSERT INTO history_accounts VALUES (20, 'GCHPXGVDKPF5KT4CNAT7X77OXYZ7YVE4JHKFDUHCGCVWCL4K4PQ67KKZ', 43, '2016-06-29 16:34:45');
+INSERT INTO history_accounts VALUES (21, 'GDR53WAEIKOU3ZKN34CSHAWH7HV6K63CBJRUTWUDBFSMY7RRQK3SPKOS', 45, '2016-06-29 16:34:47');
+INSERT INTO history_accounts VALUES (22, 'GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD', 48, '2016-06-29 16:34:50');
+INSERT INTO history_accounts VALUES (23, 'GACJPE4YUR22VP4CM2BDFDAHY3DLEF3H7NENKUQ53DT5TEI2GAHT5N4X', 53, '2016-06-29 16:34:55');
+INSERT INTO history_accounts VALUES (24, 'GANFZDRBCNTUXIODCJEYMACPMCSZEVE4WZGZ3CZDZ3P2SXK4KH75IK6Y', 55, '2016-06-29 16:34:57');
+INSERT INTO history_accounts VALUES (25, 'GAKNI7DPN7BGAB62BU4GQC364JCFXFV7KIVTTDVOQK2ZPBPSJETABDQU', 57, '2016-06-29 16:34:59');
 
 
 --
@@ -489,69 +586,150 @@ INSERT INTO history_effects VALUES (1, 244813139969, 2, 3, '{"amount": "1000.000
 INSERT INTO history_effects VALUES (25, 244813139969, 3, 10, '{"weight": 1, "public_key": "GAKNI7DPN7BGAB62BU4GQC364JCFXFV7KIVTTDVOQK2ZPBPSJETABDQU"}');
 
 
+--
+-- Data for Name: history_ledger_upgrades; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+INSERT INTO history_ledger_upgrades VALUES (8589934592, 2, 1, 1, 2);
+INSERT INTO history_ledger_upgrades VALUES (8589934592, 2, 2, 3, 10000);
+
+
 --
 -- Data for Name: history_ledgers; Type: TABLE DATA; Schema: public; Owner: -
 --
 
-INSERT INTO history_ledgers VALUES (1, '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', NULL, 0, 0, '1970-01-01 00:00:00', '2016-06-29 16:34:09.007367', '2016-06-29 16:34:09.007367', 4294967296, 8, 1000000000000000000, 0, 100, 100000000, 100);
-INSERT INTO history_ledgers VALUES (2, 'fb22bbb0ead9bb480ec3044722b33ffd6b4946aecdbd8f788873f79c09f70335', '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', 0, 0, '2016-06-29 16:34:04', '2016-06-29 16:34:09.010774', '2016-06-29 16:34:09.010774', 8589934592, 8, 1000000000000000000, 0, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (3, '7cdae330dcecade466d466c4f0c2c2a02fc48b476cf7d2cb9e4731a69624ca44', 'fb22bbb0ead9bb480ec3044722b33ffd6b4946aecdbd8f788873f79c09f70335', 1, 1, '2016-06-29 16:34:05', '2016-06-29 16:34:09.013412', '2016-06-29 16:34:09.013412', 12884901888, 8, 1000000000000000000, 100, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (4, 'b7bb22fc2725108201a32edc78403fd53fd1d578fa20edaa081ed463422661f0', '7cdae330dcecade466d466c4f0c2c2a02fc48b476cf7d2cb9e4731a69624ca44', 1, 1, '2016-06-29 16:34:06', '2016-06-29 16:34:09.021429', '2016-06-29 16:34:09.021429', 17179869184, 8, 1000000000000000000, 200, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (5, '5df4e168b81b3573173d7c921f1ba8e3a74d51f7c0374e435f06065477691bbc', 'b7bb22fc2725108201a32edc78403fd53fd1d578fa20edaa081ed463422661f0', 3, 3, '2016-06-29 16:34:07', '2016-06-29 16:34:09.027724', '2016-06-29 16:34:09.027724', 21474836480, 8, 1000000000000000000, 500, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (6, '57285676071d0ea948d8561ddc969e54d4d2f684f9304611fa8705128deaabe0', '5df4e168b81b3573173d7c921f1ba8e3a74d51f7c0374e435f06065477691bbc', 1, 1, '2016-06-29 16:34:08', '2016-06-29 16:34:09.035635', '2016-06-29 16:34:09.035635', 25769803776, 8, 1000000000000000000, 600, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (7, 'ed7ec35197e255143851646965a62973d566474c4aa3e755cf241c5ee88a7b73', '57285676071d0ea948d8561ddc969e54d4d2f684f9304611fa8705128deaabe0', 1, 1, '2016-06-29 16:34:09', '2016-06-29 16:34:09.040211', '2016-06-29 16:34:09.040211', 30064771072, 8, 1000000000000000000, 700, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (8, '24baf3dc56fd0819f25cb2024562c64c971ca1bb7fc4ff65efddbac4ffc3be3b', 'ed7ec35197e255143851646965a62973d566474c4aa3e755cf241c5ee88a7b73', 4, 4, '2016-06-29 16:34:10', '2016-06-29 16:34:09.046152', '2016-06-29 16:34:09.046152', 34359738368, 8, 1000000000000000000, 1100, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (9, '1c9139bae67821a5deb6e96e5058deb76f83d085a16ac4e6b7b551cc4637f1bd', '24baf3dc56fd0819f25cb2024562c64c971ca1bb7fc4ff65efddbac4ffc3be3b', 1, 1, '2016-06-29 16:34:11', '2016-06-29 16:34:09.057936', '2016-06-29 16:34:09.057936', 38654705664, 8, 1000000000000000000, 1200, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (10, 'd0b5bfcf3fd5dfe188354a142904dcbc6366b6ac0ba559970654d3a84e55e7db', '1c9139bae67821a5deb6e96e5058deb76f83d085a16ac4e6b7b551cc4637f1bd', 1, 2, '2016-06-29 16:34:12', '2016-06-29 16:34:09.0635', '2016-06-29 16:34:09.0635', 42949672960, 8, 1000000000000000000, 1400, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (11, 'b9f956b26ba7b3a480017f09eaf7c559b74f58bc678908727a09d65f5bd27872', 'd0b5bfcf3fd5dfe188354a142904dcbc6366b6ac0ba559970654d3a84e55e7db', 1, 1, '2016-06-29 16:34:13', '2016-06-29 16:34:09.069222', '2016-06-29 16:34:09.069222', 47244640256, 8, 1000000000000000000, 1500, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (12, '10f6bea3c70bf69b0753075ca35f37ffcadde446eb1c5d094e815216daa8711b', 'b9f956b26ba7b3a480017f09eaf7c559b74f58bc678908727a09d65f5bd27872', 1, 1, '2016-06-29 16:34:14', '2016-06-29 16:34:09.075512', '2016-06-29 16:34:09.075512', 51539607552, 8, 1000000000000000000, 1600, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (13, 'f8c6b5e201ae76d715d3eb497d5a31cdb3b0346e6148b2e05dcdbcf216a46642', '10f6bea3c70bf69b0753075ca35f37ffcadde446eb1c5d094e815216daa8711b', 2, 2, '2016-06-29 16:34:15', '2016-06-29 16:34:09.080862', '2016-06-29 16:34:09.080862', 55834574848, 8, 1000000000000000000, 1800, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (14, '8fa796e38b1d05cef9c8e353704583803afe65cde71e3aa34918b7b069d745a2', 'f8c6b5e201ae76d715d3eb497d5a31cdb3b0346e6148b2e05dcdbcf216a46642', 2, 2, '2016-06-29 16:34:16', '2016-06-29 16:34:09.088931', '2016-06-29 16:34:09.088931', 60129542144, 8, 1000000000000000000, 2000, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (15, 'f464f0cc1d68129cb88b949ed709d926884d08be9da3f4df044b1bbb66b8a163', '8fa796e38b1d05cef9c8e353704583803afe65cde71e3aa34918b7b069d745a2', 1, 1, '2016-06-29 16:34:17', '2016-06-29 16:34:09.095079', '2016-06-29 16:34:09.095079', 64424509440, 8, 1000000000000000000, 2100, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (16, '820b74b571d87173c0b9e26c19ce7a6444e1b285043e2f0141b16126b0df1db7', 'f464f0cc1d68129cb88b949ed709d926884d08be9da3f4df044b1bbb66b8a163', 3, 3, '2016-06-29 16:34:18', '2016-06-29 16:34:09.099691', '2016-06-29 16:34:09.099691', 68719476736, 8, 1000000000000000000, 2400, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (17, '5c8d74ee57d23d260c49be2f8199c5270e3515e1c9bf7cb7c5db8d636638966c', '820b74b571d87173c0b9e26c19ce7a6444e1b285043e2f0141b16126b0df1db7', 2, 2, '2016-06-29 16:34:19', '2016-06-29 16:34:09.110072', '2016-06-29 16:34:09.110072', 73014444032, 8, 1000000000000000000, 2600, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (18, 'd07aee90169d8a9c0e3b16bbdd1cd79178a768306a2ea3916b2b66f909ab4d54', '5c8d74ee57d23d260c49be2f8199c5270e3515e1c9bf7cb7c5db8d636638966c', 3, 3, '2016-06-29 16:34:20', '2016-06-29 16:34:09.115633', '2016-06-29 16:34:09.115633', 77309411328, 8, 1000000000000000000, 2900, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (19, 'c53162f2d34b34aff18c32f43ca63cd9036e9fe7b961b6cbcaf77122d465bd5a', 'd07aee90169d8a9c0e3b16bbdd1cd79178a768306a2ea3916b2b66f909ab4d54', 1, 1, '2016-06-29 16:34:21', '2016-06-29 16:34:09.123894', '2016-06-29 16:34:09.123894', 81604378624, 8, 1000000000000000000, 3000, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (20, '337eb030b7d0fdfecab3b13853cbc7bc44c953d4f3bcf33b847444d1b1acdac9', 'c53162f2d34b34aff18c32f43ca63cd9036e9fe7b961b6cbcaf77122d465bd5a', 1, 1, '2016-06-29 16:34:22', '2016-06-29 16:34:09.130777', '2016-06-29 16:34:09.130777', 85899345920, 8, 1000000000000000000, 3100, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (21, 'c9002d5676ea0cfa94d02b70f7e00966d299604ae15e3f3c8885701965f951e8', '337eb030b7d0fdfecab3b13853cbc7bc44c953d4f3bcf33b847444d1b1acdac9', 2, 2, '2016-06-29 16:34:23', '2016-06-29 16:34:09.136797', '2016-06-29 16:34:09.136797', 90194313216, 8, 1000000000000000000, 3300, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (22, 'a2185667d16e302acdd47e2fd49b59398eeccd1b7ef480f063955ffb266cd455', 'c9002d5676ea0cfa94d02b70f7e00966d299604ae15e3f3c8885701965f951e8', 1, 1, '2016-06-29 16:34:24', '2016-06-29 16:34:09.145043', '2016-06-29 16:34:09.145043', 94489280512, 8, 1000000000000000000, 3400, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (23, '60aa37da1d4e3aded83a3b4970821ab79ada120b034cb6111d3a98597513619d', 'a2185667d16e302acdd47e2fd49b59398eeccd1b7ef480f063955ffb266cd455', 1, 1, '2016-06-29 16:34:25', '2016-06-29 16:34:09.1504', '2016-06-29 16:34:09.1504', 98784247808, 8, 1000000000000000000, 3500, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (24, '9fcaa4deccfe3f2daa5cce744a3e0d886207bd35bf4f77ae836756dfc5ba9544', '60aa37da1d4e3aded83a3b4970821ab79ada120b034cb6111d3a98597513619d', 1, 1, '2016-06-29 16:34:26', '2016-06-29 16:34:09.154322', '2016-06-29 16:34:09.154322', 103079215104, 8, 1000000000000000000, 3600, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (25, 'd20cef6fe3aaa219d99d39f4100cf5286cd9489225007e238ab5b2ef5f1875dd', '9fcaa4deccfe3f2daa5cce744a3e0d886207bd35bf4f77ae836756dfc5ba9544', 1, 1, '2016-06-29 16:34:27', '2016-06-29 16:34:09.159046', '2016-06-29 16:34:09.159046', 107374182400, 8, 1000000000000000000, 3700, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (26, 'fe171a26b7bf9a75eb963e2f12d1aef7b278689066ab56176acee33157abdc12', 'd20cef6fe3aaa219d99d39f4100cf5286cd9489225007e238ab5b2ef5f1875dd', 2, 2, '2016-06-29 16:34:28', '2016-06-29 16:34:09.164851', '2016-06-29 16:34:09.164851', 111669149696, 8, 1000000000000000000, 3900, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (27, '658c89159547a94e13f3951947ba4ed0538f649a518107085b08315cfd7a2630', 'fe171a26b7bf9a75eb963e2f12d1aef7b278689066ab56176acee33157abdc12', 1, 1, '2016-06-29 16:34:29', '2016-06-29 16:34:09.169944', '2016-06-29 16:34:09.169944', 115964116992, 8, 1000000000000000000, 4000, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (28, '20970cff13a9b84272f846832db281cdbc3dafdd5ab51e06d6e7e78ab2443659', '658c89159547a94e13f3951947ba4ed0538f649a518107085b08315cfd7a2630', 7, 7, '2016-06-29 16:34:30', '2016-06-29 16:34:09.17543', '2016-06-29 16:34:09.17543', 120259084288, 8, 1000000000000000000, 4700, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (29, '53136ca4350555c522a52a2acc1f040595e510b8a7ffe48f16ccb70ba9e67db2', '20970cff13a9b84272f846832db281cdbc3dafdd5ab51e06d6e7e78ab2443659', 1, 1, '2016-06-29 16:34:31', '2016-06-29 16:34:09.18882', '2016-06-29 16:34:09.18882', 124554051584, 8, 1000000000000000000, 4800, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (30, '9b652c088b47c6a5af6574f4f4d19f9ae7bebaabc240dca74b74e8d24d284e71', '53136ca4350555c522a52a2acc1f040595e510b8a7ffe48f16ccb70ba9e67db2', 1, 1, '2016-06-29 16:34:32', '2016-06-29 16:34:09.192842', '2016-06-29 16:34:09.192843', 128849018880, 8, 1000000000000000000, 4900, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (31, 'ea8392131b70f17a294212b396a6c06ddfcfde7800e806987f6113623d5f2b2d', '9b652c088b47c6a5af6574f4f4d19f9ae7bebaabc240dca74b74e8d24d284e71', 1, 1, '2016-06-29 16:34:33', '2016-06-29 16:34:09.19688', '2016-06-29 16:34:09.19688', 133143986176, 8, 1000000000000000000, 5000, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (32, '7c779a3360debae7b94803aee99b1ceba229af4f65113162bb44b12774adcc4c', 'ea8392131b70f17a294212b396a6c06ddfcfde7800e806987f6113623d5f2b2d', 2, 2, '2016-06-29 16:34:34', '2016-06-29 16:34:09.201016', '2016-06-29 16:34:09.201016', 137438953472, 8, 1000000000000000000, 5200, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (33, '5092290a187f35857dd0c6edb8bd909fe956e00bed4d3b5e51cf2858671227e5', '7c779a3360debae7b94803aee99b1ceba229af4f65113162bb44b12774adcc4c', 1, 1, '2016-06-29 16:34:35', '2016-06-29 16:34:09.206753', '2016-06-29 16:34:09.206753', 141733920768, 8, 1000000000000000000, 5300, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (34, '78bb254f3edb6601690e1bb24fad11fc9552582ce877ee44bd9f11a4e5753564', '5092290a187f35857dd0c6edb8bd909fe956e00bed4d3b5e51cf2858671227e5', 1, 1, '2016-06-29 16:34:36', '2016-06-29 16:34:09.211857', '2016-06-29 16:34:09.211857', 146028888064, 8, 1000000000000000000, 5400, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (35, '1d959a45b01ad085b6b0dd139829c19c0aaa4c82d50c3a56088aeb2d5743c896', '78bb254f3edb6601690e1bb24fad11fc9552582ce877ee44bd9f11a4e5753564', 1, 1, '2016-06-29 16:34:37', '2016-06-29 16:34:09.216', '2016-06-29 16:34:09.216', 150323855360, 8, 1000000000000000000, 5500, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (36, 'e9dfdda7873f06d25d41696ac54100acf72e997e95728e636e229d5b87e1103c', '1d959a45b01ad085b6b0dd139829c19c0aaa4c82d50c3a56088aeb2d5743c896', 1, 1, '2016-06-29 16:34:38', '2016-06-29 16:34:09.220071', '2016-06-29 16:34:09.220071', 154618822656, 8, 1000000000000000000, 5600, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (37, '573d4c42fdfbe408bcf4ab046f83eb8a2cf52f3cbfa4ba09b2b93e72840453b2', 'e9dfdda7873f06d25d41696ac54100acf72e997e95728e636e229d5b87e1103c', 1, 1, '2016-06-29 16:34:39', '2016-06-29 16:34:09.225053', '2016-06-29 16:34:09.225053', 158913789952, 8, 1000000000000000000, 5700, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (38, '98d102af2d6aa56584bec40c7653363449f66f4cf4c226d05a93b20940d82646', '573d4c42fdfbe408bcf4ab046f83eb8a2cf52f3cbfa4ba09b2b93e72840453b2', 2, 2, '2016-06-29 16:34:40', '2016-06-29 16:34:09.229752', '2016-06-29 16:34:09.229752', 163208757248, 8, 1000000000000000000, 5900, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (39, '890c50c0932c82f8c41df25d8568c53ceb42a33946f2df983db358b1a83e3799', '98d102af2d6aa56584bec40c7653363449f66f4cf4c226d05a93b20940d82646', 1, 1, '2016-06-29 16:34:41', '2016-06-29 16:34:09.237889', '2016-06-29 16:34:09.237889', 167503724544, 8, 1000000000000000000, 6000, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (40, '20ef938feddb4219f252eb19b879b7fa8648960e13e868f5d6733d53a37a2731', '890c50c0932c82f8c41df25d8568c53ceb42a33946f2df983db358b1a83e3799', 2, 2, '2016-06-29 16:34:42', '2016-06-29 16:34:09.242176', '2016-06-29 16:34:09.242176', 171798691840, 8, 1000000000000000000, 6200, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (41, '366f4a57087dfc337d57d78f79b168bfee79de82ae0a15e67a191134d9ac1e49', '20ef938feddb4219f252eb19b879b7fa8648960e13e868f5d6733d53a37a2731', 2, 2, '2016-06-29 16:34:43', '2016-06-29 16:34:09.247617', '2016-06-29 16:34:09.247617', 176093659136, 8, 1000000000000000000, 6400, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (42, '68f7cdfa2ac3f6e498fd30db9b1bf526372814a0c6afe6cb3d3901226d677209', '366f4a57087dfc337d57d78f79b168bfee79de82ae0a15e67a191134d9ac1e49', 1, 1, '2016-06-29 16:34:44', '2016-06-29 16:34:09.253938', '2016-06-29 16:34:09.253938', 180388626432, 8, 1000000000000000000, 6500, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (43, 'b4e79e623978fb2aebca1ec33072de1ac1a7cb46972c66d26d84a3144cdac182', '68f7cdfa2ac3f6e498fd30db9b1bf526372814a0c6afe6cb3d3901226d677209', 1, 1, '2016-06-29 16:34:45', '2016-06-29 16:34:09.258199', '2016-06-29 16:34:09.258199', 184683593728, 8, 1000000000000000000, 6600, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (44, '277c3f2dc5f9fa4eb8e3bea34fc843a331593fd8bf7d184298bc779fd859152e', 'b4e79e623978fb2aebca1ec33072de1ac1a7cb46972c66d26d84a3144cdac182', 1, 1, '2016-06-29 16:34:46', '2016-06-29 16:34:09.263678', '2016-06-29 16:34:09.263678', 188978561024, 8, 1000000000000000000, 6700, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (45, '79d1ae228f9b699da85112c1faa4f0f4c5c4afed550142e83554deced0858749', '277c3f2dc5f9fa4eb8e3bea34fc843a331593fd8bf7d184298bc779fd859152e', 1, 1, '2016-06-29 16:34:47', '2016-06-29 16:34:09.268328', '2016-06-29 16:34:09.268328', 193273528320, 8, 1000000000000000000, 6800, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (46, '3ad81dbb2c6aae081b02a8d6447ba507fe14d15f691b12bc977071e6b575cdb6', '79d1ae228f9b699da85112c1faa4f0f4c5c4afed550142e83554deced0858749', 2, 2, '2016-06-29 16:34:48', '2016-06-29 16:34:09.273658', '2016-06-29 16:34:09.273658', 197568495616, 8, 1000000000000000000, 7000, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (47, '5e1e79ef7a71b949b2bbeed735f454e918a820dd56a1ff4c3dde72b827cedaf0', '3ad81dbb2c6aae081b02a8d6447ba507fe14d15f691b12bc977071e6b575cdb6', 1, 1, '2016-06-29 16:34:49', '2016-06-29 16:34:09.279223', '2016-06-29 16:34:09.279223', 201863462912, 8, 1000190720969537511, 30469589, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (48, '86828dece93d95adcdf7c92f3bfa12afbe90c97841637d286ddbc263633c8fed', '5e1e79ef7a71b949b2bbeed735f454e918a820dd56a1ff4c3dde72b827cedaf0', 1, 1, '2016-06-29 16:34:50', '2016-06-29 16:34:09.283597', '2016-06-29 16:34:09.283597', 206158430208, 8, 1000190720969537511, 30469689, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (49, '817455af1d00a880abdbc2d5b9b1a7599b75931edba42a948da547a960e31410', '86828dece93d95adcdf7c92f3bfa12afbe90c97841637d286ddbc263633c8fed', 2, 2, '2016-06-29 16:34:51', '2016-06-29 16:34:09.288866', '2016-06-29 16:34:09.288866', 210453397504, 8, 1000190720969537511, 30469889, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (50, '1b605f49072ff6bd40ccb13d2ba9a1afbab3f2a5c0a1b1a8d7b77536b867ec50', '817455af1d00a880abdbc2d5b9b1a7599b75931edba42a948da547a960e31410', 1, 1, '2016-06-29 16:34:52', '2016-06-29 16:34:09.29421', '2016-06-29 16:34:09.29421', 214748364800, 8, 1000190720969537511, 30469989, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (51, '85a0c73535835431cbd5170837ed5be3aa8de2bdf656bfca7585104eb169bbeb', '1b605f49072ff6bd40ccb13d2ba9a1afbab3f2a5c0a1b1a8d7b77536b867ec50', 1, 1, '2016-06-29 16:34:53', '2016-06-29 16:34:09.298085', '2016-06-29 16:34:09.298085', 219043332096, 8, 1000190720969537511, 30470089, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (52, '4f261dfbd6491b335340bcb29fa6735c0660339393eabc7ee9315abdab2f1586', '85a0c73535835431cbd5170837ed5be3aa8de2bdf656bfca7585104eb169bbeb', 1, 1, '2016-06-29 16:34:54', '2016-06-29 16:34:09.302884', '2016-06-29 16:34:09.302884', 223338299392, 8, 1000190720969537511, 30470189, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (53, 'd48ce25386e6910336a4977b8f3dfb9c1095e0f29726d5fb5e4efe82d0f5f0f1', '4f261dfbd6491b335340bcb29fa6735c0660339393eabc7ee9315abdab2f1586', 1, 1, '2016-06-29 16:34:55', '2016-06-29 16:34:09.307427', '2016-06-29 16:34:09.307427', 227633266688, 8, 1000190720969537511, 30470289, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (54, '0027eff1c2f17b8ab7f3a1bd1b90ddb4b302843180412c83830b2c4b0578a968', 'd48ce25386e6910336a4977b8f3dfb9c1095e0f29726d5fb5e4efe82d0f5f0f1', 1, 2, '2016-06-29 16:34:56', '2016-06-29 16:34:09.31272', '2016-06-29 16:34:09.31272', 231928233984, 8, 1000190720969537511, 30470489, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (55, '4ca9d0efafaec46db7f82fabe157341eb4d8c16ea1addab5d8a9705dfa6c79a1', '0027eff1c2f17b8ab7f3a1bd1b90ddb4b302843180412c83830b2c4b0578a968', 1, 1, '2016-06-29 16:34:57', '2016-06-29 16:34:09.318595', '2016-06-29 16:34:09.318595', 236223201280, 8, 1000190720969537511, 30470589, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (56, 'df49ca7c5d95a9d114bddb1cb6ee6826ca3e06f1f8d56ca4dbc8f720bc25e5e2', '4ca9d0efafaec46db7f82fabe157341eb4d8c16ea1addab5d8a9705dfa6c79a1', 1, 1, '2016-06-29 16:34:58', '2016-06-29 16:34:09.323873', '2016-06-29 16:34:09.323873', 240518168576, 8, 1000190720969537511, 30470689, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (57, '27d8624e4cb6cd71a2d36c78a21c9d1abec47175e55c87459877049702bb5fa8', 'df49ca7c5d95a9d114bddb1cb6ee6826ca3e06f1f8d56ca4dbc8f720bc25e5e2', 1, 1, '2016-06-29 16:34:59', '2016-06-29 16:34:09.327991', '2016-06-29 16:34:09.327991', 244813135872, 8, 1000190720969537511, 30470789, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (58, '6728a7de5d054186933b5776924a12ff34faa6cf77615c8b31eec1a386115c60', '27d8624e4cb6cd71a2d36c78a21c9d1abec47175e55c87459877049702bb5fa8', 1, 1, '2016-06-29 16:35:00', '2016-06-29 16:34:09.333569', '2016-06-29 16:34:09.333569', 249108103168, 8, 1000190720969537511, 30470889, 100, 100000000, 10000);
-INSERT INTO history_ledgers VALUES (59, 'bcf5788cb12788e5a7288407263982a60d24307a4a4bd2fb5e30583c7c37736d', '6728a7de5d054186933b5776924a12ff34faa6cf77615c8b31eec1a386115c60', 0, 0, '2016-06-29 16:35:01', '2016-06-29 16:34:09.337252', '2016-06-29 16:34:09.337252', 253403070464, 8, 1000190720969537511, 30470889, 100, 100000000, 10000);
+INSERT INTO history_ledgers VALUES (1, '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', NULL, 0, 0, '1970-01-01 00:00:00', '2016-06-29 16:34:09.007367', '2016-06-29 16:34:09.007367', 4294967296, 12, 1000000000000000000, 0, 100, 100000000, 100, 1, 0, 0);
+INSERT INTO history_ledgers VALUES (2, 'fb22bbb0ead9bb480ec3044722b33ffd6b4946aecdbd8f788873f79c09f70335', '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', 0, 0, '2016-06-29 16:34:04', '2016-06-29 16:34:09.010774', '2016-06-29 16:34:09.010774', 8589934592, 12, 1000000000000000000, 0, 100, 100000000, 10000, 1, 0, 0);
+INSERT INTO history_ledgers VALUES (3, '7cdae330dcecade466d466c4f0c2c2a02fc48b476cf7d2cb9e4731a69624ca44', 'fb22bbb0ead9bb480ec3044722b33ffd6b4946aecdbd8f788873f79c09f70335', 1, 1, '2016-06-29 16:34:05', '2016-06-29 16:34:09.013412', '2016-06-29 16:34:09.013412', 12884901888, 12, 1000000000000000000, 100, 100, 100000000, 10000, 2, 0, 1);
+INSERT INTO history_ledgers VALUES (4, 'b7bb22fc2725108201a32edc78403fd53fd1d578fa20edaa081ed463422661f0', '7cdae330dcecade466d466c4f0c2c2a02fc48b476cf7d2cb9e4731a69624ca44', 1, 1, '2016-06-29 16:34:06', '2016-06-29 16:34:09.021429', '2016-06-29 16:34:09.021429', 17179869184, 12, 1000000000000000000, 200, 100, 100000000, 10000, 3, 0, 2);
+INSERT INTO history_ledgers VALUES (5, '5df4e168b81b3573173d7c921f1ba8e3a74d51f7c0374e435f06065477691bbc', 'b7bb22fc2725108201a32edc78403fd53fd1d578fa20edaa081ed463422661f0', 3, 3, '2016-06-29 16:34:07', '2016-06-29 16:34:09.027724', '2016-06-29 16:34:09.027724', 21474836480, 12, 1000000000000000000, 500, 100, 100000000, 10000, 3, 0, 5);
+INSERT INTO history_ledgers VALUES (6, '57285676071d0ea948d8561ddc969e54d4d2f684f9304611fa8705128deaabe0', '5df4e168b81b3573173d7c921f1ba8e3a74d51f7c0374e435f06065477691bbc', 1, 1, '2016-06-29 16:34:08', '2016-06-29 16:34:09.035635', '2016-06-29 16:34:09.035635', 25769803776, 12, 1000000000000000000, 600, 100, 100000000, 10000, 3, 0, 6);
+INSERT INTO history_ledgers VALUES (7, 'ed7ec35197e255143851646965a62973d566474c4aa3e755cf241c5ee88a7b73', '57285676071d0ea948d8561ddc969e54d4d2f684f9304611fa8705128deaabe0', 1, 1, '2016-06-29 16:34:09', '2016-06-29 16:34:09.040211', '2016-06-29 16:34:09.040211', 30064771072, 12, 1000000000000000000, 700, 100, 100000000, 10000, 4, 0, 7);
+INSERT INTO history_ledgers VALUES (8, '24baf3dc56fd0819f25cb2024562c64c971ca1bb7fc4ff65efddbac4ffc3be3b', 'ed7ec35197e255143851646965a62973d566474c4aa3e755cf241c5ee88a7b73', 4, 4, '2016-06-29 16:34:10', '2016-06-29 16:34:09.046152', '2016-06-29 16:34:09.046152', 34359738368, 12, 1000000000000000000, 1100, 100, 100000000, 10000, 4, 0, 11);
+INSERT INTO history_ledgers VALUES (9, '1c9139bae67821a5deb6e96e5058deb76f83d085a16ac4e6b7b551cc4637f1bd', '24baf3dc56fd0819f25cb2024562c64c971ca1bb7fc4ff65efddbac4ffc3be3b', 1, 1, '2016-06-29 16:34:11', '2016-06-29 16:34:09.057936', '2016-06-29 16:34:09.057936', 38654705664, 12, 1000000000000000000, 1200, 100, 100000000, 10000, 5, 0, 12);
+INSERT INTO history_ledgers VALUES (10, 'd0b5bfcf3fd5dfe188354a142904dcbc6366b6ac0ba559970654d3a84e55e7db', '1c9139bae67821a5deb6e96e5058deb76f83d085a16ac4e6b7b551cc4637f1bd', 1, 2, '2016-06-29 16:34:12', '2016-06-29 16:34:09.0635', '2016-06-29 16:34:09.0635', 42949672960, 12, 1000000000000000000, 1400, 100, 100000000, 10000, 5, 0, 14);
+INSERT INTO history_ledgers VALUES (11, 'b9f956b26ba7b3a480017f09eaf7c559b74f58bc678908727a09d65f5bd27872', 'd0b5bfcf3fd5dfe188354a142904dcbc6366b6ac0ba559970654d3a84e55e7db', 1, 1, '2016-06-29 16:34:13', '2016-06-29 16:34:09.069222', '2016-06-29 16:34:09.069222', 47244640256, 12, 1000000000000000000, 1500, 100, 100000000, 10000, 6, 0, 15);
+INSERT INTO history_ledgers VALUES (12, '10f6bea3c70bf69b0753075ca35f37ffcadde446eb1c5d094e815216daa8711b', 'b9f956b26ba7b3a480017f09eaf7c559b74f58bc678908727a09d65f5bd27872', 1, 1, '2016-06-29 16:34:14', '2016-06-29 16:34:09.075512', '2016-06-29 16:34:09.075512', 51539607552, 12, 1000000000000000000, 1600, 100, 100000000, 10000, 7, 0, 16);
+INSERT INTO history_ledgers VALUES (13, 'f8c6b5e201ae76d715d3eb497d5a31cdb3b0346e6148b2e05dcdbcf216a46642', '10f6bea3c70bf69b0753075ca35f37ffcadde446eb1c5d094e815216daa8711b', 2, 2, '2016-06-29 16:34:15', '2016-06-29 16:34:09.080862', '2016-06-29 16:34:09.080862', 55834574848, 12, 1000000000000000000, 1800, 100, 100000000, 10000, 9, 0, 18);
+INSERT INTO history_ledgers VALUES (14, '8fa796e38b1d05cef9c8e353704583803afe65cde71e3aa34918b7b069d745a2', 'f8c6b5e201ae76d715d3eb497d5a31cdb3b0346e6148b2e05dcdbcf216a46642', 2, 2, '2016-06-29 16:34:16', '2016-06-29 16:34:09.088931', '2016-06-29 16:34:09.088931', 60129542144, 12, 1000000000000000000, 2000, 100, 100000000, 10000, 9, 1, 20);
+INSERT INTO history_ledgers VALUES (15, 'f464f0cc1d68129cb88b949ed709d926884d08be9da3f4df044b1bbb66b8a163', '8fa796e38b1d05cef9c8e353704583803afe65cde71e3aa34918b7b069d745a2', 1, 1, '2016-06-29 16:34:17', '2016-06-29 16:34:09.095079', '2016-06-29 16:34:09.095079', 64424509440, 12, 1000000000000000000, 2100, 100, 100000000, 10000, 9, 1, 21);
+INSERT INTO history_ledgers VALUES (16, '820b74b571d87173c0b9e26c19ce7a6444e1b285043e2f0141b16126b0df1db7', 'f464f0cc1d68129cb88b949ed709d926884d08be9da3f4df044b1bbb66b8a163', 3, 3, '2016-06-29 16:34:18', '2016-06-29 16:34:09.099691', '2016-06-29 16:34:09.099691', 68719476736, 12, 1000000000000000000, 2400, 100, 100000000, 10000, 12, 1, 24);
+INSERT INTO history_ledgers VALUES (17, '5c8d74ee57d23d260c49be2f8199c5270e3515e1c9bf7cb7c5db8d636638966c', '820b74b571d87173c0b9e26c19ce7a6444e1b285043e2f0141b16126b0df1db7', 2, 2, '2016-06-29 16:34:19', '2016-06-29 16:34:09.110072', '2016-06-29 16:34:09.110072', 73014444032, 12, 1000000000000000000, 2600, 100, 100000000, 10000, 12, 3, 26);
+INSERT INTO history_ledgers VALUES (18, 'd07aee90169d8a9c0e3b16bbdd1cd79178a768306a2ea3916b2b66f909ab4d54', '5c8d74ee57d23d260c49be2f8199c5270e3515e1c9bf7cb7c5db8d636638966c', 3, 3, '2016-06-29 16:34:20', '2016-06-29 16:34:09.115633', '2016-06-29 16:34:09.115633', 77309411328, 12, 1000000000000000000, 2900, 100, 100000000, 10000, 12, 3, 29);
+INSERT INTO history_ledgers VALUES (19, 'c53162f2d34b34aff18c32f43ca63cd9036e9fe7b961b6cbcaf77122d465bd5a', 'd07aee90169d8a9c0e3b16bbdd1cd79178a768306a2ea3916b2b66f909ab4d54', 1, 1, '2016-06-29 16:34:21', '2016-06-29 16:34:09.123894', '2016-06-29 16:34:09.123894', 81604378624, 12, 1000000000000000000, 3000, 100, 100000000, 10000, 12, 3, 30);
+INSERT INTO history_ledgers VALUES (20, '337eb030b7d0fdfecab3b13853cbc7bc44c953d4f3bcf33b847444d1b1acdac9', 'c53162f2d34b34aff18c32f43ca63cd9036e9fe7b961b6cbcaf77122d465bd5a', 1, 1, '2016-06-29 16:34:22', '2016-06-29 16:34:09.130777', '2016-06-29 16:34:09.130777', 85899345920, 12, 1000000000000000000, 3100, 100, 100000000, 10000, 12, 3, 31);
+INSERT INTO history_ledgers VALUES (21, 'c9002d5676ea0cfa94d02b70f7e00966d299604ae15e3f3c8885701965f951e8', '337eb030b7d0fdfecab3b13853cbc7bc44c953d4f3bcf33b847444d1b1acdac9', 2, 2, '2016-06-29 16:34:23', '2016-06-29 16:34:09.136797', '2016-06-29 16:34:09.136797', 90194313216, 12, 1000000000000000000, 3300, 100, 100000000, 10000, 14, 3, 33);
+INSERT INTO history_ledgers VALUES (22, 'a2185667d16e302acdd47e2fd49b59398eeccd1b7ef480f063955ffb266cd455', 'c9002d5676ea0cfa94d02b70f7e00966d299604ae15e3f3c8885701965f951e8', 1, 1, '2016-06-29 16:34:24', '2016-06-29 16:34:09.145043', '2016-06-29 16:34:09.145043', 94489280512, 12, 1000000000000000000, 3400, 100, 100000000, 10000, 14, 4, 34);
+INSERT INTO history_ledgers VALUES (23, '60aa37da1d4e3aded83a3b4970821ab79ada120b034cb6111d3a98597513619d', 'a2185667d16e302acdd47e2fd49b59398eeccd1b7ef480f063955ffb266cd455', 1, 1, '2016-06-29 16:34:25', '2016-06-29 16:34:09.1504', '2016-06-29 16:34:09.1504', 98784247808, 12, 1000000000000000000, 3500, 100, 100000000, 10000, 14, 4, 35);
+INSERT INTO history_ledgers VALUES (24, '9fcaa4deccfe3f2daa5cce744a3e0d886207bd35bf4f77ae836756dfc5ba9544', '60aa37da1d4e3aded83a3b4970821ab79ada120b034cb6111d3a98597513619d', 1, 1, '2016-06-29 16:34:26', '2016-06-29 16:34:09.154322', '2016-06-29 16:34:09.154322', 103079215104, 12, 1000000000000000000, 3600, 100, 100000000, 10000, 14, 4, 36);
+INSERT INTO history_ledgers VALUES (25, 'd20cef6fe3aaa219d99d39f4100cf5286cd9489225007e238ab5b2ef5f1875dd', '9fcaa4deccfe3f2daa5cce744a3e0d886207bd35bf4f77ae836756dfc5ba9544', 1, 1, '2016-06-29 16:34:27', '2016-06-29 16:34:09.159046', '2016-06-29 16:34:09.159046', 107374182400, 12, 1000000000000000000, 3700, 100, 100000000, 10000, 15, 4, 37);
+INSERT INTO history_ledgers VALUES (26, 'fe171a26b7bf9a75eb963e2f12d1aef7b278689066ab56176acee33157abdc12', 'd20cef6fe3aaa219d99d39f4100cf5286cd9489225007e238ab5b2ef5f1875dd', 2, 2, '2016-06-29 16:34:28', '2016-06-29 16:34:09.164851', '2016-06-29 16:34:09.164851', 111669149696, 12, 1000000000000000000, 3900, 100, 100000000, 10000, 15, 4, 39);
+INSERT INTO history_ledgers VALUES (27, '658c89159547a94e13f3951947ba4ed0538f649a518107085b08315cfd7a2630', 'fe171a26b7bf9a75eb963e2f12d1aef7b278689066ab56176acee33157abdc12', 1, 1, '2016-06-29 16:34:29', '2016-06-29 16:34:09.169944', '2016-06-29 16:34:09.169944', 115964116992, 12, 1000000000000000000, 4000, 100, 100000000, 10000, 16, 4, 40);
+INSERT INTO history_ledgers VALUES (28, '20970cff13a9b84272f846832db281cdbc3dafdd5ab51e06d6e7e78ab2443659', '658c89159547a94e13f3951947ba4ed0538f649a518107085b08315cfd7a2630', 7, 7, '2016-06-29 16:34:30', '2016-06-29 16:34:09.17543', '2016-06-29 16:34:09.17543', 120259084288, 12, 1000000000000000000, 4700, 100, 100000000, 10000, 16, 4, 47);
+INSERT INTO history_ledgers VALUES (29, '53136ca4350555c522a52a2acc1f040595e510b8a7ffe48f16ccb70ba9e67db2', '20970cff13a9b84272f846832db281cdbc3dafdd5ab51e06d6e7e78ab2443659', 1, 1, '2016-06-29 16:34:31', '2016-06-29 16:34:09.18882', '2016-06-29 16:34:09.18882', 124554051584, 12, 1000000000000000000, 4800, 100, 100000000, 10000, 16, 4, 48);
+INSERT INTO history_ledgers VALUES (30, '9b652c088b47c6a5af6574f4f4d19f9ae7bebaabc240dca74b74e8d24d284e71', '53136ca4350555c522a52a2acc1f040595e510b8a7ffe48f16ccb70ba9e67db2', 1, 1, '2016-06-29 16:34:32', '2016-06-29 16:34:09.192842', '2016-06-29 16:34:09.192843', 128849018880, 12, 1000000000000000000, 4900, 100, 100000000, 10000, 16, 4, 49);
+INSERT INTO history_ledgers VALUES (31, 'ea8392131b70f17a294212b396a6c06ddfcfde7800e806987f6113623d5f2b2d', '9b652c088b47c6a5af6574f4f4d19f9ae7bebaabc240dca74b74e8d24d284e71', 1, 1, '2016-06-29 16:34:33', '2016-06-29 16:34:09.19688', '2016-06-29 16:34:09.19688', 133143986176, 12, 1000000000000000000, 5000, 100, 100000000, 10000, 16, 4, 50);
+INSERT INTO history_ledgers VALUES (32, '7c779a3360debae7b94803aee99b1ceba229af4f65113162bb44b12774adcc4c', 'ea8392131b70f17a294212b396a6c06ddfcfde7800e806987f6113623d5f2b2d', 2, 2, '2016-06-29 16:34:34', '2016-06-29 16:34:09.201016', '2016-06-29 16:34:09.201016', 137438953472, 12, 1000000000000000000, 5200, 100, 100000000, 10000, 16, 4, 52);
+INSERT INTO history_ledgers VALUES (33, '5092290a187f35857dd0c6edb8bd909fe956e00bed4d3b5e51cf2858671227e5', '7c779a3360debae7b94803aee99b1ceba229af4f65113162bb44b12774adcc4c', 1, 1, '2016-06-29 16:34:35', '2016-06-29 16:34:09.206753', '2016-06-29 16:34:09.206753', 141733920768, 12, 1000000000000000000, 5300, 100, 100000000, 10000, 17, 4, 53);
+INSERT INTO history_ledgers VALUES (34, '78bb254f3edb6601690e1bb24fad11fc9552582ce877ee44bd9f11a4e5753564', '5092290a187f35857dd0c6edb8bd909fe956e00bed4d3b5e51cf2858671227e5', 1, 1, '2016-06-29 16:34:36', '2016-06-29 16:34:09.211857', '2016-06-29 16:34:09.211857', 146028888064, 12, 1000000000000000000, 5400, 100, 100000000, 10000, 17, 5, 54);
+INSERT INTO history_ledgers VALUES (35, '1d959a45b01ad085b6b0dd139829c19c0aaa4c82d50c3a56088aeb2d5743c896', '78bb254f3edb6601690e1bb24fad11fc9552582ce877ee44bd9f11a4e5753564', 1, 1, '2016-06-29 16:34:37', '2016-06-29 16:34:09.216', '2016-06-29 16:34:09.216', 150323855360, 12, 1000000000000000000, 5500, 100, 100000000, 10000, 17, 5, 55);
+INSERT INTO history_ledgers VALUES (36, 'e9dfdda7873f06d25d41696ac54100acf72e997e95728e636e229d5b87e1103c', '1d959a45b01ad085b6b0dd139829c19c0aaa4c82d50c3a56088aeb2d5743c896', 1, 1, '2016-06-29 16:34:38', '2016-06-29 16:34:09.220071', '2016-06-29 16:34:09.220071', 154618822656, 12, 1000000000000000000, 5600, 100, 100000000, 10000, 17, 5, 56);
+INSERT INTO history_ledgers VALUES (37, '573d4c42fdfbe408bcf4ab046f83eb8a2cf52f3cbfa4ba09b2b93e72840453b2', 'e9dfdda7873f06d25d41696ac54100acf72e997e95728e636e229d5b87e1103c', 1, 1, '2016-06-29 16:34:39', '2016-06-29 16:34:09.225053', '2016-06-29 16:34:09.225053', 158913789952, 12, 1000000000000000000, 5700, 100, 100000000, 10000, 17, 4, 57);
+INSERT INTO history_ledgers VALUES (38, '98d102af2d6aa56584bec40c7653363449f66f4cf4c226d05a93b20940d82646', '573d4c42fdfbe408bcf4ab046f83eb8a2cf52f3cbfa4ba09b2b93e72840453b2', 2, 2, '2016-06-29 16:34:40', '2016-06-29 16:34:09.229752', '2016-06-29 16:34:09.229752', 163208757248, 12, 1000000000000000000, 5900, 100, 100000000, 10000, 19, 4, 59);
+INSERT INTO history_ledgers VALUES (39, '890c50c0932c82f8c41df25d8568c53ceb42a33946f2df983db358b1a83e3799', '98d102af2d6aa56584bec40c7653363449f66f4cf4c226d05a93b20940d82646', 1, 1, '2016-06-29 16:34:41', '2016-06-29 16:34:09.237889', '2016-06-29 16:34:09.237889', 167503724544, 12, 1000000000000000000, 6000, 100, 100000000, 10000, 19, 4, 60);
+INSERT INTO history_ledgers VALUES (40, '20ef938feddb4219f252eb19b879b7fa8648960e13e868f5d6733d53a37a2731', '890c50c0932c82f8c41df25d8568c53ceb42a33946f2df983db358b1a83e3799', 2, 2, '2016-06-29 16:34:42', '2016-06-29 16:34:09.242176', '2016-06-29 16:34:09.242176', 171798691840, 12, 1000000000000000000, 6200, 100, 100000000, 10000, 19, 6, 62);
+INSERT INTO history_ledgers VALUES (41, '366f4a57087dfc337d57d78f79b168bfee79de82ae0a15e67a191134d9ac1e49', '20ef938feddb4219f252eb19b879b7fa8648960e13e868f5d6733d53a37a2731', 2, 2, '2016-06-29 16:34:43', '2016-06-29 16:34:09.247617', '2016-06-29 16:34:09.247617', 176093659136, 12, 1000000000000000000, 6400, 100, 100000000, 10000, 19, 6, 64);
+INSERT INTO history_ledgers VALUES (42, '68f7cdfa2ac3f6e498fd30db9b1bf526372814a0c6afe6cb3d3901226d677209', '366f4a57087dfc337d57d78f79b168bfee79de82ae0a15e67a191134d9ac1e49', 1, 1, '2016-06-29 16:34:44', '2016-06-29 16:34:09.253938', '2016-06-29 16:34:09.253938', 180388626432, 12, 1000000000000000000, 6500, 100, 100000000, 10000, 19, 6, 65);
+INSERT INTO history_ledgers VALUES (43, 'b4e79e623978fb2aebca1ec33072de1ac1a7cb46972c66d26d84a3144cdac182', '68f7cdfa2ac3f6e498fd30db9b1bf526372814a0c6afe6cb3d3901226d677209', 1, 1, '2016-06-29 16:34:45', '2016-06-29 16:34:09.258199', '2016-06-29 16:34:09.258199', 184683593728, 12, 1000000000000000000, 6600, 100, 100000000, 10000, 20, 6, 66);
+INSERT INTO history_ledgers VALUES (44, '277c3f2dc5f9fa4eb8e3bea34fc843a331593fd8bf7d184298bc779fd859152e', 'b4e79e623978fb2aebca1ec33072de1ac1a7cb46972c66d26d84a3144cdac182', 1, 1, '2016-06-29 16:34:46', '2016-06-29 16:34:09.263678', '2016-06-29 16:34:09.263678', 188978561024, 12, 1000000000000000000, 6700, 100, 100000000, 10000, 19, 6, 67);
+INSERT INTO history_ledgers VALUES (45, '79d1ae228f9b699da85112c1faa4f0f4c5c4afed550142e83554deced0858749', '277c3f2dc5f9fa4eb8e3bea34fc843a331593fd8bf7d184298bc779fd859152e', 1, 1, '2016-06-29 16:34:47', '2016-06-29 16:34:09.268328', '2016-06-29 16:34:09.268328', 193273528320, 12, 1000000000000000000, 6800, 100, 100000000, 10000, 20, 6, 68);
+INSERT INTO history_ledgers VALUES (46, '3ad81dbb2c6aae081b02a8d6447ba507fe14d15f691b12bc977071e6b575cdb6', '79d1ae228f9b699da85112c1faa4f0f4c5c4afed550142e83554deced0858749', 2, 2, '2016-06-29 16:34:48', '2016-06-29 16:34:09.273658', '2016-06-29 16:34:09.273658', 197568495616, 12, 1000000000000000000, 7000, 100, 100000000, 10000, 20, 6, 70);
+INSERT INTO history_ledgers VALUES (47, '5e1e79ef7a71b949b2bbeed735f454e918a820dd56a1ff4c3dde72b827cedaf0', '3ad81dbb2c6aae081b02a8d6447ba507fe14d15f691b12bc977071e6b575cdb6', 1, 1, '2016-06-29 16:34:49', '2016-06-29 16:34:09.279223', '2016-06-29 16:34:09.279223', 201863462912, 12, 1000190720969537511, 30469589, 100, 100000000, 10000, 20, 6, 71);
+INSERT INTO history_ledgers VALUES (48, '86828dece93d95adcdf7c92f3bfa12afbe90c97841637d286ddbc263633c8fed', '5e1e79ef7a71b949b2bbeed735f454e918a820dd56a1ff4c3dde72b827cedaf0', 1, 1, '2016-06-29 16:34:50', '2016-06-29 16:34:09.283597', '2016-06-29 16:34:09.283597', 206158430208, 12, 1000190720969537511, 30469689, 100, 100000000, 10000, 21, 6, 72);
+INSERT INTO history_ledgers VALUES (49, '817455af1d00a880abdbc2d5b9b1a7599b75931edba42a948da547a960e31410', '86828dece93d95adcdf7c92f3bfa12afbe90c97841637d286ddbc263633c8fed', 2, 2, '2016-06-29 16:34:51', '2016-06-29 16:34:09.288866', '2016-06-29 16:34:09.288866', 210453397504, 12, 1000190720969537511, 30469889, 100, 100000000, 10000, 21, 6, 74);
+INSERT INTO history_ledgers VALUES (50, '1b605f49072ff6bd40ccb13d2ba9a1afbab3f2a5c0a1b1a8d7b77536b867ec50', '817455af1d00a880abdbc2d5b9b1a7599b75931edba42a948da547a960e31410', 1, 1, '2016-06-29 16:34:52', '2016-06-29 16:34:09.29421', '2016-06-29 16:34:09.29421', 214748364800, 12, 1000190720969537511, 30469989, 100, 100000000, 10000, 21, 6, 75);
+INSERT INTO history_ledgers VALUES (51, '85a0c73535835431cbd5170837ed5be3aa8de2bdf656bfca7585104eb169bbeb', '1b605f49072ff6bd40ccb13d2ba9a1afbab3f2a5c0a1b1a8d7b77536b867ec50', 1, 1, '2016-06-29 16:34:53', '2016-06-29 16:34:09.298085', '2016-06-29 16:34:09.298085', 219043332096, 12, 1000190720969537511, 30470089, 100, 100000000, 10000, 21, 6, 76);
+INSERT INTO history_ledgers VALUES (52, '4f261dfbd6491b335340bcb29fa6735c0660339393eabc7ee9315abdab2f1586', '85a0c73535835431cbd5170837ed5be3aa8de2bdf656bfca7585104eb169bbeb', 1, 1, '2016-06-29 16:34:54', '2016-06-29 16:34:09.302884', '2016-06-29 16:34:09.302884', 223338299392, 12, 1000190720969537511, 30470189, 100, 100000000, 10000, 21, 6, 77);
+INSERT INTO history_ledgers VALUES (53, 'd48ce25386e6910336a4977b8f3dfb9c1095e0f29726d5fb5e4efe82d0f5f0f1', '4f261dfbd6491b335340bcb29fa6735c0660339393eabc7ee9315abdab2f1586', 1, 1, '2016-06-29 16:34:55', '2016-06-29 16:34:09.307427', '2016-06-29 16:34:09.307427', 227633266688, 12, 1000190720969537511, 30470289, 100, 100000000, 10000, 22, 6, 78);
+INSERT INTO history_ledgers VALUES (54, '0027eff1c2f17b8ab7f3a1bd1b90ddb4b302843180412c83830b2c4b0578a968', 'd48ce25386e6910336a4977b8f3dfb9c1095e0f29726d5fb5e4efe82d0f5f0f1', 1, 2, '2016-06-29 16:34:56', '2016-06-29 16:34:09.31272', '2016-06-29 16:34:09.31272', 231928233984, 12, 1000190720969537511, 30470489, 100, 100000000, 10000, 22, 6, 80);
+INSERT INTO history_ledgers VALUES (55, '4ca9d0efafaec46db7f82fabe157341eb4d8c16ea1addab5d8a9705dfa6c79a1', '0027eff1c2f17b8ab7f3a1bd1b90ddb4b302843180412c83830b2c4b0578a968', 1, 1, '2016-06-29 16:34:57', '2016-06-29 16:34:09.318595', '2016-06-29 16:34:09.318595', 236223201280, 12, 1000190720969537511, 30470589, 100, 100000000, 10000, 23, 6, 81);
+INSERT INTO history_ledgers VALUES (56, 'df49ca7c5d95a9d114bddb1cb6ee6826ca3e06f1f8d56ca4dbc8f720bc25e5e2', '4ca9d0efafaec46db7f82fabe157341eb4d8c16ea1addab5d8a9705dfa6c79a1', 1, 1, '2016-06-29 16:34:58', '2016-06-29 16:34:09.323873', '2016-06-29 16:34:09.323873', 240518168576, 12, 1000190720969537511, 30470689, 100, 100000000, 10000, 23, 6, 82);
+INSERT INTO history_ledgers VALUES (57, '27d8624e4cb6cd71a2d36c78a21c9d1abec47175e55c87459877049702bb5fa8', 'df49ca7c5d95a9d114bddb1cb6ee6826ca3e06f1f8d56ca4dbc8f720bc25e5e2', 1, 1, '2016-06-29 16:34:59', '2016-06-29 16:34:09.327991', '2016-06-29 16:34:09.327991', 244813135872, 12, 1000190720969537511, 30470789, 100, 100000000, 10000, 24, 6, 83);
+INSERT INTO history_ledgers VALUES (58, '6728a7de5d054186933b5776924a12ff34faa6cf77615c8b31eec1a386115c60', '27d8624e4cb6cd71a2d36c78a21c9d1abec47175e55c87459877049702bb5fa8', 1, 1, '2016-06-29 16:35:00', '2016-06-29 16:34:09.333569', '2016-06-29 16:34:09.333569', 249108103168, 12, 1000190720969537511, 30470889, 100, 100000000, 10000, 24, 6, 84);
+INSERT INTO history_ledgers VALUES (59, 'bcf5788cb12788e5a7288407263982a60d24307a4a4bd2fb5e30583c7c37736d', '6728a7de5d054186933b5776924a12ff34faa6cf77615c8b31eec1a386115c60', 0, 0, '2016-06-29 16:35:01', '2016-06-29 16:34:09.337252', '2016-06-29 16:34:09.337252', 253403070464, 12, 1000190720969537511, 30470889, 100, 100000000, 10000, 24, 6, 84);
+
+
+--
+-- Data for Name: history_operation_assets; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+INSERT INTO history_operation_assets VALUES (12884905985, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (17179873281, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (30064775169, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (34359742465, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (34359746561, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (34359750657, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (34359754753, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (38654709761, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (42949677057, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (42949677058, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (47244644353, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (51539611649, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (55834578945, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (55834583041, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (60129546241, 'credit_alphanum4', 'USD', 'GCHC4D2CS45CJRNN4QAHT2LFZAJIU5PA7H53K3VOP6WEJ6XWHNSNZKQG');
+INSERT INTO history_operation_assets VALUES (60129550337, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (64424513537, 'credit_alphanum4', 'USD', 'GCHC4D2CS45CJRNN4QAHT2LFZAJIU5PA7H53K3VOP6WEJ6XWHNSNZKQG');
+INSERT INTO history_operation_assets VALUES (68719480833, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (68719484929, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (68719489025, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (73014448129, 'credit_alphanum4', 'EUR', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
+INSERT INTO history_operation_assets VALUES (73014452225, 'credit_alphanum4', 'USD', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
+INSERT INTO history_operation_assets VALUES (141733924865, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (77309415425, 'credit_alphanum4', 'USD', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
+INSERT INTO history_operation_assets VALUES (77309419521, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (77309419521, 'credit_alphanum4', 'USD', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
+INSERT INTO history_operation_assets VALUES (77309423617, 'credit_alphanum4', 'EUR', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
+INSERT INTO history_operation_assets VALUES (77309423617, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (81604382721, 'credit_alphanum4', 'USD', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
+INSERT INTO history_operation_assets VALUES (81604382721, 'credit_alphanum4', 'EUR', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
+INSERT INTO history_operation_assets VALUES (81604382721, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (85899350017, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (85899350017, 'credit_alphanum4', 'EUR', 'GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU');
+INSERT INTO history_operation_assets VALUES (90194317313, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (90194321409, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (94489284609, 'credit_alphanum4', 'USD', 'GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD');
+INSERT INTO history_operation_assets VALUES (98784251905, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (98784251905, 'credit_alphanum4', 'USD', 'GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD');
+INSERT INTO history_operation_assets VALUES (103079219201, 'credit_alphanum4', 'USD', 'GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD');
+INSERT INTO history_operation_assets VALUES (103079219201, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (107374186497, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (111669153793, 'credit_alphanum4', 'USD', 'GB6GN3LJUW6JYR7EDOJ47VBH7D45M4JWHXGK6LHJRAEI5JBSN2DBQY7Q');
+INSERT INTO history_operation_assets VALUES (111669153793, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (111669157889, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (111669157889, 'credit_alphanum4', 'USD', 'GB6GN3LJUW6JYR7EDOJ47VBH7D45M4JWHXGK6LHJRAEI5JBSN2DBQY7Q');
+INSERT INTO history_operation_assets VALUES (115964121089, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (146028892161, 'credit_alphanum4', 'USD', 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H');
+INSERT INTO history_operation_assets VALUES (150323859457, 'credit_alphanum4', 'USD', 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H');
+INSERT INTO history_operation_assets VALUES (154618826753, 'credit_alphanum4', 'USD', 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H');
+INSERT INTO history_operation_assets VALUES (158913794049, 'credit_alphanum4', 'USD', 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H');
+INSERT INTO history_operation_assets VALUES (163208761345, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (163208765441, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (171798695937, 'credit_alphanum4', 'USD', 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF');
+INSERT INTO history_operation_assets VALUES (171798700033, 'credit_alphanum4', 'EUR', 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF');
+INSERT INTO history_operation_assets VALUES (176093663233, 'credit_alphanum4', 'USD', 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF');
+INSERT INTO history_operation_assets VALUES (176093667329, 'credit_alphanum4', 'EUR', 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF');
+INSERT INTO history_operation_assets VALUES (180388630529, 'credit_alphanum4', 'EUR', 'GD4SMOE3VPSF7ZR3CTEQ3P5UNTBMEJDA2GLXTHR7MMARANKKJDZ7RPGF');
+INSERT INTO history_operation_assets VALUES (184683597825, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (193273532417, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (206158434305, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (227633270785, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (231928238081, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (231928238082, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (236223205377, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (240518172673, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (244813139969, 'native', '', '');
+INSERT INTO history_operation_assets VALUES (249108107265, 'credit_alphanum4', 'USD', 'GANFZDRBCNTUXIODCJEYMACPMCSZEVE4WZGZ3CZDZ3P2SXK4KH75IK6Y');
 
 
 --
@@ -1109,6 +1287,34 @@ CREATE UNIQUE INDEX hs_transaction_by_id ON history_transactions USING btree (id
 CREATE INDEX htp_by_htid ON history_transaction_participants USING btree (history_transaction_id);
 
 
+--
+-- Name: index_asset_stats_on_amount; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_amount ON asset_stats USING btree (amount, asset_code, asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_asset; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_asset_stats_on_asset ON asset_stats USING btree (asset_code, asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_issuer; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_issuer ON asset_stats USING btree (asset_issuer);
+
+
+--
+-- Name: index_asset_stats_on_num_accounts; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_asset_stats_on_num_accounts ON asset_stats USING btree (num_accounts, asset_code, asset_issuer);
+
+
 --
 -- Name: index_history_accounts_on_address; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -1130,6 +1336,13 @@ CREATE UNIQUE INDEX index_history_accounts_on_id ON history_accounts USING btree
 CREATE INDEX index_history_effects_on_type ON history_effects USING btree (type);
 
 
+--
+-- Name: index_history_ledger_upgrades_on_ledger_sequence; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_history_ledger_upgrades_on_ledger_sequence ON history_ledger_upgrades USING btree (ledger_sequence, application_order);
+
+
 --
 -- Name: index_history_ledgers_on_closed_at; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -1172,6 +1385,20 @@ CREATE UNIQUE INDEX index_history_ledgers_on_previous_ledger_hash ON history_led
 CREATE UNIQUE INDEX index_history_ledgers_on_sequence ON history_ledgers USING btree (sequence);
 
 
+--
+-- Name: index_history_operation_assets_on_asset; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE UNIQUE INDEX index_history_operation_assets_on_asset ON history_operation_assets USING btree (asset_type, asset_code, asset_issuer, history_operation_id);
+
+
+--
+-- Name: index_history_operation_assets_on_operation; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX index_history_operation_assets_on_operation ON history_operation_assets USING btree (history_operation_id);
+
+
 --
 -- Name: index_history_operations_on_id; Type: INDEX; Schema: public; Owner: -; Tablespace: 
 --
@@ -1207,6 +1434,13 @@ CREATE UNIQUE INDEX index_history_transactions_on_id ON history_transactions USI
 CREATE INDEX trade_effects_by_order_book ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), ((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text))) WHERE (type = 33);
 
 
+--
+-- Name: trade_effects_by_pair_and_operation; Type: INDEX; Schema: public; Owner: -; Tablespace: 
+--
+
+CREATE INDEX trade_effects_by_pair_and_operation ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), ((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text)), history_operation_id) WHERE (type = 33);
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -17,8 +17,9 @@ func (t *T) CoreRepo() *db2.Repo {
 // output
 func (t *T) Finish() {
 	RestoreLogger()
-	// Reset cached ledger state
-	ledger.SetState(ledger.State{})
+	// Reset cached ledger state, and the loader used to refresh it
+	ledger.Reset()
+	ledger.SetLoader(nil)
 
 	if t.LogBuffer.Len() > 0 {
 		t.T.Log("\n" + t.LogBuffer.String())
@@ -49,30 +50,39 @@ func (t *T) ScenarioWithoutHorizon(name string) *T {
 
 // UpdateLedgerState updates the cached ledger state (or panicing on failure).
 func (t *T) UpdateLedgerState() {
-	var next ledger.State
+	next, err := t.LoadLedgerState()
+	if err != nil {
+		panic(err)
+	}
+
+	ledger.SetState(next)
+}
 
-	err := t.CoreRepo().GetRaw(&next, `
+// LoadLedgerState loads the ledger state from the test databases.
+func (t *T) LoadLedgerState() (next ledger.State, err error) {
+	err = t.CoreRepo().GetRaw(&next, `
 		SELECT
 			COALESCE(MIN(ledgerseq), 0) as core_elder,
-			COALESCE(MAX(ledgerseq), 0) as core_latest
+			COALESCE(MAX(ledgerseq), 0) as core_latest,
+			COALESCE((
+				SELECT ledgerhash FROM ledgerheaders ORDER BY ledgerseq DESC LIMIT 1
+			), '') as core_latest_hash
 		FROM ledgerheaders
 	`)
 
 	if err != nil {
-		panic(err)
+		return
 	}
 
 	err = t.HorizonRepo().GetRaw(&next, `
 			SELECT
 				COALESCE(MIN(sequence), 0) as history_elder,
-				COALESCE(MAX(sequence), 0) as history_latest
+				COALESCE(MAX(sequence), 0) as history_latest,
+				COALESCE((
+					SELECT ledger_hash FROM history_ledgers ORDER BY sequence DESC LIMIT 1
+				), '') as history_latest_hash
 			FROM history_ledgers
 		`)
 
-	if err != nil {
-		panic(err)
-	}
-
-	ledger.SetState(next)
 	return
 }
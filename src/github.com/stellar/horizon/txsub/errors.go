@@ -70,6 +70,25 @@ func (fte *FailedTransactionError) OperationResultCodes() (result []string, err
 	return
 }
 
+// LimitExceededError represents an error that occurred because a submitted
+// envelope exceeded one of the System's Limits.
+type LimitExceededError struct {
+	// Limit names the limit that was exceeded: "envelope_size", "operations"
+	// or "signatures".
+	Limit string
+
+	// Max is the value of the limit.
+	Max int
+
+	// Actual is the envelope's size, in bytes, or its number of operations or
+	// signatures.
+	Actual int
+}
+
+func (err *LimitExceededError) Error() string {
+	return fmt.Sprintf("tx exceeds %s limit: %d > %d", err.Limit, err.Actual, err.Max)
+}
+
 // MalformedTransactionError represent an error that occurred because
 // a TransactionEnvelope could not be decoded from the provided data.
 type MalformedTransactionError struct {
@@ -79,3 +98,33 @@ type MalformedTransactionError struct {
 func (err *MalformedTransactionError) Error() string {
 	return "tx malformed"
 }
+
+// OperationNotPermittedError represents an error that occurred because a
+// submitted transaction contains an operation whose type is not among the
+// System's SubmittableOperations.
+type OperationNotPermittedError struct {
+	// Index is the position of the operation within the transaction.
+	Index int
+
+	// Type is the operation's type.
+	Type xdr.OperationType
+}
+
+func (err *OperationNotPermittedError) Error() string {
+	return fmt.Sprintf("tx operation %d not permitted: %s", err.Index, err.Type)
+}
+
+// WrongNetworkError represents an error that occurred because a transaction
+// was signed for a different stellar network than the one it was submitted to.
+type WrongNetworkError struct {
+	// Passphrase is the passphrase of the network submitted to.
+	Passphrase string
+
+	// SignedPassphrase is the passphrase of the network the transaction was
+	// signed for.
+	SignedPassphrase string
+}
+
+func (err *WrongNetworkError) Error() string {
+	return fmt.Sprintf("tx signed for wrong network: %s", err.SignedPassphrase)
+}
@@ -2,18 +2,22 @@ package txsub
 
 import (
 	"github.com/stellar/go/build"
+	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/xdr"
 	"golang.org/x/net/context"
 )
 
 type envelopeInfo struct {
+	Envelope      xdr.TransactionEnvelope
 	Hash          string
 	Sequence      uint64
 	SourceAddress string
 }
 
-func extractEnvelopeInfo(ctx context.Context, env string, passphrase string) (result envelopeInfo, err error) {
+// extractEnvelopeInfo decodes `env`, rejecting it should it exceed the
+// operation or signature counts of `limits` before hashing it.
+func extractEnvelopeInfo(ctx context.Context, env string, passphrase string, limits Limits) (result envelopeInfo, err error) {
 	var tx xdr.TransactionEnvelope
 
 	err = xdr.SafeUnmarshalBase64(env, &tx)
@@ -23,6 +27,13 @@ func extractEnvelopeInfo(ctx context.Context, env string, passphrase string) (re
 		return
 	}
 
+	err = limits.checkCounts(tx)
+	if err != nil {
+		return
+	}
+
+	result.Envelope = tx
+
 	txb := build.TransactionBuilder{TX: &tx.Tx}
 	txb.Mutate(build.Network{passphrase})
 
@@ -38,3 +49,117 @@ func extractEnvelopeInfo(ctx context.Context, env string, passphrase string) (re
 
 	return
 }
+
+// wellKnownNetworks are the passphrases of the networks that checkNetwork can
+// recognize a transaction as being signed for.
+var wellKnownNetworks = []string{
+	build.PublicNetwork.Passphrase,
+	build.TestNetwork.Passphrase,
+}
+
+// checkNetwork returns a *WrongNetworkError if none of the signatures on `env`
+// are valid for the network identified by `passphrase`, but one is valid for
+// another well-known network.  Only signatures made by the source accounts of
+// the transaction and its operations can be checked, so where the network
+// cannot be determined no error is returned.
+func checkNetwork(env xdr.TransactionEnvelope, passphrase string) error {
+	if len(env.Signatures) == 0 {
+		return nil
+	}
+
+	signers, err := sourceKeypairs(env.Tx)
+	if err != nil {
+		return err
+	}
+
+	ok, err := signedFor(env, passphrase, signers)
+	if err != nil || ok {
+		return err
+	}
+
+	for _, other := range wellKnownNetworks {
+		if other == passphrase {
+			continue
+		}
+
+		ok, err = signedFor(env, other, signers)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return &WrongNetworkError{
+				Passphrase:       passphrase,
+				SignedPassphrase: other,
+			}
+		}
+	}
+
+	return nil
+}
+
+// signedFor returns true if any of the signatures on `env` is a valid
+// signature by one of `signers` for the network identified by `passphrase`.
+func signedFor(
+	env xdr.TransactionEnvelope,
+	passphrase string,
+	signers []keypair.KP,
+) (bool, error) {
+	tx := env.Tx
+	txb := build.TransactionBuilder{TX: &tx}
+	txb.Mutate(build.Network{passphrase})
+
+	hash, err := txb.Hash()
+	if err != nil {
+		return false, err
+	}
+
+	for _, sig := range env.Signatures {
+		for _, kp := range signers {
+			if kp.Hint() != [4]byte(sig.Hint) {
+				continue
+			}
+
+			if kp.Verify(hash[:], sig.Signature) == nil {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// sourceKeypairs returns the keypairs of the source accounts of `tx` and its
+// operations.
+func sourceKeypairs(tx xdr.Transaction) ([]keypair.KP, error) {
+	ids := []xdr.AccountId{tx.SourceAccount}
+	for _, op := range tx.Operations {
+		if op.SourceAccount != nil {
+			ids = append(ids, *op.SourceAccount)
+		}
+	}
+
+	seen := map[string]bool{}
+	var result []keypair.KP
+
+	for _, id := range ids {
+		raw := id.MustEd25519()
+		address, err := strkey.Encode(strkey.VersionByteAccountID, raw[:])
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		kp, err := keypair.Parse(address)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, kp)
+	}
+
+	return result, nil
+}
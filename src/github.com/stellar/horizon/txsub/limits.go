@@ -0,0 +1,68 @@
+package txsub
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/stellar/go/xdr"
+)
+
+// Limits bounds the transaction envelopes accepted for submission, guarding
+// horizon against envelopes crafted to exhaust its memory while being decoded.
+// Submissions exceeding a limit are rejected with a *LimitExceededError.  A
+// zero field imposes no limit.
+type Limits struct {
+	// MaxEnvelopeSize is the largest size, in bytes, of the XDR encoding of a
+	// submitted envelope.  It is checked before the envelope is decoded, and
+	// so also bounds the work done decoding it.
+	MaxEnvelopeSize int
+
+	// MaxOperations is the largest number of operations a submitted
+	// transaction may contain.
+	MaxOperations int
+
+	// MaxSignatures is the largest number of signatures a submitted envelope
+	// may carry.
+	MaxSignatures int
+}
+
+// DefaultLimits are the limits horizon applies unless configured otherwise.
+// The operation and signature limits are those stellar-core imposes, so
+// transactions it would accept are never rejected.
+var DefaultLimits = Limits{
+	MaxEnvelopeSize: 64 * 1024,
+	MaxOperations:   100,
+	MaxSignatures:   20,
+}
+
+// checkSize returns a *LimitExceededError if the base64 encoded envelope `env`
+// decodes to more than MaxEnvelopeSize bytes.
+func (l Limits) checkSize(env string) error {
+	if l.MaxEnvelopeSize == 0 {
+		return nil
+	}
+
+	size := base64.StdEncoding.DecodedLen(len(env))
+	size -= len(env) - len(strings.TrimRight(env, "="))
+
+	return check("envelope_size", l.MaxEnvelopeSize, size)
+}
+
+// checkCounts returns a *LimitExceededError if `env` has more operations or
+// signatures than allowed.
+func (l Limits) checkCounts(env xdr.TransactionEnvelope) error {
+	err := check("operations", l.MaxOperations, len(env.Tx.Operations))
+	if err != nil {
+		return err
+	}
+
+	return check("signatures", l.MaxSignatures, len(env.Signatures))
+}
+
+func check(limit string, max, actual int) error {
+	if max == 0 || actual <= max {
+		return nil
+	}
+
+	return &LimitExceededError{Limit: limit, Max: max, Actual: actual}
+}
@@ -0,0 +1,24 @@
+package txsub
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// OperationTypes is a set of operation types.
+type OperationTypes map[xdr.OperationType]bool
+
+// check returns an *OperationNotPermittedError for the first operation of
+// `env` whose type is not in the set.  A nil set permits every type.
+func (permitted OperationTypes) check(env xdr.TransactionEnvelope) error {
+	if permitted == nil {
+		return nil
+	}
+
+	for i, op := range env.Tx.Operations {
+		if !permitted[op.Body.Type] {
+			return &OperationNotPermittedError{Index: i, Type: op.Body.Type}
+		}
+	}
+
+	return nil
+}
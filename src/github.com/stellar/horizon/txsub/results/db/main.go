@@ -49,6 +49,20 @@ func (rp *DB) ResultByHash(ctx context.Context, hash string) txsub.Result {
 }
 
 func txResultFromHistory(tx history.Transaction) txsub.Result {
+	// failed transactions are stored in history when enabled
+	if !tx.Successful {
+		return txsub.Result{
+			Err: &txsub.FailedTransactionError{
+				ResultXDR: tx.TxResult,
+			},
+			Hash:           tx.TransactionHash,
+			LedgerSequence: tx.LedgerSequence,
+			EnvelopeXDR:    tx.TxEnvelope,
+			ResultXDR:      tx.TxResult,
+			ResultMetaXDR:  tx.TxMeta,
+		}
+	}
+
 	return txsub.Result{
 		Hash:           tx.TransactionHash,
 		LedgerSequence: tx.LedgerSequence,
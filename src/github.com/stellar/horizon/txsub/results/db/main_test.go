@@ -6,6 +6,7 @@ import (
 	"github.com/stellar/horizon/db2/core"
 	"github.com/stellar/horizon/db2/history"
 	"github.com/stellar/horizon/test"
+	"github.com/stellar/horizon/txsub"
 )
 
 func TestResultProvider(t *testing.T) {
@@ -25,3 +26,27 @@ func TestResultProvider(t *testing.T) {
 	tt.Require.NoError(ret.Err)
 	tt.Assert.Equal(hash, ret.Hash)
 }
+
+func TestResultProvider_FailedInHistory(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	rp := &DB{
+		Core:    &core.Q{Repo: tt.CoreRepo()},
+		History: &history.Q{Repo: tt.HorizonRepo()},
+	}
+
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	_, err := tt.HorizonRepo().ExecRaw(`
+		UPDATE history_transactions SET successful = false
+		WHERE transaction_hash = $1
+	`, hash)
+	tt.Require.NoError(err)
+
+	// a failed transaction stored in history is reported as such
+	ret := rp.ResultByHash(tt.Ctx, hash)
+	if tt.Assert.IsType(&txsub.FailedTransactionError{}, ret.Err) {
+		tt.Assert.Equal(ret.ResultXDR, ret.Err.(*txsub.FailedTransactionError).ResultXDR)
+	}
+	tt.Assert.Equal(hash, ret.Hash)
+}
@@ -96,6 +96,19 @@ func (m *Manager) Update(updates map[string]uint64) {
 	}
 }
 
+// Fail notifies every request buffered within the manager with `err`, such as
+// when the sequence information that would unblock them cannot be loaded, and
+// removes them.
+func (m *Manager) Fail(err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for address, queue := range m.queues {
+		queue.Fail(err)
+		delete(m.queues, address)
+	}
+}
+
 // size returns the count of submissions buffered within this manager.  This
 // internal version assumes you have locked the manager previously.
 func (m *Manager) size() int {
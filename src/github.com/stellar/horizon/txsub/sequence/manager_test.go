@@ -41,6 +41,23 @@ func TestManager(t *testing.T) {
 			So(len(results[1]), ShouldEqual, 0)
 		})
 
+		Convey("Fail", func() {
+			results := []<-chan error{
+				mgr.Push("1", 2),
+				mgr.Push("1", 3),
+				mgr.Push("2", 2),
+			}
+
+			mgr.Fail(ErrBadSequence)
+
+			So(mgr.Size(), ShouldEqual, 0)
+			So(len(mgr.queues), ShouldEqual, 0)
+
+			for _, result := range results {
+				So(<-result, ShouldEqual, ErrBadSequence)
+			}
+		})
+
 		Convey("Push returns ErrNoMoreRoom when fill", func() {
 			for i := 0; i < mgr.MaxSize; i++ {
 				mgr.Push("1", 2)
@@ -104,11 +104,17 @@ func (q *Queue) Update(sequence uint64) {
 	// if the queue wasn't changed, see if it is too old, clear
 	// it and make room for other's
 	if time.Since(q.lastActiveAt) > q.timeout {
-		for q.Size() > 0 {
-			ch, _ := q.pop()
-			ch <- ErrBadSequence
-			close(ch)
-		}
+		q.Fail(ErrBadSequence)
+	}
+}
+
+// Fail removes every queued submission from the queue, notifying each of them
+// with `err`.
+func (q *Queue) Fail(err error) {
+	for q.Size() > 0 {
+		ch, _ := q.pop()
+		ch <- err
+		close(ch)
 	}
 }
 
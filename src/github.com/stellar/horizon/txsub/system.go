@@ -16,6 +16,10 @@ import (
 type System struct {
 	initializer sync.Once
 
+	// networkLock guards NetworkPassphrase and ValidateNetwork, which may be
+	// changed by SetNetwork while submissions are in flight.
+	networkLock sync.RWMutex
+
 	Pending           OpenSubmissionList
 	Results           ResultProvider
 	Sequences         SequenceProvider
@@ -24,6 +28,21 @@ type System struct {
 	NetworkPassphrase string
 	SubmissionTimeout time.Duration
 
+	// ValidateNetwork causes submissions whose signatures show they were made
+	// for a different network than NetworkPassphrase to be rejected with a
+	// *WrongNetworkError before being submitted.
+	ValidateNetwork bool
+
+	// Limits bounds the envelopes accepted for submission.  The zero value
+	// imposes no limits; see DefaultLimits.
+	Limits Limits
+
+	// SubmittableOperations, when not nil, is the set of operation types a
+	// submitted transaction may contain.  Transactions containing any other
+	// type are rejected with an *OperationNotPermittedError before being
+	// submitted.
+	SubmittableOperations OperationTypes
+
 	Metrics struct {
 		// SubmissionTimer exposes timing metrics about the rate and latency of
 		// submissions to stellar-core
@@ -47,6 +66,24 @@ type System struct {
 	}
 }
 
+// SetNetwork sets the passphrase of the network submissions are made to, and
+// whether submissions are checked against it (see ValidateNetwork).  It is
+// safe to call while submissions are in flight.
+func (sys *System) SetNetwork(passphrase string, validate bool) {
+	sys.networkLock.Lock()
+	defer sys.networkLock.Unlock()
+	sys.NetworkPassphrase = passphrase
+	sys.ValidateNetwork = validate
+}
+
+// Network returns the passphrase of the network submissions are made to, and
+// whether submissions are checked against it.
+func (sys *System) Network() (passphrase string, validate bool) {
+	sys.networkLock.RLock()
+	defer sys.networkLock.RUnlock()
+	return sys.NetworkPassphrase, sys.ValidateNetwork
+}
+
 // Submit submits the provided base64 encoded transaction envelope to the
 // network using this submission system.
 func (sys *System) Submit(ctx context.Context, env string) (result <-chan Result) {
@@ -54,13 +91,36 @@ func (sys *System) Submit(ctx context.Context, env string) (result <-chan Result
 	response := make(chan Result, 1)
 	result = response
 
+	// an oversized envelope is rejected before it is decoded
+	err := sys.Limits.checkSize(env)
+	if err != nil {
+		sys.finish(response, Result{Err: err})
+		return
+	}
+
+	passphrase, validateNetwork := sys.Network()
+
 	// calculate hash of transaction
-	info, err := extractEnvelopeInfo(ctx, env, sys.NetworkPassphrase)
+	info, err := extractEnvelopeInfo(ctx, env, passphrase, sys.Limits)
+	if err != nil {
+		sys.finish(response, Result{Err: err, EnvelopeXDR: env})
+		return
+	}
+
+	err = sys.SubmittableOperations.check(info.Envelope)
 	if err != nil {
 		sys.finish(response, Result{Err: err, EnvelopeXDR: env})
 		return
 	}
 
+	if validateNetwork {
+		err = checkNetwork(info.Envelope, passphrase)
+		if err != nil {
+			sys.finish(response, Result{Err: err, EnvelopeXDR: env})
+			return
+		}
+	}
+
 	// check the configured result provider for an existing result
 	r := sys.Results.ResultByHash(ctx, info.Hash)
 
@@ -163,7 +223,7 @@ func (sys *System) submitOnce(ctx context.Context, env string) SubmissionResult
 // Tick triggers the system to update itself with any new data available.
 func (sys *System) Tick(ctx context.Context) {
 	sys.Init()
-	logger := log.Ctx(ctx)
+	logger := log.Ctx(ctx).In(log.TxSubSubsystem)
 
 	logger.
 		WithField("queued", sys.SubmissionQueue.String()).
@@ -174,6 +234,8 @@ func (sys *System) Tick(ctx context.Context) {
 		curSeq, err := sys.Sequences.Get(addys)
 		if err != nil {
 			logger.WithStack(err).Error(err)
+			// fail the queued submissions rather than leaving them to time out
+			sys.SubmissionQueue.Fail(err)
 		} else {
 			sys.SubmissionQueue.Update(curSeq)
 		}
@@ -1,12 +1,15 @@
 package txsub
 
 import (
+	"encoding/base64"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stellar/go/build"
+	"github.com/stellar/go/xdr"
 	"github.com/stellar/horizon/test"
 	"github.com/stellar/horizon/txsub/sequence"
 )
@@ -92,6 +95,127 @@ func TestTxsub(t *testing.T) {
 				So(system.Metrics.FailedSubmissionsMeter.Count(), ShouldEqual, 0)
 				So(system.Metrics.SubmissionTimer.Count(), ShouldEqual, 1)
 			})
+
+			Convey("when validating the network", func() {
+				system.ValidateNetwork = true
+
+				Convey("submits transactions signed for the network", func() {
+					_ = system.Submit(ctx, successTx.EnvelopeXDR)
+					So(submitter.WasSubmittedTo, ShouldBeTrue)
+				})
+
+				Convey("rejects transactions signed for another well-known network", func() {
+					system.NetworkPassphrase = build.PublicNetwork.Passphrase
+					r := <-system.Submit(ctx, successTx.EnvelopeXDR)
+
+					So(r.Err, ShouldResemble, &WrongNetworkError{
+						Passphrase:       build.PublicNetwork.Passphrase,
+						SignedPassphrase: build.TestNetwork.Passphrase,
+					})
+					So(submitter.WasSubmittedTo, ShouldBeFalse)
+				})
+
+				Convey("submits transactions whose network cannot be determined", func() {
+					system.NetworkPassphrase = "some private network"
+					_ = system.Submit(ctx, successTx.EnvelopeXDR)
+					So(submitter.WasSubmittedTo, ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("Submit with limits", func() {
+			raw, err := base64.StdEncoding.DecodeString(successTx.EnvelopeXDR)
+			So(err, ShouldBeNil)
+
+			Convey("rejects oversized envelopes without decoding them", func() {
+				system.Limits = Limits{MaxEnvelopeSize: len(raw) - 1}
+				r := <-system.Submit(ctx, successTx.EnvelopeXDR)
+				So(r.Err, ShouldResemble, &LimitExceededError{
+					Limit:  "envelope_size",
+					Max:    len(raw) - 1,
+					Actual: len(raw),
+				})
+				So(submitter.WasSubmittedTo, ShouldBeFalse)
+
+				r = <-system.Submit(ctx, strings.Repeat("A", 1024))
+				So(r.Err, ShouldHaveSameTypeAs, &LimitExceededError{})
+			})
+
+			Convey("rejects transactions with too many operations or signatures", func() {
+				var env xdr.TransactionEnvelope
+				So(xdr.SafeUnmarshalBase64(successTx.EnvelopeXDR, &env), ShouldBeNil)
+				env.Tx.Operations = append(env.Tx.Operations, env.Tx.Operations[0])
+				env.Signatures = append(env.Signatures, env.Signatures[0])
+				doubled, err := xdr.MarshalBase64(env)
+				So(err, ShouldBeNil)
+
+				system.Limits = Limits{MaxOperations: 1}
+				r := <-system.Submit(ctx, doubled)
+				So(r.Err, ShouldResemble, &LimitExceededError{
+					Limit:  "operations",
+					Max:    1,
+					Actual: 2,
+				})
+
+				system.Limits = Limits{MaxSignatures: 1}
+				r = <-system.Submit(ctx, doubled)
+				So(r.Err, ShouldResemble, &LimitExceededError{
+					Limit:  "signatures",
+					Max:    1,
+					Actual: 2,
+				})
+				So(submitter.WasSubmittedTo, ShouldBeFalse)
+			})
+
+			Convey("submits envelopes within the limits", func() {
+				system.Limits = DefaultLimits
+				system.Limits.MaxEnvelopeSize = len(raw)
+				_ = system.Submit(ctx, successTx.EnvelopeXDR)
+				So(submitter.WasSubmittedTo, ShouldBeTrue)
+			})
+		})
+
+		Convey("Submit with permitted operations", func() {
+			var env xdr.TransactionEnvelope
+			So(xdr.SafeUnmarshalBase64(successTx.EnvelopeXDR, &env), ShouldBeNil)
+			env.Tx.Operations = append(env.Tx.Operations, xdr.Operation{
+				Body: xdr.OperationBody{Type: xdr.OperationTypeInflation},
+			})
+			withInflation, err := xdr.MarshalBase64(env)
+			So(err, ShouldBeNil)
+
+			Convey("rejects transactions containing an operation of another type", func() {
+				system.SubmittableOperations = OperationTypes{
+					xdr.OperationTypePayment: true,
+				}
+				r := <-system.Submit(ctx, successTx.EnvelopeXDR)
+				So(r.Err, ShouldResemble, &OperationNotPermittedError{
+					Index: 0,
+					Type:  xdr.OperationTypeCreateAccount,
+				})
+
+				system.SubmittableOperations[xdr.OperationTypeCreateAccount] = true
+				r = <-system.Submit(ctx, withInflation)
+				So(r.Err, ShouldResemble, &OperationNotPermittedError{
+					Index: 1,
+					Type:  xdr.OperationTypeInflation,
+				})
+				So(submitter.WasSubmittedTo, ShouldBeFalse)
+			})
+
+			Convey("submits transactions containing only permitted operations", func() {
+				system.SubmittableOperations = OperationTypes{
+					xdr.OperationTypeCreateAccount: true,
+				}
+				_ = system.Submit(ctx, successTx.EnvelopeXDR)
+				So(submitter.WasSubmittedTo, ShouldBeTrue)
+			})
+
+			Convey("permits every operation when unset", func() {
+				system.SubmittableOperations = nil
+				_ = system.Submit(ctx, withInflation)
+				So(submitter.WasSubmittedTo, ShouldBeTrue)
+			})
 		})
 
 		Convey("Tick", func() {
@@ -114,6 +238,15 @@ func TestTxsub(t *testing.T) {
 				So(len(system.Pending.Pending(ctx)), ShouldEqual, 0)
 			})
 
+			Convey("fails queued submissions when sequences cannot be loaded", func() {
+				seq := system.SubmissionQueue.Push("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", 2)
+				sequences.Err = errors.New("busted for some reason")
+				system.Tick(ctx)
+
+				So(<-seq, ShouldEqual, sequences.Err)
+				So(system.SubmissionQueue.Size(), ShouldEqual, 0)
+			})
+
 			Convey("removes old submissions that have timed out", func() {
 				l := make(chan Result, 1)
 				system.SubmissionTimeout = 100 * time.Millisecond